@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,9 +15,12 @@ import (
 
 func NewArchiveCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
 	var (
-		olderThan    int
+		olderThan    string
+		auto         bool
+		autoGroups   []string
 		dryRun       bool
 		forceArchive bool
+		selector     noteSelector
 	)
 
 	cmd := &cobra.Command{
@@ -24,10 +28,25 @@ func NewArchiveCmd(svc **service.Service, workspaceOverride *string) *cobra.Comm
 		Short: "Archive notes",
 		Long: `Move notes to the archive directory.
 
+With --auto, only notes in the completed and .closed groups are considered
+(override with --groups), and --older-than defaults to 30d. Notes that were
+already archived are skipped, so it is safe to run repeatedly, e.g. from cron
+with --force.
+
+--query and --tag select notes the way nb search does: --query takes a search
+query (AND, OR and NOT included) and --tag, which can be repeated, keeps the
+notes carrying every tag. Given with either, --older-than narrows the
+selection to notes last modified before that age. The matching notes are
+counted and confirmed before anything is archived.
+
 Examples:
-  nb archive note1.md note2.md     # Archive specific files
-  nb archive --older-than 30       # Archive notes older than 30 days
-  nb archive --dry-run             # Show what would be archived`,
+  nb archive note1.md note2.md            # Archive specific files
+  nb archive --older-than 30              # Archive notes older than 30 days
+  nb archive --auto --older-than 30d      # Archive stale completed/closed notes
+  nb archive --auto --groups done --force # Sweep a custom group without prompting
+  nb archive --auto --dry-run             # Show what would be archived
+  nb archive --query "sprint 12"          # Archive notes mentioning sprint 12
+  nb archive --tag spike --older-than 2w  # Archive stale spike notes`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			s := *svc
 
@@ -38,8 +57,22 @@ Examples:
 			}
 
 			var filesToArchive []string
+			autoAge := 30 * 24 * time.Hour
+
+			if selector.active() && (len(args) > 0 || auto) {
+				return fmt.Errorf("--query and --tag cannot be combined with file arguments or --auto")
+			}
 
-			if len(args) > 0 {
+			if selector.active() {
+				selector.olderThan = olderThan
+				notes, err := selector.resolve(s, ctx)
+				if err != nil {
+					return err
+				}
+				for _, note := range notes {
+					filesToArchive = append(filesToArchive, note.Path)
+				}
+			} else if len(args) > 0 {
 				// Archive specific files - need to resolve to full paths
 
 				for _, arg := range args {
@@ -77,14 +110,32 @@ Examples:
 						return fmt.Errorf("file not found in workspace: %s", arg)
 					}
 				}
-			} else if olderThan > 0 {
+			} else if auto {
+				if olderThan != "" {
+					if autoAge, err = parseArchiveAge(olderThan); err != nil {
+						return err
+					}
+				}
+				candidates, err := s.AutoArchiveCandidates(ctx, autoAge, autoGroups)
+				if err != nil {
+					return err
+				}
+				for _, note := range candidates {
+					filesToArchive = append(filesToArchive, note.Path)
+				}
+			} else if olderThan != "" {
+				age, err := parseArchiveAge(olderThan)
+				if err != nil {
+					return err
+				}
+
 				// Find old notes to archive across all note types
 				noteTypes, err := s.ListNoteTypes(ctx.NotebookContextWorkspace)
 				if err != nil {
 					return fmt.Errorf("could not list note types: %w", err)
 				}
 
-				cutoff := time.Now().AddDate(0, 0, -olderThan)
+				cutoff := time.Now().Add(-age)
 
 				for _, noteType := range noteTypes {
 					notes, err := s.ListNotes(ctx, noteType)
@@ -103,7 +154,7 @@ Examples:
 					}
 				}
 			} else {
-				return fmt.Errorf("specify files to archive or use --older-than")
+				return fmt.Errorf("specify files to archive or use --query, --tag, --older-than or --auto")
 			}
 
 			if len(filesToArchive) == 0 {
@@ -131,7 +182,8 @@ Examples:
 				}
 			}
 
-			// Archive the files
+			// Archive exactly the files listed and confirmed above, even for
+			// --auto, where notes may have gone stale since.
 			if err := s.ArchiveNotes(ctx, filesToArchive); err != nil {
 				return err
 			}
@@ -141,9 +193,41 @@ Examples:
 		},
 	}
 
-	cmd.Flags().IntVar(&olderThan, "older-than", 0, "Archive notes older than N days")
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Archive notes older than this age: days (30 or 30d), weeks (2w), or a duration (36h)")
+	cmd.Flags().BoolVar(&auto, "auto", false, "Archive stale notes in the auto-archive groups only")
+	cmd.Flags().StringSliceVar(&autoGroups, "groups", service.DefaultAutoArchiveGroups, "Groups swept by --auto")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be archived without doing it")
 	cmd.Flags().BoolVar(&forceArchive, "force", false, "Skip confirmation prompt")
+	selector.addFlags(cmd)
 
 	return cmd
 }
+
+// parseArchiveAge parses an --older-than value: a bare number of days ("30"),
+// days or weeks with a suffix ("30d", "2w"), or a Go duration ("36h").
+func parseArchiveAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	invalid := fmt.Errorf("invalid age %q: use days (30 or 30d), weeks (2w), or a duration like 36h", value)
+
+	unit := 24 * time.Hour
+	number := value
+	switch {
+	case strings.HasSuffix(value, "d"):
+		number = strings.TrimSuffix(value, "d")
+	case strings.HasSuffix(value, "w"):
+		number = strings.TrimSuffix(value, "w")
+		unit = 7 * 24 * time.Hour
+	}
+	if n, err := strconv.Atoi(number); err == nil {
+		if n <= 0 {
+			return 0, invalid
+		}
+		return time.Duration(n) * unit, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, invalid
+	}
+	return d, nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestParseArchiveAge(t *testing.T) {
+	day := 24 * time.Hour
+	cases := map[string]time.Duration{
+		"30":  30 * day,
+		"30d": 30 * day,
+		"2w":  14 * day,
+		"36h": 36 * time.Hour,
+	}
+	for in, want := range cases {
+		got, err := parseArchiveAge(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, bad := range []string{"", "0", "-3d", "soon", "3x"} {
+		_, err := parseArchiveAge(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestFilterNotesByTags(t *testing.T) {
+	notes := []*models.Note{
+		{Path: "a.md", Tags: []string{"spike", "Backend"}},
+		{Path: "b.md", Tags: []string{"#spike"}},
+		{Path: "c.md"},
+	}
+	paths := func(ns []*models.Note) []string {
+		var out []string
+		for _, n := range ns {
+			out = append(out, n.Path)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"a.md", "b.md"}, paths(filterNotesByTags(notes, []string{"spike"})))
+	assert.Equal(t, []string{"a.md"}, paths(filterNotesByTags(notes, []string{"spike", "backend"})), "every tag must match, ignoring case")
+	assert.Len(t, filterNotesByTags(notes, nil), 3)
+	assert.Len(t, filterNotesByTags(notes, []string{""}), 3, "an unset --tag filters nothing")
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
 	"github.com/spf13/cobra"
 
 	"github.com/grovetools/nb/pkg/index"
@@ -21,7 +22,64 @@ func buildVaultIndex(s *service.Service, workspaceOverride string) (*index.Index
 	if err != nil {
 		return nil, fmt.Errorf("get workspace context: %w", err)
 	}
-	node := ctx.NotebookContextWorkspace
+	roots, err := vaultIndexRoots(s, ctx.NotebookContextWorkspace)
+	if err != nil {
+		return nil, err
+	}
+
+	ix := index.New()
+	if err := ix.Build(roots); err != nil {
+		return nil, fmt.Errorf("build vault index: %w", err)
+	}
+	return ix, nil
+}
+
+// buildAllWorkspacesIndex builds the vault index over the global notebook
+// and every registered workspace. Worktrees sharing a notebook context are
+// indexed once.
+func buildAllWorkspacesIndex(s *service.Service) (*index.Index, error) {
+	starts := []string{"global"}
+	if provider := s.GetWorkspaceProvider(); provider != nil {
+		for _, ws := range provider.All() {
+			starts = append(starts, ws.Path)
+		}
+	}
+
+	var roots []index.Root
+	seenContexts := make(map[string]bool)
+	seenDirs := make(map[string]bool)
+	for _, start := range starts {
+		ctx, err := s.GetWorkspaceContext(start)
+		if err != nil {
+			continue // Skip workspaces without a notebook context
+		}
+		node := ctx.NotebookContextWorkspace
+		if seenContexts[node.Path] {
+			continue
+		}
+		seenContexts[node.Path] = true
+		wsRoots, err := vaultIndexRoots(s, node)
+		if err != nil {
+			continue
+		}
+		for _, r := range wsRoots {
+			if !seenDirs[r.Dir] {
+				seenDirs[r.Dir] = true
+				roots = append(roots, r)
+			}
+		}
+	}
+
+	ix := index.New()
+	if err := ix.Build(roots); err != nil {
+		return nil, fmt.Errorf("build vault index: %w", err)
+	}
+	return ix, nil
+}
+
+// vaultIndexRoots returns the index roots of a notebook context: all content
+// dirs (notes/plans/chats) plus the concepts dir.
+func vaultIndexRoots(s *service.Service, node *coreworkspace.WorkspaceNode) ([]index.Root, error) {
 	locator := s.GetNotebookLocator()
 
 	var roots []index.Root
@@ -35,12 +93,7 @@ func buildVaultIndex(s *service.Service, workspaceOverride string) (*index.Index
 	if conceptsDir, err := locator.GetNotesDir(node, "concepts"); err == nil {
 		roots = append(roots, index.Root{Dir: conceptsDir, Workspace: node.Name})
 	}
-
-	ix := index.New()
-	if err := ix.Build(roots); err != nil {
-		return nil, fmt.Errorf("build vault index: %w", err)
-	}
-	return ix, nil
+	return roots, nil
 }
 
 // resolveNoteArg turns a CLI note argument (path, stem, id, alias, or title)
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewBackupCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		backupWorkspace   string
+		backupDest        string
+		backupIncremental bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up a workspace's notebook to a .tar.gz archive",
+		Long: `Create a timestamped .tar.gz archive of a workspace's notebook directory.
+
+Each backup is recorded in a .nb-backup-manifest.json file in the destination
+directory. With --incremental, only files modified since the previous backup of
+the same workspace are included.
+
+Examples:
+  nb backup                                # Back up the current workspace
+  nb backup --workspace myproject          # Back up a workspace by name
+  nb backup --dest ~/backups --incremental # Only files changed since the last backup
+  nb backup restore ~/backups/myproject-20250101-120000.tar.gz`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+
+			ctx, err := resolveNamedWorkspaceContext(s, backupWorkspace, *workspaceOverride)
+			if err != nil {
+				return err
+			}
+
+			dest := backupDest
+			if dest == "" {
+				dest = defaultBackupDir()
+			}
+
+			var opts []service.BackupOption
+			if backupIncremental {
+				opts = append(opts, service.Incremental())
+			}
+			if err := s.BackupWorkspace(ctx, dest, opts...); err != nil {
+				return fmt.Errorf("backup workspace: %w", err)
+			}
+
+			manifest, err := service.ReadBackupManifest(dest)
+			if err != nil {
+				return err
+			}
+			if latest := manifest.Latest(ctx.NotebookContextWorkspace.Name); latest != nil {
+				kind := "full"
+				if latest.Incremental {
+					kind = "incremental"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Created %s backup of %s (%d files): %s\n",
+					kind, latest.Workspace, len(latest.Files), filepath.Join(dest, latest.Archive))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&backupWorkspace, "workspace", "", "Name of the workspace to back up (defaults to the current workspace)")
+	cmd.Flags().StringVar(&backupDest, "dest", "", "Directory to write the backup to (defaults to the grove data dir)")
+	cmd.Flags().BoolVar(&backupIncremental, "incremental", false, "Only include files modified since the previous backup")
+
+	cmd.AddCommand(newBackupRestoreCmd(svc, workspaceOverride))
+
+	return cmd
+}
+
+func newBackupRestoreCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		restoreWorkspace string
+		restoreForce     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore <archive>",
+		Short: "Restore a backup archive into a workspace's notebook",
+		Long: `Extract a backup archive created by 'nb backup' into a workspace's notebook
+directory. Existing files are kept unless --force is given.
+
+Examples:
+  nb backup restore ~/backups/myproject-20250101-120000.tar.gz
+  nb backup restore backup.tar.gz --workspace myproject --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+
+			ctx, err := resolveNamedWorkspaceContext(s, restoreWorkspace, *workspaceOverride)
+			if err != nil {
+				return err
+			}
+
+			restored, err := s.RestoreWorkspaceBackup(ctx, args[0], restoreForce)
+			if err != nil {
+				return fmt.Errorf("restore backup: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Restored %d files into %s\n",
+				len(restored), ctx.NotebookContextWorkspace.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&restoreWorkspace, "workspace", "", "Name of the workspace to restore into (defaults to the current workspace)")
+	cmd.Flags().BoolVar(&restoreForce, "force", false, "Overwrite files that already exist")
+
+	return cmd
+}
+
+// defaultBackupDir is where backups go when --dest is not given.
+func defaultBackupDir() string {
+	return filepath.Join(paths.DataDir(), "backups", "nb")
+}
+
+// resolveNamedWorkspaceContext returns the context for the workspace called
+// name, or for workspaceOverride (the usual -W/cwd resolution) when name is
+// empty.
+func resolveNamedWorkspaceContext(s *service.Service, name, workspaceOverride string) (*service.WorkspaceContext, error) {
+	if name == "" {
+		ctx, err := s.GetWorkspaceContext(workspaceOverride)
+		if err != nil {
+			return nil, fmt.Errorf("get workspace context: %w", err)
+		}
+		return ctx, nil
+	}
+	if name == "global" {
+		return s.GetWorkspaceContext("global")
+	}
+	for _, ws := range s.GetWorkspaceProvider().All() {
+		if ws.Name == name {
+			ctx, err := s.GetWorkspaceContext(ws.Path)
+			if err != nil {
+				return nil, fmt.Errorf("get workspace context for %s: %w", name, err)
+			}
+			return ctx, nil
+		}
+	}
+	return nil, fmt.Errorf("workspace not found: %s", name)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/models"
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// catRenderWidth is the word-wrap width for `nb cat --render`.
+const catRenderWidth = 100
+
+func NewCatCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		raw    bool
+		render bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "cat <note>",
+		Short: "Print a note's content",
+		Long: `Print a note to the terminal without opening an editor.
+
+The note may be given as a file path, filename stem, frontmatter id, alias,
+or exact title. Frontmatter is stripped unless --raw is given. With --render
+the body is rendered as Markdown; when stdout is not a terminal (or colors are
+off) the plain text is printed instead.
+
+Examples:
+  nb cat my-note
+  nb cat inbox/20260301-idea.md --raw
+  nb cat "Weekly Sync" --render`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			paths, err := resolveNotePaths(*svc, *workspaceOverride, args)
+			if err != nil {
+				return err
+			}
+			data, err := os.ReadFile(paths[0])
+			if err != nil {
+				return fmt.Errorf("read note: %w", err)
+			}
+			out := cmd.OutOrStdout()
+			return printNote(out, string(data), raw, render && styledOutput(out))
+		},
+	}
+
+	cmd.Flags().BoolVar(&raw, "raw", false, "Keep the frontmatter")
+	cmd.Flags().BoolVar(&render, "render", false, "Render the Markdown for the terminal")
+	return cmd
+}
+
+// printNote writes content to w, without its frontmatter unless raw, and
+// rendered as Markdown when render is set.
+func printNote(w io.Writer, content string, raw, render bool) error {
+	text := (&models.Note{Content: content}).ToMarkdown(raw)
+
+	if render {
+		r, err := glamour.NewTermRenderer(
+			glamour.WithAutoStyle(),
+			glamour.WithWordWrap(catRenderWidth),
+		)
+		if err != nil {
+			return fmt.Errorf("create markdown renderer: %w", err)
+		}
+		rendered, err := r.Render(text)
+		if err != nil {
+			return fmt.Errorf("render markdown: %w", err)
+		}
+		text = rendered
+	}
+
+	if _, err := io.WriteString(w, text); err != nil {
+		return err
+	}
+	if len(text) > 0 && text[len(text)-1] != '\n' {
+		_, err := io.WriteString(w, "\n")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintNoteStripsFrontmatter(t *testing.T) {
+	content := "---\ntitle: Idea\ntags: [a]\n---\n# Idea\n\nBody text"
+
+	var buf bytes.Buffer
+	require.NoError(t, printNote(&buf, content, false, false))
+	assert.Equal(t, "# Idea\n\nBody text\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, printNote(&buf, content, true, false))
+	assert.Equal(t, content+"\n", buf.String())
+}
+
+func TestPrintNoteWithoutFrontmatter(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, printNote(&buf, "just text\n", false, false))
+	assert.Equal(t, "just text\n", buf.String())
+}
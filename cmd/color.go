@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// noColor is set by the root --no-color flag.
+var noColor bool
+
+// AddColorFlag registers the persistent --no-color flag on root.
+func AddColorFlag(root *cobra.Command) {
+	root.PersistentFlags().BoolVar(&noColor, "no-color", false, "Print plain text without colors or icons (also set by NO_COLOR)")
+}
+
+// styledOutput reports whether CLI renderers may write ANSI styling and
+// nerd-font icons to w. It is false when --no-color or NO_COLOR is set, when
+// TERM is "dumb", and when w is not a terminal (pipes, files, CI), so that
+// output stays clean for scripts. The TUI does not consult it.
+func styledOutput(w io.Writer) bool {
+	if noColor || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(interface{ Fd() uintptr })
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestStyledOutputDisabledForNonTerminals(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	assert.False(t, styledOutput(&bytes.Buffer{}), "buffers are never terminals")
+
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, styledOutput(&bytes.Buffer{}))
+}
+
+func TestPrintSearchResultsPlain(t *testing.T) {
+	var out bytes.Buffer
+	printSearchResultsPlain(&out, []*models.Note{
+		{Title: "a.md", Path: "/n/a.md", Workspace: "proj", Branch: "main"},
+		{Title: "b.md", Path: "/n/b.md"},
+	})
+	assert.Equal(t, "Found 2 results:\n\n"+
+		"1. a.md\n   /n/a.md\n   Workspace: proj (branch: main)\n"+
+		"2. b.md\n   /n/b.md\n", out.String())
+	assert.NotContains(t, out.String(), "\x1b[")
+
+	out.Reset()
+	printSearchResultsPlain(&out, nil)
+	assert.Equal(t, "No results found\n", out.String())
+}
+
+func TestHyperlinkOutputNeedsATerminal(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_HYPERLINK", "1")
+	assert.False(t, hyperlinkOutput(&bytes.Buffer{}), "never for pipes, even when forced")
+}
+
+func TestTerminalSupportsHyperlinks(t *testing.T) {
+	for _, key := range []string{"TERM_PROGRAM", "TERM", "WT_SESSION", "KONSOLE_VERSION", "DOMTERM", "VTE_VERSION"} {
+		t.Setenv(key, "")
+	}
+	assert.False(t, terminalSupportsHyperlinks())
+
+	t.Setenv("VTE_VERSION", "4600")
+	assert.False(t, terminalSupportsHyperlinks(), "VTE before 0.50")
+	t.Setenv("VTE_VERSION", "6800")
+	assert.True(t, terminalSupportsHyperlinks())
+
+	t.Setenv("VTE_VERSION", "")
+	t.Setenv("TERM", "xterm-kitty")
+	assert.True(t, terminalSupportsHyperlinks())
+}
+
+func TestNoteLink(t *testing.T) {
+	assert.Equal(t, "title", noteLink(false, "/n/a.md", "title"))
+	assert.Equal(t, "\x1b]8;;file:///n/my%20note.md\x1b\\title\x1b]8;;\x1b\\", noteLink(true, "/n/my note.md", "title"))
+
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	assert.Equal(t, "file://"+filepath.ToSlash(filepath.Join(home, "notes", "a.md")), fileURL("~/notes/a.md"), "~ is expanded")
+}
+
+func TestWriteNotesTableLinksTitles(t *testing.T) {
+	modified := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	notes := []*models.Note{
+		{Title: "2026 plan", Path: "/n/plan.md", Type: "inbox", ModifiedAt: modified, WordCount: 12},
+		{Title: "naïve idea", Path: "/n/idea.md", Type: "inbox", ModifiedAt: modified, WordCount: 3},
+	}
+	var plain, linked bytes.Buffer
+	writeNotesTable(&plain, notes, nil, false, false)
+	writeNotesTable(&linked, notes, nil, false, true)
+
+	plainLines := strings.SplitAfter(plain.String(), "\n")
+	linkedLines := strings.SplitAfter(linked.String(), "\n")
+	require.Len(t, linkedLines, len(plainLines))
+	assert.Equal(t, "ibx      2026-01-02  "+hyperlink("file:///n/plan.md", "2026 plan")+strings.Repeat(" ", 20)+"  12\n", linkedLines[2])
+
+	// Stripped of the links, the table is aligned as the plain one.
+	strip := func(s string) string {
+		for _, note := range notes {
+			s = strings.ReplaceAll(s, hyperlink(fileURL(note.Path), note.Title), note.Title)
+		}
+		return s
+	}
+	assert.Equal(t, plain.String(), strip(linked.String()))
+	assert.Equal(t, "ibx      2026-01-02  naïve idea                     3\n", plainLines[3], "widths count runes, not bytes")
+}
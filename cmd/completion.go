@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/grovetools/core/pkg/workspace"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Generate a shell completion script",
+		Long: `Generate a completion script for nb. Besides commands and flags, it
+completes workspace names for -W, note types for --type, and tags for --tag.
+
+Examples:
+  # bash (current shell / permanently)
+  source <(nb completion bash)
+  nb completion bash > ~/.local/share/bash-completion/completions/nb
+
+  # zsh
+  nb completion zsh > "${fpath[1]}/_nb"
+
+  # fish
+  nb completion fish > ~/.config/fish/completions/nb.fish`,
+		Args:                  cobra.ExactArgs(1),
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		DisableFlagsInUseLine: true,
+		// Generating a script needs no workspace discovery.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, out := cmd.Root(), cmd.OutOrStdout()
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletionV2(out, true)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			case "powershell":
+				return root.GenPowerShellCompletionWithDesc(out)
+			}
+			return fmt.Errorf("unsupported shell %q (valid: bash, zsh, fish, powershell)", args[0])
+		},
+	}
+}
+
+// RegisterWorkspaceCompletion completes the root -W/--workspace flag with the
+// names of discovered workspaces (plus "global"). Names are accepted by
+// GetWorkspaceContext alongside paths.
+func RegisterWorkspaceCompletion(root *cobra.Command, svc **service.Service) {
+	_ = root.RegisterFlagCompletionFunc("workspace", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		s := *svc
+		if s == nil || s.GetWorkspaceProvider() == nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		names := []string{"global"}
+		for _, ws := range s.GetWorkspaceProvider().All() {
+			names = append(names, ws.Name)
+		}
+		sort.Strings(names[1:])
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+// completeNoteTypes is a flag completion func listing the note types (group
+// directories) of the current workspace.
+func completeNoteTypes(svc **service.Service, workspaceOverride *string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		s := *svc
+		ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		types, err := s.ListNoteTypes(ctx.NotebookContextWorkspace)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		var typeNames []string
+		for _, t := range types {
+			typeNames = append(typeNames, string(t))
+		}
+		sort.Strings(typeNames)
+		return typeNames, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeTags is a flag completion func listing the frontmatter tags used by
+// notes in the current workspace.
+func completeTags(svc **service.Service, workspaceOverride *string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		s := *svc
+		ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		notes, err := s.ListAllNotes(ctx, false, false)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		seen := make(map[string]bool)
+		var tags []string
+		for _, note := range notes {
+			for _, tag := range note.Tags {
+				if !seen[tag] {
+					seen[tag] = true
+					tags = append(tags, tag)
+				}
+			}
+		}
+		sort.Strings(tags)
+		return tags, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// discoveryCacheTTL bounds how stale the cached workspace discovery used by
+// shell completion may be.
+const discoveryCacheTTL = 10 * time.Minute
+
+// IsCompletionRequest reports whether cmd is cobra's hidden completion
+// command, which runs on every <TAB>.
+func IsCompletionRequest(cmd *cobra.Command) bool {
+	return cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd
+}
+
+// DiscoverWorkspaces runs discover, caching the result on disk. When
+// useCache is set (shell completion) a cache younger than discoveryCacheTTL
+// is returned instead, so completion doesn't pay for a full filesystem scan
+// on every keypress. Cache errors are ignored; discovery still runs.
+func DiscoverWorkspaces(discover func() (*workspace.DiscoveryResult, error), useCache bool) (*workspace.DiscoveryResult, error) {
+	cachePath := discoveryCachePath()
+	if useCache && cachePath != "" {
+		if result := readDiscoveryCache(cachePath, discoveryCacheTTL); result != nil {
+			return result, nil
+		}
+	}
+
+	result, err := discover()
+	if err != nil {
+		return nil, err
+	}
+	if cachePath != "" {
+		writeDiscoveryCache(cachePath, result)
+	}
+	return result, nil
+}
+
+func discoveryCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "nb", "workspaces.json")
+}
+
+func readDiscoveryCache(path string, ttl time.Duration) *workspace.DiscoveryResult {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > ttl {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var result workspace.DiscoveryResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil
+	}
+	return &result
+}
+
+func writeDiscoveryCache(path string, result *workspace.DiscoveryResult) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return
+	}
+	_ = os.Rename(tmp, path)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grovetools/core/pkg/workspace"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoveryCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nb", "workspaces.json")
+	want := &workspace.DiscoveryResult{
+		Projects: []workspace.Project{{Name: "alpha", Path: "/code/alpha"}},
+	}
+
+	writeDiscoveryCache(path, want)
+	got := readDiscoveryCache(path, time.Minute)
+	require.NotNil(t, got)
+	require.Len(t, got.Projects, 1)
+	assert.Equal(t, "alpha", got.Projects[0].Name)
+
+	stale := time.Now().Add(-2 * time.Minute)
+	require.NoError(t, os.Chtimes(path, stale, stale))
+	assert.Nil(t, readDiscoveryCache(path, time.Minute), "expired cache should be ignored")
+
+	assert.Nil(t, readDiscoveryCache(filepath.Join(t.TempDir(), "missing.json"), time.Minute))
+}
+
+func TestCompletionCmdGeneratesScripts(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		root := &cobra.Command{Use: "nb"}
+		root.AddCommand(NewCompletionCmd())
+
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetArgs([]string{"completion", shell})
+		require.NoError(t, root.Execute(), shell)
+		assert.Contains(t, out.String(), "nb", shell)
+	}
+
+	root := &cobra.Command{Use: "nb"}
+	root.AddCommand(NewCompletionCmd())
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"completion", "tcsh"})
+	assert.Error(t, root.Execute())
+}
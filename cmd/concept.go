@@ -524,6 +524,9 @@ precedence over --json, and returns at most 10 concepts.`,
 			if err != nil {
 				return err
 			}
+			if page.Warnings > 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipped %d unreadable path(s); results may be incomplete\n", page.Warnings)
+			}
 			results := page.Results
 
 			if filesOnly {
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	coreconfig "github.com/grovetools/core/config"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewConfigCmd(svc **service.Service) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "View and change notebook settings",
+		Long: `Show the effective nb settings and change them in the user config file.
+
+Writable settings live in the nb section of the global grove config
+(~/.config/grove/grove.yml, or grove.toml when that is the file in use).
+Comments and other settings in the file are kept. Read-only settings come from the environment or
+the core notebook config and are shown for reference.
+
+Examples:
+  nb config list
+  nb config get timestamp_timezone
+  nb config set follow_symlinks true
+  nb config set related_min_score 0.4
+  nb config validate`,
+	}
+
+	cmd.AddCommand(newConfigListCmd(svc))
+	cmd.AddCommand(newConfigGetCmd(svc))
+	cmd.AddCommand(newConfigSetCmd())
+	cmd.AddCommand(newConfigValidateCmd())
+
+	return cmd
+}
+
+func newConfigListCmd(svc **service.Service) *cobra.Command {
+	var listJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all settings with their effective values",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+
+			type entry struct {
+				service.ConfigSetting
+				Value string `json:"value"`
+			}
+			var entries []entry
+			for _, setting := range service.ConfigSettings() {
+				value, err := s.ConfigValue(setting.Key)
+				if err != nil {
+					return err
+				}
+				entries = append(entries, entry{ConfigSetting: setting, Value: value})
+			}
+
+			out := cmd.OutOrStdout()
+			if listJSON {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(entries)
+			}
+
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "KEY\tVALUE\tDESCRIPTION")
+			for _, e := range entries {
+				desc := e.Description
+				if e.ReadOnly {
+					desc += " (read-only)"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", e.Key, e.Value, desc)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "\nConfig file: %s\n", service.UserConfigPath())
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&listJSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func newConfigGetCmd(svc **service.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the effective value of a setting",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := (*svc).ConfigValue(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), value)
+			return nil
+		},
+	}
+}
+
+func newConfigSetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Write a setting to the user config file",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := service.UserConfigPath()
+			if err := service.SetUserConfigValue(path, args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Set %s = %s in %s\n", args[0], args[1], path)
+			return nil
+		},
+	}
+}
+
+func newConfigValidateCmd() *cobra.Command {
+	var validateJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check the grove config files for errors",
+		Long: `Check the grove config files nb loads (the global config, the project config
+and their overrides) and report problems:
+
+  - files that do not parse
+  - unknown fields in the nb, notebooks, groves and other core sections
+  - notebook root_dir paths that do not exist and cannot be created
+  - grove and explicit project paths that do not resolve to a directory
+  - path templates that do not parse, and undefined notebook names
+  - invalid nb settings
+
+The exit code is 0 when the config is valid, 1 when there are only warnings
+and 2 when there are errors. With --debug, every nb command runs these checks
+and logs what they find.
+
+Examples:
+  nb config validate
+  nb config validate --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("get working directory: %w", err)
+			}
+			cfg, loadErr := coreconfig.LoadDefault()
+			report := service.ValidateConfig(service.ConfigFiles(cwd), cfg, loadErr)
+
+			out := cmd.OutOrStdout()
+			if validateJSON {
+				if report.Issues == nil {
+					report.Issues = []service.ConfigIssue{}
+				}
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(report); err != nil {
+					return err
+				}
+			} else {
+				printConfigReport(out, report)
+			}
+
+			if code := report.ExitCode(); code != 0 {
+				return &ExitError{
+					Code: code,
+					Err: fmt.Errorf("config has %d error(s) and %d warning(s)",
+						report.Count(service.ConfigError), report.Count(service.ConfigWarning)),
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&validateJSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func printConfigReport(out io.Writer, report *service.ConfigReport) {
+	if len(report.Files) == 0 {
+		fmt.Fprintln(out, "No config files found")
+	} else {
+		fmt.Fprintln(out, "Config files:")
+		for _, file := range report.Files {
+			fmt.Fprintf(out, "  %s\n", file)
+		}
+	}
+	if len(report.Issues) == 0 {
+		fmt.Fprintln(out, "\nConfig is valid")
+		return
+	}
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "%s\t%s\n", issue.Severity, issue)
+	}
+	_ = w.Flush()
+}
+
+// LogConfigIssues runs the `nb config validate` checks and logs each issue at
+// debug level. main calls it on every invocation when debug logging is on.
+func LogConfigIssues(logger *logrus.Entry, cfg *coreconfig.Config, loadErr error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	report := service.ValidateConfig(service.ConfigFiles(cwd), cfg, loadErr)
+	for _, issue := range report.Issues {
+		logger.WithField("severity", string(issue.Severity)).Debugf("config: %s", issue)
+	}
+}
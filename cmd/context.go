@@ -3,7 +3,9 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
 
 	"github.com/grovetools/nb/pkg/service"
@@ -11,8 +13,9 @@ import (
 
 func NewContextCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
 	var (
-		contextJSON bool
-		contextPath string
+		contextJSON    bool
+		contextPath    string
+		contextSummary bool
 	)
 
 	cmd := &cobra.Command{
@@ -20,7 +23,16 @@ func NewContextCmd(svc **service.Service, workspaceOverride *string) *cobra.Comm
 		Short: "Show current workspace context",
 		Long: `Display information about the current workspace context.
 
-This is useful for integration with other tools like Neovim.`,
+This is useful for integration with other tools like Neovim.
+
+With --summary, print a one-line overview of the notebook scope instead: note
+count per group, open tasks and last activity. Archived notes are not counted.
+
+Examples:
+  nb context
+  nb context --path learn
+  nb context --summary
+  nb context --summary --json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			s := *svc
 
@@ -39,6 +51,20 @@ This is useful for integration with other tools like Neovim.`,
 				return nil
 			}
 
+			if contextSummary {
+				summary, err := s.WorkspaceSummary(ctx.NotebookContextWorkspace, false)
+				if err != nil {
+					return err
+				}
+				if contextJSON {
+					encoder := json.NewEncoder(cmd.OutOrStdout())
+					encoder.SetIndent("", "  ")
+					return encoder.Encode(summary)
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", summary.Workspace, summary)
+				return nil
+			}
+
 			if contextJSON {
 				// JSON output
 				output := map[string]any{
@@ -53,9 +79,16 @@ This is useful for integration with other tools like Neovim.`,
 				return encoder.Encode(output)
 			}
 
-			// Human-readable output to stdout
+			// Human-readable output to stdout. Headings are bold on a
+			// terminal and plain otherwise.
 			out := cmd.OutOrStdout()
-			fmt.Fprintln(out, "Current Location:")
+			heading := func(s string) string { return s }
+			if styledOutput(out) {
+				bold := lipgloss.NewStyle().Bold(true)
+				heading = func(s string) string { return bold.Render(s) }
+			}
+
+			fmt.Fprintln(out, heading("Current Location:"))
 			fmt.Fprintf(out, "  Name: %s\n", ctx.CurrentWorkspace.Name)
 			fmt.Fprintf(out, "  Path: %s\n", ctx.CurrentWorkspace.Path)
 			fmt.Fprintf(out, "  Kind: %s\n", ctx.CurrentWorkspace.Kind)
@@ -63,15 +96,20 @@ This is useful for integration with other tools like Neovim.`,
 				fmt.Fprintf(out, "  Branch: %s\n", ctx.Branch)
 			}
 
-			fmt.Fprintln(out, "\nNotebook Scope:")
+			fmt.Fprintln(out, "\n"+heading("Notebook Scope:"))
 			fmt.Fprintf(out, "  Name: %s\n", ctx.NotebookContextWorkspace.Name)
 			fmt.Fprintf(out, "  Identifier: %s\n", ctx.NotebookContextWorkspace.Identifier("_"))
 			fmt.Fprintf(out, "  Path: %s\n", ctx.NotebookContextWorkspace.Path)
 			fmt.Fprintf(out, "  Kind: %s\n", ctx.NotebookContextWorkspace.Kind)
 
-			fmt.Fprintln(out, "\nPaths:")
-			for key, path := range ctx.Paths {
-				fmt.Fprintf(out, "  %s: %s\n", key, path)
+			fmt.Fprintln(out, "\n"+heading("Paths:"))
+			keys := make([]string, 0, len(ctx.Paths))
+			for key := range ctx.Paths {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				fmt.Fprintf(out, "  %s: %s\n", key, ctx.Paths[key])
 			}
 
 			return nil
@@ -80,6 +118,7 @@ This is useful for integration with other tools like Neovim.`,
 
 	cmd.Flags().BoolVar(&contextJSON, "json", false, "Output as JSON")
 	cmd.Flags().StringVar(&contextPath, "path", "", "Get specific path (current, llm, learn)")
+	cmd.Flags().BoolVar(&contextSummary, "summary", false, "Print note counts per group, open tasks and last activity")
 
 	return cmd
 }
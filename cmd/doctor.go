@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"text/tabwriter"
@@ -15,17 +16,94 @@ import (
 
 // NewDoctorCmd builds the `nb doctor` parent command. Its subcommands audit —
 // and optionally repair — invariants in the notebook. Today it hosts `notes`,
-// the note↔plan link reconciler.
+// the note↔plan link reconciler. The parent itself runs the read-only
+// --duplicates analysis.
 func NewDoctorCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		duplicates    bool
+		byContent     bool
+		nearRatio     float64
+		allWorkspaces bool
+		jsonOut       bool
+	)
+
 	cmd := &cobra.Command{
 		Use:   "doctor",
 		Short: "Diagnose and repair notebook invariants",
-		Long:  "Diagnostics for the notebook. Subcommands audit invariants and, with --fix, repair them.",
+		Long: `Diagnostics for the notebook. Subcommands audit invariants and, with --fix, repair them.
+
+With --duplicates, report clusters of likely duplicate notes: notes whose
+titles match once case and punctuation are ignored and, with --content, notes
+whose bodies are identical once frontmatter is dropped and whitespace is
+collapsed. --near <ratio> also pairs bodies where the shorter one appears in
+the longer one and is at least ratio of its length. Nothing is changed.
+
+Examples:
+  nb doctor --duplicates
+  nb doctor --duplicates --content -w
+  nb doctor --duplicates --near 0.8 --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !duplicates {
+				return cmd.Help()
+			}
+			if nearRatio < 0 || nearRatio >= 1 {
+				return fmt.Errorf("--near must be between 0 and 1, got %v", nearRatio)
+			}
+			s := *svc
+
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("resolving workspace context: %w", err)
+			}
+			var opts []service.DuplicateOption
+			if byContent {
+				opts = append(opts, service.WithContentDuplicates())
+			}
+			if nearRatio > 0 {
+				opts = append(opts, service.WithNearDuplicates(nearRatio))
+			}
+			clusters, err := s.FindDuplicates(ctx, allWorkspaces, opts...)
+			if err != nil {
+				return err
+			}
+
+			if jsonOut {
+				if clusters == nil {
+					clusters = []service.DuplicateCluster{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(clusters)
+			}
+			printDuplicateClusters(cmd.OutOrStdout(), clusters)
+			return nil
+		},
 	}
+
+	cmd.Flags().BoolVar(&duplicates, "duplicates", false, "Report likely duplicate notes (read-only)")
+	cmd.Flags().BoolVar(&byContent, "content", false, "With --duplicates, also match notes by normalized body")
+	cmd.Flags().Float64Var(&nearRatio, "near", 0, "With --duplicates, also match near-identical bodies at this length ratio (0-1, e.g. 0.8)")
+	cmd.Flags().BoolVarP(&allWorkspaces, "workspaces", "w", false, "With --duplicates, search every workspace")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "With --duplicates, emit machine-readable JSON output")
+
 	cmd.AddCommand(newDoctorNotesCmd(svc, workspaceOverride))
 	return cmd
 }
 
+func printDuplicateClusters(out io.Writer, clusters []service.DuplicateCluster) {
+	if len(clusters) == 0 {
+		fmt.Fprintln(out, "No duplicate notes found.")
+		return
+	}
+	for _, c := range clusters {
+		fmt.Fprintf(out, "%s: %s (%d notes)\n", c.Reason, c.Key, len(c.Notes))
+		for _, n := range c.Notes {
+			fmt.Fprintf(out, "  %s\n", n.Path)
+		}
+	}
+}
+
 func newDoctorNotesCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
 	var jsonOut bool
 	var fix bool
@@ -0,0 +1,12 @@
+package cmd
+
+// ExitError is returned by commands that need an exit status other than 1.
+// main exits with Code after the error is printed.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string { return e.Err.Error() }
+
+func (e *ExitError) Unwrap() error { return e.Err }
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewGroupCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "group",
+		Short: "Manage note groups",
+		Long: `Manage the top-level groups (directories such as inbox, issues, research)
+in a workspace. Groups are also created implicitly when the first note is
+added; these commands create, list and delete them explicitly.
+
+Examples:
+  nb group create research --description "Deep dives"
+  nb group list
+  nb group rename research deep-dives
+  nb group delete scratch -W ~/code/other-project`,
+	}
+
+	cmd.AddCommand(
+		newGroupCreateCmd(svc, workspaceOverride),
+		newGroupListCmd(svc, workspaceOverride),
+		newGroupDeleteCmd(svc, workspaceOverride),
+		newGroupRenameCmd(svc, workspaceOverride),
+	)
+
+	return cmd
+}
+
+func newGroupCreateCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		description string
+		icon        string
+		sortOrder   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create an empty group",
+		Long: `Create an empty group directory in the workspace. Names may not contain
+path separators, start with a dot, or be one of the reserved directories
+(plans, concepts, templates, recipes, archive).
+
+When --description, --icon or --sort is given, the settings are saved to a
+.nb-group.yml file inside the group.
+
+Examples:
+  nb group create research
+  nb group create meetings --description "Weekly syncs" --sort modified`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+
+			var opts []service.GroupOption
+			if description != "" {
+				opts = append(opts, service.WithGroupDescription(description))
+			}
+			if icon != "" {
+				opts = append(opts, service.WithGroupIcon(icon))
+			}
+			if sortOrder != "" {
+				opts = append(opts, service.WithGroupSort(sortOrder))
+			}
+			if err := s.CreateGroup(ctx, args[0], opts...); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Created group %s in %s\n", args[0], ctx.NotebookContextWorkspace.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&description, "description", "d", "", "Description saved in .nb-group.yml")
+	cmd.Flags().StringVar(&icon, "icon", "", "Display icon saved in .nb-group.yml")
+	cmd.Flags().StringVar(&sortOrder, "sort", "", "Default sort order saved in .nb-group.yml")
+
+	return cmd
+}
+
+func newGroupListCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the groups in a workspace",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+			groups, err := s.ListGroups(ctx)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				if groups == nil {
+					groups = []service.GroupInfo{}
+				}
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(groups)
+			}
+			if len(groups) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No groups found")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "GROUP\tNOTES\tDESCRIPTION")
+			for _, g := range groups {
+				desc := ""
+				if g.Config != nil {
+					desc = g.Config.Description
+				}
+				fmt.Fprintf(w, "%s\t%d\t%s\n", g.Name, g.NoteCount, truncateString(desc, 50))
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func newGroupDeleteCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		force bool
+		yes   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a group",
+		Long: `Delete a group directory. Groups that still contain files are refused
+unless --force is given, in which case the group and everything in it
+(including archived notes) is removed after confirmation.
+
+Examples:
+  nb group delete scratch
+  nb group delete old-research --force --yes`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeGroupNames(svc, workspaceOverride),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+
+			if force && !yes {
+				fmt.Printf("Delete group %s and all of its contents? [y/N] ", args[0])
+				var response string
+				_, _ = fmt.Scanln(&response)
+
+				if strings.ToLower(response) != "y" {
+					fmt.Println("Cancelled")
+					return nil
+				}
+			}
+			if err := s.DeleteGroup(ctx, args[0], force); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Deleted group %s\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Delete the group even if it contains files")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+func newGroupRenameCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "rename <old> <new>",
+		Short: "Rename a group",
+		Long: `Rename a group directory, along with its archive in the notebook's
+.archive directory if it has one. Notes in the group whose type is the old
+group (or one of its subgroups) get the new type, and a tag naming the old
+group is renamed too. The rewritten files are listed.
+
+Examples:
+  nb group rename research deep-dives
+  nb group rename meetings syncs --dry-run
+  nb group rename scratch drafts -W ~/code/other-project`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeGroupNames(svc, workspaceOverride),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+
+			out := cmd.OutOrStdout()
+			if dryRun {
+				paths, err := s.PreviewRenameGroup(ctx, args[0], args[1])
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(out, "Would rename group %s to %s and update %d file(s)\n", args[0], args[1], len(paths))
+				for _, p := range paths {
+					fmt.Fprintf(out, "  %s\n", p)
+				}
+				return nil
+			}
+
+			paths, err := s.RenameGroup(ctx, args[0], args[1])
+			for _, p := range paths {
+				fmt.Fprintf(out, "Updated: %s\n", p)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Renamed group %s to %s (%d file(s) updated)\n", args[0], args[1], len(paths))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without renaming anything")
+
+	return cmd
+}
+
+// completeGroupNames completes the first argument with the workspace's
+// group names.
+func completeGroupNames(svc **service.Service, workspaceOverride *string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		s := *svc
+		ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		groups, err := s.ListGroups(ctx)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		names := make([]string, 0, len(groups))
+		for _, g := range groups {
+			names = append(names, g.Name)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/grovetools/core/util/pathutil"
+)
+
+// hyperlinkOutput reports whether CLI renderers may wrap note paths in OSC 8
+// terminal hyperlinks when writing to w. It needs styledOutput and a terminal
+// known to support them; FORCE_HYPERLINK=1 or =0 overrides the detection.
+// Terminals that don't understand OSC 8 usually ignore it, but a few print it,
+// so unknown terminals get plain text.
+func hyperlinkOutput(w io.Writer) bool {
+	if !styledOutput(w) {
+		return false
+	}
+	if force, ok := os.LookupEnv("FORCE_HYPERLINK"); ok && force != "" {
+		return force != "0"
+	}
+	return terminalSupportsHyperlinks()
+}
+
+// terminalSupportsHyperlinks recognizes the terminals that render OSC 8 from
+// the variables they set.
+func terminalSupportsHyperlinks() bool {
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper":
+		return true
+	}
+	switch os.Getenv("TERM") {
+	case "xterm-kitty", "alacritty", "foot", "xterm-ghostty", "wezterm":
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KONSOLE_VERSION") != "" || os.Getenv("DOMTERM") != "" {
+		return true
+	}
+	// GNOME Terminal, Tilix and other VTE terminals since 0.50.
+	if v, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && v >= 5000 {
+		return true
+	}
+	return false
+}
+
+// hyperlink wraps text in an OSC 8 hyperlink to target.
+func hyperlink(target, text string) string {
+	return "\x1b]8;;" + target + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// fileURL returns the file:// URL of path, made absolute with ~ expanded.
+func fileURL(path string) string {
+	if expanded, err := pathutil.Expand(path); err == nil {
+		path = expanded
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	if !strings.HasPrefix(u.Path, "/") {
+		u.Path = "/" + u.Path // Windows drive paths: file:///C:/...
+	}
+	return u.String()
+}
+
+// noteLink renders text as a hyperlink to the note file at path when link is
+// set, and as plain text otherwise.
+func noteLink(link bool, path, text string) string {
+	if !link || path == "" {
+		return text
+	}
+	return hyperlink(fileURL(path), text)
+}
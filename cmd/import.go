@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/models"
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// importProgressThreshold is the item count above which `nb import` reports
+// progress on stderr.
+const importProgressThreshold = 20
+
+func NewImportCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		mapping  string
+		noteType string
+		quiet    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <file.json>",
+		Short: "Create notes from a JSON export",
+		Long: `Create one note per object in a JSON array, such as an export from a
+project management tool. --mapping selects which fields become the note's
+title, body, tags, status and created date; nested fields use dot notation
+("fields.summary"), array elements a numeric index ("labels.0"). Unset keys
+default to the top-level fields title, body, tags, status and date.
+
+Tags may be an array of strings, an array of objects with a "name" key, or a
+comma-separated string. The paths of the created notes are printed.
+
+Examples:
+  nb import tasks.json
+  nb import jira.json -t issues --mapping '{"title":"fields.summary","body":"fields.description","status":"fields.status.name","tags":"fields.labels","date":"fields.created"}'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+
+			m, err := service.ParseJSONImportMapping(mapping)
+			if err != nil {
+				return err
+			}
+
+			opts := []service.ImportOption{service.WithImportNoteType(models.NoteType(noteType))}
+			if !quiet {
+				errOut := cmd.ErrOrStderr()
+				opts = append(opts, service.WithImportProgress(func(done, total int) {
+					if total < importProgressThreshold {
+						return
+					}
+					fmt.Fprintf(errOut, "\rImporting %d/%d", done, total)
+					if done == total {
+						fmt.Fprintln(errOut)
+					}
+				}))
+			}
+
+			paths, err := s.ImportFromJSON(ctx, args[0], m, opts...)
+			for _, p := range paths {
+				fmt.Fprintln(cmd.OutOrStdout(), p)
+			}
+			if err != nil {
+				return fmt.Errorf("imported %d notes before failing: %w", len(paths), err)
+			}
+			if !quiet {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Imported %d notes into %s/%s\n", len(paths), ctx.NotebookContextWorkspace.Name, noteType)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&mapping, "mapping", "", `Field mapping as a JSON object with keys title, body, tags, status, date`)
+	cmd.Flags().StringVarP(&noteType, "type", "t", service.InboxGroup, "Note type (group) to create the notes in")
+	_ = cmd.RegisterFlagCompletionFunc("type", completeNoteTypes(svc, workspaceOverride))
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print the created note paths")
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewInboxCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		inboxJSON          bool
+		inboxCount         bool
+		inboxAllWorkspaces bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "inbox",
+		Short: "List unfiled inbox notes",
+		Long: `List the notes sitting in the inbox group, newest first. Archived inbox
+notes are not included.
+
+Examples:
+  nb inbox                 # Inbox notes in the current workspace
+  nb inbox --count         # Just the number, e.g. for a shell prompt
+  nb inbox -w --json       # Inbox notes across all workspaces as JSON`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+
+			if inboxCount {
+				n, err := s.CountInbox(ctx, inboxAllWorkspaces)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), n)
+				return nil
+			}
+
+			notes, err := s.GetInboxNotes(ctx, inboxAllWorkspaces)
+			if err != nil {
+				return err
+			}
+			if inboxJSON {
+				return FormatNoteOutput(notes, OutputJSON, cmd.OutOrStdout())
+			}
+			if len(notes) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "Inbox is empty")
+				return nil
+			}
+			printNotesTable(notes, s.NoteTypes)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&inboxJSON, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVarP(&inboxCount, "count", "c", false, "Print only the number of inbox notes")
+	cmd.Flags().BoolVarP(&inboxAllWorkspaces, "workspaces", "w", false, "Include inbox notes from all workspaces")
+
+	return cmd
+}
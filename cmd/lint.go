@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// NewLintCmd builds `nb lint`, which checks notes for problems that tools
+// relying on frontmatter would trip over: duplicate ids and, with --no-tags,
+// notes without tags.
+func NewLintCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		fixIDs  bool
+		jsonOut bool
+		noTags  bool
+		addTags []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check notes for duplicate ids and missing tags",
+		Long: `Check the notes in the current workspace for frontmatter ids used by more
+than one note. Ids are derived from titles, so notes with similar titles can
+end up sharing one, and links by id then resolve to the wrong note.
+
+With --fix-ids, the oldest note in each conflict keeps its id and the others
+get the old id with a timestamp suffix. Only the id line is rewritten. A plan
+job whose note_ref names the old id is repointed when it is the renamed note's
+own job (from its plan_ref and plan_job).
+
+--no-tags also lists the notes without any tag, such as quick captures that
+were never filed. --add-tags tags them all with the given comma-separated
+tags (implying --no-tags); notes without frontmatter are reported and left
+alone.
+
+Exits non-zero when duplicate ids or, with --no-tags, untagged notes remain.
+
+Examples:
+  nb lint
+  nb lint --fix-ids
+  nb lint --no-tags
+  nb lint --add-tags triage,inbox
+  nb lint --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("resolving workspace context: %w", err)
+			}
+			conflicts, err := s.VerifyFrontmatterIDs(ctx)
+			if err != nil {
+				return err
+			}
+
+			var fixes []service.IDFix
+			if fixIDs && len(conflicts) > 0 {
+				fixes, err = s.FixIDConflicts(ctx, conflicts)
+				if err != nil {
+					return err
+				}
+			}
+
+			var untagged, tagged, skipped []string
+			if noTags || len(addTags) > 0 {
+				notes, err := s.FindNotesWithoutTags(ctx)
+				if err != nil {
+					return err
+				}
+				for _, note := range notes {
+					untagged = append(untagged, note.Path)
+				}
+				skipped = untagged
+				if len(addTags) > 0 && len(untagged) > 0 {
+					tagged, skipped, err = tagUntaggedNotes(s, untagged, addTags)
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonOut {
+				if conflicts == nil {
+					conflicts = []service.IDConflict{}
+				}
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(struct {
+					Conflicts []service.IDConflict `json:"conflicts"`
+					Fixes     []service.IDFix      `json:"fixes,omitempty"`
+					Untagged  []string             `json:"untagged,omitempty"`
+					Tagged    []string             `json:"tagged,omitempty"`
+				}{conflicts, fixes, untagged, tagged}); err != nil {
+					return err
+				}
+			} else {
+				printIDConflicts(out, conflicts, fixes)
+				if noTags || len(addTags) > 0 {
+					printUntaggedNotes(out, untagged, tagged, skipped)
+				}
+			}
+
+			if len(conflicts) > 0 && !fixIDs {
+				return fmt.Errorf("%d duplicate id(s) found; re-run with --fix-ids to repair", len(conflicts))
+			}
+			if len(skipped) > 0 {
+				if len(addTags) > 0 {
+					return fmt.Errorf("%d note(s) without frontmatter could not be tagged", len(skipped))
+				}
+				return fmt.Errorf("%d note(s) without tags found; re-run with --add-tags to tag them", len(skipped))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&fixIDs, "fix-ids", false, "Give notes with a duplicate id a new, unique id")
+	cmd.Flags().BoolVar(&noTags, "no-tags", false, "Also list notes without any tag")
+	cmd.Flags().StringSliceVar(&addTags, "add-tags", nil, "Add these comma-separated tags to every note without tags (implies --no-tags)")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Emit machine-readable JSON output")
+	return cmd
+}
+
+func printIDConflicts(out io.Writer, conflicts []service.IDConflict, fixes []service.IDFix) {
+	if len(conflicts) == 0 {
+		fmt.Fprintln(out, "No duplicate ids found.")
+		return
+	}
+	for _, c := range conflicts {
+		fmt.Fprintf(out, "id %s is used by %d notes\n", c.ID, len(c.Paths))
+		for _, p := range c.Paths {
+			fmt.Fprintf(out, "  %s\n", p)
+		}
+	}
+	if len(fixes) == 0 {
+		return
+	}
+	fmt.Fprintln(out)
+	for _, f := range fixes {
+		fmt.Fprintf(out, "Renamed id %s -> %s in %s\n", f.OldID, f.NewID, f.Path)
+		for _, ref := range f.UpdatedRefs {
+			fmt.Fprintf(out, "  updated note_ref in %s\n", ref)
+		}
+		for _, ref := range f.UncertainRefs {
+			fmt.Fprintf(out, "  check note_ref in %s: it still names %s, which may have meant this note\n", ref, f.OldID)
+		}
+	}
+}
+
+// tagUntaggedNotes adds tags to each note in paths. Notes without frontmatter
+// can't hold tags and are returned as skipped.
+func tagUntaggedNotes(s *service.Service, paths, tags []string) (tagged, skipped []string, err error) {
+	var taggable []string
+	for _, path := range paths {
+		raw, err := s.ReadRawFrontmatter(path)
+		if err != nil || raw == "" {
+			skipped = append(skipped, path)
+			continue
+		}
+		taggable = append(taggable, path)
+	}
+	seen := make(map[string]bool, len(taggable))
+	for _, tag := range tags {
+		changed, err := s.AddTag(taggable, tag)
+		for _, path := range changed {
+			if !seen[path] {
+				seen[path] = true
+				tagged = append(tagged, path)
+			}
+		}
+		if err != nil {
+			return tagged, skipped, err
+		}
+	}
+	sort.Strings(tagged)
+	return tagged, skipped, nil
+}
+
+func printUntaggedNotes(out io.Writer, untagged, tagged, skipped []string) {
+	fmt.Fprintln(out)
+	if len(untagged) == 0 {
+		fmt.Fprintln(out, "No notes without tags found.")
+		return
+	}
+	if len(tagged) == 0 {
+		fmt.Fprintf(out, "%d note(s) without tags:\n", len(untagged))
+		for _, p := range untagged {
+			fmt.Fprintf(out, "  %s\n", p)
+		}
+		return
+	}
+	fmt.Fprintf(out, "Tagged %d note(s) that had no tags:\n", len(tagged))
+	for _, p := range tagged {
+		fmt.Fprintf(out, "  %s\n", p)
+	}
+	if len(skipped) > 0 {
+		fmt.Fprintf(out, "Skipped %d note(s) without frontmatter:\n", len(skipped))
+		for _, p := range skipped {
+			fmt.Fprintf(out, "  %s\n", p)
+		}
+	}
+}
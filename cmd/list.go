@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 
@@ -36,8 +38,15 @@ func NewListCmd(svc **service.Service, workspaceOverride *string) *cobra.Command
 		listTag           string
 		listCounts        bool
 		listPriority      string
+		listFlag          string
 		listCriticalOnly  bool
 		listPlanRef       string
+		listOutput        string
+		listSince         string
+		listFrom          string
+		listTo            string
+		listDateField     string
+		listTree          bool
 	)
 
 	cmd := &cobra.Command{
@@ -50,7 +59,15 @@ Examples:
   nb list              # List current notes
   nb list llm          # List LLM notes
   nb list learn        # List learning notes
-  nb list docs         # List documentation notes`,
+  nb list docs         # List documentation notes
+  nb list -o paths | xargs grep "TODO"   # Pipe note paths to other tools
+  nb list -o titles    # One title per line
+  nb list --since 2h   # Notes whose file changed in the last two hours (fast)
+  nb list --since 7d -o paths
+  nb list --from 2026-01-01 --to 2026-01-31             # Created in January
+  nb list --from 2026-03-01 --field modified -o paths
+  nb list --tree       # Every note, grouped as a tree
+  nb list --tree --tag infra --workspaces`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 			s := *svc
@@ -61,6 +78,42 @@ Examples:
 				return fmt.Errorf("get workspace context: %w", err)
 			}
 
+			// --json is kept as shorthand for --output json.
+			if err := validateOutputFormat(listOutput); err != nil {
+				return err
+			}
+			outputFormat := listOutput
+			if listJSON {
+				if outputFormat != "" && outputFormat != OutputJSON {
+					return fmt.Errorf("--json conflicts with --output %s", outputFormat)
+				}
+				outputFormat = OutputJSON
+			}
+			// --tree prints the notes every other flag selects as a group
+			// tree; without a type it takes every type, as --all does.
+			if listTree {
+				if outputFormat != "" {
+					return fmt.Errorf("--tree conflicts with --output %s", outputFormat)
+				}
+				if listCounts {
+					return fmt.Errorf("--tree conflicts with --counts")
+				}
+				if len(args) == 0 && !cmd.Flags().Changed("type") {
+					listAll = true
+				}
+			}
+			renderNotes := func(notes []*models.Note) error {
+				if listTree {
+					printWorkspaceTree(cmd.OutOrStdout(), service.NewWorkspaceTree(notes))
+					return nil
+				}
+				if outputFormat == "" {
+					printNotesTable(notes, s.NoteTypes)
+					return nil
+				}
+				return FormatNoteOutput(notes, outputFormat, os.Stdout)
+			}
+
 			// Resolve the effective priority filter. --critical-only is
 			// shorthand for --priority p0; both may not conflict.
 			priorityFilter := listPriority
@@ -73,6 +126,98 @@ Examples:
 			if !service.IsValidPriority(priorityFilter) {
 				return fmt.Errorf("invalid priority %q (want one of p0,p1,p2,p3 or empty)", priorityFilter)
 			}
+			if listFlag != "" && service.FlagColor(s.Flags(), listFlag) == "" {
+				return fmt.Errorf("unknown flag %q (expected one of %s)", listFlag, strings.Join(service.FlagNames(s.Flags()), ", "))
+			}
+
+			// --since lists every note in the workspace whose file changed
+			// recently, deciding from mtimes so unchanged notes are never
+			// parsed. It is a cheaper alternative to nb recent.
+			if listSince != "" {
+				if listAllBranches || listAllWorkspaces {
+					return fmt.Errorf("--since cannot be combined with --all-branches or --workspaces")
+				}
+				age, err := parseArchiveAge(listSince)
+				if err != nil {
+					return fmt.Errorf("--since: %w", err)
+				}
+				notes, err := s.GetChangedNotes(wsCtx, time.Now().Add(-age))
+				if err != nil {
+					return err
+				}
+				notes = filterNotesByTags(notes, []string{listTag})
+				notes = filterNotesByPriority(notes, priorityFilter)
+				notes = filterNotesByFlag(notes, listFlag)
+				notes = filterNotesByPlanRef(notes, listPlanRef)
+
+				if len(notes) == 0 {
+					if outputFormat == "" {
+						listUlog.Info("No changed notes found").
+							Field("since", listSince).
+							Pretty(fmt.Sprintf("No notes changed in the last %s", listSince)).
+							PrettyOnly().
+							Log(ctx)
+					} else if outputFormat == OutputJSON {
+						listUlog.Info("No changed notes found").
+							Field("since", listSince).
+							Pretty("[]").
+							PrettyOnly().
+							Log(ctx)
+					}
+					return nil
+				}
+				return renderNotes(notes)
+			}
+
+			// --from/--to list the workspace's notes created (or modified)
+			// within a date range, oldest first.
+			if listFrom != "" || listTo != "" {
+				if listSince != "" || listAllBranches || listAllWorkspaces {
+					return fmt.Errorf("--from and --to cannot be combined with --since, --all-branches or --workspaces")
+				}
+				var from, to time.Time
+				if listFrom != "" {
+					if from, err = service.ParseDateBound(listFrom, false); err != nil {
+						return fmt.Errorf("--from: %w", err)
+					}
+				}
+				if listTo != "" {
+					if to, err = service.ParseDateBound(listTo, true); err != nil {
+						return fmt.Errorf("--to: %w", err)
+					}
+				}
+				notes, err := s.SearchByDateRange(wsCtx, from, to, listDateField)
+				if err != nil {
+					return err
+				}
+				notes = filterNotesByTags(notes, []string{listTag})
+				notes = filterNotesByPriority(notes, priorityFilter)
+				notes = filterNotesByFlag(notes, listFlag)
+				notes = filterNotesByPlanRef(notes, listPlanRef)
+
+				if len(notes) == 0 {
+					if outputFormat == "" {
+						listUlog.Info("No notes found in date range").
+							Field("from", listFrom).
+							Field("to", listTo).
+							Pretty(fmt.Sprintf("No notes %s in that range", listDateField)).
+							PrettyOnly().
+							Log(ctx)
+					} else if outputFormat == OutputJSON {
+						listUlog.Info("No notes found in date range").
+							Field("from", listFrom).
+							Field("to", listTo).
+							Pretty("[]").
+							PrettyOnly().
+							Log(ctx)
+					}
+					return nil
+				}
+				return renderNotes(notes)
+			}
+			if cmd.Flags().Changed("field") {
+				return fmt.Errorf("--field applies to --from and --to")
+			}
 
 			// Handle --all-branches flag
 			if listAllBranches {
@@ -85,31 +230,20 @@ Examples:
 					return err
 				}
 
-				// Filter by tag if provided
-				if listTag != "" {
-					var filteredNotes []*models.Note
-					for _, note := range repoNotes {
-						for _, tag := range note.Tags {
-							if tag == listTag {
-								filteredNotes = append(filteredNotes, note)
-								break
-							}
-						}
-					}
-					repoNotes = filteredNotes
-				}
+				repoNotes = filterNotesByTags(repoNotes, []string{listTag})
 
 				repoNotes = filterNotesByPriority(repoNotes, priorityFilter)
+				repoNotes = filterNotesByFlag(repoNotes, listFlag)
 				repoNotes = filterNotesByPlanRef(repoNotes, listPlanRef)
 
 				if len(repoNotes) == 0 {
-					if !listJSON {
+					if outputFormat == "" {
 						listUlog.Info("No notes found in repository").
 							Field("repository", wsCtx.NotebookContextWorkspace.Name).
 							Pretty(fmt.Sprintf("No notes found in any branch of the '%s' repository", wsCtx.NotebookContextWorkspace.Name)).
 							PrettyOnly().
 							Log(ctx)
-					} else {
+					} else if outputFormat == OutputJSON {
 						listUlog.Info("No notes found in repository").
 							Field("repository", wsCtx.NotebookContextWorkspace.Name).
 							Pretty("[]").
@@ -119,12 +253,7 @@ Examples:
 					return nil
 				}
 
-				if listJSON {
-					return outputJSON(repoNotes)
-				} else {
-					printNotesTable(repoNotes, s.NoteTypes)
-				}
-				return nil
+				return renderNotes(repoNotes)
 			}
 
 			// Fast-path: --workspaces --counts reads cached counts from daemon
@@ -135,7 +264,7 @@ Examples:
 				if client.IsRunning() {
 					counts, err := client.GetNoteCounts(ctx)
 					if err == nil && len(counts) > 0 {
-						if listJSON {
+						if outputFormat == OutputJSON {
 							return outputJSONCounts(counts)
 						}
 						printCountsTable(counts)
@@ -157,30 +286,19 @@ Examples:
 					return err
 				}
 
-				// Filter by tag if provided
-				if listTag != "" {
-					var filteredNotes []*models.Note
-					for _, note := range allNotes {
-						for _, tag := range note.Tags {
-							if tag == listTag {
-								filteredNotes = append(filteredNotes, note)
-								break
-							}
-						}
-					}
-					allNotes = filteredNotes
-				}
+				allNotes = filterNotesByTags(allNotes, []string{listTag})
 
 				allNotes = filterNotesByPriority(allNotes, priorityFilter)
+				allNotes = filterNotesByFlag(allNotes, listFlag)
 				allNotes = filterNotesByPlanRef(allNotes, listPlanRef)
 
 				if len(allNotes) == 0 {
-					if !listJSON {
+					if outputFormat == "" {
 						listUlog.Info("No notes found across all workspaces").
 							Pretty("No notes found across all workspaces").
 							PrettyOnly().
 							Log(ctx)
-					} else {
+					} else if outputFormat == OutputJSON {
 						listUlog.Info("No notes found across all workspaces").
 							Pretty("[]").
 							PrettyOnly().
@@ -190,12 +308,7 @@ Examples:
 				}
 
 				// Output based on format
-				if listJSON {
-					return outputJSON(allNotes)
-				} else {
-					printNotesTable(allNotes, s.NoteTypes)
-				}
-				return nil
+				return renderNotes(allNotes)
 			}
 
 			if listAll {
@@ -222,30 +335,19 @@ Examples:
 					return err
 				}
 
-				// Filter by tag if provided
-				if listTag != "" {
-					var filteredNotes []*models.Note
-					for _, note := range allNotes {
-						for _, tag := range note.Tags {
-							if tag == listTag {
-								filteredNotes = append(filteredNotes, note)
-								break
-							}
-						}
-					}
-					allNotes = filteredNotes
-				}
+				allNotes = filterNotesByTags(allNotes, []string{listTag})
 
 				allNotes = filterNotesByPriority(allNotes, priorityFilter)
+				allNotes = filterNotesByFlag(allNotes, listFlag)
 				allNotes = filterNotesByPlanRef(allNotes, listPlanRef)
 
 				if len(allNotes) == 0 {
-					if !listJSON {
+					if outputFormat == "" {
 						listUlog.Info("No notes found").
 							Pretty("No notes found").
 							PrettyOnly().
 							Log(ctx)
-					} else {
+					} else if outputFormat == OutputJSON {
 						listUlog.Info("No notes found").
 							Pretty("[]").
 							PrettyOnly().
@@ -255,12 +357,7 @@ Examples:
 				}
 
 				// Output based on format
-				if listJSON {
-					return outputJSON(allNotes)
-				} else {
-					printNotesTable(allNotes, s.NoteTypes)
-				}
-				return nil
+				return renderNotes(allNotes)
 			}
 
 			// Original single-type listing
@@ -280,31 +377,20 @@ Examples:
 				return err
 			}
 
-			// Filter by tag if provided
-			if listTag != "" {
-				var filteredNotes []*models.Note
-				for _, note := range notes {
-					for _, tag := range note.Tags {
-						if tag == listTag {
-							filteredNotes = append(filteredNotes, note)
-							break
-						}
-					}
-				}
-				notes = filteredNotes
-			}
+			notes = filterNotesByTags(notes, []string{listTag})
 
 			notes = filterNotesByPriority(notes, priorityFilter)
+			notes = filterNotesByFlag(notes, listFlag)
 			notes = filterNotesByPlanRef(notes, listPlanRef)
 
 			if len(notes) == 0 {
-				if listJSON {
+				if outputFormat == OutputJSON {
 					listUlog.Info("No notes found").
 						Field("note_type", noteType).
 						Pretty("[]").
 						PrettyOnly().
 						Log(ctx)
-				} else {
+				} else if outputFormat == "" {
 					listUlog.Info("No notes found").
 						Field("note_type", noteType).
 						Pretty(fmt.Sprintf("No %s notes found", noteType)).
@@ -315,48 +401,72 @@ Examples:
 			}
 
 			// Output based on format
-			if listJSON {
-				return outputJSON(notes)
-			} else {
-				printNotesTable(notes, s.NoteTypes)
-			}
-
-			return nil
+			return renderNotes(notes)
 		},
 	}
 
 	cmd.Flags().BoolVar(&listAll, "all", false, "List all note types")
 	cmd.Flags().StringVarP(&listType, "type", "t", "inbox", "Note type to list")
+	_ = cmd.RegisterFlagCompletionFunc("type", completeNoteTypes(svc, workspaceOverride))
 	cmd.Flags().BoolVarP(&listGlobal, "global", "g", false, "List global notes only")
-	cmd.Flags().BoolVar(&listJSON, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&listJSON, "json", false, "Output in JSON format (same as --output json)")
+	cmd.Flags().StringVarP(&listOutput, "output", "o", "", "Output format: paths, titles, or json")
 	cmd.Flags().BoolVarP(&listAllWorkspaces, "workspaces", "w", false, "List notes from all workspaces")
 	cmd.Flags().BoolVar(&listAllBranches, "all-branches", false, "List notes from all branches in the current repository")
-	cmd.Flags().StringVar(&listTag, "tag", "", "Filter notes by a specific tag")
+	cmd.Flags().StringVar(&listTag, "tag", "", "Filter notes by a specific tag (case-insensitive, a leading # is ignored)")
+	_ = cmd.RegisterFlagCompletionFunc("tag", completeTags(svc, workspaceOverride))
 	cmd.Flags().BoolVar(&listCounts, "counts", false, "Show aggregate counts per workspace (fast, uses daemon cache with --workspaces)")
 	cmd.Flags().StringVar(&listPriority, "priority", "", "Filter notes by priority level: p0 (most critical) .. p3")
+	cmd.Flags().StringVar(&listFlag, "flag", "", "Filter notes by flag (see the flags setting; default red, yellow, green)")
 	cmd.Flags().BoolVar(&listCriticalOnly, "critical-only", false, "Show only p0 (critical) notes; shorthand for --priority p0")
+	cmd.Flags().StringVar(&listSince, "since", "", "List notes in the workspace whose file changed within this age: days (7d), weeks (2w), or a duration (2h); newest first")
+	cmd.Flags().StringVar(&listFrom, "from", "", "List notes dated on or after this date (YYYY-MM-DD or RFC3339), oldest first")
+	cmd.Flags().StringVar(&listTo, "to", "", "List notes dated on or before this date (YYYY-MM-DD includes the whole day, or RFC3339)")
+	cmd.Flags().StringVar(&listDateField, "field", service.DateFieldCreated, "Date --from and --to compare: created or modified")
+	cmd.Flags().BoolVar(&listTree, "tree", false, "Print the listed notes as a group tree (every type unless one is given)")
 	cmd.Flags().StringVar(&listPlanRef, "plan-ref", "", "Filter to notes whose plan_ref frontmatter exactly matches this value (e.g. plans/my-feature)")
 
 	return cmd
 }
 
+// printNotesTable prints notes as a table on stdout. Type icons are only
+// shown when styledOutput allows it, so piped output is plain text, and
+// titles only link to their notes when hyperlinkOutput allows it.
 func printNotesTable(notes []*models.Note, noteTypes map[string]*coreconfig.NoteTypeConfig) {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	writeNotesTable(os.Stdout, notes, noteTypes, styledOutput(os.Stdout), hyperlinkOutput(os.Stdout))
+}
+
+// titleColumnWidth is the width of the title column of a notes table, which
+// truncateString keeps every title within.
+const titleColumnWidth = 29
+
+func writeNotesTable(out io.Writer, notes []*models.Note, noteTypes map[string]*coreconfig.NoteTypeConfig, styled, linked bool) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	// The title and word count share the last cell, which tabwriter doesn't
+	// align: a linked title holds escape codes tabwriter would count as
+	// width, so it is padded here by its visible width instead.
+	titleCell := func(title, words string) string {
+		return title + strings.Repeat(" ", titleColumnWidth-utf8.RuneCountInString(title)) + "  " + words
+	}
 
 	// Print header
-	fmt.Fprintln(w, "TYPE\tDATE\tTITLE\tWORDS")
-	fmt.Fprintln(w, "-------\t----------\t-----------------------------\t------")
+	fmt.Fprintf(w, "TYPE\tDATE\t%s\n", titleCell("TITLE", "WORDS"))
+	fmt.Fprintf(w, "-------\t----------\t%s\n", titleCell(strings.Repeat("-", titleColumnWidth), "------"))
 
 	// Print each note
 	for _, note := range notes {
-		typeIcon := getNoteTypeIcon(noteTypes, note.Type)
-		typeAbbrev := getTypeAbbreviation(note.Type)
-		typeStr := fmt.Sprintf("%s %s", typeIcon, typeAbbrev)
+		typeStr := getTypeAbbreviation(note.Type)
+		if styled {
+			typeStr = getNoteTypeIcon(noteTypes, note.Type) + " " + typeStr
+		}
 		dateStr := note.ModifiedAt.Format("2006-01-02")
-		titleStr := truncateString(note.Title, 29)
+		titleStr := truncateString(note.Title, titleColumnWidth)
 		wordsStr := fmt.Sprintf("%d", note.WordCount)
+		padded := titleCell(titleStr, wordsStr)
+		cell := noteLink(linked, note.Path, titleStr) + padded[len(titleStr):]
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", typeStr, dateStr, titleStr, wordsStr)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", typeStr, dateStr, cell)
 	}
 
 	w.Flush()
@@ -442,6 +552,22 @@ func filterNotesByPriority(notes []*models.Note, priority string) []*models.Note
 	return filtered
 }
 
+// filterNotesByFlag returns only the notes flagged flag. An empty filter is a
+// no-op. Like priority, flags are not in the daemon note index, so
+// service.NoteFlag reads them from disk for daemon-sourced notes.
+func filterNotesByFlag(notes []*models.Note, flag string) []*models.Note {
+	if flag == "" {
+		return notes
+	}
+	filtered := make([]*models.Note, 0, len(notes))
+	for _, note := range notes {
+		if strings.EqualFold(service.NoteFlag(note), flag) {
+			filtered = append(filtered, note)
+		}
+	}
+	return filtered
+}
+
 // filterNotesByPlanRef returns only the notes whose PlanRef exactly matches the
 // requested value. An empty filter is a no-op (returns the input unchanged).
 //
@@ -496,12 +622,6 @@ func filterNotesByPlanRef(notes []*models.Note, planRef string) []*models.Note {
 	return filtered
 }
 
-func outputJSON(notes []*models.Note) error {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(notes)
-}
-
 func outputJSONCounts(counts map[string]*coremodels.NoteCounts) error {
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
@@ -535,22 +655,9 @@ func tryDaemonListNotes(ctx context.Context, wsFilter, tagFilter string) ([]*mod
 			continue
 		}
 
-		// Apply tag filter if specified
-		if tagFilter != "" {
-			hasTag := false
-			for _, t := range e.Tags {
-				if t == tagFilter {
-					hasTag = true
-					break
-				}
-			}
-			if !hasTag {
-				continue
-			}
-		}
-
 		notes = append(notes, noteFromIndexEntry(e))
 	}
+	notes = filterNotesByTags(notes, []string{tagFilter})
 
 	if len(notes) == 0 {
 		return nil, nil
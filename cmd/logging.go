@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// LogOptions holds the root command's logging flags.
+type LogOptions struct {
+	verbosity verbosityValue
+	debug     bool
+	format    string
+}
+
+// verbosityValue backs --verbose/-v as a counter so that -v selects info and
+// -vv debug logging. pflag repeats a shorthand by calling Set with the flag's
+// NoOptDefVal, hence "+1". It still reports itself as a bool flag, so code
+// reading the flag with GetBool("verbose") sees true for any -v.
+type verbosityValue int
+
+func (v *verbosityValue) Set(s string) error {
+	switch s {
+	case "+1", "true":
+		*v++
+	case "false":
+		*v = 0
+	default:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("invalid verbosity %q", s)
+		}
+		*v = verbosityValue(n)
+	}
+	return nil
+}
+
+func (v *verbosityValue) String() string { return strconv.FormatBool(*v > 0) }
+func (v *verbosityValue) Type() string   { return "bool" }
+
+// AddLogFlags registers --verbose/-v (repeatable), --debug and --log-format on
+// root. The standard grove root command already defines a boolean --verbose;
+// its value is swapped for a counter rather than registering a second flag.
+func AddLogFlags(root *cobra.Command) *LogOptions {
+	opts := &LogOptions{}
+	flags := root.PersistentFlags()
+	if f := flags.Lookup("verbose"); f != nil {
+		f.Value = &opts.verbosity
+		f.DefValue = "false"
+		f.NoOptDefVal = "+1"
+		f.Usage = "Verbose logging (-v for info, -vv for debug)"
+	} else {
+		flags.VarPF(&opts.verbosity, "verbose", "v", "Verbose logging (-v for info, -vv for debug)").NoOptDefVal = "+1"
+	}
+	flags.BoolVar(&opts.debug, "debug", false, "Debug logging (same as -vv)")
+	flags.StringVar(&opts.format, "log-format", "text", "Log output format: text or json")
+	return opts
+}
+
+// Level returns the log level selected by the flags. Without -v or --debug
+// it is Warn, unless GROVE_LOG_LEVEL is set, in which case ok is false and the
+// logger's configured level should be kept.
+func (o *LogOptions) Level() (level logrus.Level, ok bool) {
+	switch {
+	case o.debug || o.verbosity >= 2:
+		return logrus.DebugLevel, true
+	case o.verbosity == 1:
+		return logrus.InfoLevel, true
+	case os.Getenv("GROVE_LOG_LEVEL") != "":
+		return 0, false
+	default:
+		return logrus.WarnLevel, true
+	}
+}
+
+// Apply configures logger's level and formatter from the flags.
+func (o *LogOptions) Apply(logger *logrus.Logger) error {
+	if level, ok := o.Level(); ok {
+		logger.SetLevel(level)
+	}
+	switch o.format {
+	case "", "text":
+	case "json":
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return fmt.Errorf("invalid --log-format %q (valid: text, json)", o.format)
+	}
+	return nil
+}
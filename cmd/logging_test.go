@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogFlags(t *testing.T) {
+	t.Setenv("GROVE_LOG_LEVEL", "")
+
+	cases := []struct {
+		args []string
+		want logrus.Level
+	}{
+		{nil, logrus.WarnLevel},
+		{[]string{"-v"}, logrus.InfoLevel},
+		{[]string{"--verbose"}, logrus.InfoLevel},
+		{[]string{"-vv"}, logrus.DebugLevel},
+		{[]string{"--debug"}, logrus.DebugLevel},
+	}
+	for _, tc := range cases {
+		root := &cobra.Command{Use: "nb", Run: func(*cobra.Command, []string) {}}
+		root.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose logging")
+		opts := AddLogFlags(root)
+		root.SetArgs(tc.args)
+		require.NoError(t, root.Execute(), tc.args)
+
+		level, ok := opts.Level()
+		assert.True(t, ok, tc.args)
+		assert.Equal(t, tc.want, level, tc.args)
+
+		verbose, err := root.PersistentFlags().GetBool("verbose")
+		require.NoError(t, err, tc.args)
+		assert.Equal(t, len(tc.args) > 0 && tc.args[0] != "--debug", verbose, tc.args)
+	}
+}
+
+func TestLogFormat(t *testing.T) {
+	logger := logrus.New()
+	opts := &LogOptions{format: "json"}
+	require.NoError(t, opts.Apply(logger))
+	assert.IsType(t, &logrus.JSONFormatter{}, logger.Formatter)
+
+	assert.Error(t, (&LogOptions{format: "xml"}).Apply(logrus.New()))
+}
@@ -147,6 +147,7 @@ Examples:
 	cmd.Flags().StringVar(&moveTargetWorkspace, "workspace", "", "Target workspace/repository")
 	cmd.Flags().StringVar(&moveTargetBranch, "branch", "", "Target branch (for git repositories)")
 	cmd.Flags().StringVarP(&moveTargetType, "type", "t", "", "Target note type (current, llm, learn, etc.)")
+	_ = cmd.RegisterFlagCompletionFunc("type", completeNoteTypes(svc, workspaceOverride))
 	cmd.Flags().BoolVar(&moveApplyMigrate, "migrate", true, "Apply nb migrate to standardize the note")
 	cmd.Flags().BoolVar(&moveDryRun, "dry-run", false, "Preview changes without moving files")
 	cmd.Flags().BoolVar(&moveForce, "force", false, "Overwrite existing files at destination")
@@ -503,7 +504,7 @@ func noteIDAt(path string) string {
 	if err != nil {
 		return ""
 	}
-	fm, _, err := frontmatter.Parse(string(content))
+	fm, err := frontmatter.ParsePartial(string(content))
 	if err != nil || fm == nil {
 		return ""
 	}
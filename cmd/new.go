@@ -11,6 +11,7 @@ import (
 
 	grovelogging "github.com/grovetools/core/logging"
 
+	"github.com/grovetools/nb/pkg/frontmatter"
 	"github.com/grovetools/nb/pkg/models"
 	"github.com/grovetools/nb/pkg/service"
 )
@@ -24,7 +25,10 @@ func NewNewCmd(svc **service.Service, workspaceOverride *string) *cobra.Command
 		noEdit     bool
 		globalNote bool
 		fromStdin  bool
+		noteBody   string
 		priority   string
+		attach     []string
+		date       string
 	)
 
 	cmd := &cobra.Command{
@@ -42,6 +46,7 @@ Examples:
   nb new -t todos "sprint tasks" # Create todos note
   nb new -g "todo list"      # Create global note
   nb new -g -t daily         # Create global daily note
+  nb new -t daily --date 2024-01-05 # Backfill a daily note for a past day
 
   # Custom types (defined in your grove.yml):
   nb new -t projects/grove "new feature idea"
@@ -52,7 +57,17 @@ Examples:
 
   # Explicit stdin control:
   echo "content" | nb new --stdin "title"
-  nb new --stdin "manual" < file.txt`,
+  echo "content" | nb new --type inbox --title "My Note"
+  nb new --stdin "manual" < file.txt
+
+  # Body from a flag (no editor):
+  nb new -t inbox --body "Call the vendor back" "follow-up"
+
+  # Copy files into attachments/<note>/ and link them from the note:
+  nb new --attach diagram.png --attach spec.pdf "design review"
+
+  # Skip the pre_create/post_create hooks from the [nb] config:
+  nb new --no-hooks --body "generated" "batch item"`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			s := *svc // Dereference the pointer to get the service instance
 
@@ -64,11 +79,7 @@ Examples:
 
 			// Auto-detect stdin if not explicitly set
 			if !cmd.Flags().Changed("stdin") {
-				stat, err := os.Stdin.Stat()
-				if err == nil && (stat.Mode()&os.ModeCharDevice) == 0 {
-					// stdin is piped/redirected, auto-enable
-					fromStdin = true
-				}
+				fromStdin = stdinIsPiped()
 			}
 
 			// Auto-disable editor in non-interactive contexts
@@ -97,24 +108,22 @@ Examples:
 				actualNoteType = "quick"
 			}
 
-			// Read stdin to EOF before creating the note, so slow pipes
-			// deliver all their content before we write the file.
-			var stdinContent []byte
-			if fromStdin {
-				stdinContent, err = io.ReadAll(os.Stdin)
-				if err != nil {
-					return fmt.Errorf("read stdin: %w", err)
-				}
-			}
-
-			// Create options
+			// Create options. With a body from stdin or --body there is
+			// nothing left to write, so the editor only opens without one.
 			var opts []service.CreateOption
-			if noEdit || fromStdin {
+			if noEdit || fromStdin || noteBody != "" {
 				opts = append(opts, service.WithoutEditor())
 			}
 			if globalNote {
 				opts = append(opts, service.InGlobalWorkspace())
 			}
+			if date != "" {
+				createdAt, err := parseNoteDate(date)
+				if err != nil {
+					return err
+				}
+				opts = append(opts, service.WithCreatedAt(createdAt))
+			}
 
 			// Handle concepts type specially
 			if actualNoteType == "concepts" {
@@ -138,7 +147,29 @@ Examples:
 			}
 
 			// Create the note
-			note, err := s.CreateNote(ctx, models.NoteType(actualNoteType), title, opts...)
+			var note *models.Note
+			switch {
+			case len(attach) > 0:
+				// CreateNoteWithAttachment removes the note again if
+				// attaching fails, so read stdin up front.
+				if fromStdin {
+					body, err := io.ReadAll(os.Stdin)
+					if err != nil {
+						return fmt.Errorf("read note body: %w", err)
+					}
+					noteBody = string(body)
+				}
+				if noteBody != "" {
+					opts = append(opts, service.WithBody(noteBody))
+				}
+				note, err = s.CreateNoteWithAttachment(ctx, models.NoteType(actualNoteType), title, attach, opts...)
+			case fromStdin:
+				note, err = s.CreateNoteFromStdin(ctx, models.NoteType(actualNoteType), title, opts...)
+			case noteBody != "":
+				note, err = s.CreateNote(ctx, models.NoteType(actualNoteType), title, append(opts, service.WithBody(noteBody))...)
+			default:
+				note, err = s.CreateNote(ctx, models.NoteType(actualNoteType), title, opts...)
+			}
 			if err != nil {
 				return err
 			}
@@ -150,19 +181,6 @@ Examples:
 				}
 			}
 
-			// If we read stdin content, append it to the note
-			if len(stdinContent) > 0 {
-				existingContent, err := os.ReadFile(note.Path)
-				if err != nil {
-					return fmt.Errorf("read note: %w", err)
-				}
-
-				newContent := string(existingContent) + "\n" + string(stdinContent)
-				if err := s.UpdateNoteContent(note.Path, newContent); err != nil {
-					return fmt.Errorf("update note content: %w", err)
-				}
-			}
-
 			newUlog.Success("Note created").
 				Field("path", note.Path).
 				Field("type", actualNoteType).
@@ -175,29 +193,37 @@ Examples:
 	}
 
 	cmd.Flags().StringVarP(&noteType, "type", "t", "inbox", "Note type (a directory in your notes folder, e.g., 'inbox', 'meetings')")
-	_ = cmd.RegisterFlagCompletionFunc("type", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		s := *svc
-		ctx, err := s.GetWorkspaceContext(*workspaceOverride)
-		if err != nil {
-			return nil, cobra.ShellCompDirectiveError
-		}
-
-		types, err := s.ListNoteTypes(ctx.NotebookContextWorkspace)
-		if err != nil {
-			return nil, cobra.ShellCompDirectiveError
-		}
-
-		var typeNames []string
-		for _, t := range types {
-			typeNames = append(typeNames, string(t))
-		}
-		return typeNames, cobra.ShellCompDirectiveNoFileComp
-	})
+	_ = cmd.RegisterFlagCompletionFunc("type", completeNoteTypes(svc, workspaceOverride))
 	cmd.Flags().StringVarP(&noteName, "name", "n", "", "Note name/title")
+	cmd.Flags().StringVar(&noteName, "title", "", "Note title (same as --name)")
 	cmd.Flags().BoolVar(&noEdit, "no-edit", false, "Don't open editor after creating")
 	cmd.Flags().BoolVarP(&globalNote, "global", "g", false, "Create note in global workspace")
 	cmd.Flags().BoolVar(&fromStdin, "stdin", false, "Read content from stdin (auto-detected when piped)")
+	cmd.Flags().StringVar(&noteBody, "body", "", "Note body (skips the editor)")
+	cmd.Flags().StringArrayVar(&attach, "attach", nil, "File to copy into the note's attachments directory and link (repeatable)")
+	cmd.Flags().StringVar(&date, "date", "", "Date the note as created on this day (YYYY-MM-DD) or at this timestamp instead of now")
 	cmd.Flags().StringVar(&priority, "priority", "", "Priority level: p0 (most critical) .. p3, empty = none")
 
 	return cmd
 }
+
+// parseNoteDate parses --date as a day (YYYY-MM-DD, at the current time of
+// day so same-day notes still sort by creation) or as a full frontmatter
+// timestamp.
+func parseNoteDate(value string) (time.Time, error) {
+	if day, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		now := time.Now()
+		return time.Date(day.Year(), day.Month(), day.Day(), now.Hour(), now.Minute(), now.Second(), 0, time.Local), nil
+	}
+	if t, err := frontmatter.ParseTimestamp(value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --date %q (want YYYY-MM-DD or a timestamp like 2024-01-05T09:00:00Z)", value)
+}
+
+// stdinIsPiped reports whether stdin is a pipe or redirected file rather than
+// an interactive terminal.
+func stdinIsPiped() bool {
+	stat, err := os.Stdin.Stat()
+	return err == nil && (stat.Mode()&os.ModeCharDevice) == 0
+}
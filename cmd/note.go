@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewNoteCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "note",
+		Short: "Inspect and move individual notes",
+	}
+
+	cmd.AddCommand(newNoteInfoCmd(svc, workspaceOverride))
+	cmd.AddCommand(newNoteMoveCmd(svc, workspaceOverride))
+	cmd.AddCommand(newNoteReadabilityCmd(svc, workspaceOverride))
+	cmd.AddCommand(newNoteDiffCmd(svc, workspaceOverride))
+	cmd.AddCommand(newNoteLinkCmd(svc, workspaceOverride))
+	cmd.AddCommand(newNoteFlagCmd(svc, workspaceOverride))
+	cmd.AddCommand(newNoteAttachmentsCmd(svc, workspaceOverride))
+	cmd.AddCommand(newNoteAttachCmd(svc, workspaceOverride))
+	cmd.AddCommand(newNoteDetachCmd(svc, workspaceOverride))
+
+	return cmd
+}
+
+func newNoteInfoCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:     "info <note>",
+		Aliases: []string{"show"},
+		Short:   "Show everything nb knows about a note",
+		Long: `Show the metadata nb parses from a note: title, id, type, tags, status,
+workspace, group, branch, plan_ref, note_ref, link_ref, timestamps, word
+count, file size and permissions. It also reports whether the note is archived, whether
+its plan_ref points at an existing plan, and whether it is in the vault
+index used by links, backlinks and tags.
+
+The note may be given as a file path, or as a filename stem, frontmatter id,
+alias or title of a note in the current workspace.
+
+Examples:
+  nb note info inbox/20240101-idea.md
+  nb note info my-note --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+
+			// The vault index is only needed for the "indexed" and backlink
+			// facts; a note outside any workspace is still inspectable.
+			ix, ixErr := buildVaultIndex(s, *workspaceOverride)
+
+			path := args[0]
+			if _, err := os.Stat(path); err != nil {
+				if ixErr != nil {
+					return fmt.Errorf("note not found: %s", path)
+				}
+				doc, err := resolveNoteArg(ix, path)
+				if err != nil {
+					return err
+				}
+				path = doc.Path
+			}
+
+			info, err := s.GetNoteInfo(path)
+			if err != nil {
+				return err
+			}
+			if ixErr == nil {
+				if _, ok := ix.Doc(info.Note.Path); ok {
+					info.Indexed = true
+					info.Backlinks = len(ix.Backlinks(info.Note.Path))
+				}
+			}
+
+			if jsonOutput {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(info)
+			}
+			return printNoteInfo(cmd.OutOrStdout(), info)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func printNoteInfo(out io.Writer, info *service.NoteInfo) error {
+	n := info.Note
+	orNone := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+
+	planRef := orNone(n.PlanRef)
+	if n.PlanRef != "" {
+		if info.PlanRefResolved {
+			planRef += " (resolves to " + info.PlanDir + ")"
+		} else {
+			planRef += " (unresolved)"
+		}
+	}
+	linkRef := orNone(n.LinkRef)
+	if n.LinkRef != "" && n.LinkType != "" {
+		linkRef += " (" + n.LinkType + ")"
+	}
+	frontmatterState := yesNo(info.HasFrontmatter)
+	if info.FrontmatterError != "" {
+		frontmatterState = "invalid: " + info.FrontmatterError
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	rows := [][2]string{
+		{"Path", n.Path},
+		{"Title", orNone(n.FrontmatterTitle)},
+		{"Filename", n.Title},
+		{"ID", orNone(n.ID)},
+		{"Type", orNone(string(n.Type))},
+		{"Tags", orNone(strings.Join(n.Tags, ", "))},
+		{"Aliases", orNone(strings.Join(n.Aliases, ", "))},
+		{"Status", orNone(info.Status)},
+		{"Priority", orNone(n.Priority)},
+		{"Flag", orNone(n.Flag)},
+		{"Workspace", orNone(n.Workspace)},
+		{"Group", orNone(info.Group)},
+		{"Branch", orNone(n.Branch)},
+		{"Plan ref", planRef},
+		{"Plan job", orNone(n.PlanJob)},
+		{"Note ref", orNone(info.NoteRef)},
+		{"Link ref", linkRef},
+		{"Created", n.CreatedAt.Format(time.RFC3339)},
+		{"Modified", n.ModifiedAt.Format(time.RFC3339)},
+		{"Words", fmt.Sprintf("%d", n.WordCount)},
+		{"Todos", fmt.Sprintf("%d open, %d done", n.TodoOpen, n.TodoDone)},
+		{"Size", fmt.Sprintf("%d bytes", info.Size)},
+		{"Permissions", info.Permissions},
+		{"Frontmatter", frontmatterState},
+		{"Archived", yesNo(n.IsArchived)},
+		{"Indexed", yesNo(info.Indexed)},
+		{"Backlinks", fmt.Sprintf("%d", info.Backlinks)},
+	}
+	if n.Remote != nil {
+		rows = append(rows, [2]string{"Remote", fmt.Sprintf("%s #%s (%s)", n.Remote.Provider, n.Remote.ID, orNone(n.Remote.State))})
+	}
+	for _, r := range rows {
+		fmt.Fprintf(w, "%s:\t%s\n", r[0], r[1])
+	}
+	return w.Flush()
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func newNoteAttachmentsCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var pathsOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "attachments <note>",
+		Short: "List a note's attachments",
+		Long: `List the files in a note's attachment directory, attachments/<note-stem>
+beside the note, with their sizes. --paths prints only absolute paths, for
+scripts.
+
+The note may be given as a file path, or as a filename stem, frontmatter id,
+alias or title of a note in the current workspace.
+
+Examples:
+  nb note attachments inbox/20240101-design.md
+  nb note attachments design --paths | xargs open`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			paths, err := resolveNotePaths(s, *workspaceOverride, args)
+			if err != nil {
+				return err
+			}
+			files, err := s.GetNoteAttachments(paths[0])
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if pathsOnly {
+				for _, f := range files {
+					fmt.Fprintln(out, f)
+				}
+				return nil
+			}
+			if len(files) == 0 {
+				fmt.Fprintln(out, "No attachments")
+				return nil
+			}
+			dir := service.NoteAttachmentDir(paths[0])
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+			for _, f := range files {
+				size := "?"
+				if info, err := os.Stat(f); err == nil {
+					size = service.FormatBytes(info.Size())
+				}
+				rel, err := filepath.Rel(dir, f)
+				if err != nil {
+					rel = f
+				}
+				fmt.Fprintf(w, "%s\t  %s\n", size, rel)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&pathsOnly, "paths", false, "Print only the absolute paths")
+	return cmd
+}
+
+func newNoteAttachCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attach <note> <file>...",
+		Short: "Attach files to a note",
+		Long: `Copy files into the note's attachment directory, attachments/<note-stem>
+beside the note, and append a reference to each at the end of the note: an
+image embed for images, a link otherwise. A file whose name is taken gets a
+numeric suffix.
+
+Examples:
+  nb note attach inbox/20240101-design.md sketch.png
+  nb note attach design spec.pdf data.csv`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			paths, err := resolveNotePaths(s, *workspaceOverride, args[:1])
+			if err != nil {
+				return err
+			}
+			copied, err := s.AddAttachments(paths[0], args[1:])
+			for _, f := range copied {
+				fmt.Fprintf(cmd.OutOrStdout(), "Attached %s\n", f)
+			}
+			return err
+		},
+	}
+	return cmd
+}
+
+func newNoteDetachCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "detach <note> <attachment>",
+		Short: "Remove an attachment from a note",
+		Long: `Delete a file from the note's attachment directory and remove the reference
+line nb note attach wrote for it; other mentions of the file are left alone.
+The attachment is given by its name within the directory (as nb note
+attachments lists it) or by its path. The directory is removed once empty.
+
+Examples:
+  nb note detach inbox/20240101-design.md sketch.png
+  nb note detach design attachments/20240101-design/spec.pdf`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			paths, err := resolveNotePaths(s, *workspaceOverride, args[:1])
+			if err != nil {
+				return err
+			}
+			removed, err := s.RemoveAttachment(paths[0], args[1])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed %s\n", removed)
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func newNoteDiffCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		noFrontmatter bool
+		contextLines  int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff <note> <other-note|revision>",
+		Short: "Show a unified diff between two notes or note versions",
+		Long: `Print a unified diff between two notes. When the second argument is not an
+existing file, it is taken as a git revision (commit hash, branch or tag) and
+the note is compared with its content at that revision.
+
+The first note may be given as a file path, or as a filename stem,
+frontmatter id, alias or title of a note in the current workspace.
+
+Examples:
+  nb note diff inbox/draft.md inbox/draft-v2.md
+  nb note diff my-note HEAD~3 --no-frontmatter
+  nb note diff my-note a1b2c3d -U 0`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			paths, err := resolveNotePaths(s, *workspaceOverride, args[:1])
+			if err != nil {
+				return err
+			}
+
+			opts := []service.DiffOption{service.WithDiffContext(contextLines)}
+			if noFrontmatter {
+				opts = append(opts, service.WithoutFrontmatter())
+			}
+
+			var diff string
+			if info, statErr := os.Stat(args[1]); statErr == nil && !info.IsDir() {
+				diff, err = s.DiffNotes(paths[0], args[1], opts...)
+			} else {
+				diff, err = s.DiffNoteRevision(paths[0], args[1], opts...)
+			}
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if diff == "" {
+				fmt.Fprintln(out, "No differences")
+				return nil
+			}
+			fmt.Fprint(out, diff)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&noFrontmatter, "no-frontmatter", false, "Compare the note bodies only")
+	cmd.Flags().IntVarP(&contextLines, "unified", "U", service.DefaultDiffContext, "Lines of context around each change")
+
+	return cmd
+}
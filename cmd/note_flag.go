@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func newNoteFlagCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var clear bool
+
+	cmd := &cobra.Command{
+		Use:   "flag <note> [flag]",
+		Short: "Show, set or clear a note's flag",
+		Long: `Flags are colored triage labels, kept apart from tags in the note's flag
+frontmatter field. A note has at most one. With a flag, nb note flag sets it,
+or clears it when the note already has that flag; --clear removes any flag.
+With neither, the note's current flag is printed.
+
+The palette is configured with the flags setting and defaults to red, yellow
+and green. The TUI colors flagged notes and cycles a note's flag with "!";
+nb list --flag, nb search --flag and a "#flag:<name>" TUI filter select them.
+
+Examples:
+  nb note flag release-checklist red
+  nb note flag release-checklist --clear
+  nb note flag inbox/20240101-idea.md`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			if clear && len(args) == 2 {
+				return fmt.Errorf("--clear cannot be combined with a flag")
+			}
+			paths, err := resolveNotePaths(s, *workspaceOverride, args[:1])
+			if err != nil {
+				return err
+			}
+			path := paths[0]
+			out := cmd.OutOrStdout()
+
+			current, err := service.ReadNoteFlag(path)
+			if err != nil {
+				return fmt.Errorf("read note flag: %w", err)
+			}
+			if current == "" && len(args) == 1 {
+				fmt.Fprintf(out, "%s has no flag\n", path)
+				return nil
+			}
+			var want string
+			switch {
+			case clear:
+				want = current
+			case len(args) == 2:
+				want = args[1]
+			default:
+				fmt.Fprintln(out, current)
+				return nil
+			}
+
+			flag, err := s.SetFlag(path, want)
+			if err != nil {
+				return err
+			}
+			if flag == "" {
+				fmt.Fprintf(out, "Cleared flag of %s\n", path)
+			} else {
+				fmt.Fprintf(out, "Flagged %s %s\n", path, flag)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&clear, "clear", false, "Remove the note's flag")
+	cmd.ValidArgsFunction = func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 1 || *svc == nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		var names []string
+		for _, name := range service.FlagNames((*svc).Flags()) {
+			if strings.HasPrefix(name, toComplete) {
+				names = append(names, name)
+			}
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+	return cmd
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func newNoteLinkCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		linkType string
+		bodyLink bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "link <source> <target>",
+		Short: "Link one note to another",
+		Long: `Link a note to another by setting link_ref (and link_type) in the source's
+frontmatter and adding the source to the target's backlinks. Both are stored
+as paths relative to the note holding them. The link type is reference,
+parent or followup. --body also appends a reference such as
+"[See also: Target title](../ideas/target.md)" to the source's body.
+
+Notes may be given as file paths, or as a filename stem, frontmatter id,
+alias or title of a note in the current workspace.
+
+Examples:
+  nb note link inbox/20240101-idea.md issues/20240105-bug.md
+  nb note link retro-notes sprint-plan --type followup
+  nb note link spike-design design-doc --type parent --body`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			paths, err := resolveNotePaths(s, *workspaceOverride, args)
+			if err != nil {
+				return err
+			}
+			var opts []service.LinkOption
+			if bodyLink {
+				opts = append(opts, service.WithBodyLink())
+			}
+			if err := s.LinkNotes(paths[0], paths[1], linkType, opts...); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Linked %s → %s (%s)\n", paths[0], paths[1], linkType)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&linkType, "type", service.LinkTypeReference, "Link type: "+strings.Join(service.LinkTypes, ", "))
+	cmd.Flags().BoolVar(&bodyLink, "body", false, "Also add a reference to the target in the source's body")
+	return cmd
+}
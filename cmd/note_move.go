@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// NewMvCmd is `nb mv`, a top-level shorthand for `nb note move`.
+func NewMvCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	cmd := newNoteMoveCmd(svc, workspaceOverride)
+	cmd.Use = "mv <note>... <workspace>/<group>"
+	cmd.Short = "Move notes to a workspace and group (shorthand for nb note move)"
+	cmd.Aliases = nil
+	return cmd
+}
+
+func newNoteMoveCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var copyNotes bool
+
+	cmd := &cobra.Command{
+		Use:     "move <note>... <workspace>/<group>",
+		Aliases: []string{"mv"},
+		Short:   "Move notes to a workspace and group",
+		Long: `Move notes to a group in any workspace, updating their frontmatter to
+match the new location. The destination is written as <workspace>/<group>,
+where the group may be nested (e.g. my-project/issues/bugs); use "global" for
+the global workspace. A note that would overwrite an existing file gets a
+timestamp suffix instead.
+
+Notes may be given as file paths, as paths relative to the current
+workspace's notes directory (inbox/20240101-idea.md), or as a filename stem,
+frontmatter id, alias or title of a note in the current workspace.
+
+Examples:
+  nb note move inbox/20240101-idea.md my-project/learn
+  nb mv my-note other-project/issues/bugs
+  nb mv --copy my-note global/inbox`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+
+			wsName, group, err := parseMoveDestination(args[len(args)-1])
+			if err != nil {
+				return err
+			}
+			destWorkspace, err := findWorkspaceByName(s, wsName)
+			if err != nil {
+				return err
+			}
+			paths, err := resolveMoveSources(s, *workspaceOverride, args[:len(args)-1])
+			if err != nil {
+				return err
+			}
+
+			transfer, verb := s.MoveNotes, "Moved"
+			if copyNotes {
+				transfer, verb = s.CopyNotes, "Copied"
+			}
+			newPaths, err := transfer(paths, destWorkspace, group)
+			if err != nil {
+				return err
+			}
+			for i, newPath := range newPaths {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %s -> %s\n", verb, paths[i], newPath)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&copyNotes, "copy", false, "Copy instead of move (keep the original notes)")
+
+	return cmd
+}
+
+// parseMoveDestination splits a "<workspace>/<group>" destination. The group
+// keeps any further slashes, so nested groups are allowed.
+func parseMoveDestination(dest string) (workspace, group string, err error) {
+	dest = strings.Trim(strings.TrimSpace(dest), "/")
+	workspace, group, ok := strings.Cut(dest, "/")
+	if !ok || workspace == "" || group == "" {
+		return "", "", fmt.Errorf("invalid destination %q: want <workspace>/<group>, e.g. my-project/inbox", dest)
+	}
+	return workspace, group, nil
+}
+
+// findWorkspaceByName returns the workspace node called name, or the global
+// workspace for "global".
+func findWorkspaceByName(s *service.Service, name string) (*coreworkspace.WorkspaceNode, error) {
+	if name == "global" {
+		ctx, err := s.GetWorkspaceContext("global")
+		if err != nil {
+			return nil, fmt.Errorf("get global workspace context: %w", err)
+		}
+		return ctx.NotebookContextWorkspace, nil
+	}
+	if provider := s.GetWorkspaceProvider(); provider != nil {
+		if ws := provider.FindByName(name); ws != nil {
+			return ws, nil
+		}
+	}
+	return nil, fmt.Errorf("workspace not found: %s", name)
+}
+
+// resolveMoveSources is resolveNotePaths that also accepts paths relative to
+// the notes directory of the current workspace, so `nb mv inbox/x.md ...`
+// works from anywhere inside the project.
+func resolveMoveSources(s *service.Service, workspaceOverride string, args []string) ([]string, error) {
+	var notesRoot string
+	if ctx, err := s.GetWorkspaceContext(workspaceOverride); err == nil {
+		notesRoot, _ = s.GetNotebookLocator().GetNotesDir(ctx.NotebookContextWorkspace, "")
+	}
+
+	resolved := make([]string, len(args))
+	copy(resolved, args)
+	for i, arg := range args {
+		if _, err := os.Stat(arg); err == nil || notesRoot == "" || filepath.IsAbs(arg) {
+			continue
+		}
+		candidate := filepath.Join(notesRoot, arg)
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			resolved[i] = candidate
+		}
+	}
+	return resolveNotePaths(s, workspaceOverride, resolved)
+}
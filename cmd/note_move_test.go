@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoveDestination(t *testing.T) {
+	cases := []struct {
+		dest, workspace, group string
+		wantErr                bool
+	}{
+		{dest: "my-project/inbox", workspace: "my-project", group: "inbox"},
+		{dest: "my-project/issues/bugs/", workspace: "my-project", group: "issues/bugs"},
+		{dest: "global/learn", workspace: "global", group: "learn"},
+		{dest: "inbox", wantErr: true},
+		{dest: "/inbox", wantErr: true},
+		{dest: "my-project/", wantErr: true},
+	}
+	for _, tc := range cases {
+		ws, group, err := parseMoveDestination(tc.dest)
+		if tc.wantErr {
+			assert.Error(t, err, tc.dest)
+			continue
+		}
+		assert.NoError(t, err, tc.dest)
+		assert.Equal(t, tc.workspace, ws, tc.dest)
+		assert.Equal(t, tc.group, group, tc.dest)
+	}
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func newNoteReadabilityCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "readability <note>",
+		Short: "Score how easy a note is to read",
+		Long: `Score a note's prose: the Flesch reading ease and Flesch-Kincaid grade
+level, average sentence and word length, the number of paragraphs, and an
+easy/medium/hard rating (reading ease of 60 and up is easy, below 30 is hard).
+Frontmatter, headings, code and Markdown syntax are left out.
+
+The note may be given as a file path, or as a filename stem, frontmatter id,
+alias or title of a note in the current workspace.
+
+Examples:
+  nb note readability inbox/20240101-idea.md
+  nb note readability my-note --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			paths, err := resolveNotePaths(s, *workspaceOverride, args)
+			if err != nil {
+				return err
+			}
+			score, err := s.ComputeNoteReadability(paths[0])
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonOutput {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(score)
+			}
+			if score.Words == 0 {
+				fmt.Fprintf(out, "%s has no prose to score\n", paths[0])
+				return nil
+			}
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			rows := [][2]string{
+				{"Reading ease", fmt.Sprintf("%.1f", score.ReadingEase)},
+				{"Grade level", fmt.Sprintf("%.1f", score.GradeLevel)},
+				{"Complexity", score.Complexity},
+				{"Words", fmt.Sprintf("%d", score.Words)},
+				{"Sentences", fmt.Sprintf("%d", score.Sentences)},
+				{"Paragraphs", fmt.Sprintf("%d", score.Paragraphs)},
+				{"Words/sentence", fmt.Sprintf("%.1f", score.AvgSentenceLength)},
+				{"Letters/word", fmt.Sprintf("%.1f", score.AvgWordLength)},
+			}
+			for _, r := range rows {
+				fmt.Fprintf(w, "%s:\t%s\n", r[0], r[1])
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
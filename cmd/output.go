@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// Machine-oriented output formats accepted by --output on `nb list` and
+// `nb search`. The empty format means the command's default human output.
+const (
+	OutputPaths  = "paths"
+	OutputTitles = "titles"
+	OutputJSON   = "json"
+)
+
+// validateOutputFormat rejects unknown --output values before any work is done.
+func validateOutputFormat(format string) error {
+	switch format {
+	case "", OutputPaths, OutputTitles, OutputJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid --output %q (want one of %s, %s, %s)", format, OutputPaths, OutputTitles, OutputJSON)
+	}
+}
+
+// FormatNoteOutput writes notes to w in a pipeline-friendly format:
+//   - paths:  one absolute file path per line
+//   - titles: one title per line (frontmatter title, falling back to the filename)
+//   - json:   an indented JSON array of note objects
+//
+// No headers or decorations are emitted, so the output can be fed straight into
+// xargs, grep, or jq.
+func FormatNoteOutput(notes []*models.Note, format string, w io.Writer) error {
+	switch format {
+	case OutputPaths:
+		for _, note := range notes {
+			if _, err := fmt.Fprintln(w, note.Path); err != nil {
+				return err
+			}
+		}
+	case OutputTitles:
+		for _, note := range notes {
+			title := note.FrontmatterTitle
+			if title == "" {
+				title = note.Title
+			}
+			if _, err := fmt.Fprintln(w, title); err != nil {
+				return err
+			}
+		}
+	case OutputJSON:
+		if notes == nil {
+			notes = []*models.Note{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(notes)
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+	return nil
+}
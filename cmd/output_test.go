@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestFormatNoteOutput(t *testing.T) {
+	notes := []*models.Note{
+		{Path: "/nb/inbox/20250101-first.md", Title: "20250101-first.md", FrontmatterTitle: "First"},
+		{Path: "/nb/inbox/20250102-second.md", Title: "20250102-second.md"},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{OutputPaths, "/nb/inbox/20250101-first.md\n/nb/inbox/20250102-second.md\n"},
+		{OutputTitles, "First\n20250102-second.md\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := FormatNoteOutput(notes, tt.format, &buf); err != nil {
+				t.Fatalf("FormatNoteOutput: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+
+	t.Run(OutputJSON, func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := FormatNoteOutput(notes, OutputJSON, &buf); err != nil {
+			t.Fatalf("FormatNoteOutput: %v", err)
+		}
+		var decoded []map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("output is not a JSON array: %v\n%s", err, buf.String())
+		}
+		if len(decoded) != 2 {
+			t.Errorf("got %d JSON objects, want 2", len(decoded))
+		}
+	})
+
+	t.Run("empty json is an array", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := FormatNoteOutput(nil, OutputJSON, &buf); err != nil {
+			t.Fatalf("FormatNoteOutput: %v", err)
+		}
+		if buf.String() != "[]\n" {
+			t.Errorf("got %q, want %q", buf.String(), "[]\n")
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		if err := FormatNoteOutput(notes, "yaml", &bytes.Buffer{}); err == nil {
+			t.Error("expected an error for an unknown format")
+		}
+		if err := validateOutputFormat("yaml"); err == nil {
+			t.Error("validateOutputFormat should reject unknown formats")
+		}
+	})
+}
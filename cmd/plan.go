@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewPlanCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Manage plans in the notebook",
+		Long: `Manage plan directories under plans/ in the current workspace.
+
+Examples:
+  nb plan status my-feature hold
+  nb plan status my-feature active
+  nb plan notes my-feature
+  nb plan timeline my-feature`,
+	}
+
+	cmd.AddCommand(newPlanStatusCmd(svc, workspaceOverride))
+	cmd.AddCommand(newPlanNotesCmd(svc, workspaceOverride))
+	cmd.AddCommand(newPlanTimelineCmd(svc, workspaceOverride))
+
+	return cmd
+}
+
+func newPlanStatusCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status <name> <status>",
+		Short: "Set the status of a plan",
+		Long: `Set the status field in a plan's .grove-plan.yml, creating the file if the
+plan has none. Other settings in the file are left untouched.
+
+Valid statuses: active, hold, closed, cancelled.
+
+Examples:
+  nb plan status my-feature hold
+  nb plan status plans/my-feature closed
+  nb plan status my-feature active -W ~/code/other-project`,
+		Args: cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			switch len(args) {
+			case 0:
+				s := *svc
+				ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+				if err != nil {
+					return nil, cobra.ShellCompDirectiveError
+				}
+				names, err := s.ListPlanNames(ctx)
+				if err != nil {
+					return nil, cobra.ShellCompDirectiveError
+				}
+				return names, cobra.ShellCompDirectiveNoFileComp
+			case 1:
+				return service.PlanStatuses, cobra.ShellCompDirectiveNoFileComp
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+			if err := s.UpdatePlanStatus(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Plan %s is now %s\n", args[0], args[1])
+			return nil
+		},
+	}
+}
+
+// completePlanNames completes the first argument with the plans in the
+// current workspace.
+func completePlanNames(svc **service.Service, workspaceOverride *string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		s := *svc
+		ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		names, err := s.ListPlanNames(ctx)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func newPlanNotesCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		archived     bool
+		outputFormat string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "notes <name>",
+		Short: "List the notes in a plan",
+		Long: `List the notes in plans/<name> and its subdirectories, sorted by path. Only
+the plan's directory is read, so this is quicker than nb list on a workspace
+with many groups.
+
+--archived also lists the plan's .archive directory, and finds a plan that was
+itself archived into plans/.archive.
+
+Examples:
+  nb plan notes my-feature
+  nb plan notes plans/my-feature --archived
+  nb plan notes my-feature --output paths | xargs grep -l TODO`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePlanNames(svc, workspaceOverride),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputFormat(outputFormat); err != nil {
+				return err
+			}
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+			notes, err := s.GetPlanAllNotes(ctx, args[0], archived)
+			if err != nil {
+				return err
+			}
+			if outputFormat != "" {
+				return FormatNoteOutput(notes, outputFormat, cmd.OutOrStdout())
+			}
+			if len(notes) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No notes in plan %s\n", args[0])
+				return nil
+			}
+			printNotesTable(notes, s.NoteTypes)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&archived, "archived", false, "Include archived notes and archived plans")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "", "Output format: paths, titles, or json")
+
+	return cmd
+}
+
+func newPlanTimelineCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		since, until string
+		jsonOutput   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "timeline <name>",
+		Short: "Show a plan's activity over time",
+		Long: `Show a plan's history, oldest first: when each of its notes was created,
+when notes were archived into its .archive directory, and when notes elsewhere
+in the workspace that link to it with plan_ref were created.
+
+--since and --until take a date (2024-01-01), an RFC3339 timestamp, or an age
+(7d, 2w, 36h). A date given to --until includes that whole day.
+
+Examples:
+  nb plan timeline my-feature
+  nb plan timeline my-feature --since 2w
+  nb plan timeline plans/my-feature --since 2024-01-01 --until 2024-01-31 --json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePlanNames(svc, workspaceOverride),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+			sinceTime, err := parseTimelineBound("--since", since, now, false)
+			if err != nil {
+				return err
+			}
+			untilTime, err := parseTimelineBound("--until", until, now, true)
+			if err != nil {
+				return err
+			}
+
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+			entries, err := s.GetPlanTimeline(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			entries = service.FilterTimeline(entries, sinceTime, untilTime)
+
+			out := cmd.OutOrStdout()
+			if jsonOutput {
+				if entries == nil {
+					entries = []service.TimelineEntry{}
+				}
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(entries)
+			}
+			if len(entries) == 0 {
+				fmt.Fprintf(out, "No activity for plan %s\n", args[0])
+				return nil
+			}
+			for _, e := range entries {
+				fmt.Fprintln(out, e)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only show events at or after this time (date, RFC3339, or age like 7d)")
+	cmd.Flags().StringVar(&until, "until", "", "Only show events before this time (date, RFC3339, or age like 7d)")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+// parseTimelineBound parses --since or --until: an RFC3339 timestamp, a
+// YYYY-MM-DD date (local midnight, or the following midnight when endOfDay is
+// set so the day is included), or an age counted back from now.
+func parseTimelineBound(flag, value string, now time.Time, endOfDay bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+	age, err := parseArchiveAge(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q: use a date (2024-01-01), an RFC3339 time, or an age (7d, 2w, 36h)", flag, value)
+	}
+	return now.Add(-age), nil
+}
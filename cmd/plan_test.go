@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimelineBound(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseTimelineBound("--since", "", now, false)
+	require.NoError(t, err)
+	assert.True(t, got.IsZero())
+
+	got, err = parseTimelineBound("--since", "2024-03-01", now, false)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), got)
+
+	got, err = parseTimelineBound("--until", "2024-03-01", now, true)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.Local), got)
+
+	got, err = parseTimelineBound("--until", "2024-03-01T08:30:00Z", now, true)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC), got)
+
+	got, err = parseTimelineBound("--since", "2w", now, false)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-14*24*time.Hour), got)
+
+	_, err = parseTimelineBound("--since", "last", now, false)
+	assert.ErrorContains(t, err, "--since")
+}
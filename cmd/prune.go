@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewPruneCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove empty group directories",
+		Long: `Remove the directories in the current workspace's notebook that hold no
+files, such as groups emptied by moving or archiving their notes. Nested empty
+directories are removed deepest first. Reserved directories (templates, plans,
+concepts, recipes, archive) are kept even when empty, and a directory holding
+any file at all, including attachments or a group config, is kept.
+
+Examples:
+  nb prune --dry-run
+  nb prune
+  nb prune -W my-project`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+			pruned, err := s.PruneEmptyDirs(ctx, dryRun)
+			out := cmd.OutOrStdout()
+			verb := "Removed"
+			if dryRun {
+				verb = "Would remove"
+			}
+			for _, dir := range pruned {
+				fmt.Fprintf(out, "%s %s\n", verb, dir)
+			}
+			if err != nil {
+				return err
+			}
+			if len(pruned) == 0 {
+				fmt.Fprintln(out, "No empty directories")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the directories that would be removed without removing them")
+	return cmd
+}
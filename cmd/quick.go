@@ -2,13 +2,13 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	grovelogging "github.com/grovetools/core/logging"
 
+	"github.com/grovetools/nb/pkg/models"
 	"github.com/grovetools/nb/pkg/service"
 )
 
@@ -19,11 +19,15 @@ func NewQuickCmd(svc **service.Service, workspaceOverride *string) *cobra.Comman
 		Use:   "quick [content]",
 		Short: "Create a quick note without opening editor",
 		Long: `Create a quick note with timestamp title, no editor.
+
+The content can also be piped on stdin. With neither, the new note is opened
+in $EDITOR.
 	
 Examples:
   nb quick "Remember to review PR #123"
-  nb quick "Meeting at 3pm with team"`,
-		Args: cobra.ExactArgs(1),
+  nb quick "Meeting at 3pm with team"
+  git log -1 --format=%B | nb quick`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			s := *svc
 
@@ -33,29 +37,24 @@ Examples:
 				return fmt.Errorf("get workspace context: %w", err)
 			}
 
-			content := args[0]
-
 			// Create timestamp-based title with "quick" suffix
 			title := time.Now().Format("2006-01-02-150405") + "-quick"
 
-			// Create the note without opening editor in the quick directory
-			note, err := s.CreateNote(ctx, "quick", title, service.WithoutEditor())
-			if err != nil {
-				return err
+			// Create the note in the quick directory. Content comes from the
+			// argument or stdin; without either, fall back to the editor.
+			var note *models.Note
+			var content string
+			switch {
+			case len(args) > 0:
+				content = args[0]
+				note, err = s.CreateNote(ctx, "quick", title, service.WithoutEditor(), service.WithBody(content))
+			case stdinIsPiped():
+				note, err = s.CreateNoteFromStdin(ctx, "quick", title)
+			default:
+				note, err = s.CreateNote(ctx, "quick", title)
 			}
-
-			// Read the created note content to preserve the frontmatter
-			existingContent, err := os.ReadFile(note.Path)
 			if err != nil {
-				return fmt.Errorf("read note: %w", err)
-			}
-
-			// Append the quick content
-			newContent := string(existingContent) + content + "\n"
-
-			// Write the updated content
-			if err := s.UpdateNoteContent(note.Path, newContent); err != nil {
-				return fmt.Errorf("update note content: %w", err)
+				return err
 			}
 
 			quickUlog.Success("Created quick note").
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewRecentCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		recentLimit         int
+		recentJSON          bool
+		recentAllWorkspaces bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "recent",
+		Short: "Show the most recently modified notes",
+		Long: `Show the most recently modified (non-archived) notes, newest first.
+
+Examples:
+  nb recent                # 10 most recent notes in the current workspace
+  nb recent --limit 25     # 25 most recent notes
+  nb recent -w --json      # Most recent notes across all workspaces as JSON`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+
+			notes, err := s.GetRecentNotes(ctx, recentLimit, recentAllWorkspaces)
+			if err != nil {
+				return err
+			}
+
+			if recentJSON {
+				return FormatNoteOutput(notes, OutputJSON, cmd.OutOrStdout())
+			}
+			if len(notes) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No notes found")
+				return nil
+			}
+			printNotesTable(notes, s.NoteTypes)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&recentLimit, "limit", "n", 10, "Maximum number of notes to show (0 for all)")
+	cmd.Flags().BoolVar(&recentJSON, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVarP(&recentAllWorkspaces, "workspaces", "w", false, "Include notes from all workspaces")
+
+	return cmd
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewRelatedCmd(svc **service.Service) *cobra.Command {
+	var (
+		relatedLimit int
+		relatedJSON  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "related <path>",
+		Short: "Show notes related to a note by shared tags",
+		Long: `Show notes whose tags overlap with the given note, best match first.
+
+Notes are scored by the Jaccard similarity of their tag sets, with a small
+boost for notes in the same workspace. Matches below the minimum score
+(default 0.3, configurable as related_min_score in the [nb] config section)
+are left out.
+
+Examples:
+  nb related inbox/20250101-idea.md
+  nb related ./note.md --limit 5
+  nb related ./note.md --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+
+			path, err := filepath.Abs(args[0])
+			if err != nil {
+				return fmt.Errorf("resolve path: %w", err)
+			}
+
+			related, err := s.GetRelatedNotesScored(path, relatedLimit)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if relatedJSON {
+				if related == nil {
+					related = []service.RelatedNote{}
+				}
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(related)
+			}
+			if len(related) == 0 {
+				fmt.Fprintln(out, "No related notes found")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "SCORE\tWORKSPACE\tTITLE\tTAGS")
+			for _, r := range related {
+				title := r.Note.FrontmatterTitle
+				if title == "" {
+					title = r.Note.Title
+				}
+				fmt.Fprintf(w, "%.2f\t%s\t%s\t%s\n", r.Score, r.Note.Workspace,
+					truncateString(title, 40), strings.Join(r.Note.Tags, ", "))
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().IntVarP(&relatedLimit, "limit", "n", 10, "Maximum number of related notes to show (0 for all)")
+	cmd.Flags().BoolVar(&relatedJSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewReminderCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reminder",
+		Short: "Set, list and deliver note reminders",
+		Long: `Reminders are stored in a note's frontmatter:
+
+  reminder:
+    at: "2024-06-01T09:00:00Z"
+    message: Review this
+
+nb reminder check (or a long-running nb reminder daemon) sends a desktop
+notification for each reminder that came due, using notify-send on Linux and
+osascript on macOS.
+
+Examples:
+  nb reminder set my-note 2h "Follow up"
+  nb reminder list --all
+  nb reminder daemon`,
+	}
+
+	cmd.AddCommand(newReminderSetCmd(svc, workspaceOverride))
+	cmd.AddCommand(newReminderClearCmd(svc, workspaceOverride))
+	cmd.AddCommand(newReminderListCmd(svc, workspaceOverride))
+	cmd.AddCommand(newReminderCheckCmd(svc, workspaceOverride, false))
+	cmd.AddCommand(newReminderCheckCmd(svc, workspaceOverride, true))
+
+	return cmd
+}
+
+func newReminderSetCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <note> <datetime> [message]",
+		Short: "Set a note's reminder",
+		Long: `Set the reminder of a note, replacing any it had. The time is a duration from
+now (30m, 2h, 3d), an RFC3339 timestamp, or a local "YYYY-MM-DD HH:MM" or
+"YYYY-MM-DD" (9am that day).
+
+Examples:
+  nb reminder set inbox/20240101-idea.md 2h
+  nb reminder set my-note "2024-06-01 09:00" Review this`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			paths, err := resolveNotePaths(s, *workspaceOverride, args[:1])
+			if err != nil {
+				return err
+			}
+			at, err := service.ParseReminderTime(args[1], time.Now())
+			if err != nil {
+				return err
+			}
+			message := strings.Join(args[2:], " ")
+			if err := s.SetNoteReminder(paths[0], at, message); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Reminder set for %s\n", at.Local().Format("2006-01-02 15:04"))
+			return nil
+		},
+	}
+}
+
+func newReminderClearCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear <note>...",
+		Short: "Remove notes' reminders",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			paths, err := resolveNotePaths(s, *workspaceOverride, args)
+			if err != nil {
+				return err
+			}
+			for _, path := range paths {
+				if err := s.ClearNoteReminder(path); err != nil {
+					return err
+				}
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Cleared reminders on %d notes\n", len(paths))
+			return nil
+		},
+	}
+}
+
+func newReminderListCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		all        bool
+		jsonOutput bool
+		pending    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List note reminders, soonest first",
+		Long: `List the reminders of the current workspace's notes, soonest first.
+
+Examples:
+  nb reminder list
+  nb reminder list --all --pending`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+			reminders, err := s.ListReminders(ctx, all)
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			if pending {
+				var future []service.NoteReminder
+				for _, r := range reminders {
+					if r.At.After(now) {
+						future = append(future, r)
+					}
+				}
+				reminders = future
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonOutput {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(reminders)
+			}
+			if len(reminders) == 0 {
+				fmt.Fprintln(out, "No reminders")
+				return nil
+			}
+			for _, r := range reminders {
+				when := r.At.Local().Format("2006-01-02 15:04")
+				if !r.At.After(now) {
+					when += " (past)"
+				}
+				fmt.Fprintf(out, "%s  %s", when, r.Title)
+				if r.Message != "" {
+					fmt.Fprintf(out, ": %s", r.Message)
+				}
+				fmt.Fprintf(out, "\n  %s\n", r.Path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "List reminders in all workspaces")
+	cmd.Flags().BoolVar(&pending, "pending", false, "Only reminders that are still to come")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+// newReminderCheckCmd builds `nb reminder check`, which notifies once, or
+// `nb reminder daemon`, which keeps checking until interrupted.
+func newReminderCheckCmd(svc **service.Service, workspaceOverride *string, daemon bool) *cobra.Command {
+	var (
+		all      bool
+		interval time.Duration
+	)
+
+	use, short := "check", "Notify due reminders that haven't been notified yet"
+	if daemon {
+		use, short = "daemon", "Keep checking for due reminders until interrupted"
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: short + `. Each reminder is notified
+once: notified reminders are recorded in ~/.grove/nb/reminders-state.json,
+so restarting doesn't notify again. Reminders that were already due
+before the first check are not notified.
+
+Examples:
+  nb reminder check --all
+  nb reminder daemon --all --interval 30s`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+			out := cmd.OutOrStdout()
+			check := func() error {
+				sent, err := s.CheckReminders(ctx, all, time.Now(), func(r service.NoteReminder) error {
+					message := r.Message
+					if message == "" {
+						message = r.Path
+					}
+					return service.SendDesktopNotification(r.Title, message)
+				})
+				for _, r := range sent {
+					fmt.Fprintf(out, "Reminded: %s\n", r.Title)
+				}
+				return err
+			}
+
+			if !daemon {
+				return check()
+			}
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+			stop := make(chan os.Signal, 1)
+			signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+			defer signal.Stop(stop)
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				if err := check(); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+				}
+				select {
+				case <-stop:
+					return nil
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Check reminders in all workspaces")
+	if daemon {
+		cmd.Flags().DurationVar(&interval, "interval", time.Minute, "How often to check")
+	}
+
+	return cmd
+}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -16,24 +18,62 @@ var searchUlog = grovelogging.NewUnifiedLogger("grove-notebook.cmd.search")
 
 func NewSearchCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
 	var (
-		searchAll   bool
-		searchType  string
-		searchLimit int
+		searchAll    bool
+		searchType   string
+		searchLimit  int
+		searchOutput string
+		searchIn     string
+		searchTags   []string
+		searchAny    bool
+		searchFlag   string
+		searchAnd    []string
+		searchOr     []string
+		searchNot    []string
 	)
 
 	cmd := &cobra.Command{
-		Use:   "search <query>",
+		Use:   "search [query]",
 		Short: "Search notes",
 		Long: `Search for notes matching the query.
 
+By default the full text of notes is searched. Use --in title to match only
+filenames and frontmatter titles, or --in all to match either.
+
+--tag keeps only notes with that frontmatter tag. Repeat it to require several
+tags, or add --any to accept notes with at least one of them. --flag keeps
+only notes with that flag.
+
+A query can combine terms with the upper-case operators AND, OR and NOT, which
+search once per term and combine the matching notes. AND and OR cannot be mixed
+in one query; the --and, --or and --not flags can be, and add to the query:
+every --and term must match, at least one --or term, and no --not term. Quote
+a term to keep an operator word literal.
+
 Examples:
   nb search "authentication"     # Search in current workspace
   nb search "todo" --all         # Search all workspaces
-  nb search "api" -t llm         # Search only LLM notes`,
-		Args: cobra.MinimumNArgs(1),
+  nb search "api" -t llm         # Search only LLM notes
+  nb search "todo" -o paths      # One matching path per line
+  nb search "design" --in title  # Only notes titled "design"
+  nb search panic --tag bug      # Matches tagged "bug"
+  nb search api --tag bug --tag backend --any  # Tagged "bug" or "backend"
+  nb search release --flag red                 # Flagged red
+  nb search "kubernetes AND deployment"        # Both terms
+  nb search "postgres OR mysql NOT draft"      # Either, but not "draft"
+  nb search --and kubernetes --and deployment  # Same as the first`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && len(searchAnd)+len(searchOr)+len(searchNot) == 0 {
+				return fmt.Errorf("requires a query or --and, --or or --not terms")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			s := *svc
 
+			if err := validateOutputFormat(searchOutput); err != nil {
+				return err
+			}
+
 			// Get workspace context
 			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
 			if err != nil {
@@ -49,12 +89,43 @@ Examples:
 			if searchType != "" {
 				opts = append(opts, service.OfType(models.NoteType(searchType)))
 			}
-			opts = append(opts, service.WithLimit(searchLimit))
+			if len(searchTags) > 0 {
+				opts = append(opts, service.WithTags(searchTags, searchAny))
+			}
+			if searchFlag != "" {
+				if service.FlagColor(s.Flags(), searchFlag) == "" {
+					return fmt.Errorf("unknown flag %q (expected one of %s)", searchFlag, strings.Join(service.FlagNames(s.Flags()), ", "))
+				}
+				opts = append(opts, service.WithFlag(searchFlag))
+			}
+			var unreadable int
+			opts = append(opts, service.WithLimit(searchLimit), service.SearchIn(searchIn), service.WithWarningCount(&unreadable))
 
-			results, err := s.SearchNotes(ctx, query, opts...)
+			terms, err := searchBooleanQuery(query, searchAnd, searchOr, searchNot)
 			if err != nil {
 				return err
 			}
+			var results []*models.Note
+			if terms.IsEmpty() {
+				results, err = s.SearchNotes(ctx, query, opts...)
+			} else {
+				results, err = s.SearchNotesBoolean(ctx, terms, opts...)
+			}
+			if err != nil {
+				return err
+			}
+			if unreadable > 0 {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipped %d unreadable path(s); results may be incomplete\n", unreadable)
+			}
+
+			if searchOutput != "" {
+				return FormatNoteOutput(results, searchOutput, os.Stdout)
+			}
+
+			if !styledOutput(cmd.OutOrStdout()) {
+				printSearchResultsPlain(cmd.OutOrStdout(), results)
+				return nil
+			}
 
 			if len(results) == 0 {
 				searchUlog.Info("No results found").
@@ -72,17 +143,10 @@ Examples:
 				PrettyOnly().
 				Emit()
 
+			link := hyperlinkOutput(cmd.OutOrStdout())
 			for i, note := range results {
 				var prettyStr strings.Builder
-				prettyStr.WriteString(fmt.Sprintf("%d. %s\n", i+1, note.Title))
-				prettyStr.WriteString(fmt.Sprintf("   %s", note.Path))
-				if note.Workspace != "" {
-					prettyStr.WriteString(fmt.Sprintf("\n   Workspace: %s", note.Workspace))
-					if note.Branch != "" {
-						prettyStr.WriteString(fmt.Sprintf(" (branch: %s)", note.Branch))
-					}
-				}
-				prettyStr.WriteString("\n")
+				writeSearchResult(&prettyStr, i+1, note, link)
 
 				searchUlog.Info("Search result").
 					Field("query", query).
@@ -102,7 +166,70 @@ Examples:
 
 	cmd.Flags().BoolVar(&searchAll, "all", false, "Search all workspaces")
 	cmd.Flags().StringVarP(&searchType, "type", "t", "", "Filter by note type")
+	_ = cmd.RegisterFlagCompletionFunc("type", completeNoteTypes(svc, workspaceOverride))
 	cmd.Flags().IntVar(&searchLimit, "limit", 50, "Maximum results")
+	cmd.Flags().StringVarP(&searchOutput, "output", "o", "", "Output format: paths, titles, or json")
+	cmd.Flags().StringVar(&searchIn, "in", service.SearchInBody, "Search scope: title, body, or all")
+	cmd.Flags().StringArrayVar(&searchTags, "tag", nil, "Only notes with this tag (repeatable; all must match)")
+	cmd.Flags().StringVar(&searchFlag, "flag", "", "Only notes with this flag")
+	cmd.Flags().BoolVar(&searchAny, "any", false, "With several --tag flags, match notes with any of them")
+	cmd.Flags().StringArrayVar(&searchAnd, "and", nil, "Term every result must contain (repeatable)")
+	cmd.Flags().StringArrayVar(&searchOr, "or", nil, "Term results may contain; at least one must match (repeatable)")
+	cmd.Flags().StringArrayVar(&searchNot, "not", nil, "Term results must not contain (repeatable)")
 
 	return cmd
 }
+
+// searchBooleanQuery combines the positional query with the --and, --or and
+// --not terms. A query without operators counts as one more --and term when
+// there are flag terms; with neither, the result is empty and the query is
+// searched as a literal string.
+func searchBooleanQuery(query string, and, or, not []string) (service.BooleanQuery, error) {
+	terms := service.BooleanQuery{All: and, Any: or, None: not}
+	if strings.TrimSpace(query) == "" {
+		return terms, nil
+	}
+	parsed, ok, err := service.ParseBooleanQuery(query)
+	if err != nil {
+		return service.BooleanQuery{}, err
+	}
+	if !ok {
+		if !terms.IsEmpty() {
+			terms.All = append([]string{query}, terms.All...)
+		}
+		return terms, nil
+	}
+	terms.All = append(parsed.All, terms.All...)
+	terms.Any = append(parsed.Any, terms.Any...)
+	terms.None = append(parsed.None, terms.None...)
+	return terms, nil
+}
+
+// writeSearchResult writes one numbered search hit: title, path, and the
+// workspace and branch when known. With link the path is a terminal
+// hyperlink to the file.
+func writeSearchResult(w io.Writer, n int, note *models.Note, link bool) {
+	fmt.Fprintf(w, "%d. %s\n", n, note.Title)
+	fmt.Fprintf(w, "   %s", noteLink(link, note.Path, note.Path))
+	if note.Workspace != "" {
+		fmt.Fprintf(w, "\n   Workspace: %s", note.Workspace)
+		if note.Branch != "" {
+			fmt.Fprintf(w, " (branch: %s)", note.Branch)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// printSearchResultsPlain is the unstyled counterpart of the logger-based
+// output, used when stdout is not a terminal or colors are disabled. It
+// writes straight to out without icons or escape codes.
+func printSearchResultsPlain(out io.Writer, results []*models.Note) {
+	if len(results) == 0 {
+		fmt.Fprintln(out, "No results found")
+		return
+	}
+	fmt.Fprintf(out, "Found %d results:\n\n", len(results))
+	for i, note := range results {
+		writeSearchResult(out, i+1, note, false)
+	}
+}
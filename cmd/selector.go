@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/models"
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// noteSelector picks a set of notes in the current workspace from the
+// --query, --tag and --older-than flags. Bulk commands share it so the
+// selector syntax is the same everywhere.
+type noteSelector struct {
+	query     string
+	tags      []string
+	olderThan string
+}
+
+// addFlags registers --query and --tag on cmd. --older-than is left to the
+// command, since some commands give it a meaning of its own without a query
+// or tag.
+func (sel *noteSelector) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&sel.query, "query", "", "Select notes matching this search query (same syntax as nb search, including AND, OR and NOT)")
+	cmd.Flags().StringArrayVar(&sel.tags, "tag", nil, "Select notes with this tag (repeatable; all must match)")
+}
+
+// active reports whether a query or tag was given.
+func (sel *noteSelector) active() bool {
+	return strings.TrimSpace(sel.query) != "" || len(sel.tags) > 0
+}
+
+// resolve returns the non-archived notes matching every selector, sorted by
+// path. The query is searched like nb search, in titles and bodies, with no
+// result limit. Without a query every note in the workspace is considered.
+// --older-than keeps the notes last modified before that age.
+func (sel *noteSelector) resolve(s *service.Service, ctx *service.WorkspaceContext) ([]*models.Note, error) {
+	var cutoff time.Time
+	if sel.olderThan != "" {
+		age, err := parseArchiveAge(sel.olderThan)
+		if err != nil {
+			return nil, err
+		}
+		cutoff = time.Now().Add(-age)
+	}
+
+	var (
+		notes []*models.Note
+		err   error
+	)
+	if query := strings.TrimSpace(sel.query); query != "" {
+		opts := []service.SearchOption{service.WithLimit(math.MaxInt)}
+		if len(sel.tags) > 0 {
+			opts = append(opts, service.WithTags(sel.tags, false))
+		}
+		terms, terr := searchBooleanQuery(query, nil, nil, nil)
+		if terr != nil {
+			return nil, terr
+		}
+		if terms.IsEmpty() {
+			notes, err = s.SearchNotes(ctx, query, opts...)
+		} else {
+			notes, err = s.SearchNotesBoolean(ctx, terms, opts...)
+		}
+	} else {
+		notes, err = s.ListAllNotes(ctx, false, false)
+		notes = filterNotesByTags(notes, sel.tags)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("select notes: %w", err)
+	}
+
+	var selected []*models.Note
+	for _, note := range notes {
+		if note.IsArchived {
+			continue
+		}
+		if !cutoff.IsZero() && !note.ModifiedAt.Before(cutoff) {
+			continue
+		}
+		selected = append(selected, note)
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Path < selected[j].Path })
+	return selected, nil
+}
+
+// filterNotesByTags returns the notes carrying every tag, compared
+// normalized and case-insensitively as nb search --tag does. Empty tags are
+// ignored, so a nil slice or an unset --tag filters nothing.
+func filterNotesByTags(notes []*models.Note, tags []string) []*models.Note {
+	if len(tags) == 0 {
+		return notes
+	}
+	var filtered []*models.Note
+	for _, note := range notes {
+		have := make(map[string]bool, len(note.Tags))
+		for _, t := range note.Tags {
+			have[strings.ToLower(service.NormalizeTag(t))] = true
+		}
+		all := true
+		for _, t := range tags {
+			if t = strings.ToLower(service.NormalizeTag(t)); t != "" && !have[t] {
+				all = false
+				break
+			}
+		}
+		if all {
+			filtered = append(filtered, note)
+		}
+	}
+	return filtered
+}
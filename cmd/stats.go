@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// NewStatsCmd builds `nb stats`, a summary of the current workspace notebook.
+func NewStatsCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		showSize bool
+		jsonOut  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show file counts and disk usage for the notebook",
+		Long: `Show how many files the current workspace notebook holds.
+
+With --size, also show the disk usage: total, markdown and other files
+(attachments, artifacts) separately, the largest files, and the storage used
+by each group directory, biggest first. Archives are included. Sizes come
+from file metadata; no note is read.
+
+Examples:
+  nb stats
+  nb stats --size
+  nb stats --size --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("resolving workspace context: %w", err)
+			}
+			size, err := s.GetNotebookSize(ctx)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonOut {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(size)
+			}
+			fmt.Fprintf(out, "Workspace: %s\n", ctx.NotebookContextWorkspace.Name)
+			fmt.Fprintf(out, "Files:     %d (%d markdown) in %d groups\n", size.FileCount, size.MarkdownFiles, len(size.Groups))
+			if showSize {
+				printNotebookSize(out, size)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&showSize, "size", false, "Show disk usage by file kind, largest file and group")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Emit machine-readable JSON output")
+	return cmd
+}
+
+func printNotebookSize(out io.Writer, size *service.NotebookSize) {
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Size:      %s (markdown %s, other %s)\n",
+		formatBytes(size.TotalBytes), formatBytes(size.MarkdownBytes), formatBytes(size.OtherBytes))
+
+	if len(size.Largest) > 0 {
+		fmt.Fprintln(out, "\nLargest files:")
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, f := range size.Largest {
+			fmt.Fprintf(tw, "  %s\t%s\n", formatBytes(f.Bytes), f.Path)
+		}
+		_ = tw.Flush()
+	}
+
+	if len(size.Groups) > 0 {
+		fmt.Fprintln(out, "\nBy group:")
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, g := range size.Groups {
+			fmt.Fprintf(tw, "  %s\t%s\t%d files\n", g.Group, formatBytes(g.Bytes), g.Files)
+		}
+		_ = tw.Flush()
+	}
+}
+
+// formatBytes renders n in binary units with one decimal, e.g. "1.5 MiB".
+func formatBytes(n int64) string {
+	return service.FormatBytes(n)
+}
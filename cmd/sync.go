@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -13,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	grovelogging "github.com/grovetools/core/logging"
@@ -28,18 +30,153 @@ var syncUlog = grovelogging.NewUnifiedLogger("grove-notebook.cmd.sync")
 
 // NewSyncCmd creates the `sync` subcommand.
 func NewSyncCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
-	var provider string
+	var (
+		provider      string
+		syncWorkspace string
+		direction     string
+		since         string
+		push          bool
+		incremental   bool
+		caldav        bool
+		quiet         bool
+		logFile       string
+		webhook       bool
+		webhookPort   int
+		webhookSecret string
+	)
 
 	cmd := &cobra.Command{
-		Use:   "sync",
+		Use:   "sync [note...]",
 		Short: "Sync notes with remote services",
-		Long:  `Syncs notes with configured remote services like GitHub issues and pull requests.`,
+		Long: `Syncs notes with configured remote services like GitHub issues and pull requests.
+
+By default changes flow both ways. With --direction pull, only remote items are
+fetched and local notes created or updated; with --direction push, only local
+notes, comments and edits are sent to the remote.
+
+With --since, only remote items updated after the given time are fetched. The
+time can be a date (2024-01-01), an RFC3339 timestamp, an age (7d, 2w, 36h), or
+"last" for the previous successful sync with each remote. The last-sync time is
+recorded in .nb-sync-state.json in the workspace directory.
+--incremental fetches only the items updated since the last clean pull of the
+repository, whichever workspace ran it; these watermarks are kept per
+repository in ~/.grove/nb/sync-state.json. A remote without one gets a full
+sync.
+
+--push is short for --direction push. Given notes, it pushes just those: a note
+already linked to a remote item updates it, any other note becomes a new GitHub
+issue labelled with its tags, and the issue's ID and URL are recorded in the
+note's remote frontmatter.
+
+--quiet and --log make the sync suitable for cron: --quiet prints nothing but
+errors, and --log appends one JSON line per run to a file with the time,
+workspace, direction and each remote's report. The command exits non-zero only
+when a remote can't be synced at all; per-item failures are left to the log. A
+sync holds the notebook lock (~/.grove/nb/<notebook>.lock) while it runs, and
+a quiet run that can't get it within 30 seconds skips this run.
+
+--caldav exports the workspace's daily notes to the CalDAV calendar set in
+the nb config (caldav.url, caldav.username) instead, as all-day events dated by
+each note's date or created field. The password is read from the environment
+variable named by caldav.password_env (default NB_CALDAV_PASSWORD).
+
+--webhook runs a server that receives GitHub webhook deliveries instead of
+polling. Point a repository webhook (content type application/json, issues and
+pull request events) at http://<host>:<port>/ with a secret; the secret is
+given with --secret or the NB_WEBHOOK_SECRET environment variable. Each
+delivery whose HMAC-SHA256 signature checks out pulls just the issue or pull
+request it is about. The server's PID is kept in ~/.grove/nb/webhook.pid,
+and "nb remote sync --webhook stop" stops it.
+
+Examples:
+  nb remote sync
+  nb remote sync --direction pull
+  nb remote sync --since last
+  nb remote sync --incremental
+  nb remote sync --since 2024-01-01
+  nb remote sync --workspace myproject --direction push
+  nb remote sync --push inbox/20240101-flaky-login.md
+  nb remote sync --caldav
+  nb remote sync --quiet --incremental --log ~/.local/state/nb/sync.log
+  nb remote sync --webhook --port 8765 --secret "$WEBHOOK_SECRET"
+  nb remote sync --webhook stop`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
 			s := *svc
-			wsCtx, err := s.GetWorkspaceContext(*workspaceOverride)
+
+			if (quiet || logFile != "") && (caldav || len(args) > 0) {
+				return fmt.Errorf("--quiet and --log apply to a workspace sync, not --caldav or pushing notes")
+			}
+			if quiet {
+				cmd.SilenceUsage = true
+			}
+
+			if webhook {
+				if len(args) == 1 && args[0] == "stop" {
+					return stopWebhookServer(cmd.OutOrStdout())
+				}
+				if len(args) > 0 || push || caldav || quiet || logFile != "" {
+					return fmt.Errorf("--webhook takes no notes and no other sync mode")
+				}
+				if webhookSecret == "" {
+					webhookSecret = os.Getenv(webhookSecretEnv)
+				}
+				if webhookSecret == "" {
+					return fmt.Errorf("--webhook needs a secret: pass --secret or export %s", webhookSecretEnv)
+				}
+				wsCtx, err := resolveNamedWorkspaceContext(s, syncWorkspace, *workspaceOverride)
+				if err != nil {
+					return err
+				}
+				syncer := sync.NewSyncer(s)
+				syncer.RegisterProvider("github", func() sync.Provider {
+					return github.NewProvider()
+				})
+				syncUlog.Info("Webhook server started").
+					Field("port", webhookPort).
+					Pretty(fmt.Sprintf("Listening for GitHub webhooks on port %d (stop with: nb remote sync --webhook stop)", webhookPort)).
+					PrettyOnly().
+					Log(ctx)
+				return syncer.StartWebhookServer(wsCtx, webhookPort, webhookSecret)
+			}
+			if cmd.Flags().Changed("port") || cmd.Flags().Changed("secret") {
+				return fmt.Errorf("--port and --secret require --webhook")
+			}
+
+			if caldav {
+				if len(args) > 0 || push {
+					return fmt.Errorf("--caldav exports daily notes and takes no notes or --push")
+				}
+				wsCtx, err := resolveNamedWorkspaceContext(s, syncWorkspace, *workspaceOverride)
+				if err != nil {
+					return err
+				}
+				return exportCalDAV(ctx, s, wsCtx)
+			}
+
+			if push {
+				if cmd.Flags().Changed("direction") && direction != string(sync.DirectionPush) {
+					return fmt.Errorf("--push conflicts with --direction %s", direction)
+				}
+				direction = string(sync.DirectionPush)
+			}
+			if len(args) > 0 && !push {
+				return fmt.Errorf("syncing individual notes requires --push")
+			}
+			syncDirection, err := sync.ParseSyncDirection(direction)
+			if err != nil {
+				return err
+			}
+			if incremental && since != "" {
+				return fmt.Errorf("--incremental conflicts with --since")
+			}
+			sinceTime, sinceLast, err := parseSyncSince(since, time.Now())
 			if err != nil {
-				return fmt.Errorf("get workspace context: %w", err)
+				return err
+			}
+			wsCtx, err := resolveNamedWorkspaceContext(s, syncWorkspace, *workspaceOverride)
+			if err != nil {
+				return err
 			}
 
 			// Create syncer and register providers
@@ -48,50 +185,70 @@ func NewSyncCmd(svc **service.Service, workspaceOverride *string) *cobra.Command
 				return github.NewProvider()
 			})
 
+			if len(args) > 0 {
+				return pushNotes(ctx, s, syncer, wsCtx, *workspaceOverride, args)
+			}
+
 			// Run sync
-			reports, err := syncer.SyncWorkspace(wsCtx)
+			reports, err := syncer.SyncWorkspace(wsCtx, sync.SyncOptions{
+				Direction:     syncDirection,
+				Provider:      provider,
+				Since:         sinceTime,
+				SinceLastSync: sinceLast,
+				Incremental:   incremental,
+			})
+			if logFile != "" {
+				if lerr := appendSyncLog(logFile, newSyncLogEntry(wsCtx, syncDirection, reports, err)); lerr != nil {
+					return lerr
+				}
+			}
+			if quiet && errors.Is(err, sync.ErrSyncLocked) {
+				return nil
+			}
 			if err != nil {
 				return err
 			}
+			if !quiet {
+				displaySyncReports(ctx, reports, sinceLast || incremental)
+			}
 
-			// Display results
+			notifyDaemonRefreshCmd()
+
+			var failed []string
 			for _, report := range reports {
-				syncUlog.Success("Sync complete").
-					Field("provider", report.Provider).
-					Field("created", report.Created).
-					Field("updated", report.Updated).
-					Field("unchanged", report.Unchanged).
-					Field("failed", report.Failed).
-					Pretty(fmt.Sprintf("Synced with %s: %d created, %d updated, %d unchanged, %d failed.",
-						report.Provider, report.Created, report.Updated, report.Unchanged, report.Failed)).
-					PrettyOnly().
-					Log(ctx)
-				// Show error details if there were any failures
-				if len(report.Errors) > 0 {
-					syncUlog.Error("Sync errors encountered").
-						Field("provider", report.Provider).
-						Field("error_count", len(report.Errors)).
-						Pretty("Errors:").
-						PrettyOnly().
-						Log(ctx)
-					for _, errMsg := range report.Errors {
-						syncUlog.Error("Sync error").
-							Field("provider", report.Provider).
-							Field("error", errMsg).
-							Pretty(fmt.Sprintf("  - %s", errMsg)).
-							PrettyOnly().
-							Log(ctx)
-					}
+				if report.Error != "" {
+					failed = append(failed, report.Provider)
 				}
 			}
+			if len(failed) > 0 {
+				return fmt.Errorf("sync failed for %s", strings.Join(failed, ", "))
+			}
 
-			notifyDaemonRefreshCmd()
-
+			if s.Config != nil && s.Config.Hooks.PostSyncGit && os.Getenv(service.HookEnvVar) == "" {
+				result, err := s.CommitAndPushNotebook(wsCtx, "", false)
+				if !quiet {
+					printGitSyncResult(cmd.OutOrStdout(), result, err)
+				}
+				if err != nil {
+					return fmt.Errorf("post_sync_git: %w", err)
+				}
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&provider, "provider", "", "Sync only with a specific provider (e.g., github)")
+	cmd.Flags().StringVar(&syncWorkspace, "workspace", "", "Name of the workspace to sync (defaults to the current workspace)")
+	cmd.Flags().StringVar(&direction, "direction", "both", "Sync direction: pull, push, or both")
+	cmd.Flags().BoolVar(&push, "push", false, "Only push local changes (same as --direction push); with notes, push just those")
+	cmd.Flags().StringVar(&since, "since", "", "Only fetch remote items updated after this time (date, RFC3339, age like 7d, or \"last\")")
+	cmd.Flags().BoolVar(&incremental, "incremental", false, "Only fetch remote items updated since the repository's last successful sync")
+	cmd.Flags().BoolVar(&caldav, "caldav", false, "Export daily notes to the configured CalDAV calendar")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Print nothing but errors (for cron)")
+	cmd.Flags().StringVar(&logFile, "log", "", "Append a JSON line describing the run to this file")
+	cmd.Flags().BoolVar(&webhook, "webhook", false, "Run a server that syncs GitHub issues and pull requests as webhooks arrive (\"--webhook stop\" stops it)")
+	cmd.Flags().IntVar(&webhookPort, "port", 8765, "Port the --webhook server listens on")
+	cmd.Flags().StringVar(&webhookSecret, "secret", "", "Secret of the GitHub webhook (defaults to $"+webhookSecretEnv+")")
 
 	// Add subcommands for Notebook Sync Phase 2 (daemon-coordinated)
 	cmd.AddCommand(NewSyncHistoryCmd(svc, workspaceOverride))
@@ -103,6 +260,248 @@ func NewSyncCmd(svc **service.Service, workspaceOverride *string) *cobra.Command
 	return cmd
 }
 
+// webhookSecretEnv is the environment variable --webhook reads its secret
+// from when --secret is not given.
+const webhookSecretEnv = "NB_WEBHOOK_SECRET"
+
+// stopWebhookServer terminates the webhook server recorded in the PID file.
+func stopWebhookServer(out io.Writer) error {
+	pid, err := sync.ReadWebhookPID()
+	if err != nil {
+		return err
+	}
+	if pid == 0 {
+		fmt.Fprintln(out, "No webhook server is running")
+		return nil
+	}
+	proc, err := os.FindProcess(pid)
+	if err == nil {
+		err = proc.Signal(syscall.SIGTERM)
+	}
+	if err != nil {
+		if errors.Is(err, os.ErrProcessDone) {
+			// The server died without cleaning up after itself.
+			os.Remove(sync.WebhookPIDPath())
+			fmt.Fprintln(out, "No webhook server is running")
+			return nil
+		}
+		return fmt.Errorf("stop webhook server (pid %d): %w", pid, err)
+	}
+	fmt.Fprintf(out, "Stopped webhook server (pid %d)\n", pid)
+	return nil
+}
+
+// displaySyncReports prints a summary of each remote's sync.
+func displaySyncReports(ctx context.Context, reports []*sync.Report, sinceLast bool) {
+	for _, report := range reports {
+		if report.Error != "" {
+			syncUlog.Error("Sync failed").
+				Field("provider", report.Provider).
+				Field("error", report.Error).
+				Pretty(fmt.Sprintf("Sync with %s failed: %s", report.Provider, report.Error)).
+				PrettyOnly().
+				Log(ctx)
+			continue
+		}
+		if sinceLast && report.Since.IsZero() {
+			syncUlog.Info("No previous sync, running a full sync").
+				Field("provider", report.Provider).
+				Pretty(fmt.Sprintf("No previous sync with %s; ran a full sync.", report.Provider)).
+				PrettyOnly().
+				Log(ctx)
+		}
+		if !report.Since.IsZero() {
+			syncUlog.Info("Incremental sync").
+				Field("provider", report.Provider).
+				Field("since", report.Since).
+				Field("fetched", report.Fetched).
+				Pretty(fmt.Sprintf("Fetched %d %s item(s) updated since %s.",
+					report.Fetched, report.Provider, report.Since.Local().Format("2006-01-02 15:04"))).
+				PrettyOnly().
+				Log(ctx)
+		}
+		syncUlog.Success("Sync complete").
+			Field("provider", report.Provider).
+			Field("created", report.Created).
+			Field("updated", report.Updated).
+			Field("unchanged", report.Unchanged).
+			Field("failed", report.Failed).
+			Pretty(fmt.Sprintf("Synced with %s: %d created, %d updated, %d unchanged, %d failed.",
+				report.Provider, report.Created, report.Updated, report.Unchanged, report.Failed)).
+			PrettyOnly().
+			Log(ctx)
+		// Show error details if there were any failures
+		if len(report.Errors) > 0 {
+			syncUlog.Error("Sync errors encountered").
+				Field("provider", report.Provider).
+				Field("error_count", len(report.Errors)).
+				Pretty("Errors:").
+				PrettyOnly().
+				Log(ctx)
+			for _, errMsg := range report.Errors {
+				syncUlog.Error("Sync error").
+					Field("provider", report.Provider).
+					Field("error", errMsg).
+					Pretty(fmt.Sprintf("  - %s", errMsg)).
+					PrettyOnly().
+					Log(ctx)
+			}
+		}
+	}
+}
+
+// syncLogEntry is one line of the --log file.
+type syncLogEntry struct {
+	Time      time.Time      `json:"time"`
+	Workspace string         `json:"workspace"`
+	Direction string         `json:"direction"`
+	Reports   []*sync.Report `json:"reports"`
+	// Skipped is set when another sync held the lock.
+	Skipped bool   `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+func newSyncLogEntry(wsCtx *service.WorkspaceContext, direction sync.SyncDirection, reports []*sync.Report, err error) syncLogEntry {
+	entry := syncLogEntry{
+		Time:      time.Now().UTC(),
+		Workspace: wsCtx.CurrentWorkspace.Name,
+		Direction: string(direction),
+		Reports:   reports,
+	}
+	if entry.Reports == nil {
+		entry.Reports = []*sync.Report{}
+	}
+	if errors.Is(err, sync.ErrSyncLocked) {
+		entry.Skipped = true
+	} else if err != nil {
+		entry.Error = err.Error()
+	}
+	return entry
+}
+
+// appendSyncLog appends entry to the file at path as a JSON line.
+func appendSyncLog(path string, entry syncLogEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode sync log entry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create sync log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open sync log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("write sync log: %w", err)
+	}
+	return nil
+}
+
+// pushNotes pushes the notes named by args to the remote one by one, creating
+// an issue for each note not yet linked to one.
+func pushNotes(ctx context.Context, s *service.Service, syncer *sync.Syncer, wsCtx *service.WorkspaceContext, workspaceOverride string, args []string) error {
+	paths, err := resolveNotePaths(s, workspaceOverride, args)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		note, err := service.ParseNote(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		created := note.Remote == nil || note.Remote.ID == ""
+		if err := syncer.SyncNoteToRemote(wsCtx, note); err != nil {
+			return fmt.Errorf("push %s: %w", filepath.Base(path), err)
+		}
+		pushed, err := service.ParseNote(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		var url string
+		if pushed.Remote != nil {
+			url = pushed.Remote.URL
+		}
+		verb := "Updated"
+		if created {
+			verb = "Created"
+		}
+		syncUlog.Success("Pushed note").
+			Field("path", path).
+			Field("url", url).
+			Field("created", created).
+			Pretty(fmt.Sprintf("%s %s for %s", verb, url, filepath.Base(path))).
+			PrettyOnly().
+			Log(ctx)
+	}
+	notifyDaemonRefreshCmd()
+	return nil
+}
+
+// exportCalDAV exports wsCtx's daily notes to the CalDAV calendar in the nb
+// config.
+func exportCalDAV(ctx context.Context, s *service.Service, wsCtx *service.WorkspaceContext) error {
+	cfg := s.Config.CalDAV
+	passwordEnv := cfg.PasswordEnv
+	if passwordEnv == "" {
+		passwordEnv = service.DefaultCalDAVPasswordEnv
+	}
+	password := os.Getenv(passwordEnv)
+	if cfg.Username != "" && password == "" {
+		return fmt.Errorf("CalDAV password not set: export it as %s", passwordEnv)
+	}
+
+	report, err := sync.NewSyncer(s).ExportToCalDAV(wsCtx, cfg.URL, cfg.Username, password)
+	if err != nil {
+		return err
+	}
+	syncUlog.Success("CalDAV export complete").
+		Field("created", report.Created).
+		Field("updated", report.Updated).
+		Field("skipped", report.Skipped).
+		Field("failed", report.Failed).
+		Pretty(fmt.Sprintf("Exported daily notes to CalDAV: %d created, %d updated, %d skipped (no date), %d failed.",
+			report.Created, report.Updated, report.Skipped, report.Failed)).
+		PrettyOnly().
+		Log(ctx)
+	for _, errMsg := range report.Errors {
+		syncUlog.Error("CalDAV export error").
+			Field("error", errMsg).
+			Pretty(fmt.Sprintf("  - %s", errMsg)).
+			PrettyOnly().
+			Log(ctx)
+	}
+	if report.Failed > 0 {
+		return fmt.Errorf("%d daily note(s) failed to export", report.Failed)
+	}
+	return nil
+}
+
+// parseSyncSince parses the --since flag. "last" means the previous sync with
+// each remote; otherwise the value is an RFC3339 timestamp, a YYYY-MM-DD date
+// (local midnight), or an age counted back from now.
+func parseSyncSince(value string, now time.Time) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "":
+		return time.Time{}, false, nil
+	case "last":
+		return time.Time{}, true, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, false, nil
+	}
+	age, err := parseArchiveAge(value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid --since %q: use a date (2024-01-01), an RFC3339 time, an age (7d, 2w, 36h), or \"last\"", value)
+	}
+	return now.Add(-age), false, nil
+}
+
 // NewSyncHistoryCmd creates the `sync history` subcommand.
 // Displays the version history for a document from the sync server.
 func NewSyncHistoryCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
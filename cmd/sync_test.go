@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/service"
+	"github.com/grovetools/nb/pkg/sync"
+)
+
+func TestParseSyncSince(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	got, last, err := parseSyncSince("", now)
+	require.NoError(t, err)
+	assert.True(t, got.IsZero())
+	assert.False(t, last)
+
+	_, last, err = parseSyncSince("last", now)
+	require.NoError(t, err)
+	assert.True(t, last)
+
+	got, _, err = parseSyncSince("2024-03-01T08:30:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC), got)
+
+	got, _, err = parseSyncSince("2024-03-01", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), got)
+
+	got, _, err = parseSyncSince("7d", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-7*24*time.Hour), got)
+
+	_, _, err = parseSyncSince("yesterday", now)
+	assert.Error(t, err)
+}
+
+func TestAppendSyncLog(t *testing.T) {
+	ws := &coreworkspace.WorkspaceNode{Name: "proj", Path: t.TempDir()}
+	wsCtx := &service.WorkspaceContext{NotebookContextWorkspace: ws, CurrentWorkspace: ws}
+	path := filepath.Join(t.TempDir(), "logs", "sync.log")
+
+	reports := []*sync.Report{{Provider: "github", Created: 2, Fetched: 5}}
+	require.NoError(t, appendSyncLog(path, newSyncLogEntry(wsCtx, sync.DirectionPull, reports, nil)))
+	require.NoError(t, appendSyncLog(path, newSyncLogEntry(wsCtx, sync.DirectionBoth, nil, sync.ErrSyncLocked)))
+	require.NoError(t, appendSyncLog(path, newSyncLogEntry(wsCtx, sync.DirectionBoth, nil, errors.New("gh: not logged in"))))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var entries []syncLogEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry syncLogEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry), "each line is one JSON entry")
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "proj", entries[0].Workspace)
+	assert.Equal(t, "pull", entries[0].Direction)
+	require.Len(t, entries[0].Reports, 1)
+	assert.Equal(t, 2, entries[0].Reports[0].Created)
+	assert.Empty(t, entries[0].Error)
+
+	assert.True(t, entries[1].Skipped, "a locked notebook skips the run")
+	assert.Empty(t, entries[1].Error)
+	assert.NotNil(t, entries[1].Reports)
+
+	assert.False(t, entries[2].Skipped)
+	assert.Equal(t, "gh: not logged in", entries[2].Error)
+}
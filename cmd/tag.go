@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/index"
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewTagCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Add or remove a tag on several notes",
+	}
+
+	cmd.AddCommand(newTagEditCmd(svc, workspaceOverride, "add"))
+	cmd.AddCommand(newTagEditCmd(svc, workspaceOverride, "remove"))
+
+	return cmd
+}
+
+// newTagEditCmd builds `nb tag add` or `nb tag remove`, which differ only in
+// the service call and wording.
+func newTagEditCmd(svc **service.Service, workspaceOverride *string, action string) *cobra.Command {
+	short, aliases := "Add a tag to notes", []string(nil)
+	if action == "remove" {
+		short, aliases = "Remove a tag from notes", []string{"rm"}
+	}
+
+	cmd := &cobra.Command{
+		Use:     action + " <tag> <note>...",
+		Aliases: aliases,
+		Short:   short,
+		Long: short + `' frontmatter. Other frontmatter fields are kept, and
+notes that already have (or lack) the tag are not rewritten. A leading '#'
+on the tag is ignored.
+
+Notes may be given as file paths, or as a filename stem, frontmatter id,
+alias or title of a note in the current workspace.
+
+Examples:
+  nb tag ` + action + ` idea inbox/20240101-a.md inbox/20240102-b.md
+  nb tag ` + action + ` "#review" my-note other-note`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			paths, err := resolveNotePaths(s, *workspaceOverride, args[1:])
+			if err != nil {
+				return err
+			}
+
+			edit := s.AddTag
+			if action == "remove" {
+				edit = s.RemoveTag
+			}
+			changed, err := edit(paths, args[0])
+			if err != nil {
+				return err
+			}
+
+			tag := service.NormalizeTag(args[0])
+			if action == "remove" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Removed #%s from %d of %d notes\n", tag, len(changed), len(paths))
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "Added #%s to %d of %d notes\n", tag, len(changed), len(paths))
+			}
+			return nil
+		},
+	}
+	cmd.ValidArgsFunction = func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return completeTags(svc, workspaceOverride)(c, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+
+	return cmd
+}
+
+// resolveNotePaths maps note arguments to file paths. Existing files are used
+// as-is; anything else is looked up in the vault index, which is only built
+// when needed.
+func resolveNotePaths(s *service.Service, workspaceOverride string, args []string) ([]string, error) {
+	var ix *index.Index
+	paths := make([]string, 0, len(args))
+	for _, arg := range args {
+		if info, err := os.Stat(arg); err == nil && !info.IsDir() {
+			abs, err := filepath.Abs(arg)
+			if err != nil {
+				return nil, fmt.Errorf("resolve %s: %w", arg, err)
+			}
+			paths = append(paths, abs)
+			continue
+		}
+		if ix == nil {
+			built, err := buildVaultIndex(s, workspaceOverride)
+			if err != nil {
+				return nil, err
+			}
+			ix = built
+		}
+		doc, err := resolveNoteArg(ix, arg)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, doc.Path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewTemplateCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Inspect note templates",
+		Long: `Inspect which templates new notes are created from.
+
+Examples:
+  nb template resolve research/spikes`,
+	}
+
+	cmd.AddCommand(newTemplateResolveCmd(svc, workspaceOverride))
+
+	return cmd
+}
+
+func newTemplateResolveCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "resolve <group>",
+		Short: "Show which template a new note in a group would use",
+		Long: `Show which template a note created in the group would start from. The most
+specific match wins: a group_templates entry in the [nb] config for the group,
+then for each parent group (research/spikes, then research), then the note
+type's template, then nb's built-in content.
+
+Examples:
+  nb template resolve research/spikes
+  nb template resolve inbox --json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+			res, err := s.ResolveTemplate(ctx, args[0])
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonOutput {
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(res)
+			}
+			switch res.Source {
+			case service.TemplateSourceGroup:
+				fmt.Fprintf(out, "%s: group template for %s\n  %s\n", res.Group, res.Key, res.Path)
+				if _, err := os.Stat(res.Path); err != nil {
+					fmt.Fprintln(out, "  (file not found; notes fall back to the type template)")
+				}
+			case service.TemplateSourceType:
+				if res.Path != "" {
+					fmt.Fprintf(out, "%s: note type template\n  %s\n", res.Group, res.Path)
+				} else {
+					fmt.Fprintf(out, "%s: note type template (inline)\n", res.Group)
+				}
+			default:
+				fmt.Fprintf(out, "%s: built-in default content\n", res.Group)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+
+	return cmd
+}
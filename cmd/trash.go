@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func NewTrashCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trash <note>...",
+		Short: "Move notes to the trash, or list and restore trashed notes",
+		Long: `Move notes to the trash instead of deleting them. Trashed notes are kept
+in nb/trash under the Grove data directory, with a timestamp prefix, and can be
+put back with 'nb trash restore'. Notes trashed more than 30 days ago are
+purged automatically.
+
+Notes may be given as file paths, or as a filename stem, frontmatter id,
+alias or title of a note in the current workspace.
+
+Examples:
+  nb trash inbox/20240101-old-idea.md
+  nb trash list
+  nb trash restore 20240101-old-idea.md`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			paths, err := resolveNotePaths(s, *workspaceOverride, args)
+			if err != nil {
+				return err
+			}
+			for _, path := range paths {
+				if err := s.TrashNote(path); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Trashed %s\n", path)
+			}
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newTrashListCmd(svc))
+	cmd.AddCommand(newTrashRestoreCmd(svc))
+
+	return cmd
+}
+
+func newTrashListCmd(svc **service.Service) *cobra.Command {
+	var jsonOut bool
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List trashed notes, most recent first",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			entries, err := s.ListTrash()
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if jsonOut {
+				if entries == nil {
+					entries = []service.TrashEntry{}
+				}
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(entries)
+			}
+			if len(entries) == 0 {
+				fmt.Fprintln(out, "Trash is empty")
+				return nil
+			}
+			tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(tw, "TRASHED\tNAME\tORIGINAL PATH")
+			for _, entry := range entries {
+				fmt.Fprintf(tw, "%s\t%s\t%s\n",
+					entry.TrashedAt.Local().Format("2006-01-02 15:04"), filepath.Base(entry.Path), entry.OriginalPath)
+			}
+			return tw.Flush()
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Emit machine-readable JSON output")
+	return cmd
+}
+
+func newTrashRestoreCmd(svc **service.Service) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore <path>",
+		Short: "Move a trashed note back to where it was trashed from",
+		Long: `Move a trashed note back to its original location. The note may be named by
+its path or file name in the trash (as shown by 'nb trash list'), or by its
+original path, in which case the most recently trashed copy is restored. A
+file already at the original location is never overwritten.
+
+Examples:
+  nb trash restore 20261016T120000.000000000-20240101-old-idea.md
+  nb trash restore ~/notebooks/workspaces/proj/inbox/20240101-old-idea.md`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			restored, err := s.RestoreTrashEntry(args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Restored %s\n", restored)
+			return nil
+		},
+	}
+	cmd.ValidArgsFunction = func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		entries, err := (*svc).ListTrash()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, filepath.Base(entry.Path)+"\t"+entry.OriginalPath)
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+	return cmd
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/service"
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+func NewTreeCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		treeAll       bool
+		treeArchived  bool
+		treeArtifacts bool
+		treeDepth     int
+		treeJSON      bool
+		treeUnfiled   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tree",
+		Short: "Show the notebook as a workspace/group/note tree",
+		Long: `Print the notebook hierarchy: workspaces, their groups (inbox, plans/<name>,
+.archive, ...) and the notes inside them. Groups show how many files they
+contain. It lists the same notes as the TUI browser, but the browser groups
+them itself (ecosystems, the Today and ungrouped sections, folding), so the two
+layouts can differ.
+
+Examples:
+  nb tree                    # Current workspace
+  nb tree --all --depth 2    # Every workspace, groups only
+  nb tree --archived         # Include .archive and .closed notes
+  nb tree --unfiled          # Include stray notes in the notebook root
+  nb tree --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+			if treeUnfiled {
+				s.Config.ShowUnfiled = true
+			}
+
+			roots, err := s.BuildTree(ctx, service.TreeOptions{
+				AllWorkspaces:    treeAll,
+				IncludeArchived:  treeArchived,
+				IncludeArtifacts: treeArtifacts,
+			})
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if treeJSON {
+				nodes := make([]treeNodeJSON, 0, len(roots))
+				for _, root := range roots {
+					nodes = append(nodes, toTreeNodeJSON(root, treeDepth, 0))
+				}
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				return enc.Encode(nodes)
+			}
+			if len(roots) == 0 {
+				fmt.Fprintln(out, "No notes found")
+				return nil
+			}
+			for _, root := range roots {
+				printTree(out, root, treeDepth)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&treeAll, "all", "a", false, "Show every workspace, not just the current one")
+	cmd.Flags().BoolVar(&treeArchived, "archived", false, "Include archived and closed notes")
+	cmd.Flags().BoolVar(&treeArtifacts, "artifacts", false, "Include plan artifacts")
+	cmd.Flags().BoolVar(&treeUnfiled, "unfiled", false, "Include Markdown files loose in the notebook root, under \"unfiled\"")
+	cmd.Flags().IntVarP(&treeDepth, "depth", "d", 0, "Maximum depth below each workspace (0 for unlimited)")
+	cmd.Flags().BoolVar(&treeJSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+// treeNodeJSON is the JSON shape of a tree node; tree.Item itself can't be
+// encoded because of its Parent back-pointer.
+type treeNodeJSON struct {
+	Name     string         `json:"name"`
+	Path     string         `json:"path"`
+	Type     tree.ItemType  `json:"type"`
+	Title    string         `json:"title,omitempty"`
+	Children []treeNodeJSON `json:"children,omitempty"`
+}
+
+func toTreeNodeJSON(item *tree.Item, maxDepth, depth int) treeNodeJSON {
+	node := treeNodeJSON{Name: item.Name, Path: item.Path, Type: item.Type}
+	if !item.IsDir {
+		node.Title, _ = item.Metadata["Title"].(string)
+	}
+	if maxDepth > 0 && depth >= maxDepth {
+		return node
+	}
+	for _, child := range item.Children {
+		node.Children = append(node.Children, toTreeNodeJSON(child, maxDepth, depth+1))
+	}
+	return node
+}
+
+// printTree writes root and its descendants with box-drawing connectors.
+func printTree(w io.Writer, root *tree.Item, maxDepth int) {
+	fmt.Fprintf(w, "%s (%d)\n", root.Name, countTreeFiles(root))
+	printTreeChildren(w, root, "", maxDepth, 1)
+}
+
+func printTreeChildren(w io.Writer, item *tree.Item, indent string, maxDepth, depth int) {
+	if maxDepth > 0 && depth > maxDepth {
+		return
+	}
+	for i, child := range item.Children {
+		connector, childIndent := "├── ", indent+"│   "
+		if i == len(item.Children)-1 {
+			connector, childIndent = "└── ", indent+"    "
+		}
+		label := child.Name
+		if child.IsDir {
+			label = fmt.Sprintf("%s (%d)", child.Name, countTreeFiles(child))
+		}
+		fmt.Fprintf(w, "%s%s%s\n", indent, connector, label)
+		printTreeChildren(w, child, childIndent, maxDepth, depth+1)
+	}
+}
+
+func countTreeFiles(item *tree.Item) int {
+	count := 0
+	tree.Walk(item, func(node *tree.Item, _ int) bool {
+		if !node.IsDir {
+			count++
+		}
+		return true
+	})
+	return count
+}
+
+// printWorkspaceTree writes each workspace of t with its groups, nested, and
+// the titles of the notes in them, using the same connectors as printTree.
+func printWorkspaceTree(w io.Writer, t *service.WorkspaceTree) {
+	for _, ws := range t.Workspaces {
+		count := 0
+		for _, key := range ws.Groups {
+			count += t.NoteCount(key)
+		}
+		fmt.Fprintf(w, "%s (%d)\n", ws.Name, count)
+		for i, key := range ws.Groups {
+			printWorkspaceTreeGroup(w, t, key, "", i == len(ws.Groups)-1)
+		}
+	}
+}
+
+// printWorkspaceTreeGroup writes the group at key, then its nested groups
+// and its notes one level further in.
+func printWorkspaceTreeGroup(w io.Writer, t *service.WorkspaceTree, key, indent string, last bool) {
+	connector, childIndent := "├── ", indent+"│   "
+	if last {
+		connector, childIndent = "└── ", indent+"    "
+	}
+	g := t.Groups[key]
+	fmt.Fprintf(w, "%s%s%s (%d)\n", indent, connector, g.Name, t.NoteCount(key))
+
+	notes := t.Notes[key]
+	entries := len(g.Children) + len(notes)
+	for i, child := range g.Children {
+		printWorkspaceTreeGroup(w, t, child, childIndent, i == entries-1)
+	}
+	for i, note := range notes {
+		noteConnector := "├── "
+		if len(g.Children)+i == entries-1 {
+			noteConnector = "└── "
+		}
+		title := note.Title
+		if title == "" {
+			title = filepath.Base(note.Path)
+		}
+		fmt.Fprintf(w, "%s%s%s\n", childIndent, noteConnector, title)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,23 +18,60 @@ import (
 
 	"github.com/grovetools/nb/pkg/service"
 	"github.com/grovetools/nb/pkg/tui/browser"
+	"github.com/grovetools/nb/pkg/tui/browser/views"
 )
 
 // NewTuiCmd creates the `nb tui` command.
 func NewTuiCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		focus      string
+		noMouse    bool
+		tool       string
+		width      int
+		height     int
+		splitRatio int
+		unfiled    bool
+		sortFlag   string
+	)
+
 	cmd := &cobra.Command{
 		Use:   "tui",
 		Short: "Launch an interactive TUI for browsing notes across workspaces",
 		Long: `Launch an interactive Terminal User Interface for browsing and managing notes.
-This view provides a workspace-centric way to explore your entire notebook.`,
+This view provides a workspace-centric way to explore your entire notebook.
+
+Examples:
+  nb tui                       # Focus the current directory's workspace, if any
+  nb tui --focus myproject     # Start focused on a workspace by name
+  nb tui --focus .             # Start focused on the current directory's workspace
+  nb tui --no-mouse            # Leave the mouse to the terminal (native text selection)
+  nb tui --tool "feh -F"       # Open images and other non-markdown files with feh
+  nb tui --width 100 --height 30 # Lay out for a small pane before the first resize
+  nb tui --split-ratio 40      # Give the preview/editor split 60% of the width
+  nb tui --unfiled             # Also list stray notes in the notebook root
+  nb tui --sort modified       # Most recently modified notes first
+  nb tui --sort title:desc     # Titles from Z to A`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// Check for TTY
-			if !isatty.IsTerminal(os.Stdout.Fd()) && !isatty.IsCygwinTerminal(os.Stdout.Fd()) {
-				return fmt.Errorf("TUI mode requires an interactive terminal")
-			}
-
 			s := *svc
 
+			if width < 0 || height < 0 {
+				return fmt.Errorf("--width and --height must not be negative")
+			}
+			if splitRatio != 0 && (splitRatio < 10 || splitRatio > 90) {
+				return fmt.Errorf("--split-ratio must be between 10 and 90, got %d", splitRatio)
+			}
+			var sortConfig views.SortConfig
+			if sortFlag != "" {
+				parsed, err := views.ParseSortConfig(sortFlag)
+				if err != nil {
+					return err
+				}
+				sortConfig = parsed
+			}
+			if unfiled {
+				s.Config.ShowUnfiled = true
+			}
+
 			// Get current workspace context to determine initial focus
 			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
 			if err != nil {
@@ -41,25 +79,18 @@ This view provides a workspace-centric way to explore your entire notebook.`,
 			}
 
 			var initialFocus *workspace.WorkspaceNode
-			if ctx.NotebookContextWorkspace.Name != "global" {
-				rawInitialFocus := ctx.NotebookContextWorkspace
-				// The workspace from GetWorkspaceContext might be a "raw" discovery.
-				// We need to find the canonical instance from the provider to ensure
-				// all metadata (like its Kind) is fully resolved.
-				provider := s.GetWorkspaceProvider()
-				foundInProvider := false
-				for _, ws := range provider.All() {
-					isSame, _ := pathutil.ComparePaths(ws.Path, rawInitialFocus.Path)
-					if isSame {
-						initialFocus = ws
-						foundInProvider = true
-						break
-					}
-				}
-				if !foundInProvider {
-					// Fallback if not found, though this is unlikely.
-					initialFocus = rawInitialFocus
+			if focus != "" {
+				ctx, initialFocus, err = resolveTuiFocus(s, focus)
+				if err != nil {
+					return err
 				}
+			} else if ctx.NotebookContextWorkspace.Name != "global" {
+				initialFocus = canonicalWorkspaceNode(s, ctx.NotebookContextWorkspace)
+			}
+
+			// Check for TTY
+			if !isatty.IsTerminal(os.Stdout.Fd()) && !isatty.IsCygwinTerminal(os.Stdout.Fd()) {
+				return fmt.Errorf("TUI mode requires an interactive terminal")
 			}
 
 			// Create the pure browser model and wrap it in the CLI environment
@@ -69,14 +100,24 @@ This view provides a workspace-centric way to explore your entire notebook.`,
 				Service:      s,
 				InitialFocus: initialFocus,
 				Context:      ctx,
+				OpenTool:     tool,
+				Width:        width,
+				Height:       height,
+				SplitRatio:   splitRatio,
+				Sort:         sortConfig,
 			})
-			host := &cliEnvironmentHost{model: browserModel}
+			host := &cliEnvironmentHost{model: browserModel, editor: s.EditorCommand()}
 
-			// Wrap in StandaloneHost (handles DoneMsg, CloseRequestMsg,
-			// EditRequestMsg) then compositor (GPU-accelerated rendering).
+			// Wrap in StandaloneHost (handles DoneMsg and CloseRequestMsg)
+			// then compositor (GPU-accelerated rendering).
 			standaloneHost := embed.NewStandaloneHost(host)
 			compModel := compositor.NewModel(standaloneHost)
-			p := tea.NewProgram(compModel, tea.WithAltScreen())
+			opts := []tea.ProgramOption{tea.WithAltScreen()}
+			if !noMouse {
+				// Click to move the cursor (double click opens), wheel to scroll.
+				opts = append(opts, tea.WithMouseCellMotion())
+			}
+			p := tea.NewProgram(compModel, opts...)
 
 			finalModel, runErr := p.Run()
 
@@ -98,9 +139,60 @@ This view provides a workspace-centric way to explore your entire notebook.`,
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVar(&focus, "focus", "", "Start focused on the named workspace ('.' for the current directory's workspace)")
+	cmd.Flags().BoolVar(&noMouse, "no-mouse", false, "Disable mouse capture (click and wheel navigation)")
+	cmd.Flags().StringVar(&tool, "tool", "", "Command for opening non-markdown files (default: system image/PDF viewer or xdg-open/open)")
+	cmd.Flags().IntVar(&width, "width", 0, "Initial width in columns, used until the terminal reports its size")
+	cmd.Flags().IntVar(&height, "height", 0, "Initial height in rows, used until the terminal reports its size")
+	cmd.Flags().IntVar(&splitRatio, "split-ratio", 0, "Percent of the width kept by the tree when a preview or editor is split beside it, 10-90 (default: last used, else automatic)")
+	cmd.Flags().StringVar(&sortFlag, "sort", "", "Initial note order: created, modified, title or workspace, optionally with :asc or :desc (default: last used, else created:desc)")
+	cmd.Flags().BoolVar(&unfiled, "unfiled", false, "Also list Markdown files loose in the notebook root, under an \"unfiled\" group")
+
 	return cmd
 }
 
+// resolveTuiFocus maps a --focus value to the workspace context and canonical
+// workspace node the browser should start on. "." uses the workspace of the
+// current directory, anything else must match a discovered workspace name.
+func resolveTuiFocus(s *service.Service, focus string) (*service.WorkspaceContext, *workspace.WorkspaceNode, error) {
+	if focus == "." {
+		ctx, err := s.GetWorkspaceContext("")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get workspace context: %w", err)
+		}
+		if ctx.NotebookContextWorkspace.Name == "global" {
+			return nil, nil, fmt.Errorf("--focus .: current directory is not inside a workspace")
+		}
+		return ctx, canonicalWorkspaceNode(s, ctx.NotebookContextWorkspace), nil
+	}
+
+	for _, ws := range s.GetWorkspaceProvider().All() {
+		if ws.Name != focus {
+			continue
+		}
+		ctx, err := s.GetWorkspaceContext(ws.Path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get workspace context for %s: %w", focus, err)
+		}
+		return ctx, ws, nil
+	}
+	return nil, nil, fmt.Errorf("workspace not found: %s", focus)
+}
+
+// canonicalWorkspaceNode returns the provider's instance of raw. The workspace
+// from GetWorkspaceContext might be a "raw" discovery; the provider's copy has
+// all metadata (like its Kind) fully resolved.
+func canonicalWorkspaceNode(s *service.Service, raw *workspace.WorkspaceNode) *workspace.WorkspaceNode {
+	for _, ws := range s.GetWorkspaceProvider().All() {
+		if isSame, _ := pathutil.ComparePaths(ws.Path, raw.Path); isSame {
+			return ws
+		}
+	}
+	// Fallback if not found, though this is unlikely.
+	return raw
+}
+
 // cliEnvironmentHost is a tea.Model wrapper that intercepts embed messages from
 // the pure browser model and translates them into CLI-specific behaviors:
 //
@@ -109,12 +201,15 @@ This view provides a workspace-centric way to explore your entire notebook.`,
 //     opening a local editor.
 //   - In a tmux session, edit requests open the file in a tmux split pane,
 //     reusing an existing split when possible.
-//   - Otherwise, the request is passed through to the parent host (typically
-//     embed.StandaloneHost), which falls back to $EDITOR via tea.ExecProcess.
+//   - Otherwise, the editor runs in the terminal via tea.ExecProcess, its
+//     command line split as service.EditorArgs does.
 //
 // All other messages are forwarded to the wrapped browser model unchanged.
 type cliEnvironmentHost struct {
 	model tea.Model
+	// editor is the editor command line, e.g. "code --wait"; see
+	// service.EditorArgs.
+	editor string
 
 	// Tmux split state, owned by the host so the browser model stays free of
 	// any environment awareness.
@@ -136,7 +231,7 @@ func (h *cliEnvironmentHost) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if mux.ActiveMux() != mux.MuxNone {
 			return h, h.openInTmuxCmd(msg.Path)
 		}
-		// Fall through to the parent host (StandaloneHost) which runs $EDITOR.
+		return h, h.openInEditorCmd(msg.Path)
 
 	case embed.PreviewRequestMsg:
 		if os.Getenv("GROVE_NVIM_PLUGIN") == "true" {
@@ -184,6 +279,15 @@ func writeNvimIPC(action, path string) {
 	_ = os.WriteFile(tempFile, []byte(action+":"+path+"\n"), 0o644)
 }
 
+// openInEditorCmd runs the editor on path in the terminal, suspending the
+// TUI until it exits; the browser then refreshes.
+func (h *cliEnvironmentHost) openInEditorCmd(path string) tea.Cmd {
+	args := service.EditorArgs(h.editor, path)
+	return tea.ExecProcess(exec.Command(args[0], args[1:]...), func(error) tea.Msg {
+		return embed.EditFinishedMsg{}
+	})
+}
+
 // tmuxSplitFinishedMsg reports the result of a tmux split-or-reuse operation
 // initiated by the cliEnvironmentHost. It is internal to this file.
 type tmuxSplitFinishedMsg struct {
@@ -199,6 +303,11 @@ type tmuxSplitFinishedMsg struct {
 func (h *cliEnvironmentHost) openInTmuxCmd(path string) tea.Cmd {
 	splitPaneID := h.tmuxSplitPaneID
 	tuiPaneID := h.tmuxTUIPaneID
+	splitRatio := 0
+	if bm, ok := h.model.(browser.Model); ok {
+		splitRatio = bm.SplitRatio()
+	}
+	editor := h.editor
 	return func() tea.Msg {
 		ctx := context.Background()
 
@@ -213,11 +322,10 @@ func (h *cliEnvironmentHost) openInTmuxCmd(path string) tea.Cmd {
 				return tmuxSplitFinishedMsg{err: fmt.Errorf("IsPopup error: %w", err)}
 			}
 			if isPopup {
-				editor := os.Getenv("EDITOR")
-				if editor == "" {
-					editor = "nvim"
-				}
-				if err := tuiEngine.OpenInEditorWindow(ctx, editor, path, "notebook", 2, false); err != nil {
+				// The window runs "<editor> <path>" through a shell.
+				args := service.EditorArgs(editor, path)
+				editorLine := service.QuoteCommandLine(args[:len(args)-1])
+				if err := tuiEngine.OpenInEditorWindow(ctx, editorLine, path, "notebook", 2, false); err != nil {
 					return tmuxSplitFinishedMsg{err: fmt.Errorf("popup mode - failed to open in editor: %w", err)}
 				}
 				if err := tuiEngine.ClosePopup(ctx); err != nil {
@@ -227,19 +335,15 @@ func (h *cliEnvironmentHost) openInTmuxCmd(path string) tea.Cmd {
 			}
 		}
 
-		return openInTmuxSplit(ctx, engine, splitPaneID, tuiPaneID, path)
+		return openInTmuxSplit(ctx, engine, splitPaneID, tuiPaneID, editor, path, splitRatio)
 	}
 }
 
 // openInTmuxSplit reuses the host's existing split pane (if any) or creates a
 // new one alongside the TUI, then returns a tmuxSplitFinishedMsg with the
-// updated pane bookkeeping for the host to absorb.
-func openInTmuxSplit(ctx context.Context, engine mux.MuxEngine, splitPaneID, tuiPaneID, path string) tea.Msg {
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vim"
-	}
-
+// updated pane bookkeeping for the host to absorb. A non-zero splitRatio is
+// the percent of the width the TUI keeps.
+func openInTmuxSplit(ctx context.Context, engine mux.MuxEngine, splitPaneID, tuiPaneID, editor, path string, splitRatio int) tea.Msg {
 	// If we already have a split pane, try to reuse it.
 	paneStillExists := false
 	if splitPaneID != "" {
@@ -267,28 +371,19 @@ func openInTmuxSplit(ctx context.Context, engine mux.MuxEngine, splitPaneID, tui
 		return tmuxSplitFinishedMsg{err: fmt.Errorf("failed to get pane width: %w", err)}
 	}
 
-	// Reserve roughly 30% of the screen for the TUI (40-80 cols), then give the
-	// editor the rest. Below 120 cols just split 50/50.
-	tuiWidth := currentWidth * 30 / 100
-	if tuiWidth < 40 {
-		tuiWidth = 40
-	}
-	if tuiWidth > 80 {
-		tuiWidth = 80
-	}
-	if currentWidth < 120 {
-		tuiWidth = 0
-	}
+	tuiWidth := tmuxTUIWidth(currentWidth, splitRatio)
 
 	editorWidth := 0
 	if tuiWidth > 0 {
 		editorWidth = currentWidth - tuiWidth - 1
-		if editorWidth < 40 {
+		// An explicit split ratio is honored down to narrow editors.
+		if editorWidth < 1 || (editorWidth < 40 && splitRatio == 0) {
 			editorWidth = 0
 		}
 	}
 
-	commandToRun := fmt.Sprintf("%s %q", editor, path)
+	// The pane runs the command through a shell.
+	commandToRun := service.QuoteCommandLine(service.EditorArgs(editor, path))
 	paneID, err := engine.SplitWindow(ctx, "", true, editorWidth, commandToRun)
 	if err != nil {
 		return tmuxSplitFinishedMsg{err: fmt.Errorf("failed to split tmux window: %w", err)}
@@ -300,3 +395,24 @@ func openInTmuxSplit(ctx context.Context, engine mux.MuxEngine, splitPaneID, tui
 		clearPanes: shouldClearOldPanes,
 	}
 }
+
+// tmuxTUIWidth is the width the TUI keeps when the editor is split beside it,
+// or 0 for an even split. With a split ratio the TUI keeps that percent of
+// the pane. Otherwise it reserves roughly 30% of the screen (40-80 cols) and
+// below 120 cols splits 50/50.
+func tmuxTUIWidth(currentWidth, splitRatio int) int {
+	if splitRatio > 0 {
+		return currentWidth * splitRatio / 100
+	}
+	tuiWidth := currentWidth * 30 / 100
+	if tuiWidth < 40 {
+		tuiWidth = 40
+	}
+	if tuiWidth > 80 {
+		tuiWidth = 80
+	}
+	if currentWidth < 120 {
+		tuiWidth = 0
+	}
+	return tuiWidth
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTmuxTUIWidth(t *testing.T) {
+	// Without a ratio: ~30% clamped to 40-80 cols, even split below 120.
+	assert.Equal(t, 0, tmuxTUIWidth(100, 0))
+	assert.Equal(t, 48, tmuxTUIWidth(160, 0))
+	assert.Equal(t, 80, tmuxTUIWidth(400, 0))
+
+	// An explicit ratio is used as is, even in narrow panes.
+	assert.Equal(t, 40, tmuxTUIWidth(100, 40))
+	assert.Equal(t, 200, tmuxTUIWidth(400, 50))
+}
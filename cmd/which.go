@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/grovetools/nb/pkg/index"
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// whichMatch is one note `nb which` resolved a query to.
+type whichMatch struct {
+	Path      string `json:"path"`
+	Title     string `json:"title"`
+	Workspace string `json:"workspace"`
+	Group     string `json:"group"`
+	Branch    string `json:"branch,omitempty"`
+	Archived  bool   `json:"archived"`
+}
+
+func NewWhichCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		jsonOutput bool
+		pathOnly   bool
+		allSpaces  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "which <note>",
+		Short: "Print where a note lives",
+		Long: `Resolve a note the way nb cat and nb note info do, without opening it, and
+print its absolute path, workspace, group, branch and whether it is archived.
+
+The note may be a file path, filename stem, frontmatter id, alias or title.
+When none of those match exactly, notes whose filename or title contains the
+query are listed. The current workspace is searched first; when nothing
+matches there, or with --all, every workspace is searched, so a note moved
+to another workspace is still found. When several notes match, all of them
+are printed and nb which exits with status 2. --path prints only the path,
+for scripts.
+
+Examples:
+  nb which weekly-sync
+  nb which "Release checklist" --json
+  vim "$(nb which idea --path)"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			query := args[0]
+
+			var paths []string
+			if info, err := os.Stat(query); err == nil && !info.IsDir() {
+				abs, err := filepath.Abs(query)
+				if err != nil {
+					return fmt.Errorf("resolve %s: %w", query, err)
+				}
+				paths = []string{abs}
+			} else {
+				docs, err := resolveWhich(s, *workspaceOverride, query, allSpaces)
+				if err != nil {
+					return err
+				}
+				for _, doc := range docs {
+					paths = append(paths, doc.Path)
+				}
+			}
+			if len(paths) == 0 {
+				return fmt.Errorf("no note found for %q", query)
+			}
+
+			matches := make([]whichMatch, 0, len(paths))
+			for _, path := range paths {
+				info, err := s.GetNoteInfo(path)
+				if err != nil {
+					return err
+				}
+				title := info.Note.FrontmatterTitle
+				if title == "" {
+					title = info.Note.Title
+				}
+				matches = append(matches, whichMatch{
+					Path:      info.Note.Path,
+					Title:     title,
+					Workspace: info.Note.Workspace,
+					Group:     info.Group,
+					Branch:    info.Note.Branch,
+					Archived:  info.Note.IsArchived,
+				})
+			}
+
+			out := cmd.OutOrStdout()
+			switch {
+			case jsonOutput:
+				enc := json.NewEncoder(out)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(matches); err != nil {
+					return err
+				}
+			case pathOnly:
+				for _, m := range matches {
+					fmt.Fprintln(out, m.Path)
+				}
+			default:
+				if err := printWhichMatches(out, matches); err != nil {
+					return err
+				}
+			}
+			if len(matches) > 1 {
+				return &ExitError{Code: 2, Err: fmt.Errorf("%q matches %d notes", query, len(matches))}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVarP(&pathOnly, "path", "p", false, "Print only the path")
+	cmd.Flags().BoolVarP(&allSpaces, "all", "a", false, "Search every workspace, not just the current one")
+	cmd.MarkFlagsMutuallyExclusive("json", "path")
+	return cmd
+}
+
+// resolveWhich resolves query with whichCandidates in the current workspace
+// and, when nothing matches there or all is set, in every workspace.
+func resolveWhich(s *service.Service, workspaceOverride, query string, all bool) ([]*index.Doc, error) {
+	if !all {
+		ix, err := buildVaultIndex(s, workspaceOverride)
+		if err != nil {
+			return nil, err
+		}
+		if docs := whichCandidates(ix, query); len(docs) > 0 {
+			return docs, nil
+		}
+	}
+	ix, err := buildAllWorkspacesIndex(s)
+	if err != nil {
+		return nil, err
+	}
+	return whichCandidates(ix, query), nil
+}
+
+// whichCandidates resolves query like resolveNoteArg, falling back to the
+// notes whose filename stem or title contains it, ignoring case.
+func whichCandidates(ix *index.Index, query string) []*index.Doc {
+	if docs := ix.Resolve(query); len(docs) > 0 {
+		return docs
+	}
+	needle := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(query), ".md"))
+	if needle == "" {
+		return nil
+	}
+	var docs []*index.Doc
+	for _, d := range ix.Docs() {
+		stem := strings.TrimSuffix(filepath.Base(d.Path), filepath.Ext(d.Path))
+		if strings.Contains(strings.ToLower(stem), needle) || strings.Contains(strings.ToLower(d.Title), needle) {
+			docs = append(docs, d)
+		}
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Path < docs[j].Path })
+	return docs
+}
+
+// printWhichMatches prints a single match as its path followed by its
+// details, and several matches as a table.
+func printWhichMatches(out io.Writer, matches []whichMatch) error {
+	orNone := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	archived := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if len(matches) == 1 {
+		m := matches[0]
+		fmt.Fprintln(out, m.Path)
+		fmt.Fprintf(w, "  Title:\t%s\n", orNone(m.Title))
+		fmt.Fprintf(w, "  Workspace:\t%s\n", orNone(m.Workspace))
+		fmt.Fprintf(w, "  Group:\t%s\n", orNone(m.Group))
+		fmt.Fprintf(w, "  Branch:\t%s\n", orNone(m.Branch))
+		fmt.Fprintf(w, "  Archived:\t%s\n", archived(m.Archived))
+		return w.Flush()
+	}
+	fmt.Fprintln(w, "PATH\tWORKSPACE\tGROUP\tBRANCH\tARCHIVED")
+	for _, m := range matches {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", m.Path, orNone(m.Workspace), orNone(m.Group), orNone(m.Branch), archived(m.Archived))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/index"
+)
+
+func TestWhichCandidates(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"inbox/20260101-release-checklist.md": "---\ntitle: Release checklist\n---\n",
+		"inbox/weekly-sync.md":                "# Weekly\n",
+		"learn/weekly-sync.md":                "# Weekly\n",
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	ix := index.New()
+	require.NoError(t, ix.Build([]index.Root{{Dir: dir, Workspace: "ws"}}))
+
+	stems := func(docs []*index.Doc) []string {
+		var out []string
+		for _, d := range docs {
+			rel, _ := filepath.Rel(dir, d.Path)
+			out = append(out, rel)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"inbox/20260101-release-checklist.md"}, stems(whichCandidates(ix, "Release checklist")), "exact title")
+	assert.Equal(t, []string{"inbox/weekly-sync.md", "learn/weekly-sync.md"}, stems(whichCandidates(ix, "weekly-sync")), "ambiguous stem")
+	assert.Equal(t, []string{"inbox/20260101-release-checklist.md"}, stems(whichCandidates(ix, "CHECKLIST")), "substring fallback")
+	assert.Empty(t, whichCandidates(ix, "nothing-like-it"))
+}
+
+func TestPrintWhichMatches(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, printWhichMatches(&buf, []whichMatch{
+		{Path: "/nb/ws/inbox/a.md", Title: "A", Workspace: "ws", Group: "inbox"},
+	}))
+	assert.Equal(t, "/nb/ws/inbox/a.md\n  Title:      A\n  Workspace:  ws\n  Group:      inbox\n  Branch:     -\n  Archived:   no\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, printWhichMatches(&buf, []whichMatch{
+		{Path: "/nb/ws/inbox/a.md", Workspace: "ws", Group: "inbox"},
+		{Path: "/nb/ws/.archive/a.md", Workspace: "ws", Group: ".archive", Archived: true},
+	}))
+	assert.Contains(t, buf.String(), "PATH")
+	assert.Contains(t, buf.String(), "/nb/ws/.archive/a.md  ws         .archive  -       yes")
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"text/tabwriter"
 
@@ -15,27 +16,193 @@ var workspaceUlog = grovelogging.NewUnifiedLogger("grove-notebook.cmd.workspace"
 
 func NewWorkspaceCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:        "workspace",
-		Short:      "Manage workspaces (deprecated)",
-		Long:       `Manage workspace registrations and settings. Most of this functionality is now handled by 'grove ws'.`,
-		Hidden:     true,
-		Deprecated: "use 'grove ws' for workspace management and 'nb context' for context info.",
+		Use:   "workspace",
+		Short: "Archive, restore and git-sync workspace notebooks",
+		Long: `Archive and restore workspace notebooks, and commit and push them with git.
+Registering and discovering workspaces is handled by 'grove ws'; see
+'nb context' for context info.
+
+Examples:
+  nb workspace archive old-project
+  nb workspace archive --list
+  nb workspace unarchive old-project
+  nb workspace sync-git -m "Weekly notes"`,
 	}
 
 	// Most subcommands are removed as workspace management is now centralized in grove-core and 'grove ws' command.
 	// We keep 'current' for debugging purposes, but point users to 'nb context'.
 	cmd.AddCommand(
 		newWorkspaceCurrentCmd(svc, workspaceOverride),
+		newWorkspaceArchiveCmd(svc),
+		newWorkspaceUnarchiveCmd(svc),
+		newWorkspaceSyncGitCmd(svc, workspaceOverride),
 	)
 
 	return cmd
 }
 
+func newWorkspaceArchiveCmd(svc **service.Service) *cobra.Command {
+	var list bool
+
+	cmd := &cobra.Command{
+		Use:   "archive <name>",
+		Short: "Move a workspace's notes out of the way",
+		Long: `Archive a workspace that is no longer active. Its notebook directory moves
+from workspaces/<name>/ to workspaces/.archived/<name>/ along with a
+.nb-archive-info.yml recording the archive date. Nothing is deleted: the notes
+just drop out of listings, search and the TUI (which shows archived workspaces
+in their own section while archives are toggled on). A worktree shares its
+parent project's notebook, so it can't be archived on its own.
+
+With --list, print the archived workspaces instead.
+
+Examples:
+  nb workspace archive old-project
+  nb workspace archive --list`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if list {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			if list {
+				archived, err := s.ListArchivedWorkspaces()
+				if err != nil {
+					return err
+				}
+				if len(archived) == 0 {
+					fmt.Fprintln(cmd.OutOrStdout(), "No archived workspaces")
+					return nil
+				}
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				fmt.Fprintln(w, "WORKSPACE\tARCHIVED\tPATH")
+				for _, aw := range archived {
+					date := "-"
+					if aw.Info != nil && !aw.Info.ArchivedAt.IsZero() {
+						date = aw.Info.ArchivedAt.Local().Format("2006-01-02")
+					}
+					fmt.Fprintf(w, "%s\t%s\t%s\n", aw.Name, date, aw.Path)
+				}
+				return w.Flush()
+			}
+
+			if err := s.ArchiveWorkspace(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Archived workspace %s (restore with 'nb workspace unarchive %s')\n", args[0], args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&list, "list", false, "List archived workspaces")
+	return cmd
+}
+
+func newWorkspaceUnarchiveCmd(svc **service.Service) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unarchive <name>",
+		Short: "Restore an archived workspace",
+		Long: `Move an archived workspace's notebook directory back from
+workspaces/.archived/<name>/ so its notes show up again.
+
+Examples:
+  nb workspace unarchive old-project`,
+		Args: cobra.ExactArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			archived, err := (*svc).ListArchivedWorkspaces()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			names := make([]string, 0, len(archived))
+			for _, aw := range archived {
+				names = append(names, aw.Name)
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			if err := s.UnarchiveWorkspace(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Unarchived workspace %s\n", args[0])
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newWorkspaceSyncGitCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
+	var (
+		message string
+		amend   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync-git",
+		Short: "Commit and push all pending notebook changes",
+		Long: `Stage every change in the workspace's notebook directory (see 'nb git init'),
+commit it and push it to the branch's upstream. Other files of the enclosing
+repository are not staged. A branch without an upstream is pushed to origin
+(or the only remote) and tracked.
+The default message is "nb: auto-sync <date>".
+
+With --amend the last commit is amended instead and pushed with
+--force-with-lease. Set hooks.post_sync_git in the [nb] config to run this
+after every successful 'nb remote sync'.
+
+Examples:
+  nb workspace sync-git
+  nb workspace sync-git -W my-project -m "Meeting notes"
+  nb workspace sync-git --amend`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s := *svc
+			ctx, err := s.GetWorkspaceContext(*workspaceOverride)
+			if err != nil {
+				return fmt.Errorf("get workspace context: %w", err)
+			}
+			result, err := s.CommitAndPushNotebook(ctx, message, amend)
+			printGitSyncResult(cmd.OutOrStdout(), result, err)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Commit message (default \"nb: auto-sync <date>\")")
+	cmd.Flags().BoolVar(&amend, "amend", false, "Amend the last commit instead of creating one")
+	return cmd
+}
+
+// printGitSyncResult reports what CommitAndPushNotebook did before err, if
+// anything.
+func printGitSyncResult(out io.Writer, result *service.GitSyncResult, err error) {
+	if result == nil {
+		return
+	}
+	switch {
+	case result.Amended:
+		fmt.Fprintf(out, "* Amended the last commit in %s\n", result.RepoPath)
+	case result.Committed:
+		fmt.Fprintf(out, "* Committed %q in %s\n", result.Message, result.RepoPath)
+	default:
+		fmt.Fprintf(out, "No changes to commit in %s\n", result.RepoPath)
+	}
+	if err == nil {
+		fmt.Fprintf(out, "* Pushed to %s\n", result.Remote)
+	}
+}
+
 func newWorkspaceCurrentCmd(svc **service.Service, workspaceOverride *string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "current",
-		Short: "Show current workspace (use 'nb context' instead)",
-		Long:  "This command is deprecated. Please use 'nb context' for more detailed information.",
+		Use:    "current",
+		Short:  "Show current workspace (use 'nb context' instead)",
+		Long:   "This command is deprecated. Please use 'nb context' for more detailed information.",
+		Hidden: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			s := *svc
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -19,6 +20,7 @@ import (
 var (
 	svc               *service.Service
 	workspaceOverride string
+	noHooks           bool
 )
 
 func main() {
@@ -26,7 +28,10 @@ func main() {
 		"nb",
 		"A workspace-based note-taking system",
 	)
-	rootCmd.PersistentFlags().StringVarP(&workspaceOverride, "workspace", "W", "", "Override current workspace context by path")
+	rootCmd.PersistentFlags().StringVarP(&workspaceOverride, "workspace", "W", "", "Override current workspace context by path or workspace name")
+	rootCmd.PersistentFlags().BoolVar(&noHooks, "no-hooks", false, "Don't run the hooks from the [nb] config (pre_create, post_create, post_sync_git)")
+	logOpts := cmd.AddLogFlags(rootCmd)
+	cmd.AddColorFlag(rootCmd)
 
 	vInfo := version.GetInfo()
 	rootCmd.Version = vInfo.Version
@@ -37,12 +42,18 @@ func main() {
 		BuildArch: vInfo.Platform,
 	})
 
-	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+	rootCmd.PersistentPreRunE = func(c *cobra.Command, args []string) error {
 		// This runs once before any subcommand
 		logger := logging.NewLogger("nb")
+		if err := logOpts.Apply(logger.Logger); err != nil {
+			return err
+		}
 
 		// 1. Load configuration using grove-core
 		cfg, err := coreconfig.LoadDefault()
+		if logger.Logger.IsLevelEnabled(logrus.DebugLevel) {
+			cmd.LogConfigIssues(logger, cfg, err)
+		}
 		if err != nil {
 			// Non-fatal, proceed with an empty config for local mode.
 			cfg = &coreconfig.Config{}
@@ -55,17 +66,25 @@ func main() {
 		discoveryLogger.SetOutput(os.Stderr)
 		discoveryLogger.SetLevel(logrus.WarnLevel)
 		discoveryService := workspace.NewDiscoveryService(discoveryLogger)
-		result, err := discoveryService.DiscoverAll()
+		// Completion requests run on every <TAB>, so they reuse a recent
+		// discovery result instead of rescanning.
+		result, err := cmd.DiscoverWorkspaces(discoveryService.DiscoverAll, cmd.IsCompletionRequest(c))
 		if err != nil {
 			return fmt.Errorf("failed to discover workspaces: %w", err)
 		}
 		provider := workspace.NewProvider(result)
 
 		// 3. Initialize the main service
-		// In the future, service.Config can be derived from the core config extensions.
+		// nb-specific settings come from the [nb] section of the grove config.
 		serviceCfg := &service.Config{
 			Editor: os.Getenv("EDITOR"), // A common way to get editor
 		}
+		if err := serviceCfg.ApplyCoreConfig(cfg); err != nil {
+			logger.Warnf("ignoring invalid nb config: %v", err)
+		}
+		if noHooks {
+			serviceCfg.Hooks = service.HooksConfig{}
+		}
 		svc, err = service.New(serviceCfg, provider, cfg, logger)
 		if err != nil {
 			return fmt.Errorf("failed to initialize service: %w", err)
@@ -78,12 +97,21 @@ func main() {
 	rootCmd.AddCommand(cmd.NewQuickCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewWorkspaceCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewSearchCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewNoteCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewListCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewTreeCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewRecentCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewInboxCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewRelatedCmd(&svc))
 	rootCmd.AddCommand(cmd.NewArchiveCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewBackupCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewConfigCmd(&svc))
 	rootCmd.AddCommand(cmd.NewContextCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewInitCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewMigrateCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewImportCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewMoveCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewMvCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewObsidianCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewVersionCmd())
 	rootCmd.AddCommand(cmd.NewTuiCmd(&svc, &workspaceOverride))
@@ -92,14 +120,31 @@ func main() {
 	rootCmd.AddCommand(cmd.NewRemoteCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewGitCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewConceptCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewPlanCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewGroupCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewTemplateCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewReminderCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewSyncthingCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewPromoteCmd(&svc))
 	rootCmd.AddCommand(cmd.NewDoctorCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewLintCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewStatsCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewCatCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewWhichCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewBacklinksCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewLinksCmd(&svc, &workspaceOverride))
 	rootCmd.AddCommand(cmd.NewTagsCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewTagCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewTrashCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewPruneCmd(&svc, &workspaceOverride))
+	rootCmd.AddCommand(cmd.NewCompletionCmd())
+	cmd.RegisterWorkspaceCompletion(rootCmd, &svc)
 
 	if err := cli.Execute(rootCmd); err != nil {
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -434,7 +434,7 @@ func claimFromNote(path string) (planName, planJob string) {
 	if err != nil {
 		return "", ""
 	}
-	fm, _, err := frontmatter.Parse(string(content))
+	fm, err := frontmatter.ParsePartial(string(content))
 	if err != nil || fm == nil || fm.PlanRef == "" {
 		return "", ""
 	}
@@ -449,6 +449,10 @@ type jobFrontmatter struct {
 	ID      string `yaml:"id"`
 	NoteRef string `yaml:"note_ref"`
 	Status  string `yaml:"status"`
+	// LinkType is set by nb note link, which wrote its link to note_ref
+	// before it got its own link_ref key. Such a note_ref is a note-to-note
+	// link, not a job's note hint.
+	LinkType string `yaml:"link_type"`
 }
 
 var fmBlockRe = regexp.MustCompile(`(?s)^---\n(.*?)\n---`)
@@ -492,7 +496,7 @@ func buildNoteIndex(workspaceDir string) *noteIndex {
 		for _, path := range files {
 			n := &indexedNote{path: path, base: filepath.Base(path)}
 			if content, err := os.ReadFile(path); err == nil {
-				if fm, _, err := frontmatter.Parse(string(content)); err == nil && fm != nil {
+				if fm, err := frontmatter.ParsePartial(string(content)); err == nil && fm != nil {
 					n.id = strings.TrimSpace(fm.ID)
 					n.hasLink = fm.PlanRef != "" || fm.PlanJob != ""
 					n.readable = true
@@ -597,8 +601,8 @@ func scanUnclaimedJobs(plansDir, workspaceDir string, claims map[string]struct{}
 			}
 			jobPath := filepath.Join(planDir, jf.Name())
 			jfm := parseJobFrontmatter(jobPath)
-			if jfm == nil || jfm.NoteRef == "" {
-				continue // jobs without a note_ref hint are ignored
+			if jfm == nil || jfm.NoteRef == "" || jfm.LinkType != "" {
+				continue // jobs without a note_ref hint (or with an nb link) are ignored
 			}
 			if _, claimed := claims[planName+"/"+jf.Name()]; claimed {
 				continue
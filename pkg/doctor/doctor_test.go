@@ -344,6 +344,23 @@ func TestRun_StaleRefToNoteOwnedByAnotherJobIsCleared(t *testing.T) {
 	assert.Equal(t, "01-owned.md", pj)
 }
 
+// TestRun_NoteLinkIsNotAJobHint: a job file carrying a note_ref written by nb
+// note link (marked by its link_type) is left alone.
+func TestRun_NoteLinkIsNotAJobHint(t *testing.T) {
+	silenceEvents(t)
+	root := filepath.Join(t.TempDir(), "workspaces", "testws")
+	planDir := filepath.Join(root, "plans", "linkplan")
+	require.NoError(t, os.MkdirAll(planDir, 0o755))
+	jobPath := filepath.Join(planDir, "01-linked.md")
+	content := "---\nid: linked\ntitle: linked\nstatus: pending\nnote_ref: ../../inbox/gone.md\nlink_type: reference\n---\n\nprompt body\n"
+	require.NoError(t, os.WriteFile(jobPath, []byte(content), 0o644))
+
+	r, err := Run(root, "testws", true)
+	require.NoError(t, err)
+	assert.Empty(t, r.UnclaimedJobs)
+	assert.Equal(t, "../../inbox/gone.md", readNoteRef(t, jobPath))
+}
+
 // TestRun_TwoJobsOneNote: when two jobs point at the same note, the first (in
 // deterministic plan/job order) wins it and the second's hint is cleared — no
 // note is ever double-claimed.
@@ -1,9 +1,11 @@
 package frontmatter
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -23,6 +25,12 @@ type RemoteMetadata struct {
 	Milestone string   `yaml:"milestone,omitempty"`
 }
 
+// Reminder is a note's `reminder` field: when to notify, and what to say.
+type Reminder struct {
+	At      string `yaml:"at"` // RFC3339
+	Message string `yaml:"message,omitempty"`
+}
+
 // Frontmatter represents the structured metadata at the beginning of a note
 type Frontmatter struct {
 	ID         string   `yaml:"id"`
@@ -35,11 +43,19 @@ type Frontmatter struct {
 	Worktree   string   `yaml:"worktree,omitempty"`
 	Created    string   `yaml:"created"`
 	Modified   string   `yaml:"modified"`
-	Started    string   `yaml:"started,omitempty"`  // For LLM notes
-	PlanRef    string   `yaml:"plan_ref,omitempty"` // Reference to associated plan (slug form: plans/<planName>)
-	PlanJob    string   `yaml:"plan_job,omitempty"` // Per-job linkage: the promoted job's filename (e.g. 01-foo.md)
-	Priority   string   `yaml:"priority,omitempty"` // p0 (most critical) .. p3, empty = none
-	Name       string   `yaml:"name,omitempty"`     // Canonical name when the filename is generic (e.g. skills/<name>/SKILL.md)
+	Started    string   `yaml:"started,omitempty"`        // For LLM notes
+	PlanRef    string   `yaml:"plan_ref,omitempty"`       // Reference to associated plan (slug form: plans/<planName>)
+	PlanJob    string   `yaml:"plan_job,omitempty"`       // Per-job linkage: the promoted job's filename (e.g. 01-foo.md)
+	LinkRef    string   `yaml:"link_ref,omitempty"`       // Note this one links to with nb note link, relative to its directory
+	LinkType   string   `yaml:"link_type,omitempty"`      // Kind of the link_ref link: reference, parent or followup
+	Backlinks  []string `yaml:"backlinks,flow,omitempty"` // Notes whose link_ref points here, relative to this note's directory
+	Priority   string   `yaml:"priority,omitempty"`       // p0 (most critical) .. p3, empty = none
+	Flag       string   `yaml:"flag,omitempty"`           // Triage flag from the configured palette, e.g. red
+	Status     string   `yaml:"status,omitempty"`         // Free-form workflow status, e.g. from an import
+	Name       string   `yaml:"name,omitempty"`           // Canonical name when the filename is generic (e.g. skills/<name>/SKILL.md)
+
+	// Reminder set with nb reminder set
+	Reminder *Reminder `yaml:"reminder,omitempty"`
 
 	// Remote sync metadata
 	Remote *RemoteMetadata `yaml:"remote,omitempty"`
@@ -52,9 +68,26 @@ type Frontmatter struct {
 	Featured    bool   `yaml:"featured,omitempty"`
 }
 
-// Parse extracts frontmatter from content and returns the parsed data and body
+// utf8BOM is the byte-order mark some Windows editors prepend to UTF-8 files.
+const utf8BOM = "\ufeff"
+
+// NormalizeContent strips a leading UTF-8 BOM and converts CRLF line endings
+// to LF so that the `---` delimiters match regardless of where the note was
+// written.
+func NormalizeContent(content string) string {
+	content = strings.TrimPrefix(content, utf8BOM)
+	if strings.Contains(content, "\r\n") {
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+	}
+	return content
+}
+
+// Parse extracts frontmatter from content and returns the parsed data and body.
+// A leading UTF-8 BOM is ignored and CRLF line endings are normalized, so the
+// returned body is always LF-terminated and round-trips to LF on rewrite.
+// Content without frontmatter is returned unchanged.
 func Parse(content string) (*Frontmatter, string, error) {
-	matches := frontmatterPattern.FindStringSubmatch(content)
+	matches := frontmatterPattern.FindStringSubmatch(NormalizeContent(content))
 	if len(matches) != 3 {
 		// No frontmatter found
 		return nil, content, nil
@@ -79,6 +112,102 @@ func Parse(content string) (*Frontmatter, string, error) {
 	return &fm, bodyContent, nil
 }
 
+// Frontmatter delimiters as matched by frontmatterPattern: an opening "---"
+// line at the very start and the first "---" line after it.
+var (
+	openDelimiter  = []byte("---\n")
+	closeDelimiter = []byte("\n---\n")
+)
+
+// ParseFromBytes is Parse for content read straight from disk. It finds the
+// delimiters with bytes.Index and unmarshals the YAML from a subslice of data,
+// so the file is never copied into a string as a whole; only the body is
+// converted. BOM and CRLF handling match Parse (CRLF input is copied once to
+// normalize it), and content without frontmatter is returned unchanged.
+func ParseFromBytes(data []byte) (*Frontmatter, string, error) {
+	normalized := bytes.TrimPrefix(data, []byte(utf8BOM))
+	if bytes.Contains(normalized, []byte("\r\n")) {
+		normalized = bytes.ReplaceAll(normalized, []byte("\r\n"), []byte("\n"))
+	}
+	if !bytes.HasPrefix(normalized, openDelimiter) {
+		return nil, string(data), nil
+	}
+	start := len(openDelimiter)
+	end := bytes.Index(normalized[start:], closeDelimiter)
+	if end < 0 {
+		return nil, string(data), nil
+	}
+	end += start
+
+	var fm Frontmatter
+	if err := yaml.Unmarshal(normalized[start:end], &fm); err != nil {
+		return nil, string(data), fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+
+	// Ensure arrays are never nil
+	if fm.Aliases == nil {
+		fm.Aliases = []string{}
+	}
+	if fm.Tags == nil {
+		fm.Tags = []string{}
+	}
+
+	return &fm, string(normalized[end+len(closeDelimiter):]), nil
+}
+
+// ParsePartial is Parse for callers that only need the metadata. It reads
+// content line by line up to the closing delimiter and unmarshals just that
+// header, so the body is neither scanned nor copied. BOM and CRLF handling
+// match Parse, and content without frontmatter yields nil.
+func ParsePartial(content string) (*Frontmatter, error) {
+	content = strings.TrimPrefix(content, utf8BOM)
+	header, ok := frontmatterHeader(content)
+	if !ok {
+		return nil, nil
+	}
+	if strings.Contains(header, "\r\n") {
+		header = strings.ReplaceAll(header, "\r\n", "\n")
+	}
+
+	var fm Frontmatter
+	if err := yaml.Unmarshal([]byte(header), &fm); err != nil {
+		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+
+	// Ensure arrays are never nil
+	if fm.Aliases == nil {
+		fm.Aliases = []string{}
+	}
+	if fm.Tags == nil {
+		fm.Tags = []string{}
+	}
+
+	return &fm, nil
+}
+
+// frontmatterHeader returns the YAML between the opening and closing "---"
+// lines of content, as frontmatterPattern would match it after CRLF
+// normalization. ok is false when content has no frontmatter.
+func frontmatterHeader(content string) (header string, ok bool) {
+	line, rest, found := strings.Cut(content, "\n")
+	if !found || strings.TrimSuffix(line, "\r") != "---" {
+		return "", false
+	}
+	start := len(content) - len(rest)
+	for pos, first := start, true; pos < len(content); first = false {
+		end := strings.IndexByte(content[pos:], '\n')
+		if end < 0 {
+			return "", false // The closing delimiter must end in a newline
+		}
+		if !first && strings.TrimSuffix(content[pos:pos+end], "\r") == "---" {
+			// Drop the newline ending the last YAML line, as the pattern does.
+			return strings.TrimSuffix(strings.TrimSuffix(content[start:pos], "\n"), "\r"), true
+		}
+		pos += end + 1
+	}
+	return "", false
+}
+
 // UpdateField sets a single named frontmatter field to value, used by the
 // `nb internal update-frontmatter` command. An empty value CLEARS the link
 // fields (plan_ref, plan_job) — flow's demote path relies on this — while every
@@ -156,9 +285,31 @@ func Build(fm *Frontmatter) string {
 	if fm.PlanJob != "" {
 		sb.WriteString(fmt.Sprintf("plan_job: %s\n", formatYAMLValue(fm.PlanJob)))
 	}
+	if fm.LinkRef != "" {
+		sb.WriteString(fmt.Sprintf("link_ref: %s\n", formatYAMLValue(fm.LinkRef)))
+	}
+	if fm.LinkType != "" {
+		sb.WriteString(fmt.Sprintf("link_type: %s\n", formatYAMLValue(fm.LinkType)))
+	}
+	if len(fm.Backlinks) > 0 {
+		sb.WriteString(fmt.Sprintf("backlinks: %s\n", formatYAMLArray(fm.Backlinks)))
+	}
 	if fm.Priority != "" {
 		sb.WriteString(fmt.Sprintf("priority: %s\n", formatYAMLValue(fm.Priority)))
 	}
+	if fm.Flag != "" {
+		sb.WriteString(fmt.Sprintf("flag: %s\n", formatYAMLValue(fm.Flag)))
+	}
+	if fm.Status != "" {
+		sb.WriteString(fmt.Sprintf("status: %s\n", formatYAMLValue(fm.Status)))
+	}
+	if fm.Reminder != nil && fm.Reminder.At != "" {
+		sb.WriteString("reminder:\n")
+		sb.WriteString(fmt.Sprintf("  at: %s\n", formatYAMLValue(fm.Reminder.At)))
+		if fm.Reminder.Message != "" {
+			sb.WriteString(fmt.Sprintf("  message: %s\n", formatYAMLValue(fm.Reminder.Message)))
+		}
+	}
 
 	// Remote sync metadata
 	if fm.Remote != nil {
@@ -226,22 +377,105 @@ func BuildContent(fm *Frontmatter, bodyContent string) string {
 // Existing files keep it forever (no mass migration); it remains parseable.
 const legacyTimestampFormat = "2006-01-02 15:04:05"
 
-// FormatTimestamp formats a time.Time into the standard frontmatter timestamp
-// format. New writes are RFC3339 in UTC; legacy timezone-less values in
+// Timestamp settings shared by FormatTimestamp and ParseTimestamp. They default
+// to RFC3339 in UTC and are changed once at startup via SetTimestampFormat.
+var (
+	timestampMu       sync.RWMutex
+	timestampLayout   = time.RFC3339
+	timestampLocation = time.UTC
+	// displayLocation is where times are shown; nil means time.Local.
+	displayLocation *time.Location
+)
+
+// SetTimestampFormat configures the layout new timestamps are written in and
+// the location they are written in. The location is also used to
+// interpret values that carry no zone offset (including the legacy format), so
+// a note written in local time keeps its wall-clock meaning. An empty layout
+// restores RFC3339; a nil location restores UTC.
+func SetTimestampFormat(layout string, loc *time.Location) {
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	timestampMu.Lock()
+	defer timestampMu.Unlock()
+	timestampLayout = layout
+	timestampLocation = loc
+}
+
+// TimestampLocation returns the location configured by SetTimestampFormat.
+func TimestampLocation() *time.Location {
+	timestampMu.RLock()
+	defer timestampMu.RUnlock()
+	return timestampLocation
+}
+
+// SetDisplayLocation sets the location times are shown in, e.g. in the TUI's
+// date columns. nil, the default, restores the system zone: the zone
+// timestamps are written in only decides what is shown when the user set it.
+func SetDisplayLocation(loc *time.Location) {
+	timestampMu.Lock()
+	defer timestampMu.Unlock()
+	displayLocation = loc
+}
+
+// DisplayLocation returns the location set by SetDisplayLocation, or
+// time.Local.
+func DisplayLocation() *time.Location {
+	timestampMu.RLock()
+	defer timestampMu.RUnlock()
+	if displayLocation == nil {
+		return time.Local
+	}
+	return displayLocation
+}
+
+// ValidateTimestampLayout reports whether layout can round-trip a timestamp to
+// the second, which both FormatTimestamp and ParseTimestamp rely on.
+func ValidateTimestampLayout(layout string) error {
+	ref := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	parsed, err := time.ParseInLocation(layout, ref.Format(layout), time.UTC)
+	if err != nil || !parsed.Equal(ref) {
+		return fmt.Errorf("timestamp format %q must include the full date and time to the second", layout)
+	}
+	return nil
+}
+
+// FormatTimestamp formats a time.Time into the configured frontmatter
+// timestamp format (RFC3339 in UTC by default). Legacy timezone-less values in
 // existing files are only re-emitted when nb already rewrites a note's
 // frontmatter for other reasons.
 func FormatTimestamp(t time.Time) string {
-	return t.UTC().Format(time.RFC3339)
+	timestampMu.RLock()
+	defer timestampMu.RUnlock()
+	return t.In(timestampLocation).Format(timestampLayout)
 }
 
 // ParseTimestamp parses a frontmatter timestamp string into time.Time.
-// It accepts both the current RFC3339/UTC format and the legacy
-// timezone-less format (dual-read forever, per the sync protocol).
+// It accepts the configured format, RFC3339 and the legacy timezone-less
+// format (dual-read forever, per the sync protocol). Values without a zone
+// offset are read in the configured location, and the result is returned in
+// that location so it displays consistently with FormatTimestamp.
 func ParseTimestamp(s string) (time.Time, error) {
+	timestampMu.RLock()
+	layout, loc := timestampLayout, timestampLocation
+	timestampMu.RUnlock()
+
+	if layout != time.RFC3339 {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t.In(loc), nil
+		}
+	}
 	if t, err := time.Parse(time.RFC3339, s); err == nil {
-		return t, nil
+		return t.In(loc), nil
+	}
+	t, err := time.ParseInLocation(legacyTimestampFormat, s, loc)
+	if err != nil {
+		return time.Time{}, err
 	}
-	return time.Parse(legacyTimestampFormat, s)
+	return t, nil
 }
 
 // formatYAMLArray formats a string slice as a YAML flow-style array
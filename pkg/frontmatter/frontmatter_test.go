@@ -221,6 +221,29 @@ tags: []
 created: 2023-01-01 10:00:00
 modified: 2023-01-01 10:00:00
 priority: p0
+---`,
+		},
+		{
+			name: "with flag",
+			fm: &Frontmatter{
+				ID:       "flagged",
+				Title:    "Triage Me",
+				Aliases:  []string{},
+				Tags:     []string{},
+				Created:  "2023-01-01 10:00:00",
+				Modified: "2023-01-01 10:00:00",
+				Priority: "p1",
+				Flag:     "yellow",
+			},
+			want: `---
+id: flagged
+title: Triage Me
+aliases: []
+tags: []
+created: 2023-01-01 10:00:00
+modified: 2023-01-01 10:00:00
+priority: p1
+flag: yellow
 ---`,
 		},
 		{
@@ -434,6 +457,9 @@ func TestRoundTrip(t *testing.T) {
 		Created:    "2023-01-01 10:00:00",
 		Modified:   "2023-01-02 11:00:00",
 		Started:    "2023-01-01 09:30:00",
+		LinkRef:    "../issues/bug.md",
+		LinkType:   "followup",
+		Backlinks:  []string{"../inbox/idea.md"},
 	}
 
 	body := "# Test Content\n\nThis is a test."
@@ -459,6 +485,126 @@ func TestRoundTrip(t *testing.T) {
 	}
 }
 
+func TestParseBOMAndCRLF(t *testing.T) {
+	lf := "---\nid: win-1\ntitle: Windows Note\naliases: []\ntags: [win]\ncreated: 2023-01-01 10:00:00\nmodified: 2023-01-01 10:00:00\n---\n\n# Heading\n\nLine one.\nLine two.\n"
+	crlf := strings.ReplaceAll(lf, "\n", "\r\n")
+	wantFM := &Frontmatter{
+		ID:       "win-1",
+		Title:    "Windows Note",
+		Aliases:  []string{},
+		Tags:     []string{"win"},
+		Created:  "2023-01-01 10:00:00",
+		Modified: "2023-01-01 10:00:00",
+	}
+	wantBody := "\n# Heading\n\nLine one.\nLine two.\n"
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "LF", content: lf},
+		{name: "BOM + LF", content: "\ufeff" + lf},
+		{name: "CRLF", content: crlf},
+		{name: "BOM + CRLF", content: "\ufeff" + crlf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm, body, err := Parse(tt.content)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(fm, wantFM) {
+				t.Errorf("Parse() gotFM = %+v, want %+v", fm, wantFM)
+			}
+			if body != wantBody {
+				t.Errorf("Parse() gotBody = %q, want %q", body, wantBody)
+			}
+
+			// Rewriting must produce LF-only content without a BOM.
+			rebuilt := BuildContent(fm, body)
+			if strings.Contains(rebuilt, "\r") {
+				t.Errorf("rebuilt content still contains CR: %q", rebuilt)
+			}
+			if strings.HasPrefix(rebuilt, "\ufeff") {
+				t.Errorf("rebuilt content still starts with a BOM")
+			}
+		})
+	}
+}
+
+func TestParseNoFrontmatterUnchanged(t *testing.T) {
+	content := "\ufeff# Title\r\n\r\nBody"
+	fm, body, err := Parse(content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if fm != nil {
+		t.Errorf("Parse() gotFM = %+v, want nil", fm)
+	}
+	if body != content {
+		t.Errorf("Parse() gotBody = %q, want original content %q", body, content)
+	}
+}
+
+func TestParseFromBytesMatchesParse(t *testing.T) {
+	lf := "---\nid: n-1\ntitle: Note\ntags: [a, b]\ncreated: 2023-01-01 10:00:00\nmodified: 2023-01-01 10:00:00\n---\n\n# Heading\n\nBody.\n"
+	inputs := map[string]string{
+		"LF":                lf,
+		"BOM + CRLF":        "\ufeff" + strings.ReplaceAll(lf, "\n", "\r\n"),
+		"no frontmatter":    "\ufeff# Title\r\n\r\nBody",
+		"unclosed":          "---\ntitle: x\n# Body\n",
+		"empty body":        "---\ntitle: x\n---\n",
+		"delimiter in body": "---\ntitle: x\n---\nabove\n---\nbelow\n",
+		"malformed yaml":    "---\ntitle: [unclosed\n---\nbody\n",
+	}
+
+	for name, content := range inputs {
+		t.Run(name, func(t *testing.T) {
+			wantFM, wantBody, wantErr := Parse(content)
+			fm, body, err := ParseFromBytes([]byte(content))
+			if (err != nil) != (wantErr != nil) {
+				t.Fatalf("ParseFromBytes() error = %v, Parse() error = %v", err, wantErr)
+			}
+			if !reflect.DeepEqual(fm, wantFM) {
+				t.Errorf("ParseFromBytes() gotFM = %+v, want %+v", fm, wantFM)
+			}
+			if body != wantBody {
+				t.Errorf("ParseFromBytes() gotBody = %q, want %q", body, wantBody)
+			}
+		})
+	}
+}
+
+func TestParsePartialMatchesParse(t *testing.T) {
+	lf := "---\nid: n-1\ntitle: Note\ntags: [a, b]\ncreated: 2023-01-01 10:00:00\nmodified: 2023-01-01 10:00:00\n---\n\n# Heading\n\nBody.\n"
+	inputs := map[string]string{
+		"LF":                 lf,
+		"BOM + CRLF":         "\ufeff" + strings.ReplaceAll(lf, "\n", "\r\n"),
+		"no frontmatter":     "\ufeff# Title\r\n\r\nBody",
+		"unclosed":           "---\ntitle: x\n# Body\n",
+		"closing without LF": "---\ntitle: x\n---",
+		"empty header":       "---\n\n---\nbody\n",
+		"no header line":     "---\n---\nbody\n",
+		"empty body":         "---\ntitle: x\n---\n",
+		"delimiter in body":  "---\ntitle: x\n---\nabove\n---\nbelow\n",
+		"malformed yaml":     "---\ntitle: [unclosed\n---\nbody\n",
+	}
+
+	for name, content := range inputs {
+		t.Run(name, func(t *testing.T) {
+			wantFM, _, wantErr := Parse(content)
+			fm, err := ParsePartial(content)
+			if (err != nil) != (wantErr != nil) {
+				t.Fatalf("ParsePartial() error = %v, Parse() error = %v", err, wantErr)
+			}
+			if !reflect.DeepEqual(fm, wantFM) {
+				t.Errorf("ParsePartial() gotFM = %+v, want %+v", fm, wantFM)
+			}
+		})
+	}
+}
+
 func TestRoundTripWithColonInTitle(t *testing.T) {
 	// Test that titles with colons round-trip correctly (regression test for double-frontmatter bug)
 	original := &Frontmatter{
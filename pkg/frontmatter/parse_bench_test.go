@@ -0,0 +1,73 @@
+package frontmatter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// benchmarkNotes builds a ~10MB collection of 2,000 notes of ~5KB each,
+// shaped like real notes: frontmatter followed by a markdown body.
+func benchmarkNotes() [][]byte {
+	body := strings.Repeat("Some prose with a [[link]] and a #tag in it.\n- [ ] a todo item\n", 75)
+	notes := make([][]byte, 2000)
+	for i := range notes {
+		notes[i] = []byte(fmt.Sprintf("---\nid: note-%d\ntitle: Note %d\naliases: []\ntags: [bench, n%d]\ncreated: 2024-01-01T10:00:00Z\nmodified: 2024-01-02T10:00:00Z\n---\n\n# Note %d\n\n%s", i, i, i%10, i, body))
+	}
+	return notes
+}
+
+func BenchmarkParse(b *testing.B) {
+	notes := benchmarkNotes()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, data := range notes {
+			if _, _, err := Parse(string(data)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkParseFromBytes(b *testing.B) {
+	notes := benchmarkNotes()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, data := range notes {
+			if _, _, err := ParseFromBytes(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// benchmarkLargeNote is a single ~10KB note, the size at which skipping the
+// body starts to matter for ParsePartial.
+func benchmarkLargeNote() string {
+	body := strings.Repeat("Some prose with a [[link]] and a #tag in it.\n- [ ] a todo item\n", 150)
+	return "---\nid: large\ntitle: Large note\naliases: []\ntags: [bench]\ncreated: 2024-01-01T10:00:00Z\nmodified: 2024-01-02T10:00:00Z\n---\n\n# Large note\n\n" + body
+}
+
+func BenchmarkParseLargeNote(b *testing.B) {
+	note := benchmarkLargeNote()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := Parse(note); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParsePartialLargeNote(b *testing.B) {
+	note := benchmarkLargeNote()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParsePartial(note); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
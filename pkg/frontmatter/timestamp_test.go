@@ -60,3 +60,107 @@ func TestFormatTimestampWritesUTCRFC3339(t *testing.T) {
 		t.Errorf("round-trip = %v, want %v", back, in)
 	}
 }
+
+// withTimestampFormat applies a timestamp configuration for the duration of a
+// test and restores the defaults afterwards.
+func withTimestampFormat(t *testing.T, layout string, loc *time.Location) {
+	t.Helper()
+	SetTimestampFormat(layout, loc)
+	t.Cleanup(func() { SetTimestampFormat("", nil) })
+}
+
+func TestTimestampRoundTripAcrossTimezones(t *testing.T) {
+	instant := time.Date(2026, 3, 8, 23, 45, 10, 0, time.UTC)
+	zones := []*time.Location{
+		time.UTC,
+		time.FixedZone("PST", -8*3600),
+		time.FixedZone("IST", 5*3600+30*60),
+		time.FixedZone("NZDT", 13*3600),
+	}
+	layouts := []string{time.RFC3339, legacyTimestampFormat, "2006-01-02T15:04:05"}
+
+	for _, loc := range zones {
+		for _, layout := range layouts {
+			t.Run(loc.String()+"/"+layout, func(t *testing.T) {
+				withTimestampFormat(t, layout, loc)
+
+				s := FormatTimestamp(instant)
+				got, err := ParseTimestamp(s)
+				if err != nil {
+					t.Fatalf("ParseTimestamp(%q) error: %v", s, err)
+				}
+				if !got.Equal(instant) {
+					t.Errorf("round-trip of %q drifted by %v", s, got.Sub(instant))
+				}
+				if got.Location() != loc {
+					t.Errorf("parsed location = %v, want %v", got.Location(), loc)
+				}
+			})
+		}
+	}
+}
+
+func TestParseTimestampLegacyUsesConfiguredLocation(t *testing.T) {
+	pst := time.FixedZone("PST", -8*3600)
+	withTimestampFormat(t, "", pst)
+
+	got, err := ParseTimestamp("2025-01-11 10:00:00")
+	if err != nil {
+		t.Fatalf("ParseTimestamp(legacy) error: %v", err)
+	}
+	want := time.Date(2025, 1, 11, 18, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("ParseTimestamp(legacy) = %v, want %v", got, want)
+	}
+
+	// Values with an explicit offset are unaffected by the configured zone.
+	got, err = ParseTimestamp("2026-06-11T09:30:00Z")
+	if err != nil {
+		t.Fatalf("ParseTimestamp(RFC3339) error: %v", err)
+	}
+	if want := time.Date(2026, 6, 11, 9, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ParseTimestamp(RFC3339) = %v, want %v", got, want)
+	}
+}
+
+func TestParseTimestampAcceptsOldFormatsAfterChange(t *testing.T) {
+	withTimestampFormat(t, "02 Jan 2006 15:04:05", time.UTC)
+
+	want := time.Date(2025, 1, 11, 10, 0, 0, 0, time.UTC)
+	for _, s := range []string{"11 Jan 2025 10:00:00", "2025-01-11T10:00:00Z", "2025-01-11 10:00:00"} {
+		got, err := ParseTimestamp(s)
+		if err != nil {
+			t.Fatalf("ParseTimestamp(%q) error: %v", s, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseTimestamp(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestValidateTimestampLayout(t *testing.T) {
+	for _, layout := range []string{time.RFC3339, legacyTimestampFormat, "2006-01-02T15:04:05"} {
+		if err := ValidateTimestampLayout(layout); err != nil {
+			t.Errorf("ValidateTimestampLayout(%q) = %v, want nil", layout, err)
+		}
+	}
+	for _, layout := range []string{"2006-01-02", "15:04", "not a layout"} {
+		if err := ValidateTimestampLayout(layout); err == nil {
+			t.Errorf("ValidateTimestampLayout(%q) = nil, want error", layout)
+		}
+	}
+}
+
+func TestDisplayLocationDefaultsToLocal(t *testing.T) {
+	withTimestampFormat(t, "", time.UTC)
+	if got := DisplayLocation(); got != time.Local {
+		t.Errorf("DisplayLocation() = %v, want the system zone: writing in UTC must not move displayed times", got)
+	}
+
+	pst := time.FixedZone("PST", -8*3600)
+	SetDisplayLocation(pst)
+	t.Cleanup(func() { SetDisplayLocation(nil) })
+	if got := DisplayLocation(); got != pst {
+		t.Errorf("DisplayLocation() = %v, want the explicitly set %v", got, pst)
+	}
+}
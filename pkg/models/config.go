@@ -6,6 +6,20 @@ type FilenameFormat string
 // PlanConfigFilename is the name of the plan configuration file within a plan directory.
 const PlanConfigFilename = ".grove-plan.yml"
 
+// GroupConfigFilename is the name of the optional group configuration file
+// within a group directory.
+const GroupConfigFilename = ".nb-group.yml"
+
+// GroupConfig is the contents of a group's .nb-group.yml.
+type GroupConfig struct {
+	Name        string `yaml:"name,omitempty" json:"name,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	// Sort is the default sort order for the group's notes (e.g. "modified").
+	Sort string `yaml:"sort,omitempty" json:"sort,omitempty"`
+	// Icon overrides the icon shown for the group in the TUI.
+	Icon string `yaml:"icon,omitempty" json:"icon,omitempty"`
+}
+
 const (
 	// FilenameFormatTimestampTitle uses YYYYMMDD-HHMMSS-title.md
 	FilenameFormatTimestampTitle FilenameFormat = "timestamp-title"
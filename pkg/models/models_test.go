@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -121,6 +122,40 @@ func TestEmptyNote(t *testing.T) {
 	}
 }
 
+func TestNoteToMarkdown(t *testing.T) {
+	content := "---\nid: abc\ntitle: Hello\n---\n\n# Hello\n\nBody text.\n"
+	note := &Note{Content: content}
+
+	if got := note.ToMarkdown(true); got != content {
+		t.Errorf("ToMarkdown(true) = %q, want %q", got, content)
+	}
+	if got, want := note.ToMarkdown(false), "# Hello\n\nBody text.\n"; got != want {
+		t.Errorf("ToMarkdown(false) = %q, want %q", got, want)
+	}
+
+	plain := &Note{Content: "# No frontmatter\n"}
+	if got := plain.ToMarkdown(false); got != plain.Content {
+		t.Errorf("ToMarkdown(false) without frontmatter = %q, want content unchanged", got)
+	}
+
+	malformed := &Note{Content: "---\ntitle: [unclosed\n---\nbody\n"}
+	if got := malformed.ToMarkdown(false); got != malformed.Content {
+		t.Errorf("ToMarkdown(false) with malformed frontmatter = %q, want content unchanged", got)
+	}
+}
+
+func TestNoteExcerpt(t *testing.T) {
+	note := &Note{Content: "---\ntitle: T\n---\n\n# Heading\n\n  first line  \n\nsecond\nthird\n"}
+	got := note.Excerpt(3)
+	want := []string{"# Heading", "first line", "second"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Excerpt(3) = %q, want %q", got, want)
+	}
+	if got := (&Note{Content: "\n\n"}).Excerpt(5); len(got) != 0 {
+		t.Errorf("Excerpt of blank note = %q, want none", got)
+	}
+}
+
 // Helper functions for testing
 func isValidNoteType(nt NoteType) bool {
 	validTypes := []NoteType{
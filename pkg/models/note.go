@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
 
 // NoteType represents the type of note
 type NoteType string
@@ -17,6 +22,12 @@ type RemoteMetadata struct {
 	Milestone string    `json:"milestone,omitempty"`
 }
 
+// Reminder is a note's pending notification.
+type Reminder struct {
+	At      time.Time `json:"at"`
+	Message string    `json:"message,omitempty"`
+}
+
 // Note represents a note file
 type Note struct {
 	Path             string    `json:"path"`
@@ -37,8 +48,15 @@ type Note struct {
 	IsArchived       bool      `json:"is_archived"`
 	IsArtifact       bool      `json:"is_artifact,omitempty"`
 	PlanRef          string    `json:"plan_ref,omitempty"`
-	PlanJob          string    `json:"plan_job,omitempty"` // Promoted job's filename (per-job linkage)
-	Priority         string    `json:"priority,omitempty"` // p0 (most critical) .. p3, empty = none
+	PlanJob          string    `json:"plan_job,omitempty"`  // Promoted job's filename (per-job linkage)
+	LinkRef          string    `json:"link_ref,omitempty"`  // Linked note, relative to this note's directory
+	LinkType         string    `json:"link_type,omitempty"` // reference, parent or followup
+	Backlinks        []string  `json:"backlinks,omitempty"` // Notes linking here, relative to this note's directory
+	Priority         string    `json:"priority,omitempty"`  // p0 (most critical) .. p3, empty = none
+	Flag             string    `json:"flag,omitempty"`      // Triage flag from the configured palette
+
+	// Reminder set with nb reminder set
+	Reminder *Reminder `json:"reminder,omitempty"`
 
 	// Remote sync metadata
 	Remote *RemoteMetadata `json:"remote,omitempty"`
@@ -49,3 +67,33 @@ type Note struct {
 	Tags       []string `json:"tags"`
 	Repository string   `json:"repository,omitempty"`
 }
+
+// ToMarkdown renders the note's content as markdown. With includeFrontmatter
+// the YAML frontmatter block is kept; otherwise only the body is returned, with
+// the blank lines that separated it from the frontmatter trimmed. Content with
+// malformed frontmatter is treated as all body, so nothing is lost.
+func (n *Note) ToMarkdown(includeFrontmatter bool) string {
+	if includeFrontmatter {
+		return n.Content
+	}
+	fm, body, err := frontmatter.Parse(n.Content)
+	if err != nil || fm == nil {
+		return n.Content
+	}
+	return strings.TrimLeft(body, "\n")
+}
+
+// Excerpt returns up to maxLines non-blank lines from the start of the note's
+// body (frontmatter excluded), with surrounding whitespace trimmed.
+func (n *Note) Excerpt(maxLines int) []string {
+	var lines []string
+	for _, line := range strings.Split(n.ToMarkdown(false), "\n") {
+		if len(lines) >= maxLines {
+			break
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// AttachmentsDirName is the directory, next to a note, that holds one
+// subdirectory of attached files per note (attachments/<note-stem>/).
+const AttachmentsDirName = "attachments"
+
+// imageExtensions are linked with markdown image syntax; anything else gets
+// a plain link.
+var imageExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true,
+	".svg": true, ".webp": true, ".bmp": true,
+}
+
+// NoteAttachmentDir returns the directory attachments of the note at
+// notePath are copied into: attachments/<stem> beside the note.
+func NoteAttachmentDir(notePath string) string {
+	stem := strings.TrimSuffix(filepath.Base(notePath), filepath.Ext(notePath))
+	return filepath.Join(filepath.Dir(notePath), AttachmentsDirName, stem)
+}
+
+// CreateNoteWithAttachment creates a note like CreateNote and then attaches
+// each file in attachmentPaths with AddAttachments. All attachments are
+// checked before the note is written, so a missing file creates nothing, and
+// if attaching still fails the note and any copied files are removed again.
+// When the options ask for an editor, it opens after the references are
+// added.
+func (s *Service) CreateNoteWithAttachment(ctx *WorkspaceContext, noteType models.NoteType, title string, attachmentPaths []string, options ...CreateOption) (*models.Note, error) {
+	if err := validateAttachments(attachmentPaths); err != nil {
+		return nil, err
+	}
+	opts := &createOptions{openEditor: true}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	note, err := s.CreateNote(ctx, noteType, title, append(options, WithoutEditor())...)
+	if err != nil {
+		return nil, err
+	}
+	if copied, err := s.AddAttachments(note.Path, attachmentPaths); err != nil {
+		s.discardNote(note.Path, copied)
+		return nil, err
+	}
+	if updated, err := ParseNote(note.Path); err == nil {
+		updated.Workspace, updated.Branch, updated.Type = note.Workspace, note.Branch, note.Type
+		note = updated
+	}
+
+	if opts.openEditor && s.Config.Editor != "" {
+		if err := s.openInEditor(note.Path); err != nil {
+			s.opLog("create", note.Path, note.Workspace).WithError(err).Warn("Failed to open editor")
+		}
+	}
+	return note, nil
+}
+
+// discardNote deletes a note CreateNoteWithAttachment just created along with
+// the attachments already copied for it, and their directories once empty.
+func (s *Service) discardNote(notePath string, copied []string) {
+	for _, path := range copied {
+		_ = os.Remove(path)
+	}
+	// os.Remove leaves non-empty directories alone.
+	dir := NoteAttachmentDir(notePath)
+	for _, d := range []string{dir, filepath.Dir(dir)} {
+		if info, err := os.Stat(d); err == nil && info.IsDir() {
+			_ = os.Remove(d)
+		}
+	}
+	if err := s.DeleteNote(notePath); err != nil {
+		s.opLog("attach", notePath, "").WithError(err).Warn("Failed to remove note after attaching failed")
+	}
+}
+
+// AddAttachments copies each file into the note's attachment directory and
+// appends a markdown reference to it at the end of the note: an image embed
+// for image files, a link otherwise. A file whose name is already taken in
+// the directory gets a numeric suffix. It returns the copied paths.
+func (s *Service) AddAttachments(notePath string, attachmentPaths []string) ([]string, error) {
+	if len(attachmentPaths) == 0 {
+		return nil, nil
+	}
+	if err := validateAttachments(attachmentPaths); err != nil {
+		return nil, err
+	}
+
+	dir := NoteAttachmentDir(notePath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create attachment directory: %w", err)
+	}
+
+	var copied, refs []string
+	for _, src := range attachmentPaths {
+		dst := uniqueAttachmentPath(dir, filepath.Base(src))
+		if err := copyFile(src, dst); err != nil {
+			return copied, fmt.Errorf("copy attachment %s: %w", src, err)
+		}
+		copied = append(copied, dst)
+		refs = append(refs, attachmentReference(notePath, dst))
+	}
+
+	unlock, err := s.LockNotebook(notePath)
+	if err != nil {
+		return copied, err
+	}
+	defer unlock()
+	err = rewriteNote(notePath, func(content []byte) ([]byte, error) {
+		return []byte(strings.TrimRight(string(content), "\n") + "\n\n" + strings.Join(refs, "\n") + "\n"), nil
+	})
+	if err != nil {
+		return copied, fmt.Errorf("write note: %w", err)
+	}
+
+	ws, _, _ := GetNoteMetadata(notePath)
+	s.opLog("attach", notePath, ws).WithField("count", len(copied)).Info("Added attachments")
+	return copied, nil
+}
+
+// attachmentReference renders the markdown reference for an attachment,
+// relative to the note so it keeps working when the notebook moves.
+func attachmentReference(notePath, attachmentPath string) string {
+	rel, err := filepath.Rel(filepath.Dir(notePath), attachmentPath)
+	if err != nil {
+		rel = attachmentPath
+	}
+	target := strings.ReplaceAll(filepath.ToSlash(rel), " ", "%20")
+	name := filepath.Base(attachmentPath)
+	if imageExtensions[strings.ToLower(filepath.Ext(name))] {
+		return fmt.Sprintf("![%s](%s)", name, target)
+	}
+	return fmt.Sprintf("[%s](%s)", name, target)
+}
+
+// GetNoteAttachments returns the absolute paths of the files in the note's
+// attachment directory (attachments/<stem> beside it), sorted by name. Files
+// in subdirectories are included. A note without attachments returns none.
+func (s *Service) GetNoteAttachments(notePath string) ([]string, error) {
+	abs, err := filepath.Abs(notePath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve note path: %w", err)
+	}
+	dir := NoteAttachmentDir(abs)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read attachment directory: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// RemoveAttachment deletes one of the note's attachments, given by its path
+// or by its path within the attachment directory, and removes the reference
+// AddAttachments wrote for it: a line holding exactly that reference. Other
+// mentions of the file are left for the user to edit. The attachment
+// directory is removed once it is empty. It returns the deleted file's path.
+func (s *Service) RemoveAttachment(notePath, attachment string) (string, error) {
+	abs, err := filepath.Abs(notePath)
+	if err != nil {
+		return "", fmt.Errorf("resolve note path: %w", err)
+	}
+	dir := NoteAttachmentDir(abs)
+	// A name within the directory wins over a path relative to the cwd.
+	path := filepath.Join(dir, attachment)
+	if filepath.IsAbs(attachment) {
+		path = filepath.Clean(attachment)
+	} else if _, err := os.Stat(path); err != nil {
+		if cwdPath, err := filepath.Abs(attachment); err == nil {
+			path = cwdPath
+		}
+	}
+	if !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is not an attachment of %s", attachment, filepath.Base(abs))
+	}
+	if info, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("attachment %s: %w", attachment, err)
+	} else if info.IsDir() {
+		return "", fmt.Errorf("attachment %s is a directory", attachment)
+	}
+
+	unlock, err := s.LockNotebook(abs)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	if err := os.Remove(path); err != nil {
+		return "", fmt.Errorf("remove attachment: %w", err)
+	}
+	// Drop the now-empty directories up to and including the note's own.
+	for d := filepath.Dir(path); strings.HasPrefix(d, dir); d = filepath.Dir(d) {
+		if os.Remove(d) != nil {
+			break
+		}
+	}
+
+	ref := attachmentReference(abs, path)
+	err = rewriteNote(abs, func(content []byte) ([]byte, error) {
+		var kept []string
+		removed := false
+		for _, line := range strings.SplitAfter(string(content), "\n") {
+			if strings.TrimRight(line, "\r\n") == ref {
+				removed = true
+				continue
+			}
+			kept = append(kept, line)
+		}
+		if !removed {
+			return content, nil
+		}
+		return []byte(strings.TrimRight(strings.Join(kept, ""), "\n") + "\n"), nil
+	})
+	if err != nil {
+		return path, fmt.Errorf("write note: %w", err)
+	}
+
+	ws, _, _ := GetNoteMetadata(abs)
+	s.opLog("detach", abs, ws).WithField("attachment", path).Info("Removed attachment")
+	return path, nil
+}
+
+func validateAttachments(paths []string) error {
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return fmt.Errorf("attachment %s: %w", p, err)
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("attachment %s is not a regular file", p)
+		}
+	}
+	return nil
+}
+
+// uniqueAttachmentPath returns dir/name, or dir/<stem>-N<ext> if that is
+// taken.
+func uniqueAttachmentPath(dir, name string) string {
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	candidate := filepath.Join(dir, name)
+	for n := 2; ; n++ {
+		if _, err := os.Stat(candidate); os.IsNotExist(err) {
+			return candidate
+		}
+		candidate = filepath.Join(dir, fmt.Sprintf("%s-%d%s", stem, n, ext))
+	}
+}
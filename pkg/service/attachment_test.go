@@ -0,0 +1,131 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddAttachments(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	captureNoteEvents(t)
+	root := t.TempDir()
+	notePath := filepath.Join(root, "inbox", "20240101-design.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(notePath), 0o755))
+	require.NoError(t, os.WriteFile(notePath, []byte("---\ntitle: Design\n---\n# Design\n"), 0o644))
+
+	srcDir := t.TempDir()
+	image := filepath.Join(srcDir, "sketch one.png")
+	data := filepath.Join(srcDir, "data.csv")
+	require.NoError(t, os.WriteFile(image, []byte("png"), 0o644))
+	require.NoError(t, os.WriteFile(data, []byte("a,b"), 0o644))
+
+	s := newTestService()
+	copied, err := s.AddAttachments(notePath, []string{image, data})
+	require.NoError(t, err)
+
+	dir := filepath.Join(root, "inbox", "attachments", "20240101-design")
+	assert.Equal(t, dir, NoteAttachmentDir(notePath))
+	assert.Equal(t, []string{filepath.Join(dir, "sketch one.png"), filepath.Join(dir, "data.csv")}, copied)
+
+	content, err := os.ReadFile(notePath)
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: Design\n---\n# Design\n\n"+
+		"![sketch one.png](attachments/20240101-design/sketch%20one.png)\n"+
+		"[data.csv](attachments/20240101-design/data.csv)\n", string(content))
+
+	// Attaching the same file again keeps the first copy.
+	copied, err = s.AddAttachments(notePath, []string{data})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "data-2.csv")}, copied)
+
+	_, err = s.AddAttachments(notePath, []string{filepath.Join(srcDir, "missing.pdf")})
+	assert.Error(t, err)
+	_, err = s.AddAttachments(notePath, []string{srcDir})
+	assert.Error(t, err, "directories are rejected")
+}
+
+func TestCreateNoteWithAttachmentCleansUpOnFailure(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	captureNoteEvents(t)
+	s, err := New(&Config{}, nil, nil, nil)
+	require.NoError(t, err)
+	ws := &coreworkspace.WorkspaceNode{Name: "proj", Path: t.TempDir()}
+	ctx := &WorkspaceContext{NotebookContextWorkspace: ws, CurrentWorkspace: ws}
+
+	src := filepath.Join(t.TempDir(), "data.csv")
+	require.NoError(t, os.WriteFile(src, []byte("a,b"), 0o644))
+	// A file where the attachments directory should go makes copying fail
+	// after the note is written.
+	inbox := filepath.Join(ws.Path, ".notebook", "notes", "inbox")
+	require.NoError(t, os.MkdirAll(inbox, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(inbox, AttachmentsDirName), nil, 0o644))
+
+	note, err := s.CreateNoteWithAttachment(ctx, "inbox", "Report", []string{src}, WithoutEditor())
+	require.Error(t, err)
+	assert.Nil(t, note)
+	entries, err := os.ReadDir(inbox)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "the note is removed again")
+	assert.Equal(t, AttachmentsDirName, entries[0].Name())
+}
+
+func TestGetNoteAttachmentsAndRemove(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	captureNoteEvents(t)
+	root := t.TempDir()
+	notePath := filepath.Join(root, "20240101-design.md")
+	require.NoError(t, os.WriteFile(notePath, []byte("# Design\n"), 0o600))
+
+	s := newTestService()
+	files, err := s.GetNoteAttachments(notePath)
+	require.NoError(t, err)
+	assert.Empty(t, files, "no attachment directory yet")
+
+	srcDir := t.TempDir()
+	var srcs []string
+	for _, name := range []string{"b.csv", "a.png"} {
+		src := filepath.Join(srcDir, name)
+		require.NoError(t, os.WriteFile(src, []byte(name), 0o644))
+		srcs = append(srcs, src)
+	}
+	_, err = s.AddAttachments(notePath, srcs)
+	require.NoError(t, err)
+
+	dir := NoteAttachmentDir(notePath)
+	files, err = s.GetNoteAttachments(notePath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "a.png"), filepath.Join(dir, "b.csv")}, files)
+
+	// A mention of the attachment in prose is not the reference attach wrote.
+	content, err := os.ReadFile(notePath)
+	require.NoError(t, err)
+	prose := "See the [raw numbers](attachments/20240101-design/b.csv) too.\n"
+	require.NoError(t, os.WriteFile(notePath, append(content, []byte(prose)...), 0o600))
+
+	removed, err := s.RemoveAttachment(notePath, "b.csv")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "b.csv"), removed)
+	content, err = os.ReadFile(notePath)
+	require.NoError(t, err)
+	assert.Equal(t, "# Design\n\n![a.png](attachments/20240101-design/a.png)\n"+prose, string(content))
+	info, err := os.Stat(notePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "the note keeps its mode")
+
+	_, err = s.RemoveAttachment(notePath, "../../20240101-design.md")
+	assert.Error(t, err, "only files in the attachment directory can be removed")
+	_, err = s.RemoveAttachment(notePath, "missing.pdf")
+	assert.Error(t, err)
+
+	_, err = s.RemoveAttachment(notePath, filepath.Join(dir, "a.png"))
+	require.NoError(t, err)
+	assert.NoDirExists(t, dir, "the emptied directory is removed")
+	files, err = s.GetNoteAttachments(notePath)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// DefaultAutoArchiveGroups are the groups AutoArchive sweeps when none are
+// given: finished work and closed remote issues/PRs.
+var DefaultAutoArchiveGroups = []string{"completed", ".closed"}
+
+// AutoArchiveCandidates returns the notes in the workspace that AutoArchive
+// would move: notes in one of groups (DefaultAutoArchiveGroups when empty)
+// last modified more than olderThan ago, oldest first. Notes already in an
+// .archive directory are never candidates.
+func (s *Service) AutoArchiveCandidates(ctx *WorkspaceContext, olderThan time.Duration, groups []string) ([]*models.Note, error) {
+	if olderThan <= 0 {
+		return nil, fmt.Errorf("auto-archive age must be positive, got %s", olderThan)
+	}
+	if len(groups) == 0 {
+		groups = DefaultAutoArchiveGroups
+	}
+	notes, err := s.ListAllNotes(ctx, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("list notes for auto-archive: %w", err)
+	}
+	return selectAutoArchiveCandidates(notes, time.Now().Add(-olderThan), groups), nil
+}
+
+// AutoArchive archives the notes returned by AutoArchiveCandidates with
+// ArchiveNotes and returns their original paths. Archived notes leave the
+// swept groups, so running it again only picks up newly stale notes.
+func (s *Service) AutoArchive(ctx *WorkspaceContext, olderThan time.Duration, groups []string) ([]string, error) {
+	candidates, err := s.AutoArchiveCandidates(ctx, olderThan, groups)
+	if err != nil {
+		return nil, err
+	}
+	paths := make([]string, 0, len(candidates))
+	for _, note := range candidates {
+		paths = append(paths, note.Path)
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	if err := s.ArchiveNotes(ctx, paths); err != nil {
+		return nil, fmt.Errorf("auto-archive: %w", err)
+	}
+	return paths, nil
+}
+
+// selectAutoArchiveCandidates keeps the notes modified before cutoff whose
+// group matches one of groups, oldest first. A group matches either the whole
+// note group ("github-issues/.closed") or any one of its path segments
+// (".closed").
+func selectAutoArchiveCandidates(notes []*models.Note, cutoff time.Time, groups []string) []*models.Note {
+	var candidates []*models.Note
+	for _, note := range notes {
+		if note == nil || !note.ModifiedAt.Before(cutoff) {
+			continue
+		}
+		if strings.Contains(note.Path, "/.archive/") {
+			continue
+		}
+		if noteInGroups(note.Group, groups) {
+			candidates = append(candidates, note)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].ModifiedAt.Before(candidates[j].ModifiedAt)
+	})
+	return candidates
+}
+
+func noteInGroups(group string, groups []string) bool {
+	if group == "" {
+		return false
+	}
+	segments := strings.Split(group, "/")
+	for _, g := range groups {
+		g = strings.Trim(g, "/")
+		if g == group {
+			return true
+		}
+		for _, seg := range segments {
+			if seg == g {
+				return true
+			}
+		}
+	}
+	return false
+}
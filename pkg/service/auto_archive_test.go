@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestSelectAutoArchiveCandidates(t *testing.T) {
+	now := time.Now()
+	cutoff := now.Add(-30 * 24 * time.Hour)
+	old := now.Add(-60 * 24 * time.Hour)
+	older := now.Add(-90 * 24 * time.Hour)
+
+	notes := []*models.Note{
+		{Path: "/nb/completed/old.md", Group: "completed", ModifiedAt: old},
+		{Path: "/nb/completed/fresh.md", Group: "completed", ModifiedAt: now},
+		{Path: "/nb/github-issues/.closed/issue-1.md", Group: "github-issues/.closed", ModifiedAt: older},
+		{Path: "/nb/inbox/stale.md", Group: "inbox", ModifiedAt: older},
+		{Path: "/nb/completed/.archive/gone.md", Group: "completed", ModifiedAt: older},
+	}
+
+	got := selectAutoArchiveCandidates(notes, cutoff, DefaultAutoArchiveGroups)
+	var paths []string
+	for _, n := range got {
+		paths = append(paths, n.Path)
+	}
+	assert.Equal(t, []string{"/nb/github-issues/.closed/issue-1.md", "/nb/completed/old.md"}, paths, "oldest first, archived and fresh notes skipped")
+
+	got = selectAutoArchiveCandidates(notes, cutoff, []string{"inbox"})
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, "/nb/inbox/stale.md", got[0].Path)
+	}
+	assert.Empty(t, selectAutoArchiveCandidates(notes, cutoff, []string{"plans"}))
+}
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// BackupManifestName is the file written into a backup destination directory
+// that records every backup taken there.
+const BackupManifestName = ".nb-backup-manifest.json"
+
+// backupTimestampFormat is used in archive file names.
+const backupTimestampFormat = "20060102-150405"
+
+// BackupManifest is the on-disk contents of BackupManifestName.
+type BackupManifest struct {
+	Backups []BackupRecord `json:"backups"`
+}
+
+// BackupRecord describes a single archive created by BackupWorkspace.
+type BackupRecord struct {
+	Workspace   string    `json:"workspace"`
+	Source      string    `json:"source"`
+	Archive     string    `json:"archive"`
+	CreatedAt   time.Time `json:"created_at"`
+	Incremental bool      `json:"incremental"`
+	// Since is the timestamp of the backup this one builds on. Only files
+	// modified after it, or missing from the backups it builds on, were
+	// included. Zero for full backups.
+	Since time.Time `json:"since,omitempty"`
+	Files []string  `json:"files"`
+}
+
+// Latest returns the most recent backup of the given workspace, or nil.
+func (m *BackupManifest) Latest(workspace string) *BackupRecord {
+	var latest *BackupRecord
+	for i := range m.Backups {
+		b := &m.Backups[i]
+		if b.Workspace != workspace {
+			continue
+		}
+		if latest == nil || b.CreatedAt.After(latest.CreatedAt) {
+			latest = b
+		}
+	}
+	return latest
+}
+
+// backedUpFiles returns the paths archived by the workspace's most recent full
+// backup and every backup taken after it, i.e. the chain an incremental backup
+// builds on.
+func (m *BackupManifest) backedUpFiles(workspace string) map[string]bool {
+	var fullAt time.Time
+	for _, b := range m.Backups {
+		if b.Workspace == workspace && !b.Incremental && b.CreatedAt.After(fullAt) {
+			fullAt = b.CreatedAt
+		}
+	}
+	files := make(map[string]bool)
+	for _, b := range m.Backups {
+		if b.Workspace != workspace || b.CreatedAt.Before(fullAt) {
+			continue
+		}
+		for _, f := range b.Files {
+			files[f] = true
+		}
+	}
+	return files
+}
+
+// BackupOption configures BackupWorkspace.
+type BackupOption func(*backupOptions)
+
+type backupOptions struct {
+	incremental bool
+}
+
+// Incremental makes BackupWorkspace include only files modified since the
+// previous backup of the same workspace in destDir, plus files none of the
+// backups it builds on contain (e.g. new notes with a backdated mtime). When
+// there is no previous backup a full one is taken.
+func Incremental() BackupOption {
+	return func(o *backupOptions) {
+		o.incremental = true
+	}
+}
+
+// ReadBackupManifest loads the manifest in destDir. A missing manifest yields
+// an empty one.
+func ReadBackupManifest(destDir string) (*BackupManifest, error) {
+	data, err := os.ReadFile(filepath.Join(destDir, BackupManifestName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &BackupManifest{}, nil
+		}
+		return nil, fmt.Errorf("read backup manifest: %w", err)
+	}
+	var m BackupManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse backup manifest: %w", err)
+	}
+	return &m, nil
+}
+
+func writeBackupManifest(destDir string, m *BackupManifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode backup manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(destDir, BackupManifestName), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write backup manifest: %w", err)
+	}
+	return nil
+}
+
+// BackupWorkspace writes a timestamped .tar.gz of the workspace's notebook
+// directory into destDir and records it in destDir's manifest. Paths inside
+// the archive are relative to the notebook directory and file modification
+// times are preserved so RestoreBackup can put them back with os.Chtimes.
+//
+// Incremental backups only track additions and modifications; files deleted
+// since the previous backup are not recorded.
+func (s *Service) BackupWorkspace(ctx *WorkspaceContext, destDir string, opts ...BackupOption) error {
+	var o backupOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	sourceDir, err := s.workspaceNotebookDir(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = backupDir(ctx.NotebookContextWorkspace.Name, sourceDir, destDir, o)
+	return err
+}
+
+// RestoreWorkspaceBackup extracts archivePath into the workspace's notebook
+// directory. See RestoreBackup.
+func (s *Service) RestoreWorkspaceBackup(ctx *WorkspaceContext, archivePath string, overwrite bool) ([]string, error) {
+	destRoot, err := s.workspaceNotebookDir(ctx)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := s.LockNotebook(destRoot)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return RestoreBackup(archivePath, destRoot, overwrite)
+}
+
+// workspaceNotebookDir returns the root notes directory of ctx's notebook.
+func (s *Service) workspaceNotebookDir(ctx *WorkspaceContext) (string, error) {
+	if ctx == nil || ctx.NotebookContextWorkspace == nil {
+		return "", fmt.Errorf("no workspace context")
+	}
+	dir, err := s.notebookLocator.GetNotesDir(ctx.NotebookContextWorkspace, "")
+	if err != nil {
+		return "", fmt.Errorf("get notebook dir: %w", err)
+	}
+	return dir, nil
+}
+
+// backupDir implements BackupWorkspace for an already resolved source
+// directory and returns the record that was appended to the manifest.
+func backupDir(workspace, sourceDir, destDir string, o backupOptions) (*BackupRecord, error) {
+	if _, err := os.Stat(sourceDir); err != nil {
+		return nil, fmt.Errorf("stat notebook dir: %w", err)
+	}
+	if err := os.MkdirAll(destDir, 0o755); err != nil {
+		return nil, fmt.Errorf("create backup dir: %w", err)
+	}
+
+	manifest, err := ReadBackupManifest(destDir)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	record := BackupRecord{
+		Workspace: workspace,
+		Source:    sourceDir,
+		CreatedAt: now,
+		Files:     []string{},
+	}
+	if o.incremental {
+		if prev := manifest.Latest(workspace); prev != nil {
+			record.Incremental = true
+			record.Since = prev.CreatedAt
+		}
+	}
+	var known map[string]bool
+	if record.Incremental {
+		known = manifest.backedUpFiles(workspace)
+	}
+
+	archivePath := uniqueBackupPath(destDir, workspace, now)
+	record.Archive = filepath.Base(archivePath)
+
+	f, err := os.Create(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("create backup archive: %w", err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	absDest, _ := filepath.Abs(destDir)
+	walkErr := filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		// Never archive the backup destination into itself.
+		if absPath, _ := filepath.Abs(path); info.IsDir() && absPath == absDest {
+			return filepath.SkipDir
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		// Mtimes can predate the previous backup for files it never saw
+		// (sync and `nb new --date` backdate them), so only skip known ones.
+		if record.Incremental && !info.ModTime().After(record.Since) && known[rel] {
+			return nil
+		}
+		if err := addFileToTar(tw, path, rel, info); err != nil {
+			return err
+		}
+		record.Files = append(record.Files, rel)
+		return nil
+	})
+
+	if err := tw.Close(); err != nil && walkErr == nil {
+		walkErr = err
+	}
+	if err := gz.Close(); err != nil && walkErr == nil {
+		walkErr = err
+	}
+	if err := f.Close(); err != nil && walkErr == nil {
+		walkErr = err
+	}
+	if walkErr != nil {
+		os.Remove(archivePath)
+		return nil, fmt.Errorf("write backup archive: %w", walkErr)
+	}
+
+	manifest.Backups = append(manifest.Backups, record)
+	if err := writeBackupManifest(destDir, manifest); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// uniqueBackupPath returns a not yet existing archive path for workspace at t.
+func uniqueBackupPath(destDir, workspace string, t time.Time) string {
+	base := fmt.Sprintf("%s-%s", workspace, t.Format(backupTimestampFormat))
+	path := filepath.Join(destDir, base+".tar.gz")
+	for i := 1; ; i++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return path
+		}
+		path = filepath.Join(destDir, fmt.Sprintf("%s-%d.tar.gz", base, i))
+	}
+}
+
+func addFileToTar(tw *tar.Writer, path, name string, info os.FileInfo) error {
+	hdr, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	_, err = io.Copy(tw, src)
+	return err
+}
+
+// RestoreBackup extracts archivePath into destRoot, restoring each file's
+// modification time. Existing files are left alone unless overwrite is set.
+// It returns the archive-relative paths that were written.
+func RestoreBackup(archivePath, destRoot string, overwrite bool) ([]string, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("open backup archive: %w", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("read backup archive: %w", err)
+	}
+	defer gz.Close()
+
+	var restored []string
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return restored, fmt.Errorf("read backup archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := filepath.FromSlash(hdr.Name)
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return restored, fmt.Errorf("refusing to restore unsafe path %q", hdr.Name)
+		}
+		target := filepath.Join(destRoot, filepath.Clean(name))
+
+		if !overwrite {
+			if _, err := os.Stat(target); err == nil {
+				continue
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return restored, fmt.Errorf("create directory: %w", err)
+		}
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, hdr.FileInfo().Mode().Perm())
+		if err != nil {
+			return restored, fmt.Errorf("restore %s: %w", hdr.Name, err)
+		}
+		_, copyErr := io.Copy(out, tr)
+		closeErr := out.Close()
+		if copyErr != nil {
+			return restored, fmt.Errorf("restore %s: %w", hdr.Name, copyErr)
+		}
+		if closeErr != nil {
+			return restored, fmt.Errorf("restore %s: %w", hdr.Name, closeErr)
+		}
+		if err := os.Chtimes(target, hdr.ModTime, hdr.ModTime); err != nil {
+			return restored, fmt.Errorf("restore times for %s: %w", hdr.Name, err)
+		}
+		restored = append(restored, hdr.Name)
+	}
+	return restored, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupDirIncrementalAndRestore(t *testing.T) {
+	base := t.TempDir()
+	source := filepath.Join(base, "notebook")
+	dest := filepath.Join(base, "backups")
+	require.NoError(t, os.MkdirAll(filepath.Join(source, "inbox"), 0o755))
+
+	old := time.Now().Add(-time.Hour)
+	for _, name := range []string{"inbox/a.md", "inbox/b.md"} {
+		path := filepath.Join(source, name)
+		require.NoError(t, os.WriteFile(path, []byte("# "+name+"\n"), 0o644))
+		require.NoError(t, os.Chtimes(path, old, old))
+	}
+
+	full, err := backupDir("ws", source, dest, backupOptions{incremental: true})
+	require.NoError(t, err)
+	assert.False(t, full.Incremental, "first backup has nothing to build on")
+	assert.Equal(t, []string{"inbox/a.md", "inbox/b.md"}, full.Files)
+
+	// Touch b after the first backup.
+	later := time.Now().Add(time.Hour)
+	bPath := filepath.Join(source, "inbox", "b.md")
+	require.NoError(t, os.WriteFile(bPath, []byte("# changed\n"), 0o644))
+	require.NoError(t, os.Chtimes(bPath, later, later))
+
+	inc, err := backupDir("ws", source, dest, backupOptions{incremental: true})
+	require.NoError(t, err)
+	assert.True(t, inc.Incremental)
+	assert.Equal(t, []string{"inbox/b.md"}, inc.Files)
+	assert.NotEqual(t, full.Archive, inc.Archive)
+
+	manifest, err := ReadBackupManifest(dest)
+	require.NoError(t, err)
+	require.Len(t, manifest.Backups, 2)
+	assert.Equal(t, inc.Archive, manifest.Latest("ws").Archive)
+	assert.Nil(t, manifest.Latest("other"))
+
+	restoreRoot := filepath.Join(base, "restored")
+	restored, err := RestoreBackup(filepath.Join(dest, full.Archive), restoreRoot, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"inbox/a.md", "inbox/b.md"}, restored)
+
+	info, err := os.Stat(filepath.Join(restoreRoot, "inbox", "a.md"))
+	require.NoError(t, err)
+	assert.WithinDuration(t, old, info.ModTime(), time.Second)
+
+	// Existing files are kept unless overwrite is requested.
+	restored, err = RestoreBackup(filepath.Join(dest, inc.Archive), restoreRoot, false)
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+
+	restored, err = RestoreBackup(filepath.Join(dest, inc.Archive), restoreRoot, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"inbox/b.md"}, restored)
+	content, err := os.ReadFile(filepath.Join(restoreRoot, "inbox", "b.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# changed\n", string(content))
+}
+
+func TestBackupDirIncrementalIncludesBackdatedNewFiles(t *testing.T) {
+	base := t.TempDir()
+	source := filepath.Join(base, "notebook")
+	dest := filepath.Join(base, "backups")
+	require.NoError(t, os.MkdirAll(filepath.Join(source, "inbox"), 0o755))
+
+	old := time.Now().Add(-time.Hour)
+	aPath := filepath.Join(source, "inbox", "a.md")
+	require.NoError(t, os.WriteFile(aPath, []byte("# a\n"), 0o644))
+	require.NoError(t, os.Chtimes(aPath, old, old))
+
+	full, err := backupDir("ws", source, dest, backupOptions{incremental: true})
+	require.NoError(t, err)
+	require.False(t, full.Incremental)
+
+	// A note created after the backup but dated before it, as sync and
+	// `nb new --date` do.
+	backdated := time.Now().Add(-24 * time.Hour)
+	newPath := filepath.Join(source, "inbox", "synced.md")
+	require.NoError(t, os.WriteFile(newPath, []byte("# synced\n"), 0o644))
+	require.NoError(t, os.Chtimes(newPath, backdated, backdated))
+
+	inc, err := backupDir("ws", source, dest, backupOptions{incremental: true})
+	require.NoError(t, err)
+	assert.True(t, inc.Incremental)
+	assert.Equal(t, []string{"inbox/synced.md"}, inc.Files)
+
+	// Once archived it is skipped like any other unchanged file.
+	again, err := backupDir("ws", source, dest, backupOptions{incremental: true})
+	require.NoError(t, err)
+	assert.Empty(t, again.Files)
+}
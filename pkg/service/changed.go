@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// statCacheTTL is how long a workspace's file walk is reused by
+// GetChangedNotes before the notebook is walked again.
+const statCacheTTL = 5 * time.Second
+
+// fileStat is the cached mtime of one note file.
+type fileStat struct {
+	path    string
+	modTime time.Time
+}
+
+// statCache holds the result of the last file walk per workspace, so repeated
+// GetChangedNotes calls (e.g. from sync loops) don't stat every file again.
+// The zero value is ready to use.
+type statCache struct {
+	mu      sync.Mutex
+	entries map[string]statCacheEntry
+}
+
+type statCacheEntry struct {
+	walkedAt time.Time
+	files    []fileStat
+}
+
+func (c *statCache) get(key string, now time.Time) ([]fileStat, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || now.Sub(entry.walkedAt) > statCacheTTL {
+		return nil, false
+	}
+	return entry.files, true
+}
+
+func (c *statCache) put(key string, now time.Time, files []fileStat) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]statCacheEntry)
+	}
+	c.entries[key] = statCacheEntry{walkedAt: now, files: files}
+}
+
+// GetChangedNotes returns the notes in ctx whose file was modified after
+// since, newest first. Unlike ListAllNotes it decides from the file mtime
+// alone and only parses the notes that changed, which keeps it cheap on large
+// notebooks. Archived notes and artifacts are skipped.
+func (s *Service) GetChangedNotes(ctx *WorkspaceContext, since time.Time) ([]*models.Note, error) {
+	files, err := s.noteFileStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []fileStat
+	for _, f := range files {
+		if f.modTime.After(since) {
+			changed = append(changed, f)
+		}
+	}
+	sort.SliceStable(changed, func(i, j int) bool {
+		return changed[i].modTime.After(changed[j].modTime)
+	})
+
+	notes := make([]*models.Note, 0, len(changed))
+	for _, f := range changed {
+		note, err := ParseNote(f.path)
+		if err != nil {
+			continue // Deleted or unreadable since the walk
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// noteFileStats returns the path and mtime of every markdown note in ctx,
+// walking the notebook only when the cached walk is older than statCacheTTL.
+func (s *Service) noteFileStats(ctx *WorkspaceContext) ([]fileStat, error) {
+	key := ctx.NotebookContextWorkspace.Path
+	now := time.Now()
+	if files, ok := s.statCache.get(key, now); ok {
+		return files, nil
+	}
+
+	contentDirs, err := s.notebookLocator.GetAllContentDirs(ctx.NotebookContextWorkspace)
+	if err != nil {
+		return nil, fmt.Errorf("get content directories: %w", err)
+	}
+
+	var files []fileStat
+	seen := make(map[string]struct{})
+	for _, contentDir := range contentDirs {
+		if _, err := os.Stat(contentDir.Path); err != nil {
+			continue
+		}
+		_ = s.walkNotebook(contentDir.Path, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil // Skip errors
+			}
+			if info.IsDir() {
+				switch info.Name() {
+				case ".archive", "archive", ".artifacts", ".git", ".grove-worktrees", ".grove":
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasPrefix(info.Name(), ".") || !strings.HasSuffix(info.Name(), ".md") {
+				return nil
+			}
+			if _, ok := seen[path]; ok {
+				return nil
+			}
+			seen[path] = struct{}{}
+			files = append(files, fileStat{path: path, modTime: info.ModTime()})
+			return nil
+		})
+	}
+
+	s.statCache.put(key, now, files)
+	return files, nil
+}
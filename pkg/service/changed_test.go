@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChangedNotesUsesMtimeAndCache(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	captureNoteEvents(t)
+	s, err := New(&Config{}, nil, nil, nil)
+	require.NoError(t, err)
+	ws := &coreworkspace.WorkspaceNode{Name: "ws", Path: t.TempDir()}
+	ctx := &WorkspaceContext{NotebookContextWorkspace: ws, CurrentWorkspace: ws}
+
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+	create := func(title string, createdAt time.Time) string {
+		note, err := s.CreateNote(ctx, "inbox", title, WithCreatedAt(createdAt), WithoutEditor())
+		require.NoError(t, err)
+		return note.Path
+	}
+	create("Old", base.Add(-48*time.Hour))
+	newer := create("Newer", base.Add(2*time.Hour))
+	newest := create("New", base.Add(time.Hour))
+
+	notes, err := s.GetChangedNotes(ctx, base)
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	assert.Equal(t, newer, notes[0].Path, "newest first")
+	assert.Equal(t, newest, notes[1].Path)
+
+	// The walk is reused within statCacheTTL, so a note written since is
+	// not seen yet.
+	create("Later", base.Add(3*time.Hour))
+	notes, err = s.GetChangedNotes(ctx, base)
+	require.NoError(t, err)
+	assert.Len(t, notes, 2)
+
+	key := ctx.NotebookContextWorkspace.Path
+	_, ok := s.statCache.get(key, time.Now())
+	assert.True(t, ok, "GetChangedNotes caches its walk")
+	_, ok = s.statCache.get(key, time.Now().Add(statCacheTTL+time.Second))
+	assert.False(t, ok, "cached walk expires after statCacheTTL")
+}
@@ -76,6 +76,9 @@ type ConceptSearchResult struct {
 type ConceptSearchPage struct {
 	Results       []ConceptSearchResult
 	EligibleTotal int
+	// Warnings counts the files the full-text search could not read; their
+	// matches are missing from Results.
+	Warnings int
 }
 
 // CompactConceptSearchResult is the bounded machine-facing concept shape.
@@ -284,6 +287,7 @@ func searchConceptDirsPage(dirs []conceptSearchDir, query string, opts ConceptSe
 
 	accums := make(map[string]*conceptAccum, len(dirs))
 	searchPaths := make([]string, 0, len(dirs))
+	unreadable := make(map[string]struct{})
 	for _, d := range dirs {
 		accums[d.Path] = &conceptAccum{
 			dir:    d,
@@ -355,10 +359,13 @@ func searchConceptDirsPage(dirs []conceptSearchDir, query string, opts ConceptSe
 	} else {
 		overviewOnly := scope == ConceptSearchInOverview
 		for _, token := range tokens {
-			lines, err := runConceptTokenSearch(token, searchPaths, overviewOnly)
+			lines, tokenWarnings, err := runConceptTokenSearch(token, searchPaths, overviewOnly)
 			if err != nil {
 				return ConceptSearchPage{}, err
 			}
+			for _, warning := range tokenWarnings {
+				unreadable[warning] = struct{}{}
+			}
 			for _, line := range lines {
 				filePath, lineNum, text, ok := parseGrepLine(line)
 				if !ok {
@@ -486,12 +493,13 @@ func searchConceptDirsPage(dirs []conceptSearchDir, query string, opts ConceptSe
 	if opts.Limit > 0 && len(results) > opts.Limit {
 		results = results[:opts.Limit]
 	}
-	return ConceptSearchPage{Results: results, EligibleTotal: eligibleTotal}, nil
+	return ConceptSearchPage{Results: results, EligibleTotal: eligibleTotal, Warnings: len(unreadable)}, nil
 }
 
 // runConceptTokenSearch runs one fixed-string, case-insensitive token search
-// over the given directories and returns raw "file:line:text" output lines.
-func runConceptTokenSearch(token string, dirs []string, overviewOnly bool) ([]string, error) {
+// over the given directories and returns raw "file:line:text" output lines,
+// along with the files it could not read.
+func runConceptTokenSearch(token string, dirs []string, overviewOnly bool) ([]string, []string, error) {
 	var cmd *exec.Cmd
 	if rgPath, err := exec.LookPath("rg"); err == nil {
 		args := []string{"-n", "--ignore-case", "--fixed-strings"}
@@ -510,22 +518,14 @@ func runConceptTokenSearch(token string, dirs []string, overviewOnly bool) ([]st
 		args = append(args, dirs...)
 		cmd = exec.Command(grepPath, args...)
 	} else {
-		return nil, fmt.Errorf("neither 'rg' nor 'grep' found in PATH")
+		return nil, nil, fmt.Errorf("neither 'rg' nor 'grep' found in PATH")
 	}
 
-	output, err := cmd.Output()
+	output, warnings, err := RunSearchCommand(cmd)
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			// Exit code 1 means no matches found
-			if exitErr.ExitCode() == 1 {
-				return nil, nil
-			}
-			return nil, fmt.Errorf("search command failed: %w, stderr: %s", err, exitErr.Stderr)
-		}
-		return nil, fmt.Errorf("search command failed: %w", err)
+		return nil, nil, err
 	}
-
-	return strings.Split(string(output), "\n"), nil
+	return strings.Split(string(output), "\n"), warnings, nil
 }
 
 // parseGrepLine splits one "path:linenum:content" output line.
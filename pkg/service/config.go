@@ -0,0 +1,159 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	coreconfig "github.com/grovetools/core/config"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+// ConfigExtensionKey is the top-level grove config key holding nb settings,
+// e.g. an `[nb]` table in grove.toml.
+const ConfigExtensionKey = "nb"
+
+// ExtensionConfig mirrors the `[nb]` section of the grove configuration.
+type ExtensionConfig struct {
+	// FollowSymlinks opts notebook walks into descending symlinked directories.
+	FollowSymlinks bool `yaml:"follow_symlinks"`
+	// RelatedMinScore overrides DefaultRelatedMinScore for `nb related`. A
+	// pointer so that an explicit 0 (report every note sharing a tag) is
+	// told apart from unset.
+	RelatedMinScore *float64 `yaml:"related_min_score"`
+	// TimestampFormat is a Go time layout for frontmatter timestamps
+	// (default RFC3339).
+	TimestampFormat string `yaml:"timestamp_format"`
+	// TimestampTimezone is "utc" (default), "local", or an IANA zone name.
+	TimestampTimezone string `yaml:"timestamp_timezone"`
+	// PlansAsGroup shows plans/ as an ordinary group in the TUI, without
+	// plan statuses, on-hold handling or plan_ref links.
+	PlansAsGroup bool `yaml:"plans_as_group"`
+	// ShowUnfiled lists loose notes in the notebook root under "unfiled".
+	ShowUnfiled bool `yaml:"show_unfiled"`
+	// ShowTodaySection pins a "Today" section above the TUI tree.
+	ShowTodaySection bool `yaml:"show_today_section"`
+	// DefaultWorkspace is where notes go when nb runs outside any workspace
+	// (default "global").
+	DefaultWorkspace string `yaml:"default_workspace"`
+	// CreatedFromGit dates notes without a frontmatter created value by the
+	// commit that added them, before falling back to file times.
+	CreatedFromGit bool `yaml:"created_from_git"`
+	// MaxParseSize is the size in bytes above which note files are listed
+	// by filename without being parsed, and previewed only in part. Unset
+	// means DefaultMaxParseSize; 0 means no limit.
+	MaxParseSize *int64 `yaml:"max_parse_size"`
+	// ConfirmThreshold makes TUI archives and deletes of more notes than
+	// this ask for the count to be typed. 0 (default) never does.
+	ConfirmThreshold int `yaml:"confirm_threshold"`
+	// ConfirmSingle set to false archives or deletes a single note without
+	// asking (default true).
+	ConfirmSingle *bool `yaml:"confirm_single"`
+	// GroupTemplates maps group paths (e.g. "research/spikes") to note
+	// template files. A group without an entry uses its nearest parent's.
+	GroupTemplates map[string]string `yaml:"group_templates"`
+	// Hooks are shell commands run around note creation.
+	Hooks HooksConfig `yaml:"hooks"`
+	// CalDAV is the calendar daily notes are exported to by
+	// `nb remote sync --caldav`.
+	CalDAV CalDAVConfig `yaml:"caldav"`
+	// Flags is the palette of note flags, in the order the TUI cycles
+	// through them. Unset means DefaultFlags.
+	Flags []FlagConfig `yaml:"flags"`
+}
+
+// CalDAVConfig locates the CalDAV calendar daily notes are exported to. The
+// password is not stored in the config; PasswordEnv names the environment
+// variable holding it.
+type CalDAVConfig struct {
+	// URL is the calendar collection, e.g.
+	// https://cloud.example.com/remote.php/dav/calendars/me/personal/
+	URL      string `yaml:"url"`
+	Username string `yaml:"username"`
+	// PasswordEnv defaults to DefaultCalDAVPasswordEnv.
+	PasswordEnv string `yaml:"password_env"`
+}
+
+// DefaultCalDAVPasswordEnv is the environment variable the CalDAV password is
+// read from when caldav.password_env is unset.
+const DefaultCalDAVPasswordEnv = "NB_CALDAV_PASSWORD"
+
+// ApplyCoreConfig overlays the `[nb]` extension section of coreCfg onto c.
+// A missing section leaves c untouched.
+func (c *Config) ApplyCoreConfig(coreCfg *coreconfig.Config) error {
+	if coreCfg == nil {
+		return nil
+	}
+	var ext ExtensionConfig
+	if err := coreCfg.UnmarshalExtension(ConfigExtensionKey, &ext); err != nil {
+		return fmt.Errorf("load %s config: %w", ConfigExtensionKey, err)
+	}
+	c.FollowSymlinks = ext.FollowSymlinks
+	c.PlansAsGroup = ext.PlansAsGroup
+	c.ShowUnfiled = ext.ShowUnfiled
+	c.ShowTodaySection = ext.ShowTodaySection
+	c.DefaultWorkspace = ext.DefaultWorkspace
+	c.CreatedFromGit = ext.CreatedFromGit
+	switch {
+	case ext.MaxParseSize == nil:
+		c.MaxParseSize = 0
+	case *ext.MaxParseSize < 0:
+		return fmt.Errorf("max_parse_size must not be negative, got %d", *ext.MaxParseSize)
+	case *ext.MaxParseSize == 0:
+		c.MaxParseSize = -1
+	default:
+		c.MaxParseSize = *ext.MaxParseSize
+	}
+	if ext.ConfirmThreshold < 0 {
+		return fmt.Errorf("confirm_threshold must not be negative, got %d", ext.ConfirmThreshold)
+	}
+	c.ConfirmThreshold = ext.ConfirmThreshold
+	c.SkipSingleConfirm = ext.ConfirmSingle != nil && !*ext.ConfirmSingle
+	c.GroupTemplates = ext.GroupTemplates
+	c.Hooks = ext.Hooks
+	c.CalDAV = ext.CalDAV
+	if ext.RelatedMinScore != nil && (*ext.RelatedMinScore < 0 || *ext.RelatedMinScore > 1) {
+		return fmt.Errorf("related_min_score must be between 0 and 1, got %v", *ext.RelatedMinScore)
+	}
+	c.RelatedMinScore = ext.RelatedMinScore
+	if err := validateFlags(ext.Flags); err != nil {
+		return err
+	}
+	c.Flags = ext.Flags
+
+	if ext.TimestampFormat != "" {
+		if err := frontmatter.ValidateTimestampLayout(ext.TimestampFormat); err != nil {
+			return err
+		}
+	}
+	loc, err := ParseTimestampTimezone(ext.TimestampTimezone)
+	if err != nil {
+		return err
+	}
+	c.TimestampFormat = ext.TimestampFormat
+	c.TimestampLocation = loc
+	// Only an explicit timestamp_timezone moves the displayed times off the
+	// system zone; the UTC default only governs what is written.
+	c.DisplayLocation = nil
+	if strings.TrimSpace(ext.TimestampTimezone) != "" {
+		c.DisplayLocation = loc
+	}
+	return nil
+}
+
+// ParseTimestampTimezone resolves the timestamp_timezone setting: "" or "utc"
+// is UTC, "local" is the system zone, anything else an IANA name.
+func ParseTimestampTimezone(name string) (*time.Location, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "utc":
+		return time.UTC, nil
+	case "local":
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp_timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
@@ -0,0 +1,388 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/pkg/paths"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+// ConfigSetting is a key understood by `nb config`.
+type ConfigSetting struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+	// ReadOnly settings come from the environment or the core notebook
+	// config and cannot be changed with `nb config set`.
+	ReadOnly bool `json:"read_only"`
+}
+
+var configSettings = []ConfigSetting{
+	{Key: "default_notebook", Description: "Notebook used for new notes (notebooks.rules.default)", ReadOnly: true},
+	{Key: "notebook_root", Description: "Root directory of the default notebook", ReadOnly: true},
+	{Key: "default_type", Description: "Note type used when none is given", ReadOnly: true},
+	{Key: "editor", Description: "Editor notes are opened in ($EDITOR)", ReadOnly: true},
+	{Key: "follow_symlinks", Description: "Descend into symlinked directories when walking notebooks (true/false)"},
+	{Key: "plans_as_group", Description: "Show plans/ in the TUI as an ordinary group, without plan statuses or links (true/false)"},
+	{Key: "show_unfiled", Description: "List loose notes in the notebook root under an \"unfiled\" group (true/false)"},
+	{Key: "show_today_section", Description: "Pin a \"Today\" section of today's notes above the TUI tree (true/false)"},
+	{Key: "default_workspace", Description: "Workspace used outside any workspace (default global)"},
+	{Key: "created_from_git", Description: "Date notes without a created field by the commit that added them (true/false)"},
+	{Key: "max_parse_size", Description: "Bytes above which notes are listed by filename without being parsed (0 = no limit)"},
+	{Key: "confirm_threshold", Description: "Type the count to confirm TUI archives and deletes of more notes than this (0 = never)"},
+	{Key: "confirm_single", Description: "Confirm TUI archives and deletes of a single note (true/false)"},
+	{Key: "related_min_score", Description: "Minimum tag similarity for nb related (0 to 1)"},
+	{Key: "timestamp_format", Description: "Go time layout for frontmatter timestamps"},
+	{Key: "timestamp_timezone", Description: "Zone timestamps are written in: utc, local, or an IANA name"},
+}
+
+// ConfigSettings returns every key `nb config` can show, in display order.
+func ConfigSettings() []ConfigSetting {
+	return append([]ConfigSetting(nil), configSettings...)
+}
+
+// LookupConfigSetting returns the setting called key, or an error listing the
+// valid keys.
+func LookupConfigSetting(key string) (ConfigSetting, error) {
+	for _, setting := range configSettings {
+		if setting.Key == key {
+			return setting, nil
+		}
+	}
+	keys := make([]string, 0, len(configSettings))
+	for _, setting := range configSettings {
+		keys = append(keys, setting.Key)
+	}
+	return ConfigSetting{}, fmt.Errorf("unknown config key %q (valid keys: %s)", key, strings.Join(keys, ", "))
+}
+
+// ConfigValue returns the effective value of key as a string.
+func (s *Service) ConfigValue(key string) (string, error) {
+	if _, err := LookupConfigSetting(key); err != nil {
+		return "", err
+	}
+	cfg := s.Config
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	switch key {
+	case "default_notebook":
+		return s.defaultNotebookName(), nil
+	case "notebook_root":
+		if s.CoreConfig != nil && s.CoreConfig.Notebooks != nil {
+			if nb, ok := s.CoreConfig.Notebooks.Definitions[s.defaultNotebookName()]; ok && nb != nil {
+				return nb.RootDir, nil
+			}
+		}
+		return "", nil
+	case "default_type":
+		if cfg.DefaultType != "" {
+			return string(cfg.DefaultType), nil
+		}
+		return "inbox", nil
+	case "editor":
+		return cfg.Editor, nil
+	case "follow_symlinks":
+		return strconv.FormatBool(cfg.FollowSymlinks), nil
+	case "plans_as_group":
+		return strconv.FormatBool(cfg.PlansAsGroup), nil
+	case "show_unfiled":
+		return strconv.FormatBool(cfg.ShowUnfiled), nil
+	case "show_today_section":
+		return strconv.FormatBool(cfg.ShowTodaySection), nil
+	case "default_workspace":
+		if cfg.DefaultWorkspace != "" {
+			return cfg.DefaultWorkspace, nil
+		}
+		return globalWorkspace, nil
+	case "created_from_git":
+		return strconv.FormatBool(cfg.CreatedFromGit), nil
+	case "max_parse_size":
+		switch {
+		case cfg.MaxParseSize < 0:
+			return "0", nil
+		case cfg.MaxParseSize == 0:
+			return strconv.FormatInt(DefaultMaxParseSize, 10), nil
+		}
+		return strconv.FormatInt(cfg.MaxParseSize, 10), nil
+	case "confirm_threshold":
+		return strconv.Itoa(cfg.ConfirmThreshold), nil
+	case "confirm_single":
+		return strconv.FormatBool(!cfg.SkipSingleConfirm), nil
+	case "related_min_score":
+		return strconv.FormatFloat(s.relatedMinScore(), 'g', -1, 64), nil
+	case "timestamp_format":
+		if cfg.TimestampFormat != "" {
+			return cfg.TimestampFormat, nil
+		}
+		return time.RFC3339, nil
+	case "timestamp_timezone":
+		if cfg.TimestampLocation != nil {
+			return cfg.TimestampLocation.String(), nil
+		}
+		return "UTC", nil
+	}
+	return "", nil
+}
+
+func (s *Service) defaultNotebookName() string {
+	if s.CoreConfig != nil && s.CoreConfig.Notebooks != nil && s.CoreConfig.Notebooks.Rules != nil &&
+		s.CoreConfig.Notebooks.Rules.Default != "" {
+		return s.CoreConfig.Notebooks.Rules.Default
+	}
+	return "default"
+}
+
+// parseConfigValue validates value for the writable setting key and returns
+// it typed as it is stored in YAML.
+func parseConfigValue(key, value string) (interface{}, error) {
+	setting, err := LookupConfigSetting(key)
+	if err != nil {
+		return nil, err
+	}
+	if setting.ReadOnly {
+		return nil, fmt.Errorf("%s is read-only: %s", key, setting.Description)
+	}
+
+	switch key {
+	case "follow_symlinks", "plans_as_group", "show_unfiled", "show_today_section", "created_from_git", "confirm_single":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false, got %q", key, value)
+		}
+		return b, nil
+	case "max_parse_size":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("max_parse_size must be a number of bytes, 0 or more, got %q", value)
+		}
+		return n, nil
+	case "confirm_threshold":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("confirm_threshold must be a whole number, 0 or more, got %q", value)
+		}
+		return n, nil
+	case "related_min_score":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || f < 0 || f > 1 {
+			return nil, fmt.Errorf("related_min_score must be a number between 0 and 1, got %q", value)
+		}
+		return f, nil
+	case "timestamp_format":
+		if err := frontmatter.ValidateTimestampLayout(value); err != nil {
+			return nil, err
+		}
+	case "timestamp_timezone":
+		if _, err := ParseTimestampTimezone(value); err != nil {
+			return nil, err
+		}
+	case "default_workspace":
+		if strings.TrimSpace(value) == "" || strings.ContainsAny(value, `/\`) {
+			return nil, fmt.Errorf("default_workspace must be a workspace name, got %q", value)
+		}
+	}
+	return value, nil
+}
+
+// UserConfigPath returns the user's global grove config file: grove.yml in the
+// grove config dir, or grove.toml when only that exists.
+func UserConfigPath() string {
+	dir := paths.ConfigDir()
+	yamlPath := filepath.Join(dir, "grove.yml")
+	if _, err := os.Stat(yamlPath); err == nil {
+		return yamlPath
+	}
+	tomlPath := filepath.Join(dir, "grove.toml")
+	if _, err := os.Stat(tomlPath); err == nil {
+		return tomlPath
+	}
+	return yamlPath
+}
+
+// SetUserConfigValue validates value and writes it as key in the `nb` section
+// of the YAML or TOML config file at path, creating the file or section if
+// needed. The rest of the file, including comments, is preserved.
+func SetUserConfigValue(path, key, value string) error {
+	typed, err := parseConfigValue(key, value)
+	if err != nil {
+		return err
+	}
+	ext := filepath.Ext(path)
+	if ext != ".yml" && ext != ".yaml" && ext != ".toml" {
+		return fmt.Errorf("cannot edit %s: only YAML and TOML config files are supported; add %s under %s by hand",
+			path, key, ConfigExtensionKey)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read config: %w", err)
+	}
+	var out []byte
+	if ext == ".toml" {
+		out, err = setTOMLConfigValue(data, key, typed)
+	} else {
+		out, err = setYAMLConfigValue(data, path, key, typed)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	if err := os.WriteFile(path, out, 0o644); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
+
+// setYAMLConfigValue returns the YAML document data with key set to value in
+// its `nb` mapping.
+func setYAMLConfigValue(data []byte, path, key string, value interface{}) ([]byte, error) {
+	var doc yaml.Node
+	if len(strings.TrimSpace(string(data))) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parse config: %w", err)
+		}
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parse config: %s is not a YAML mapping", path)
+	}
+
+	var section *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == ConfigExtensionKey {
+			section = root.Content[i+1]
+			break
+		}
+	}
+	if section == nil {
+		section = &yaml.Node{Kind: yaml.MappingNode}
+		root.Content = append(root.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: ConfigExtensionKey}, section)
+	} else if section.Kind == yaml.ScalarNode && section.Tag == "!!null" {
+		// An empty "nb:" key.
+		section.Kind, section.Tag, section.Value = yaml.MappingNode, "", ""
+	} else if section.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s in %s is not a mapping", ConfigExtensionKey, path)
+	}
+	updateNodeValue(section, key, value)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// setTOMLConfigValue returns the TOML document data with key set to value in
+// its [nb] table. Only the line holding the key is replaced (or a line is
+// added), since the TOML encoder would drop comments and reorder the file.
+func setTOMLConfigValue(data []byte, key string, value interface{}) ([]byte, error) {
+	var existing map[string]interface{}
+	if err := toml.Unmarshal(data, &existing); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	encoded, err := toml.Marshal(map[string]interface{}{key: value})
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+	entry := strings.TrimSpace(string(encoded))
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(data) == 0 {
+		lines = nil
+	}
+	section, end := -1, len(lines)
+	for i, line := range lines {
+		name, ok := tomlTableHeader(line)
+		if !ok {
+			continue
+		}
+		if section >= 0 {
+			end = i
+			break
+		}
+		if name == ConfigExtensionKey {
+			section = i
+		}
+	}
+
+	switch {
+	case section < 0:
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "["+ConfigExtensionKey+"]", entry)
+	default:
+		replaced := false
+		for i := section + 1; i < end; i++ {
+			if tomlLineKey(lines[i]) == key {
+				lines[i] = entry
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			// After the table's last entry, before the blank lines that
+			// separate it from the next table.
+			at := end
+			for at > section+1 && strings.TrimSpace(lines[at-1]) == "" {
+				at--
+			}
+			lines = append(lines[:at], append([]string{entry}, lines[at:]...)...)
+		}
+	}
+
+	out := []byte(strings.Join(lines, "\n") + "\n")
+	var updated map[string]interface{}
+	if err := toml.Unmarshal(out, &updated); err != nil {
+		// e.g. nb written as an inline table or with dotted keys.
+		return nil, fmt.Errorf("cannot set %s in this config file (%v); add it under [%s] by hand",
+			key, err, ConfigExtensionKey)
+	}
+	return out, nil
+}
+
+// tomlTableHeader returns the name of the table a "[name]" line opens. Array
+// of tables headers ("[[name]]") count as headers too, with ok set.
+func tomlTableHeader(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "[") {
+		return "", false
+	}
+	name, _, found := strings.Cut(strings.TrimPrefix(line, "["), "]")
+	if !found {
+		return "", false
+	}
+	return strings.Trim(strings.TrimSpace(name), `"'`), true
+}
+
+// tomlLineKey returns the key of a "key = value" line, or "" for any other
+// line.
+func tomlLineKey(line string) string {
+	key, _, found := strings.Cut(line, "=")
+	if !found || strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(key), `"'`)
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetUserConfigValue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grove.yml")
+	require.NoError(t, os.WriteFile(path, []byte("# my settings\nversion: \"1.0\"\nnb:\n  follow_symlinks: false\n"), 0o644))
+
+	require.NoError(t, SetUserConfigValue(path, "follow_symlinks", "true"))
+	require.NoError(t, SetUserConfigValue(path, "related_min_score", "0.5"))
+	require.NoError(t, SetUserConfigValue(path, "timestamp_timezone", "local"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# my settings\nversion: \"1.0\"\nnb:\n  follow_symlinks: true\n  related_min_score: 0.5\n  timestamp_timezone: local\n", string(data))
+}
+
+func TestSetUserConfigValueCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grove", "grove.yml")
+	require.NoError(t, SetUserConfigValue(path, "timestamp_format", "2006-01-02 15:04:05"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "nb:\n  timestamp_format: \"2006-01-02 15:04:05\"\n", string(data))
+}
+
+func TestSetUserConfigValueTOML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grove.toml")
+	require.NoError(t, os.WriteFile(path, []byte("# my settings\nversion = \"1.0\"\n\n[nb]\nfollow_symlinks = false # for now\n\n[flow]\nplans_directory = \"~/plans\"\n"), 0o644))
+
+	require.NoError(t, SetUserConfigValue(path, "follow_symlinks", "true"))
+	require.NoError(t, SetUserConfigValue(path, "related_min_score", "0.5"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# my settings\nversion = \"1.0\"\n\n[nb]\nfollow_symlinks = true\nrelated_min_score = 0.5\n\n[flow]\nplans_directory = \"~/plans\"\n", string(data))
+
+	created := filepath.Join(t.TempDir(), "grove.toml")
+	require.NoError(t, os.WriteFile(created, []byte("version = \"1.0\"\n"), 0o644))
+	require.NoError(t, SetUserConfigValue(created, "timestamp_timezone", "local"))
+	data, err = os.ReadFile(created)
+	require.NoError(t, err)
+	assert.Equal(t, "version = \"1.0\"\n\n[nb]\ntimestamp_timezone = 'local'\n", string(data))
+
+	inline := filepath.Join(t.TempDir(), "grove.toml")
+	original := "nb = { follow_symlinks = false }\n"
+	require.NoError(t, os.WriteFile(inline, []byte(original), 0o644))
+	assert.Error(t, SetUserConfigValue(inline, "follow_symlinks", "true"), "an inline nb table can't be edited line by line")
+	data, err = os.ReadFile(inline)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(data))
+}
+
+func TestSetUserConfigValueRejectsBadInput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grove.yml")
+
+	err := SetUserConfigValue(path, "folow_symlinks", "true")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "valid keys: ")
+	assert.Contains(t, err.Error(), "follow_symlinks")
+
+	assert.Error(t, SetUserConfigValue(path, "editor", "vim"), "read-only key")
+	assert.Error(t, SetUserConfigValue(path, "follow_symlinks", "maybe"))
+	assert.Error(t, SetUserConfigValue(path, "related_min_score", "1.5"))
+	assert.Error(t, SetUserConfigValue(path, "timestamp_timezone", "Mars/Olympus"))
+	assert.Error(t, SetUserConfigValue(path, "default_workspace", "code/inbox"))
+	assert.Error(t, SetUserConfigValue(path, "confirm_threshold", "-1"))
+	assert.Error(t, SetUserConfigValue(filepath.Join(t.TempDir(), "grove.json"), "follow_symlinks", "true"))
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "rejected values must not create the file")
+}
+
+func TestDefaultWorkspaceFallsBackToGlobal(t *testing.T) {
+	s := newTestService()
+	assert.Nil(t, s.defaultWorkspace(), "unconfigured")
+
+	s.Config = &Config{DefaultWorkspace: "global"}
+	assert.Nil(t, s.defaultWorkspace())
+
+	s.Config = &Config{DefaultWorkspace: "inbox-capture"}
+	assert.Nil(t, s.defaultWorkspace(), "unknown workspaces fall back to global")
+
+	value, err := s.ConfigValue("default_workspace")
+	require.NoError(t, err)
+	assert.Equal(t, "inbox-capture", value)
+}
@@ -0,0 +1,429 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+
+	coreconfig "github.com/grovetools/core/config"
+	"github.com/grovetools/core/util/pathutil"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigSeverity grades a ConfigIssue.
+type ConfigSeverity string
+
+const (
+	// ConfigWarning is an issue nb can run with, such as an unknown field or
+	// a grove path that does not exist yet.
+	ConfigWarning ConfigSeverity = "warning"
+	// ConfigError is an issue that breaks nb or makes it ignore settings.
+	ConfigError ConfigSeverity = "error"
+)
+
+// ConfigIssue is one problem found by ValidateConfig.
+type ConfigIssue struct {
+	Severity ConfigSeverity `json:"severity"`
+	File     string         `json:"file,omitempty"`  // Config file, when the issue is tied to one
+	Field    string         `json:"field,omitempty"` // Dotted path, e.g. notebooks.definitions.main.root_dir
+	Message  string         `json:"message"`
+}
+
+func (i ConfigIssue) String() string {
+	var b strings.Builder
+	if i.File != "" {
+		b.WriteString(i.File + ": ")
+	}
+	if i.Field != "" {
+		b.WriteString(i.Field + ": ")
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// ConfigReport is the result of ValidateConfig.
+type ConfigReport struct {
+	Files  []string      `json:"files"`
+	Issues []ConfigIssue `json:"issues"`
+}
+
+func (r *ConfigReport) add(severity ConfigSeverity, file, field, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, ConfigIssue{
+		Severity: severity,
+		File:     file,
+		Field:    field,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Count returns how many issues have the given severity.
+func (r *ConfigReport) Count(severity ConfigSeverity) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// ExitCode is 0 for a clean report, 1 when there are only warnings and 2 when
+// there are errors.
+func (r *ConfigReport) ExitCode() int {
+	switch {
+	case r.Count(ConfigError) > 0:
+		return 2
+	case r.Count(ConfigWarning) > 0:
+		return 1
+	}
+	return 0
+}
+
+// ConfigFiles lists the existing config files coreconfig.LoadFrom(cwd) reads,
+// in load order: the global grove config and its override, then the project
+// config found from cwd and its override.
+func ConfigFiles(cwd string) []string {
+	var candidates []string
+	global := UserConfigPath()
+	candidates = append(candidates, global)
+	candidates = append(candidates, configOverrides(filepath.Dir(global))...)
+	if project, err := coreconfig.FindConfigFile(cwd); err == nil {
+		candidates = append(candidates, project)
+		candidates = append(candidates, configOverrides(filepath.Dir(project))...)
+	}
+
+	var files []string
+	seen := make(map[string]bool)
+	for _, path := range candidates {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+func configOverrides(dir string) []string {
+	return []string{
+		filepath.Join(dir, "grove.override.yml"),
+		filepath.Join(dir, "grove.override.yaml"),
+		filepath.Join(dir, "grove.override.toml"),
+	}
+}
+
+// ValidateConfig checks the config files for syntax errors and unknown
+// fields, then checks cfg, the config loaded from them, for settings that
+// cannot work: notebook root directories that cannot be created, grove paths
+// that do not resolve, path templates that do not parse, references to
+// undefined notebooks and invalid nb settings. loadErr is the error, if any,
+// from loading cfg; it is reported and the checks on cfg are skipped.
+func ValidateConfig(files []string, cfg *coreconfig.Config, loadErr error) *ConfigReport {
+	report := &ConfigReport{Files: files}
+	for _, file := range files {
+		checkConfigFile(report, file)
+	}
+	if loadErr != nil {
+		report.add(ConfigError, "", "", "load config: %v", loadErr)
+		return report
+	}
+	if cfg == nil {
+		return report
+	}
+	checkNotebooks(report, cfg)
+	checkGroves(report, cfg)
+	if err := (&Config{}).ApplyCoreConfig(cfg); err != nil {
+		report.add(ConfigError, "", ConfigExtensionKey, "%v", err)
+	}
+	return report
+}
+
+// configSectionTypes are the top-level sections whose fields are known. Other
+// top-level keys belong to other tools' extensions and are not checked.
+var configSectionTypes = map[string]reflect.Type{
+	ConfigExtensionKey:  reflect.TypeOf(ExtensionConfig{}),
+	"notebooks":         reflect.TypeOf(coreconfig.NotebooksConfig{}),
+	"groves":            reflect.TypeOf(map[string]coreconfig.GroveSourceConfig{}),
+	"search_paths":      reflect.TypeOf(map[string]coreconfig.SearchPathConfig{}),
+	"explicit_projects": reflect.TypeOf([]coreconfig.ExplicitProject{}),
+	"tui":               reflect.TypeOf(coreconfig.TUIConfig{}),
+	"context":           reflect.TypeOf(coreconfig.ContextConfig{}),
+}
+
+// checkConfigFile reports a file that does not parse, and fields in the
+// known sections that no setting reads.
+func checkConfigFile(report *ConfigReport, file string) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		report.add(ConfigError, file, "", "read config: %v", err)
+		return
+	}
+	raw := map[string]interface{}{}
+	if strings.EqualFold(filepath.Ext(file), ".toml") {
+		err = toml.Unmarshal(data, &raw)
+	} else {
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		report.add(ConfigError, file, "", "invalid syntax: %v", err)
+		return
+	}
+
+	for _, section := range sortedKeys(raw) {
+		t, ok := configSectionTypes[section]
+		if !ok {
+			continue
+		}
+		for _, field := range unknownConfigFields(section, raw[section], t) {
+			report.add(ConfigWarning, file, field, "unknown field")
+		}
+	}
+}
+
+// unknownConfigFields returns the dotted paths of keys in raw that have no
+// matching yaml-tagged field in t. Structs with an inline field accept any
+// key.
+func unknownConfigFields(prefix string, raw interface{}, t reflect.Type) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var unknown []string
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fields, open := yamlFields(t)
+		if open {
+			return nil
+		}
+		for _, key := range sortedKeys(m) {
+			ft, ok := fields[key]
+			if !ok {
+				unknown = append(unknown, prefix+"."+key)
+				continue
+			}
+			unknown = append(unknown, unknownConfigFields(prefix+"."+key, m[key], ft)...)
+		}
+	case reflect.Map:
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for _, key := range sortedKeys(m) {
+			unknown = append(unknown, unknownConfigFields(prefix+"."+key, m[key], t.Elem())...)
+		}
+	case reflect.Slice:
+		list, ok := raw.([]interface{})
+		if !ok {
+			return nil
+		}
+		for i, item := range list {
+			unknown = append(unknown, unknownConfigFields(fmt.Sprintf("%s[%d]", prefix, i), item, t.Elem())...)
+		}
+	}
+	return unknown
+}
+
+// yamlFields maps the yaml key of each field of struct type t to its type.
+// open is true when t has an inline field, which takes any other key.
+func yamlFields(t reflect.Type) (fields map[string]reflect.Type, open bool) {
+	fields = make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if strings.Contains(opts, "inline") {
+			return nil, true
+		}
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
+	}
+	return fields, false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checkNotebooks checks each notebook's root directory and path templates,
+// the global notebook's root and the default notebook reference.
+func checkNotebooks(report *ConfigReport, cfg *coreconfig.Config) {
+	if cfg.Notebooks == nil {
+		return
+	}
+	names := make([]string, 0, len(cfg.Notebooks.Definitions))
+	for name := range cfg.Notebooks.Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		nb := cfg.Notebooks.Definitions[name]
+		if nb == nil {
+			continue
+		}
+		field := "notebooks.definitions." + name
+		if nb.RootDir != "" {
+			if msg := checkCreatableDir(nb.RootDir); msg != "" {
+				report.add(ConfigError, "", field+".root_dir", "%s", msg)
+			}
+		}
+		templates := []struct{ key, value string }{
+			{"notes_path_template", nb.NotesPathTemplate},
+			{"plans_path_template", nb.PlansPathTemplate},
+			{"chats_path_template", nb.ChatsPathTemplate},
+			{"templates_path_template", nb.TemplatesPathTemplate},
+			{"recipes_path_template", nb.RecipesPathTemplate},
+			{"in_progress_path_template", nb.InProgressPathTemplate},
+			{"completed_path_template", nb.CompletedPathTemplate},
+			{"prompts_path_template", nb.PromptsPathTemplate},
+		}
+		for _, tpl := range templates {
+			if tpl.value == "" {
+				continue
+			}
+			if _, err := template.New(tpl.key).Parse(tpl.value); err != nil {
+				report.add(ConfigError, "", field+"."+tpl.key, "invalid template: %v", err)
+			}
+		}
+	}
+
+	rules := cfg.Notebooks.Rules
+	if rules == nil {
+		return
+	}
+	if rules.Default != "" && len(cfg.Notebooks.Definitions) > 0 {
+		if _, ok := cfg.Notebooks.Definitions[rules.Default]; !ok {
+			report.add(ConfigError, "", "notebooks.rules.default", "notebook %q is not defined under notebooks.definitions", rules.Default)
+		}
+	}
+	if rules.Global != nil && rules.Global.RootDir != "" {
+		if msg := checkCreatableDir(rules.Global.RootDir); msg != "" {
+			report.add(ConfigError, "", "notebooks.rules.global.root_dir", "%s", msg)
+		}
+	}
+}
+
+// checkGroves checks that every enabled grove and explicit project path is an
+// existing directory, and that groves only name defined notebooks.
+func checkGroves(report *ConfigReport, cfg *coreconfig.Config) {
+	names := make([]string, 0, len(cfg.Groves))
+	for name := range cfg.Groves {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		grove := cfg.Groves[name]
+		field := "groves." + name
+		if grove.Enabled != nil && !*grove.Enabled {
+			continue
+		}
+		if grove.Path == "" {
+			report.add(ConfigError, "", field+".path", "path is empty")
+		} else if severity, msg := checkExistingDir(grove.Path); msg != "" {
+			report.add(severity, "", field+".path", "%s", msg)
+		}
+		if grove.Notebook != "" {
+			if cfg.Notebooks == nil || cfg.Notebooks.Definitions[grove.Notebook] == nil {
+				report.add(ConfigError, "", field+".notebook", "notebook %q is not defined under notebooks.definitions", grove.Notebook)
+			}
+		}
+	}
+
+	if len(cfg.SearchPaths) > 0 {
+		report.add(ConfigWarning, "", "search_paths", "search_paths is deprecated; use groves")
+	}
+
+	for i, project := range cfg.ExplicitProjects {
+		if !project.Enabled {
+			continue
+		}
+		if severity, msg := checkExistingDir(project.Path); msg != "" {
+			report.add(severity, "", fmt.Sprintf("explicit_projects[%d].path", i), "%s", msg)
+		}
+	}
+}
+
+// checkExistingDir describes what is wrong with path as a directory that
+// should already exist: a missing one is a warning, anything else an error.
+func checkExistingDir(path string) (ConfigSeverity, string) {
+	expanded, err := pathutil.Expand(path)
+	if err != nil {
+		return ConfigError, fmt.Sprintf("cannot expand %s: %v", path, err)
+	}
+	info, err := os.Stat(expanded)
+	switch {
+	case os.IsNotExist(err):
+		return ConfigWarning, fmt.Sprintf("%s does not exist", expanded)
+	case err != nil:
+		return ConfigError, err.Error()
+	case !info.IsDir():
+		return ConfigError, fmt.Sprintf("%s is not a directory", expanded)
+	}
+	return "", ""
+}
+
+// checkCreatableDir describes why path is neither an existing directory nor
+// one nb could create, or returns "". Creatability is probed by creating and
+// removing a temporary file in the nearest existing ancestor.
+func checkCreatableDir(path string) string {
+	expanded, err := pathutil.Expand(path)
+	if err != nil {
+		return fmt.Sprintf("cannot expand %s: %v", path, err)
+	}
+	info, err := os.Stat(expanded)
+	if err == nil {
+		if !info.IsDir() {
+			return fmt.Sprintf("%s is not a directory", expanded)
+		}
+		return ""
+	}
+	if !os.IsNotExist(err) {
+		return err.Error()
+	}
+
+	dir := filepath.Dir(expanded)
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return fmt.Sprintf("%s cannot be created: %s is not a directory", expanded, dir)
+			}
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return fmt.Sprintf("%s cannot be created", expanded)
+		}
+		dir = parent
+	}
+	probe, err := os.CreateTemp(dir, ".nb-config-check-*")
+	if err != nil {
+		return fmt.Sprintf("%s does not exist and cannot be created: %s is not writable", expanded, dir)
+	}
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+	return ""
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	coreconfig "github.com/grovetools/core/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func issueFields(report *ConfigReport, severity ConfigSeverity) []string {
+	var fields []string
+	for _, issue := range report.Issues {
+		if issue.Severity == severity {
+			fields = append(fields, issue.Field)
+		}
+	}
+	return fields
+}
+
+func TestValidateConfigFileUnknownFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grove.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`version: "1.0"
+flow:
+  anything: goes
+nb:
+  follow_symlinks: true
+  hooks:
+    pre_creat: "exit 0"
+notebooks:
+  definitions:
+    main:
+      root_dri: ~/notes
+`), 0o644))
+
+	report := ValidateConfig([]string{path}, nil, nil)
+	assert.Equal(t, []string{"nb.hooks.pre_creat", "notebooks.definitions.main.root_dri"}, issueFields(report, ConfigWarning))
+	assert.Equal(t, 1, report.ExitCode())
+}
+
+func TestValidateConfigFileSyntaxError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grove.yml")
+	require.NoError(t, os.WriteFile(path, []byte("nb:\n  follow_symlinks: [true\n"), 0o644))
+
+	report := ValidateConfig([]string{path}, nil, nil)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, ConfigError, report.Issues[0].Severity)
+	assert.Equal(t, 2, report.ExitCode())
+}
+
+func TestValidateConfigSemantics(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+	disabled := false
+
+	cfg := &coreconfig.Config{
+		Notebooks: &coreconfig.NotebooksConfig{
+			Definitions: map[string]*coreconfig.Notebook{
+				"ok":      {RootDir: filepath.Join(dir, "new", "notebook")},
+				"blocked": {RootDir: filepath.Join(file, "notebook")},
+				"tpl":     {NotesPathTemplate: "workspaces/{{ .Workspace.Name"},
+			},
+			Rules: &coreconfig.NotebookRules{Default: "missing"},
+		},
+		Groves: map[string]coreconfig.GroveSourceConfig{
+			"gone": {Path: filepath.Join(dir, "gone")},
+			"off":  {Path: filepath.Join(dir, "off"), Enabled: &disabled},
+			"here": {Path: dir, Notebook: "nope"},
+		},
+	}
+
+	report := ValidateConfig(nil, cfg, nil)
+	assert.Equal(t, []string{
+		"notebooks.definitions.blocked.root_dir",
+		"notebooks.definitions.tpl.notes_path_template",
+		"notebooks.rules.default",
+		"groves.here.notebook",
+	}, issueFields(report, ConfigError))
+	assert.Equal(t, []string{"groves.gone.path"}, issueFields(report, ConfigWarning))
+	assert.NoDirExists(t, filepath.Join(dir, "new"), "checking a root_dir does not create it")
+	assert.Equal(t, 2, report.ExitCode())
+
+	assert.Equal(t, 0, ValidateConfig(nil, &coreconfig.Config{}, nil).ExitCode())
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+func TestCreatedAtOverrideAgreesWithMtime(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	captureNoteEvents(t)
+	s, err := New(&Config{}, nil, nil, nil)
+	require.NoError(t, err)
+	ws := &coreworkspace.WorkspaceNode{Name: "proj", Path: t.TempDir()}
+	ctx := &WorkspaceContext{NotebookContextWorkspace: ws, CurrentWorkspace: ws}
+
+	created := time.Date(2020, 1, 2, 9, 30, 15, 0, time.Local)
+	note, err := s.CreateNote(ctx, "inbox", "Old Idea", WithCreatedAt(created), WithoutEditor())
+	require.NoError(t, err)
+	assert.Equal(t, "20200102-old-idea.md", filepath.Base(note.Path))
+
+	fm, _, err := frontmatter.Parse(readFile(t, note.Path))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(fm.ID, "20200102-093015"), "id %q uses the override date", fm.ID)
+
+	fmCreated, err := frontmatter.ParseTimestamp(fm.Created)
+	require.NoError(t, err)
+	info, err := os.Stat(note.Path)
+	require.NoError(t, err)
+	assert.True(t, fmCreated.Equal(info.ModTime()), "frontmatter created %v, mtime %v", fmCreated, info.ModTime())
+	assert.True(t, fmCreated.Equal(created), "frontmatter created %v, want %v", fmCreated, created)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+// createdFromGit makes NoteCreatedAt consult git history. It is set once at
+// startup from the created_from_git setting; see SetCreatedFromGit.
+var createdFromGit atomic.Bool
+
+// SetCreatedFromGit turns the git step of NoteCreatedAt on or off. It costs
+// one git process per note without a frontmatter created date, so it is off
+// by default.
+func SetCreatedFromGit(on bool) {
+	createdFromGit.Store(on)
+}
+
+// NoteCreatedAt is the creation time of the file at path, the first of:
+//
+//  1. fmCreated, its frontmatter created value, when it parses;
+//  2. the time of the commit that added it, when created_from_git is on and
+//     it is tracked by git;
+//  3. its birth time, where the OS and filesystem record one;
+//  4. its modification time.
+//
+// Every note and tree item is dated through it, so a note without a created
+// date sorts the same in every listing.
+func NoteCreatedAt(path, fmCreated string, info os.FileInfo) time.Time {
+	if fmCreated != "" {
+		if t, err := frontmatter.ParseTimestamp(fmCreated); err == nil {
+			return t
+		}
+	}
+	if createdFromGit.Load() {
+		if t, ok := gitFirstCommitTime(path); ok {
+			return t
+		}
+	}
+	if t, ok := fileBirthTime(path, info); ok {
+		return t
+	}
+	return info.ModTime()
+}
+
+// gitFirstCommitTime returns the author time of the commit that added path,
+// following renames. ok is false outside a repository or for an untracked
+// file.
+func gitFirstCommitTime(path string) (time.Time, bool) {
+	cmd := exec.Command("git", "log", "--follow", "--diff-filter=A", "--format=%at", "--", filepath.Base(path))
+	cmd.Dir = filepath.Dir(path)
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, false
+	}
+	// Newest first: with --follow an earlier name's addition comes last.
+	lines := strings.Fields(string(out))
+	if len(lines) == 0 {
+		return time.Time{}, false
+	}
+	secs, err := strconv.ParseInt(lines[len(lines)-1], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(secs, 0), true
+}
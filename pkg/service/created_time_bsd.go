@@ -0,0 +1,18 @@
+//go:build darwin || freebsd || netbsd
+
+package service
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// fileBirthTime reads the birth time stat already returned.
+func fileBirthTime(_ string, info os.FileInfo) (time.Time, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || st.Birthtimespec.Sec == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(st.Birthtimespec.Sec), int64(st.Birthtimespec.Nsec)), true
+}
@@ -0,0 +1,14 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !windows
+
+package service
+
+import (
+	"os"
+	"time"
+)
+
+// fileBirthTime is unavailable here; NoteCreatedAt falls back to the
+// modification time.
+func fileBirthTime(string, os.FileInfo) (time.Time, bool) {
+	return time.Time{}, false
+}
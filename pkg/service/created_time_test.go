@@ -0,0 +1,68 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoteCreatedAtPrefersFrontmatter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "note.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Note\n"), 0o644))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	got := NoteCreatedAt(path, "2024-03-01T10:00:00Z", info)
+	assert.True(t, got.Equal(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)), "got %v", got)
+}
+
+func TestNoteCreatedAtFallsBackToFileTimes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "note.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Note\n"), 0o644))
+	mtime := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(path, mtime, mtime))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	want := info.ModTime()
+	if birth, ok := fileBirthTime(path, info); ok {
+		want = birth
+	}
+	assert.True(t, NoteCreatedAt(path, "", info).Equal(want))
+	assert.True(t, NoteCreatedAt(path, "not a date", info).Equal(want), "an unparsable created value is ignored")
+}
+
+func TestNoteCreatedAtFromGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(env []string, args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), env...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git(nil, "init", "-q")
+	path := filepath.Join(dir, "note.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Note\n"), 0o644))
+	git(nil, "add", "note.md")
+	git([]string{"GIT_AUTHOR_DATE=2020-01-02T03:04:05Z", "GIT_COMMITTER_DATE=2020-01-02T03:04:05Z"},
+		"-c", "user.name=t", "-c", "user.email=t@example.com", "commit", "-qm", "add")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	SetCreatedFromGit(true)
+	t.Cleanup(func() { SetCreatedFromGit(false) })
+	want := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.True(t, NoteCreatedAt(path, "", info).Equal(want), "got %v", NoteCreatedAt(path, "", info))
+
+	SetCreatedFromGit(false)
+	assert.False(t, NoteCreatedAt(path, "", info).Equal(want), "git is only consulted when enabled")
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package service
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// fileBirthTime reads the creation time stat already returned.
+func fileBirthTime(_ string, info os.FileInfo) (time.Time, bool) {
+	attrs, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, attrs.CreationTime.Nanoseconds()), true
+}
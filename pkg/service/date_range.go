@@ -0,0 +1,89 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// Date fields SearchByDateRange can filter on.
+const (
+	DateFieldCreated  = "created"
+	DateFieldModified = "modified"
+)
+
+// SearchByDateRange returns the notes in ctx whose field ("created" or
+// "modified") falls within start and end, inclusive, oldest first. A zero
+// start or end leaves that side open. Modified dates come from file mtimes,
+// so only matching notes are parsed; created dates need every note parsed
+// and follow NoteCreatedAt. Archived notes are not searched.
+func (s *Service) SearchByDateRange(ctx *WorkspaceContext, start, end time.Time, field string) ([]*models.Note, error) {
+	field = strings.ToLower(strings.TrimSpace(field))
+	if field != DateFieldCreated && field != DateFieldModified {
+		return nil, fmt.Errorf("invalid date field %q (expected %s or %s)", field, DateFieldCreated, DateFieldModified)
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return nil, fmt.Errorf("date range ends (%s) before it starts (%s)",
+			end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+	inRange := func(t time.Time) bool {
+		return (start.IsZero() || !t.Before(start)) && (end.IsZero() || !t.After(end))
+	}
+
+	files, err := s.noteFileStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type dated struct {
+		note *models.Note
+		at   time.Time
+	}
+	var matches []dated
+	for _, f := range files {
+		if field == DateFieldModified && !inRange(f.modTime) {
+			continue
+		}
+		note, err := ParseNote(f.path)
+		if err != nil {
+			continue // Deleted or unreadable since the walk
+		}
+		at := f.modTime
+		if field == DateFieldCreated {
+			if !inRange(note.CreatedAt) {
+				continue
+			}
+			at = note.CreatedAt
+		}
+		matches = append(matches, dated{note: note, at: at})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].at.Before(matches[j].at) })
+
+	notes := make([]*models.Note, len(matches))
+	for i, m := range matches {
+		notes[i] = m.note
+	}
+	s.Logger.WithField("field", field).WithField("results_count", len(notes)).Debug("Date range search completed")
+	return notes, nil
+}
+
+// ParseDateBound reads one end of a date range: RFC3339, or a local
+// YYYY-MM-DD that stands for the start of that day, or its last instant when
+// endOfDay is set so the day is included.
+func ParseDateBound(value string, endOfDay bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (want YYYY-MM-DD or RFC3339)", value)
+	}
+	if endOfDay {
+		return day.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return day, nil
+}
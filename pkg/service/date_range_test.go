@@ -0,0 +1,73 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchByDateRange(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	s, err := New(&Config{}, nil, nil, nil)
+	require.NoError(t, err)
+	ws := &coreworkspace.WorkspaceNode{Name: "ws", Path: t.TempDir()}
+	ctx := &WorkspaceContext{NotebookContextWorkspace: ws, CurrentWorkspace: ws}
+	dir, err := s.getNotePathForContext(ctx, "inbox")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+
+	jan := func(day int) time.Time { return time.Date(2026, 1, day, 12, 0, 0, 0, time.UTC) }
+	// created is the frontmatter date; the file mtime is a week later.
+	write := func(name string, created time.Time) {
+		path := filepath.Join(dir, name)
+		content := "---\ntitle: " + name + "\ncreated: " + created.Format(time.RFC3339) + "\n---\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		mtime := created.AddDate(0, 0, 7)
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+	}
+	write("c.md", jan(10))
+	write("a.md", jan(1))
+	write("b.md", jan(5))
+
+	titles := func(start, end time.Time, field string) []string {
+		notes, err := s.SearchByDateRange(ctx, start, end, field)
+		require.NoError(t, err)
+		var out []string
+		for _, n := range notes {
+			out = append(out, n.FrontmatterTitle)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"a.md", "b.md"}, titles(jan(1), jan(5), DateFieldCreated), "inclusive, oldest first")
+	assert.Equal(t, []string{"b.md", "c.md"}, titles(jan(5), time.Time{}, DateFieldCreated), "open end")
+	assert.Equal(t, []string{"b.md", "c.md"}, titles(jan(12), jan(17), DateFieldModified), "by mtime")
+	assert.Empty(t, titles(jan(1), jan(5), DateFieldModified))
+
+	_, err = s.SearchByDateRange(ctx, jan(5), jan(1), DateFieldCreated)
+	assert.Error(t, err, "reversed range")
+	_, err = s.SearchByDateRange(ctx, time.Time{}, time.Time{}, "updated")
+	assert.Error(t, err, "unknown field")
+}
+
+func TestParseDateBound(t *testing.T) {
+	got, err := ParseDateBound("2026-01-31", false)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local), got)
+
+	got, err = ParseDateBound("2026-01-31", true)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local).Add(-time.Nanosecond), got, "the whole day is included")
+
+	got, err = ParseDateBound("2026-01-31T08:00:00Z", true)
+	require.NoError(t, err)
+	assert.True(t, got.Equal(time.Date(2026, 1, 31, 8, 0, 0, 0, time.UTC)), "RFC3339 is exact")
+
+	_, err = ParseDateBound("last week", false)
+	assert.Error(t, err)
+}
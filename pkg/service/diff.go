@@ -0,0 +1,120 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/grovetools/core/git"
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// DefaultDiffContext is the number of unchanged lines DiffNotes shows around
+// each change.
+const DefaultDiffContext = 3
+
+type diffOptions struct {
+	context          int
+	stripFrontmatter bool
+}
+
+// DiffOption configures DiffNotes and DiffNoteRevision.
+type DiffOption func(*diffOptions)
+
+// WithDiffContext sets how many unchanged lines surround each change.
+func WithDiffContext(lines int) DiffOption {
+	return func(o *diffOptions) {
+		if lines >= 0 {
+			o.context = lines
+		}
+	}
+}
+
+// WithoutFrontmatter leaves the notes' frontmatter out of the comparison, so
+// only body changes show.
+func WithoutFrontmatter() DiffOption {
+	return func(o *diffOptions) {
+		o.stripFrontmatter = true
+	}
+}
+
+// DiffNotes returns a unified diff turning the note at path1 into the one at
+// path2, or "" when they are identical.
+func (s *Service) DiffNotes(path1, path2 string, opts ...DiffOption) (string, error) {
+	a, err := os.ReadFile(path1)
+	if err != nil {
+		return "", fmt.Errorf("read note: %w", err)
+	}
+	b, err := os.ReadFile(path2)
+	if err != nil {
+		return "", fmt.Errorf("read note: %w", err)
+	}
+	return diffNoteContents(path1, string(a), path2, string(b), opts...)
+}
+
+// DiffNoteRevision returns a unified diff from the note at path as of the git
+// revision rev (a commit hash, branch or tag) to its current content.
+func (s *Service) DiffNoteRevision(path, rev string, opts ...DiffOption) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("resolve path: %w", err)
+	}
+	current, err := os.ReadFile(abs)
+	if err != nil {
+		return "", fmt.Errorf("read note: %w", err)
+	}
+	root, err := git.GetGitRoot(filepath.Dir(abs))
+	if err != nil || root == "" {
+		return "", fmt.Errorf("%s is not in a git repository", path)
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", fmt.Errorf("resolve path: %w", err)
+	}
+
+	cmd := exec.Command("git", "show", rev+":"+filepath.ToSlash(rel))
+	cmd.Dir = root
+	old, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("git show %s failed: %s", rev, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git show %s failed: %w", rev, err)
+	}
+	return diffNoteContents(path+"@"+rev, string(old), path, string(current), opts...)
+}
+
+// diffNoteContents computes the unified diff between two note contents
+// labelled name1 and name2.
+func diffNoteContents(name1, content1, name2, content2 string, opts ...DiffOption) (string, error) {
+	o := diffOptions{context: DefaultDiffContext}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.stripFrontmatter {
+		content1 = noteBody(content1)
+		content2 = noteBody(content2)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(content1),
+		B:        difflib.SplitLines(content2),
+		FromFile: name1,
+		ToFile:   name2,
+		Context:  o.context,
+	})
+	if err != nil {
+		return "", fmt.Errorf("compute diff: %w", err)
+	}
+	return diff, nil
+}
+
+// noteBody returns content without its frontmatter, as Note.ToMarkdown does;
+// content whose frontmatter doesn't parse is compared whole.
+func noteBody(content string) string {
+	return (&models.Note{Content: content}).ToMarkdown(false)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffNotes(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.md")
+	b := filepath.Join(dir, "b.md")
+	require.NoError(t, os.WriteFile(a, []byte("---\ntitle: A\n---\n# Note\n\none\ntwo\nthree\n"), 0o644))
+	require.NoError(t, os.WriteFile(b, []byte("---\ntitle: B\n---\n# Note\n\none\n2\nthree\n"), 0o644))
+	s := newTestService()
+
+	diff, err := s.DiffNotes(a, b)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- "+a)
+	assert.Contains(t, diff, "+++ "+b)
+	assert.Contains(t, diff, "-title: A\n+title: B\n")
+	assert.Contains(t, diff, "-two\n+2\n")
+	assert.Contains(t, diff, " three\n")
+
+	diff, err = s.DiffNotes(a, b, WithoutFrontmatter())
+	require.NoError(t, err)
+	assert.NotContains(t, diff, "title:")
+	assert.Contains(t, diff, "-two\n+2\n")
+
+	diff, err = s.DiffNotes(a, b, WithoutFrontmatter(), WithDiffContext(0))
+	require.NoError(t, err)
+	assert.NotContains(t, diff, " one\n")
+
+	diff, err = s.DiffNotes(a, a)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+func TestDiffNoteRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@t"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	note := filepath.Join(dir, "inbox", "note.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(note), 0o755))
+	require.NoError(t, os.WriteFile(note, []byte("# Note\n\nfirst draft\n"), 0o644))
+	git("add", ".")
+	git("commit", "-q", "-m", "add note")
+	require.NoError(t, os.WriteFile(note, []byte("# Note\n\nsecond draft\n"), 0o644))
+
+	diff, err := newTestService().DiffNoteRevision(note, "HEAD")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- "+note+"@HEAD")
+	assert.Contains(t, diff, "-first draft\n+second draft\n")
+
+	_, err = newTestService().DiffNoteRevision(note, "nosuchrev")
+	assert.ErrorContains(t, err, "git show nosuchrev failed")
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// Reasons a DuplicateCluster was formed.
+const (
+	DuplicateTitle       = "title"        // Same normalized title
+	DuplicateContent     = "content"      // Same body after whitespace normalization
+	DuplicateNearContent = "near-content" // One body contains the other and their lengths are close
+)
+
+// DuplicateCluster is a set of notes that are likely copies of each other.
+type DuplicateCluster struct {
+	Reason string         `json:"reason"`
+	Key    string         `json:"key"` // Normalized title, or a short content hash
+	Notes  []*models.Note `json:"notes"`
+}
+
+type duplicateOptions struct {
+	byContent bool
+	nearRatio float64
+}
+
+// DuplicateOption configures FindDuplicates.
+type DuplicateOption func(*duplicateOptions)
+
+// WithContentDuplicates also clusters notes whose bodies are identical once
+// frontmatter is dropped and whitespace is collapsed.
+func WithContentDuplicates() DuplicateOption {
+	return func(o *duplicateOptions) {
+		o.byContent = true
+	}
+}
+
+// WithNearDuplicates also clusters bodies that are near-identical: the shorter
+// normalized body appears in the longer one and is at least ratio (0..1] of its
+// length. It implies WithContentDuplicates.
+func WithNearDuplicates(ratio float64) DuplicateOption {
+	return func(o *duplicateOptions) {
+		o.byContent = true
+		o.nearRatio = ratio
+	}
+}
+
+// FindDuplicates reports clusters of likely duplicate notes in ctx, or in every
+// workspace when allWorkspaces is set. Notes are grouped by normalized title,
+// and by content when asked to. Archived notes are ignored. It only reads.
+func (s *Service) FindDuplicates(ctx *WorkspaceContext, allWorkspaces bool, options ...DuplicateOption) ([]DuplicateCluster, error) {
+	var (
+		notes []*models.Note
+		err   error
+	)
+	if allWorkspaces {
+		notes, err = s.ListNotesFromAllWorkspaces(false, false)
+	} else {
+		notes, err = s.ListAllNotes(ctx, false, false)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list notes for duplicates: %w", err)
+	}
+	return FindDuplicateClusters(notes, options...), nil
+}
+
+// FindDuplicateClusters is the analysis behind FindDuplicates, for notes
+// already in memory. Clusters are ordered by reason (title, content,
+// near-content), then by key; notes within a cluster by path.
+func FindDuplicateClusters(notes []*models.Note, options ...DuplicateOption) []DuplicateCluster {
+	opts := &duplicateOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	var clusters []DuplicateCluster
+	byTitle := make(map[string][]*models.Note)
+	bodies := make(map[*models.Note]string)
+	byHash := make(map[string][]*models.Note)
+	for _, note := range notes {
+		if note == nil || note.IsArchived {
+			continue
+		}
+		if title := normalizeDuplicateTitle(duplicateTitle(note)); title != "" && title != "untitled" {
+			byTitle[title] = append(byTitle[title], note)
+		}
+		if opts.byContent {
+			body := normalizeDuplicateBody(note.Content)
+			if body == "" {
+				continue
+			}
+			bodies[note] = body
+			sum := sha256.Sum256([]byte(body))
+			hash := hex.EncodeToString(sum[:])[:12]
+			byHash[hash] = append(byHash[hash], note)
+		}
+	}
+	clusters = append(clusters, collectClusters(DuplicateTitle, byTitle)...)
+	clusters = append(clusters, collectClusters(DuplicateContent, byHash)...)
+
+	if opts.nearRatio > 0 && opts.nearRatio < 1 {
+		clusters = append(clusters, nearDuplicateClusters(byHash, bodies, opts.nearRatio)...)
+	}
+	return clusters
+}
+
+// nearDuplicateClusters pairs up distinct bodies (one representative per exact
+// hash) where the shorter is contained in the longer and long enough relative
+// to it. Each pair is its own cluster, keyed by both hashes.
+func nearDuplicateClusters(byHash map[string][]*models.Note, bodies map[*models.Note]string, ratio float64) []DuplicateCluster {
+	type entry struct {
+		hash string
+		body string
+	}
+	entries := make([]entry, 0, len(byHash))
+	for hash, notes := range byHash {
+		entries = append(entries, entry{hash: hash, body: bodies[notes[0]]})
+	}
+	// Shortest first, so for each body only the longer ones within the ratio
+	// need checking.
+	sort.Slice(entries, func(i, j int) bool {
+		if len(entries[i].body) != len(entries[j].body) {
+			return len(entries[i].body) < len(entries[j].body)
+		}
+		return entries[i].hash < entries[j].hash
+	})
+
+	var clusters []DuplicateCluster
+	for i, short := range entries {
+		for _, long := range entries[i+1:] {
+			if float64(len(short.body)) < ratio*float64(len(long.body)) {
+				break
+			}
+			if !strings.Contains(long.body, short.body) {
+				continue
+			}
+			notes := append(append([]*models.Note(nil), byHash[short.hash]...), byHash[long.hash]...)
+			sortNotesByPath(notes)
+			clusters = append(clusters, DuplicateCluster{
+				Reason: DuplicateNearContent,
+				Key:    short.hash + "~" + long.hash,
+				Notes:  notes,
+			})
+		}
+	}
+	sort.SliceStable(clusters, func(i, j int) bool { return clusters[i].Key < clusters[j].Key })
+	return clusters
+}
+
+func collectClusters(reason string, groups map[string][]*models.Note) []DuplicateCluster {
+	var clusters []DuplicateCluster
+	for key, notes := range groups {
+		if len(notes) < 2 {
+			continue
+		}
+		notes = append([]*models.Note(nil), notes...)
+		sortNotesByPath(notes)
+		clusters = append(clusters, DuplicateCluster{Reason: reason, Key: key, Notes: notes})
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].Key < clusters[j].Key })
+	return clusters
+}
+
+func sortNotesByPath(notes []*models.Note) {
+	sort.Slice(notes, func(i, j int) bool { return notes[i].Path < notes[j].Path })
+}
+
+// duplicateTitle is the frontmatter (or H1) title, falling back to the
+// filename without its date prefix and extension.
+func duplicateTitle(note *models.Note) string {
+	if note.FrontmatterTitle != "" {
+		return note.FrontmatterTitle
+	}
+	name := strings.TrimSuffix(note.Title, ".md")
+	if len(name) > 9 && name[8] == '-' && strings.IndexFunc(name[:8], func(r rune) bool { return !unicode.IsDigit(r) }) < 0 {
+		name = name[9:]
+	}
+	return name
+}
+
+// normalizeDuplicateTitle lowercases title and reduces punctuation and
+// separators to single spaces, so "API design" and "api-design!" match.
+func normalizeDuplicateTitle(title string) string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// normalizeDuplicateBody drops frontmatter and collapses all whitespace, so
+// re-wrapped or re-indented copies hash the same.
+func normalizeDuplicateBody(content string) string {
+	if _, body, err := frontmatter.Parse(content); err == nil {
+		content = body
+	}
+	return strings.Join(strings.Fields(content), " ")
+}
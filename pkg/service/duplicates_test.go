@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestFindDuplicateClusters(t *testing.T) {
+	note := func(path, title, content string) *models.Note {
+		return &models.Note{Path: path, Title: path, FrontmatterTitle: title, Content: content}
+	}
+	a := note("a.md", "API Design", "---\ntitle: API Design\n---\n\nFirst draft of the API.\n")
+	b := note("b.md", "api-design!", "---\ntitle: api-design!\nid: other\n---\nFirst draft\n   of the API.")
+	c := note("c.md", "Something else", "First draft of the API.\n\nPlus one more line at the end.")
+	d := note("d.md", "Unrelated", "Nothing in common.")
+	archived := note("e.md", "API Design", "First draft of the API.")
+	archived.IsArchived = true
+	notes := []*models.Note{d, c, b, a, archived}
+
+	clusters := FindDuplicateClusters(notes)
+	require.Len(t, clusters, 1, "titles only by default")
+	assert.Equal(t, DuplicateTitle, clusters[0].Reason)
+	assert.Equal(t, "api design", clusters[0].Key)
+	assert.Equal(t, []*models.Note{a, b}, clusters[0].Notes)
+
+	clusters = FindDuplicateClusters(notes, WithContentDuplicates())
+	require.Len(t, clusters, 2)
+	assert.Equal(t, DuplicateContent, clusters[1].Reason, "frontmatter and whitespace are ignored")
+	assert.Equal(t, []*models.Note{a, b}, clusters[1].Notes)
+
+	clusters = FindDuplicateClusters(notes, WithNearDuplicates(0.9))
+	assert.Len(t, clusters, 2, "c is too much longer for a 0.9 ratio")
+
+	clusters = FindDuplicateClusters(notes, WithNearDuplicates(0.3))
+	require.Len(t, clusters, 3)
+	assert.Equal(t, DuplicateNearContent, clusters[2].Reason)
+	assert.Equal(t, []*models.Note{a, b, c}, clusters[2].Notes)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// DefaultEditor is run when no editor is configured, or the configured one
+// can't be parsed.
+const DefaultEditor = "vim"
+
+// errUnterminatedQuote is returned by SplitCommandLine for a quote that is
+// never closed.
+var errUnterminatedQuote = errors.New("unterminated quote")
+
+// SplitCommandLine splits s into words the way a POSIX shell would, without
+// any expansion: words are separated by unquoted whitespace, single quotes
+// preserve everything up to the closing quote, and inside double quotes or
+// unquoted text a backslash escapes the next character. "code --wait" gives
+// [code --wait]; `"my editor" -x` gives [my editor -x].
+func SplitCommandLine(s string) ([]string, error) {
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune // 0, '\'' or '"'
+		escaped bool
+	)
+	for _, r := range s {
+		switch {
+		case escaped:
+			// Inside double quotes a backslash only escapes characters the
+			// shell treats specially; otherwise it is kept.
+			if quote == '"' && !strings.ContainsRune(`"\$`+"`", r) {
+				word.WriteRune('\\')
+			}
+			word.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inWord = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, errUnterminatedQuote
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// EditorArgs returns the command and arguments for opening path in editor,
+// a command line such as "code --wait" or "emacsclient -nw". An empty or
+// unparseable editor falls back to DefaultEditor.
+func EditorArgs(editor, path string) []string {
+	words, err := SplitCommandLine(editor)
+	if err != nil || len(words) == 0 {
+		words = []string{DefaultEditor}
+	}
+	return append(words, path)
+}
+
+// QuoteCommandLine joins words into a command line SplitCommandLine splits
+// back into them, single-quoting every word that holds anything but plain
+// characters. It is how an editor command is handed to a shell, e.g. a tmux
+// pane.
+func QuoteCommandLine(words []string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		if w != "" && strings.Trim(w, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_@%+=:,./-") == "" {
+			quoted[i] = w
+			continue
+		}
+		quoted[i] = "'" + strings.ReplaceAll(w, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// EditorCommand returns the editor command line to use: Config.Editor, then
+// $EDITOR. Pass it to EditorArgs, which falls back to DefaultEditor.
+func (s *Service) EditorCommand() string {
+	if s.Config != nil && s.Config.Editor != "" {
+		return s.Config.Editor
+	}
+	return os.Getenv("EDITOR")
+}
+
+// openInEditor opens a file in the configured editor
+func (s *Service) openInEditor(path string) error {
+	args := EditorArgs(s.EditorCommand(), path)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
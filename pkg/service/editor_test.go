@@ -0,0 +1,84 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{in: "vim", want: []string{"vim"}},
+		{in: "code --wait", want: []string{"code", "--wait"}},
+		{in: "  emacsclient   -nw\t", want: []string{"emacsclient", "-nw"}},
+		{in: `"/Applications/My Editor/bin/edit" -x`, want: []string{"/Applications/My Editor/bin/edit", "-x"}},
+		{in: `subl -n --command 'set_layout {"cols": 2}'`, want: []string{"subl", "-n", "--command", `set_layout {"cols": 2}`}},
+		{in: `nvim -c "set tw=80"`, want: []string{"nvim", "-c", "set tw=80"}},
+		{in: `my\ editor -f`, want: []string{"my editor", "-f"}},
+		{in: `ed "a\"b" "c\d"`, want: []string{"ed", `a"b`, `c\d`}},
+		{in: `vim ''`, want: []string{"vim", ""}},
+		{in: "", want: nil},
+		{in: `code "--wait`, wantErr: true},
+		{in: `vim 'x`, wantErr: true},
+		{in: `vim \`, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := SplitCommandLine(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("SplitCommandLine(%q) = %q, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("SplitCommandLine(%q): %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitCommandLine(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEditorArgs(t *testing.T) {
+	tests := []struct {
+		editor string
+		want   []string
+	}{
+		{editor: "code --wait", want: []string{"code", "--wait", "/n/a.md"}},
+		{editor: `"my editor" -x`, want: []string{"my editor", "-x", "/n/a.md"}},
+		{editor: "", want: []string{"vim", "/n/a.md"}},
+		{editor: "   ", want: []string{"vim", "/n/a.md"}},
+		{editor: `code "--wait`, want: []string{"vim", "/n/a.md"}},
+	}
+	for _, tt := range tests {
+		if got := EditorArgs(tt.editor, "/n/a.md"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("EditorArgs(%q) = %q, want %q", tt.editor, got, tt.want)
+		}
+	}
+}
+
+func TestQuoteCommandLine(t *testing.T) {
+	tests := []struct {
+		words []string
+		want  string
+	}{
+		{words: []string{"code", "--wait", "/n/a.md"}, want: "code --wait /n/a.md"},
+		{words: []string{"vim", "/n/my note.md"}, want: "vim '/n/my note.md'"},
+		{words: []string{"vim", "/n/it's.md"}, want: `vim '/n/it'\''s.md'`},
+		{words: []string{"vim", "$HOME;rm"}, want: "vim '$HOME;rm'"},
+		{words: []string{"vim", ""}, want: "vim ''"},
+	}
+	for _, tt := range tests {
+		got := QuoteCommandLine(tt.words)
+		if got != tt.want {
+			t.Errorf("QuoteCommandLine(%q) = %q, want %q", tt.words, got, tt.want)
+		}
+		if back, err := SplitCommandLine(got); err != nil || !reflect.DeepEqual(back, tt.words) {
+			t.Errorf("SplitCommandLine(%q) = %q, %v, want %q", got, back, err, tt.words)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+// ExportFormat selects how ExportNotes writes notes out.
+type ExportFormat string
+
+const (
+	// ExportCopy copies each note file into a directory.
+	ExportCopy ExportFormat = "copy"
+	// ExportConcat concatenates the notes into one Markdown file, with a
+	// `##` heading per note.
+	ExportConcat ExportFormat = "concat"
+)
+
+// Exporter writes a set of notes to a destination and returns how many it
+// wrote. On error the count covers the notes written before the failure.
+type Exporter interface {
+	Export(paths []string, dest string) (int, error)
+}
+
+// NewExporter returns the Exporter for format.
+func NewExporter(format ExportFormat) (Exporter, error) {
+	switch format {
+	case ExportCopy:
+		return copyExporter{}, nil
+	case ExportConcat:
+		return concatExporter{}, nil
+	}
+	return nil, fmt.Errorf("unknown export format %q (want %s or %s)", format, ExportCopy, ExportConcat)
+}
+
+// ExportFormatForPath picks the format a destination implies: a .md file is
+// concatenated into, anything else is a directory to copy into.
+func ExportFormatForPath(dest string) ExportFormat {
+	if strings.EqualFold(filepath.Ext(dest), ".md") {
+		return ExportConcat
+	}
+	return ExportCopy
+}
+
+// ExportNotes writes the notes at paths to dest in the given format.
+func (s *Service) ExportNotes(paths []string, dest string, format ExportFormat) (int, error) {
+	exporter, err := NewExporter(format)
+	if err != nil {
+		return 0, err
+	}
+	n, err := exporter.Export(paths, dest)
+	log := s.opLog("export", "", "").WithField("dest", dest).WithField("format", string(format)).WithField("count", n)
+	if err != nil {
+		log.WithError(err).Warn("Export failed")
+		return n, err
+	}
+	log.Info("Exported notes")
+	return n, nil
+}
+
+// copyExporter copies notes into a directory, created if needed. A file name
+// already taken there gets a numeric suffix rather than being overwritten.
+type copyExporter struct{}
+
+func (copyExporter) Export(paths []string, dest string) (int, error) {
+	if err := os.MkdirAll(dest, 0o755); err != nil {
+		return 0, fmt.Errorf("create export directory: %w", err)
+	}
+	for i, src := range paths {
+		if err := copyFile(src, uniqueAttachmentPath(dest, filepath.Base(src))); err != nil {
+			return i, fmt.Errorf("export %s: %w", src, err)
+		}
+	}
+	return len(paths), nil
+}
+
+// concatExporter writes notes into a single new Markdown file. Each note
+// becomes a `##` section titled from its frontmatter (or file name); its
+// frontmatter and a leading `# title` heading repeating that title are left
+// out. An existing file is never overwritten.
+type concatExporter struct{}
+
+func (concatExporter) Export(paths []string, dest string) (int, error) {
+	var sections []string
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return 0, fmt.Errorf("read %s: %w", path, err)
+		}
+		sections = append(sections, exportSection(path, string(content)))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return 0, fmt.Errorf("create export directory: %w", err)
+	}
+	f, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		if os.IsExist(err) {
+			return 0, fmt.Errorf("export file %s already exists", dest)
+		}
+		return 0, fmt.Errorf("create export file: %w", err)
+	}
+	if _, err := f.WriteString(strings.Join(sections, "\n")); err != nil {
+		_ = f.Close()
+		return 0, fmt.Errorf("write export file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return 0, fmt.Errorf("write export file: %w", err)
+	}
+	return len(paths), nil
+}
+
+// exportSection renders one note as a `##` section for concatExporter.
+func exportSection(path, content string) string {
+	title := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	fm, body, err := frontmatter.Parse(content)
+	if err != nil {
+		body = content
+	} else if fm != nil && fm.Title != "" {
+		title = fm.Title
+	}
+
+	body = strings.TrimLeft(body, "\n")
+	if first, rest, _ := strings.Cut(body, "\n"); strings.TrimSpace(first) == "# "+title {
+		body = strings.TrimLeft(rest, "\n")
+	}
+	body = strings.TrimRight(body, "\n")
+	if body == "" {
+		return "## " + title + "\n"
+	}
+	return "## " + title + "\n\n" + body + "\n"
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeExportNotes(t *testing.T) []string {
+	t.Helper()
+	dir := t.TempDir()
+	a := filepath.Join(dir, "20240101-alpha.md")
+	b := filepath.Join(dir, "20240102-beta.md")
+	require.NoError(t, os.WriteFile(a, []byte("---\ntitle: Alpha\n---\n\n# Alpha\n\nFirst body.\n"), 0o644))
+	require.NoError(t, os.WriteFile(b, []byte("No frontmatter here.\n"), 0o644))
+	return []string{a, b}
+}
+
+func TestExportNotesConcat(t *testing.T) {
+	paths := writeExportNotes(t)
+	dest := filepath.Join(t.TempDir(), "out", "export.md")
+
+	n, err := newTestService().ExportNotes(paths, dest, ExportConcat)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "## Alpha\n\nFirst body.\n\n## 20240102-beta\n\nNo frontmatter here.\n", readFile(t, dest))
+
+	_, err = newTestService().ExportNotes(paths, dest, ExportConcat)
+	assert.Error(t, err, "an existing file is not overwritten")
+}
+
+func TestExportNotesCopy(t *testing.T) {
+	paths := writeExportNotes(t)
+	dest := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dest, "20240101-alpha.md"), []byte("taken"), 0o644))
+
+	n, err := newTestService().ExportNotes(paths, dest, ExportCopy)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "taken", readFile(t, filepath.Join(dest, "20240101-alpha.md")))
+	assert.Equal(t, readFile(t, paths[0]), readFile(t, filepath.Join(dest, "20240101-alpha-2.md")))
+	assert.Equal(t, readFile(t, paths[1]), readFile(t, filepath.Join(dest, "20240102-beta.md")))
+}
+
+func TestExportFormatForPath(t *testing.T) {
+	assert.Equal(t, ExportConcat, ExportFormatForPath("/tmp/notes.md"))
+	assert.Equal(t, ExportConcat, ExportFormatForPath("notes.MD"))
+	assert.Equal(t, ExportCopy, ExportFormatForPath("/tmp/notes"))
+}
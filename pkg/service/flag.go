@@ -0,0 +1,186 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// FlagConfig is one entry of the note flag palette: the name written to a
+// note's flag frontmatter field and the color the TUI draws the note in. The
+// color is a theme color name (red, orange, yellow, green, cyan, blue, pink)
+// or any lipgloss color, such as "#ff8800" or "208".
+type FlagConfig struct {
+	Name  string `yaml:"name"`
+	Color string `yaml:"color"`
+}
+
+// DefaultFlags is the flag palette used when none is configured.
+var DefaultFlags = []FlagConfig{
+	{Name: "red", Color: "red"},
+	{Name: "yellow", Color: "yellow"},
+	{Name: "green", Color: "green"},
+}
+
+// validateFlags rejects a configured palette with unnamed or repeated flags.
+func validateFlags(flags []FlagConfig) error {
+	seen := make(map[string]bool, len(flags))
+	for i, f := range flags {
+		name := normalizeFlag(f.Name)
+		if name == "" {
+			return fmt.Errorf("flags[%d] has no name", i)
+		}
+		if seen[name] {
+			return fmt.Errorf("flag %q is defined more than once", f.Name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// normalizeFlag is the form flag names are stored and compared in.
+func normalizeFlag(flag string) string {
+	return strings.ToLower(strings.TrimSpace(flag))
+}
+
+// Flags returns the configured flag palette, or DefaultFlags.
+func (s *Service) Flags() []FlagConfig {
+	if s != nil && s.Config != nil && len(s.Config.Flags) > 0 {
+		return s.Config.Flags
+	}
+	return DefaultFlags
+}
+
+// FlagNames lists the names in flags.
+func FlagNames(flags []FlagConfig) []string {
+	names := make([]string, len(flags))
+	for i, f := range flags {
+		names[i] = normalizeFlag(f.Name)
+	}
+	return names
+}
+
+// FlagColor returns the color of flag in flags, or "" when flag is not in the
+// palette.
+func FlagColor(flags []FlagConfig, flag string) string {
+	flag = normalizeFlag(flag)
+	if flag == "" {
+		return ""
+	}
+	for _, f := range flags {
+		if normalizeFlag(f.Name) == flag {
+			return f.Color
+		}
+	}
+	return ""
+}
+
+// NextFlag returns the flag after current in flags. No flag follows the last
+// one, and the first follows no flag or a flag missing from the palette.
+func NextFlag(flags []FlagConfig, current string) string {
+	current = normalizeFlag(current)
+	for i, f := range flags {
+		if normalizeFlag(f.Name) != current {
+			continue
+		}
+		if i+1 < len(flags) {
+			return normalizeFlag(flags[i+1].Name)
+		}
+		return ""
+	}
+	if len(flags) == 0 {
+		return ""
+	}
+	return normalizeFlag(flags[0].Name)
+}
+
+// SetFlag toggles flag on the note at path: the note's flag frontmatter field
+// is set to flag, or removed when the note already carries it or flag is
+// empty. The flag must be in the palette. It returns the note's new flag.
+// Other frontmatter and its formatting are preserved.
+func (s *Service) SetFlag(path, flag string) (string, error) {
+	flag = normalizeFlag(flag)
+	if flag != "" && FlagColor(s.Flags(), flag) == "" {
+		return "", fmt.Errorf("unknown flag %q (expected one of %s)", flag, strings.Join(FlagNames(s.Flags()), ", "))
+	}
+
+	unlock, err := s.LockNotebook(path)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	current, err := ReadNoteFlag(path)
+	if err != nil {
+		return "", fmt.Errorf("read note flag: %w", err)
+	}
+	if flag == current {
+		flag = ""
+	}
+	var value interface{}
+	if flag != "" {
+		value = flag
+	}
+	err = rewriteNote(path, func(content []byte) ([]byte, error) {
+		return updateFrontmatterFields(content, map[string]interface{}{"flag": value})
+	})
+	if err != nil {
+		return "", fmt.Errorf("update note flag: %w", err)
+	}
+
+	ws, _, _ := GetNoteMetadata(path)
+	s.opLog("flag", path, ws).WithField("flag", flag).Info("Set note flag")
+	return flag, nil
+}
+
+// NoteFlag returns the flag of note. Notes from the daemon index don't carry
+// one, so an empty Flag is read from the note's file.
+func NoteFlag(note *models.Note) string {
+	if note.Flag != "" {
+		return note.Flag
+	}
+	flag, _ := ReadNoteFlag(note.Path)
+	return flag
+}
+
+// maxFlagScanLines bounds how far into a note ReadNoteFlag looks for the end
+// of the frontmatter.
+const maxFlagScanLines = 200
+
+// ReadNoteFlag returns the flag frontmatter field of the note at path, or ""
+// when it has none. Only the frontmatter is read, line by line, and then
+// parsed with frontmatter.ParsePartial, so it is cheap enough to call for
+// every note of a listing.
+func ReadNoteFlag(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var header strings.Builder
+	scanner := bufio.NewScanner(f)
+	for i := 0; i <= maxFlagScanLines && scanner.Scan(); i++ {
+		line := scanner.Text()
+		if i == 0 && strings.TrimSpace(strings.TrimPrefix(line, "\ufeff")) != "---" {
+			return "", nil // No frontmatter
+		}
+		header.WriteString(line)
+		header.WriteByte('\n')
+		if i > 0 && strings.TrimSpace(line) == "---" {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	fm, err := frontmatter.ParsePartial(header.String())
+	if err != nil || fm == nil {
+		return "", err
+	}
+	return normalizeFlag(fm.Flag), nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetFlag(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "triage.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\nid: triage\ntitle: Triage\ncustom: kept\n---\nBody.\n"), 0o644))
+
+	s := newTestService()
+	flag, err := s.SetFlag(path, "Red")
+	require.NoError(t, err)
+	assert.Equal(t, "red", flag)
+
+	note, err := ParseNote(path)
+	require.NoError(t, err)
+	assert.Equal(t, "red", note.Flag)
+	got, err := ReadNoteFlag(path)
+	require.NoError(t, err)
+	assert.Equal(t, "red", got)
+	raw, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "custom: kept", "other frontmatter is preserved")
+
+	// Setting the flag the note already has clears it.
+	flag, err = s.SetFlag(path, "red")
+	require.NoError(t, err)
+	assert.Empty(t, flag)
+	raw, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "flag:")
+
+	_, err = s.SetFlag(path, "purple")
+	assert.ErrorContains(t, err, `unknown flag "purple"`)
+}
+
+func TestSetFlagCustomPalette(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "triage.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\nid: triage\ntitle: Triage\n---\n"), 0o644))
+
+	s := newTestService()
+	s.Config = &Config{Flags: []FlagConfig{{Name: "waiting", Color: "#d7af00"}}}
+	_, err := s.SetFlag(path, "red")
+	assert.Error(t, err, "the default palette no longer applies")
+	flag, err := s.SetFlag(path, "waiting")
+	require.NoError(t, err)
+	assert.Equal(t, "waiting", flag)
+}
+
+func TestNextFlag(t *testing.T) {
+	assert.Equal(t, "red", NextFlag(DefaultFlags, ""))
+	assert.Equal(t, "yellow", NextFlag(DefaultFlags, "red"))
+	assert.Equal(t, "green", NextFlag(DefaultFlags, "Yellow"))
+	assert.Equal(t, "", NextFlag(DefaultFlags, "green"), "no flag follows the last")
+	assert.Equal(t, "red", NextFlag(DefaultFlags, "removed"), "an unknown flag restarts the cycle")
+	assert.Equal(t, "", NextFlag(nil, ""))
+}
+
+func TestReadNoteFlag(t *testing.T) {
+	dir := t.TempDir()
+	for _, tt := range []struct {
+		content string
+		want    string
+	}{
+		{"---\ntitle: A\nflag: \"yellow\" # triage\n---\n", "yellow"},
+		{"---\ntitle: A\n---\nflag: red\n", ""},
+		{"flag: red\n", ""},
+		{"\ufeff---\r\nflag: Red\r\n---\r\n", "red"},
+		{"---\ntitle: A\n---\n", ""},
+	} {
+		path := filepath.Join(dir, "note.md")
+		require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o644))
+		got, err := ReadNoteFlag(path)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.content)
+	}
+}
+
+func TestValidateFlags(t *testing.T) {
+	assert.NoError(t, validateFlags(nil))
+	assert.NoError(t, validateFlags(DefaultFlags))
+	assert.ErrorContains(t, validateFlags([]FlagConfig{{Color: "red"}}), "has no name")
+	assert.ErrorContains(t, validateFlags([]FlagConfig{{Name: "red"}, {Name: "Red"}}), "more than once")
+}
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	coremodels "github.com/grovetools/core/pkg/models"
 	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
 )
 
 // ValidPriorities is the ordered set of accepted note priority values
@@ -51,15 +55,157 @@ func (s *Service) UpdateNotePriority(path, priority string) error {
 	return nil
 }
 
+// ReadRawFrontmatter returns the raw YAML between the frontmatter delimiters
+// of the note at path, as written on disk but with LF line endings. An empty string is returned
+// for notes without frontmatter.
+func (s *Service) ReadRawFrontmatter(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read note for frontmatter: %w", err)
+	}
+	raw, _, err := extractFrontmatterString(content)
+	if err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+// UpdateNoteRawFrontmatter replaces the frontmatter block of the note at path
+// with rawYAML, leaving the body untouched. The YAML must parse as a mapping
+// and carry the required id and title fields; otherwise the note is not
+// modified and the parse/validation error is returned.
+func (s *Service) UpdateNoteRawFrontmatter(path, rawYAML string) error {
+	var fm frontmatter.Frontmatter
+	if err := yaml.Unmarshal([]byte(rawYAML), &fm); err != nil {
+		return fmt.Errorf("parse frontmatter: %w", err)
+	}
+	var missing []string
+	if strings.TrimSpace(fm.ID) == "" {
+		missing = append(missing, "id")
+	}
+	if strings.TrimSpace(fm.Title) == "" {
+		missing = append(missing, "title")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required frontmatter field(s): %s", strings.Join(missing, ", "))
+	}
+
+	unlock, err := s.LockNotebook(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat note: %w", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read note: %w", err)
+	}
+	if _, _, err := extractFrontmatterString(content); err != nil {
+		return fmt.Errorf("read existing frontmatter: %w", err)
+	}
+
+	if err := os.WriteFile(path, replaceFrontmatter(content, rawYAML), info.Mode()); err != nil {
+		return fmt.Errorf("write note with updated frontmatter: %w", err)
+	}
+
+	ws, _, noteType := GetNoteMetadata(path)
+	EmitNoteEvent(coremodels.NoteEvent{
+		Event:     coremodels.NoteEventUpdated,
+		Workspace: ws,
+		NoteType:  noteType,
+		Path:      path,
+	})
+	return nil
+}
+
+// Touch marks the note at path as modified now without editing it, so it
+// sorts to the top of recent/modified listings. Only the frontmatter modified
+// field is rewritten (other fields and formatting are kept) and the file mtime
+// is set to the same instant. Notes without frontmatter only get their mtime
+// bumped.
+func (s *Service) Touch(path string) error {
+	unlock, err := s.LockNotebook(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat note: %w", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read note: %w", err)
+	}
+	raw, _, err := extractFrontmatterString(content)
+	if err != nil {
+		return fmt.Errorf("read existing frontmatter: %w", err)
+	}
+
+	stamp := frontmatter.FormatTimestamp(time.Now())
+	now, err := frontmatter.ParseTimestamp(stamp)
+	if err != nil {
+		return fmt.Errorf("parse timestamp: %w", err)
+	}
+	if raw != "" {
+		var newContent []byte
+		if updated, ok := replaceFrontmatterLine(raw, "modified", stamp); ok {
+			newContent = replaceFrontmatter(content, updated)
+		} else if newContent, err = updateFrontmatterFields(content, map[string]interface{}{"modified": stamp}); err != nil {
+			return fmt.Errorf("update modified frontmatter: %w", err)
+		}
+		if err := os.WriteFile(path, newContent, info.Mode()); err != nil {
+			return fmt.Errorf("write note: %w", err)
+		}
+	}
+	if err := os.Chtimes(path, now, now); err != nil {
+		return fmt.Errorf("set note mtime: %w", err)
+	}
+
+	ws, _, noteType := GetNoteMetadata(path)
+	s.opLog("touch", path, ws).Debug("Touched note")
+	EmitNoteEvent(coremodels.NoteEvent{
+		Event:     coremodels.NoteEventUpdated,
+		Workspace: ws,
+		NoteType:  noteType,
+		Path:      path,
+	})
+	return nil
+}
+
+// replaceFrontmatterLine swaps the value of a top-level "key: value" line in
+// raw YAML, leaving every other byte alone. It reports false when there is no
+// such line, e.g. when the key is missing or written as a block.
+func replaceFrontmatterLine(raw, key, value string) (string, bool) {
+	lines := strings.Split(raw, "\n")
+	for i, line := range lines {
+		rest, ok := strings.CutPrefix(line, key+":")
+		if !ok || strings.TrimSpace(rest) == "" {
+			continue
+		}
+		lines[i] = key + ": " + value
+		if strings.HasSuffix(rest, "\r") {
+			lines[i] += "\r"
+		}
+		return strings.Join(lines, "\n"), true
+	}
+	return raw, false
+}
+
 // parseFrontmatterToMap extracts YAML frontmatter from markdown content.
 // Returns the parsed YAML as a map, the remaining content, and any error.
 func parseFrontmatterToMap(content []byte) (map[string]interface{}, []byte, error) {
-	contentStr := string(content)
+	contentStr := frontmatter.NormalizeContent(string(content))
 
 	// Check if the file starts with frontmatter delimiter
-	if !strings.HasPrefix(contentStr, "---\n") && !strings.HasPrefix(contentStr, "---\r\n") {
+	if !strings.HasPrefix(contentStr, "---\n") {
 		// No frontmatter, return empty map and full content
-		return make(map[string]interface{}), content, nil
+		return make(map[string]interface{}), []byte(contentStr), nil
 	}
 
 	// Find the closing delimiter
@@ -75,10 +221,7 @@ func parseFrontmatterToMap(content []byte) (map[string]interface{}, []byte, erro
 	} else {
 		tmpIdx := strings.Index(contentStr[startIdx:], "\n---\n")
 		if tmpIdx == -1 {
-			tmpIdx = strings.Index(contentStr[startIdx:], "\r\n---\r\n")
-			if tmpIdx == -1 {
-				return nil, nil, fmt.Errorf("invalid frontmatter: no closing delimiter found")
-			}
+			return nil, nil, fmt.Errorf("invalid frontmatter: no closing delimiter found")
 		}
 		endIdx = startIdx + tmpIdx
 	}
@@ -177,7 +320,9 @@ func updateFrontmatterNode(yamlData []byte, updates map[string]interface{}) ([]b
 	return buf.Bytes(), nil
 }
 
-// updateNodeValue updates a specific field in a YAML node.
+// updateNodeValue updates a specific field in a YAML node. A nil value
+// removes the field, and a *yaml.Node value replaces it wholesale (for
+// mappings such as `reminder`).
 func updateNodeValue(node *yaml.Node, key string, value interface{}) {
 	if node.Kind != yaml.MappingNode {
 		return
@@ -187,14 +332,24 @@ func updateNodeValue(node *yaml.Node, key string, value interface{}) {
 	for i := 0; i < len(node.Content)-1; i += 2 {
 		keyNode := node.Content[i]
 		if keyNode.Value == key {
-			// Update the value node
-			valueNode := node.Content[i+1]
-			valueNode.Kind = yaml.ScalarNode
-			valueNode.Value = fmt.Sprint(value)
-			valueNode.Tag = resolveYAMLTag(value)
+			switch v := value.(type) {
+			case nil:
+				node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			case *yaml.Node:
+				node.Content[i+1] = v
+			default:
+				// Update the value node
+				valueNode := node.Content[i+1]
+				valueNode.Kind = yaml.ScalarNode
+				valueNode.Value = fmt.Sprint(value)
+				valueNode.Tag = resolveYAMLTag(value)
+			}
 			return
 		}
 	}
+	if value == nil {
+		return
+	}
 
 	// Key not found, add it
 	keyNode := &yaml.Node{
@@ -203,15 +358,29 @@ func updateNodeValue(node *yaml.Node, key string, value interface{}) {
 		Tag:   "!!str",
 	}
 
-	valueNode := &yaml.Node{
-		Kind:  yaml.ScalarNode,
-		Value: fmt.Sprint(value),
-		Tag:   resolveYAMLTag(value),
+	valueNode, ok := value.(*yaml.Node)
+	if !ok {
+		valueNode = &yaml.Node{
+			Kind:  yaml.ScalarNode,
+			Value: fmt.Sprint(value),
+			Tag:   resolveYAMLTag(value),
+		}
 	}
 
 	node.Content = append(node.Content, keyNode, valueNode)
 }
 
+// flowSequence returns values as a flow-style YAML sequence ("[a, b]"), the
+// form nb writes tags and backlinks in, for use as an updateFrontmatterFields
+// value.
+func flowSequence(values []string) *yaml.Node {
+	seq := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, v := range values {
+		seq.Content = append(seq.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v})
+	}
+	return seq
+}
+
 // resolveYAMLTag determines the appropriate YAML tag for a value.
 func resolveYAMLTag(value interface{}) string {
 	switch value.(type) {
@@ -229,11 +398,13 @@ func resolveYAMLTag(value interface{}) string {
 }
 
 // extractFrontmatterString extracts the raw YAML string between delimiters.
+// Like frontmatter.Parse it ignores a leading UTF-8 BOM and reads CRLF files
+// as LF, so the returned body (and any content rebuilt from it) is LF.
 func extractFrontmatterString(content []byte) (string, []byte, error) {
-	contentStr := string(content)
+	contentStr := frontmatter.NormalizeContent(string(content))
 
-	if !strings.HasPrefix(contentStr, "---\n") && !strings.HasPrefix(contentStr, "---\r\n") {
-		return "", content, nil
+	if !strings.HasPrefix(contentStr, "---\n") {
+		return "", []byte(contentStr), nil
 	}
 
 	startIdx := strings.Index(contentStr, "\n") + 1
@@ -243,10 +414,7 @@ func extractFrontmatterString(content []byte) (string, []byte, error) {
 
 	endIdx := strings.Index(contentStr[startIdx:], "\n---\n")
 	if endIdx == -1 {
-		endIdx = strings.Index(contentStr[startIdx:], "\r\n---\r\n")
-		if endIdx == -1 {
-			return "", nil, fmt.Errorf("invalid frontmatter: no closing delimiter found")
-		}
+		return "", nil, fmt.Errorf("invalid frontmatter: no closing delimiter found")
 	}
 	endIdx += startIdx
 
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	coremodels "github.com/grovetools/core/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateNoteRawFrontmatterKeepsBody(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	events := captureNoteEvents(t)
+	path := filepath.Join(t.TempDir(), "note.md")
+	body := "\n# Title  \n\n---\nnot: frontmatter\n---\n\ttabbed line\n\n\nno trailing newline"
+	require.NoError(t, os.WriteFile(path, []byte("---\nid: 20240101-a\ntitle: Old\n---\n"+body), 0o600))
+
+	raw := "id: 20240101-a\ntitle: New   # comment kept\ntags: [x, y]\n"
+	require.NoError(t, newTestService().UpdateNoteRawFrontmatter(path, raw))
+
+	assert.Equal(t, "---\nid: 20240101-a\ntitle: New   # comment kept\ntags: [x, y]\n---\n"+body, readFile(t, path))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	require.Len(t, *events, 1)
+	assert.Equal(t, coremodels.NoteEventUpdated, (*events)[0].Event)
+}
+
+func TestUpdateNoteRawFrontmatterRejectsInvalid(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	events := captureNoteEvents(t)
+	path := filepath.Join(t.TempDir(), "note.md")
+	original := "---\nid: 20240101-a\ntitle: A\n---\n\nbody\n"
+	require.NoError(t, os.WriteFile(path, []byte(original), 0o644))
+	s := newTestService()
+
+	cases := []struct {
+		name    string
+		raw     string
+		wantErr string
+	}{
+		{"missing id", "title: A\n", "missing required frontmatter field(s): id"},
+		{"missing title", "id: 20240101-a\n", "missing required frontmatter field(s): title"},
+		{"missing both", "tags: [x]\n", "missing required frontmatter field(s): id, title"},
+		{"blank title", "id: 20240101-a\ntitle: '  '\n", "title"},
+		{"bad yaml", "id: 20240101-a\ntitle: [unclosed\n", "parse frontmatter"},
+		{"not a mapping", "- id\n- title\n", "parse frontmatter"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := s.UpdateNoteRawFrontmatter(path, tc.raw)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+			assert.Equal(t, original, readFile(t, path), "note must be left untouched")
+		})
+	}
+	assert.Empty(t, *events)
+}
+
+func TestUpdateNoteRawFrontmatterWithoutFrontmatter(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	captureNoteEvents(t)
+	path := filepath.Join(t.TempDir(), "note.md")
+	body := "# Plain note\n\nno frontmatter here\n"
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o644))
+
+	require.NoError(t, newTestService().UpdateNoteRawFrontmatter(path, "id: 20240101-a\ntitle: Plain note"))
+
+	assert.Equal(t, "---\nid: 20240101-a\ntitle: Plain note\n---\n"+body, readFile(t, path))
+}
@@ -15,14 +15,11 @@ import (
 // staged is committed (no auto-staging). Returns ErrNothingToCommit if there's
 // nothing staged.
 func (s *Service) GitCommit(repoPath, message string) error {
-	cmd := exec.Command("git", "commit", "-m", message)
-	cmd.Dir = repoPath
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		if strings.Contains(string(output), "nothing to commit") {
+	if output, err := runGit(repoPath, "commit", "-m", message); err != nil {
+		if strings.Contains(output, "nothing to commit") {
 			return ErrNothingToCommit
 		}
-		return fmt.Errorf("git commit failed: %w\n%s", err, string(output))
+		return fmt.Errorf("git commit failed: %w\n%s", err, output)
 	}
 	return nil
 }
@@ -32,34 +29,29 @@ var ErrNothingToCommit = fmt.Errorf("nothing to commit")
 
 // GitStageAll runs `git add .` in the given repo.
 func (s *Service) GitStageAll(repoPath string) error {
-	cmd := exec.Command("git", "add", ".")
-	cmd.Dir = repoPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("git add failed: %w\n%s", err, string(output))
+	if output, err := runGit(repoPath, "add", "."); err != nil {
+		return fmt.Errorf("git add failed: %w\n%s", err, output)
 	}
 	return nil
 }
 
 // GitUnstageAll runs `git reset HEAD` in the given repo.
 func (s *Service) GitUnstageAll(repoPath string) error {
-	cmd := exec.Command("git", "reset", "HEAD")
-	cmd.Dir = repoPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("git reset failed: %w\n%s", err, string(output))
+	if output, err := runGit(repoPath, "reset", "HEAD"); err != nil {
+		return fmt.Errorf("git reset failed: %w\n%s", err, output)
 	}
 	return nil
 }
 
-// GitStagePaths runs `git add -- <paths>...` in the given repo.
+// GitStagePaths runs `git add -A -- <paths>...` in the given repo, so
+// deletions under the paths are staged too.
 func (s *Service) GitStagePaths(repoPath string, paths []string) error {
 	if len(paths) == 0 {
 		return nil
 	}
-	args := append([]string{"add", "--"}, paths...)
-	cmd := exec.Command("git", args...)
-	cmd.Dir = repoPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("git add failed: %w\n%s", err, string(output))
+	args := append([]string{"add", "-A", "--"}, paths...)
+	if output, err := runGit(repoPath, args...); err != nil {
+		return fmt.Errorf("git add failed: %w\n%s", err, output)
 	}
 	return nil
 }
@@ -70,14 +62,20 @@ func (s *Service) GitUnstagePaths(repoPath string, paths []string) error {
 		return nil
 	}
 	args := append([]string{"reset", "HEAD", "--"}, paths...)
-	cmd := exec.Command("git", args...)
-	cmd.Dir = repoPath
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return fmt.Errorf("git reset failed: %w\n%s", err, string(output))
+	if output, err := runGit(repoPath, args...); err != nil {
+		return fmt.Errorf("git reset failed: %w\n%s", err, output)
 	}
 	return nil
 }
 
+// runGit runs git in dir and returns its combined output.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
 // FindGitRoot returns the git repository root containing the given file path,
 // or the empty string if the path is not inside a git repository.
 func (s *Service) FindGitRoot(path string) (string, error) {
@@ -94,6 +92,11 @@ func (s *Service) ArchivePlanDirectory(ctx *WorkspaceContext, planGroup string)
 	if err != nil {
 		return nil, fmt.Errorf("get plans directory: %w", err)
 	}
+	unlock, err := s.LockNotebook(plansBaseDir)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
 	planName := strings.TrimPrefix(planGroup, "plans/")
 	sourcePath := filepath.Join(plansBaseDir, planName)
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/grovetools/core/git"
+)
+
+// ErrNoGitRemote is returned by CommitAndPushNotebook when the notebook's
+// repository has no remote to push to. The commit has been made.
+var ErrNoGitRemote = errors.New("notebook repository has no git remote")
+
+// GitSyncResult describes what CommitAndPushNotebook did.
+type GitSyncResult struct {
+	RepoPath  string
+	Message   string
+	Committed bool // False when there was nothing to commit
+	Amended   bool
+	Remote    string
+}
+
+// DefaultGitSyncMessage is the commit message CommitAndPushNotebook uses
+// when none is given.
+func DefaultGitSyncMessage(now time.Time) string {
+	return "nb: auto-sync " + now.Format("2006-01-02 15:04:05")
+}
+
+// NotebookGitRoot returns the root of the git repository holding ctx's
+// notebook directory, or an error when it is not under git.
+func (s *Service) NotebookGitRoot(ctx *WorkspaceContext) (string, error) {
+	root, _, err := s.notebookGitPaths(ctx)
+	return root, err
+}
+
+// notebookGitPaths returns the root of the git repository holding ctx's
+// notebook and the notebook directory, relative to that root.
+func (s *Service) notebookGitPaths(ctx *WorkspaceContext) (root, notebookDir string, err error) {
+	inboxPath, err := s.GetNotebookLocator().GetNotesDir(ctx.NotebookContextWorkspace, "inbox")
+	if err != nil {
+		return "", "", fmt.Errorf("resolve notebook path: %w", err)
+	}
+	// The inbox may not exist yet; its workspace directory does.
+	dir := filepath.Dir(inboxPath)
+	root, err = git.GetGitRoot(dir)
+	if err != nil || root == "" {
+		return "", "", fmt.Errorf("notebook directory is not a git repository; run 'nb git init'")
+	}
+	// git reports the root with symlinks resolved, so resolve dir the same
+	// way before making it relative (e.g. /var vs /private/var on macOS).
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	if resolvedRoot, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolvedRoot
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", "", fmt.Errorf("notebook directory %s is outside its git repository %s", dir, root)
+	}
+	return root, rel, nil
+}
+
+// CommitAndPushNotebook stages every change in ctx's notebook directory (git
+// add -A -- <dir>, so other files of an enclosing repository aren't staged),
+// commits it with message, or DefaultGitSyncMessage
+// when message is empty, and pushes to the branch's upstream. Without an
+// upstream the branch is pushed to origin, or the only remote, and tracked
+// from then on. With amend the last commit is amended instead and pushed
+// with --force-with-lease.
+//
+// A clean tree is not an error: whatever is not yet pushed still is. A
+// repository without remotes returns ErrNoGitRemote after committing.
+func (s *Service) CommitAndPushNotebook(ctx *WorkspaceContext, message string, amend bool) (*GitSyncResult, error) {
+	root, notebookDir, err := s.notebookGitPaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if message == "" {
+		message = DefaultGitSyncMessage(time.Now())
+	}
+	result := &GitSyncResult{RepoPath: root, Message: message}
+
+	if err := s.commitNotebookChanges(root, notebookDir, message, amend, result); err != nil {
+		return result, err
+	}
+
+	remote, hasUpstream, err := gitPushTarget(root)
+	if err != nil {
+		return result, err
+	}
+	result.Remote = remote
+	pushArgs := []string{"push"}
+	if amend {
+		pushArgs = append(pushArgs, "--force-with-lease")
+	}
+	if !hasUpstream {
+		pushArgs = append(pushArgs, "--set-upstream", remote, "HEAD")
+	}
+	if out, err := runGit(root, pushArgs...); err != nil {
+		return result, fmt.Errorf("git push failed: %w\n%s", err, out)
+	}
+
+	s.opLog("git_sync", "", ctx.NotebookContextWorkspace.Name).
+		WithField("repo", root).WithField("committed", result.Committed).
+		Info("Committed and pushed notebook")
+	return result, nil
+}
+
+// commitNotebookChanges stages and commits everything under notebookDir
+// (relative to root) while holding the notebook lock, so no note is
+// half-written when it is staged.
+func (s *Service) commitNotebookChanges(root, notebookDir, message string, amend bool, result *GitSyncResult) error {
+	unlock, err := s.LockNotebook(filepath.Join(root, notebookDir))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.GitStagePaths(root, []string{notebookDir}); err != nil {
+		return err
+	}
+	if amend {
+		if out, err := runGit(root, "commit", "--amend", "-m", message); err != nil {
+			return fmt.Errorf("git commit --amend failed: %w\n%s", err, out)
+		}
+		result.Committed, result.Amended = true, true
+		return nil
+	}
+	switch err := s.GitCommit(root, message); {
+	case errors.Is(err, ErrNothingToCommit):
+	case err != nil:
+		return err
+	default:
+		result.Committed = true
+	}
+	return nil
+}
+
+// gitPushTarget returns the remote HEAD is pushed to and whether the branch
+// already tracks an upstream there.
+func gitPushTarget(root string) (remote string, hasUpstream bool, err error) {
+	if out, err := runGit(root, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}"); err == nil {
+		if name, _, ok := strings.Cut(strings.TrimSpace(out), "/"); ok {
+			return name, true, nil
+		}
+	}
+	out, err := runGit(root, "remote")
+	if err != nil {
+		return "", false, fmt.Errorf("git remote failed: %w\n%s", err, out)
+	}
+	remotes := strings.Fields(out)
+	switch {
+	case len(remotes) == 0:
+		return "", false, ErrNoGitRemote
+	case len(remotes) == 1:
+		return remotes[0], false, nil
+	}
+	for _, r := range remotes {
+		if r == "origin" {
+			return r, false, nil
+		}
+	}
+	return "", false, fmt.Errorf("notebook repository has several remotes and no upstream; push once with 'git push -u <remote>'")
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// initGitRepo creates a repository with a committer identity in a temp dir.
+func initGitRepo(t *testing.T, bare bool) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	args := []string{"init", "-q"}
+	if bare {
+		args = append(args, "--bare")
+	}
+	out, err := runGit(dir, args...)
+	require.NoError(t, err, out)
+	if !bare {
+		for _, kv := range [][2]string{{"user.name", "nb"}, {"user.email", "nb@example.com"}} {
+			out, err := runGit(dir, "config", kv[0], kv[1])
+			require.NoError(t, err, out)
+		}
+	}
+	return dir
+}
+
+func TestCommitNotebookChanges(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	s := newTestService()
+	repo := initGitRepo(t, false)
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "note.md"), []byte("# Note\n"), 0o644))
+
+	var result GitSyncResult
+	require.NoError(t, s.commitNotebookChanges(repo, ".", "nb: auto-sync", false, &result))
+	assert.True(t, result.Committed)
+	log, err := runGit(repo, "log", "--format=%s")
+	require.NoError(t, err)
+	assert.Equal(t, "nb: auto-sync\n", log)
+
+	result = GitSyncResult{}
+	require.NoError(t, s.commitNotebookChanges(repo, ".", "again", false, &result), "a clean tree is not an error")
+	assert.False(t, result.Committed)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "note.md"), []byte("# Note\n\nMore.\n"), 0o644))
+	require.NoError(t, s.commitNotebookChanges(repo, ".", "amended", true, &result))
+	assert.True(t, result.Amended)
+	log, err = runGit(repo, "log", "--format=%s")
+	require.NoError(t, err)
+	assert.Equal(t, "amended\n", log)
+}
+
+func TestCommitNotebookChangesOnlyStagesNotebookDir(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	s := newTestService()
+	repo := initGitRepo(t, false)
+	notes := filepath.Join(repo, "notes")
+	require.NoError(t, os.MkdirAll(notes, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(notes, "keep.md"), []byte("# Keep\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(notes, "gone.md"), []byte("# Gone\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n"), 0o644))
+
+	var result GitSyncResult
+	require.NoError(t, s.commitNotebookChanges(repo, "notes", "first", false, &result))
+	assert.True(t, result.Committed)
+
+	require.NoError(t, os.Remove(filepath.Join(notes, "gone.md")))
+	require.NoError(t, s.commitNotebookChanges(repo, "notes", "second", false, &result))
+
+	tracked, err := runGit(repo, "ls-files")
+	require.NoError(t, err, tracked)
+	assert.Equal(t, "notes/keep.md\n", tracked, "deletions are staged and files outside the notebook aren't")
+	status, err := runGit(repo, "status", "--porcelain")
+	require.NoError(t, err, status)
+	assert.Equal(t, "?? main.go\n", status)
+}
+
+func TestGitPushTarget(t *testing.T) {
+	repo := initGitRepo(t, false)
+	_, _, err := gitPushTarget(repo)
+	assert.ErrorIs(t, err, ErrNoGitRemote)
+
+	bare := initGitRepo(t, true)
+	out, err := runGit(repo, "remote", "add", "backup", bare)
+	require.NoError(t, err, out)
+	remote, tracked, err := gitPushTarget(repo)
+	require.NoError(t, err)
+	assert.Equal(t, "backup", remote, "the only remote is used")
+	assert.False(t, tracked)
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "note.md"), []byte("# Note\n"), 0o644))
+	for _, args := range [][]string{{"add", "-A"}, {"commit", "-qm", "first"}, {"push", "-q", "--set-upstream", "backup", "HEAD"}} {
+		out, err := runGit(repo, args...)
+		require.NoError(t, err, out)
+	}
+	remote, tracked, err = gitPushTarget(repo)
+	require.NoError(t, err)
+	assert.Equal(t, "backup", remote)
+	assert.True(t, tracked)
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// reservedGroupNames are directories nb manages itself or treats specially,
+// so they can't be created or deleted as plain groups.
+var reservedGroupNames = map[string]bool{
+	"plans":     true,
+	"concepts":  true,
+	"templates": true,
+	"recipes":   true,
+	"archive":   true,
+}
+
+// ValidateGroupName returns an error if name can't be used as a top-level
+// group: it must be non-empty, contain no path separators, not start with a
+// dot, and not be a reserved directory such as plans or concepts.
+func ValidateGroupName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("group name cannot be empty")
+	case strings.ContainsAny(name, `/\`):
+		return fmt.Errorf("group name %q cannot contain path separators", name)
+	case strings.HasPrefix(name, "."):
+		return fmt.Errorf("group name %q cannot start with a dot", name)
+	case reservedGroupNames[name]:
+		return fmt.Errorf("group name %q is reserved", name)
+	}
+	return nil
+}
+
+// GroupInfo describes a top-level group directory in a workspace.
+type GroupInfo struct {
+	Name      string              `json:"name"`
+	Path      string              `json:"path"`
+	NoteCount int                 `json:"note_count"`
+	Config    *models.GroupConfig `json:"config,omitempty"`
+}
+
+type groupOptions struct {
+	config models.GroupConfig
+}
+
+type GroupOption func(*groupOptions)
+
+// WithGroupDescription stores a description in the group's .nb-group.yml.
+func WithGroupDescription(description string) GroupOption {
+	return func(o *groupOptions) {
+		o.config.Description = description
+	}
+}
+
+// WithGroupIcon stores a display icon in the group's .nb-group.yml.
+func WithGroupIcon(icon string) GroupOption {
+	return func(o *groupOptions) {
+		o.config.Icon = icon
+	}
+}
+
+// WithGroupSort stores a default sort order in the group's .nb-group.yml.
+func WithGroupSort(sortOrder string) GroupOption {
+	return func(o *groupOptions) {
+		o.config.Sort = sortOrder
+	}
+}
+
+// CreateGroup creates an empty top-level group directory in the workspace.
+// When any GroupOption is given, a .nb-group.yml recording the name and the
+// options is written alongside.
+func (s *Service) CreateGroup(ctx *WorkspaceContext, group string, options ...GroupOption) error {
+	if err := ValidateGroupName(group); err != nil {
+		return err
+	}
+	opts := &groupOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	groupDir, err := s.groupDir(ctx, group)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(groupDir); err == nil {
+		return fmt.Errorf("group already exists: %s", group)
+	}
+	if err := os.MkdirAll(groupDir, 0o755); err != nil {
+		return fmt.Errorf("create group directory: %w", err)
+	}
+
+	if opts.config != (models.GroupConfig{}) {
+		opts.config.Name = group
+		if err := writeGroupConfig(groupDir, &opts.config); err != nil {
+			return err
+		}
+	}
+	s.opLog("create_group", "", ctx.NotebookContextWorkspace.Name).WithField("group", group).Info("Created group")
+	return nil
+}
+
+// ListGroups returns the workspace's top-level groups, sorted by name, with
+// the number of notes each contains and its .nb-group.yml if present.
+// Hidden directories are skipped.
+func (s *Service) ListGroups(ctx *WorkspaceContext) ([]GroupInfo, error) {
+	root, err := s.notebookLocator.GetNotesDir(ctx.NotebookContextWorkspace, "")
+	if err != nil {
+		return nil, fmt.Errorf("get notes directory: %w", err)
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read notes directory: %w", err)
+	}
+
+	var groups []GroupInfo
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		dir := filepath.Join(root, entry.Name())
+		cfg, err := ReadGroupConfig(dir)
+		if err != nil {
+			s.opLog("list_groups", "", ctx.NotebookContextWorkspace.Name).WithError(err).WithField("dir", dir).Warn("Ignoring invalid group config")
+		}
+		groups = append(groups, GroupInfo{
+			Name:      entry.Name(),
+			Path:      dir,
+			NoteCount: countGroupNotes(dir),
+			Config:    cfg,
+		})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups, nil
+}
+
+// DeleteGroup removes a top-level group directory. A group that still holds
+// notes is only removed when force is set; archived notes count too.
+func (s *Service) DeleteGroup(ctx *WorkspaceContext, group string, force bool) error {
+	if err := ValidateGroupName(group); err != nil {
+		return err
+	}
+	groupDir, err := s.groupDir(ctx, group)
+	if err != nil {
+		return err
+	}
+	unlock, err := s.LockNotebook(groupDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if info, err := os.Stat(groupDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("group not found: %s", group)
+	}
+	if n := countGroupFiles(groupDir); n > 0 && !force {
+		return fmt.Errorf("group %s contains %d files; use --force to delete it anyway", group, n)
+	}
+	if err := os.RemoveAll(groupDir); err != nil {
+		return fmt.Errorf("delete group: %w", err)
+	}
+	s.opLog("delete_group", "", ctx.NotebookContextWorkspace.Name).WithField("group", group).Info("Deleted group")
+	return nil
+}
+
+// ReadGroupConfig loads dir's .nb-group.yml. It returns nil, nil when the
+// group has no config file.
+func ReadGroupConfig(dir string) (*models.GroupConfig, error) {
+	data, err := os.ReadFile(filepath.Join(dir, models.GroupConfigFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read group config: %w", err)
+	}
+	var cfg models.GroupConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse group config: %w", err)
+	}
+	return &cfg, nil
+}
+
+func writeGroupConfig(dir string, cfg *models.GroupConfig) error {
+	data, err := marshalGroupConfig(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, models.GroupConfigFilename), data, 0o644); err != nil {
+		return fmt.Errorf("write group config: %w", err)
+	}
+	return nil
+}
+
+func marshalGroupConfig(cfg *models.GroupConfig) ([]byte, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("marshal group config: %w", err)
+	}
+	return data, nil
+}
+
+func (s *Service) groupDir(ctx *WorkspaceContext, group string) (string, error) {
+	root, err := s.notebookLocator.GetNotesDir(ctx.NotebookContextWorkspace, "")
+	if err != nil {
+		return "", fmt.Errorf("get notes directory: %w", err)
+	}
+	return filepath.Join(root, group), nil
+}
+
+// countGroupNotes counts the markdown notes under dir, skipping hidden
+// directories such as .archive.
+func countGroupNotes(dir string) int {
+	count := 0
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".md") {
+			count++
+		}
+		return nil
+	})
+	return count
+}
+
+// countGroupFiles counts every file under dir except the group config.
+func countGroupFiles(dir string) int {
+	count := 0
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && d.Name() != models.GroupConfigFilename {
+			count++
+		}
+		return nil
+	})
+	return count
+}
@@ -0,0 +1,281 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	coremodels "github.com/grovetools/core/pkg/models"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// RenameGroup renames the top-level group oldGroup to newGroup. The group
+// directory is renamed (its per-directory .archive moves with it), as is the
+// group's archive under the notebook-level .archive directory if it has one.
+// Every note moved whose type is oldGroup or one of its subgroups gets the
+// new type, and a tag naming oldGroup is renamed too. It returns the files
+// rewritten, at their new paths; notes that only moved are not listed.
+func (s *Service) RenameGroup(ctx *WorkspaceContext, oldGroup, newGroup string) ([]string, error) {
+	return s.renameGroup(ctx, oldGroup, newGroup, false)
+}
+
+// PreviewRenameGroup reports what RenameGroup would rewrite without changing
+// anything.
+func (s *Service) PreviewRenameGroup(ctx *WorkspaceContext, oldGroup, newGroup string) ([]string, error) {
+	return s.renameGroup(ctx, oldGroup, newGroup, true)
+}
+
+func (s *Service) renameGroup(ctx *WorkspaceContext, oldGroup, newGroup string, dryRun bool) ([]string, error) {
+	if err := ValidateGroupName(oldGroup); err != nil {
+		return nil, err
+	}
+	if err := ValidateGroupName(newGroup); err != nil {
+		return nil, err
+	}
+	if oldGroup == newGroup {
+		return nil, fmt.Errorf("group is already called %s", newGroup)
+	}
+	root, err := s.notebookLocator.GetNotesDir(ctx.NotebookContextWorkspace, "")
+	if err != nil {
+		return nil, fmt.Errorf("get notes directory: %w", err)
+	}
+	if !dryRun {
+		unlock, err := s.LockNotebook(root)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+
+	// Directory renames, the group itself first.
+	moves := [][2]string{{filepath.Join(root, oldGroup), filepath.Join(root, newGroup)}}
+	if info, err := os.Stat(moves[0][0]); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("group not found: %s", oldGroup)
+	}
+	archiveDir := filepath.Join(root, ".archive", oldGroup)
+	if info, err := os.Stat(archiveDir); err == nil && info.IsDir() {
+		moves = append(moves, [2]string{archiveDir, filepath.Join(root, ".archive", newGroup)})
+	}
+	for _, mv := range moves {
+		if _, err := os.Stat(mv[1]); err == nil {
+			return nil, fmt.Errorf("cannot rename group to %s: %s already exists", newGroup, mv[1])
+		}
+	}
+
+	// Every rewrite is worked out before anything moves, so a note that
+	// can't be read stops the rename while the group is still intact.
+	rewrites := make([][]groupNoteRewrite, len(moves))
+	notes := make([][]string, len(moves))
+	for i, mv := range moves {
+		notes[i], rewrites[i], err = groupRenameRewrites(mv[0], oldGroup, newGroup)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var updated []string
+	if dryRun {
+		for i, mv := range moves {
+			for _, rw := range rewrites[i] {
+				updated = append(updated, filepath.Join(mv[1], rw.rel))
+			}
+		}
+		return updated, nil
+	}
+
+	var txn groupRenameTxn
+	for i, mv := range moves {
+		if err := txn.rename(mv[0], mv[1]); err != nil {
+			return nil, txn.fail(fmt.Errorf("rename group directory: %w", err))
+		}
+		for _, rw := range rewrites[i] {
+			path := filepath.Join(mv[1], rw.rel)
+			if err := txn.write(path, rw); err != nil {
+				return nil, txn.fail(fmt.Errorf("write note: %w", err))
+			}
+			updated = append(updated, path)
+		}
+	}
+
+	for i, mv := range moves {
+		for _, rel := range notes[i] {
+			EmitNoteEvent(coremodels.NoteEvent{
+				Event:         coremodels.NoteEventMoved,
+				Workspace:     ctx.NotebookContextWorkspace.Name,
+				NoteType:      newGroup,
+				Path:          filepath.Join(mv[1], rel),
+				PrevWorkspace: ctx.NotebookContextWorkspace.Name,
+				PrevNoteType:  oldGroup,
+				PrevPath:      filepath.Join(mv[0], rel),
+			})
+		}
+	}
+
+	s.opLog("rename_group", "", ctx.NotebookContextWorkspace.Name).
+		WithField("group", oldGroup).
+		WithField("new_group", newGroup).
+		WithField("updated", len(updated)).
+		Info("Renamed group")
+	return updated, nil
+}
+
+// groupNoteRewrite is the new content of a file under a group being renamed,
+// rel being its path relative to the group directory. original is what the
+// file held before, for rolling back.
+type groupNoteRewrite struct {
+	rel      string
+	content  []byte
+	original []byte
+	mode     fs.FileMode
+}
+
+// groupRenameTxn records the directory renames and file writes of a group
+// rename so that a failure part way through can undo them.
+type groupRenameTxn struct {
+	renames [][2]string
+	paths   []string
+	writes  []groupNoteRewrite
+}
+
+func (t *groupRenameTxn) rename(from, to string) error {
+	if err := os.Rename(from, to); err != nil {
+		return err
+	}
+	t.renames = append(t.renames, [2]string{from, to})
+	return nil
+}
+
+func (t *groupRenameTxn) write(path string, rw groupNoteRewrite) error {
+	if err := os.WriteFile(path, rw.content, rw.mode); err != nil {
+		return err
+	}
+	t.paths = append(t.paths, path)
+	t.writes = append(t.writes, rw)
+	return nil
+}
+
+// fail undoes everything done so far, newest first, and returns err,
+// along with any step that could not be undone.
+func (t *groupRenameTxn) fail(err error) error {
+	var undoErrs []error
+	for i := len(t.writes) - 1; i >= 0; i-- {
+		if werr := os.WriteFile(t.paths[i], t.writes[i].original, t.writes[i].mode); werr != nil {
+			undoErrs = append(undoErrs, fmt.Errorf("restore %s: %w", t.paths[i], werr))
+		}
+	}
+	for i := len(t.renames) - 1; i >= 0; i-- {
+		mv := t.renames[i]
+		if rerr := os.Rename(mv[1], mv[0]); rerr != nil {
+			undoErrs = append(undoErrs, fmt.Errorf("move %s back: %w", mv[1], rerr))
+		}
+	}
+	t.renames, t.paths, t.writes = nil, nil, nil
+	if len(undoErrs) > 0 {
+		return fmt.Errorf("%w (rollback incomplete: %w)", err, errors.Join(undoErrs...))
+	}
+	return err
+}
+
+// groupRenameRewrites walks dir, a group being renamed from oldGroup to
+// newGroup, and returns the relative paths of its notes and the rewrites
+// their frontmatter (and the group's .nb-group.yml) need. Nothing is written.
+func groupRenameRewrites(dir, oldGroup, newGroup string) ([]string, []groupNoteRewrite, error) {
+	var notes []string
+	var rewrites []groupNoteRewrite
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		if rel == models.GroupConfigFilename {
+			return groupConfigRewrite(path, oldGroup, newGroup, &rewrites)
+		}
+		if !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		notes = append(notes, rel)
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read note: %w", err)
+		}
+		fm, err := frontmatter.ParsePartial(string(content))
+		if err != nil || fm == nil {
+			// Nothing to rewrite in a note without frontmatter.
+			return nil
+		}
+		fields := make(map[string]interface{})
+		if fm.Type == oldGroup || strings.HasPrefix(fm.Type, oldGroup+"/") {
+			fields["type"] = newGroup + strings.TrimPrefix(fm.Type, oldGroup)
+		}
+		for i, tag := range fm.Tags {
+			if tag == oldGroup {
+				fm.Tags[i] = newGroup
+				fields["tags"] = flowSequence(frontmatter.MergeTags(fm.Tags))
+			}
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		updated, err := updateFrontmatterFields(content, fields)
+		if err != nil {
+			return fmt.Errorf("update %s: %w", rel, err)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("stat note: %w", err)
+		}
+		rewrites = append(rewrites, groupNoteRewrite{
+			rel:      rel,
+			content:  updated,
+			original: content,
+			mode:     info.Mode(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("read group %s: %w", oldGroup, err)
+	}
+	return notes, rewrites, nil
+}
+
+// groupConfigRewrite renames the group in the .nb-group.yml at path when it
+// records oldGroup as the name.
+func groupConfigRewrite(path, oldGroup, newGroup string, rewrites *[]groupNoteRewrite) error {
+	cfg, err := ReadGroupConfig(filepath.Dir(path))
+	if err != nil || cfg == nil || cfg.Name != oldGroup {
+		// An unreadable config is left for ListGroups to warn about.
+		return nil
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read group config: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat group config: %w", err)
+	}
+	cfg.Name = newGroup
+	data, err := marshalGroupConfig(cfg)
+	if err != nil {
+		return err
+	}
+	*rewrites = append(*rewrites, groupNoteRewrite{
+		rel:      models.GroupConfigFilename,
+		content:  data,
+		original: original,
+		mode:     info.Mode(),
+	})
+	return nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestGroupRenameRewrites(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.md":            "---\ntitle: A\ntype: issues\ntags: [issues, x]\ncustom: kept # note\n---\nbody a\n",
+		"sub/b.md":        "---\ntitle: B\ntype: issues/bugs\n---\nbody b\n",
+		"c.md":            "---\ntitle: C\ntype: other\n---\nbody c\n",
+		"plain.md":        "no frontmatter\n",
+		".archive/old.md": "---\ntitle: Old\ntype: issues\n---\n",
+		".git/HEAD.md":    "---\ntype: issues\n---\n",
+		"data.json":       "{}",
+	}
+	for rel, content := range files {
+		path := filepath.Join(dir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	require.NoError(t, writeGroupConfig(dir, &models.GroupConfig{Name: "issues", Icon: "I"}))
+
+	notes, rewrites, err := groupRenameRewrites(dir, "issues", "tickets")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"a.md", "sub/b.md", "c.md", "plain.md", ".archive/old.md"}, notes)
+
+	byRel := make(map[string]string)
+	for _, rw := range rewrites {
+		byRel[rw.rel] = string(rw.content)
+	}
+	require.Len(t, byRel, 4, "a.md, sub/b.md, .archive/old.md and the group config")
+
+	fm, body, err := frontmatter.Parse(byRel["a.md"])
+	require.NoError(t, err)
+	assert.Equal(t, "tickets", fm.Type)
+	assert.Equal(t, []string{"tickets", "x"}, fm.Tags)
+	assert.Contains(t, body, "body a")
+	assert.Contains(t, byRel["a.md"], "tags: [tickets, x]\n", "tags stay a flow sequence")
+	assert.Contains(t, byRel["a.md"], "custom: kept # note\n", "other fields keep their formatting")
+
+	fm, _, err = frontmatter.Parse(byRel["sub/b.md"])
+	require.NoError(t, err)
+	assert.Equal(t, "tickets/bugs", fm.Type)
+
+	fm, _, err = frontmatter.Parse(byRel[".archive/old.md"])
+	require.NoError(t, err)
+	assert.Equal(t, "tickets", fm.Type)
+
+	assert.Contains(t, byRel[models.GroupConfigFilename], "name: tickets")
+
+	// Nothing is written.
+	assert.Equal(t, files["a.md"], readFile(t, filepath.Join(dir, "a.md")))
+}
+
+func TestGroupRenameTxnRollsBack(t *testing.T) {
+	root := t.TempDir()
+	oldDir, newDir := filepath.Join(root, "issues"), filepath.Join(root, "tickets")
+	require.NoError(t, os.MkdirAll(oldDir, 0o755))
+	original := "---\ntype: issues\n---\n"
+	require.NoError(t, os.WriteFile(filepath.Join(oldDir, "a.md"), []byte(original), 0o600))
+
+	var txn groupRenameTxn
+	require.NoError(t, txn.rename(oldDir, newDir))
+	require.NoError(t, txn.write(filepath.Join(newDir, "a.md"), groupNoteRewrite{
+		rel:      "a.md",
+		content:  []byte("---\ntype: tickets\n---\n"),
+		original: []byte(original),
+		mode:     0o600,
+	}))
+	// The archive move fails: its source doesn't exist.
+	err := txn.rename(filepath.Join(root, ".archive", "issues"), filepath.Join(root, ".archive", "tickets"))
+	require.Error(t, err)
+	err = txn.fail(err)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "rollback incomplete")
+
+	assert.NoDirExists(t, newDir)
+	assert.Equal(t, original, readFile(t, filepath.Join(oldDir, "a.md")))
+	info, err := os.Stat(filepath.Join(oldDir, "a.md"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestValidateGroupName(t *testing.T) {
+	assert.NoError(t, ValidateGroupName("research"))
+	assert.NoError(t, ValidateGroupName("meeting-notes"))
+	for _, bad := range []string{"", "  ", "a/b", `a\b`, ".archive", "..", "plans", "concepts"} {
+		assert.Error(t, ValidateGroupName(bad), bad)
+	}
+}
+
+func TestGroupConfigRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := ReadGroupConfig(dir)
+	require.NoError(t, err)
+	assert.Nil(t, cfg, "missing config is not an error")
+
+	want := &models.GroupConfig{Name: "research", Description: "Deep dives", Icon: "R"}
+	require.NoError(t, writeGroupConfig(dir, want))
+	got, err := ReadGroupConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestCountGroupNotes(t *testing.T) {
+	dir := t.TempDir()
+	for _, rel := range []string{"a.md", "b.md", "data.json", "sub/c.md", ".archive/old.md", models.GroupConfigFilename} {
+		path := filepath.Join(dir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte("x"), 0o644))
+	}
+	assert.Equal(t, 3, countGroupNotes(dir), "markdown notes outside hidden dirs")
+	assert.Equal(t, 5, countGroupFiles(dir), "every file but the group config")
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// HooksConfig holds shell commands run around note creation. Each runs with
+// `sh -c` (`cmd /C` on Windows) and receives the note's details as NB_NOTE_*
+// environment variables.
+type HooksConfig struct {
+	// PreCreate runs before the note file is written; a non-zero exit aborts
+	// the creation.
+	PreCreate string `yaml:"pre_create"`
+	// PostCreate runs in the background once the note exists. Failures are
+	// logged and otherwise ignored.
+	PostCreate string `yaml:"post_create"`
+	// PostSyncGit commits and pushes the notebook (see
+	// CommitAndPushNotebook) after a successful `nb remote sync`.
+	PostSyncGit bool `yaml:"post_sync_git"`
+}
+
+// HookEnvVar is set to the hook's name in a hook's environment. nb does not
+// run hooks while it is set, so a hook that creates notes cannot loop.
+const HookEnvVar = "NB_HOOK"
+
+// preCreateHookTimeout bounds how long creation waits on a pre_create hook.
+const preCreateHookTimeout = 30 * time.Second
+
+// hookNote is what a hook is told about the note being created.
+type hookNote struct {
+	path      string
+	title     string
+	noteType  models.NoteType
+	workspace string
+	branch    string
+	id        string
+	tags      []string
+}
+
+// newHookNote describes a created note to a hook. Workspace, branch and type
+// come from the caller, which knows them better than the parsed file.
+func newHookNote(note *models.Note, workspace, branch string, noteType models.NoteType) hookNote {
+	title := note.FrontmatterTitle
+	if title == "" {
+		title = note.Title
+	}
+	return hookNote{
+		path:      note.Path,
+		title:     title,
+		noteType:  noteType,
+		workspace: workspace,
+		branch:    branch,
+		id:        note.ID,
+		tags:      note.Tags,
+	}
+}
+
+// WithoutHooks skips the configured pre_create and post_create hooks.
+func WithoutHooks() CreateOption {
+	return func(o *createOptions) {
+		o.skipHooks = true
+	}
+}
+
+// hookCommand returns the command configured for hook, or "" when hooks are
+// off for this creation.
+func (s *Service) hookCommand(opts *createOptions, hook string) string {
+	if opts.skipHooks || s.Config == nil || os.Getenv(HookEnvVar) != "" {
+		return ""
+	}
+	switch hook {
+	case "pre_create":
+		return strings.TrimSpace(s.Config.Hooks.PreCreate)
+	case "post_create":
+		return strings.TrimSpace(s.Config.Hooks.PostCreate)
+	}
+	return ""
+}
+
+// runPreCreateHook runs the pre_create hook and returns an error, including
+// the hook's output, when it exits non-zero.
+func (s *Service) runPreCreateHook(opts *createOptions, note hookNote) error {
+	command := s.hookCommand(opts, "pre_create")
+	if command == "" {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), preCreateHookTimeout)
+	defer cancel()
+
+	cmd := hookShell(ctx, command)
+	cmd.Env = append(os.Environ(), hookEnv("pre_create", note)...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("pre_create hook rejected %s: %w: %s", filepath.Base(note.path), err, msg)
+		}
+		return fmt.Errorf("pre_create hook rejected %s: %w", filepath.Base(note.path), err)
+	}
+	return nil
+}
+
+// startPostCreateHook starts the post_create hook without waiting for it.
+func (s *Service) startPostCreateHook(opts *createOptions, note hookNote) {
+	command := s.hookCommand(opts, "post_create")
+	if command == "" {
+		return
+	}
+	cmd := hookShell(context.Background(), command)
+	cmd.Env = append(os.Environ(), hookEnv("post_create", note)...)
+	log := s.opLog("post-create-hook", note.path, note.workspace)
+	if err := cmd.Start(); err != nil {
+		log.WithError(err).Warn("Failed to start post_create hook")
+		return
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			log.WithError(err).Warn("post_create hook failed")
+		}
+	}()
+}
+
+// hookShell returns the command running a hook through the platform's shell.
+func hookShell(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// hookEnv lists the environment variables describing note to a hook.
+func hookEnv(hook string, note hookNote) []string {
+	return []string{
+		HookEnvVar + "=" + hook,
+		"NB_NOTE_PATH=" + note.path,
+		"NB_NOTE_TITLE=" + note.title,
+		"NB_NOTE_TYPE=" + string(note.noteType),
+		"NB_NOTE_WORKSPACE=" + note.workspace,
+		"NB_NOTE_BRANCH=" + note.branch,
+		"NB_NOTE_ID=" + note.id,
+		"NB_NOTE_TAGS=" + strings.Join(note.tags, ","),
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+func newHookTestService(hooks HooksConfig) *Service {
+	s := newTestService()
+	s.Config = &Config{Hooks: hooks}
+	return s
+}
+
+func TestPreCreateHookAbortsOnNonZeroExit(t *testing.T) {
+	t.Setenv(HookEnvVar, "")
+	s := newHookTestService(HooksConfig{PreCreate: `echo "no titles about $NB_NOTE_TITLE" >&2; exit 3`})
+	note := hookNote{path: "/nb/inbox/20240101-secret.md", title: "secret", noteType: "inbox"}
+
+	err := s.runPreCreateHook(&createOptions{}, note)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "20240101-secret.md")
+	assert.Contains(t, err.Error(), "no titles about secret")
+
+	assert.NoError(t, s.runPreCreateHook(&createOptions{skipHooks: true}, note), "WithoutHooks skips the hook")
+}
+
+func TestPreCreateHookAllowsZeroExit(t *testing.T) {
+	t.Setenv(HookEnvVar, "")
+	s := newHookTestService(HooksConfig{PreCreate: `test "$NB_NOTE_TYPE" = inbox`})
+	assert.NoError(t, s.runPreCreateHook(&createOptions{}, hookNote{noteType: "inbox"}))
+	assert.Error(t, s.runPreCreateHook(&createOptions{}, hookNote{noteType: "learn"}))
+}
+
+func TestPostCreateHookGetsNoteEnv(t *testing.T) {
+	t.Setenv(HookEnvVar, "")
+	out := filepath.Join(t.TempDir(), "hook.out")
+	s := newHookTestService(HooksConfig{
+		PostCreate: `printf '%s|%s|%s|%s|%s' "$NB_HOOK" "$NB_NOTE_PATH" "$NB_NOTE_WORKSPACE" "$NB_NOTE_ID" "$NB_NOTE_TAGS" > "` + out + `.tmp" && mv "` + out + `.tmp" "` + out + `"`,
+	})
+	s.startPostCreateHook(&createOptions{}, hookNote{
+		path:      "/nb/inbox/note.md",
+		workspace: "proj",
+		id:        "20240101-note",
+		tags:      []string{"a", "b"},
+	})
+
+	var got []byte
+	require.Eventually(t, func() bool {
+		var err error
+		got, err = os.ReadFile(out)
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Equal(t, "post_create|/nb/inbox/note.md|proj|20240101-note|a,b", string(got))
+}
+
+func TestPreCreateHookGetsGeneratedID(t *testing.T) {
+	t.Setenv(HookEnvVar, "")
+	t.Setenv("GROVE_HOME", t.TempDir())
+	captureNoteEvents(t)
+	out := filepath.Join(t.TempDir(), "hook.out")
+	s, err := New(&Config{Hooks: HooksConfig{PreCreate: `printf '%s' "$NB_NOTE_ID" > "` + out + `"`}}, nil, nil, nil)
+	require.NoError(t, err)
+	ws := &coreworkspace.WorkspaceNode{Name: "proj", Path: t.TempDir()}
+	ctx := &WorkspaceContext{NotebookContextWorkspace: ws, CurrentWorkspace: ws}
+
+	note, err := s.CreateNote(ctx, "inbox", "Hooked", WithoutEditor())
+	require.NoError(t, err)
+	got, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.NotEmpty(t, string(got))
+	assert.Equal(t, note.ID, string(got), "pre_create sees the id the note is written with")
+}
+
+func TestHooksDoNotRunInsideAHook(t *testing.T) {
+	t.Setenv(HookEnvVar, "post_create")
+	s := newHookTestService(HooksConfig{PreCreate: "exit 1"})
+	assert.NoError(t, s.runPreCreateHook(&createOptions{}, hookNote{}))
+}
+
+// The pre_create hook runs before the notebook lock is taken, in
+// CreateNoteWithContent as in CreateNote, so a hook running nb isn't blocked.
+func TestPreCreateHookRunsOutsideNotebookLock(t *testing.T) {
+	if _, err := exec.LookPath("flock"); err != nil {
+		t.Skip("flock not installed")
+	}
+	t.Setenv(HookEnvVar, "")
+	t.Setenv("GROVE_HOME", t.TempDir())
+	captureNoteEvents(t)
+	hook := `for f in "$GROVE_HOME"/nb/*.lock; do [ -e "$f" ] || continue; flock -n "$f" true || exit 1; done`
+	s, err := New(&Config{Hooks: HooksConfig{PreCreate: hook}}, nil, nil, nil)
+	require.NoError(t, err)
+	ws := &coreworkspace.WorkspaceNode{Name: "proj", Path: t.TempDir()}
+	ctx := &WorkspaceContext{NotebookContextWorkspace: ws, CurrentWorkspace: ws}
+
+	// Each entry point runs twice: the second run finds the lock file the
+	// first one left behind.
+	for _, title := range []string{"Plain one", "Plain two"} {
+		_, err := s.CreateNote(ctx, "inbox", title, WithoutEditor())
+		require.NoError(t, err, "CreateNote %q", title)
+	}
+	for _, title := range []string{"Synced one", "Synced two"} {
+		fm := &frontmatter.Frontmatter{ID: title, Title: title}
+		_, err := s.CreateNoteWithContent(ctx, "inbox", title, fm, "body\n")
+		require.NoError(t, err, "CreateNoteWithContent %q", title)
+	}
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	coremodels "github.com/grovetools/core/pkg/models"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+// IDConflict is a frontmatter id shared by more than one note.
+type IDConflict struct {
+	ID    string   `json:"id"`
+	Paths []string `json:"paths"` // Oldest note first
+}
+
+// IDFix records one note whose id was regenerated by FixIDConflicts.
+type IDFix struct {
+	Path        string   `json:"path"`
+	OldID       string   `json:"old_id"`
+	NewID       string   `json:"new_id"`
+	UpdatedRefs []string `json:"updated_refs,omitempty"` // Files whose note_ref now points at NewID
+	// UncertainRefs are files whose note_ref names OldID but that are not
+	// the own job of any note sharing it. They are left pointing at OldID,
+	// the note that kept it, and may have meant this note instead.
+	UncertainRefs []string `json:"uncertain_refs,omitempty"`
+}
+
+// idFields is the part of a note's frontmatter FixIDConflicts works with.
+// note_ref and the created string are not on frontmatter.Frontmatter, so the
+// raw YAML is read directly.
+type idFields struct {
+	ID      string `yaml:"id"`
+	Created string `yaml:"created"`
+	PlanRef string `yaml:"plan_ref"`
+	PlanJob string `yaml:"plan_job"`
+	NoteRef string `yaml:"note_ref"`
+}
+
+type idEntry struct {
+	path   string
+	fields idFields
+}
+
+// VerifyFrontmatterIDs reads the `id` of every note in ctx and returns the ids
+// used by more than one note, sorted by id. Notes without an id and archived
+// notes are ignored. It only reads.
+func (s *Service) VerifyFrontmatterIDs(ctx *WorkspaceContext) ([]IDConflict, error) {
+	entries, err := s.readIDEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return groupIDConflicts(entries), nil
+}
+
+// FixIDConflicts gives every note but the oldest in each conflict a new id:
+// the old one with a timestamp suffix. Only the `id` line is rewritten. A job
+// whose note_ref names the old id is repointed when it is the renamed note's
+// own job (its plan_ref/plan_job). References that are no conflicting note's
+// own job can't be attributed; they are left on the note that kept the id
+// and listed in each fix's UncertainRefs.
+func (s *Service) FixIDConflicts(ctx *WorkspaceContext, conflicts []IDConflict) ([]IDFix, error) {
+	unlock, err := s.LockNotebookFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	entries, err := s.readIDEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byPath := make(map[string]idEntry, len(entries))
+	used := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		byPath[e.path] = e
+		used[e.fields.ID] = true
+	}
+
+	suffix := time.Now().Format("20060102150405")
+	var fixes []IDFix
+	for _, conflict := range conflicts {
+		uncertain := uncertainNoteRefs(entries, byPath, conflict)
+		for _, path := range conflict.Paths[1:] {
+			newID := conflict.ID + "-" + suffix
+			for n := 2; used[newID]; n++ {
+				newID = fmt.Sprintf("%s-%s-%d", conflict.ID, suffix, n)
+			}
+			if err := s.setFrontmatterField(path, "id", newID); err != nil {
+				return fixes, fmt.Errorf("set id of %s: %w", path, err)
+			}
+			used[newID] = true
+			fix := IDFix{Path: path, OldID: conflict.ID, NewID: newID, UncertainRefs: uncertain}
+
+			for _, ref := range entries {
+				if ref.fields.NoteRef != conflict.ID || !isOwnJob(byPath[path].fields, ref.path) {
+					continue
+				}
+				if err := s.setFrontmatterField(ref.path, "note_ref", newID); err != nil {
+					return fixes, fmt.Errorf("update note_ref in %s: %w", ref.path, err)
+				}
+				fix.UpdatedRefs = append(fix.UpdatedRefs, ref.path)
+			}
+			s.opLog("fix-id", path, ctx.NotebookContextWorkspace.Name).Infof("Regenerated duplicate id %s as %s", conflict.ID, newID)
+			fixes = append(fixes, fix)
+		}
+	}
+	return fixes, nil
+}
+
+// readIDEntries reads the id fields of every note in ctx, skipping files
+// whose frontmatter is missing or unreadable.
+func (s *Service) readIDEntries(ctx *WorkspaceContext) ([]idEntry, error) {
+	files, err := s.noteFileStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list notes for id check: %w", err)
+	}
+	entries := make([]idEntry, 0, len(files))
+	for _, f := range files {
+		content, err := os.ReadFile(f.path)
+		if err != nil {
+			continue
+		}
+		raw, _, err := extractFrontmatterString(content)
+		if err != nil || raw == "" {
+			continue
+		}
+		var fields idFields
+		if err := yaml.Unmarshal([]byte(raw), &fields); err != nil {
+			continue
+		}
+		entries = append(entries, idEntry{path: f.path, fields: fields})
+	}
+	return entries, nil
+}
+
+// groupIDConflicts groups entries by id and returns the shared ones. Paths are
+// ordered by created time, then path, so the first is the note that keeps the
+// id.
+func groupIDConflicts(entries []idEntry) []IDConflict {
+	byID := make(map[string][]idEntry)
+	for _, e := range entries {
+		if e.fields.ID != "" {
+			byID[e.fields.ID] = append(byID[e.fields.ID], e)
+		}
+	}
+
+	var conflicts []IDConflict
+	for id, group := range byID {
+		if len(group) < 2 {
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			ci, erri := frontmatter.ParseTimestamp(group[i].fields.Created)
+			cj, errj := frontmatter.ParseTimestamp(group[j].fields.Created)
+			if erri == nil && errj == nil && !ci.Equal(cj) {
+				return ci.Before(cj)
+			}
+			return group[i].path < group[j].path
+		})
+		paths := make([]string, len(group))
+		for i, e := range group {
+			paths[i] = e.path
+		}
+		conflicts = append(conflicts, IDConflict{ID: id, Paths: paths})
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].ID < conflicts[j].ID })
+	return conflicts
+}
+
+// uncertainNoteRefs returns the files whose note_ref names conflict.ID but
+// that are not the own job of any of the notes sharing it.
+func uncertainNoteRefs(entries []idEntry, byPath map[string]idEntry, conflict IDConflict) []string {
+	var refs []string
+	for _, ref := range entries {
+		if ref.fields.NoteRef != conflict.ID {
+			continue
+		}
+		owned := false
+		for _, path := range conflict.Paths {
+			if isOwnJob(byPath[path].fields, ref.path) {
+				owned = true
+				break
+			}
+		}
+		if !owned {
+			refs = append(refs, ref.path)
+		}
+	}
+	return refs
+}
+
+// isOwnJob reports whether jobPath is the plan job a note was promoted to,
+// according to the note's plan_ref and plan_job.
+func isOwnJob(note idFields, jobPath string) bool {
+	if note.PlanJob == "" || filepath.Base(jobPath) != note.PlanJob {
+		return false
+	}
+	return note.PlanRef == "" || filepath.Base(filepath.Dir(jobPath)) == filepath.Base(note.PlanRef)
+}
+
+// setFrontmatterField rewrites a single top-level frontmatter field, keeping
+// the rest of the file byte-for-byte where possible.
+func (s *Service) setFrontmatterField(path, key, value string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat note: %w", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read note: %w", err)
+	}
+	raw, _, err := extractFrontmatterString(content)
+	if err != nil {
+		return fmt.Errorf("read existing frontmatter: %w", err)
+	}
+
+	var newContent []byte
+	if updated, ok := replaceFrontmatterLine(raw, key, value); ok {
+		newContent = replaceFrontmatter(content, updated)
+	} else if newContent, err = updateFrontmatterFields(content, map[string]interface{}{key: value}); err != nil {
+		return fmt.Errorf("update %s frontmatter: %w", key, err)
+	}
+	if err := os.WriteFile(path, newContent, info.Mode()); err != nil {
+		return fmt.Errorf("write note: %w", err)
+	}
+
+	ws, _, noteType := GetNoteMetadata(path)
+	EmitNoteEvent(coremodels.NoteEvent{
+		Event:     coremodels.NoteEventUpdated,
+		Workspace: ws,
+		NoteType:  noteType,
+		Path:      path,
+	})
+	return nil
+}
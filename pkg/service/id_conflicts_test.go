@@ -0,0 +1,47 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupIDConflictsOldestFirst(t *testing.T) {
+	entries := []idEntry{
+		{path: "/nb/inbox/b.md", fields: idFields{ID: "dup", Created: "2024-01-02T09:00:00Z"}},
+		{path: "/nb/inbox/a.md", fields: idFields{ID: "unique"}},
+		{path: "/nb/inbox/c.md", fields: idFields{ID: "dup", Created: "2024-01-01T09:00:00Z"}},
+		{path: "/nb/inbox/d.md"},
+		{path: "/nb/inbox/e.md"},
+	}
+
+	conflicts := groupIDConflicts(entries)
+	assert.Equal(t, []IDConflict{
+		{ID: "dup", Paths: []string{"/nb/inbox/c.md", "/nb/inbox/b.md"}},
+	}, conflicts, "notes without an id never conflict")
+}
+
+func TestIsOwnJob(t *testing.T) {
+	note := idFields{PlanRef: "plans/feature", PlanJob: "01-api.md"}
+	assert.True(t, isOwnJob(note, "/nb/plans/feature/01-api.md"))
+	assert.False(t, isOwnJob(note, "/nb/plans/other/01-api.md"), "same filename in another plan")
+	assert.False(t, isOwnJob(idFields{}, "/nb/plans/feature/01-api.md"), "note was never promoted")
+}
+
+func TestUncertainNoteRefs(t *testing.T) {
+	entries := []idEntry{
+		{path: "/nb/inbox/a.md", fields: idFields{ID: "dup", PlanRef: "plans/feature", PlanJob: "01-api.md"}},
+		{path: "/nb/inbox/b.md", fields: idFields{ID: "dup"}},
+		{path: "/nb/plans/feature/01-api.md", fields: idFields{NoteRef: "dup"}},
+		{path: "/nb/plans/other/02-ui.md", fields: idFields{NoteRef: "dup"}},
+		{path: "/nb/plans/other/03-db.md", fields: idFields{NoteRef: "unique"}},
+	}
+	byPath := make(map[string]idEntry, len(entries))
+	for _, e := range entries {
+		byPath[e.path] = e
+	}
+
+	conflict := IDConflict{ID: "dup", Paths: []string{"/nb/inbox/a.md", "/nb/inbox/b.md"}}
+	assert.Equal(t, []string{"/nb/plans/other/02-ui.md"}, uncertainNoteRefs(entries, byPath, conflict),
+		"a's own job is accounted for; the other reference can't be attributed")
+}
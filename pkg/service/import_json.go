@@ -0,0 +1,290 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// JSONImportMapping names the fields of each imported JSON object that feed a
+// note. Fields are dot-separated paths into nested objects ("fields.summary");
+// a numeric segment indexes into an array ("labels.0"). An empty field is
+// not imported.
+type JSONImportMapping struct {
+	TitleField  string `json:"title"`
+	BodyField   string `json:"body"`
+	TagsField   string `json:"tags"`
+	StatusField string `json:"status"`
+	DateField   string `json:"date"`
+}
+
+// DefaultJSONImportMapping maps the top-level keys title, body, tags, status
+// and date.
+func DefaultJSONImportMapping() JSONImportMapping {
+	return JSONImportMapping{
+		TitleField:  "title",
+		BodyField:   "body",
+		TagsField:   "tags",
+		StatusField: "status",
+		DateField:   "date",
+	}
+}
+
+// ParseJSONImportMapping reads a mapping given as a JSON object such as
+// {"title": "fields.summary", "body": "fields.description"}. Keys that are
+// not set keep their DefaultJSONImportMapping value.
+func ParseJSONImportMapping(s string) (JSONImportMapping, error) {
+	mapping := DefaultJSONImportMapping()
+	if strings.TrimSpace(s) == "" {
+		return mapping, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&mapping); err != nil {
+		return mapping, fmt.Errorf("parse import mapping: %w", err)
+	}
+	if mapping.TitleField == "" {
+		return mapping, fmt.Errorf("parse import mapping: title field cannot be empty")
+	}
+	return mapping, nil
+}
+
+type importOptions struct {
+	noteType models.NoteType
+	progress func(done, total int)
+}
+
+type ImportOption func(*importOptions)
+
+// WithImportNoteType sets the group imported notes are created in. The
+// default is the inbox.
+func WithImportNoteType(noteType models.NoteType) ImportOption {
+	return func(o *importOptions) {
+		o.noteType = noteType
+	}
+}
+
+// WithImportProgress registers a callback invoked after each item is
+// imported, with the number of items done so far and the total.
+func WithImportProgress(fn func(done, total int)) ImportOption {
+	return func(o *importOptions) {
+		o.progress = fn
+	}
+}
+
+// ImportFromJSON creates one note per object in the JSON array at jsonPath,
+// extracting the title, body, tags, status and date through mapping. It
+// returns the paths of the created notes; on error, the notes created before
+// the failing item are kept and their paths returned alongside the error.
+func (s *Service) ImportFromJSON(ctx *WorkspaceContext, jsonPath string, mapping JSONImportMapping, options ...ImportOption) ([]string, error) {
+	opts := &importOptions{noteType: InboxGroup}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		return nil, fmt.Errorf("read import file: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var items []any
+	if err := dec.Decode(&items); err != nil {
+		return nil, fmt.Errorf("parse import file: expected a JSON array of objects: %w", err)
+	}
+
+	noteDir, err := s.getNotePathForContext(ctx, string(opts.noteType))
+	if err != nil {
+		return nil, fmt.Errorf("get note path: %w", err)
+	}
+
+	log := s.opLog("import_json", "", ctx.NotebookContextWorkspace.Name)
+	log.WithField("file", jsonPath).WithField("items", len(items)).Info("Importing notes from JSON")
+
+	var created []string
+	for i, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return created, fmt.Errorf("import item %d: expected an object, got %T", i, item)
+		}
+
+		fm, body, err := jsonItemToNote(obj, mapping)
+		if err != nil {
+			return created, fmt.Errorf("import item %d: %w", i, err)
+		}
+		if fm.Title == "" {
+			fm.Title = fmt.Sprintf("Imported item %d", i+1)
+		}
+		fm.ID = GenerateNoteID(fm.Title)
+
+		note, err := s.CreateNoteWithContent(ctx, opts.noteType, uniqueFilenameTitle(noteDir, fm.Title), fm, body)
+		if err != nil {
+			return created, fmt.Errorf("import item %d: %w", i, err)
+		}
+		created = append(created, note.Path)
+
+		if opts.progress != nil {
+			opts.progress(i+1, len(items))
+		}
+	}
+
+	log.WithField("created", len(created)).Info("Imported notes from JSON")
+	return created, nil
+}
+
+// jsonItemToNote builds the frontmatter and body of a note from one imported
+// object.
+func jsonItemToNote(obj map[string]any, mapping JSONImportMapping) (*frontmatter.Frontmatter, string, error) {
+	now := time.Now()
+	fm := &frontmatter.Frontmatter{
+		Title:   jsonFieldString(obj, mapping.TitleField),
+		Status:  jsonFieldString(obj, mapping.StatusField),
+		Tags:    jsonFieldStrings(obj, mapping.TagsField),
+		Aliases: []string{},
+	}
+
+	created := now
+	if raw := jsonFieldString(obj, mapping.DateField); raw != "" {
+		t, err := parseImportDate(raw)
+		if err != nil {
+			return nil, "", fmt.Errorf("field %q: %w", mapping.DateField, err)
+		}
+		created = t
+	}
+	fm.Created = frontmatter.FormatTimestamp(created)
+	fm.Modified = fm.Created
+
+	body := fmt.Sprintf("# %s\n", fm.Title)
+	if text := jsonFieldString(obj, mapping.BodyField); text != "" {
+		body += "\n" + strings.TrimRight(text, "\n") + "\n"
+	}
+	return fm, body, nil
+}
+
+// lookupJSONField follows a dot-separated path through nested objects and
+// arrays. It reports false when any segment is missing.
+func lookupJSONField(obj map[string]any, path string) (any, bool) {
+	if path == "" {
+		return nil, false
+	}
+	var cur any = obj
+	for _, key := range strings.Split(path, ".") {
+		switch v := cur.(type) {
+		case map[string]any:
+			next, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			cur = next
+		case []any:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil, false
+			}
+			cur = v[idx]
+		default:
+			return nil, false
+		}
+	}
+	return cur, cur != nil
+}
+
+// jsonFieldString returns the field at path as a string. Numbers and bools
+// are formatted; objects and arrays yield "".
+func jsonFieldString(obj map[string]any, path string) string {
+	v, ok := lookupJSONField(obj, path)
+	if !ok {
+		return ""
+	}
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+// jsonFieldStrings returns the field at path as a list of strings. It accepts
+// an array of scalars, an array of objects with a "name" key (the shape most
+// trackers use for labels), or a comma-separated string.
+func jsonFieldStrings(obj map[string]any, path string) []string {
+	v, ok := lookupJSONField(obj, path)
+	if !ok {
+		return []string{}
+	}
+	var out []string
+	add := func(s string) {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	switch v := v.(type) {
+	case string:
+		for _, part := range strings.Split(v, ",") {
+			add(part)
+		}
+	case []any:
+		for _, elem := range v {
+			switch e := elem.(type) {
+			case string:
+				add(e)
+			case json.Number:
+				add(e.String())
+			case map[string]any:
+				if name, ok := e["name"].(string); ok {
+					add(name)
+				}
+			}
+		}
+	}
+	if out == nil {
+		return []string{}
+	}
+	return out
+}
+
+// importDateLayouts are tried in order after frontmatter.ParseTimestamp.
+var importDateLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+func parseImportDate(raw string) (time.Time, error) {
+	if t, err := frontmatter.ParseTimestamp(raw); err == nil {
+		return t, nil
+	}
+	for _, layout := range importDateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t, nil
+		}
+	}
+	if secs, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Unix(secs, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q", raw)
+}
+
+// uniqueFilenameTitle returns title, or title with a numeric suffix, such
+// that GenerateFilename yields a file that doesn't exist yet in dir. Imports
+// often contain repeated titles, which would otherwise overwrite each other.
+func uniqueFilenameTitle(dir, title string) string {
+	candidate := title
+	for n := 2; ; n++ {
+		if _, err := os.Stat(filepath.Join(dir, GenerateFilename(candidate))); os.IsNotExist(err) {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s %d", title, n)
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeImportObject(t *testing.T, s string) map[string]any {
+	t.Helper()
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var obj map[string]any
+	require.NoError(t, dec.Decode(&obj))
+	return obj
+}
+
+func TestParseJSONImportMapping(t *testing.T) {
+	mapping, err := ParseJSONImportMapping("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultJSONImportMapping(), mapping)
+
+	mapping, err = ParseJSONImportMapping(`{"title": "fields.summary", "tags": ""}`)
+	require.NoError(t, err)
+	assert.Equal(t, "fields.summary", mapping.TitleField)
+	assert.Equal(t, "", mapping.TagsField, "explicitly cleared")
+	assert.Equal(t, "body", mapping.BodyField, "unset keys keep defaults")
+
+	_, err = ParseJSONImportMapping(`{"summary": "x"}`)
+	assert.Error(t, err, "unknown keys are rejected")
+	_, err = ParseJSONImportMapping(`{"title": ""}`)
+	assert.Error(t, err)
+}
+
+func TestJSONItemToNoteNested(t *testing.T) {
+	obj := decodeImportObject(t, `{
+		"key": 42,
+		"fields": {
+			"summary": "Login fails",
+			"description": "Steps:\n1. open\n",
+			"status": {"name": "In Progress"},
+			"labels": [{"name": "bug"}, {"name": "auth"}],
+			"created": "2024-03-05"
+		}
+	}`)
+	mapping := JSONImportMapping{
+		TitleField:  "fields.summary",
+		BodyField:   "fields.description",
+		TagsField:   "fields.labels",
+		StatusField: "fields.status.name",
+		DateField:   "fields.created",
+	}
+
+	fm, body, err := jsonItemToNote(obj, mapping)
+	require.NoError(t, err)
+	assert.Equal(t, "Login fails", fm.Title)
+	assert.Equal(t, "In Progress", fm.Status)
+	assert.Equal(t, []string{"bug", "auth"}, fm.Tags)
+	assert.True(t, strings.HasPrefix(fm.Created, "2024-03-05"), fm.Created)
+	assert.Equal(t, "# Login fails\n\nSteps:\n1. open\n", body)
+
+	assert.Equal(t, "42", jsonFieldString(obj, "key"))
+	assert.Equal(t, "auth", jsonFieldString(obj, "fields.labels.1.name"))
+	assert.Equal(t, "", jsonFieldString(obj, "fields.missing"))
+
+	_, _, err = jsonItemToNote(decodeImportObject(t, `{"title": "x", "date": "soon"}`), DefaultJSONImportMapping())
+	assert.Error(t, err, "unparseable dates are reported")
+}
+
+func TestJSONFieldStringsCommaSeparated(t *testing.T) {
+	obj := decodeImportObject(t, `{"tags": "a, b,,c"}`)
+	assert.Equal(t, []string{"a", "b", "c"}, jsonFieldStrings(obj, "tags"))
+	assert.Equal(t, []string{}, jsonFieldStrings(obj, "nope"))
+}
+
+func TestUniqueFilenameTitle(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, "Bug", uniqueFilenameTitle(dir, "Bug"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, GenerateFilename("Bug")), nil, 0o644))
+	assert.Equal(t, "Bug 2", uniqueFilenameTitle(dir, "Bug"))
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// InboxGroup is the group new notes land in by default.
+const InboxGroup = "inbox"
+
+// IsInboxNote reports whether a note with the given group and path counts as
+// an unfiled inbox note: it sits directly in the inbox group (not a subgroup
+// like inbox/.archive) and is not inside an .archive directory. The CLI and
+// the TUI badge both count with it.
+func IsInboxNote(group, path string) bool {
+	if group != InboxGroup {
+		return false
+	}
+	sep := string(filepath.Separator)
+	return !strings.Contains(path, sep+".archive"+sep)
+}
+
+// GetInboxNotes returns the non-archived inbox notes in the given workspace
+// context, or across every workspace when allWorkspaces is set, newest first.
+func (s *Service) GetInboxNotes(ctx *WorkspaceContext, allWorkspaces bool) ([]*models.Note, error) {
+	var (
+		notes []*models.Note
+		err   error
+	)
+	if allWorkspaces {
+		notes, err = s.ListNotesFromAllWorkspaces(false, false)
+	} else {
+		notes, err = s.ListAllNotes(ctx, false, false)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list notes for inbox: %w", err)
+	}
+	return SelectInboxNotes(notes), nil
+}
+
+// CountInbox returns the number of notes GetInboxNotes would return.
+func (s *Service) CountInbox(ctx *WorkspaceContext, allWorkspaces bool) (int, error) {
+	notes, err := s.GetInboxNotes(ctx, allWorkspaces)
+	if err != nil {
+		return 0, err
+	}
+	return len(notes), nil
+}
+
+// SelectInboxNotes keeps the notes that pass IsInboxNote and are not marked
+// archived, newest first. The input slice is not modified.
+func SelectInboxNotes(notes []*models.Note) []*models.Note {
+	var inbox []*models.Note
+	for _, note := range notes {
+		if note == nil || note.IsArchived || !IsInboxNote(note.Group, note.Path) {
+			continue
+		}
+		inbox = append(inbox, note)
+	}
+	sort.SliceStable(inbox, func(i, j int) bool {
+		return inbox[i].ModifiedAt.After(inbox[j].ModifiedAt)
+	})
+	return inbox
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestSelectInboxNotes(t *testing.T) {
+	now := time.Now()
+	notes := []*models.Note{
+		{Path: "/nb/ws/inbox/old.md", Group: "inbox", ModifiedAt: now.Add(-time.Hour)},
+		{Path: "/nb/ws/inbox/new.md", Group: "inbox", ModifiedAt: now},
+		{Path: "/nb/ws/inbox/.archive/done.md", Group: "inbox", ModifiedAt: now},
+		{Path: "/nb/ws/inbox/flagged.md", Group: "inbox", IsArchived: true},
+		{Path: "/nb/ws/inbox/sub/nested.md", Group: "inbox/sub"},
+		{Path: "/nb/ws/issues/bug.md", Group: "issues"},
+		nil,
+	}
+
+	var paths []string
+	for _, n := range SelectInboxNotes(notes) {
+		paths = append(paths, n.Path)
+	}
+	assert.Equal(t, []string{"/nb/ws/inbox/new.md", "/nb/ws/inbox/old.md"}, paths)
+}
@@ -21,14 +21,19 @@ func (s *Service) newItemFromFile(path string, info os.FileInfo) (*tree.Item, er
 	}
 
 	// Determine item type and populate metadata
-	if strings.HasSuffix(info.Name(), ".md") {
+	var fmCreated string
+	if strings.HasSuffix(info.Name(), ".md") && ExceedsMaxParseSize(info.Size()) {
+		// Too large to read: list it under its filename.
+		item.Type = tree.TypeNote
+		item.Metadata["Title"] = strings.TrimSuffix(info.Name(), ".md")
+	} else if strings.HasSuffix(info.Name(), ".md") {
 		// It's a markdown note, parse for frontmatter
 		content, err := os.ReadFile(path)
 		if err != nil {
 			return nil, err
 		}
 		contentStr := string(content)
-		fm, _, err := frontmatter.Parse(contentStr)
+		fm, err := frontmatter.ParsePartial(contentStr)
 
 		item.Type = tree.TypeNote
 		if err == nil && fm != nil {
@@ -38,14 +43,15 @@ func (s *Service) newItemFromFile(path string, info os.FileInfo) (*tree.Item, er
 			item.Metadata["ID"] = fm.ID
 			item.Metadata["PlanRef"] = fm.PlanRef
 			item.Metadata["PlanJob"] = fm.PlanJob
+			item.Metadata["LinkRef"] = fm.LinkRef
+			item.Metadata["LinkType"] = fm.LinkType
 			item.Metadata["Priority"] = fm.Priority
+			item.Metadata["Flag"] = fm.Flag
 			if fm.Remote != nil {
 				item.Metadata["RemoteState"] = fm.Remote.State
 			}
 			// Use frontmatter timestamps if available
-			if created, err := frontmatter.ParseTimestamp(fm.Created); err == nil {
-				item.Metadata["Created"] = created
-			}
+			fmCreated = fm.Created
 			if modified, err := frontmatter.ParseTimestamp(fm.Modified); err == nil {
 				item.ModTime = modified
 			}
@@ -69,6 +75,7 @@ func (s *Service) newItemFromFile(path string, info os.FileInfo) (*tree.Item, er
 
 	// Common metadata for all files
 	item.Metadata["Path"] = path // Store full path in metadata for easy access in TUI
+	item.Metadata["Created"] = NoteCreatedAt(path, fmCreated, info)
 
 	return item, nil
 }
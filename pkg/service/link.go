@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	coremodels "github.com/grovetools/core/pkg/models"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+// Link types accepted by LinkNotes.
+const (
+	LinkTypeReference = "reference"
+	LinkTypeParent    = "parent"
+	LinkTypeFollowup  = "followup"
+)
+
+// LinkTypes lists the accepted link types.
+var LinkTypes = []string{LinkTypeReference, LinkTypeParent, LinkTypeFollowup}
+
+// linkTypeLabels prefix the body reference WithBodyLink inserts.
+var linkTypeLabels = map[string]string{
+	LinkTypeReference: "See also",
+	LinkTypeParent:    "Parent",
+	LinkTypeFollowup:  "Follow-up",
+}
+
+type linkOptions struct {
+	bodyLink bool
+}
+
+// LinkOption configures LinkNotes.
+type LinkOption func(*linkOptions)
+
+// WithBodyLink makes LinkNotes also append a Markdown reference to the target,
+// such as "[See also: Title](../ideas/target.md)", to the source's body,
+// unless the body already holds it.
+func WithBodyLink() LinkOption {
+	return func(o *linkOptions) {
+		o.bodyLink = true
+	}
+}
+
+// LinkNotes links the note at sourcePath to the one at targetPath: the
+// source's link_ref is set to the target and its link_type to linkType
+// (reference, parent or followup; empty means reference), and the source is
+// added to the target's backlinks. Both paths are stored relative to the
+// directory of the note holding them, so links survive moving the notebook.
+// Linking again replaces the source's previous link_ref but keeps the old
+// target's backlink. Other frontmatter and its formatting are preserved.
+func (s *Service) LinkNotes(sourcePath, targetPath, linkType string, options ...LinkOption) error {
+	opts := &linkOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+	if linkType == "" {
+		linkType = LinkTypeReference
+	}
+	label, ok := linkTypeLabels[linkType]
+	if !ok {
+		return fmt.Errorf("invalid link type %q (expected one of %s)", linkType, strings.Join(LinkTypes, ", "))
+	}
+
+	source, err := filepath.Abs(sourcePath)
+	if err != nil {
+		return fmt.Errorf("resolve source path: %w", err)
+	}
+	target, err := filepath.Abs(targetPath)
+	if err != nil {
+		return fmt.Errorf("resolve target path: %w", err)
+	}
+	if source == target {
+		return fmt.Errorf("cannot link a note to itself")
+	}
+	targetNote, err := ParseNote(target)
+	if err != nil {
+		return fmt.Errorf("read target note: %w", err)
+	}
+
+	unlock, err := s.LockNotebook(source)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	ref := relativeNoteLink(source, target)
+	err = rewriteNote(source, func(content []byte) ([]byte, error) {
+		updated, err := updateFrontmatterFields(content, map[string]interface{}{
+			"link_ref":  ref,
+			"link_type": linkType,
+		})
+		if err != nil || !opts.bodyLink {
+			return updated, err
+		}
+		title := targetNote.FrontmatterTitle
+		if title == "" {
+			title = targetNote.Title
+		}
+		line := fmt.Sprintf("[%s: %s](%s)", label, title, ref)
+		if bytes.Contains(updated, []byte(line)) {
+			return updated, nil
+		}
+		if len(updated) > 0 && !bytes.HasSuffix(updated, []byte("\n")) {
+			updated = append(updated, '\n')
+		}
+		return append(updated, []byte("\n"+line+"\n")...), nil
+	})
+	if err != nil {
+		return fmt.Errorf("update source note: %w", err)
+	}
+
+	backlink := relativeNoteLink(target, source)
+	err = rewriteNote(target, func(content []byte) ([]byte, error) {
+		fm, _ := frontmatter.ParsePartial(string(content))
+		var backlinks []string
+		if fm != nil {
+			backlinks = fm.Backlinks
+		}
+		for _, b := range backlinks {
+			if b == backlink {
+				return content, nil
+			}
+		}
+		return updateFrontmatterFields(content, map[string]interface{}{"backlinks": flowSequence(append(backlinks, backlink))})
+	})
+	if err != nil {
+		return fmt.Errorf("update target note: %w", err)
+	}
+
+	ws, _, _ := GetNoteMetadata(source)
+	s.opLog("link", source, ws).WithField("target", target).WithField("link_type", linkType).Info("Linked notes")
+	return nil
+}
+
+// rewriteNote applies edit to the contents of the note at path and writes the
+// result back, keeping the file's mode, when it changed.
+func rewriteNote(path string, edit func([]byte) ([]byte, error)) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	updated, err := edit(content)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(updated, content) {
+		return nil
+	}
+	if err := os.WriteFile(path, updated, info.Mode()); err != nil {
+		return err
+	}
+
+	ws, _, noteType := GetNoteMetadata(path)
+	EmitNoteEvent(coremodels.NoteEvent{
+		Event:     coremodels.NoteEventUpdated,
+		Workspace: ws,
+		NoteType:  noteType,
+		Path:      path,
+	})
+	return nil
+}
+
+// relativeNoteLink is the slash-separated path of to relative to the
+// directory of from, as a Markdown link in from would spell it.
+func relativeNoteLink(from, to string) string {
+	rel, err := filepath.Rel(filepath.Dir(from), to)
+	if err != nil {
+		return filepath.ToSlash(to)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// ResolveLinkRef returns the absolute path a link_ref or backlinks entry
+// written in the note at notePath points to.
+func ResolveLinkRef(notePath, ref string) string {
+	ref = filepath.FromSlash(ref)
+	if filepath.IsAbs(ref) {
+		return filepath.Clean(ref)
+	}
+	return filepath.Join(filepath.Dir(notePath), ref)
+}
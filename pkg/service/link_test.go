@@ -0,0 +1,63 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+func TestLinkNotes(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	dir := t.TempDir()
+	write := func(rel, content string) string {
+		path := filepath.Join(dir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	source := write("inbox/idea.md", "---\nid: idea\ntitle: Idea\ncustom: kept\n---\nBody.\n")
+	target := write("issues/bug.md", "---\nid: bug\ntitle: The Bug\n---\n")
+	other := write("inbox/other.md", "---\nid: other\ntitle: Other\n---\n")
+
+	s := newTestService()
+	require.NoError(t, s.LinkNotes(source, target, LinkTypeFollowup, WithBodyLink()))
+
+	raw, err := os.ReadFile(source)
+	require.NoError(t, err)
+	fm, body, err := frontmatter.Parse(string(raw))
+	require.NoError(t, err)
+	assert.Equal(t, "../issues/bug.md", fm.LinkRef)
+	assert.Equal(t, LinkTypeFollowup, fm.LinkType)
+	assert.Contains(t, string(raw), "custom: kept", "other frontmatter is preserved")
+	assert.NotContains(t, string(raw), "note_ref", "a plan job's note_ref is left to the plan tooling")
+	assert.Contains(t, body, "Body.\n\n[Follow-up: The Bug](../issues/bug.md)\n")
+	assert.Equal(t, target, ResolveLinkRef(source, fm.LinkRef))
+
+	note, err := ParseNote(target)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"../inbox/idea.md"}, note.Backlinks)
+
+	// Linking again adds neither a second backlink nor a second body line.
+	require.NoError(t, s.LinkNotes(source, target, LinkTypeFollowup, WithBodyLink()))
+	require.NoError(t, s.LinkNotes(other, target, ""))
+	note, err = ParseNote(target)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"../inbox/idea.md", "../inbox/other.md"}, note.Backlinks)
+	raw, err = os.ReadFile(source)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(raw), "[Follow-up: The Bug]"))
+
+	note, err = ParseNote(other)
+	require.NoError(t, err)
+	assert.Equal(t, LinkTypeReference, note.LinkType, "empty type means reference")
+
+	assert.Error(t, s.LinkNotes(source, target, "sibling"))
+	assert.Error(t, s.LinkNotes(source, source, ""))
+	assert.Error(t, s.LinkNotes(source, filepath.Join(dir, "missing.md"), ""))
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// Structured log field names shared by service operations, so that
+// `--log-format json` output can be filtered the same way for every command.
+const (
+	LogFieldOperation = "operation"
+	LogFieldNotePath  = "note_path"
+	LogFieldWorkspace = "workspace"
+)
+
+// opLog returns s.Logger tagged with the operation name and, when non-empty,
+// the note path and workspace it acts on. Levels follow one convention:
+// Info for notes created, deleted, moved or archived; Debug for path
+// resolution and search internals; Warn when an operation degrades or falls
+// back; Error for failures.
+func (s *Service) opLog(operation, notePath, workspace string) *logrus.Entry {
+	fields := logrus.Fields{LogFieldOperation: operation}
+	if notePath != "" {
+		fields[LogFieldNotePath] = notePath
+	}
+	if workspace != "" {
+		fields[LogFieldWorkspace] = workspace
+	}
+	return s.Logger.WithFields(fields)
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/grovetools/core/pkg/paths"
+)
+
+// NBHomeDir returns ~/.grove/nb, where nb keeps its per-user files: the
+// trash, notebook locks, sync and reminder state, and the webhook PID.
+// GROVE_HOME replaces ~/.grove when it is set, and the Grove state
+// directory is used if the home directory can't be determined.
+func NBHomeDir() string {
+	if root := os.Getenv("GROVE_HOME"); root != "" {
+		return filepath.Join(root, "nb")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return filepath.Join(paths.StateDir(), "nb")
+	}
+	return filepath.Join(home, ".grove", "nb")
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNBHomeDir(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("GROVE_HOME", root)
+	assert.Equal(t, filepath.Join(root, "nb"), NBHomeDir())
+
+	home := t.TempDir()
+	t.Setenv("GROVE_HOME", "")
+	t.Setenv("HOME", home)
+	assert.Equal(t, filepath.Join(home, ".grove", "nb"), NBHomeDir())
+}
@@ -20,8 +20,13 @@ const (
 
 // GenerateFilename creates a timestamped filename
 func GenerateFilename(suffix string) string {
+	return generateFilenameAt(time.Now(), suffix)
+}
+
+// generateFilenameAt is GenerateFilename for a note dated now.
+func generateFilenameAt(now time.Time, suffix string) string {
 	// Use YYYYMMDD format for cleaner filenames
-	date := time.Now().Format("20060102")
+	date := now.Format("20060102")
 	if suffix != "" {
 		return fmt.Sprintf("%s-%s.md", date, SanitizeFilename(suffix))
 	}
@@ -30,7 +35,12 @@ func GenerateFilename(suffix string) string {
 
 // GenerateNoteID creates an ID from filename (without .md extension)
 func GenerateNoteID(suffix string) string {
-	timestamp := time.Now().Format("20060102-150405")
+	return generateNoteIDAt(time.Now(), suffix)
+}
+
+// generateNoteIDAt is GenerateNoteID for a note dated now.
+func generateNoteIDAt(now time.Time, suffix string) string {
+	timestamp := now.Format("20060102-150405")
 	if suffix != "" {
 		return fmt.Sprintf("%s-%s", timestamp, SanitizeFilename(suffix))
 	}
@@ -54,12 +64,16 @@ func SanitizeFilename(s string) string {
 	return s
 }
 
-// ParseNote reads and parses a note file
+// ParseNote reads and parses a note file. A file over MaxParseSize is not
+// read; see parseOversizedNote.
 func ParseNote(path string) (*models.Note, error) {
 	info, err := os.Stat(path)
 	if err != nil {
 		return nil, err
 	}
+	if ExceedsMaxParseSize(info.Size()) {
+		return parseOversizedNote(path, info), nil
+	}
 
 	content, err := os.ReadFile(path)
 	if err != nil {
@@ -69,15 +83,47 @@ func ParseNote(path string) (*models.Note, error) {
 	contentStr := string(content)
 
 	// Parse frontmatter
-	fm, _, err := frontmatter.Parse(contentStr)
+	fm, err := frontmatter.ParsePartial(contentStr)
 	if err != nil {
 		// If frontmatter parsing fails, continue with default parsing
 		fm = nil
 	}
+	return buildNote(path, info, contentStr, fm), nil
+}
+
+// ReadNote reads the note at path once and returns it parsed, together with
+// its frontmatter and body as frontmatter.Parse splits them, for code that
+// rewrites notes. The frontmatter is nil for a note without one. Unlike
+// ParseNote, the whole file is read whatever its size, and malformed
+// frontmatter is an error rather than ignored.
+func (s *Service) ReadNote(path string) (*models.Note, *frontmatter.Frontmatter, string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	contentStr := string(content)
+	fm, body, err := frontmatter.Parse(contentStr)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return buildNote(path, info, contentStr, fm), fm, body, nil
+}
 
+// buildNote describes the note at path from its file info, content and
+// parsed frontmatter, which may be nil.
+func buildNote(path string, info os.FileInfo, contentStr string, fm *frontmatter.Frontmatter) *models.Note {
 	// Extract metadata from path
 	workspace, branch, noteType := GetNoteMetadata(path)
 
+	var fmCreated string
+	if fm != nil {
+		fmCreated = fm.Created
+	}
+
 	todoOpen, todoDone, todoCancelled := CountTodos(contentStr)
 
 	note := &models.Note{
@@ -86,7 +132,7 @@ func ParseNote(path string) (*models.Note, error) {
 		Type:          models.NoteType(noteType),
 		Workspace:     workspace,
 		Branch:        branch,
-		CreatedAt:     info.ModTime(), // Could use birthtime if available
+		CreatedAt:     NoteCreatedAt(path, fmCreated, info),
 		ModifiedAt:    info.ModTime(),
 		Content:       contentStr,
 		WordCount:     countWords(contentStr),
@@ -129,9 +175,18 @@ func ParseNote(path string) (*models.Note, error) {
 		if fm.PlanJob != "" {
 			note.PlanJob = fm.PlanJob
 		}
+		note.LinkRef = fm.LinkRef
+		note.LinkType = fm.LinkType
+		note.Backlinks = fm.Backlinks
 		if fm.Priority != "" {
 			note.Priority = fm.Priority
 		}
+		note.Flag = fm.Flag
+		if fm.Reminder != nil && fm.Reminder.At != "" {
+			if t, err := frontmatter.ParseTimestamp(fm.Reminder.At); err == nil {
+				note.Reminder = &models.Reminder{At: t, Message: fm.Reminder.Message}
+			}
+		}
 
 		// Parse remote sync fields
 		if fm.Remote != nil {
@@ -154,11 +209,6 @@ func ParseNote(path string) (*models.Note, error) {
 		}
 
 		// Parse timestamps from frontmatter if available
-		if fm.Created != "" {
-			if t, err := frontmatter.ParseTimestamp(fm.Created); err == nil {
-				note.CreatedAt = t
-			}
-		}
 		if fm.Modified != "" {
 			if t, err := frontmatter.ParseTimestamp(fm.Modified); err == nil {
 				note.ModifiedAt = t
@@ -166,10 +216,30 @@ func ParseNote(path string) (*models.Note, error) {
 		}
 	}
 
-	return note, nil
+	return note
+}
+
+// parseOversizedNote describes a note too large to parse from its path and
+// file times alone: it is titled after its filename and has no content,
+// frontmatter or todo counts.
+func parseOversizedNote(path string, info os.FileInfo) *models.Note {
+	workspace, branch, noteType := GetNoteMetadata(path)
+	title := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return &models.Note{
+		Path:             path,
+		Title:            title,
+		FrontmatterTitle: title,
+		Type:             models.NoteType(noteType),
+		Workspace:        workspace,
+		Branch:           branch,
+		CreatedAt:        NoteCreatedAt(path, "", info),
+		ModifiedAt:       info.ModTime(),
+		IsArchived:       strings.Contains(path, "/archive/") || strings.Contains(path, "/.archive/"),
+	}
 }
 
-// ParseGenericFile creates a Note model for a non-Markdown file.
+// ParseGenericFile creates a Note model for a non-Markdown file. It only
+// stats the file, so any size is cheap to list.
 func ParseGenericFile(path string) (*models.Note, error) {
 	info, err := os.Stat(path)
 	if err != nil {
@@ -186,7 +256,7 @@ func ParseGenericFile(path string) (*models.Note, error) {
 		Type:             models.NoteType(ext),
 		Workspace:        workspace,
 		Branch:           branch,
-		CreatedAt:        info.ModTime(),
+		CreatedAt:        NoteCreatedAt(path, "", info),
 		ModifiedAt:       info.ModTime(),
 		IsArchived:       strings.Contains(path, "/archive/") || strings.Contains(path, "/.archive/"),
 	}
@@ -213,7 +283,7 @@ func ParseArtifact(path string) (*models.Note, error) {
 		Type:       "artifact",
 		Workspace:  workspace,
 		Branch:     branch,
-		CreatedAt:  info.ModTime(),
+		CreatedAt:  NoteCreatedAt(path, "", info),
 		ModifiedAt: info.ModTime(),
 		IsArtifact: true,
 	}
@@ -240,17 +310,21 @@ func countWords(content string) int {
 
 // CountTodos counts markdown checkbox items per line: open "- [ ]", done
 // "- [x]"/"- [X]", cancelled "- [-]" (the "* " bullet variants are accepted
-// too). Lines inside ``` code fences are skipped so fenced example checkboxes
-// don't inflate the counts.
+// too). Lines inside ``` or ~~~ code fences are skipped so fenced example
+// checkboxes don't inflate the counts; a fence only closes on the marker that
+// opened it.
 func CountTodos(content string) (open, done, cancelled int) {
-	inFence := false
+	fence := ""
 	for _, line := range strings.Split(content, "\n") {
 		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(trimmed, "```") {
-			inFence = !inFence
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
 			continue
 		}
-		if inFence {
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
 			continue
 		}
 		rest, ok := strings.CutPrefix(trimmed, "- ")
@@ -320,7 +394,7 @@ func generateLLMContent(title, workspace, branch string, tags []string, now time
 
 // generateDailyContent creates content for daily notes
 func generateDailyContent(title, workspace, branch string, tags []string, now time.Time, timestampStr string) string {
-	id := GenerateNoteID(fmt.Sprintf("daily-%s", now.Format("2006-01-02")))
+	id := generateNoteIDAt(now, fmt.Sprintf("daily-%s", now.Format("2006-01-02")))
 	fm := &frontmatter.Frontmatter{
 		ID:       id,
 		Title:    title,
@@ -353,7 +427,7 @@ func generateDailyContent(title, workspace, branch string, tags []string, now ti
 
 // generateLearnContent creates content for learning notes
 func generateLearnContent(title, workspace, branch string, tags []string, now time.Time, timestampStr string) string {
-	id := GenerateNoteID(title)
+	id := generateNoteIDAt(now, title)
 	fm := &frontmatter.Frontmatter{
 		ID:       id,
 		Title:    title,
@@ -387,7 +461,7 @@ func generateLearnContent(title, workspace, branch string, tags []string, now ti
 
 // generateBlogContent creates content for blog posts
 func generateBlogContent(title, workspace, branch string, tags []string, now time.Time, timestampStr string) string {
-	id := GenerateNoteID(title)
+	id := generateNoteIDAt(now, title)
 	// Match the schema for blog posts
 	fm := &frontmatter.Frontmatter{
 		ID:          id,
@@ -422,7 +496,7 @@ Start writing your amazing blog post here.
 
 // generatePromptsContent creates content for reusable LLM prompts
 func generatePromptsContent(title, workspace, branch string, tags []string, now time.Time, timestampStr string) string {
-	id := GenerateNoteID(title)
+	id := generateNoteIDAt(now, title)
 	fm := &frontmatter.Frontmatter{
 		ID:       id,
 		Title:    title,
@@ -461,7 +535,7 @@ func generatePromptsContent(title, workspace, branch string, tags []string, now
 
 // generateDocsContent creates content for documentation notes
 func generateDocsContent(title, workspace, branch string, tags []string, now time.Time, timestampStr string) string {
-	id := GenerateNoteID(title)
+	id := generateNoteIDAt(now, title)
 	fm := &frontmatter.Frontmatter{
 		ID:       id,
 		Title:    title,
@@ -491,7 +565,7 @@ func generateDocsContent(title, workspace, branch string, tags []string, now tim
 
 // generateDefaultContent creates content for all other note types
 func generateDefaultContent(title, workspace, branch, worktree string, tags []string, now time.Time, timestampStr string) string {
-	id := GenerateNoteID(title)
+	id := generateNoteIDAt(now, title)
 	fm := &frontmatter.Frontmatter{
 		ID:       id,
 		Title:    title,
@@ -520,6 +594,12 @@ func generateDefaultContent(title, workspace, branch, worktree string, tags []st
 // If noteTypeConfig is provided with a TemplatePath, it reads from that file.
 // Otherwise, it falls back to generating default content.
 func CreateNoteContent(noteType models.NoteType, title, workspace, branch, worktree, currentWorkspaceName string, template string, noteTypeConfig *coreconfig.NoteTypeConfig) string {
+	return createNoteContentAt(time.Now(), noteType, title, workspace, branch, worktree, currentWorkspaceName, template, noteTypeConfig)
+}
+
+// createNoteContentAt is CreateNoteContent for a note dated now: now fills
+// the template timestamps, the frontmatter created/modified fields and the id.
+func createNoteContentAt(now time.Time, noteType models.NoteType, title, workspace, branch, worktree, currentWorkspaceName string, template string, noteTypeConfig *coreconfig.NoteTypeConfig) string {
 	// Extract path components for tags
 	pathTags := frontmatter.ExtractPathTags(string(noteType))
 
@@ -532,8 +612,8 @@ func CreateNoteContent(noteType models.NoteType, title, workspace, branch, workt
 			// Simple template variable replacement
 			replacements := map[string]string{
 				"{{.Title}}":     title,
-				"{{.Timestamp}}": frontmatter.FormatTimestamp(time.Now()),
-				"{{.Date}}":      time.Now().Format("2006-01-02"),
+				"{{.Timestamp}}": frontmatter.FormatTimestamp(now),
+				"{{.Date}}":      now.Format("2006-01-02"),
 				"{{.Workspace}}": workspace,
 				"{{.Branch}}":    branch,
 			}
@@ -551,8 +631,8 @@ func CreateNoteContent(noteType models.NoteType, title, workspace, branch, workt
 		// Simple template variable replacement
 		replacements := map[string]string{
 			"{{.Title}}":     title,
-			"{{.Timestamp}}": frontmatter.FormatTimestamp(time.Now()),
-			"{{.Date}}":      time.Now().Format("2006-01-02"),
+			"{{.Timestamp}}": frontmatter.FormatTimestamp(now),
+			"{{.Date}}":      now.Format("2006-01-02"),
 			"{{.Workspace}}": workspace,
 			"{{.Branch}}":    branch,
 		}
@@ -565,7 +645,6 @@ func CreateNoteContent(noteType models.NoteType, title, workspace, branch, workt
 	}
 
 	// Default templates
-	now := time.Now()
 	timestampStr := frontmatter.FormatTimestamp(now)
 
 	// Dispatch to specialized content generators based on note type
@@ -688,18 +767,18 @@ func GetNoteMetadata(path string) (workspaceIdentifier, branch, noteType string)
 // RenameNote renames a note by updating its filename, title, and first heading.
 // The frontmatter ID is preserved so the note's identity is stable across retitles.
 func (s *Service) RenameNote(oldPath, newTitle string) (string, error) {
-	// Read the note content
-	content, err := os.ReadFile(oldPath)
+	unlock, err := s.LockNotebook(oldPath)
 	if err != nil {
-		return "", fmt.Errorf("read note: %w", err)
+		return "", err
 	}
+	defer unlock()
 
-	contentStr := string(content)
-
-	// Parse frontmatter
-	fm, body, err := frontmatter.Parse(contentStr)
-	if err != nil || fm == nil {
-		return "", fmt.Errorf("parse frontmatter: %w", err)
+	_, fm, body, err := s.ReadNote(oldPath)
+	if err != nil {
+		return "", fmt.Errorf("read note: %w", err)
+	}
+	if fm == nil {
+		return "", fmt.Errorf("%s has no frontmatter", oldPath)
 	}
 
 	// Update frontmatter fields. The ID is intentionally preserved: it is the
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// NoteInfo is everything nb derives about a single note file: the fields
+// ParseNote extracts plus file-level facts and link checks. It backs
+// `nb note info`.
+type NoteInfo struct {
+	Note *models.Note `json:"note"`
+
+	// Group is the note's directory relative to the workspace notes root,
+	// taken from its path (Note.Type may be overridden by frontmatter).
+	Group string `json:"group"`
+	// Status and NoteRef are read from the raw frontmatter; ParseNote does
+	// not carry them onto models.Note.
+	Status  string `json:"status,omitempty"`
+	NoteRef string `json:"note_ref,omitempty"`
+
+	HasFrontmatter   bool   `json:"has_frontmatter"`
+	FrontmatterError string `json:"frontmatter_error,omitempty"`
+
+	Size        int64  `json:"size"`
+	Permissions string `json:"permissions"`
+
+	// PlanRefResolved reports whether plan_ref points at an existing plan
+	// directory, which PlanDir then holds.
+	PlanRefResolved bool   `json:"plan_ref_resolved"`
+	PlanDir         string `json:"plan_dir,omitempty"`
+
+	// Indexed and Backlinks are filled in by the caller from the vault
+	// index, which the service does not build.
+	Indexed   bool `json:"indexed"`
+	Backlinks int  `json:"backlinks"`
+}
+
+var rawFrontmatterPattern = regexp.MustCompile(`(?s)\A\x{feff}?---\r?\n(.*?)\r?\n---`)
+
+// GetNoteInfo parses the note at path and gathers the metadata shown by
+// `nb note info`. Unlike ParseNote it surfaces frontmatter parse errors
+// instead of silently falling back.
+func (s *Service) GetNoteInfo(path string) (*NoteInfo, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve note path: %w", err)
+	}
+	stat, err := os.Stat(abs)
+	if err != nil {
+		return nil, fmt.Errorf("stat note: %w", err)
+	}
+	if stat.IsDir() {
+		return nil, fmt.Errorf("%s is a directory", abs)
+	}
+
+	note, err := ParseNote(abs)
+	if err != nil {
+		return nil, fmt.Errorf("parse note: %w", err)
+	}
+	_, _, group := GetNoteMetadata(abs)
+
+	info := &NoteInfo{
+		Note:        note,
+		Group:       group,
+		Size:        stat.Size(),
+		Permissions: stat.Mode().Perm().String(),
+	}
+
+	if m := rawFrontmatterPattern.FindStringSubmatch(note.Content); m != nil {
+		info.HasFrontmatter = true
+		if _, err := frontmatter.ParsePartial(note.Content); err != nil {
+			info.FrontmatterError = err.Error()
+		}
+		var raw struct {
+			Status  string `yaml:"status"`
+			NoteRef string `yaml:"note_ref"`
+		}
+		if err := yaml.Unmarshal([]byte(m[1]), &raw); err == nil {
+			info.Status = raw.Status
+			info.NoteRef = raw.NoteRef
+		}
+	}
+
+	if note.PlanRef != "" {
+		info.PlanDir = s.existingLivePlanForNote(abs)
+		info.PlanRefResolved = info.PlanDir != ""
+	}
+
+	return info, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNoteInfo(t *testing.T) {
+	root := t.TempDir()
+	contentRoot := filepath.Join(root, "workspaces", "proj")
+	notePath := filepath.Join(contentRoot, "inbox", "20240101-idea.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(notePath), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(contentRoot, "plans", "feature"), 0o755))
+	require.NoError(t, os.WriteFile(notePath, []byte(`---
+id: idea-1
+title: Idea
+tags: [a, b]
+status: doing
+note_ref: inbox/other.md
+plan_ref: plans/feature
+created: 2024-01-01T10:00:00Z
+modified: 2024-01-02T10:00:00Z
+---
+# Idea
+
+- [ ] first
+`), 0o600))
+
+	s := &Service{}
+	info, err := s.GetNoteInfo(notePath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "idea-1", info.Note.ID)
+	assert.Equal(t, "Idea", info.Note.FrontmatterTitle)
+	assert.Equal(t, []string{"a", "b"}, info.Note.Tags)
+	assert.Equal(t, "proj", info.Note.Workspace)
+	assert.Equal(t, "inbox", info.Group)
+	assert.Equal(t, "doing", info.Status)
+	assert.Equal(t, "inbox/other.md", info.NoteRef)
+	assert.True(t, info.HasFrontmatter)
+	assert.Empty(t, info.FrontmatterError)
+	assert.Equal(t, "-rw-------", info.Permissions)
+	assert.True(t, info.PlanRefResolved)
+	assert.Equal(t, filepath.Join(contentRoot, "plans", "feature"), info.PlanDir)
+	assert.Equal(t, 1, info.Note.TodoOpen)
+}
+
+func TestGetNoteInfoReportsFrontmatterErrors(t *testing.T) {
+	notePath := filepath.Join(t.TempDir(), "broken.md")
+	require.NoError(t, os.WriteFile(notePath, []byte("---\ntitle: [unclosed\n---\nbody\n"), 0o644))
+
+	info, err := (&Service{}).GetNoteInfo(notePath)
+	require.NoError(t, err)
+	assert.True(t, info.HasFrontmatter)
+	assert.NotEmpty(t, info.FrontmatterError)
+	assert.False(t, info.PlanRefResolved)
+
+	_, err = (&Service{}).GetNoteInfo(filepath.Dir(notePath))
+	assert.Error(t, err, "directories are rejected")
+}
@@ -97,3 +97,35 @@ Just a simple note without frontmatter.
 	assert.Empty(t, note.Tags)
 	assert.False(t, note.HasTodos)
 }
+
+func TestReadNote(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "read.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\nid: read\ntitle: Read Me\ntags: [a]\n---\n\n# Read Me\n\n- [ ] task\n"), 0o644))
+
+	s := newTestService()
+	note, fm, body, err := s.ReadNote(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Read Me", fm.Title)
+	assert.Equal(t, "\n# Read Me\n\n- [ ] task\n", body)
+	assert.Equal(t, "read", note.ID)
+	assert.Equal(t, []string{"a"}, note.Tags)
+	assert.Equal(t, 1, note.TodoOpen)
+
+	parsed, err := ParseNote(path)
+	require.NoError(t, err)
+	assert.Equal(t, parsed, note, "ReadNote describes the note as ParseNote does")
+
+	plain := filepath.Join(dir, "plain.md")
+	require.NoError(t, os.WriteFile(plain, []byte("# Plain\n"), 0o644))
+	note, fm, body, err = s.ReadNote(plain)
+	require.NoError(t, err)
+	assert.Nil(t, fm)
+	assert.Equal(t, "# Plain\n", body)
+	assert.Equal(t, plain, note.Path)
+
+	broken := filepath.Join(dir, "broken.md")
+	require.NoError(t, os.WriteFile(broken, []byte("---\ntitle: [unclosed\n---\n"), 0o644))
+	_, _, _, err = s.ReadNote(broken)
+	assert.Error(t, err, "malformed frontmatter is an error")
+}
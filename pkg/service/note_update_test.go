@@ -0,0 +1,61 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+// An update that produces the bytes already on disk must not rewrite the
+// note: its mtime (which the frontmatter's modified would otherwise be
+// applied to) stays put.
+func TestUpdateNoteWithContentUnchangedIsNoOp(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "note.md")
+	fm := &frontmatter.Frontmatter{
+		ID:       "20240101-a",
+		Title:    "A",
+		Created:  "2024-01-01T00:00:00Z",
+		Modified: "2024-01-02T00:00:00Z",
+	}
+	body := "# A\n\nBody.\n"
+	require.NoError(t, os.WriteFile(path, []byte(frontmatter.BuildContent(fm, body)), 0o600))
+	old := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	s := newTestService()
+	require.NoError(t, s.UpdateNoteWithContent(path, fm, body))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(old), "unchanged update moved mtime to %v", info.ModTime())
+
+	require.NoError(t, s.UpdateNoteWithContent(path, fm, body+"More.\n"))
+	info, err = os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), info.ModTime().UTC())
+	assert.Contains(t, readFile(t, path), "More.")
+}
+
+func TestUpdateNoteFrontmatterUnchangedIsNoOp(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "note.md")
+	fm := &frontmatter.Frontmatter{
+		ID:    "20240101-a",
+		Title: "A",
+		Type:  "inbox",
+		Tags:  frontmatter.MergeTags(frontmatter.ExtractPathTags("inbox"), nil),
+	}
+	require.NoError(t, os.WriteFile(path, []byte(frontmatter.BuildContent(fm, "# A\n")), 0o600))
+	old := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	require.NoError(t, newTestService().updateNoteFrontmatter(path, nil, "inbox", false))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(old), "unchanged update moved mtime to %v", info.ModTime())
+}
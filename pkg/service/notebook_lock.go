@@ -0,0 +1,272 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grovetools/core/util/pathutil"
+)
+
+// notebookLockTimeout is how long LockNotebook waits for another process to
+// release the notebook before giving up.
+const notebookLockTimeout = 30 * time.Second
+
+// notebookLockPoll is how often LockNotebook retries while it waits.
+const notebookLockPoll = 100 * time.Millisecond
+
+// ErrNotebookLocked is returned by LockNotebook when another nb process kept
+// the notebook locked for longer than notebookLockTimeout.
+var ErrNotebookLocked = errors.New("notebook is locked by another nb process")
+
+// errLockBusy is returned by the platform lock functions when a non-blocking
+// lock is held elsewhere.
+var errLockBusy = errors.New("lock is busy")
+
+// notebookLocks is the process's side of the notebook locks. Each notebook
+// has a mutex that orders the process's own goroutines; its holder then
+// takes the file lock that orders processes. Holds don't nest: code that
+// already holds a notebook's lock must not ask for it again. The zero value
+// is ready to use.
+type notebookLocks struct {
+	mu     sync.Mutex
+	byName map[string]*sync.Mutex
+	held   map[string]bool
+}
+
+// mutex returns the in-process mutex of the named notebook.
+func (l *notebookLocks) mutex(name string) *sync.Mutex {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.byName == nil {
+		l.byName = make(map[string]*sync.Mutex)
+	}
+	if l.byName[name] == nil {
+		l.byName[name] = &sync.Mutex{}
+	}
+	return l.byName[name]
+}
+
+// setHeld records whether this process holds the named notebook's file lock.
+func (l *notebookLocks) setHeld(name string, held bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.held == nil {
+		l.held = make(map[string]bool)
+	}
+	l.held[name] = held
+}
+
+func (l *notebookLocks) isHeld(name string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.held[name]
+}
+
+// NotebookLockPath returns the lock file of the named notebook,
+// ~/.grove/nb/<notebook>.lock.
+func NotebookLockPath(notebook string) string {
+	return filepath.Join(NBHomeDir(), filepath.Base(notebook)+".lock")
+}
+
+// LockNotebook takes the lock of every notebook holding one of paths (the
+// default notebook when none is given), keeping other goroutines and nb
+// processes from mutating their notes at the same time. It waits up to
+// notebookLockTimeout for another process to finish. The file lock is an
+// OS-level advisory lock (flock), so it is released when the process exits,
+// even by a crash. Reads don't take it. The returned unlock must be called
+// once the mutation is done.
+func (s *Service) LockNotebook(paths ...string) (unlock func(), err error) {
+	var unlocks []func()
+	release := func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+	// Notebooks are always locked in name order, so two callers locking the
+	// same pair can't deadlock.
+	for _, name := range s.notebooksFor(paths) {
+		u, err := s.lockNotebookNamed(name)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		unlocks = append(unlocks, u)
+	}
+	var once sync.Once
+	return func() { once.Do(release) }, nil
+}
+
+// LockNotebookFor takes the lock of the notebook ctx's notes live in; see
+// LockNotebook.
+func (s *Service) LockNotebookFor(ctx *WorkspaceContext) (unlock func(), err error) {
+	if ctx == nil || ctx.NotebookContextWorkspace == nil || s.notebookLocator == nil {
+		return s.LockNotebook()
+	}
+	dir, err := s.notebookLocator.GetNotesDir(ctx.NotebookContextWorkspace, "")
+	if err != nil {
+		return s.LockNotebook()
+	}
+	return s.LockNotebook(dir)
+}
+
+// lockNotebookNamed takes the lock of one notebook.
+func (s *Service) lockNotebookNamed(name string) (func(), error) {
+	mu := s.notebookLocks.mutex(name)
+	mu.Lock()
+
+	path := NotebookLockPath(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		mu.Unlock()
+		return nil, fmt.Errorf("create lock directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		mu.Unlock()
+		return nil, fmt.Errorf("open notebook lock: %w", err)
+	}
+	if err := s.waitForLock(f); err != nil {
+		f.Close()
+		mu.Unlock()
+		return nil, err
+	}
+	// The PID is only informational: the lock is the flock, not the file.
+	if err := f.Truncate(0); err == nil {
+		fmt.Fprintf(f, "%d\n", os.Getpid())
+	}
+	s.notebookLocks.setHeld(name, true)
+
+	return func() {
+		s.notebookLocks.setHeld(name, false)
+		if err := unlockFile(f); err != nil {
+			s.Logger.WithError(err).Warn("Failed to release notebook lock")
+		}
+		f.Close()
+		mu.Unlock()
+	}, nil
+}
+
+// waitForLock takes an exclusive lock on f, retrying until
+// notebookLockTimeout.
+func (s *Service) waitForLock(f *os.File) error {
+	deadline := time.Now().Add(notebookLockTimeout)
+	logged := false
+	for {
+		err := lockFile(f, true)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, errLockBusy) {
+			return fmt.Errorf("lock notebook: %w", err)
+		}
+		if time.Now().After(deadline) {
+			return ErrNotebookLocked
+		}
+		if !logged {
+			s.Logger.WithField("path", f.Name()).Info("Waiting for another nb process to release the notebook lock")
+			logged = true
+		}
+		time.Sleep(notebookLockPoll)
+	}
+}
+
+// NotebookLocked reports whether another process holds the lock of the
+// default notebook or of any configured one, i.e. is creating, moving,
+// archiving, deleting or syncing notes. It only probes with a shared lock,
+// which it releases at once. It reads the lock files, so the TUI calls it
+// from a command rather than from Update.
+func (s *Service) NotebookLocked() bool {
+	for _, name := range s.notebookNames() {
+		if s.notebookLocks.isHeld(name) {
+			continue // The holder is this process
+		}
+		if lockedElsewhere(NotebookLockPath(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// lockedElsewhere reports whether the lock file at path is held.
+func lockedElsewhere(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false // Never locked
+	}
+	defer f.Close()
+	if err := lockFile(f, false); err != nil {
+		return errors.Is(err, errLockBusy)
+	}
+	_ = unlockFile(f)
+	return false
+}
+
+// notebooksFor returns the names of the notebooks holding paths, sorted and
+// without duplicates. No paths means the default notebook.
+func (s *Service) notebooksFor(paths []string) []string {
+	if len(paths) == 0 {
+		return []string{s.defaultNotebookName()}
+	}
+	seen := make(map[string]bool)
+	var names []string
+	for _, path := range paths {
+		name := s.notebookForPath(path)
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// notebookForPath returns the name of the notebook whose root_dir holds
+// path. Notes kept in workspaces (local mode) belong to the default
+// notebook.
+func (s *Service) notebookForPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return s.defaultNotebookName()
+	}
+	best, bestLen := s.defaultNotebookName(), -1
+	if s.CoreConfig == nil || s.CoreConfig.Notebooks == nil {
+		return best
+	}
+	for name, nb := range s.CoreConfig.Notebooks.Definitions {
+		if nb == nil || nb.RootDir == "" {
+			continue
+		}
+		root, err := pathutil.Expand(nb.RootDir)
+		if err != nil {
+			continue
+		}
+		root = filepath.Clean(root)
+		rel, err := filepath.Rel(root, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if len(root) > bestLen {
+			best, bestLen = name, len(root)
+		}
+	}
+	return best
+}
+
+// notebookNames returns the default notebook and every configured one.
+func (s *Service) notebookNames() []string {
+	names := []string{s.defaultNotebookName()}
+	if s.CoreConfig != nil && s.CoreConfig.Notebooks != nil {
+		for name := range s.CoreConfig.Notebooks.Definitions {
+			if name != names[0] {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package service
+
+import "os"
+
+// lockFile is a no-op where flock is unavailable: the notebook lock then
+// only orders mutations within one process.
+func lockFile(f *os.File, exclusive bool) error {
+	return nil
+}
+
+// unlockFile is a no-op where flock is unavailable.
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,101 @@
+//go:build unix
+
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	coreconfig "github.com/grovetools/core/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// otherProcessLock opens the default notebook's lock file on its own
+// descriptor, which flock treats like another process.
+func otherProcessLock(t *testing.T, s *Service) *os.File {
+	t.Helper()
+	path := NotebookLockPath(s.defaultNotebookName())
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestLockNotebookExcludesGoroutines(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	s := newTestService()
+
+	unlock, err := s.LockNotebook()
+	require.NoError(t, err)
+	other := otherProcessLock(t, s)
+	assert.ErrorIs(t, lockFile(other, true), errLockBusy)
+	assert.False(t, s.NotebookLocked(), "this process's own lock is not reported")
+
+	acquired := make(chan struct{})
+	go func() {
+		unlockSecond, err := s.LockNotebook()
+		if err == nil {
+			close(acquired)
+			unlockSecond()
+		}
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("a second goroutine took the lock while it was held")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	unlock()
+	unlock() // unlock is idempotent
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the waiting goroutine never got the lock")
+	}
+}
+
+func TestLockNotebookPerNotebook(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("GROVE_HOME", home)
+	root := t.TempDir()
+	s := newTestService()
+	s.CoreConfig = &coreconfig.Config{Notebooks: &coreconfig.NotebooksConfig{
+		Definitions: map[string]*coreconfig.Notebook{"work": {RootDir: root}},
+	}}
+
+	note := filepath.Join(root, "workspaces", "proj", "inbox", "idea.md")
+	assert.Equal(t, "work", s.notebookForPath(note))
+	assert.Equal(t, "default", s.notebookForPath(filepath.Join(t.TempDir(), "idea.md")), "local-mode notes belong to the default notebook")
+
+	unlock, err := s.LockNotebook(note)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(home, "nb", "work.lock"))
+	assert.NoFileExists(t, filepath.Join(home, "nb", "default.lock"))
+
+	// The default notebook is free meanwhile.
+	unlockDefault, err := s.LockNotebook()
+	require.NoError(t, err)
+	unlockDefault()
+	unlock()
+}
+
+func TestNotebookLockedSeesOtherHolder(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	s := newTestService()
+	assert.False(t, s.NotebookLocked(), "no lock file yet")
+
+	unlock, err := s.LockNotebook()
+	require.NoError(t, err)
+	unlock()
+	assert.False(t, s.NotebookLocked(), "released lock")
+
+	other := otherProcessLock(t, s)
+	require.NoError(t, lockFile(other, true))
+	assert.True(t, s.NotebookLocked())
+	require.NoError(t, unlockFile(other))
+	assert.False(t, s.NotebookLocked())
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// notebookSizeLargest is how many of the largest files GetNotebookSize keeps.
+const notebookSizeLargest = 5
+
+// NotebookSize is the disk usage of one workspace notebook.
+type NotebookSize struct {
+	FileCount     int         `json:"file_count"`
+	MarkdownFiles int         `json:"markdown_files"`
+	TotalBytes    int64       `json:"total_bytes"`
+	MarkdownBytes int64       `json:"markdown_bytes"`
+	OtherBytes    int64       `json:"other_bytes"` // Attachments, artifacts and other non-markdown files
+	Largest       []FileSize  `json:"largest"`     // Largest files, biggest first
+	Groups        []GroupSize `json:"groups"`      // Per group directory, biggest first
+}
+
+// FileSize is the size of one file in the notebook.
+type FileSize struct {
+	Path  string `json:"path"`
+	Bytes int64  `json:"bytes"`
+}
+
+// GroupSize is the storage used by one group directory, e.g. "inbox" or
+// "plans/feature".
+type GroupSize struct {
+	Group string `json:"group"`
+	Files int    `json:"files"`
+	Bytes int64  `json:"bytes"`
+}
+
+// GetNotebookSize adds up the size of every file in ctx's notebook, archives
+// and attachments included, from the walk's file info alone; no file is
+// read. Groups are named as in the TUI: the directory under notes/, or
+// plans/<plan> and chats/<chat>.
+func (s *Service) GetNotebookSize(ctx *WorkspaceContext) (*NotebookSize, error) {
+	contentDirs, err := s.notebookLocator.GetAllContentDirs(ctx.NotebookContextWorkspace)
+	if err != nil {
+		return nil, fmt.Errorf("get content directories: %w", err)
+	}
+
+	size := &NotebookSize{}
+	groups := make(map[string]*GroupSize)
+	seen := make(map[string]struct{})
+	for _, contentDir := range contentDirs {
+		if _, err := os.Stat(contentDir.Path); err != nil {
+			continue
+		}
+		_ = s.walkNotebook(contentDir.Path, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil // Skip errors
+			}
+			if info.IsDir() {
+				switch info.Name() {
+				case ".git", ".grove-worktrees", ".grove":
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			if _, ok := seen[path]; ok {
+				return nil
+			}
+			seen[path] = struct{}{}
+
+			bytes := info.Size()
+			size.FileCount++
+			size.TotalBytes += bytes
+			if strings.HasSuffix(info.Name(), ".md") {
+				size.MarkdownFiles++
+				size.MarkdownBytes += bytes
+			} else {
+				size.OtherBytes += bytes
+			}
+			size.Largest = append(size.Largest, FileSize{Path: path, Bytes: bytes})
+
+			rel, _ := filepath.Rel(contentDir.Path, path)
+			name := sizeGroup(contentDir.Type, rel)
+			g, ok := groups[name]
+			if !ok {
+				g = &GroupSize{Group: name}
+				groups[name] = g
+			}
+			g.Files++
+			g.Bytes += bytes
+			return nil
+		})
+	}
+
+	sort.Slice(size.Largest, func(i, j int) bool {
+		if size.Largest[i].Bytes != size.Largest[j].Bytes {
+			return size.Largest[i].Bytes > size.Largest[j].Bytes
+		}
+		return size.Largest[i].Path < size.Largest[j].Path
+	})
+	if len(size.Largest) > notebookSizeLargest {
+		size.Largest = size.Largest[:notebookSizeLargest]
+	}
+
+	size.Groups = make([]GroupSize, 0, len(groups))
+	for _, g := range groups {
+		size.Groups = append(size.Groups, *g)
+	}
+	sort.Slice(size.Groups, func(i, j int) bool {
+		if size.Groups[i].Bytes != size.Groups[j].Bytes {
+			return size.Groups[i].Bytes > size.Groups[j].Bytes
+		}
+		return size.Groups[i].Group < size.Groups[j].Group
+	})
+	return size, nil
+}
+
+// sizeGroup names the group a file at rel (relative to a content directory
+// of type dirType) is counted under. Under notes/ that is the file's
+// directory, with files at the root counted as "quick"; under plans/ and
+// chats/ it is the plan or chat directory (two levels for archived ones).
+func sizeGroup(dirType, rel string) string {
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	dirs := parts[:len(parts)-1]
+	if dirType == "notes" {
+		if len(dirs) == 0 {
+			return "quick"
+		}
+		return strings.Join(dirs, "/")
+	}
+	if len(dirs) == 0 {
+		return dirType
+	}
+	if strings.HasPrefix(dirs[0], ".") && len(dirs) > 1 {
+		return dirType + "/" + dirs[0] + "/" + dirs[1]
+	}
+	return dirType + "/" + dirs[0]
+}
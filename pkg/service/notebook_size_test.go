@@ -0,0 +1,26 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSizeGroup(t *testing.T) {
+	cases := []struct {
+		dirType, rel, want string
+	}{
+		{"notes", "inbox/20240101-a.md", "inbox"},
+		{"notes", "projects/grove/20240101-a.md", "projects/grove"},
+		{"notes", "inbox/.archive/20240101-a.md", "inbox/.archive"},
+		{"notes", "20240101-quick.md", "quick"},
+		{"plans", "feature/01-spec.md", "plans/feature"},
+		{"plans", "feature/.artifacts/job-1/output.json", "plans/feature"},
+		{"plans", ".archive/old-plan/01-spec.md", "plans/.archive/old-plan"},
+		{"plans", "README.md", "plans"},
+		{"chats", "debug/chat.md", "chats/debug"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, sizeGroup(tc.dirType, tc.rel), tc.rel)
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// DefaultMaxParseSize is the largest file ParseNote and the TUI preview read
+// in full unless max_parse_size says otherwise.
+const DefaultMaxParseSize int64 = 1 << 20
+
+// maxParseSize holds the max_parse_size setting: 0 for DefaultMaxParseSize,
+// negative for no limit. See SetMaxParseSize.
+var maxParseSize atomic.Int64
+
+// SetMaxParseSize sets the size above which note files are listed without
+// being read: their title comes from the filename and the preview shows only
+// their head. 0 restores DefaultMaxParseSize and a negative size removes the
+// limit.
+func SetMaxParseSize(n int64) {
+	maxParseSize.Store(n)
+}
+
+// MaxParseSize returns the current limit, or 0 when there is none.
+func MaxParseSize() int64 {
+	switch n := maxParseSize.Load(); {
+	case n == 0:
+		return DefaultMaxParseSize
+	case n < 0:
+		return 0
+	default:
+		return n
+	}
+}
+
+// ExceedsMaxParseSize reports whether a file of size bytes is too large to
+// parse.
+func ExceedsMaxParseSize(size int64) bool {
+	limit := MaxParseSize()
+	return limit > 0 && size > limit
+}
+
+// FormatBytes renders n in binary units with one decimal, e.g. "1.5 MiB".
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxParseSize(t *testing.T) {
+	t.Cleanup(func() { SetMaxParseSize(0) })
+
+	assert.Equal(t, DefaultMaxParseSize, MaxParseSize())
+	assert.False(t, ExceedsMaxParseSize(DefaultMaxParseSize))
+	assert.True(t, ExceedsMaxParseSize(DefaultMaxParseSize+1))
+
+	SetMaxParseSize(-1)
+	assert.Equal(t, int64(0), MaxParseSize())
+	assert.False(t, ExceedsMaxParseSize(1<<40), "no limit")
+}
+
+func TestParseNoteOversized(t *testing.T) {
+	t.Cleanup(func() { SetMaxParseSize(0) })
+	path := filepath.Join(t.TempDir(), "huge-log.md")
+	content := "---\ntitle: Real Title\ntags: [log]\n---\n" + strings.Repeat("line\n", 100)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	SetMaxParseSize(64)
+	note, err := ParseNote(path)
+	require.NoError(t, err)
+	assert.Equal(t, "huge-log", note.Title, "titled after the filename")
+	assert.Equal(t, "huge-log", note.FrontmatterTitle)
+	assert.Empty(t, note.Tags)
+	assert.Empty(t, note.Content)
+
+	SetMaxParseSize(0)
+	note, err = ParseNote(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Real Title", note.FrontmatterTitle)
+	assert.Equal(t, []string{"log"}, note.Tags)
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "1023 B", FormatBytes(1023))
+	assert.Equal(t, "1.5 KiB", FormatBytes(1536))
+	assert.Equal(t, "1.0 MiB", FormatBytes(DefaultMaxParseSize))
+}
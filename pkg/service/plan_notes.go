@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// GetPlanNotes returns the notes in plans/<planName> and its subdirectories,
+// sorted by path. Only the plan's directory is walked, so it is much cheaper
+// than filtering ListAllNotes. planName may be given with or without the
+// "plans/" prefix. Archived notes are left out; see GetPlanAllNotes.
+func (s *Service) GetPlanNotes(ctx *WorkspaceContext, planName string) ([]*models.Note, error) {
+	return s.GetPlanAllNotes(ctx, planName, false)
+}
+
+// GetPlanAllNotes is GetPlanNotes that, with includeArchived, also returns
+// the notes in the plan's .archive directory and those of an archived plan
+// in plans/.archive/<planName>. It fails when neither directory exists.
+func (s *Service) GetPlanAllNotes(ctx *WorkspaceContext, planName string, includeArchived bool) ([]*models.Note, error) {
+	planName = strings.TrimSuffix(strings.TrimPrefix(planName, "plans/"), "/")
+	if planName == "" || strings.Contains(planName, "..") {
+		return nil, fmt.Errorf("invalid plan name %q", planName)
+	}
+	plansBaseDir, err := s.GetNotebookLocator().GetPlansDir(ctx.NotebookContextWorkspace)
+	if err != nil {
+		return nil, fmt.Errorf("get plans directory: %w", err)
+	}
+
+	dirs := []string{filepath.Join(plansBaseDir, planName)}
+	if includeArchived {
+		dirs = append(dirs, filepath.Join(plansBaseDir, ".archive", planName))
+	}
+	var (
+		notes []*models.Note
+		found bool
+	)
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		found = true
+		dirNotes, err := planDirNotes(dir, includeArchived)
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, dirNotes...)
+	}
+	if !found {
+		return nil, fmt.Errorf("plan not found: %s", planName)
+	}
+	sort.Slice(notes, func(i, j int) bool { return notes[i].Path < notes[j].Path })
+	return notes, nil
+}
+
+// planDirNotes parses the markdown notes under planDir. Hidden directories
+// such as job artifacts are skipped, and so is .archive unless
+// includeArchived is set.
+func planDirNotes(planDir string, includeArchived bool) ([]*models.Note, error) {
+	var notes []*models.Note
+	err := filepath.WalkDir(planDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == planDir || !strings.HasPrefix(d.Name(), ".") {
+				return nil
+			}
+			if d.Name() == ".archive" && includeArchived {
+				return nil
+			}
+			return filepath.SkipDir
+		}
+		if strings.HasPrefix(d.Name(), ".") || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		note, err := ParseNote(path)
+		if err != nil {
+			return nil // Unreadable note: skip it
+		}
+		notes = append(notes, note)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read plan directory: %w", err)
+	}
+	return notes, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+)
+
+func TestPlanDirNotes(t *testing.T) {
+	planDir := filepath.Join(t.TempDir(), "plans", "api")
+	for _, rel := range []string{
+		"02-impl.md",
+		"01-spec.md",
+		"notes/design.md",
+		".archive/00-draft.md",
+		".artifacts/log.md",
+		".grove-plan.yml",
+	} {
+		path := filepath.Join(planDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("---\ntitle: x\n---\n\nbody\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	names := func(includeArchived bool) []string {
+		notes, err := planDirNotes(planDir, includeArchived)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var rels []string
+		for _, note := range notes {
+			rel, _ := filepath.Rel(planDir, note.Path)
+			rels = append(rels, filepath.ToSlash(rel))
+		}
+		return rels
+	}
+
+	if got, want := names(false), []string{"01-spec.md", "02-impl.md", "notes/design.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("planDirNotes() = %v, want %v", got, want)
+	}
+	if got, want := names(true), []string{".archive/00-draft.md", "01-spec.md", "02-impl.md", "notes/design.md"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("planDirNotes(includeArchived) = %v, want %v", got, want)
+	}
+}
+
+func TestGetPlanAllNotes(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	s, err := New(&Config{}, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws := &coreworkspace.WorkspaceNode{Name: "ws", Path: t.TempDir()}
+	ctx := &WorkspaceContext{NotebookContextWorkspace: ws, CurrentWorkspace: ws}
+	plansDir, err := s.GetNotebookLocator().GetPlansDir(ws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rel := range []string{"api/01-spec.md", ".archive/old/01-idea.md"} {
+		path := filepath.Join(plansDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("---\ntitle: x\n---\n\nbody\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tt := range []struct {
+		plan            string
+		includeArchived bool
+		want            string // Note path relative to plansDir; "" for an error
+	}{
+		{plan: "api", want: "api/01-spec.md"},
+		{plan: "plans/api/", want: "api/01-spec.md"},
+		{plan: "old", includeArchived: true, want: ".archive/old/01-idea.md"},
+		{plan: "old"},
+		{plan: "missing", includeArchived: true},
+		{plan: "../api"},
+	} {
+		notes, err := s.GetPlanAllNotes(ctx, tt.plan, tt.includeArchived)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("GetPlanAllNotes(%q, %v) = %d notes, want an error", tt.plan, tt.includeArchived, len(notes))
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("GetPlanAllNotes(%q, %v): %v", tt.plan, tt.includeArchived, err)
+			continue
+		}
+		if len(notes) != 1 || !strings.HasSuffix(filepath.ToSlash(notes[0].Path), "/"+tt.want) {
+			t.Errorf("GetPlanAllNotes(%q, %v) = %v, want only %s", tt.plan, tt.includeArchived, notes, tt.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// PlanStatuses are the values UpdatePlanStatus accepts for a plan's status.
+var PlanStatuses = []string{"active", "hold", "closed", "cancelled"}
+
+// ValidatePlanStatus returns an error unless status is one of PlanStatuses.
+func ValidatePlanStatus(status string) error {
+	for _, s := range PlanStatuses {
+		if status == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid plan status %q (valid: %s)", status, strings.Join(PlanStatuses, ", "))
+}
+
+// ListPlanNames returns the names of the plan directories in the workspace's
+// plans directory, skipping hidden ones like .archive.
+func (s *Service) ListPlanNames(ctx *WorkspaceContext) ([]string, error) {
+	plansBaseDir, err := s.GetNotebookLocator().GetPlansDir(ctx.NotebookContextWorkspace)
+	if err != nil {
+		return nil, fmt.Errorf("get plans directory: %w", err)
+	}
+	entries, err := os.ReadDir(plansBaseDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read plans directory: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// UpdatePlanStatus sets the status field in plans/<planName>/.grove-plan.yml,
+// creating the file when the plan has none. Other fields and comments in the
+// file are preserved. planName may be given with or without the "plans/"
+// prefix.
+func (s *Service) UpdatePlanStatus(ctx *WorkspaceContext, planName, status string) error {
+	if err := ValidatePlanStatus(status); err != nil {
+		return err
+	}
+	planName = strings.TrimPrefix(planName, "plans/")
+	if planName == "" || strings.Contains(planName, "..") {
+		return fmt.Errorf("invalid plan name %q", planName)
+	}
+
+	plansBaseDir, err := s.GetNotebookLocator().GetPlansDir(ctx.NotebookContextWorkspace)
+	if err != nil {
+		return fmt.Errorf("get plans directory: %w", err)
+	}
+	planDir := filepath.Join(plansBaseDir, planName)
+	if info, err := os.Stat(planDir); err != nil || !info.IsDir() {
+		return fmt.Errorf("plan not found: %s", planName)
+	}
+
+	unlock, err := s.LockNotebook(planDir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	configPath := filepath.Join(planDir, models.PlanConfigFilename)
+	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read plan config: %w", err)
+	}
+	updated, err := setPlanConfigStatus(data, status)
+	if err != nil {
+		return fmt.Errorf("update plan config: %w", err)
+	}
+	if err := os.WriteFile(configPath, updated, 0o644); err != nil {
+		return fmt.Errorf("write plan config: %w", err)
+	}
+
+	s.opLog("plan_status", "", ctx.NotebookContextWorkspace.Name).
+		WithFields(logrus.Fields{"plan": planName, "status": status}).
+		Info("Updated plan status")
+	return nil
+}
+
+// setPlanConfigStatus returns the .grove-plan.yml content data with its
+// top-level status set to status.
+func setPlanConfigStatus(data []byte, status string) ([]byte, error) {
+	var doc yaml.Node
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parse yaml: %w", err)
+		}
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("plan config is not a YAML mapping")
+	}
+	updateNodeValue(root, "status", status)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("marshal yaml: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("marshal yaml: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetPlanConfigStatus(t *testing.T) {
+	in := "# plan defaults\nworktree: feature-x\nstatus: active # set by flow\nmodel: opus\n"
+	out, err := setPlanConfigStatus([]byte(in), "hold")
+	require.NoError(t, err)
+	assert.Equal(t, "# plan defaults\nworktree: feature-x\nstatus: hold # set by flow\nmodel: opus\n", string(out))
+
+	out, err = setPlanConfigStatus([]byte("worktree: feature-x\n"), "closed")
+	require.NoError(t, err)
+	assert.Equal(t, "worktree: feature-x\nstatus: closed\n", string(out), "missing status is appended")
+
+	out, err = setPlanConfigStatus(nil, "active")
+	require.NoError(t, err)
+	assert.Equal(t, "status: active\n", string(out), "empty config gets a fresh mapping")
+
+	_, err = setPlanConfigStatus([]byte("- not\n- a mapping\n"), "active")
+	assert.Error(t, err)
+}
+
+func TestValidatePlanStatus(t *testing.T) {
+	for _, s := range PlanStatuses {
+		assert.NoError(t, ValidatePlanStatus(s))
+	}
+	assert.ErrorContains(t, ValidatePlanStatus("done"), "active, hold, closed, cancelled")
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// Plan timeline events.
+const (
+	TimelineCreated  = "created"  // A note in the plan was created
+	TimelineArchived = "archived" // A note was moved into the plan's .archive
+	TimelineLinked   = "linked"   // A note outside the plan with plan_ref pointing at it was created
+)
+
+// TimelineEntry is one event in a plan's history.
+type TimelineEntry struct {
+	Time  time.Time `json:"time"`
+	Event string    `json:"event"`
+	Path  string    `json:"path"`
+	// Name is Path relative to the plan directory, or group/file for a
+	// linked note.
+	Name string `json:"name"`
+}
+
+// String renders the entry as one timeline line, e.g.
+// "2024-01-01 ── created: 01-spec.md".
+func (e TimelineEntry) String() string {
+	return fmt.Sprintf("%s ── %s: %s", e.Time.Local().Format("2006-01-02"), e.Event, e.Name)
+}
+
+// GetPlanTimeline returns the history of a plan, oldest first: the creation
+// of every note in plans/<planName> (by its created frontmatter, falling back
+// to the file time), when each archived note was archived (the modification
+// time of its copy in .archive), and the creation of notes elsewhere in the
+// workspace whose plan_ref points at the plan. planName may be given with or
+// without the "plans/" prefix; an archived plan is found in plans/.archive.
+func (s *Service) GetPlanTimeline(ctx *WorkspaceContext, planName string) ([]TimelineEntry, error) {
+	planName = strings.TrimSuffix(strings.TrimPrefix(planName, "plans/"), "/")
+	if planName == "" || strings.Contains(planName, "..") {
+		return nil, fmt.Errorf("invalid plan name %q", planName)
+	}
+	plansBaseDir, err := s.GetNotebookLocator().GetPlansDir(ctx.NotebookContextWorkspace)
+	if err != nil {
+		return nil, fmt.Errorf("get plans directory: %w", err)
+	}
+	planDir := filepath.Join(plansBaseDir, planName)
+	if info, err := os.Stat(planDir); err != nil || !info.IsDir() {
+		planDir = filepath.Join(plansBaseDir, ".archive", planName)
+		if info, err := os.Stat(planDir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("plan not found: %s", planName)
+		}
+	}
+
+	notes, err := s.ListAllNotes(ctx, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("list notes: %w", err)
+	}
+	return planTimeline(planDir, filepath.Base(planName), notes)
+}
+
+// planTimeline builds the timeline of the plan in planDir. notes are
+// searched for links to planName.
+func planTimeline(planDir, planName string, notes []*models.Note) ([]TimelineEntry, error) {
+	var entries []TimelineEntry
+	err := filepath.WalkDir(planDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(planDir, path)
+		if d.IsDir() {
+			// Job artifacts and other hidden directories aren't plan notes;
+			// .archive is, one level down.
+			if path != planDir && strings.HasPrefix(d.Name(), ".") && rel != ".archive" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		note, err := ParseNote(path)
+		if err != nil {
+			return nil
+		}
+		entries = append(entries, TimelineEntry{Time: note.CreatedAt, Event: TimelineCreated, Path: path, Name: rel})
+		if strings.HasPrefix(rel, ".archive"+string(filepath.Separator)) {
+			if info, err := d.Info(); err == nil {
+				entries = append(entries, TimelineEntry{Time: info.ModTime(), Event: TimelineArchived, Path: path, Name: rel})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read plan directory: %w", err)
+	}
+
+	prefix := planDir + string(filepath.Separator)
+	for _, note := range notes {
+		if note.PlanRef == "" || strings.HasPrefix(note.Path, prefix) {
+			continue
+		}
+		ref := strings.TrimSuffix(note.PlanRef, "/")
+		if strings.HasSuffix(ref, ".md") {
+			// Legacy plan_ref form: <plan>/<job>.md.
+			ref = filepath.Dir(ref)
+		}
+		if filepath.Base(ref) != planName {
+			continue
+		}
+		name := filepath.Join(filepath.Base(filepath.Dir(note.Path)), filepath.Base(note.Path))
+		entries = append(entries, TimelineEntry{Time: note.CreatedAt, Event: TimelineLinked, Path: note.Path, Name: name})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries, nil
+}
+
+// FilterTimeline keeps the entries at or after since and before until. A
+// zero bound is open.
+func FilterTimeline(entries []TimelineEntry, since, until time.Time) []TimelineEntry {
+	var kept []TimelineEntry
+	for _, e := range entries {
+		if !since.IsZero() && e.Time.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !e.Time.Before(until) {
+			continue
+		}
+		kept = append(kept, e)
+	}
+	return kept
+}
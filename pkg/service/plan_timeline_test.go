@@ -0,0 +1,75 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestPlanTimeline(t *testing.T) {
+	root := t.TempDir()
+	planDir := filepath.Join(root, "plans", "api")
+	writeNote := func(path, created string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		content := "---\ntitle: x\ncreated: " + created + "\n---\n\nbody\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeNote(filepath.Join(planDir, "02-impl.md"), "2024-01-05T09:00:00Z")
+	writeNote(filepath.Join(planDir, "01-spec.md"), "2024-01-01T09:00:00Z")
+	archived := filepath.Join(planDir, ".archive", "00-draft.md")
+	writeNote(archived, "2023-12-20T09:00:00Z")
+	archivedAt := time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(archived, archivedAt, archivedAt); err != nil {
+		t.Fatal(err)
+	}
+	writeNote(filepath.Join(planDir, ".artifacts", "log.md"), "2024-01-02T09:00:00Z")
+
+	notes := []*models.Note{
+		{Path: filepath.Join(root, "inbox", "idea.md"), PlanRef: "plans/api", CreatedAt: time.Date(2023, 12, 15, 0, 0, 0, 0, time.UTC)},
+		{Path: filepath.Join(root, "issues", "bug.md"), PlanRef: "api/01-spec.md", CreatedAt: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{Path: filepath.Join(root, "inbox", "other.md"), PlanRef: "plans/other", CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{Path: filepath.Join(planDir, "01-spec.md"), PlanRef: "plans/api", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	entries, err := planTimeline(planDir, "api", notes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"linked: inbox/idea.md",
+		"created: .archive/00-draft.md",
+		"created: 01-spec.md",
+		"archived: .archive/00-draft.md",
+		"created: 02-impl.md",
+		"linked: issues/bug.md",
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries %v, want %d", len(entries), entries, len(want))
+	}
+	for i, e := range entries {
+		if got := e.Event + ": " + e.Name; got != want[i] {
+			t.Errorf("entry %d = %q, want %q", i, got, want[i])
+		}
+	}
+	if !entries[3].Time.Equal(archivedAt) {
+		t.Errorf("archived at %v, want the file time %v", entries[3].Time, archivedAt)
+	}
+
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	filtered := FilterTimeline(entries, since, until)
+	if len(filtered) != 2 || filtered[0].Name != "01-spec.md" || filtered[1].Event != TimelineArchived {
+		t.Errorf("filtered = %v", filtered)
+	}
+	if got := filtered[0].String(); !strings.HasSuffix(got, " ── created: 01-spec.md") {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -67,3 +67,17 @@ modified: 2025-01-11 11:00:00
 	require.NoError(t, err)
 	assert.Equal(t, "", note.Priority)
 }
+
+func TestUpdateNotePriorityWithBOM(t *testing.T) {
+	notePath := filepath.Join(t.TempDir(), "note.md")
+	content := "\ufeff---\r\nid: 20250111-test\r\ntitle: Test Note\r\n---\r\n\r\n# Test Note\r\n"
+	require.NoError(t, os.WriteFile(notePath, []byte(content), 0o644))
+
+	s := &Service{}
+	require.NoError(t, s.UpdateNotePriority(notePath, "p1"))
+
+	raw, err := os.ReadFile(notePath)
+	require.NoError(t, err)
+	assert.Equal(t, "---\nid: 20250111-test\ntitle: Test Note\npriority: p1\n---\n\n# Test Note\n", string(raw),
+		"the existing frontmatter is updated, not preceded by a second block")
+}
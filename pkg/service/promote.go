@@ -111,7 +111,7 @@ func (s *Service) PromoteNoteToJob(notePath string, planDir string, opts Promote
 					return PromoteResult{NotePath: notePath, WorktreeMissing: true, Worktree: worktree},
 						fmt.Errorf("worktree %q not found under any worktree base for ecosystem %s; refusing to promote a repo/branch-less job (--strict)", worktree, ecoRoot)
 				}
-				s.Logger.WithFields(logrus.Fields{
+				s.opLog("promote", notePath, "").WithFields(logrus.Fields{
 					"worktree":  worktree,
 					"ecosystem": ecoRoot,
 				}).Warn("Worktree not found under any worktree base; promoted job will lack repository/branch")
@@ -199,7 +199,7 @@ func (s *Service) PromoteNoteToJob(notePath string, planDir string, opts Promote
 		fm.PlanJob = jobFilename
 		updatedNote := frontmatter.BuildContent(fm, body)
 		if writeErr := os.WriteFile(inProgressPath, []byte(updatedNote), 0o644); writeErr != nil {
-			s.Logger.WithError(writeErr).Warn("Failed to update note frontmatter with plan_ref/plan_job")
+			s.opLog("promote", inProgressPath, "").WithError(writeErr).Warn("Failed to update note frontmatter with plan_ref/plan_job")
 		}
 	}
 
@@ -295,7 +295,7 @@ func (s *Service) existingLivePlanForNote(notePath string) string {
 	if err != nil {
 		return ""
 	}
-	fm, _, err := frontmatter.Parse(string(content))
+	fm, err := frontmatter.ParsePartial(string(content))
 	if err != nil || fm == nil || fm.PlanRef == "" {
 		return ""
 	}
@@ -380,7 +380,7 @@ func (s *Service) parseNoteForPromotion(notePath string) (fm *frontmatter.Frontm
 	if parseErr != nil {
 		// On parse failure, strip the frontmatter block textually to avoid double-frontmatter.
 		// Log a warning for visibility.
-		s.Logger.WithError(parseErr).Warnf("Failed to parse frontmatter in note %s, using fallback body extraction", notePath)
+		s.opLog("promote", notePath, "").WithError(parseErr).Warn("Failed to parse frontmatter, using fallback body extraction")
 		fm = nil
 		body = stripFrontmatterBlock(string(noteContent))
 	}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// PruneEmptyDirs removes the directories under ctx's notes directory that
+// hold no files, deepest first, so a group left with nothing but empty
+// subgroups goes too. Reserved directories such as templates and plans are
+// kept even when empty, and .git is never entered. Archive and artifact
+// directories are only removed when empty, like any other. With dryRun
+// nothing is removed. It returns the directories removed (or that would be).
+func (s *Service) PruneEmptyDirs(ctx *WorkspaceContext, dryRun bool) ([]string, error) {
+	root, err := s.notebookLocator.GetNotesDir(ctx.NotebookContextWorkspace, "")
+	if err != nil {
+		return nil, fmt.Errorf("get notes directory: %w", err)
+	}
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var pruned []string
+	if _, err := pruneDir(root, true, dryRun, &pruned); err != nil {
+		return pruned, err
+	}
+	for _, dir := range pruned {
+		s.opLog("prune", dir, ctx.NotebookContextWorkspace.Name).WithField("dry_run", dryRun).Info("Pruned empty directory")
+	}
+	return pruned, nil
+}
+
+// pruneDir prunes the empty directories below dir and reports whether dir
+// itself is now empty. The root is never removed.
+func pruneDir(dir string, isRoot, dryRun bool, pruned *[]string) (bool, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false, fmt.Errorf("read %s: %w", dir, err)
+	}
+	empty := true
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || name == ".git" || (isRoot && reservedGroupNames[name]) {
+			// Files, symlinks and kept directories all make dir non-empty.
+			empty = false
+			continue
+		}
+		childEmpty, err := pruneDir(filepath.Join(dir, name), false, dryRun, pruned)
+		if err != nil {
+			return false, err
+		}
+		if !childEmpty {
+			empty = false
+		}
+	}
+	if !empty || isRoot {
+		return empty, nil
+	}
+	if !dryRun {
+		if err := os.Remove(dir); err != nil {
+			return false, fmt.Errorf("remove %s: %w", dir, err)
+		}
+	}
+	*pruned = append(*pruned, dir)
+	return true, nil
+}
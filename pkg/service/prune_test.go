@@ -0,0 +1,75 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPruneDir(t *testing.T) {
+	root := t.TempDir()
+	mkdir := func(rel string) {
+		if err := os.MkdirAll(filepath.Join(root, rel), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write := func(rel string) {
+		mkdir(filepath.Dir(rel))
+		if err := os.WriteFile(filepath.Join(root, rel), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mkdir("empty")
+	mkdir("nested/a/b")
+	mkdir("inbox/.archive")
+	write("inbox/note.md")
+	write("learn/.archive/old.md")
+	write("media/attachments/diagram.png")
+	mkdir("templates")
+	mkdir("plans")
+	mkdir(".git/refs")
+
+	want := []string{
+		filepath.Join(root, "empty"),
+		filepath.Join(root, "inbox/.archive"),
+		filepath.Join(root, "nested"),
+		filepath.Join(root, "nested/a"),
+		filepath.Join(root, "nested/a/b"),
+	}
+
+	var dry []string
+	if _, err := pruneDir(root, true, true, &dry); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(dry)
+	if !reflect.DeepEqual(dry, want) {
+		t.Errorf("dry run pruned %q, want %q", dry, want)
+	}
+	if _, err := os.Stat(filepath.Join(root, "nested/a/b")); err != nil {
+		t.Errorf("dry run removed a directory: %v", err)
+	}
+
+	var pruned []string
+	if _, err := pruneDir(root, true, false, &pruned); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(pruned)
+	if !reflect.DeepEqual(pruned, want) {
+		t.Errorf("pruned %q, want %q", pruned, want)
+	}
+	for _, dir := range want {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("%s still exists", dir)
+		}
+	}
+	for _, kept := range []string{"inbox", "learn/.archive", "media/attachments", "templates", "plans", ".git/refs"} {
+		if _, err := os.Stat(filepath.Join(root, kept)); err != nil {
+			t.Errorf("%s was removed: %v", kept, err)
+		}
+	}
+	if _, err := os.Stat(root); err != nil {
+		t.Errorf("root was removed: %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+// Readability complexity ratings, from the Flesch reading ease score.
+const (
+	ReadabilityEasy   = "easy"   // 60 and up: plain English
+	ReadabilityMedium = "medium" // 30 to 60
+	ReadabilityHard   = "hard"   // below 30: academic prose
+)
+
+// ReadabilityScore describes how hard a note's prose is to read. Markdown
+// syntax, code and frontmatter are left out of every count.
+type ReadabilityScore struct {
+	Words      int `json:"words"`
+	Sentences  int `json:"sentences"`
+	Syllables  int `json:"syllables"`
+	Paragraphs int `json:"paragraphs"`
+
+	// ReadingEase is the Flesch reading ease score: higher is easier, most
+	// prose falls between 0 and 100.
+	ReadingEase float64 `json:"reading_ease"`
+	// GradeLevel is the Flesch-Kincaid US school grade level.
+	GradeLevel float64 `json:"grade_level"`
+	// AvgSentenceLength is in words, AvgWordLength in letters.
+	AvgSentenceLength float64 `json:"avg_sentence_length"`
+	AvgWordLength     float64 `json:"avg_word_length"`
+	// Complexity is ReadabilityEasy, ReadabilityMedium or ReadabilityHard,
+	// or empty for a note without prose.
+	Complexity string `json:"complexity,omitempty"`
+}
+
+// String renders the score on one line, e.g. "reading ease 64 (easy), 14.2
+// words/sentence".
+func (r *ReadabilityScore) String() string {
+	if r.Words == 0 {
+		return "no prose"
+	}
+	return fmt.Sprintf("reading ease %.0f (%s), %.1f words/sentence", r.ReadingEase, r.Complexity, r.AvgSentenceLength)
+}
+
+// ComputeNoteReadability scores the prose of the note at path.
+func (s *Service) ComputeNoteReadability(path string) (*ReadabilityScore, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read note: %w", err)
+	}
+	return ComputeReadability(string(content)), nil
+}
+
+var (
+	mdFencePattern      = regexp.MustCompile("(?ms)^\\s*(```|~~~).*?^\\s*(```|~~~)[^\\n]*$")
+	mdInlineCodePattern = regexp.MustCompile("`[^`\\n]*`")
+	mdImagePattern      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLinkPattern       = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	mdWikiLinkPattern   = regexp.MustCompile(`\[\[(?:[^\]|]*\|)?([^\]]*)\]\]`)
+	mdHTMLPattern       = regexp.MustCompile(`<[^>\n]+>`)
+	mdURLPattern        = regexp.MustCompile(`https?://\S+`)
+	mdLinePrefixPattern = regexp.MustCompile(`^\s*(?:>\s*)*(?:[-*+]\s+(?:\[[ xX-]\]\s+)?|\d+[.)]\s+)?`)
+	mdHeadingPattern    = regexp.MustCompile(`^\s*#{1,6}\s`)
+	mdRulePattern       = regexp.MustCompile(`^\s*(?:[-*_]\s*){3,}$`)
+	sentenceEndPattern  = regexp.MustCompile(`[.!?]+(?:["')\]]*)(?:\s+|$)`)
+)
+
+// ComputeReadability scores content, a note with optional frontmatter.
+// Headings, code, tables and rules are skipped; list items count as
+// sentences of their own.
+func ComputeReadability(content string) *ReadabilityScore {
+	_, body, err := frontmatter.Parse(content)
+	if err != nil {
+		body = rawFrontmatterPattern.ReplaceAllString(content, "")
+	}
+
+	score := &ReadabilityScore{}
+	var letters int
+	for _, para := range proseParagraphs(body) {
+		score.Paragraphs++
+		for _, unit := range para {
+			for _, sentence := range sentenceEndPattern.Split(unit, -1) {
+				words := proseWords(sentence)
+				if len(words) == 0 {
+					continue
+				}
+				score.Sentences++
+				score.Words += len(words)
+				for _, w := range words {
+					letters += len([]rune(w))
+					score.Syllables += countSyllables(w)
+				}
+			}
+		}
+	}
+	if score.Words == 0 {
+		return score
+	}
+
+	words, sentences := float64(score.Words), float64(score.Sentences)
+	score.AvgSentenceLength = words / sentences
+	score.AvgWordLength = float64(letters) / words
+	syllablesPerWord := float64(score.Syllables) / words
+	score.ReadingEase = 206.835 - 1.015*score.AvgSentenceLength - 84.6*syllablesPerWord
+	score.GradeLevel = 0.39*score.AvgSentenceLength + 11.8*syllablesPerWord - 15.59
+	switch {
+	case score.ReadingEase >= 60:
+		score.Complexity = ReadabilityEasy
+	case score.ReadingEase >= 30:
+		score.Complexity = ReadabilityMedium
+	default:
+		score.Complexity = ReadabilityHard
+	}
+	return score
+}
+
+// proseParagraphs strips Markdown from body and returns its paragraphs, each
+// as the text units sentences are split from: the whole paragraph for plain
+// prose, one unit per item for lists.
+func proseParagraphs(body string) [][]string {
+	body = mdFencePattern.ReplaceAllString(body, "")
+	body = mdInlineCodePattern.ReplaceAllString(body, "")
+	body = mdImagePattern.ReplaceAllString(body, "$1")
+	body = mdLinkPattern.ReplaceAllString(body, "$1")
+	body = mdWikiLinkPattern.ReplaceAllString(body, "$1")
+	body = mdHTMLPattern.ReplaceAllString(body, "")
+	body = mdURLPattern.ReplaceAllString(body, "")
+
+	var paragraphs [][]string
+	var current []string
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			current = append(current, text.String())
+			text.Reset()
+		}
+	}
+	endParagraph := func() {
+		flush()
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, current)
+			current = nil
+		}
+	}
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || mdHeadingPattern.MatchString(line) || mdRulePattern.MatchString(line) || strings.HasPrefix(trimmed, "|") {
+			endParagraph()
+			continue
+		}
+		prefix := mdLinePrefixPattern.FindString(line)
+		if strings.TrimSpace(prefix) != "" && !strings.HasSuffix(strings.TrimSpace(prefix), ">") {
+			// A list item starts a new unit.
+			flush()
+		}
+		if text.Len() > 0 {
+			text.WriteByte(' ')
+		}
+		text.WriteString(line[len(prefix):])
+	}
+	endParagraph()
+	return paragraphs
+}
+
+// proseWords returns the words in s, dropping emphasis markers and other
+// punctuation. Tokens without a letter (numbers, symbols) are not words.
+func proseWords(s string) []string {
+	var words []string
+	for _, field := range strings.Fields(s) {
+		word := strings.TrimFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if strings.IndexFunc(word, unicode.IsLetter) >= 0 {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// countSyllables estimates the syllables in word by counting groups of
+// vowels, not counting a silent final "e" ("make") but keeping "-le"
+// ("table"). Every word has at least one.
+func countSyllables(word string) int {
+	word = strings.ToLower(word)
+	count := 0
+	inVowel := false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !inVowel {
+			count++
+		}
+		inVowel = vowel
+	}
+	if n := len(word); n > 2 && word[n-1] == 'e' && !strings.ContainsRune("aeiouyl", rune(word[n-2])) {
+		count--
+	}
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCountSyllables(t *testing.T) {
+	tests := map[string]int{
+		"the":         1,
+		"make":        1,
+		"free":        1,
+		"table":       2,
+		"document":    3,
+		"readability": 5,
+		"rhythm":      1,
+		"a":           1,
+	}
+	for word, want := range tests {
+		if got := countSyllables(word); got != want {
+			t.Errorf("countSyllables(%q) = %d, want %d", word, got, want)
+		}
+	}
+}
+
+func TestComputeReadabilityStripsMarkdown(t *testing.T) {
+	content := "---\ntitle: Cats\ntags: [pets]\n---\n" +
+		"# The cat\n\n" +
+		"The cat sat on the **mat**. It was a [good](https://example.com/mat) day!\n\n" +
+		"```go\nfunc ignored() { return averyverylongidentifier }\n```\n\n" +
+		"- Feed the cat\n" +
+		"- Pet the `cat` dog\n"
+
+	score := ComputeReadability(content)
+	if score.Paragraphs != 2 {
+		t.Errorf("paragraphs = %d, want 2", score.Paragraphs)
+	}
+	if score.Sentences != 4 {
+		t.Errorf("sentences = %d, want 4", score.Sentences)
+	}
+	// The cat sat on the mat / It was a good day / Feed the cat / Pet the dog
+	if score.Words != 17 {
+		t.Errorf("words = %d, want 17", score.Words)
+	}
+	if score.Syllables != 17 {
+		t.Errorf("syllables = %d, want 17", score.Syllables)
+	}
+	if score.AvgSentenceLength != 4.25 {
+		t.Errorf("avg sentence length = %v, want 4.25", score.AvgSentenceLength)
+	}
+	wantEase := 206.835 - 1.015*4.25 - 84.6
+	if math.Abs(score.ReadingEase-wantEase) > 1e-9 {
+		t.Errorf("reading ease = %v, want %v", score.ReadingEase, wantEase)
+	}
+	if score.Complexity != ReadabilityEasy {
+		t.Errorf("complexity = %q, want easy", score.Complexity)
+	}
+}
+
+func TestComputeReadabilityHardProse(t *testing.T) {
+	content := "Notwithstanding considerable organizational complexity, institutional " +
+		"interoperability necessitates comprehensive architectural documentation " +
+		"encompassing operational responsibilities and administrative accountability."
+	score := ComputeReadability(content)
+	if score.Complexity != ReadabilityHard {
+		t.Errorf("complexity = %q (ease %.1f), want hard", score.Complexity, score.ReadingEase)
+	}
+	if score.Sentences != 1 || score.Paragraphs != 1 {
+		t.Errorf("sentences, paragraphs = %d, %d, want 1, 1", score.Sentences, score.Paragraphs)
+	}
+}
+
+func TestComputeReadabilityEmpty(t *testing.T) {
+	score := ComputeReadability("---\ntitle: Empty\n---\n\n# Only a heading\n\n```\ncode\n```\n")
+	if score.Words != 0 || score.Complexity != "" || score.ReadingEase != 0 {
+		t.Errorf("empty note scored %+v", score)
+	}
+	if got := score.String(); got != "no prose" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestComputeNoteReadability(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "note.md")
+	if err := os.WriteFile(path, []byte("Short words are easy to read.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	score, err := (&Service{}).ComputeNoteReadability(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score.Words != 6 || score.Sentences != 1 {
+		t.Errorf("words, sentences = %d, %d, want 6, 1", score.Words, score.Sentences)
+	}
+	if _, err := (&Service{}).ComputeNoteReadability(filepath.Join(t.TempDir(), "missing.md")); err == nil {
+		t.Error("missing note: expected an error")
+	}
+}
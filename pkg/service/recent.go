@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// GetRecentNotes returns the n most recently modified non-archived notes in
+// the given workspace context, or across every workspace when allWorkspaces is
+// set. n <= 0 returns all of them, newest first.
+func (s *Service) GetRecentNotes(ctx *WorkspaceContext, n int, allWorkspaces bool) ([]*models.Note, error) {
+	var (
+		notes []*models.Note
+		err   error
+	)
+	if allWorkspaces {
+		notes, err = s.ListNotesFromAllWorkspaces(false, false)
+	} else {
+		notes, err = s.ListAllNotes(ctx, false, false)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list notes for recent: %w", err)
+	}
+	return SelectRecentNotes(notes, n, false), nil
+}
+
+// SelectRecentNotes orders notes by modification time (newest first) and
+// keeps at most n of them (n <= 0 keeps all). Archived notes are dropped
+// unless includeArchived is set. The input slice is not modified.
+//
+// It is the shared ordering used by GetRecentNotes and the TUI's recent view,
+// which already holds its notes in memory.
+func SelectRecentNotes(notes []*models.Note, n int, includeArchived bool) []*models.Note {
+	recent := make([]*models.Note, 0, len(notes))
+	for _, note := range notes {
+		if note == nil || (!includeArchived && note.IsArchived) {
+			continue
+		}
+		recent = append(recent, note)
+	}
+
+	sort.SliceStable(recent, func(i, j int) bool {
+		return recent[i].ModifiedAt.After(recent[j].ModifiedAt)
+	})
+
+	if n > 0 && len(recent) > n {
+		recent = recent[:n]
+	}
+	return recent
+}
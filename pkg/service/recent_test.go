@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestSelectRecentNotes(t *testing.T) {
+	now := time.Now()
+	old := &models.Note{Path: "old.md", ModifiedAt: now.Add(-48 * time.Hour)}
+	newest := &models.Note{Path: "newest.md", ModifiedAt: now}
+	middle := &models.Note{Path: "middle.md", ModifiedAt: now.Add(-time.Hour)}
+	archived := &models.Note{Path: ".archive/gone.md", ModifiedAt: now.Add(time.Hour), IsArchived: true}
+	notes := []*models.Note{old, newest, archived, middle}
+
+	paths := func(ns []*models.Note) []string {
+		out := make([]string, 0, len(ns))
+		for _, n := range ns {
+			out = append(out, n.Path)
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"newest.md", "middle.md", "old.md"}, paths(SelectRecentNotes(notes, 0, false)))
+	assert.Equal(t, []string{"newest.md", "middle.md"}, paths(SelectRecentNotes(notes, 2, false)))
+	assert.Equal(t, []string{".archive/gone.md", "newest.md"}, paths(SelectRecentNotes(notes, 2, true)))
+
+	// The caller's slice keeps its original order.
+	assert.Equal(t, []string{"old.md", "newest.md", ".archive/gone.md", "middle.md"}, paths(notes))
+}
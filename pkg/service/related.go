@@ -0,0 +1,130 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// DefaultRelatedMinScore is the similarity a note must reach to be reported
+// as related when Config.RelatedMinScore is unset.
+const DefaultRelatedMinScore = 0.3
+
+// relatedWorkspaceBoost is added to the score of candidates that live in the
+// same workspace as the target note and share at least one tag with it.
+const relatedWorkspaceBoost = 0.1
+
+// RelatedNote is a candidate returned by RankRelatedNotes with its score.
+type RelatedNote struct {
+	Note  *models.Note `json:"note"`
+	Score float64      `json:"score"`
+}
+
+// GetRelatedNotes returns up to limit notes (limit <= 0 means no cap) whose
+// tags overlap with the note at notePath, best match first.
+func (s *Service) GetRelatedNotes(notePath string, limit int) ([]*models.Note, error) {
+	related, err := s.GetRelatedNotesScored(notePath, limit)
+	if err != nil {
+		return nil, err
+	}
+	notes := make([]*models.Note, 0, len(related))
+	for _, r := range related {
+		notes = append(notes, r.Note)
+	}
+	return notes, nil
+}
+
+// GetRelatedNotesScored is GetRelatedNotes with the similarity scores kept.
+// Candidates are all non-archived notes across every workspace.
+func (s *Service) GetRelatedNotesScored(notePath string, limit int) ([]RelatedNote, error) {
+	target, err := ParseNote(notePath)
+	if err != nil {
+		return nil, fmt.Errorf("parse note: %w", err)
+	}
+	candidates, err := s.ListNotesFromAllWorkspaces(false, false)
+	if err != nil {
+		return nil, fmt.Errorf("list notes for related: %w", err)
+	}
+	return RankRelatedNotes(target, candidates, s.relatedMinScore(), limit), nil
+}
+
+func (s *Service) relatedMinScore() float64 {
+	if s.Config != nil && s.Config.RelatedMinScore != nil {
+		return *s.Config.RelatedMinScore
+	}
+	return DefaultRelatedMinScore
+}
+
+// RankRelatedNotes scores every candidate against target by the Jaccard
+// similarity of their tag sets (|A ∩ B| / |A ∪ B|), boosts candidates in the
+// same workspace by 0.1, and returns those scoring at least minScore, best
+// first (ties broken by most recently modified). The target itself and notes
+// without a shared tag are never returned.
+func RankRelatedNotes(target *models.Note, candidates []*models.Note, minScore float64, limit int) []RelatedNote {
+	if target == nil {
+		return nil
+	}
+	targetTags := tagSet(target.Tags)
+	if len(targetTags) == 0 {
+		return nil
+	}
+
+	var related []RelatedNote
+	for _, c := range candidates {
+		if c == nil || c.Path == target.Path {
+			continue
+		}
+		score := jaccard(targetTags, tagSet(c.Tags))
+		if score == 0 {
+			continue
+		}
+		if target.Workspace != "" && c.Workspace == target.Workspace {
+			score += relatedWorkspaceBoost
+		}
+		if score < minScore {
+			continue
+		}
+		related = append(related, RelatedNote{Note: c, Score: score})
+	}
+
+	sort.SliceStable(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		return related[i].Note.ModifiedAt.After(related[j].Note.ModifiedAt)
+	})
+
+	if limit > 0 && len(related) > limit {
+		related = related[:limit]
+	}
+	return related
+}
+
+// tagSet normalizes tags for comparison: case-insensitive, with surrounding
+// whitespace and a leading '#' ignored.
+func tagSet(tags []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(t), "#"))
+		if t != "" {
+			set[t] = struct{}{}
+		}
+	}
+	return set
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for t := range a {
+		if _, ok := b[t]; ok {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestRankRelatedNotes(t *testing.T) {
+	target := &models.Note{Path: "/nb/a/target.md", Workspace: "a", Tags: []string{"go", "tui", "nb"}}
+	candidates := []*models.Note{
+		target,
+		{Path: "/nb/b/same-tags.md", Workspace: "b", Tags: []string{"GO", "#tui", "nb"}},     // 1.0
+		{Path: "/nb/a/half.md", Workspace: "a", Tags: []string{"go", "tui"}},                 // 2/3 + 0.1
+		{Path: "/nb/b/weak.md", Workspace: "b", Tags: []string{"go", "x", "y", "z"}},         // 1/6
+		{Path: "/nb/a/weak-local.md", Workspace: "a", Tags: []string{"go", "tui", "x", "y"}}, // 2/5 + 0.1
+		{Path: "/nb/a/untagged.md", Workspace: "a"},
+	}
+
+	got := RankRelatedNotes(target, candidates, DefaultRelatedMinScore, 0)
+	var paths []string
+	for _, r := range got {
+		paths = append(paths, r.Note.Path)
+	}
+	assert.Equal(t, []string{"/nb/b/same-tags.md", "/nb/a/half.md", "/nb/a/weak-local.md"}, paths)
+	assert.InDelta(t, 1.0, got[0].Score, 1e-9)
+	assert.InDelta(t, 2.0/3.0+0.1, got[1].Score, 1e-9)
+
+	assert.Len(t, RankRelatedNotes(target, candidates, DefaultRelatedMinScore, 1), 1)
+	assert.Len(t, RankRelatedNotes(target, candidates, 0.05, 0), 4, "lower threshold admits the weak match")
+	assert.Empty(t, RankRelatedNotes(&models.Note{Path: "/x.md"}, candidates, 0, 0), "untagged target has no related notes")
+}
+
+func TestRelatedMinScoreConfig(t *testing.T) {
+	s := &Service{}
+	assert.Equal(t, DefaultRelatedMinScore, s.relatedMinScore())
+
+	zero := 0.0
+	s.Config = &Config{RelatedMinScore: &zero}
+	assert.Equal(t, 0.0, s.relatedMinScore(), "an explicit 0 is not the default")
+}
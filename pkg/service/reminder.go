@@ -0,0 +1,303 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// NoteReminder is a reminder together with the note it belongs to.
+type NoteReminder struct {
+	Path      string    `json:"path"`
+	Title     string    `json:"title"`
+	Workspace string    `json:"workspace"`
+	At        time.Time `json:"at"`
+	Message   string    `json:"message,omitempty"`
+}
+
+// reminderState is the content of the reminders state file.
+type reminderState struct {
+	// Since is when reminders were first checked. Reminders due before it
+	// are never notified.
+	Since time.Time `json:"since"`
+	// Notified lists the reminders already notified.
+	Notified []notifiedReminder `json:"notified,omitempty"`
+}
+
+// notifiedReminder identifies a notified reminder by its note and time, so
+// moving a reminder to a new time notifies it again.
+type notifiedReminder struct {
+	Path string    `json:"path"`
+	At   time.Time `json:"at"`
+}
+
+// notified reports whether r has already been notified.
+func (st *reminderState) notified(r NoteReminder) bool {
+	for _, n := range st.Notified {
+		if n.Path == r.Path && n.At.Equal(r.At) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetNoteReminder sets the `reminder` frontmatter field of the note at path
+// to fire at reminderAt with message. A note has at most one reminder;
+// setting another replaces it.
+func (s *Service) SetNoteReminder(path string, reminderAt time.Time, message string) error {
+	if reminderAt.IsZero() {
+		return fmt.Errorf("reminder time cannot be empty")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read note for reminder: %w", err)
+	}
+
+	value := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "at"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: reminderAt.UTC().Format(time.RFC3339)},
+	}}
+	if message != "" {
+		value.Content = append(value.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "message"},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: message},
+		)
+	}
+	newContent, err := updateFrontmatterFields(content, map[string]interface{}{"reminder": value})
+	if err != nil {
+		return fmt.Errorf("update reminder frontmatter: %w", err)
+	}
+	if err := os.WriteFile(path, newContent, 0o644); err != nil {
+		return fmt.Errorf("write note with reminder: %w", err)
+	}
+
+	s.opLog("set_reminder", path, "").WithField("at", reminderAt.UTC().Format(time.RFC3339)).Info("Set note reminder")
+	return nil
+}
+
+// ClearNoteReminder removes the `reminder` field of the note at path, if any.
+func (s *Service) ClearNoteReminder(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read note for reminder: %w", err)
+	}
+	raw, _, err := extractFrontmatterString(content)
+	if err != nil {
+		return err
+	}
+	if raw == "" {
+		return nil
+	}
+	newContent, err := updateFrontmatterFields(content, map[string]interface{}{"reminder": nil})
+	if err != nil {
+		return fmt.Errorf("update reminder frontmatter: %w", err)
+	}
+	if err := os.WriteFile(path, newContent, 0o644); err != nil {
+		return fmt.Errorf("write note without reminder: %w", err)
+	}
+	s.opLog("clear_reminder", path, "").Info("Cleared note reminder")
+	return nil
+}
+
+// ListReminders returns the reminders of the notes in ctx's workspace, or of
+// every workspace when all is set, soonest first. Reminders already past are
+// included.
+func (s *Service) ListReminders(ctx *WorkspaceContext, all bool) ([]NoteReminder, error) {
+	var (
+		notes []*models.Note
+		err   error
+	)
+	if all {
+		notes, err = s.ListNotesFromAllWorkspaces(false, false)
+	} else {
+		notes, err = s.ListAllNotes(ctx, false, false)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list notes for reminders: %w", err)
+	}
+	return collectReminders(notes), nil
+}
+
+// CheckReminders notifies, via notify, every reminder in the listed
+// workspaces that is due by now and hasn't been notified yet, and records
+// each one in the reminders state file as soon as it is sent, so a
+// restarted checker doesn't notify it again. Reminders due before the
+// first check are ignored. It returns the reminders it notified. A failed
+// notification is returned as an error after the others have been sent;
+// it isn't recorded, so it is retried.
+func (s *Service) CheckReminders(ctx *WorkspaceContext, all bool, now time.Time, notify func(NoteReminder) error) ([]NoteReminder, error) {
+	reminders, err := s.ListReminders(ctx, all)
+	if err != nil {
+		return nil, err
+	}
+	statePath := ReminderStatePath()
+	state, err := loadReminderState(statePath)
+	if err != nil {
+		return nil, err
+	}
+	if state.Since.IsZero() {
+		state.Since = now
+		if err := saveReminderState(statePath, state); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		sent     []NoteReminder
+		firstErr error
+	)
+	for _, r := range dueReminders(reminders, state, now) {
+		if err := notify(r); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("notify reminder for %s: %w", r.Path, err)
+			}
+			continue
+		}
+		sent = append(sent, r)
+		s.opLog("reminder", r.Path, r.Workspace).Info("Sent note reminder")
+		state.Notified = append(state.Notified, notifiedReminder{Path: r.Path, At: r.At})
+		if err := saveReminderState(statePath, state); err != nil {
+			return sent, err
+		}
+	}
+	return sent, firstErr
+}
+
+// ReminderStatePath returns the file CheckReminders records notified
+// reminders in, ~/.grove/nb/reminders-state.json.
+func ReminderStatePath() string {
+	return filepath.Join(NBHomeDir(), "reminders-state.json")
+}
+
+// collectReminders returns the reminders of notes, soonest first.
+func collectReminders(notes []*models.Note) []NoteReminder {
+	var reminders []NoteReminder
+	for _, note := range notes {
+		if note.Reminder == nil {
+			continue
+		}
+		title := note.FrontmatterTitle
+		if title == "" {
+			title = note.Title
+		}
+		reminders = append(reminders, NoteReminder{
+			Path:      note.Path,
+			Title:     title,
+			Workspace: note.Workspace,
+			At:        note.Reminder.At,
+			Message:   note.Reminder.Message,
+		})
+	}
+	sort.SliceStable(reminders, func(i, j int) bool { return reminders[i].At.Before(reminders[j].At) })
+	return reminders
+}
+
+// dueReminders returns the reminders due between state.Since and now that
+// haven't been notified yet.
+func dueReminders(reminders []NoteReminder, state reminderState, now time.Time) []NoteReminder {
+	var due []NoteReminder
+	for _, r := range reminders {
+		if r.At.Before(state.Since) || r.At.After(now) || state.notified(r) {
+			continue
+		}
+		due = append(due, r)
+	}
+	return due
+}
+
+// loadReminderState reads the reminders state file. A missing file is a
+// first run, with a zero Since.
+func loadReminderState(path string) (reminderState, error) {
+	var state reminderState
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, fmt.Errorf("read reminders state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("parse reminders state: %w", err)
+	}
+	return state, nil
+}
+
+// saveReminderState writes the reminders state file.
+func saveReminderState(path string, state reminderState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal reminders state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create state directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write reminders state: %w", err)
+	}
+	return nil
+}
+
+// ParseReminderTime reads a reminder time relative to now: a duration from
+// now ("30m", "2h", "1d"), RFC3339, or a local "YYYY-MM-DD HH:MM",
+// "YYYY-MM-DDTHH:MM" or "YYYY-MM-DD" (9am that day).
+func ParseReminderTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return now.AddDate(0, 0, n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("reminder duration must be positive: %s", s)
+		}
+		return now.Add(d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t.Add(9 * time.Hour), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid reminder time %q (want a duration like 2h or 1d, RFC3339, or YYYY-MM-DD [HH:MM])", s)
+}
+
+// SendDesktopNotification shows a desktop notification with notify-send on
+// Linux and osascript on macOS.
+func SendDesktopNotification(title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		cmd = exec.Command("notify-send", "--app-name=nb", title, message)
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		cmd = exec.Command("osascript", "-e", script)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", cmd.Args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetNoteReminderRoundTrip(t *testing.T) {
+	s := newTestService()
+	path := filepath.Join(t.TempDir(), "note.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\nid: a\ntitle: A\ntags: [x]\n---\n\nBody\n"), 0o644))
+
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, s.SetNoteReminder(path, at, "Review this"))
+
+	note, err := ParseNote(path)
+	require.NoError(t, err)
+	require.NotNil(t, note.Reminder)
+	assert.True(t, note.Reminder.At.Equal(at))
+	assert.Equal(t, "Review this", note.Reminder.Message)
+	assert.Equal(t, []string{"x"}, note.Tags)
+	assert.Contains(t, note.Content, "\nBody\n")
+
+	require.NoError(t, s.ClearNoteReminder(path))
+	note, err = ParseNote(path)
+	require.NoError(t, err)
+	assert.Nil(t, note.Reminder)
+	assert.Equal(t, "A", note.FrontmatterTitle)
+}
+
+func TestDueReminders(t *testing.T) {
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	reminders := []NoteReminder{
+		{Path: "old", At: base.Add(-time.Hour)},
+		{Path: "sent", At: base.Add(time.Minute)},
+		{Path: "due", At: base.Add(2 * time.Minute)},
+		{Path: "future", At: base.Add(time.Hour)},
+	}
+	state := reminderState{
+		Since:    base,
+		Notified: []notifiedReminder{{Path: "sent", At: base.Add(time.Minute)}},
+	}
+
+	due := dueReminders(reminders, state, base.Add(5*time.Minute))
+	require.Len(t, due, 1)
+	assert.Equal(t, "due", due[0].Path)
+
+	// A reminder moved to a new time is notified again.
+	state.Notified[0].At = base
+	assert.Len(t, dueReminders(reminders, state, base.Add(5*time.Minute)), 2)
+}
+
+func TestCheckRemindersNotifiesOnce(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	captureNoteEvents(t)
+	s, err := New(&Config{}, nil, nil, nil)
+	require.NoError(t, err)
+	ws := &coreworkspace.WorkspaceNode{Name: "proj", Path: t.TempDir()}
+	ctx := &WorkspaceContext{NotebookContextWorkspace: ws, CurrentWorkspace: ws}
+
+	dir, err := s.getNotePathForContext(ctx, "inbox")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	start := time.Now()
+	writeReminder := func(name string, at time.Time) string {
+		path := filepath.Join(dir, name+".md")
+		require.NoError(t, os.WriteFile(path, []byte("---\ntitle: "+name+"\n---\n"), 0o644))
+		require.NoError(t, s.SetNoteReminder(path, at, ""))
+		return path
+	}
+	writeReminder("before-first-run", start.Add(-time.Hour))
+	first := writeReminder("first", start.Add(time.Minute))
+	second := writeReminder("second", start.Add(2*time.Minute))
+
+	var notified []string
+	failSecond := true
+	notify := func(r NoteReminder) error {
+		if r.Path == second && failSecond {
+			failSecond = false
+			return assert.AnError
+		}
+		notified = append(notified, r.Path)
+		return nil
+	}
+
+	// The first run only records its start; the older reminder is ignored.
+	sent, err := s.CheckReminders(ctx, false, start, notify)
+	require.NoError(t, err)
+	assert.Empty(t, sent)
+
+	// The second reminder fails but the first is still recorded...
+	sent, err = s.CheckReminders(ctx, false, start.Add(5*time.Minute), notify)
+	require.Error(t, err)
+	require.Len(t, sent, 1)
+	assert.Equal(t, first, sent[0].Path)
+
+	// ...so the retry only notifies the failed one.
+	sent, err = s.CheckReminders(ctx, false, start.Add(6*time.Minute), notify)
+	require.NoError(t, err)
+	require.Len(t, sent, 1)
+	assert.Equal(t, second, sent[0].Path)
+	assert.Equal(t, []string{first, second}, notified)
+
+	sent, err = s.CheckReminders(ctx, false, start.Add(7*time.Minute), notify)
+	require.NoError(t, err)
+	assert.Empty(t, sent)
+	assert.FileExists(t, filepath.Join(NBHomeDir(), "reminders-state.json"))
+}
+
+func TestParseReminderTime(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"2h":                   now.Add(2 * time.Hour),
+		"3d":                   now.AddDate(0, 0, 3),
+		"2024-06-02T08:30:00Z": time.Date(2024, 6, 2, 8, 30, 0, 0, time.UTC),
+		"2024-06-02 08:30":     time.Date(2024, 6, 2, 8, 30, 0, 0, time.UTC),
+		"2024-06-02":           time.Date(2024, 6, 2, 9, 0, 0, 0, time.UTC),
+	}
+	for in, want := range tests {
+		got, err := ParseReminderTime(in, now)
+		require.NoError(t, err, in)
+		assert.True(t, want.Equal(got), "%s: got %v, want %v", in, got, want)
+	}
+
+	for _, in := range []string{"", "-1h", "tomorrow"} {
+		_, err := ParseReminderTime(in, now)
+		assert.Error(t, err, in)
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// searchReadErrors are the stderr messages of rg and grep for a file or
+// directory they could not read. Both carry on with the other files and exit
+// with 2 at the end, so such errors only make the results partial.
+var searchReadErrors = []string{
+	"Permission denied",
+	"Operation not permitted",
+	// A file removed while the search runs.
+	"No such file or directory",
+}
+
+// RunSearchCommand runs a content search (rg or grep over notebook files)
+// and returns its stdout. Exit status 1, no matches, is not an error.
+// Exit status 2 with only read errors on stderr returns the partial output
+// along with those errors as warnings; any other failure is an error.
+func RunSearchCommand(cmd *exec.Cmd) ([]byte, []string, error) {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err == nil {
+		return output, nil, nil
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return nil, nil, fmt.Errorf("search command failed: %w", err)
+	}
+	switch exitErr.ExitCode() {
+	case 1:
+		return output, nil, nil
+	case 2:
+		if warnings, ok := searchWarnings(stderr.String()); ok {
+			return output, warnings, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("search command failed: %w, stderr: %s", err, strings.TrimSpace(stderr.String()))
+}
+
+// searchWarnings splits a search command's stderr into lines and reports
+// whether every one of them is a read error.
+func searchWarnings(stderr string) ([]string, bool) {
+	var warnings []string
+	for _, line := range strings.Split(stderr, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !isSearchReadError(line) {
+			return nil, false
+		}
+		warnings = append(warnings, line)
+	}
+	return warnings, len(warnings) > 0
+}
+
+func isSearchReadError(line string) bool {
+	for _, msg := range searchReadErrors {
+		if strings.Contains(line, msg) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSearchCommandPartialResults(t *testing.T) {
+	// What rg prints when one directory of the notebook is unreadable.
+	partial := exec.Command("sh", "-c", `echo /nb/inbox/a.md; echo "rg: /nb/private: Permission denied (os error 13)" >&2; exit 2`)
+	output, warnings, err := RunSearchCommand(partial)
+	require.NoError(t, err)
+	assert.Equal(t, "/nb/inbox/a.md\n", string(output))
+	assert.Equal(t, []string{"rg: /nb/private: Permission denied (os error 13)"}, warnings)
+
+	none := exec.Command("sh", "-c", "exit 1")
+	output, warnings, err = RunSearchCommand(none)
+	require.NoError(t, err, "no matches is not an error")
+	assert.Empty(t, output)
+	assert.Empty(t, warnings)
+
+	invalid := exec.Command("sh", "-c", `echo "rg: regex parse error: unclosed group" >&2; exit 2`)
+	_, _, err = RunSearchCommand(invalid)
+	assert.ErrorContains(t, err, "regex parse error")
+}
+
+func TestRunSearchCommandUnreadableDirectory(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("root can read any directory")
+	}
+	grepPath, err := exec.LookPath("grep")
+	if err != nil {
+		t.Skip("grep not found")
+	}
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.md"), []byte("needle\n"), 0o644))
+	private := filepath.Join(root, "private")
+	require.NoError(t, os.Mkdir(private, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(private, "b.md"), []byte("needle\n"), 0o644))
+	require.NoError(t, os.Chmod(private, 0o000))
+	t.Cleanup(func() { os.Chmod(private, 0o755) })
+
+	output, warnings, err := RunSearchCommand(exec.Command(grepPath, "-rli", "--include=*.md", "needle", root))
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(root, "a.md")}, strings.Fields(string(output)))
+	assert.Len(t, warnings, 1)
+}
+
+func TestCountWarningsOncePerPath(t *testing.T) {
+	var count int
+	opts := &searchOptions{warnings: &count}
+	unreadable := "rg: /nb/private: Permission denied (os error 13)"
+
+	// A boolean search runs once per term over the same files.
+	opts.countWarnings([]string{unreadable})
+	opts.countWarnings([]string{unreadable, "rg: /nb/gone.md: No such file or directory (os error 2)"})
+	assert.Equal(t, 2, count)
+
+	(&searchOptions{}).countWarnings([]string{unreadable})
+}
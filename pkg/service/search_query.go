@@ -0,0 +1,229 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// BooleanQuery is a multi-term search: a note matches when it contains every
+// All term, at least one Any term (if there are any) and no None term.
+type BooleanQuery struct {
+	All  []string
+	Any  []string
+	None []string
+}
+
+// IsEmpty reports whether q has no terms at all.
+func (q BooleanQuery) IsEmpty() bool {
+	return len(q.All) == 0 && len(q.Any) == 0 && len(q.None) == 0
+}
+
+// ParseBooleanQuery splits query on the upper-case operators AND, OR and NOT
+// ("kubernetes AND deployment", "bug OR issue NOT wontfix"). Words between
+// operators form one term, and double quotes keep an operator word literal.
+// AND and OR cannot be mixed in one query. ok is false when query has no
+// operators, so it should be searched as a literal string.
+func ParseBooleanQuery(query string) (q BooleanQuery, ok bool, err error) {
+	tokens, quoted := tokenizeSearchQuery(query)
+
+	var (
+		term          []string
+		op            string // Operator before the current term
+		sawAnd, sawOr bool
+		positive      []string // Terms joined by AND or OR
+	)
+	place := func() {
+		t := strings.Join(term, " ")
+		term = nil
+		if op == "NOT" {
+			q.None = append(q.None, t)
+		} else {
+			positive = append(positive, t)
+		}
+	}
+
+	for i, tok := range tokens {
+		if quoted[i] || (tok != "AND" && tok != "OR" && tok != "NOT") {
+			term = append(term, tok)
+			continue
+		}
+		ok = true
+		switch {
+		case len(term) > 0:
+			place()
+		case tok == "NOT" && op != "NOT":
+			// "NOT x" and "x AND NOT y" negate the next term.
+		case op == "":
+			return BooleanQuery{}, false, fmt.Errorf("missing search term before %s", tok)
+		default:
+			return BooleanQuery{}, false, fmt.Errorf("missing search term after %s", op)
+		}
+		sawAnd = sawAnd || tok == "AND"
+		sawOr = sawOr || tok == "OR"
+		op = tok
+	}
+	if !ok {
+		return BooleanQuery{}, false, nil
+	}
+	if len(term) == 0 {
+		return BooleanQuery{}, false, fmt.Errorf("missing search term after %s", op)
+	}
+	place()
+
+	if sawAnd && sawOr {
+		return BooleanQuery{}, false, fmt.Errorf("cannot mix AND and OR in one query; use --and and --or instead")
+	}
+	if sawOr {
+		q.Any = positive
+	} else {
+		q.All = positive
+	}
+	return q, true, nil
+}
+
+// tokenizeSearchQuery splits query on whitespace, keeping double-quoted
+// phrases together. quoted marks the tokens that came from quotes.
+func tokenizeSearchQuery(query string) (tokens []string, quoted []bool) {
+	var (
+		cur      strings.Builder
+		inQuote  bool
+		wasQuote bool
+	)
+	emit := func() {
+		if cur.Len() > 0 || wasQuote {
+			tokens = append(tokens, cur.String())
+			quoted = append(quoted, wasQuote)
+		}
+		cur.Reset()
+		wasQuote = false
+	}
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			wasQuote = true
+		case !inQuote && (r == ' ' || r == '\t' || r == '\n'):
+			emit()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	emit()
+	return tokens, quoted
+}
+
+// SearchNotesBoolean searches once per term of q, as SearchNotes does, and
+// combines the matches: the intersection of the All terms, narrowed to the
+// union of the Any terms, minus every note matching a None term. A query of
+// only None terms excludes from every note in the search's workspaces.
+// Results are sorted by path.
+func (s *Service) SearchNotesBoolean(ctx *WorkspaceContext, q BooleanQuery, options ...SearchOption) ([]*models.Note, error) {
+	if q.IsEmpty() {
+		return nil, fmt.Errorf("search query is empty")
+	}
+	opts, scope, err := newSearchOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	search := func(term string) (map[string]*models.Note, error) {
+		found, err := s.searchCandidates(ctx, term, scope, opts)
+		if err != nil {
+			return nil, err
+		}
+		return notesByPath(found), nil
+	}
+
+	var matched map[string]*models.Note
+	for _, term := range q.All {
+		found, err := search(term)
+		if err != nil {
+			return nil, err
+		}
+		if matched == nil {
+			matched = found
+		} else {
+			matched = intersectNotes(matched, found)
+		}
+	}
+	if len(q.Any) > 0 {
+		union := make(map[string]*models.Note)
+		for _, term := range q.Any {
+			found, err := search(term)
+			if err != nil {
+				return nil, err
+			}
+			for path, note := range found {
+				union[path] = note
+			}
+		}
+		if matched == nil {
+			matched = union
+		} else {
+			matched = intersectNotes(matched, union)
+		}
+	}
+	if matched == nil {
+		var all []*models.Note
+		if opts.allWorkspaces {
+			all, err = s.ListNotesFromAllWorkspaces(false, false)
+		} else {
+			all, err = s.ListAllNotes(ctx, false, false)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list notes for search: %w", err)
+		}
+		matched = notesByPath(all)
+	}
+	for _, term := range q.None {
+		found, err := search(term)
+		if err != nil {
+			return nil, err
+		}
+		for path := range found {
+			delete(matched, path)
+		}
+	}
+
+	candidates := make([]*models.Note, 0, len(matched))
+	for _, note := range matched {
+		candidates = append(candidates, note)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Path < candidates[j].Path })
+	results := filterSearchResults(candidates, opts)
+
+	s.Logger.WithFields(logrus.Fields{
+		"all":           q.All,
+		"any":           q.Any,
+		"none":          q.None,
+		"scope":         scope,
+		"results_count": len(results),
+	}).Debug("Boolean search completed")
+
+	return results, nil
+}
+
+// notesByPath indexes notes by path.
+func notesByPath(notes []*models.Note) map[string]*models.Note {
+	byPath := make(map[string]*models.Note, len(notes))
+	for _, note := range notes {
+		byPath[note.Path] = note
+	}
+	return byPath
+}
+
+// intersectNotes returns the notes of a whose paths are also in b.
+func intersectNotes(a, b map[string]*models.Note) map[string]*models.Note {
+	both := make(map[string]*models.Note)
+	for path, note := range a {
+		if _, ok := b[path]; ok {
+			both[path] = note
+		}
+	}
+	return both
+}
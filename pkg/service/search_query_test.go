@@ -0,0 +1,77 @@
+package service
+
+import (
+	"testing"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBooleanQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  BooleanQuery
+		ok    bool
+	}{
+		{query: "kubernetes deployment"},
+		{query: "rock and roll"},
+		{query: "kubernetes AND deployment", want: BooleanQuery{All: []string{"kubernetes", "deployment"}}, ok: true},
+		{query: "postgres OR mysql NOT draft", want: BooleanQuery{Any: []string{"postgres", "mysql"}, None: []string{"draft"}}, ok: true},
+		{query: "error handling AND NOT retry", want: BooleanQuery{All: []string{"error handling"}, None: []string{"retry"}}, ok: true},
+		{query: "NOT wip", want: BooleanQuery{None: []string{"wip"}}, ok: true},
+		{query: `"AND" AND gate`, want: BooleanQuery{All: []string{"AND", "gate"}}, ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, ok, err := ParseBooleanQuery(tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseBooleanQueryErrors(t *testing.T) {
+	for _, query := range []string{"AND foo", "foo AND", "foo NOT", "a AND b OR c", "a OR OR b"} {
+		_, _, err := ParseBooleanQuery(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestSearchNotesBoolean(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	captureNoteEvents(t)
+	s, err := New(&Config{}, nil, nil, nil)
+	require.NoError(t, err)
+	ws := &coreworkspace.WorkspaceNode{Name: "ws", Path: t.TempDir()}
+	ctx := &WorkspaceContext{NotebookContextWorkspace: ws, CurrentWorkspace: ws}
+
+	for title, body := range map[string]string{
+		"Alpha": "kubernetes deployment\n",
+		"Beta":  "kubernetes only\n",
+		"Gamma": "deployment zebra\n",
+	} {
+		_, err := s.CreateNote(ctx, "inbox", title, WithBody(body), WithoutEditor())
+		require.NoError(t, err)
+	}
+	titles := func(q BooleanQuery) []string {
+		t.Helper()
+		notes, err := s.SearchNotesBoolean(ctx, q)
+		require.NoError(t, err)
+		var got []string
+		for _, note := range notes {
+			got = append(got, note.Title)
+		}
+		return got
+	}
+
+	assert.Equal(t, []string{"Alpha"}, titles(BooleanQuery{All: []string{"kubernetes", "deployment"}}))
+	assert.ElementsMatch(t, []string{"Alpha", "Beta"},
+		titles(BooleanQuery{Any: []string{"kubernetes", "deployment"}, None: []string{"zebra"}}))
+	assert.Equal(t, []string{"Beta"}, titles(BooleanQuery{All: []string{"kubernetes"}, Any: []string{"only", "zebra"}}))
+	assert.Equal(t, []string{"Gamma"}, titles(BooleanQuery{None: []string{"kubernetes"}}), "NOT alone excludes from every note")
+
+	_, err = s.SearchNotesBoolean(ctx, BooleanQuery{})
+	assert.Error(t, err, "an empty query is rejected")
+}
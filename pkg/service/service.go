@@ -1,8 +1,11 @@
 package service
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -37,7 +40,20 @@ func (s *Service) CreateNoteWithContent(
 	title string,
 	fm *frontmatter.Frontmatter,
 	body string,
+	options ...CreateOption,
 ) (*models.Note, error) {
+	opts := &createOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	now := time.Now()
+	if !opts.createdAt.IsZero() {
+		now = opts.createdAt
+		fm.Created = frontmatter.FormatTimestamp(now)
+		fm.Modified = fm.Created
+	}
+
 	// 1. Ensure directory exists
 	noteDir, err := s.getNotePathForContext(ctx, string(noteType))
 	if err != nil {
@@ -48,11 +64,32 @@ func (s *Service) CreateNoteWithContent(
 	}
 
 	// 2. Generate filename from title
-	filename := GenerateFilename(title)
+	filename := generateFilenameAt(now, title)
 	notePath := filepath.Join(noteDir, filename)
 
 	// 3. Build complete content with frontmatter + body
 	content := frontmatter.BuildContent(fm, body)
+	if err := s.runPreCreateHook(opts, hookNote{
+		path:      notePath,
+		title:     title,
+		noteType:  noteType,
+		workspace: ctx.NotebookContextWorkspace.Name,
+		branch:    ctx.Branch,
+		id:        fm.ID,
+		tags:      fm.Tags,
+	}); err != nil {
+		return nil, err
+	}
+
+	// The lock is taken after the pre-create hook, as CreateNote does, so a
+	// hook running nb doesn't wait on it.
+	if !opts.notebookLocked {
+		unlock, err := s.LockNotebookFor(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
 
 	// 4. Write file to disk
 	if err := os.WriteFile(notePath, []byte(content), 0o644); err != nil {
@@ -60,12 +97,7 @@ func (s *Service) CreateNoteWithContent(
 	}
 
 	// 5. Set file modification time to match frontmatter if specified
-	if fm.Modified != "" {
-		if modTime, err := frontmatter.ParseTimestamp(fm.Modified); err == nil {
-			// Use the same time for both atime and mtime
-			_ = os.Chtimes(notePath, modTime, modTime)
-		}
-	}
+	applyFrontmatterModTime(notePath, fm)
 
 	// 6. Parse the note and return it
 	note, err := ParseNote(notePath)
@@ -73,6 +105,7 @@ func (s *Service) CreateNoteWithContent(
 		return nil, fmt.Errorf("parse created note: %w", err)
 	}
 
+	s.opLog("create", notePath, ctx.NotebookContextWorkspace.Name).Info("Created note")
 	EmitNoteEvent(coremodels.NoteEvent{
 		Event:     coremodels.NoteEventCreated,
 		Workspace: ctx.NotebookContextWorkspace.Name,
@@ -80,16 +113,57 @@ func (s *Service) CreateNoteWithContent(
 		Path:      notePath,
 	})
 
+	s.startPostCreateHook(opts, newHookNote(note, ctx.NotebookContextWorkspace.Name, ctx.Branch, noteType))
 	return note, nil
 }
 
+// applyCreatedAt sets the file's atime and mtime to t as written in the
+// frontmatter, for notes created with WithCreatedAt. Like
+// applyFrontmatterModTime it round-trips through the timestamp format so the
+// two agree to the second.
+func applyCreatedAt(notePath string, t time.Time) {
+	if written, err := frontmatter.ParseTimestamp(frontmatter.FormatTimestamp(t)); err == nil {
+		t = written
+	}
+	_ = os.Chtimes(notePath, t, t)
+}
+
+// applyFrontmatterModTime sets the file's atime and mtime to fm.Modified so the
+// filesystem and the frontmatter agree. The value goes through ParseTimestamp,
+// the same parser ParseNote uses, so a timezone-less timestamp is read in the
+// configured location on both sides instead of drifting by the UTC offset.
+func applyFrontmatterModTime(notePath string, fm *frontmatter.Frontmatter) {
+	if fm == nil || fm.Modified == "" {
+		return
+	}
+	if modTime, err := frontmatter.ParseTimestamp(fm.Modified); err == nil {
+		_ = os.Chtimes(notePath, modTime, modTime)
+	}
+}
+
 // UpdateNoteWithContent updates an existing note's content programmatically.
 // This is used by the sync system to update notes when remote items change.
+// When the new content equals what is on disk the note is left alone: no
+// write, no mtime change and no update event. It takes the notebook lock
+// unless the caller passes WithNotebookLocked.
 func (s *Service) UpdateNoteWithContent(
 	notePath string,
 	fm *frontmatter.Frontmatter,
 	body string,
+	options ...CreateOption,
 ) error {
+	opts := &createOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+	if !opts.notebookLocked {
+		unlock, err := s.LockNotebook(notePath)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
 	// 1. Get original file info to preserve permissions
 	info, err := os.Stat(notePath)
 	if err != nil {
@@ -100,17 +174,16 @@ func (s *Service) UpdateNoteWithContent(
 	content := frontmatter.BuildContent(fm, body)
 
 	// 3. Write back to disk
-	if err := os.WriteFile(notePath, []byte(content), info.Mode()); err != nil {
+	changed, err := writeFileIfChanged(notePath, []byte(content), info.Mode())
+	if err != nil {
 		return fmt.Errorf("write updated note: %w", err)
 	}
+	if !changed {
+		return nil
+	}
 
 	// 4. Set file modification time to match frontmatter if specified
-	if fm.Modified != "" {
-		if modTime, err := frontmatter.ParseTimestamp(fm.Modified); err == nil {
-			// Use the same time for both atime and mtime
-			_ = os.Chtimes(notePath, modTime, modTime)
-		}
-	}
+	applyFrontmatterModTime(notePath, fm)
 
 	ws, _, noteType := GetNoteMetadata(notePath)
 	EmitNoteEvent(coremodels.NoteEvent{
@@ -125,13 +198,20 @@ func (s *Service) UpdateNoteWithContent(
 
 // DeleteNotes removes note files from the filesystem.
 func (s *Service) DeleteNotes(paths []string) error {
+	unlock, err := s.LockNotebook(paths...)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	var errs []string
 	for _, path := range paths {
 		ws, _, noteType := GetNoteMetadata(path)
 		if err := os.Remove(path); err != nil {
-			s.Logger.WithError(err).WithField("path", path).Error("Failed to delete note")
+			s.opLog("delete", path, ws).WithError(err).Error("Failed to delete note")
 			errs = append(errs, fmt.Sprintf("failed to delete %s: %v", path, err))
 		} else {
+			s.opLog("delete", path, ws).Info("Deleted note")
 			EmitNoteEvent(coremodels.NoteEvent{
 				Event:     coremodels.NoteEventDeleted,
 				Workspace: ws,
@@ -158,8 +238,18 @@ func (s *Service) CopyNotes(sourcePaths []string, destWorkspace *coreworkspace.W
 
 // transferNotes is a helper for moving or copying notes.
 func (s *Service) transferNotes(sourcePaths []string, destWorkspace *coreworkspace.WorkspaceNode, destGroup, mode string) ([]string, error) {
+	lockPaths := append([]string{}, sourcePaths...)
+	if destDir, err := s.notebookLocator.GetGroupDir(destWorkspace, destGroup); err == nil {
+		lockPaths = append(lockPaths, destDir)
+	}
+	unlock, err := s.LockNotebook(lockPaths...)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
 	s.Logger.WithFields(logrus.Fields{
-		"operation":             mode,
+		LogFieldOperation:       mode,
 		"count":                 len(sourcePaths),
 		"destination_workspace": destWorkspace.Name,
 		"destination_group":     destGroup,
@@ -216,21 +306,17 @@ func (s *Service) transferNotes(sourcePaths []string, destWorkspace *coreworkspa
 		// Update frontmatter to match the new location
 		if updateErr := s.updateNoteFrontmatter(destPath, destWorkspace, destGroup, isCopyToSameLocation); updateErr != nil {
 			// Log warning but don't fail the operation
-			s.Logger.WithError(updateErr).WithField("path", destPath).Warn("Failed to update frontmatter")
+			s.opLog(mode, destPath, destWorkspace.Name).WithError(updateErr).Warn("Failed to update frontmatter")
 		}
 
 		// If pasted into a plan, add necessary flow job metadata
 		if strings.HasPrefix(destGroup, "plans/") {
 			if updateErr := s.addFlowJobMetadata(destPath, destDir, isCopyToSameLocation); updateErr != nil {
-				s.Logger.WithError(updateErr).WithField("path", destPath).Warn("Failed to add flow job metadata")
+				s.opLog(mode, destPath, destWorkspace.Name).WithError(updateErr).Warn("Failed to add flow job metadata")
 			}
 		}
 
-		s.Logger.WithFields(logrus.Fields{
-			"source_path": sourcePath,
-			"dest_path":   destPath,
-			"operation":   mode,
-		}).Debug("Successfully transferred note")
+		s.opLog(mode, destPath, destWorkspace.Name).WithField("source_path", sourcePath).Info("Transferred note")
 
 		srcWs, _, srcType := GetNoteMetadata(sourcePath)
 		eventType := coremodels.NoteEventMoved
@@ -258,13 +344,11 @@ func (s *Service) transferNotes(sourcePaths []string, destWorkspace *coreworkspa
 
 // updateNoteFrontmatter updates frontmatter fields to match the new location
 func (s *Service) updateNoteFrontmatter(notePath string, destWorkspace *coreworkspace.WorkspaceNode, newType string, isCopyToSameLocation bool) error {
-	content, err := os.ReadFile(notePath)
-	if err != nil {
+	note, fm, body, err := s.ReadNote(notePath)
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
 		return fmt.Errorf("read note: %w", err)
 	}
-
-	contentStr := string(content)
-	fm, body, err := frontmatter.Parse(contentStr)
 	if err != nil || fm == nil {
 		// No frontmatter or parsing error - skip update
 		return nil
@@ -344,7 +428,10 @@ func (s *Service) updateNoteFrontmatter(notePath string, destWorkspace *corework
 	// Rebuild content with updated frontmatter
 	updatedContent := frontmatter.BuildContent(fm, body)
 
-	// Write back to file
+	// Write back to file, unless nothing changed
+	if updatedContent == note.Content {
+		return nil
+	}
 	if err := os.WriteFile(notePath, []byte(updatedContent), 0o644); err != nil {
 		return fmt.Errorf("write note: %w", err)
 	}
@@ -360,6 +447,8 @@ type Service struct {
 	CoreConfig        *coreconfig.Config
 	Logger            *logrus.Entry
 	NoteTypes         map[string]*coreconfig.NoteTypeConfig
+	statCache         statCache
+	notebookLocks     notebookLocks
 }
 
 // Config holds service configuration
@@ -368,6 +457,69 @@ type Config struct {
 	Editor      string
 	Templates   map[string]string
 	DefaultType models.NoteType
+
+	// GroupTemplates maps group paths to template files; see ResolveTemplate.
+	GroupTemplates map[string]string
+
+	// FollowSymlinks makes notebook walks descend into symlinked directories.
+	// Off by default; see walkNotebook for cycle handling.
+	FollowSymlinks bool
+
+	// RelatedMinScore is the minimum tag similarity for GetRelatedNotes.
+	// Nil means DefaultRelatedMinScore.
+	RelatedMinScore *float64
+
+	// Flags is the note flag palette; see Service.Flags. Empty means
+	// DefaultFlags.
+	Flags []FlagConfig
+
+	// TimestampFormat is the Go layout for frontmatter timestamps and
+	// TimestampLocation the zone they are written in. Empty/nil mean RFC3339
+	// in UTC. See frontmatter.SetTimestampFormat.
+	TimestampFormat   string
+	TimestampLocation *time.Location
+	// DisplayLocation is the zone times are shown in: TimestampLocation when
+	// timestamp_timezone is set, nil (the system zone) otherwise.
+	DisplayLocation *time.Location
+
+	// Hooks are shell commands run before and after a note is created.
+	Hooks HooksConfig
+
+	// CalDAV is where ExportToCalDAV sends daily notes by default.
+	CalDAV CalDAVConfig
+
+	// PlansAsGroup turns off the TUI's grove-flow plan handling: plans/ is
+	// rendered like any nested group, plan statuses are not read and notes are
+	// not linked to plans by plan_ref. Off by default.
+	PlansAsGroup bool
+
+	// ShowUnfiled makes ListAllItems also list the loose Markdown files in
+	// the notebook root under an "unfiled" group. Off by default.
+	ShowUnfiled bool
+
+	// ShowTodaySection makes the TUI start with the pinned "Today" section,
+	// the notes created or modified today, above the tree. Off by default.
+	ShowTodaySection bool
+
+	// DefaultWorkspace names the workspace GetWorkspaceContext falls back to
+	// outside any workspace. Empty means the global workspace.
+	DefaultWorkspace string
+
+	// CreatedFromGit adds git history to the fallback chain of
+	// NoteCreatedAt. Off by default.
+	CreatedFromGit bool
+
+	// MaxParseSize is the file size above which notes are not parsed; see
+	// SetMaxParseSize. 0 means DefaultMaxParseSize, negative no limit.
+	MaxParseSize int64
+
+	// ConfirmThreshold is the number of notes above which the TUI asks for
+	// the count to be typed before archiving or deleting. 0 never asks.
+	ConfirmThreshold int
+
+	// SkipSingleConfirm makes the TUI archive or delete a single note
+	// without a confirmation (confirm_single: false).
+	SkipSingleConfirm bool
 }
 
 // New creates a new note service
@@ -424,6 +576,19 @@ func New(config *Config, provider *coreworkspace.Provider, coreCfg *coreconfig.C
 		}
 	}
 
+	if config != nil {
+		frontmatter.SetTimestampFormat(config.TimestampFormat, config.TimestampLocation)
+		frontmatter.SetDisplayLocation(config.DisplayLocation)
+		SetCreatedFromGit(config.CreatedFromGit)
+		SetMaxParseSize(config.MaxParseSize)
+	}
+
+	if logger == nil {
+		discard := logrus.New()
+		discard.SetOutput(io.Discard)
+		logger = logrus.NewEntry(discard)
+	}
+
 	return &Service{
 		workspaceProvider: provider,
 		notebookLocator:   notebookLocator,
@@ -512,17 +677,22 @@ func (s *Service) CreateNote(ctx *WorkspaceContext, noteType models.NoteType, ti
 		return nil, fmt.Errorf("ensure directories: %w", err)
 	}
 
+	now := time.Now()
+	if !opts.createdAt.IsZero() {
+		now = opts.createdAt
+	}
+
 	// Generate filename
 	var filename string
 	if noteType == "quick" {
-		filename = time.Now().Format("150405") + "-quick.md"
+		filename = now.Format("150405") + "-quick.md"
 	} else if noteType == "daily" {
-		filename = time.Now().Format("20060102") + "-daily.md"
+		filename = now.Format("20060102") + "-daily.md"
 		if title == "" {
-			title = "Daily Note: " + time.Now().Format("2006-01-02")
+			title = "Daily Note: " + now.Format("2006-01-02")
 		}
 	} else {
-		filename = GenerateFilename(title)
+		filename = generateFilenameAt(now, title)
 	}
 	notePath := filepath.Join(noteDir, filename)
 
@@ -530,15 +700,15 @@ func (s *Service) CreateNote(ctx *WorkspaceContext, noteType models.NoteType, ti
 	template := s.Config.Templates[string(noteType)]
 
 	// Look up user-defined note type configuration from core config
-	var noteTypeConfig *coreconfig.NoteTypeConfig
-	if s.CoreConfig != nil && s.CoreConfig.Notebooks != nil && s.CoreConfig.Notebooks.Definitions != nil {
-		// Try to get default notebook name from rules, otherwise fall back to "default"
-		defaultNotebookName := "default"
-		if s.CoreConfig.Notebooks.Rules != nil && s.CoreConfig.Notebooks.Rules.Default != "" {
-			defaultNotebookName = s.CoreConfig.Notebooks.Rules.Default
-		}
-		if notebook, ok := s.CoreConfig.Notebooks.Definitions[defaultNotebookName]; ok && notebook.Types != nil {
-			noteTypeConfig = notebook.Types[string(noteType)]
+	noteTypeConfig := s.noteTypeConfig(string(noteType))
+
+	// A group template (see ResolveTemplate) beats the type's.
+	if res, err := s.ResolveTemplate(currentContext, string(noteType)); err == nil && res.Source == TemplateSourceGroup {
+		if data, err := os.ReadFile(res.Path); err == nil {
+			template, noteTypeConfig = string(data), nil
+		} else {
+			s.opLog("create", notePath, currentContext.NotebookContextWorkspace.Name).WithError(err).
+				WithField("template", res.Path).Warn("Failed to read group template; using the type template")
 		}
 	}
 
@@ -548,10 +718,40 @@ func (s *Service) CreateNote(ctx *WorkspaceContext, noteType models.NoteType, ti
 		worktreeName = currentContext.CurrentWorkspace.GetWorktreeName()
 	}
 
-	content := CreateNoteContent(noteType, title, currentContext.NotebookContextWorkspace.Name, currentContext.Branch, worktreeName, currentContext.CurrentWorkspace.Name, template, noteTypeConfig)
+	content := createNoteContentAt(now, noteType, title, currentContext.NotebookContextWorkspace.Name, currentContext.Branch, worktreeName, currentContext.CurrentWorkspace.Name, template, noteTypeConfig)
+	if opts.body != "" {
+		content += "\n" + opts.body
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+	}
 
-	// Write file
-	if err := os.WriteFile(notePath, []byte(content), 0o644); err != nil {
+	pending := hookNote{
+		path:      notePath,
+		title:     title,
+		noteType:  noteType,
+		workspace: currentContext.NotebookContextWorkspace.Name,
+		branch:    currentContext.Branch,
+	}
+	if fm, _, err := frontmatter.Parse(content); err == nil && fm != nil {
+		pending.id, pending.tags = fm.ID, fm.Tags
+	}
+	if err := s.runPreCreateHook(opts, pending); err != nil {
+		return nil, err
+	}
+
+	// Write file, holding the notebook lock only for the write: the editor
+	// may stay open for a long time.
+	unlock, err := s.LockNotebook(notePath)
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(notePath, []byte(content), 0o644)
+	if err == nil && !opts.createdAt.IsZero() {
+		applyCreatedAt(notePath, now)
+	}
+	unlock()
+	if err != nil {
 		return nil, fmt.Errorf("write note: %w", err)
 	}
 
@@ -573,13 +773,19 @@ func (s *Service) CreateNote(ctx *WorkspaceContext, noteType models.NoteType, ti
 		Path:      notePath,
 	})
 
+	s.opLog("create", notePath, currentContext.NotebookContextWorkspace.Name).Info("Created note")
+
 	// Open in editor if requested
 	if opts.openEditor && s.Config.Editor != "" {
 		if err := s.openInEditor(notePath); err != nil {
-			s.Logger.WithError(err).Warn("Failed to open editor")
+			s.opLog("create", notePath, currentContext.NotebookContextWorkspace.Name).WithError(err).Warn("Failed to open editor")
 		}
+	} else if opts.openEditor {
+		s.opLog("create", notePath, currentContext.NotebookContextWorkspace.Name).Warn("No editor configured; not opening note")
 	}
 
+	// Run after the editor so the hook sees what was written.
+	s.startPostCreateHook(opts, newHookNote(note, note.Workspace, note.Branch, noteType))
 	return note, nil
 }
 
@@ -1143,6 +1349,29 @@ func (s *Service) addToManifestList(manifestPath, fieldName, value string) error
 
 // SearchNotes searches for notes matching the query using filesystem tools.
 func (s *Service) SearchNotes(ctx *WorkspaceContext, query string, options ...SearchOption) ([]*models.Note, error) {
+	opts, scope, err := newSearchOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.searchCandidates(ctx, query, scope, opts)
+	if err != nil {
+		return nil, err
+	}
+	results := filterSearchResults(candidates, opts)
+
+	s.Logger.WithFields(logrus.Fields{
+		"query":         query,
+		"scope":         scope,
+		"results_count": len(results),
+	}).Debug("Search completed")
+
+	return results, nil
+}
+
+// newSearchOptions applies options over the defaults and validates the
+// search scope, which it returns.
+func newSearchOptions(options []SearchOption) (*searchOptions, string, error) {
 	opts := &searchOptions{
 		limit: 50,
 	}
@@ -1150,6 +1379,123 @@ func (s *Service) SearchNotes(ctx *WorkspaceContext, query string, options ...Se
 		opt(opts)
 	}
 
+	scope := opts.in
+	if scope == "" {
+		scope = SearchInBody
+	}
+	switch scope {
+	case SearchInTitle, SearchInBody, SearchInAll:
+	default:
+		return nil, "", fmt.Errorf("invalid search scope %q (expected title, body or all)", opts.in)
+	}
+	return opts, scope, nil
+}
+
+// searchCandidates returns the notes matching query in scope, before the
+// type, tag and limit filters.
+func (s *Service) searchCandidates(ctx *WorkspaceContext, query, scope string, opts *searchOptions) ([]*models.Note, error) {
+	var candidates []*models.Note
+	if scope != SearchInTitle {
+		found, err := s.searchNoteContent(ctx, query, opts)
+		if err != nil {
+			return nil, err
+		}
+		candidates = found
+	}
+	if scope != SearchInBody {
+		found, err := s.searchNoteTitles(ctx, query, opts)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[string]bool, len(candidates))
+		for _, note := range candidates {
+			seen[note.Path] = true
+		}
+		for _, note := range found {
+			if !seen[note.Path] {
+				candidates = append(candidates, note)
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// filterSearchResults applies the type, tag and flag filters and the limit to the
+// search candidates.
+func filterSearchResults(candidates []*models.Note, opts *searchOptions) []*models.Note {
+	var results []*models.Note
+	for _, note := range candidates {
+		if opts.noteType != "" && note.Type != opts.noteType {
+			continue
+		}
+		if len(opts.tags) > 0 && !matchesTags(note.Tags, opts.tags, opts.anyTag) {
+			continue
+		}
+		if opts.flag != "" && !strings.EqualFold(NoteFlag(note), opts.flag) {
+			continue
+		}
+		results = append(results, note)
+	}
+
+	if len(results) > opts.limit {
+		results = results[:opts.limit]
+	}
+	return results
+}
+
+// matchesTags reports whether noteTags contains every tag in want, or at
+// least one of them when any is true. Tags are compared normalized and
+// case-insensitively, so "#Bug" matches "bug".
+func matchesTags(noteTags, want []string, any bool) bool {
+	have := make(map[string]bool, len(noteTags))
+	for _, tag := range noteTags {
+		have[strings.ToLower(NormalizeTag(tag))] = true
+	}
+	for _, tag := range want {
+		found := have[strings.ToLower(NormalizeTag(tag))]
+		if any && found {
+			return true
+		}
+		if !any && !found {
+			return false
+		}
+	}
+	return !any
+}
+
+// searchNoteTitles returns the notes whose filename or frontmatter title
+// contains query, case-insensitively.
+func (s *Service) searchNoteTitles(ctx *WorkspaceContext, query string, opts *searchOptions) ([]*models.Note, error) {
+	var notes []*models.Note
+	var err error
+	if opts.allWorkspaces {
+		notes, err = s.ListNotesFromAllWorkspaces(false, false)
+	} else {
+		notes, err = s.ListAllNotes(ctx, false, false)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list notes for title search: %w", err)
+	}
+	return FilterNotesByTitle(notes, query), nil
+}
+
+// FilterNotesByTitle returns the notes whose filename or frontmatter title
+// contains query, case-insensitively.
+func FilterNotesByTitle(notes []*models.Note, query string) []*models.Note {
+	needle := strings.ToLower(query)
+	var matches []*models.Note
+	for _, note := range notes {
+		if strings.Contains(strings.ToLower(note.Title), needle) ||
+			strings.Contains(strings.ToLower(note.FrontmatterTitle), needle) {
+			matches = append(matches, note)
+		}
+	}
+	return matches
+}
+
+// searchNoteContent runs a full-text search with ripgrep (falling back to
+// grep) and parses the matching notes.
+func (s *Service) searchNoteContent(ctx *WorkspaceContext, query string, opts *searchOptions) ([]*models.Note, error) {
 	// 1. Determine directories to search
 	var searchDirs []string
 	uniqueDirs := make(map[string]bool)
@@ -1198,6 +1544,7 @@ func (s *Service) SearchNotes(ctx *WorkspaceContext, query string, options ...Se
 		}).Debug("Executing search command")
 	} else {
 		// Fallback to grep
+		s.opLog("search", "", "").Warn("rg not found in PATH; falling back to grep")
 		grepPath, err := exec.LookPath("grep")
 		if err != nil {
 			return nil, fmt.Errorf("neither 'rg' nor 'grep' found in PATH")
@@ -1212,16 +1559,17 @@ func (s *Service) SearchNotes(ctx *WorkspaceContext, query string, options ...Se
 		}).Debug("Executing search command")
 	}
 
-	output, err := cmd.Output()
+	output, warnings, err := RunSearchCommand(cmd)
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			// grep and rg exit with 1 if no matches are found, which is not an error for us.
-			if exitErr.ExitCode() != 1 {
-				return nil, fmt.Errorf("search command failed: %w, stderr: %s", err, exitErr.Stderr)
-			}
-		} else {
-			return nil, fmt.Errorf("search command failed: %w", err)
+		return nil, err
+	}
+	if len(warnings) > 0 {
+		s.opLog("search", "", "").WithField("warnings", len(warnings)).
+			Warn("Some files could not be searched; results may be incomplete")
+		for _, warning := range warnings {
+			s.Logger.WithField("warning", warning).Debug("Search warning")
 		}
+		opts.countWarnings(warnings)
 	}
 
 	// 3. Parse results
@@ -1240,24 +1588,9 @@ func (s *Service) SearchNotes(ctx *WorkspaceContext, query string, options ...Se
 			continue
 		}
 
-		// 4. In-memory filtering by type
-		if opts.noteType != "" && note.Type != opts.noteType {
-			continue
-		}
-
 		results = append(results, note)
 	}
 
-	// 5. Apply limit
-	if len(results) > opts.limit {
-		results = results[:opts.limit]
-	}
-
-	s.Logger.WithFields(logrus.Fields{
-		"query":         query,
-		"results_count": len(results),
-	}).Debug("Search completed")
-
 	return results, nil
 }
 
@@ -1269,7 +1602,7 @@ func (s *Service) ListNotes(ctx *WorkspaceContext, noteType models.NoteType) ([]
 	}
 
 	var notes []*models.Note
-	err = filepath.Walk(notePath, func(path string, info os.FileInfo, err error) error {
+	err = s.walkNotebook(notePath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return nil // Skip errors
 		}
@@ -1307,7 +1640,7 @@ func (s *Service) ListAllNotes(ctx *WorkspaceContext, includeArchived bool, incl
 			continue
 		}
 
-		_ = filepath.Walk(contentDir.Path, func(path string, info os.FileInfo, err error) error {
+		_ = s.walkNotebook(contentDir.Path, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return nil // Skip errors
 			}
@@ -1461,7 +1794,7 @@ func (s *Service) ListAllItems(ctx *WorkspaceContext, includeArchived bool, incl
 			continue
 		}
 
-		_ = filepath.Walk(contentDir.Path, func(path string, info os.FileInfo, err error) error {
+		_ = s.walkNotebook(contentDir.Path, func(path string, info os.FileInfo, err error) error {
 			if err != nil {
 				return nil // Skip errors
 			}
@@ -1556,6 +1889,14 @@ func (s *Service) ListAllItems(ctx *WorkspaceContext, includeArchived bool, incl
 		})
 	}
 
+	if s.Config != nil && s.Config.ShowUnfiled {
+		unfiled, err := s.ListUnfiledItems(ctx)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, unfiled...)
+	}
+
 	return items, nil
 }
 
@@ -1588,7 +1929,13 @@ func (s *Service) ListGlobalNotes(noteType models.NoteType) ([]*models.Note, err
 
 // ArchiveNotes moves notes to a .archive subdirectory within their current directory.
 func (s *Service) ArchiveNotes(ctx *WorkspaceContext, paths []string) error {
-	s.Logger.WithField("count", len(paths)).Info("Archiving notes")
+	unlock, err := s.LockNotebook(paths...)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s.opLog("archive", "", ctx.NotebookContextWorkspace.Name).WithField("count", len(paths)).Info("Archiving notes")
 	for _, path := range paths {
 		// 1. Get the parent directory of the note file.
 		noteDir := filepath.Dir(path)
@@ -1617,13 +1964,10 @@ func (s *Service) ArchiveNotes(ctx *WorkspaceContext, paths []string) error {
 
 		// 6. Move the note.
 		if err := os.Rename(path, dest); err != nil {
-			s.Logger.WithError(err).WithField("path", path).Error("Failed to move note to archive")
+			s.opLog("archive", path, "").WithError(err).Error("Failed to move note to archive")
 			return fmt.Errorf("failed to move %s to archive: %w", path, err)
 		}
-		s.Logger.WithFields(logrus.Fields{
-			"source_path":  path,
-			"archive_path": dest,
-		}).Debug("Archived note")
+		s.opLog("archive", path, "").WithField("archive_path", dest).Info("Archived note")
 
 		// Typed archive event: Path is the new .archive location, PrevPath the
 		// original. The daemon needs both to treat the archive as a first-class
@@ -1670,6 +2014,13 @@ func (s *Service) GetWorkspaceContext(startPath string) (*WorkspaceContext, erro
 		}
 	} else {
 		CWD = startPath
+		// -W also accepts a workspace name (as offered by shell completion)
+		// when no such path exists.
+		if _, statErr := os.Stat(startPath); os.IsNotExist(statErr) && s.workspaceProvider != nil {
+			if ws := s.workspaceProvider.FindByName(startPath); ws != nil {
+				CWD = ws.Path
+			}
+		}
 	}
 
 	currentWorkspace, err := coreworkspace.GetProjectByPath(CWD)
@@ -1678,6 +2029,8 @@ func (s *Service) GetWorkspaceContext(startPath string) (*WorkspaceContext, erro
 		// Pattern: <notebooks_root>/nb/workspaces/<workspace_name>/...
 		if ws := s.extractWorkspaceFromNotebooksPath(CWD); ws != nil {
 			currentWorkspace = ws
+		} else if ws := s.defaultWorkspace(); ws != nil {
+			currentWorkspace = ws
 		} else {
 			// Fallback to global context if not in a known workspace
 			return s.GetWorkspaceContext("global")
@@ -1726,6 +2079,34 @@ func (s *Service) GetWorkspaceContext(startPath string) (*WorkspaceContext, erro
 	return ctx, nil
 }
 
+// defaultWorkspace returns the configured DefaultWorkspace, looked up by name,
+// for GetWorkspaceContext to fall back to outside any workspace. It returns
+// nil, meaning the global context, when none is configured, it is "global",
+// or no workspace has that name.
+func (s *Service) defaultWorkspace() *coreworkspace.WorkspaceNode {
+	name := ""
+	if s.Config != nil {
+		name = s.Config.DefaultWorkspace
+	}
+	if name == "" || name == globalWorkspace {
+		s.Logger.Debug("Not in a workspace; using the global workspace")
+		return nil
+	}
+	var ws *coreworkspace.WorkspaceNode
+	if s.workspaceProvider != nil {
+		ws = s.workspaceProvider.FindByName(name)
+	}
+	if ws == nil {
+		s.Logger.WithField("default_workspace", name).Warn("Default workspace not found; using the global workspace")
+		return nil
+	}
+	s.Logger.WithFields(logrus.Fields{
+		"default_workspace": ws.Name,
+		"path":              ws.Path,
+	}).Debug("Not in a workspace; using the default workspace")
+	return ws
+}
+
 // findNotebookContextNode determines the logical owner of the notebook directory.
 // The notebook context is always the repository (project) that manages the git history.
 // For ecosystem worktree subprojects, this means finding the corresponding subproject in the main ecosystem.
@@ -1901,7 +2282,13 @@ func (s *Service) buildPathsMap(notebookContext *coreworkspace.WorkspaceNode) (m
 
 // getNotePathForContext is a convenience wrapper that uses the NotebookLocator.
 func (s *Service) getNotePathForContext(ctx *WorkspaceContext, noteType string) (string, error) {
-	return s.notebookLocator.GetNotesDir(ctx.NotebookContextWorkspace, noteType)
+	dir, err := s.notebookLocator.GetNotesDir(ctx.NotebookContextWorkspace, noteType)
+	if err == nil {
+		s.opLog("resolve_path", "", ctx.NotebookContextWorkspace.Name).
+			WithFields(logrus.Fields{"note_type": noteType, "dir": dir}).
+			Debug("Resolved notes directory")
+	}
+	return dir, err
 }
 
 // NoteTypeDir returns the directory that backs a note type in the given
@@ -1912,24 +2299,6 @@ func (s *Service) NoteTypeDir(ctx *WorkspaceContext, noteType models.NoteType) (
 	return s.getNotePathForContext(ctx, string(noteType))
 }
 
-// openInEditor opens a file in the configured editor
-func (s *Service) openInEditor(path string) error {
-	editor := s.Config.Editor
-	if editor == "" {
-		editor = os.Getenv("EDITOR")
-	}
-	if editor == "" {
-		editor = "vim" // fallback
-	}
-
-	cmd := exec.Command(editor, path)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	return cmd.Run()
-}
-
 // UpdateNoteContent updates the content of an existing note
 func (s *Service) UpdateNoteContent(path string, content string) error {
 	// Write the new content
@@ -2006,6 +2375,11 @@ type createOptions struct {
 	openEditor bool
 	useGlobal  bool
 	conceptID  string
+	body       string
+	createdAt  time.Time
+	skipHooks  bool
+	// notebookLocked is set when the caller holds the notebook lock.
+	notebookLocked bool
 }
 
 type CreateOption func(*createOptions)
@@ -2022,6 +2396,22 @@ func InGlobalWorkspace() CreateOption {
 	}
 }
 
+// WithBody appends body after the generated frontmatter and template content.
+func WithBody(body string) CreateOption {
+	return func(o *createOptions) {
+		o.body = body
+	}
+}
+
+// WithCreatedAt dates a new note at t instead of now, e.g. when importing
+// historical notes. It sets the frontmatter created (and modified) timestamps,
+// the date in the generated filename and id, and the file's mtime.
+func WithCreatedAt(t time.Time) CreateOption {
+	return func(o *createOptions) {
+		o.createdAt = t
+	}
+}
+
 // WithConceptID overrides the concept directory id derived from the title.
 func WithConceptID(id string) CreateOption {
 	return func(o *createOptions) {
@@ -2029,10 +2419,53 @@ func WithConceptID(id string) CreateOption {
 	}
 }
 
+// WithNotebookLocked tells CreateNoteWithContent and UpdateNoteWithContent
+// that the caller already holds the notebook lock (see LockNotebookFor), as a
+// sync does for its whole run. The lock doesn't nest, so taking it again would wait forever.
+func WithNotebookLocked() CreateOption {
+	return func(o *createOptions) {
+		o.notebookLocked = true
+	}
+}
+
+// Search scopes for SearchIn.
+const (
+	SearchInTitle = "title"
+	SearchInBody  = "body"
+	SearchInAll   = "all"
+)
+
 type searchOptions struct {
 	allWorkspaces bool
 	noteType      models.NoteType
 	limit         int
+	in            string
+	tags          []string
+	anyTag        bool
+	flag          string
+	warnings      *int
+	// seenWarnings holds the warnings already counted, as a boolean search
+	// runs once per term over the same files.
+	seenWarnings map[string]struct{}
+}
+
+// countWarnings adds the warnings not counted before to the WithWarningCount
+// counter, so an unreadable file is counted once however many searches
+// reach it.
+func (o *searchOptions) countWarnings(warnings []string) {
+	if o.warnings == nil {
+		return
+	}
+	if o.seenWarnings == nil {
+		o.seenWarnings = make(map[string]struct{})
+	}
+	for _, warning := range warnings {
+		if _, seen := o.seenWarnings[warning]; seen {
+			continue
+		}
+		o.seenWarnings[warning] = struct{}{}
+		*o.warnings++
+	}
 }
 
 type SearchOption func(*searchOptions)
@@ -2049,12 +2482,67 @@ func OfType(t models.NoteType) SearchOption {
 	}
 }
 
+// WithTag restricts SearchNotes to notes tagged tag. It can be given more
+// than once; a note must then carry every tag.
+func WithTag(tag string) SearchOption {
+	return func(o *searchOptions) {
+		o.tags = append(o.tags, tag)
+	}
+}
+
+// WithTags restricts SearchNotes to notes carrying all of tags, or at least
+// one of them when any is true.
+func WithTags(tags []string, any bool) SearchOption {
+	return func(o *searchOptions) {
+		o.tags = append(o.tags, tags...)
+		o.anyTag = any
+	}
+}
+
+// WithFlag restricts SearchNotes to notes carrying flag.
+func WithFlag(flag string) SearchOption {
+	return func(o *searchOptions) {
+		o.flag = flag
+	}
+}
+
+// WithWarningCount makes SearchNotes add to *count the number of files and
+// directories the full-text search could not read. Their matches are
+// missing from the results, which are otherwise complete.
+func WithWarningCount(count *int) SearchOption {
+	return func(o *searchOptions) {
+		o.warnings = count
+	}
+}
+
 func WithLimit(limit int) SearchOption {
 	return func(o *searchOptions) {
 		o.limit = limit
 	}
 }
 
+// SearchIn restricts what SearchNotes matches against: "title" (filename or
+// frontmatter title), "body" (full-text via ripgrep), or "all" (either). The
+// default is "body", the full-text search SearchNotes has always done.
+func SearchIn(scope string) SearchOption {
+	return func(o *searchOptions) {
+		o.in = scope
+	}
+}
+
+// writeFileIfChanged writes content to path unless the file already holds
+// exactly those bytes, so an update that changes nothing doesn't churn the
+// file's mtime or show up in git. It reports whether the file was written.
+func writeFileIfChanged(path string, content []byte, perm os.FileMode) (bool, error) {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+		return false, nil
+	}
+	if err := os.WriteFile(path, content, perm); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func copyFile(src, dst string) error {
 	source, err := os.Open(src)
 	if err != nil {
@@ -2106,7 +2594,7 @@ func (s *Service) ListAllNotesInWorkspace(ws *coreworkspace.WorkspaceNode) ([]*m
 	repoNotesRoot := filepath.Dir(filepath.Dir(samplePath))
 
 	var notes []*models.Note
-	err = filepath.Walk(repoNotesRoot, func(path string, info os.FileInfo, err error) error {
+	err = s.walkNotebook(repoNotesRoot, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return nil // Skip errors to continue walking
 		}
@@ -2238,8 +2726,8 @@ func (s *Service) addFlowJobMetadata(filePath, planDir string, isCopy bool) erro
 			return fmt.Errorf("write updated note with job metadata: %w", err)
 		}
 		s.Logger.WithFields(logrus.Fields{
-			"path":    filePath,
-			"updates": updates,
+			LogFieldNotePath: filePath,
+			"updates":        updates,
 		}).Debug("Added flow job metadata to pasted file")
 	}
 
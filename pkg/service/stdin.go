@@ -0,0 +1,28 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// CreateNoteFromStdin creates a note whose body is everything read from stdin.
+// The frontmatter is generated from title and noteType exactly as CreateNote
+// does, and no editor is opened.
+func (s *Service) CreateNoteFromStdin(ctx *WorkspaceContext, noteType models.NoteType, title string, options ...CreateOption) (*models.Note, error) {
+	return s.CreateNoteFromReader(ctx, noteType, title, os.Stdin, options...)
+}
+
+// CreateNoteFromReader is CreateNoteFromStdin for an arbitrary reader. It
+// reads r to EOF before writing anything, so slow pipes deliver all their
+// content first.
+func (s *Service) CreateNoteFromReader(ctx *WorkspaceContext, noteType models.NoteType, title string, r io.Reader, options ...CreateOption) (*models.Note, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read note body: %w", err)
+	}
+	options = append(options, WithBody(string(body)), WithoutEditor())
+	return s.CreateNote(ctx, noteType, title, options...)
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+func TestCreateNoteFromReader(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	captureNoteEvents(t)
+	s, err := New(&Config{}, nil, nil, nil)
+	require.NoError(t, err)
+	ws := &coreworkspace.WorkspaceNode{Name: "proj", Path: t.TempDir()}
+	ctx := &WorkspaceContext{NotebookContextWorkspace: ws, CurrentWorkspace: ws}
+
+	note, err := s.CreateNoteFromReader(ctx, "inbox", "Piped Note", strings.NewReader("line one\nline two"))
+	require.NoError(t, err)
+	assert.Equal(t, "inbox", string(note.Type))
+
+	raw, err := os.ReadFile(note.Path)
+	require.NoError(t, err)
+	fm, body, err := frontmatter.Parse(string(raw))
+	require.NoError(t, err)
+	require.NotNil(t, fm, "frontmatter is generated as for any new note")
+	assert.Equal(t, "Piped Note", fm.Title)
+	assert.True(t, strings.HasSuffix(body, "line one\nline two\n"), "the piped body follows the template, newline-terminated: %q", body)
+
+	_, err = s.CreateNoteFromReader(ctx, "inbox", "Broken Pipe", iotest.ErrReader(errors.New("pipe closed")))
+	assert.ErrorContains(t, err, "read note body")
+	entries, err := os.ReadDir(filepath.Dir(note.Path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "a failed read creates no note")
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// AddTag adds tag to the frontmatter tags of each note in paths. Notes that
+// already carry the tag are left untouched on disk. It returns the paths that
+// were rewritten; on error, the notes changed before it are returned too.
+func (s *Service) AddTag(paths []string, tag string) ([]string, error) {
+	return s.editTags(paths, tag, "tag-add", func(tags []string, tag string) []string {
+		return frontmatter.MergeTags(tags, []string{tag})
+	})
+}
+
+// RemoveTag removes tag from the frontmatter tags of each note in paths.
+// Notes without the tag are left untouched on disk. It returns the paths that
+// were rewritten.
+func (s *Service) RemoveTag(paths []string, tag string) ([]string, error) {
+	return s.editTags(paths, tag, "tag-remove", func(tags []string, tag string) []string {
+		kept := []string{}
+		for _, t := range tags {
+			if t != tag {
+				kept = append(kept, t)
+			}
+		}
+		return kept
+	})
+}
+
+// editTags normalizes tag, applies edit to the tags of each note and rewrites
+// the tags and modified fields of the notes whose tag list changed, leaving
+// the rest of their frontmatter as written.
+func (s *Service) editTags(paths []string, tag, operation string, edit func(tags []string, tag string) []string) ([]string, error) {
+	tag = NormalizeTag(tag)
+	if tag == "" {
+		return nil, fmt.Errorf("tag must not be empty")
+	}
+
+	unlock, err := s.LockNotebook(paths...)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var changed []string
+	for _, path := range paths {
+		_, fm, _, err := s.ReadNote(path)
+		if err != nil {
+			return changed, fmt.Errorf("read note %s: %w", path, err)
+		}
+		if fm == nil {
+			return changed, fmt.Errorf("%s has no frontmatter", path)
+		}
+
+		tags := edit(fm.Tags, tag)
+		if equalTags(tags, fm.Tags) {
+			continue
+		}
+		err = rewriteNote(path, func(content []byte) ([]byte, error) {
+			return updateFrontmatterFields(content, map[string]interface{}{
+				"tags":     flowSequence(tags),
+				"modified": frontmatter.FormatTimestamp(time.Now()),
+			})
+		})
+		if err != nil {
+			return changed, fmt.Errorf("write note: %w", err)
+		}
+		changed = append(changed, path)
+
+		ws, _, _ := GetNoteMetadata(path)
+		s.opLog(operation, path, ws).WithField("tag", tag).Debug("Updated note tags")
+	}
+	return changed, nil
+}
+
+// NormalizeTag trims whitespace and a leading '#' from a user-supplied tag, so
+// "#idea" and "idea" name the same tag.
+func NormalizeTag(tag string) string {
+	return strings.TrimPrefix(strings.TrimSpace(tag), "#")
+}
+
+func equalTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// FindNotesWithoutTags returns the notes in ctx that carry no tag, sorted by
+// path. Archived notes and artifacts are not included.
+func (s *Service) FindNotesWithoutTags(ctx *WorkspaceContext) ([]*models.Note, error) {
+	notes, err := s.ListAllNotes(ctx, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("list notes: %w", err)
+	}
+	var untagged []*models.Note
+	for _, note := range notes {
+		if IsUntagged(note.Tags) {
+			untagged = append(untagged, note)
+		}
+	}
+	sort.Slice(untagged, func(i, j int) bool { return untagged[i].Path < untagged[j].Path })
+	return untagged, nil
+}
+
+// IsUntagged reports whether tags holds no tag, ignoring entries that are
+// empty once normalized (such as a stray "#").
+func IsUntagged(tags []string) bool {
+	for _, tag := range tags {
+		if NormalizeTag(tag) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+func TestAddAndRemoveTag(t *testing.T) {
+	dir := t.TempDir()
+	tagged := filepath.Join(dir, "tagged.md")
+	plain := filepath.Join(dir, "plain.md")
+	require.NoError(t, os.WriteFile(tagged, []byte("---\nid: a\ntitle: A\ntags: [idea, proj]\npriority: p1\ncreated: 2024-01-01T00:00:00Z\nmodified: 2024-01-01T00:00:00Z\n---\n\n# A\n"), 0o644))
+	require.NoError(t, os.WriteFile(plain, []byte("---\nid: b\ntitle: B\ntags: []\ncreated: 2024-01-01T00:00:00Z\nmodified: 2024-01-01T00:00:00Z\n---\n\n# B\n"), 0o644))
+	before, err := os.ReadFile(tagged)
+	require.NoError(t, err)
+
+	s := newTestService()
+	changed, err := s.AddTag([]string{tagged, plain}, "#idea")
+	require.NoError(t, err)
+	assert.Equal(t, []string{plain}, changed, "a note that already has the tag is not rewritten")
+
+	after, err := os.ReadFile(tagged)
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after))
+
+	fm, body, err := frontmatter.Parse(readFile(t, plain))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"idea"}, fm.Tags)
+	assert.Equal(t, "B", fm.Title)
+	assert.Contains(t, body, "# B")
+
+	changed, err = s.RemoveTag([]string{tagged, plain}, "proj")
+	require.NoError(t, err)
+	assert.Equal(t, []string{tagged}, changed)
+	fm, _, err = frontmatter.Parse(readFile(t, tagged))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"idea"}, fm.Tags)
+	assert.Equal(t, "p1", fm.Priority, "other fields survive the rewrite")
+
+	_, err = s.AddTag([]string{plain}, " # ")
+	assert.Error(t, err)
+}
+
+func TestAddTagKeepsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "note.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\nid: a\ntitle: A\ntags: [idea]\ncustom_field: keep me\nmodified: 2024-01-01T00:00:00Z\n---\n\n# A\n"), 0o644))
+
+	s := newTestService()
+	changed, err := s.AddTag([]string{path}, "proj")
+	require.NoError(t, err)
+	assert.Equal(t, []string{path}, changed)
+
+	content := readFile(t, path)
+	assert.Contains(t, content, "custom_field: keep me\n")
+	assert.Contains(t, content, "tags: [idea, proj]\n")
+	assert.NotContains(t, content, "modified: 2024-01-01T00:00:00Z")
+	assert.True(t, strings.HasSuffix(content, "---\n\n# A\n"), "the body is untouched: %q", content)
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestMatchesTags(t *testing.T) {
+	tags := []string{"bug", "Backend"}
+
+	assert.True(t, matchesTags(tags, []string{"bug"}, false))
+	assert.True(t, matchesTags(tags, []string{"#BUG", "backend"}, false), "tags are normalized and case-insensitive")
+	assert.False(t, matchesTags(tags, []string{"bug", "frontend"}, false), "all tags are required by default")
+	assert.True(t, matchesTags(tags, []string{"frontend", "bug"}, true), "any tag is enough with any")
+	assert.False(t, matchesTags(tags, []string{"frontend"}, true))
+	assert.False(t, matchesTags(nil, []string{"bug"}, false))
+}
+
+func TestIsUntagged(t *testing.T) {
+	assert.True(t, IsUntagged(nil))
+	assert.True(t, IsUntagged([]string{}))
+	assert.True(t, IsUntagged([]string{"", " # "}), "tags that normalize to nothing don't count")
+	assert.False(t, IsUntagged([]string{"", "idea"}))
+}
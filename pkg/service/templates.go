@@ -0,0 +1,107 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	coreconfig "github.com/grovetools/core/config"
+)
+
+// Template sources reported by ResolveTemplate, most specific first.
+const (
+	TemplateSourceGroup   = "group"
+	TemplateSourceType    = "type"
+	TemplateSourceDefault = "default"
+)
+
+// TemplateResolution describes which template a new note in a group gets.
+type TemplateResolution struct {
+	Group string `json:"group"`
+	// Source is TemplateSourceGroup, TemplateSourceType or
+	// TemplateSourceDefault.
+	Source string `json:"source"`
+	// Key is the group_templates entry or note type that matched.
+	Key string `json:"key,omitempty"`
+	// Path is the template file; empty for inline and built-in templates.
+	Path string `json:"path,omitempty"`
+}
+
+// ResolveTemplate reports the template CreateNote uses for a note in group
+// (a group path such as "research/spikes"). The most specific match wins:
+// a group_templates entry for the group, then for each parent group, then
+// the note type's template, then nb's built-in content.
+func (s *Service) ResolveTemplate(ctx *WorkspaceContext, group string) (*TemplateResolution, error) {
+	group = strings.Trim(filepath.ToSlash(group), "/")
+	res := &TemplateResolution{Group: group, Source: TemplateSourceDefault}
+
+	if key, path, ok := matchGroupTemplate(s.Config.GroupTemplates, group); ok {
+		resolved, err := s.groupTemplatePath(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		res.Source, res.Key, res.Path = TemplateSourceGroup, key, resolved
+		return res, nil
+	}
+
+	if cfg := s.noteTypeConfig(group); cfg != nil && cfg.TemplatePath != "" {
+		res.Source, res.Key, res.Path = TemplateSourceType, group, cfg.TemplatePath
+	} else if s.Config.Templates[group] != "" {
+		res.Source, res.Key = TemplateSourceType, group
+	}
+	return res, nil
+}
+
+// matchGroupTemplate finds the group_templates entry for group or its
+// nearest parent group, returning the matching key and its template.
+func matchGroupTemplate(templates map[string]string, group string) (string, string, bool) {
+	for key := group; key != ""; {
+		if path := templates[key]; path != "" {
+			return key, path, true
+		}
+		i := strings.LastIndex(key, "/")
+		if i < 0 {
+			break
+		}
+		key = key[:i]
+	}
+	return "", "", false
+}
+
+// groupTemplatePath resolves a group_templates value: ~ is the home
+// directory, and relative paths are under the notebook's templates dir.
+func (s *Service) groupTemplatePath(ctx *WorkspaceContext, path string) (string, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("resolve template %s: %w", path, err)
+		}
+		return filepath.Join(home, rest), nil
+	}
+	if filepath.IsAbs(path) {
+		return path, nil
+	}
+	dir, err := s.notebookLocator.GetTemplatesDir(ctx.NotebookContextWorkspace)
+	if err != nil {
+		return "", fmt.Errorf("get templates dir: %w", err)
+	}
+	return filepath.Join(dir, path), nil
+}
+
+// noteTypeConfig returns the user's configuration of noteType in the default
+// notebook, or nil.
+func (s *Service) noteTypeConfig(noteType string) *coreconfig.NoteTypeConfig {
+	if s.CoreConfig == nil || s.CoreConfig.Notebooks == nil || s.CoreConfig.Notebooks.Definitions == nil {
+		return nil
+	}
+	// Try to get default notebook name from rules, otherwise fall back to "default"
+	defaultNotebookName := "default"
+	if s.CoreConfig.Notebooks.Rules != nil && s.CoreConfig.Notebooks.Rules.Default != "" {
+		defaultNotebookName = s.CoreConfig.Notebooks.Rules.Default
+	}
+	if notebook, ok := s.CoreConfig.Notebooks.Definitions[defaultNotebookName]; ok && notebook.Types != nil {
+		return notebook.Types[noteType]
+	}
+	return nil
+}
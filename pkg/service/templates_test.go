@@ -0,0 +1,55 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchGroupTemplateMostSpecificWins(t *testing.T) {
+	templates := map[string]string{
+		"research":        "research.md",
+		"research/spikes": "spike.md",
+		"meetings":        "",
+	}
+	tests := []struct {
+		group, wantKey, wantPath string
+		wantOK                   bool
+	}{
+		{"research/spikes", "research/spikes", "spike.md", true},
+		{"research/spikes/deep", "research/spikes", "spike.md", true},
+		{"research/papers", "research", "research.md", true},
+		{"research", "research", "research.md", true},
+		{"meetings", "", "", false},
+		{"inbox", "", "", false},
+		{"", "", "", false},
+	}
+	for _, tc := range tests {
+		key, path, ok := matchGroupTemplate(templates, tc.group)
+		assert.Equal(t, tc.wantOK, ok, tc.group)
+		assert.Equal(t, tc.wantKey, key, tc.group)
+		assert.Equal(t, tc.wantPath, path, tc.group)
+	}
+}
+
+func TestResolveTemplateFallsBackToType(t *testing.T) {
+	s := newTestService()
+	s.Config = &Config{
+		Templates:      map[string]string{"inbox": "# {{.Title}}\n"},
+		GroupTemplates: map[string]string{"research": "/abs/research.md"},
+	}
+	ctx := &WorkspaceContext{}
+
+	res, err := s.ResolveTemplate(ctx, "research/spikes")
+	assert.NoError(t, err)
+	assert.Equal(t, &TemplateResolution{Group: "research/spikes", Source: TemplateSourceGroup, Key: "research", Path: "/abs/research.md"}, res)
+
+	res, err = s.ResolveTemplate(ctx, "inbox")
+	assert.NoError(t, err)
+	assert.Equal(t, TemplateSourceType, res.Source)
+	assert.Equal(t, "inbox", res.Key)
+
+	res, err = s.ResolveTemplate(ctx, "journal")
+	assert.NoError(t, err)
+	assert.Equal(t, TemplateSourceDefault, res.Source)
+}
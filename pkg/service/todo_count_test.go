@@ -35,6 +35,11 @@ func TestCountTodos(t *testing.T) {
 			content:  "- [ ] real\n```markdown\n- [ ] fenced example\n- [x] fenced done\n```\n- [x] real done\n",
 			wantOpen: 1, wantDone: 1, wantCxl: 0,
 		},
+		{
+			name:     "tilde fences are excluded and only close on their own marker",
+			content:  "~~~\n- [ ] fenced\n```\n- [x] still fenced\n~~~\n- [ ] real\n",
+			wantOpen: 1, wantDone: 0, wantCxl: 0,
+		},
 		{
 			name:     "non-todo lines are ignored",
 			content:  "# Title\n\nplain text - [ ]not a todo (no space)\n-[x] missing bullet space\n[ ] bare brackets\n",
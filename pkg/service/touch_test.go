@@ -0,0 +1,49 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+func TestTouchOnlyRewritesModified(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "note.md")
+	content := "---\nid: 20240101-a\ntitle: A   # keep this comment\ntags: [x]\ncreated: 2024-01-01T00:00:00Z\nmodified: 2024-01-01T00:00:00Z\n---\n\n# A\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	old := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	before := time.Now().Add(-time.Second)
+	require.NoError(t, newTestService().Touch(path))
+
+	got := readFile(t, path)
+	wantLines := strings.Split(content, "\n")
+	gotLines := strings.Split(got, "\n")
+	require.Len(t, gotLines, len(wantLines))
+	for i := range wantLines {
+		if strings.HasPrefix(wantLines[i], "modified:") {
+			assert.NotEqual(t, wantLines[i], gotLines[i])
+			continue
+		}
+		assert.Equal(t, wantLines[i], gotLines[i])
+	}
+
+	fm, _, err := frontmatter.Parse(got)
+	require.NoError(t, err)
+	modified, err := frontmatter.ParseTimestamp(fm.Modified)
+	require.NoError(t, err)
+	assert.True(t, modified.After(before))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(modified), "mtime %v, frontmatter %v", info.ModTime(), modified)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
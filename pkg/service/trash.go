@@ -0,0 +1,212 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	coremodels "github.com/grovetools/core/pkg/models"
+)
+
+// TrashRetention is how long trashed notes are kept before they are purged.
+const TrashRetention = 30 * 24 * time.Hour
+
+// trashInfoExt is the suffix of the sidecar file recording where a trashed
+// note came from.
+const trashInfoExt = ".trashinfo"
+
+// trashTimeLayout prefixes trashed file names so that notes with the same
+// name never collide.
+const trashTimeLayout = "20060102T150405.000000000"
+
+// TrashEntry is one note in the trash.
+type TrashEntry struct {
+	Path         string    `json:"path"`          // File in the trash directory
+	OriginalPath string    `json:"original_path"` // Where TrashNote found it
+	TrashedAt    time.Time `json:"trashed_at"`
+}
+
+// DeleteNote permanently deletes the note at path.
+func (s *Service) DeleteNote(path string) error {
+	return s.DeleteNotes([]string{path})
+}
+
+// TrashDir returns the directory TrashNote moves notes into,
+// ~/.grove/nb/trash.
+func (s *Service) TrashDir() string {
+	return filepath.Join(NBHomeDir(), "trash")
+}
+
+// TrashNote moves the note at path into the trash instead of deleting it, so
+// RestoreTrashEntry can put it back. Entries older than TrashRetention are
+// purged first.
+func (s *Service) TrashNote(path string) error {
+	unlock, err := s.LockNotebook(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("resolve note path: %w", err)
+	}
+	ws, _, noteType := GetNoteMetadata(abs)
+	if _, err := os.Stat(abs); err != nil {
+		return fmt.Errorf("trash note: %w", err)
+	}
+	now := time.Now()
+	s.purgeTrash(now)
+
+	dir := s.TrashDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create trash directory: %w", err)
+	}
+	dest := filepath.Join(dir, now.UTC().Format(trashTimeLayout)+"-"+filepath.Base(abs))
+	info, err := json.MarshalIndent(TrashEntry{OriginalPath: abs, TrashedAt: now.UTC()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode trash info: %w", err)
+	}
+	if err := os.WriteFile(dest+trashInfoExt, append(info, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write trash info: %w", err)
+	}
+	if err := os.Rename(abs, dest); err != nil {
+		// Fall back to copy and delete if rename fails (e.g., cross-device)
+		if err := copyAndDelete(abs, dest); err != nil {
+			_ = os.Remove(dest + trashInfoExt)
+			return fmt.Errorf("move note to trash: %w", err)
+		}
+	}
+
+	s.opLog("trash", abs, ws).WithField("trash_path", dest).Info("Moved note to trash")
+	EmitNoteEvent(coremodels.NoteEvent{
+		Event:     coremodels.NoteEventDeleted,
+		Workspace: ws,
+		NoteType:  noteType,
+		Path:      abs,
+	})
+	return nil
+}
+
+// ListTrash returns the notes in the trash, most recently trashed first.
+// Entries older than TrashRetention are purged first.
+func (s *Service) ListTrash() ([]TrashEntry, error) {
+	s.purgeTrash(time.Now())
+	return readTrash(s.TrashDir())
+}
+
+// RestoreTrashEntry moves a trashed note back to where it was trashed from
+// and returns that path. ref is the entry's path or file name in the trash,
+// or the note's original path; for an original path trashed more than once
+// the newest entry is restored. An existing file is never overwritten.
+func (s *Service) RestoreTrashEntry(ref string) (string, error) {
+	entries, err := readTrash(s.TrashDir())
+	if err != nil {
+		return "", err
+	}
+	entry, ok := findTrashEntry(entries, ref)
+	if !ok {
+		return "", fmt.Errorf("no trashed note matches %s", ref)
+	}
+
+	unlock, err := s.LockNotebook(entry.OriginalPath)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	if _, err := os.Stat(entry.OriginalPath); err == nil {
+		return "", fmt.Errorf("restore %s: %s already exists", filepath.Base(entry.Path), entry.OriginalPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(entry.OriginalPath), 0o755); err != nil {
+		return "", fmt.Errorf("create note directory: %w", err)
+	}
+	if err := os.Rename(entry.Path, entry.OriginalPath); err != nil {
+		if err := copyAndDelete(entry.Path, entry.OriginalPath); err != nil {
+			return "", fmt.Errorf("restore %s: %w", filepath.Base(entry.Path), err)
+		}
+	}
+	_ = os.Remove(entry.Path + trashInfoExt)
+
+	ws, _, noteType := GetNoteMetadata(entry.OriginalPath)
+	s.opLog("trash-restore", entry.OriginalPath, ws).WithField("trash_path", entry.Path).Info("Restored note from trash")
+	EmitNoteEvent(coremodels.NoteEvent{
+		Event:     coremodels.NoteEventCreated,
+		Workspace: ws,
+		NoteType:  noteType,
+		Path:      entry.OriginalPath,
+	})
+	return entry.OriginalPath, nil
+}
+
+// purgeTrash permanently deletes trash entries trashed more than
+// TrashRetention before now. Failures are logged, never returned: purging is
+// housekeeping for the operation that triggered it.
+func (s *Service) purgeTrash(now time.Time) {
+	entries, err := readTrash(s.TrashDir())
+	if err != nil {
+		s.opLog("trash-purge", "", "").WithError(err).Warn("Failed to read trash")
+		return
+	}
+	for _, entry := range entries {
+		if now.Sub(entry.TrashedAt) <= TrashRetention {
+			continue
+		}
+		if err := os.Remove(entry.Path); err != nil && !os.IsNotExist(err) {
+			s.opLog("trash-purge", entry.Path, "").WithError(err).Warn("Failed to purge trashed note")
+			continue
+		}
+		_ = os.Remove(entry.Path + trashInfoExt)
+		s.opLog("trash-purge", entry.Path, "").WithField("original_path", entry.OriginalPath).Debug("Purged trashed note")
+	}
+}
+
+// readTrash loads the entries in dir from their .trashinfo files, newest
+// first. A missing directory is an empty trash.
+func readTrash(dir string) ([]TrashEntry, error) {
+	infos, err := filepath.Glob(filepath.Join(dir, "*"+trashInfoExt))
+	if err != nil {
+		return nil, fmt.Errorf("list trash: %w", err)
+	}
+	var entries []TrashEntry
+	for _, infoPath := range infos {
+		data, err := os.ReadFile(infoPath)
+		if err != nil {
+			continue
+		}
+		var entry TrashEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			continue
+		}
+		entry.Path = strings.TrimSuffix(infoPath, trashInfoExt)
+		if _, err := os.Stat(entry.Path); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].TrashedAt.After(entries[j].TrashedAt)
+	})
+	return entries, nil
+}
+
+// findTrashEntry picks the entry ref names from entries, which are newest
+// first.
+func findTrashEntry(entries []TrashEntry, ref string) (TrashEntry, bool) {
+	abs, _ := filepath.Abs(ref)
+	for _, entry := range entries {
+		if entry.Path == ref || entry.Path == abs || filepath.Base(entry.Path) == ref {
+			return entry, true
+		}
+	}
+	for _, entry := range entries {
+		if entry.OriginalPath == abs {
+			return entry, true
+		}
+	}
+	return TrashEntry{}, false
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTrashTestService(t *testing.T) *Service {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	s := newTestService()
+	s.Config = &Config{}
+	return s
+}
+
+func TestTrashAndRestoreNote(t *testing.T) {
+	s := newTrashTestService(t)
+	note := filepath.Join(t.TempDir(), "inbox", "20240101-idea.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(note), 0o755))
+	require.NoError(t, os.WriteFile(note, []byte("# Idea\n"), 0o644))
+
+	require.NoError(t, s.TrashNote(note))
+	assert.NoFileExists(t, note)
+
+	entries, err := s.ListTrash()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, note, entries[0].OriginalPath)
+	assert.Equal(t, s.TrashDir(), filepath.Dir(entries[0].Path))
+	assert.Contains(t, filepath.Base(entries[0].Path), "-20240101-idea.md")
+
+	restored, err := s.RestoreTrashEntry(filepath.Base(entries[0].Path))
+	require.NoError(t, err)
+	assert.Equal(t, note, restored)
+	assert.Equal(t, "# Idea\n", readFile(t, note))
+
+	entries, err = s.ListTrash()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestRestoreTrashEntryByOriginalPathDoesNotOverwrite(t *testing.T) {
+	s := newTrashTestService(t)
+	note := filepath.Join(t.TempDir(), "note.md")
+	require.NoError(t, os.WriteFile(note, []byte("first"), 0o644))
+	require.NoError(t, s.TrashNote(note))
+	require.NoError(t, os.WriteFile(note, []byte("second"), 0o644))
+
+	_, err := s.RestoreTrashEntry(note)
+	assert.Error(t, err, "the note's path is taken again")
+	assert.Equal(t, "second", readFile(t, note))
+
+	_, err = s.RestoreTrashEntry("missing.md")
+	assert.Error(t, err)
+}
+
+func TestListTrashPurgesOldEntries(t *testing.T) {
+	s := newTrashTestService(t)
+	note := filepath.Join(t.TempDir(), "old.md")
+	require.NoError(t, os.WriteFile(note, []byte("old"), 0o644))
+	require.NoError(t, s.TrashNote(note))
+	entries, err := s.ListTrash()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	s.purgeTrash(time.Now().Add(TrashRetention + time.Hour))
+	assert.NoFileExists(t, entries[0].Path)
+	assert.NoFileExists(t, entries[0].Path+trashInfoExt)
+	entries, err = s.ListTrash()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
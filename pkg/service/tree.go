@@ -0,0 +1,205 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/nb/pkg/models"
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+// TreeOptions controls what BuildTree includes.
+type TreeOptions struct {
+	// AllWorkspaces builds a root for every registered workspace instead of
+	// only the context's notebook workspace.
+	AllWorkspaces    bool
+	IncludeArchived  bool
+	IncludeArtifacts bool
+}
+
+// BuildTree returns the notebook as a hierarchy of workspaces → groups →
+// files (see tree.Build), built from the same items the TUI lists. The TUI
+// browser does not use it; its BuildDisplayTree groups the items itself.
+func (s *Service) BuildTree(ctx *WorkspaceContext, opts TreeOptions) ([]*tree.Item, error) {
+	var (
+		items []*tree.Item
+		err   error
+	)
+	if opts.AllWorkspaces {
+		items, err = s.ListItemsFromAllWorkspaces(opts.IncludeArchived, opts.IncludeArtifacts)
+	} else {
+		items, err = s.ListAllItems(ctx, opts.IncludeArchived, opts.IncludeArtifacts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list items for tree: %w", err)
+	}
+	return tree.Build(items), nil
+}
+
+// ungroupedGroup names the group of notes that sit directly in a workspace's
+// notebook directory.
+const ungroupedGroup = "ungrouped"
+
+// WorkspaceTree is the notebook as plain data: workspaces, the groups inside
+// them and the notes in each group, with no display concerns. Groups and
+// Notes are keyed by GroupKey.
+type WorkspaceTree struct {
+	Workspaces []WorkspaceTreeNode       `json:"workspaces"`
+	Groups     map[string]GroupNode      `json:"groups"`
+	Notes      map[string][]*models.Note `json:"notes"`
+}
+
+// WorkspaceTreeNode is a workspace in a WorkspaceTree.
+type WorkspaceTreeNode struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Groups holds the keys of the top-level groups, sorted by name.
+	Groups []string `json:"groups"`
+}
+
+// GroupNode is a group in a WorkspaceTree. Nested groups such as
+// plans/<name> are children of their parent directory's group.
+type GroupNode struct {
+	Key       string `json:"key"`
+	Workspace string `json:"workspace"`
+	Name      string `json:"name"` // Last path segment, e.g. "my-plan"
+	Path      string `json:"path"` // Group path, e.g. "plans/my-plan"
+	Parent    string `json:"parent,omitempty"`
+	// Children holds the keys of the nested groups, sorted by name.
+	Children []string `json:"children,omitempty"`
+}
+
+// GroupKey identifies the group at path (e.g. "plans/my-plan") in workspace.
+func GroupKey(workspace, path string) string {
+	return workspace + ":" + path
+}
+
+// NoteCount returns the number of notes in the group at key and every group
+// nested below it.
+func (t *WorkspaceTree) NoteCount(key string) int {
+	count := len(t.Notes[key])
+	for _, child := range t.Groups[key].Children {
+		count += t.NoteCount(child)
+	}
+	return count
+}
+
+// GetWorkspaceTree returns the WorkspaceTree of the named workspace's
+// notebook ("" for the current one), without archived notes or artifacts.
+func (s *Service) GetWorkspaceTree(name string) (*WorkspaceTree, error) {
+	ctx, err := s.GetWorkspaceContext(name)
+	if err != nil {
+		return nil, fmt.Errorf("get workspace context: %w", err)
+	}
+	notes, err := s.ListAllNotes(ctx, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("list notes: %w", err)
+	}
+	ws := ctx.NotebookContextWorkspace
+	return buildWorkspaceTree([]WorkspaceTreeNode{{Name: ws.Name, Path: ws.Path}}, notes), nil
+}
+
+// NewWorkspaceTree returns the WorkspaceTree of notes, with a workspace for
+// each workspace the notes belong to, in the order they first appear. It is
+// how listings that already hold their notes, such as a filtered nb list or
+// the TUI browser, group them.
+func NewWorkspaceTree(notes []*models.Note) *WorkspaceTree {
+	var workspaces []WorkspaceTreeNode
+	seen := make(map[string]bool)
+	for _, note := range notes {
+		if !seen[note.Workspace] {
+			seen[note.Workspace] = true
+			workspaces = append(workspaces, WorkspaceTreeNode{Name: note.Workspace})
+		}
+	}
+	return buildWorkspaceTree(workspaces, notes)
+}
+
+// NotesByGroup returns the notes of the named workspace keyed by their
+// group, as note.Group has it: notes loose in the notebook directory are
+// under "" rather than "ungrouped".
+func (t *WorkspaceTree) NotesByGroup(workspace string) map[string][]*models.Note {
+	byGroup := make(map[string][]*models.Note)
+	for key, g := range t.Groups {
+		if g.Workspace != workspace {
+			continue
+		}
+		for _, note := range t.Notes[key] {
+			byGroup[note.Group] = append(byGroup[note.Group], note)
+		}
+	}
+	return byGroup
+}
+
+// buildWorkspaceTree files notes under their workspace's groups, creating
+// the groups (and any parent groups) they need. Notes of workspaces not in
+// workspaces are left out.
+func buildWorkspaceTree(workspaces []WorkspaceTreeNode, notes []*models.Note) *WorkspaceTree {
+	t := &WorkspaceTree{
+		Workspaces: workspaces,
+		Groups:     make(map[string]GroupNode),
+		Notes:      make(map[string][]*models.Note),
+	}
+	known := make(map[string]int, len(workspaces))
+	for i, ws := range workspaces {
+		known[ws.Name] = i
+	}
+	// The context workspace's notes may carry no workspace name.
+	single := len(workspaces) == 1
+
+	for _, note := range notes {
+		wsName := note.Workspace
+		if _, ok := known[wsName]; !ok {
+			if !single {
+				continue
+			}
+			wsName = workspaces[0].Name
+		}
+		group := strings.Trim(filepath.ToSlash(note.Group), "/")
+		if group == "" {
+			group = ungroupedGroup
+		}
+		key := t.addGroup(wsName, group)
+		t.Notes[key] = append(t.Notes[key], note)
+	}
+
+	for key, groupNotes := range t.Notes {
+		sort.Slice(groupNotes, func(i, j int) bool { return groupNotes[i].Path < groupNotes[j].Path })
+		t.Notes[key] = groupNotes
+	}
+	for key, g := range t.Groups {
+		sort.Strings(g.Children)
+		t.Groups[key] = g
+	}
+	for i := range t.Workspaces {
+		sort.Strings(t.Workspaces[i].Groups)
+	}
+	return t
+}
+
+// addGroup makes sure the group at path and its parents exist, linking each
+// to its parent (or its workspace), and returns its key.
+func (t *WorkspaceTree) addGroup(workspace, path string) string {
+	key := GroupKey(workspace, path)
+	if _, ok := t.Groups[key]; ok {
+		return key
+	}
+	g := GroupNode{Key: key, Workspace: workspace, Name: path, Path: path}
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		g.Name = path[i+1:]
+		g.Parent = t.addGroup(workspace, path[:i])
+		parent := t.Groups[g.Parent]
+		parent.Children = append(parent.Children, key)
+		t.Groups[g.Parent] = parent
+	} else {
+		for i := range t.Workspaces {
+			if t.Workspaces[i].Name == workspace {
+				t.Workspaces[i].Groups = append(t.Workspaces[i].Groups, key)
+			}
+		}
+	}
+	t.Groups[key] = g
+	return key
+}
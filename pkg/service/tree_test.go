@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestBuildWorkspaceTree(t *testing.T) {
+	notes := []*models.Note{
+		{Path: "/nb/ws/inbox/b.md", Workspace: "ws", Group: "inbox"},
+		{Path: "/nb/ws/inbox/a.md", Workspace: "ws", Group: "inbox"},
+		{Path: "/nb/ws/plans/feat/01.md", Workspace: "ws", Group: "plans/feat"},
+		{Path: "/nb/ws/plans/fix/01.md", Workspace: "ws", Group: "plans/fix"},
+		{Path: "/nb/ws/loose.md", Workspace: "ws", Group: ""},
+		{Path: "/nb/other/inbox/c.md", Workspace: "other", Group: "inbox"},
+	}
+	tree := buildWorkspaceTree([]WorkspaceTreeNode{{Name: "ws"}, {Name: "other"}}, notes)
+
+	require.Len(t, tree.Workspaces, 2)
+	assert.Equal(t, []string{"ws:inbox", "ws:plans", "ws:ungrouped"}, tree.Workspaces[0].Groups)
+	assert.Equal(t, []string{"other:inbox"}, tree.Workspaces[1].Groups)
+
+	plans := tree.Groups["ws:plans"]
+	assert.Equal(t, []string{"ws:plans/feat", "ws:plans/fix"}, plans.Children)
+	assert.Empty(t, tree.Notes["ws:plans"])
+	assert.Equal(t, 2, tree.NoteCount("ws:plans"))
+
+	feat := tree.Groups["ws:plans/feat"]
+	assert.Equal(t, "feat", feat.Name)
+	assert.Equal(t, "plans/feat", feat.Path)
+	assert.Equal(t, "ws:plans", feat.Parent)
+
+	inbox := tree.Notes["ws:inbox"]
+	require.Len(t, inbox, 2)
+	assert.Equal(t, "/nb/ws/inbox/a.md", inbox[0].Path)
+	assert.Len(t, tree.Notes["ws:ungrouped"], 1)
+}
+
+func TestBuildWorkspaceTreeSingleWorkspaceAdoptsUnnamedNotes(t *testing.T) {
+	notes := []*models.Note{{Path: "/nb/ws/inbox/a.md", Group: "inbox"}}
+	tree := buildWorkspaceTree([]WorkspaceTreeNode{{Name: "ws"}}, notes)
+	assert.Equal(t, []string{"ws:inbox"}, tree.Workspaces[0].Groups)
+	assert.Len(t, tree.Notes["ws:inbox"], 1)
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+// UnfiledGroup is the group loose notes are listed under.
+const UnfiledGroup = "unfiled"
+
+// ListUnfiledItems returns the Markdown files sitting directly in ctx's
+// notebook root (the directory holding its notes, plans and chats) rather
+// than in one of those content directories. They are grouped under
+// UnfiledGroup. When the notes directory is itself the root, as with the
+// default centralized layout, its loose files already show up as "quick"
+// notes and nothing is returned.
+func (s *Service) ListUnfiledItems(ctx *WorkspaceContext) ([]*tree.Item, error) {
+	contentDirs, err := s.notebookLocator.GetAllContentDirs(ctx.NotebookContextWorkspace)
+	if err != nil {
+		return nil, fmt.Errorf("get content directories: %w", err)
+	}
+	items, err := s.unfiledItems(notebookRootDir(contentDirs), contentDirs)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		item.Metadata["Workspace"] = ctx.NotebookContextWorkspace.Name
+		item.Metadata["Branch"] = ctx.Branch
+	}
+	return items, nil
+}
+
+// unfiledItems lists the Markdown files directly in root, unless root is one
+// of contentDirs.
+func (s *Service) unfiledItems(root string, contentDirs []coreworkspace.ContentDirectory) ([]*tree.Item, error) {
+	if root == "" {
+		return nil, nil
+	}
+	for _, dir := range contentDirs {
+		if filepath.Clean(dir.Path) == root {
+			return nil, nil
+		}
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read notebook root: %w", err)
+	}
+	var items []*tree.Item
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".md") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		item, err := s.newItemFromFile(filepath.Join(root, name), info)
+		if err != nil {
+			continue
+		}
+		item.Metadata["Group"] = UnfiledGroup
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// notebookRootDir returns the deepest directory containing every content
+// directory, or "" when there are none.
+func notebookRootDir(contentDirs []coreworkspace.ContentDirectory) string {
+	var root string
+	for i, dir := range contentDirs {
+		path := filepath.Clean(dir.Path)
+		if i == 0 {
+			root = path
+			continue
+		}
+		for root != path && !strings.HasPrefix(path, root+string(filepath.Separator)) {
+			parent := filepath.Dir(root)
+			if parent == root {
+				break
+			}
+			root = parent
+		}
+	}
+	return root
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+)
+
+func TestNotebookRootDir(t *testing.T) {
+	assert.Equal(t, "/p/.notebook", notebookRootDir([]coreworkspace.ContentDirectory{
+		{Path: "/p/.notebook/notes", Type: "notes"},
+		{Path: "/p/.notebook/plans", Type: "plans"},
+		{Path: "/p/.notebook/chats", Type: "chats"},
+	}))
+	assert.Equal(t, "/nb/workspaces/proj", notebookRootDir([]coreworkspace.ContentDirectory{
+		{Path: "/nb/workspaces/proj", Type: "notes"},
+		{Path: "/nb/workspaces/proj/plans", Type: "plans"},
+	}))
+	assert.Equal(t, "/nb/workspaces", notebookRootDir([]coreworkspace.ContentDirectory{
+		{Path: "/nb/workspaces/proj-notes", Type: "notes"},
+		{Path: "/nb/workspaces/proj", Type: "plans"},
+	}), "a shared name prefix is not a shared directory")
+	assert.Equal(t, "", notebookRootDir(nil))
+}
+
+func TestUnfiledItems(t *testing.T) {
+	root := t.TempDir()
+	notes := filepath.Join(root, "notes")
+	require.NoError(t, os.MkdirAll(filepath.Join(notes, "inbox"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "stray.md"), []byte("---\ntitle: Stray\n---\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ".hidden.md"), []byte("x"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "image.png"), []byte("x"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(notes, "inbox", "filed.md"), []byte("x"), 0o644))
+	contentDirs := []coreworkspace.ContentDirectory{{Path: notes, Type: "notes"}}
+
+	items, err := newTestService().unfiledItems(root, contentDirs)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, filepath.Join(root, "stray.md"), items[0].Path)
+	assert.Equal(t, UnfiledGroup, items[0].Metadata["Group"])
+
+	items, err = newTestService().unfiledItems(notes, []coreworkspace.ContentDirectory{{Path: notes, Type: "notes"}})
+	require.NoError(t, err)
+	assert.Empty(t, items, "a root that is itself a content directory is already listed")
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// inodeKey identifies a directory by device and inode so that a symlink loop
+// can be detected no matter which path it is reached through.
+type inodeKey struct {
+	dev uint64
+	ino uint64
+}
+
+// walkVisited records the directories a symlink-following walk has entered.
+type walkVisited struct {
+	// inodeOf identifies a directory; it is the platform's inodeOf outside
+	// tests.
+	inodeOf func(os.FileInfo) (inodeKey, bool)
+	inodes  map[inodeKey]struct{}
+	// paths holds the canonical, symlink-free paths of the directories
+	// without an inode, e.g. on Windows.
+	paths map[string]struct{}
+}
+
+func newWalkVisited() *walkVisited {
+	return &walkVisited{
+		inodeOf: inodeOf,
+		inodes:  make(map[inodeKey]struct{}),
+		paths:   make(map[string]struct{}),
+	}
+}
+
+// enter marks the directory at path visited, reporting false when it already
+// was. Directories are identified by inode where the platform has one, else
+// by the path filepath.EvalSymlinks resolves them to.
+func (v *walkVisited) enter(path string, info os.FileInfo) bool {
+	if key, ok := v.inodeOf(info); ok {
+		if _, seen := v.inodes[key]; seen {
+			return false
+		}
+		v.inodes[key] = struct{}{}
+		return true
+	}
+	canonical, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		canonical = path
+	}
+	if abs, err := filepath.Abs(canonical); err == nil {
+		canonical = abs
+	}
+	if _, seen := v.paths[canonical]; seen {
+		return false
+	}
+	v.paths[canonical] = struct{}{}
+	return true
+}
+
+// walkNotebook walks root like filepath.Walk. When the service is configured to
+// follow symlinks, symlinked directories are descended into as well (reported
+// under their link path so group names stay stable); directories are tracked by
+// inode (or canonical path) so a link pointing back up the tree is visited
+// only once.
+func (s *Service) walkNotebook(root string, fn filepath.WalkFunc) error {
+	if s.Config == nil || !s.Config.FollowSymlinks {
+		return filepath.Walk(root, fn)
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return fn(root, nil, err)
+	}
+	err = walkFollowingSymlinks(root, info, fn, newWalkVisited())
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	}
+	return err
+}
+
+// walkFollowingSymlinks is the symlink-following counterpart of filepath.Walk.
+// info is the result of os.Stat (not Lstat), so a link to a directory reports
+// IsDir() == true and is recursed into unless it was already visited.
+func walkFollowingSymlinks(path string, info os.FileInfo, fn filepath.WalkFunc, visited *walkVisited) error {
+	if !info.IsDir() {
+		return fn(path, info, nil)
+	}
+
+	if !visited.enter(path, info) {
+		// Cycle (or a second link to the same directory): already walked.
+		return nil
+	}
+
+	entries, readErr := os.ReadDir(path)
+	if err := fn(path, info, readErr); err != nil || readErr != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		child := filepath.Join(path, name)
+		childInfo, err := os.Stat(child)
+		if err != nil {
+			// Dangling symlink or permission problem: report it like Walk does.
+			if err := fn(child, nil, err); err != nil && err != filepath.SkipDir {
+				return err
+			}
+			continue
+		}
+		if err := walkFollowingSymlinks(child, childInfo, fn, visited); err != nil {
+			if err != filepath.SkipDir {
+				return err
+			}
+			if !childInfo.IsDir() {
+				// SkipDir on a file skips the rest of this directory.
+				return nil
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package service
+
+import "os"
+
+// inodeOf reports false where os.FileInfo carries no inode; walkVisited then
+// tracks directories by canonical path.
+func inodeOf(info os.FileInfo) (inodeKey, bool) {
+	return inodeKey{}, false
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collectWalk returns the notebook-relative paths of every file reported by
+// walkNotebook under root.
+func collectWalk(t *testing.T, s *Service, root string) []string {
+	t.Helper()
+	var files []string
+	err := s.walkNotebook(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !info.IsDir() {
+			rel, _ := filepath.Rel(root, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	sort.Strings(files)
+	return files
+}
+
+func TestWalkNotebookSymlinks(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "notes")
+	shared := filepath.Join(base, "shared-issues")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "inbox"), 0o755))
+	require.NoError(t, os.MkdirAll(shared, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "inbox", "a.md"), []byte("# a\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(shared, "b.md"), []byte("# b\n"), 0o644))
+
+	// notes/issues -> ../shared-issues, and a loop back to the notes root.
+	require.NoError(t, os.Symlink(shared, filepath.Join(root, "issues")))
+	require.NoError(t, os.Symlink(root, filepath.Join(shared, "loop")))
+
+	t.Run("default does not follow", func(t *testing.T) {
+		// filepath.Walk reports the link itself but never descends into it.
+		s := &Service{Config: &Config{}}
+		assert.Equal(t, []string{"inbox/a.md", "issues"}, collectWalk(t, s, root))
+	})
+
+	t.Run("opt-in follows without looping", func(t *testing.T) {
+		s := &Service{Config: &Config{FollowSymlinks: true}}
+		assert.Equal(t, []string{"inbox/a.md", "issues/b.md"}, collectWalk(t, s, root))
+	})
+}
+
+// Without inodes (as on Windows) loops are caught by canonical path.
+func TestWalkFollowingSymlinksWithoutInodes(t *testing.T) {
+	base := t.TempDir()
+	root := filepath.Join(base, "notes")
+	shared := filepath.Join(base, "shared-issues")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "inbox"), 0o755))
+	require.NoError(t, os.MkdirAll(shared, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "inbox", "a.md"), []byte("# a\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(shared, "b.md"), []byte("# b\n"), 0o644))
+	if err := os.Symlink(shared, filepath.Join(root, "issues")); err != nil {
+		t.Skipf("cannot create symlinks: %v", err)
+	}
+	require.NoError(t, os.Symlink(root, filepath.Join(shared, "loop")))
+	require.NoError(t, os.Symlink(filepath.Join(root, "inbox"), filepath.Join(root, "inbox", "self")))
+
+	visited := newWalkVisited()
+	visited.inodeOf = func(os.FileInfo) (inodeKey, bool) { return inodeKey{}, false }
+	info, err := os.Stat(root)
+	require.NoError(t, err)
+	var files []string
+	require.NoError(t, walkFollowingSymlinks(root, info, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(root, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return err
+	}, visited))
+	assert.Equal(t, []string{"inbox/a.md", "issues/b.md"}, files)
+	assert.Empty(t, visited.inodes)
+}
@@ -0,0 +1,17 @@
+//go:build unix
+
+package service
+
+import (
+	"os"
+	"syscall"
+)
+
+// inodeOf returns the device/inode pair for info.
+func inodeOf(info os.FileInfo) (inodeKey, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return inodeKey{}, false
+	}
+	return inodeKey{dev: uint64(st.Dev), ino: uint64(st.Ino)}, true //nolint:unconvert // Dev is int32 on darwin
+}
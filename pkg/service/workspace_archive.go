@@ -0,0 +1,220 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ArchivedWorkspacesDir is the directory, beside the workspace notebook
+// directories, that ArchiveWorkspace moves retired workspaces into.
+const ArchivedWorkspacesDir = ".archived"
+
+// WorkspaceArchiveInfoFilename is written into an archived workspace's
+// directory to record when it was archived and where from.
+const WorkspaceArchiveInfoFilename = ".nb-archive-info.yml"
+
+// WorkspaceArchiveInfo is the content of WorkspaceArchiveInfoFilename.
+type WorkspaceArchiveInfo struct {
+	Workspace  string    `yaml:"workspace"`
+	ArchivedAt time.Time `yaml:"archived_at"`
+	Source     string    `yaml:"source"`
+}
+
+// ArchivedWorkspace is a workspace notebook directory under
+// ArchivedWorkspacesDir.
+type ArchivedWorkspace struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Info is nil when the directory has no readable archive info.
+	Info *WorkspaceArchiveInfo `json:"info,omitempty"`
+}
+
+// ArchiveWorkspace retires the workspace called name: its notebook directory
+// moves from workspaces/<name> to workspaces/.archived/<name> with a
+// .nb-archive-info.yml recording the date. Nothing is deleted, and the notes
+// drop out of every listing since the workspace no longer has a notebook
+// directory. UnarchiveWorkspace moves it back.
+func (s *Service) ArchiveWorkspace(name string) error {
+	dir, archived, err := s.workspaceArchivePaths(name)
+	if err != nil {
+		return err
+	}
+	unlock, err := s.LockNotebook(dir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := archiveWorkspaceDir(name, dir, archived, time.Now()); err != nil {
+		return err
+	}
+	s.opLog("archive_workspace", "", name).WithField("path", archived).Info("Archived workspace")
+	return nil
+}
+
+// UnarchiveWorkspace moves an archived workspace's notebook directory back
+// into place, dropping its archive info.
+func (s *Service) UnarchiveWorkspace(name string) error {
+	dir, archived, err := s.workspaceArchivePaths(name)
+	if err != nil {
+		return err
+	}
+	unlock, err := s.LockNotebook(dir)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := unarchiveWorkspaceDir(name, dir, archived); err != nil {
+		return err
+	}
+	s.opLog("unarchive_workspace", "", name).WithField("path", dir).Info("Unarchived workspace")
+	return nil
+}
+
+// ListArchivedWorkspaces returns the archived workspaces of every notebook
+// holding a known workspace, sorted by name.
+func (s *Service) ListArchivedWorkspaces() ([]ArchivedWorkspace, error) {
+	if s.workspaceProvider == nil {
+		return nil, nil
+	}
+	seen := make(map[string]bool)
+	var archived []ArchivedWorkspace
+	for _, ws := range s.workspaceProvider.All() {
+		contextNode, err := s.findNotebookContextNode(ws)
+		if err != nil {
+			continue
+		}
+		dir, err := s.notebookLocator.GetNotesDir(contextNode, "")
+		if err != nil {
+			continue
+		}
+		parent := filepath.Dir(dir)
+		if filepath.Base(parent) != "workspaces" || seen[parent] {
+			continue
+		}
+		seen[parent] = true
+		found, err := listArchivedWorkspaces(filepath.Join(parent, ArchivedWorkspacesDir))
+		if err != nil {
+			return nil, err
+		}
+		archived = append(archived, found...)
+	}
+	sort.Slice(archived, func(i, j int) bool { return archived[i].Name < archived[j].Name })
+	return archived, nil
+}
+
+// workspaceArchivePaths returns the notebook directory of the workspace
+// called name and the directory ArchiveWorkspace moves it to.
+func (s *Service) workspaceArchivePaths(name string) (string, string, error) {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return "", "", fmt.Errorf("workspace name cannot be empty")
+	case name == globalWorkspace:
+		return "", "", fmt.Errorf("the global workspace cannot be archived")
+	case strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, "."):
+		return "", "", fmt.Errorf("invalid workspace name %q", name)
+	}
+	if s.workspaceProvider == nil {
+		return "", "", fmt.Errorf("workspace not found: %s", name)
+	}
+	ws := s.workspaceProvider.FindByName(name)
+	if ws == nil {
+		return "", "", fmt.Errorf("workspace not found: %s", name)
+	}
+	contextNode, err := s.findNotebookContextNode(ws)
+	if err != nil {
+		return "", "", fmt.Errorf("find notebook for %s: %w", name, err)
+	}
+	// A worktree keeps its notes in its parent's notebook directory, which
+	// archiving it would take away from the parent and its other worktrees.
+	if contextNode.Path != ws.Path {
+		return "", "", fmt.Errorf("workspace %s keeps its notes in the notebook of %s; archive %s instead", name, contextNode.Name, contextNode.Name)
+	}
+	dir, err := s.notebookLocator.GetNotesDir(ws, "")
+	if err != nil {
+		return "", "", fmt.Errorf("get notebook dir: %w", err)
+	}
+	parent := filepath.Dir(dir)
+	if filepath.Base(parent) != "workspaces" {
+		return "", "", fmt.Errorf("workspace %s keeps its notes in %s, outside a notebook's workspaces directory", name, dir)
+	}
+	return dir, filepath.Join(parent, ArchivedWorkspacesDir, filepath.Base(dir)), nil
+}
+
+// archiveWorkspaceDir moves dir to archived and records the archive info.
+func archiveWorkspaceDir(name, dir, archived string, now time.Time) error {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		if _, err := os.Stat(archived); err == nil {
+			return fmt.Errorf("workspace %s is already archived", name)
+		}
+		return fmt.Errorf("workspace %s has no notebook directory at %s", name, dir)
+	}
+	if _, err := os.Stat(archived); err == nil {
+		return fmt.Errorf("cannot archive workspace %s: %s already exists", name, archived)
+	}
+	if err := os.MkdirAll(filepath.Dir(archived), 0o755); err != nil {
+		return fmt.Errorf("create archive directory: %w", err)
+	}
+	if err := os.Rename(dir, archived); err != nil {
+		return fmt.Errorf("move workspace notebook: %w", err)
+	}
+
+	data, err := yaml.Marshal(&WorkspaceArchiveInfo{Workspace: name, ArchivedAt: now.UTC(), Source: dir})
+	if err != nil {
+		return fmt.Errorf("marshal archive info: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(archived, WorkspaceArchiveInfoFilename), data, 0o644); err != nil {
+		return fmt.Errorf("write archive info: %w", err)
+	}
+	return nil
+}
+
+// unarchiveWorkspaceDir moves archived back to dir and removes its archive
+// info.
+func unarchiveWorkspaceDir(name, dir, archived string) error {
+	if info, err := os.Stat(archived); err != nil || !info.IsDir() {
+		return fmt.Errorf("workspace %s is not archived", name)
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("cannot unarchive workspace %s: %s already exists", name, dir)
+	}
+	if err := os.Rename(archived, dir); err != nil {
+		return fmt.Errorf("move workspace notebook: %w", err)
+	}
+	if err := os.Remove(filepath.Join(dir, WorkspaceArchiveInfoFilename)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove archive info: %w", err)
+	}
+	return nil
+}
+
+// listArchivedWorkspaces reads the archived workspaces in archiveDir. A
+// missing directory holds none.
+func listArchivedWorkspaces(archiveDir string) ([]ArchivedWorkspace, error) {
+	entries, err := os.ReadDir(archiveDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read archived workspaces: %w", err)
+	}
+	var archived []ArchivedWorkspace
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		ws := ArchivedWorkspace{Name: entry.Name(), Path: filepath.Join(archiveDir, entry.Name())}
+		if data, err := os.ReadFile(filepath.Join(ws.Path, WorkspaceArchiveInfoFilename)); err == nil {
+			var info WorkspaceArchiveInfo
+			if yaml.Unmarshal(data, &info) == nil {
+				ws.Info = &info
+			}
+		}
+		archived = append(archived, ws)
+	}
+	return archived, nil
+}
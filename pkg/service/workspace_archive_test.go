@@ -0,0 +1,89 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveWorkspaceDirRoundTrip(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "workspaces")
+	dir := filepath.Join(root, "old-project")
+	archived := filepath.Join(root, ArchivedWorkspacesDir, "old-project")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "inbox"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "inbox", "note.md"), []byte("# Note\n"), 0o644))
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, archiveWorkspaceDir("old-project", dir, archived, now))
+
+	assert.NoDirExists(t, dir)
+	assert.FileExists(t, filepath.Join(archived, "inbox", "note.md"))
+
+	list, err := listArchivedWorkspaces(filepath.Join(root, ArchivedWorkspacesDir))
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "old-project", list[0].Name)
+	require.NotNil(t, list[0].Info)
+	assert.True(t, now.Equal(list[0].Info.ArchivedAt))
+	assert.Equal(t, dir, list[0].Info.Source)
+
+	err = archiveWorkspaceDir("old-project", dir, archived, now)
+	assert.ErrorContains(t, err, "already archived")
+
+	require.NoError(t, unarchiveWorkspaceDir("old-project", dir, archived))
+	assert.FileExists(t, filepath.Join(dir, "inbox", "note.md"))
+	assert.NoFileExists(t, filepath.Join(dir, WorkspaceArchiveInfoFilename))
+
+	err = unarchiveWorkspaceDir("old-project", dir, archived)
+	assert.ErrorContains(t, err, "not archived")
+}
+
+func TestArchiveWorkspaceDirRefusesToOverwrite(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "workspaces")
+	dir := filepath.Join(root, "proj")
+	archived := filepath.Join(root, ArchivedWorkspacesDir, "proj")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.MkdirAll(archived, 0o755))
+
+	err := archiveWorkspaceDir("proj", dir, archived, time.Now())
+	assert.ErrorContains(t, err, "already exists")
+	assert.DirExists(t, dir)
+
+	err = unarchiveWorkspaceDir("proj", dir, archived)
+	assert.ErrorContains(t, err, "already exists")
+	assert.DirExists(t, archived)
+}
+
+func TestListArchivedWorkspacesMissingDir(t *testing.T) {
+	list, err := listArchivedWorkspaces(filepath.Join(t.TempDir(), "nope"))
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestArchiveWorkspaceRefusesWorktree(t *testing.T) {
+	t.Setenv("GROVE_HOME", t.TempDir())
+	proj := filepath.Join(t.TempDir(), "proj")
+	worktree := filepath.Join(proj, ".grove-worktrees", "feature")
+	require.NoError(t, os.MkdirAll(worktree, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(proj, "grove.yml"), []byte("name: proj\n"), 0o644))
+
+	s := newTestService()
+	s.workspaceProvider = coreworkspace.NewProvider(&coreworkspace.DiscoveryResult{Projects: []coreworkspace.Project{{
+		Name: "proj",
+		Path: proj,
+		Workspaces: []coreworkspace.DiscoveredWorkspace{
+			{Name: "proj", Path: proj, Type: coreworkspace.WorkspaceTypePrimary},
+			{Name: "feature", Path: worktree, Type: coreworkspace.WorkspaceTypeWorktree, ParentProjectPath: proj},
+		},
+	}}})
+
+	err := s.ArchiveWorkspace("feature")
+	assert.ErrorContains(t, err, "keeps its notes in the notebook of proj")
+	err = s.UnarchiveWorkspace("feature")
+	assert.ErrorContains(t, err, "keeps its notes in the notebook of proj")
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// workspaceSummaryGroups is how many groups WorkspaceSummary.String names
+// before folding the rest into "+N groups".
+const workspaceSummaryGroups = 3
+
+// WorkspaceSummary is a quick overview of one workspace's notebook.
+type WorkspaceSummary struct {
+	Workspace    string       `json:"workspace"`
+	NoteCount    int          `json:"note_count"`
+	Groups       []GroupCount `json:"groups"` // Most notes first
+	LastActivity time.Time    `json:"last_activity,omitempty"`
+	OpenTasks    int          `json:"open_tasks"`
+}
+
+// GroupCount is the number of notes in one group.
+type GroupCount struct {
+	Group string `json:"group"`
+	Count int    `json:"count"`
+}
+
+// WorkspaceSummary counts the notes in ws per group, their open tasks and
+// when one was last modified. Archived notes are only counted with
+// includeArchived; artifacts never are.
+func (s *Service) WorkspaceSummary(ws *coreworkspace.WorkspaceNode, includeArchived bool) (*WorkspaceSummary, error) {
+	ctx, err := s.GetWorkspaceContext(ws.Path)
+	if err != nil {
+		return nil, fmt.Errorf("get workspace context: %w", err)
+	}
+	notes, err := s.ListAllNotes(ctx, includeArchived, false)
+	if err != nil {
+		return nil, fmt.Errorf("list notes: %w", err)
+	}
+	return summarizeNotes(ctx.NotebookContextWorkspace.Name, notes), nil
+}
+
+// summarizeNotes builds a WorkspaceSummary from a workspace's notes.
+func summarizeNotes(workspace string, notes []*models.Note) *WorkspaceSummary {
+	summary := &WorkspaceSummary{Workspace: workspace, NoteCount: len(notes)}
+	counts := make(map[string]int)
+	for _, note := range notes {
+		group := note.Group
+		if group == "" {
+			group = string(note.Type)
+		}
+		counts[group]++
+		summary.OpenTasks += note.TodoOpen
+		if note.ModifiedAt.After(summary.LastActivity) {
+			summary.LastActivity = note.ModifiedAt
+		}
+	}
+
+	summary.Groups = make([]GroupCount, 0, len(counts))
+	for group, n := range counts {
+		summary.Groups = append(summary.Groups, GroupCount{Group: group, Count: n})
+	}
+	sort.Slice(summary.Groups, func(i, j int) bool {
+		if summary.Groups[i].Count != summary.Groups[j].Count {
+			return summary.Groups[i].Count > summary.Groups[j].Count
+		}
+		return summary.Groups[i].Group < summary.Groups[j].Group
+	})
+	return summary
+}
+
+// String renders the summary on one line, e.g.
+// "42 notes (inbox 20, learn 12, plans/api 5, +2 groups) · 7 open tasks · active 3h ago".
+func (w *WorkspaceSummary) String() string {
+	return w.format(time.Now())
+}
+
+func (w *WorkspaceSummary) format(now time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %s", w.NoteCount, plural(w.NoteCount, "note", "notes"))
+	if len(w.Groups) > 0 {
+		var parts []string
+		for i, g := range w.Groups {
+			if i == workspaceSummaryGroups {
+				rest := len(w.Groups) - i
+				parts = append(parts, fmt.Sprintf("+%d %s", rest, plural(rest, "group", "groups")))
+				break
+			}
+			parts = append(parts, fmt.Sprintf("%s %d", g.Group, g.Count))
+		}
+		fmt.Fprintf(&b, " (%s)", strings.Join(parts, ", "))
+	}
+	fmt.Fprintf(&b, " · %d open %s", w.OpenTasks, plural(w.OpenTasks, "task", "tasks"))
+	if !w.LastActivity.IsZero() {
+		fmt.Fprintf(&b, " · active %s", formatSince(now.Sub(w.LastActivity)))
+	}
+	return b.String()
+}
+
+// formatSince renders an elapsed time coarsely: "just now", "5m ago", "3h
+// ago" or "12d ago".
+func formatSince(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
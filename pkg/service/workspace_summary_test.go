@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestSummarizeNotes(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	notes := []*models.Note{
+		{Group: "inbox", TodoOpen: 2, ModifiedAt: now.Add(-48 * time.Hour)},
+		{Group: "inbox", ModifiedAt: now.Add(-3 * time.Hour)},
+		{Group: "learn", TodoOpen: 1},
+		{Group: "plans/api"},
+		{Group: "quick"},
+		{Type: "daily"},
+	}
+
+	summary := summarizeNotes("proj", notes)
+	assert.Equal(t, 6, summary.NoteCount)
+	assert.Equal(t, 3, summary.OpenTasks)
+	assert.Equal(t, now.Add(-3*time.Hour), summary.LastActivity)
+	assert.Equal(t, GroupCount{Group: "inbox", Count: 2}, summary.Groups[0])
+	assert.Len(t, summary.Groups, 5)
+	assert.Equal(t, "6 notes (inbox 2, daily 1, learn 1, +2 groups) · 3 open tasks · active 3h ago", summary.format(now))
+}
+
+func TestSummarizeNotesEmpty(t *testing.T) {
+	summary := summarizeNotes("proj", nil)
+	assert.Equal(t, "0 notes · 0 open tasks", summary.format(time.Now()))
+}
@@ -0,0 +1,233 @@
+package sync
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+	"github.com/grovetools/nb/pkg/models"
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// caldavProvider names CalDAV exports in their Report.
+const caldavProvider = "caldav"
+
+// caldavClient sends the PUT requests of a CalDAV export.
+var caldavClient = &http.Client{Timeout: 30 * time.Second}
+
+// CalDAVEvent is an all-day calendar event made from a daily note.
+type CalDAVEvent struct {
+	UID         string
+	Date        time.Time // Only the calendar day is used
+	Summary     string
+	Description string
+	Modified    time.Time
+}
+
+// ExportToCalDAV PUTs every daily note in ctx that has a date (a `date` or
+// `created` frontmatter field) to the CalDAV calendar collection at calURL as
+// an all-day event, authenticating with username and password when username
+// is set. Each note is stored as <uid>.ics, so exporting again updates the
+// events in place. Notes without a date are counted as skipped. Notes that
+// fail are counted and listed in the report; the export carries on with the
+// rest.
+//
+// NOTE: This is a Syncer method rather than a Service one, as pkg/sync
+// already imports pkg/service.
+func (s *Syncer) ExportToCalDAV(ctx *service.WorkspaceContext, calURL, username, password string) (*Report, error) {
+	if calURL == "" {
+		return nil, fmt.Errorf("no CalDAV URL configured (set caldav.url in the nb config)")
+	}
+	notes, err := s.svc.ListNotes(ctx, "daily")
+	if err != nil {
+		return nil, fmt.Errorf("list daily notes: %w", err)
+	}
+
+	report := &Report{Provider: caldavProvider}
+	for _, note := range notes {
+		ev, ok := dailyNoteEvent(note)
+		if !ok {
+			report.Skipped++
+			continue
+		}
+		created, err := putCalDAVEvent(caldavClient, calURL, username, password, ev)
+		if err != nil {
+			report.Failed++
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", filepath.Base(note.Path), err))
+			continue
+		}
+		if created {
+			report.Created++
+		} else {
+			report.Updated++
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"workspace": ctx.NotebookContextWorkspace.Name,
+		"created":   report.Created,
+		"updated":   report.Updated,
+		"skipped":   report.Skipped,
+		"failed":    report.Failed,
+	}).Info("Exported daily notes to CalDAV")
+	return report, nil
+}
+
+// dateFieldPattern matches a `date: YYYY-MM-DD` line in a note's frontmatter.
+// Frontmatter has no date field, so it is read from the raw block.
+var dateFieldPattern = regexp.MustCompile(`(?m)^date:\s*["']?(\d{4}-\d{2}-\d{2})`)
+
+// dailyNoteEvent maps a daily note to its calendar event. The day comes from
+// the note's `date` field, else its `created` timestamp; a note with neither
+// (or without frontmatter) has no event.
+func dailyNoteEvent(note *models.Note) (*CalDAVEvent, bool) {
+	fm, body, err := frontmatter.Parse(note.Content)
+	if err != nil || fm == nil {
+		return nil, false
+	}
+	date, ok := noteDay(note.Content, fm)
+	if !ok {
+		return nil, false
+	}
+
+	title := fm.Title
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(note.Path), ".md")
+	}
+	body = strings.TrimSpace(body)
+	// The title is the summary; don't repeat it as the body's heading.
+	body = strings.TrimSpace(strings.TrimPrefix(body, "# "+title))
+
+	uid := fm.ID
+	if uid == "" {
+		uid = "nb-" + strings.TrimSuffix(filepath.Base(note.Path), ".md")
+	}
+	return &CalDAVEvent{
+		UID:         uid,
+		Date:        date,
+		Summary:     title,
+		Description: body,
+		Modified:    note.ModifiedAt,
+	}, true
+}
+
+// noteDay returns the day of a daily note: its `date` field, else its
+// `created` timestamp.
+func noteDay(content string, fm *frontmatter.Frontmatter) (time.Time, bool) {
+	// The block ends at the closing delimiter; the opening one starts the
+	// content, so it has no newline before it.
+	block := content
+	if end := strings.Index(content, "\n---"); end >= 0 {
+		block = content[:end]
+	}
+	if m := dateFieldPattern.FindStringSubmatch(block); m != nil {
+		t, err := time.Parse("2006-01-02", m[1])
+		return t, err == nil
+	}
+	if fm.Created != "" {
+		t, err := frontmatter.ParseTimestamp(fm.Created)
+		return t, err == nil
+	}
+	return time.Time{}, false
+}
+
+// putCalDAVEvent stores ev in the calendar collection at calURL as
+// <uid>.ics. It reports whether the server created a new resource (201) as
+// opposed to replacing an existing one.
+func putCalDAVEvent(client *http.Client, calURL, username, password string, ev *CalDAVEvent) (bool, error) {
+	target := strings.TrimRight(calURL, "/") + "/" + url.PathEscape(ev.UID) + ".ics"
+	req, err := http.NewRequest(http.MethodPut, target, strings.NewReader(EncodeVCalendar(ev)))
+	if err != nil {
+		return false, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/calendar; charset=utf-8")
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("put event: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return false, fmt.Errorf("put event: server returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp.StatusCode == http.StatusCreated, nil
+}
+
+// EncodeVCalendar renders ev as a VCALENDAR holding a single all-day VEVENT
+// (RFC 5545): DTEND is the day after DTSTART, text is escaped, and lines are
+// folded at 75 octets and end in CRLF.
+func EncodeVCalendar(ev *CalDAVEvent) string {
+	stamp := ev.Modified
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	day := time.Date(ev.Date.Year(), ev.Date.Month(), ev.Date.Day(), 0, 0, 0, 0, time.UTC)
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//grovetools//nb//EN",
+		"BEGIN:VEVENT",
+		"UID:" + escapeICalText(ev.UID),
+		"DTSTAMP:" + stamp.UTC().Format("20060102T150405Z"),
+		"DTSTART;VALUE=DATE:" + day.Format("20060102"),
+		"DTEND;VALUE=DATE:" + day.AddDate(0, 0, 1).Format("20060102"),
+		"SUMMARY:" + escapeICalText(ev.Summary),
+	}
+	if ev.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeICalText(ev.Description))
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(foldICalLine(line))
+		b.WriteString("\r\n")
+	}
+	return b.String()
+}
+
+// escapeICalText escapes a TEXT property value: backslashes, semicolons,
+// commas and newlines.
+func escapeICalText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// foldICalLine splits a content line longer than 75 octets into a first line
+// and continuation lines starting with a space, never inside a UTF-8
+// sequence.
+func foldICalLine(line string) string {
+	const limit = 75
+	if len(line) <= limit {
+		return line
+	}
+	var b strings.Builder
+	width := limit
+	for len(line) > width {
+		cut := width
+		// Step back to the start of a rune.
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines lose one octet to the leading space.
+		width = limit - 1
+	}
+	b.WriteString(line)
+	return b.String()
+}
@@ -0,0 +1,129 @@
+package sync
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestEncodeVCalendar(t *testing.T) {
+	ev := &CalDAVEvent{
+		UID:         "20240301-daily",
+		Date:        time.Date(2024, 3, 1, 22, 0, 0, 0, time.UTC),
+		Summary:     "Daily Note: 2024-03-01",
+		Description: "Tasks; done, mostly\n" + strings.Repeat("é", 60),
+		Modified:    time.Date(2024, 3, 2, 8, 0, 0, 0, time.UTC),
+	}
+	out := EncodeVCalendar(ev)
+
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.Contains(t, out, "\r\nDTSTART;VALUE=DATE:20240301\r\n")
+	assert.Contains(t, out, "\r\nDTEND;VALUE=DATE:20240302\r\n", "all-day events end the next day")
+	assert.Contains(t, out, "\r\nDTSTAMP:20240302T080000Z\r\n")
+	assert.Contains(t, out, `DESCRIPTION:Tasks\; done\, mostly\n`)
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, "line not folded: %q", line)
+	}
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	assert.Contains(t, unfolded, strings.Repeat("é", 60), "folding splits no UTF-8 sequence")
+}
+
+func TestDailyNoteEvent(t *testing.T) {
+	note := &models.Note{
+		Path:    "/nb/daily/20240301-daily.md",
+		Content: "---\nid: d1\ntitle: Friday\ndate: 2024-03-01\ncreated: 2024-02-28T10:00:00Z\n---\n# Friday\n\nShipped it.\n",
+	}
+	ev, ok := dailyNoteEvent(note)
+	require.True(t, ok)
+	assert.Equal(t, "d1", ev.UID)
+	assert.Equal(t, "Friday", ev.Summary)
+	assert.Equal(t, "Shipped it.", ev.Description)
+	assert.Equal(t, "2024-03-01", ev.Date.Format("2006-01-02"), "date wins over created")
+
+	note.Content = "---\ntitle: Saturday\ncreated: 2024-03-02T10:00:00Z\n---\nbody\n"
+	ev, ok = dailyNoteEvent(note)
+	require.True(t, ok)
+	assert.Equal(t, "2024-03-02", ev.Date.Format("2006-01-02"))
+	assert.Equal(t, "nb-20240301-daily", ev.UID)
+
+	note.Content = "---\ntitle: Undated\n---\ndate: 2024-03-03\n"
+	_, ok = dailyNoteEvent(note)
+	assert.False(t, ok, "a date in the body is not the note's date")
+}
+
+func TestPutCalDAVEvent(t *testing.T) {
+	var gotPath, gotUser, gotType, gotBody string
+	existing := map[string]bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		gotPath = r.URL.Path
+		gotUser, _, _ = r.BasicAuth()
+		gotType = r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		if existing[r.URL.Path] {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		existing[r.URL.Path] = true
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	ev := &CalDAVEvent{UID: "d1", Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Summary: "Friday"}
+	created, err := putCalDAVEvent(srv.Client(), srv.URL+"/cal/personal/", "me", "secret", ev)
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "/cal/personal/d1.ics", gotPath)
+	assert.Equal(t, "me", gotUser)
+	assert.Equal(t, "text/calendar; charset=utf-8", gotType)
+	assert.Contains(t, gotBody, "SUMMARY:Friday")
+
+	created, err = putCalDAVEvent(srv.Client(), srv.URL+"/cal/personal", "me", "secret", ev)
+	require.NoError(t, err)
+	assert.False(t, created, "a second export replaces the event")
+
+	denied := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad credentials", http.StatusUnauthorized)
+	}))
+	defer denied.Close()
+	_, err = putCalDAVEvent(denied.Client(), denied.URL, "me", "wrong", ev)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
+
+func TestExportToCalDAVSkipsUndatedNotes(t *testing.T) {
+	syncer, ctx := newWebhookTestSyncer(t, &fakeProvider{})
+	dailyDir, err := syncer.svc.GetNotebookLocator().GetNotesDir(ctx.NotebookContextWorkspace, "daily")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(dailyDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dailyDir, "20240301-daily.md"),
+		[]byte("---\ntitle: Friday\ndate: 2024-03-01\n---\nShipped it.\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dailyDir, "scratch.md"), []byte("No frontmatter.\n"), 0o644))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	report, err := syncer.ExportToCalDAV(ctx, srv.URL, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Skipped, "an undated note is skipped")
+	assert.Equal(t, 0, report.Unchanged, "a skipped note is not unchanged")
+}
@@ -2,8 +2,12 @@ package sync
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	coreconfig "github.com/grovetools/core/config"
+
+	"github.com/grovetools/nb/pkg/models"
 )
 
 // SyncConfig holds the configuration for a single sync provider for a notebook.
@@ -13,6 +17,65 @@ type SyncConfig struct {
 	PRsType    string
 }
 
+// noteTypeFor returns the note type a remote item is pulled into, and false
+// when the config doesn't sync items of its type.
+func (c SyncConfig) noteTypeFor(item *Item) (models.NoteType, bool) {
+	switch {
+	case item.Type == "issue" && c.IssuesType != "":
+		return models.NoteType(c.IssuesType), true
+	case (item.Type == "pr" || item.Type == "pull_request") && c.PRsType != "":
+		return models.NoteType(c.PRsType), true
+	}
+	return "", false
+}
+
+// SyncDirection controls which way SyncWorkspace moves changes.
+type SyncDirection string
+
+const (
+	// DirectionBoth pulls remote changes and pushes local ones.
+	DirectionBoth SyncDirection = "both"
+	// DirectionPull only fetches from the remote, creating and updating local notes.
+	DirectionPull SyncDirection = "pull"
+	// DirectionPush only sends local notes, comments and edits to the remote.
+	DirectionPush SyncDirection = "push"
+)
+
+// ParseSyncDirection converts a --direction value into a SyncDirection. An
+// empty string means DirectionBoth.
+func ParseSyncDirection(s string) (SyncDirection, error) {
+	switch d := SyncDirection(strings.ToLower(strings.TrimSpace(s))); d {
+	case "":
+		return DirectionBoth, nil
+	case DirectionBoth, DirectionPull, DirectionPush:
+		return d, nil
+	default:
+		return "", fmt.Errorf("invalid sync direction %q (expected pull, push or both)", s)
+	}
+}
+
+func (d SyncDirection) pulls() bool { return d != DirectionPush }
+
+func (d SyncDirection) pushes() bool { return d != DirectionPull }
+
+// SyncOptions configures a SyncWorkspace run.
+type SyncOptions struct {
+	// Direction defaults to DirectionBoth when empty.
+	Direction SyncDirection
+	// Provider restricts the sync to a single provider (e.g. "github"). Empty
+	// syncs with every configured provider.
+	Provider string
+	// Since makes the sync incremental: only remote items updated after it
+	// are fetched. Zero fetches everything.
+	Since time.Time
+	// SinceLastSync takes Since from each remote's LastSyncAt in the
+	// workspace's sync state. A remote that was never synced is synced fully.
+	SinceLastSync bool
+	// Incremental takes Since from the repository's watermark for each
+	// remote in SyncWatermarksPath. A remote without one is synced fully.
+	Incremental bool
+}
+
 // GetSyncConfigForNotebook extracts the sync provider configurations for a
 // specific notebook from the global grove config. The legacy list shape of
 // the `sync` key decodes into SyncConfig.Providers in core.
@@ -34,7 +34,7 @@ func (p *GitHubProvider) Sync(config map[string]string, repoPath string) ([]*syn
 
 	// Sync issues if configured
 	if _, ok := config["issues_type"]; ok {
-		issueItems, err := p.fetchItems("issue", repoPath)
+		issueItems, err := p.fetchItems("issue", repoPath, config["since"])
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch issues: %w", err)
 		}
@@ -43,7 +43,7 @@ func (p *GitHubProvider) Sync(config map[string]string, repoPath string) ([]*syn
 
 	// Sync pull requests if configured
 	if _, ok := config["prs_type"]; ok {
-		prItems, err := p.fetchItems("pr", repoPath)
+		prItems, err := p.fetchItems("pr", repoPath, config["since"])
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch pull requests: %w", err)
 		}
@@ -257,9 +257,13 @@ type ghItem struct {
 	} `json:"milestone"`
 }
 
-// fetchItems executes the gh command to get issues or PRs.
-func (p *GitHubProvider) fetchItems(itemType string, repoPath string) ([]*sync.Item, error) {
+// fetchItems executes the gh command to get issues or PRs. A non-empty since
+// (RFC3339) limits the list to items updated after it.
+func (p *GitHubProvider) fetchItems(itemType string, repoPath string, since string) ([]*sync.Item, error) {
 	cmdArgs := []string{itemType, "list", "--state", "all", "--limit", "200", "--json", "id,number,title,body,state,url,updatedAt,labels,assignees,milestone,comments"}
+	if since != "" {
+		cmdArgs = append(cmdArgs, "--search", "updated:>"+since)
+	}
 	cmd := exec.Command("gh", cmdArgs...)
 	cmd.Dir = repoPath
 
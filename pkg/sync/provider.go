@@ -6,7 +6,8 @@ import "time"
 type Provider interface {
 	// Name returns the provider's name (e.g., "github").
 	Name() string
-	// Sync fetches all relevant items from the remote.
+	// Sync fetches all relevant items from the remote. When config has a
+	// "since" entry (RFC3339), only items updated after it are needed.
 	Sync(config map[string]string, repoPath string) ([]*Item, error)
 	// CreateItem creates a new item on the remote and returns the created item.
 	CreateItem(item *Item, repoPath string) (*Item, error)
@@ -43,10 +44,16 @@ type Item struct {
 
 // Report summarizes the results of a sync operation.
 type Report struct {
-	Provider  string
-	Created   int
-	Updated   int
-	Unchanged int
-	Failed    int
-	Errors    []string // Detailed error messages
+	Provider  string    `json:"provider"`
+	Created   int       `json:"created"`
+	Updated   int       `json:"updated"`
+	Unchanged int       `json:"unchanged"`
+	Skipped   int       `json:"skipped,omitempty"` // Items the sync can't handle, e.g. undated daily notes
+	Failed    int       `json:"failed"`
+	Errors    []string  `json:"errors,omitempty"` // Detailed error messages
+	Fetched   int       `json:"fetched"`          // Remote items fetched
+	Since     time.Time `json:"since"`            // Non-zero for an incremental sync
+	// Error is set when the sync with the provider failed as a whole, as
+	// opposed to failing for some items.
+	Error string `json:"error,omitempty"`
 }
@@ -0,0 +1,90 @@
+package sync
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/nb/pkg/models"
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// defaultPushProvider is the provider new remote items are created with.
+const defaultPushProvider = "github"
+
+// CreateRemoteIssue creates an issue from note in ctx's repository and
+// records the new issue (its ID and URL) in the note's remote frontmatter.
+// The note's tags become the issue's labels. It returns the issue URL.
+func (s *Syncer) CreateRemoteIssue(ctx *service.WorkspaceContext, note *models.Note) (string, error) {
+	if note.Remote != nil && note.Remote.ID != "" {
+		return "", fmt.Errorf("%s is already synced to %s", note.Path, note.Remote.URL)
+	}
+	provider, err := s.provider(defaultPushProvider)
+	if err != nil {
+		return "", err
+	}
+
+	item, err := s.noteToSyncItem(note)
+	if err != nil {
+		return "", fmt.Errorf("convert note to sync item: %w", err)
+	}
+	item.Type = "issue"
+	// Pushing a single note is explicit, so unlike a workspace sync its
+	// local tags are sent as labels.
+	if len(item.Labels) == 0 {
+		item.Labels = note.Tags
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"note_path": note.Path,
+		"title":     item.Title,
+		"labels":    item.Labels,
+	}).Info("Creating remote issue from note")
+
+	unlock, err := s.svc.LockNotebook(note.Path)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	created, err := provider.CreateItem(item, ctx.CurrentWorkspace.Path)
+	if err != nil {
+		return "", fmt.Errorf("create remote issue: %w", err)
+	}
+	if err := s.updateNoteWithRemoteData(note, created); err != nil {
+		return created.URL, fmt.Errorf("record remote issue in note: %w", err)
+	}
+	return created.URL, nil
+}
+
+// SyncNoteToRemote pushes a single note: a note already linked to a remote
+// item updates it with the note's title and body, any other note becomes a
+// new issue via CreateRemoteIssue.
+func (s *Syncer) SyncNoteToRemote(ctx *service.WorkspaceContext, note *models.Note) error {
+	if note.Remote == nil || note.Remote.ID == "" {
+		_, err := s.CreateRemoteIssue(ctx, note)
+		return err
+	}
+	provider, err := s.provider(note.Remote.Provider)
+	if err != nil {
+		return err
+	}
+	unlock, err := s.svc.LockNotebook(note.Path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return s.pushNoteToRemote(note, provider, ctx.CurrentWorkspace.Path)
+}
+
+// provider instantiates the registered provider called name.
+func (s *Syncer) provider(name string) (Provider, error) {
+	if name == "" {
+		name = defaultPushProvider
+	}
+	factory, ok := s.providerFactories[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported or unregistered provider: %s", name)
+	}
+	return factory(), nil
+}
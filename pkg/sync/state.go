@@ -0,0 +1,137 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// SyncStateFile is the name of the file, in the workspace directory, that
+// records when each remote was last synced.
+const SyncStateFile = ".nb-sync-state.json"
+
+// SyncState is the last-sync bookkeeping for one workspace, keyed by remote
+// (provider) name.
+type SyncState struct {
+	Remotes map[string]RemoteSyncState `json:"remotes"`
+}
+
+// RemoteSyncState records the last successful sync with one remote.
+type RemoteSyncState struct {
+	// LastSyncAt is when the sync started, so items updated while it ran are
+	// picked up by the next incremental sync.
+	LastSyncAt time.Time `json:"last_sync_at"`
+	// LastSyncCount is the number of remote items fetched.
+	LastSyncCount int `json:"last_sync_count"`
+}
+
+// LoadSyncState reads the sync state in dir. A missing file yields an empty
+// state.
+func LoadSyncState(dir string) (*SyncState, error) {
+	state := &SyncState{Remotes: make(map[string]RemoteSyncState)}
+	data, err := os.ReadFile(filepath.Join(dir, SyncStateFile))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read sync state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parse sync state %s: %w", filepath.Join(dir, SyncStateFile), err)
+	}
+	if state.Remotes == nil {
+		state.Remotes = make(map[string]RemoteSyncState)
+	}
+	return state, nil
+}
+
+// Save writes the state to dir, replacing the previous file atomically.
+func (st *SyncState) Save(dir string) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode sync state: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create sync state directory: %w", err)
+	}
+	tmp := filepath.Join(dir, SyncStateFile+".tmp")
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write sync state: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, SyncStateFile)); err != nil {
+		return fmt.Errorf("write sync state: %w", err)
+	}
+	return nil
+}
+
+// SyncWatermarks records, per repository and remote, when the last clean
+// pull started. It backs --incremental and lives in SyncWatermarksPath, so
+// every workspace syncing the same repository shares it.
+type SyncWatermarks struct {
+	// Repos maps a repository path to the watermark of each remote.
+	Repos map[string]map[string]time.Time `json:"repos"`
+}
+
+// SyncWatermarksPath returns the file SyncWatermarks are kept in,
+// ~/.grove/nb/sync-state.json.
+func SyncWatermarksPath() string {
+	return filepath.Join(service.NBHomeDir(), "sync-state.json")
+}
+
+// LoadSyncWatermarks reads the watermarks at path. A missing file yields no
+// watermarks.
+func LoadSyncWatermarks(path string) (*SyncWatermarks, error) {
+	marks := &SyncWatermarks{Repos: make(map[string]map[string]time.Time)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return marks, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read sync watermarks: %w", err)
+	}
+	if err := json.Unmarshal(data, marks); err != nil {
+		return nil, fmt.Errorf("parse sync watermarks %s: %w", path, err)
+	}
+	if marks.Repos == nil {
+		marks.Repos = make(map[string]map[string]time.Time)
+	}
+	return marks, nil
+}
+
+// Since returns the watermark of remote in repo, or the zero time when it
+// was never synced.
+func (w *SyncWatermarks) Since(repo, remote string) time.Time {
+	return w.Repos[repo][remote]
+}
+
+// Set records at as the watermark of remote in repo.
+func (w *SyncWatermarks) Set(repo, remote string, at time.Time) {
+	if w.Repos[repo] == nil {
+		w.Repos[repo] = make(map[string]time.Time)
+	}
+	w.Repos[repo][remote] = at
+}
+
+// Save writes the watermarks to path, replacing the previous file
+// atomically.
+func (w *SyncWatermarks) Save(path string) error {
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode sync watermarks: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create sync watermarks directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write sync watermarks: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("write sync watermarks: %w", err)
+	}
+	return nil
+}
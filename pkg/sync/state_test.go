@@ -0,0 +1,81 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncStateRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	state, err := LoadSyncState(dir)
+	require.NoError(t, err)
+	assert.Empty(t, state.Remotes, "missing file gives an empty state")
+
+	at := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	state.Remotes["github"] = RemoteSyncState{LastSyncAt: at, LastSyncCount: 4}
+	require.NoError(t, state.Save(dir))
+
+	_, err = os.Stat(filepath.Join(dir, SyncStateFile+".tmp"))
+	assert.True(t, os.IsNotExist(err), "temp file is renamed into place")
+
+	loaded, err := LoadSyncState(dir)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(loaded.Remotes["github"].LastSyncAt))
+	assert.Equal(t, 4, loaded.Remotes["github"].LastSyncCount)
+}
+
+func TestLoadSyncStateRejectsCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, SyncStateFile), []byte("{not json"), 0o644))
+	_, err := LoadSyncState(dir)
+	assert.Error(t, err)
+}
+
+func TestSyncWatermarksRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nb", "sync-state.json")
+
+	marks, err := LoadSyncWatermarks(path)
+	require.NoError(t, err)
+	assert.True(t, marks.Since("/src/app", "github").IsZero(), "missing file gives no watermarks")
+
+	at := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	marks.Set("/src/app", "github", at)
+	require.NoError(t, marks.Save(path))
+
+	loaded, err := LoadSyncWatermarks(path)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(loaded.Since("/src/app", "github")))
+	assert.True(t, loaded.Since("/src/other", "github").IsZero())
+}
+
+func TestSyncWorkspaceIncremental(t *testing.T) {
+	provider := &fakeProvider{}
+	syncer, ctx := newWebhookTestSyncer(t, provider)
+	opts := SyncOptions{Direction: DirectionPull, Incremental: true}
+
+	before := time.Now()
+	reports, err := syncer.SyncWorkspace(ctx, opts)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.True(t, reports[0].Since.IsZero(), "no watermark means a full sync")
+	require.Len(t, provider.synced, 1)
+	assert.NotContains(t, provider.synced[0], "since")
+
+	marks, err := LoadSyncWatermarks(SyncWatermarksPath())
+	require.NoError(t, err)
+	watermark := marks.Since(ctx.CurrentWorkspace.Path, "github")
+	assert.False(t, watermark.Before(before), "a clean pull records the watermark")
+
+	reports, err = syncer.SyncWorkspace(ctx, opts)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.True(t, watermark.Equal(reports[0].Since))
+	require.Len(t, provider.synced, 2)
+	assert.Equal(t, watermark.UTC().Format(time.RFC3339), provider.synced[1]["since"])
+}
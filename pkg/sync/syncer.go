@@ -15,6 +15,10 @@ import (
 
 const syncMarker = "<!-- nb-sync-marker -->"
 
+// ErrSyncLocked is returned by a sync when another process kept the
+// notebook locked for too long.
+var ErrSyncLocked = service.ErrNotebookLocked
+
 // ProviderFactory is a function that creates a Provider instance.
 type ProviderFactory func() Provider
 
@@ -49,18 +53,28 @@ func (s *Syncer) needsUpdate(note *models.Note, item *Item) bool {
 	return item.UpdatedAt.After(note.Remote.UpdatedAt)
 }
 
+// remoteItemType is the provider item type ("issue" or "pr") a synced note
+// maps to, judged from its note type.
+func remoteItemType(note *models.Note) string {
+	if note.Remote != nil && note.Remote.Provider == "github" {
+		noteTypeStr := string(note.Type)
+		if strings.Contains(noteTypeStr, "pr") || strings.Contains(noteTypeStr, "pull") {
+			return "pr"
+		}
+	}
+	return "issue" //nolint:goconst
+}
+
 // SyncWorkspace syncs a given workspace with its configured remote providers.
-func (s *Syncer) SyncWorkspace(ctx *service.WorkspaceContext) ([]*Report, error) {
-	// Get notebook name from config
-	notebookName := "default"
-	if s.svc.CoreConfig != nil && s.svc.CoreConfig.Notebooks != nil && s.svc.CoreConfig.Notebooks.Rules != nil {
-		notebookName = s.svc.CoreConfig.Notebooks.Rules.Default
+func (s *Syncer) SyncWorkspace(ctx *service.WorkspaceContext, opts SyncOptions) ([]*Report, error) {
+	direction := opts.Direction
+	if direction == "" {
+		direction = DirectionBoth
 	}
 
-	// Get sync config for this notebook
-	syncConfigs, err := GetSyncConfigForNotebook(s.svc.CoreConfig, notebookName)
+	syncConfigs, err := s.syncConfigs()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read sync config: %w", err)
+		return nil, err
 	}
 
 	// If no sync configured, return empty
@@ -68,54 +82,139 @@ func (s *Syncer) SyncWorkspace(ctx *service.WorkspaceContext) ([]*Report, error)
 		return []*Report{}, nil
 	}
 
+	stateDir, err := s.syncStateDir(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Hold the notebook lock for the whole run, so a cron sync and an
+	// interactive one don't interleave and other processes can't change
+	// notes between the listing and the updates. Notes are created with
+	// WithNotebookLocked, as the lock doesn't nest.
+	unlock, err := s.svc.LockNotebookFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	state, err := LoadSyncState(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	stateChanged := false
+	watermarksPath := SyncWatermarksPath()
+	watermarks, err := LoadSyncWatermarks(watermarksPath)
+	if err != nil {
+		return nil, err
+	}
+	repo := ctx.CurrentWorkspace.Path
+
 	var allReports []*Report
 	for _, config := range syncConfigs {
+		if opts.Provider != "" && config.Provider != opts.Provider {
+			continue
+		}
 		factory, ok := s.providerFactories[config.Provider]
 		if !ok {
 			// Unsupported or unregistered provider
-			fmt.Printf("Unsupported or unregistered provider: %s\n", config.Provider)
+			s.logger.WithField("provider", config.Provider).Warn("Unsupported or unregistered sync provider")
 			continue
 		}
 
 		provider := factory()
 
-		report, err := s.syncWithProvider(ctx, provider, config)
+		since := opts.Since
+		switch {
+		case opts.Incremental:
+			since = watermarks.Since(repo, provider.Name())
+		case opts.SinceLastSync:
+			since = state.Remotes[provider.Name()].LastSyncAt
+		}
+		startedAt := time.Now()
+		report, err := s.syncWithProvider(ctx, provider, config, direction, since)
 		if err != nil {
-			// Log error but continue with other providers
-			fmt.Printf("Error syncing with provider %s: %v\n", provider.Name(), err)
+			// Record the error but continue with other providers
+			s.logger.WithError(err).WithField("provider", provider.Name()).Error("Sync with provider failed")
+			allReports = append(allReports, &Report{Provider: provider.Name(), Since: since, Error: err.Error()})
 			continue
 		}
 		allReports = append(allReports, report)
+
+		// Only a clean pull moves the mark forward: a push-only run did not
+		// apply remote changes, and failed items must be retried next time.
+		if direction.pulls() && report.Failed == 0 {
+			state.Remotes[provider.Name()] = RemoteSyncState{LastSyncAt: startedAt, LastSyncCount: report.Fetched}
+			watermarks.Set(repo, provider.Name(), startedAt)
+			stateChanged = true
+		}
 	}
 
+	if stateChanged {
+		if err := state.Save(stateDir); err != nil {
+			return allReports, err
+		}
+		if err := watermarks.Save(watermarksPath); err != nil {
+			return allReports, err
+		}
+	}
 	return allReports, nil
 }
 
+// syncConfigs returns the sync provider configurations of the default
+// notebook.
+func (s *Syncer) syncConfigs() ([]SyncConfig, error) {
+	notebookName := "default"
+	if s.svc.CoreConfig != nil && s.svc.CoreConfig.Notebooks != nil && s.svc.CoreConfig.Notebooks.Rules != nil {
+		notebookName = s.svc.CoreConfig.Notebooks.Rules.Default
+	}
+	syncConfigs, err := GetSyncConfigForNotebook(s.svc.CoreConfig, notebookName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync config: %w", err)
+	}
+	return syncConfigs, nil
+}
+
+// syncStateDir is the directory of ctx's workspace, where the sync state
+// file lives. Worktrees share their parent's notebook and so its state.
+func (s *Syncer) syncStateDir(ctx *service.WorkspaceContext) (string, error) {
+	ws := ctx.NotebookContextWorkspace
+	if ws == nil || ws.Path == "" {
+		return "", fmt.Errorf("resolve sync state directory: workspace has no directory")
+	}
+	return ws.Path, nil
+}
+
 // syncWithProvider handles the sync logic for a single configured provider.
 func (s *Syncer) syncWithProvider(
 	ctx *service.WorkspaceContext,
 	provider Provider,
 	config SyncConfig,
+	direction SyncDirection,
+	since time.Time,
 ) (*Report, error) {
-	report := &Report{Provider: provider.Name()}
+	report := &Report{Provider: provider.Name(), Since: since}
 	repoPath := ctx.CurrentWorkspace.Path
 
 	s.logger.WithFields(logrus.Fields{
 		"provider":  provider.Name(),
 		"workspace": ctx.CurrentWorkspace.Name,
 		"repo_path": repoPath,
+		"direction": direction,
+		"since":     since,
 	}).Debug("Starting sync with provider")
 
 	providerConfig := map[string]string{
 		"issues_type": config.IssuesType,
 		"prs_type":    config.PRsType,
 	}
+	if !since.IsZero() {
+		providerConfig["since"] = since.UTC().Format(time.RFC3339)
+	}
 
 	// 1. Fetch remote items and map them by ID
 	remoteItems, err := provider.Sync(providerConfig, repoPath)
 	if err != nil {
 		return nil, fmt.Errorf("provider %s sync failed: %w", provider.Name(), err)
 	}
+	report.Fetched = len(remoteItems)
 	remoteItemsMap := make(map[string]*Item)
 	for _, item := range remoteItems {
 		remoteItemsMap[item.ID] = item
@@ -137,6 +236,27 @@ func (s *Syncer) syncWithProvider(
 		}
 	}
 
+	// An incremental fetch leaves out items that only changed locally. Fetch
+	// those one by one so their local edits are still pushed.
+	if !since.IsZero() && direction.pushes() {
+		for id, note := range syncedNotesMap {
+			if _, ok := remoteItemsMap[id]; ok {
+				continue
+			}
+			info, err := os.Stat(note.Path)
+			if err != nil || !info.ModTime().After(since) {
+				continue
+			}
+			item, err := provider.GetItem(remoteItemType(note), id, repoPath)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to fetch %s for locally changed %s: %v", id, note.Path, err))
+				report.Failed++
+				continue
+			}
+			remoteItemsMap[id] = item
+		}
+	}
+
 	// 3. Sync existing items (updates and remote deletions)
 	allSyncedIDs := make(map[string]bool)
 	for id := range remoteItemsMap {
@@ -165,6 +285,10 @@ func (s *Syncer) syncWithProvider(
 			// If remote is newer, pull. If local is newer, push.
 			if remoteItem.UpdatedAt.After(fileMtime) {
 				// Remote is newer ("pull")
+				if !direction.pulls() {
+					report.Unchanged++
+					continue
+				}
 				if s.needsUpdate(localNote, remoteItem) {
 					if err := s.updateNoteFromItem(localNote, remoteItem); err != nil {
 						report.Failed++
@@ -176,6 +300,10 @@ func (s *Syncer) syncWithProvider(
 				}
 			} else if fileMtime.After(remoteItem.UpdatedAt) {
 				// Local is newer ("push")
+				if !direction.pushes() {
+					report.Unchanged++
+					continue
+				}
 				// Check for new local comments to push
 				content, err := os.ReadFile(localNote.Path)
 				if err != nil {
@@ -190,13 +318,7 @@ func (s *Syncer) syncWithProvider(
 				}
 
 				if localComment != "" {
-					itemType := "issue" //nolint:goconst
-					if localNote.Remote.Provider == "github" {
-						noteTypeStr := string(localNote.Type)
-						if strings.Contains(noteTypeStr, "pr") || strings.Contains(noteTypeStr, "pull") {
-							itemType = "pr"
-						}
-					}
+					itemType := remoteItemType(localNote)
 					err := provider.AddComment(itemType, localNote.Remote.ID, localComment, repoPath)
 					if err != nil {
 						report.Failed++
@@ -214,6 +336,15 @@ func (s *Syncer) syncWithProvider(
 							}
 						}
 					}
+				} else if direction == DirectionPush {
+					// Push-only: send the local title and body to the remote
+					// instead of restoring the synced section from it.
+					if err := s.pushNoteToRemote(localNote, provider, repoPath); err != nil {
+						report.Errors = append(report.Errors, fmt.Sprintf("failed to push %s: %v", localNote.Path, err))
+						report.Failed++
+					} else {
+						report.Updated++
+					}
 				} else {
 					// No local comment, but file is modified.
 					// Rebuild the synced section from remote to restore any deleted comments
@@ -231,12 +362,11 @@ func (s *Syncer) syncWithProvider(
 
 		// Case 2: Exists only remotely -> create locally
 		case !localExists && remoteExists:
-			var noteType models.NoteType
-			if remoteItem.Type == "issue" && config.IssuesType != "" {
-				noteType = models.NoteType(config.IssuesType)
-			} else if (remoteItem.Type == "pr" || remoteItem.Type == "pull_request") && config.PRsType != "" {
-				noteType = models.NoteType(config.PRsType)
-			} else {
+			if !direction.pulls() {
+				continue
+			}
+			noteType, ok := config.noteTypeFor(remoteItem)
+			if !ok {
 				continue // Skip
 			}
 			_, err := s.createNoteFromItem(ctx, remoteItem, noteType)
@@ -254,6 +384,9 @@ func (s *Syncer) syncWithProvider(
 	}
 
 	// 4. Create new remote items from unsynced local notes
+	if !direction.pushes() {
+		unsyncedNotes = nil
+	}
 	for _, note := range unsyncedNotes {
 		// Check if the note is of a type that should be synced for creation
 		var isSyncable bool
@@ -344,6 +477,7 @@ func formatComments(comments []*Comment) string {
 }
 
 // createNoteFromItem creates a new note from a sync.Item and returns the note path.
+// The caller must hold the notebook lock.
 func (s *Syncer) createNoteFromItem(ctx *service.WorkspaceContext, item *Item, noteType models.NoteType) (string, error) {
 	s.logger.WithFields(logrus.Fields{
 		"remote_id":  item.ID,
@@ -360,14 +494,15 @@ func (s *Syncer) createNoteFromItem(ctx *service.WorkspaceContext, item *Item, n
 	bodyBuilder.WriteString(formatComments(item.Comments))
 	bodyBuilder.WriteString("\n\n" + syncMarker)
 
-	note, err := s.svc.CreateNoteWithContent(ctx, noteType, item.Title, fm, bodyBuilder.String())
+	note, err := s.svc.CreateNoteWithContent(ctx, noteType, item.Title, fm, bodyBuilder.String(), service.WithNotebookLocked())
 	if err != nil {
 		return "", err
 	}
 	return note.Path, nil
 }
 
-// updateNoteFromItem updates an existing note from a sync.Item.
+// updateNoteFromItem updates an existing note from a sync.Item. Like the
+// other note updates below, the caller holds the notebook lock.
 func (s *Syncer) updateNoteFromItem(note *models.Note, item *Item) error {
 	return s.updateNoteFromItemPreserveLocal(note, item, true)
 }
@@ -425,7 +560,7 @@ func (s *Syncer) updateNoteFromItemPreserveLocal(note *models.Note, item *Item,
 	newBody := newBodyBuilder.String()
 
 	// Parse the original content to get and update the frontmatter
-	fm, _, err := frontmatter.Parse(contentStr)
+	fm, err := frontmatter.ParsePartial(contentStr)
 	if err != nil {
 		// If parsing fails, create a new frontmatter struct
 		fm = s.buildFrontmatter(item)
@@ -447,7 +582,7 @@ func (s *Syncer) updateNoteFromItemPreserveLocal(note *models.Note, item *Item,
 		"new_comments": len(newComments),
 	}).Info("Updating local note from remote item")
 
-	return s.svc.UpdateNoteWithContent(note.Path, fm, newBody)
+	return s.svc.UpdateNoteWithContent(note.Path, fm, newBody, service.WithNotebookLocked())
 }
 
 // buildFrontmatter creates a Frontmatter struct from a sync.Item.
@@ -501,7 +636,27 @@ func (s *Syncer) updateNoteWithRemoteData(note *models.Note, item *Item) error {
 	// Also update modified timestamp
 	fm.Modified = frontmatter.FormatTimestamp(item.UpdatedAt)
 
-	return s.svc.UpdateNoteWithContent(note.Path, fm, body)
+	return s.svc.UpdateNoteWithContent(note.Path, fm, body, service.WithNotebookLocked())
+}
+
+// pushNoteToRemote pushes a synced note's title and body to its remote item
+// and records the resulting remote state in the note's frontmatter.
+func (s *Syncer) pushNoteToRemote(note *models.Note, provider Provider, repoPath string) error {
+	item, err := s.noteToSyncItem(note)
+	if err != nil {
+		return fmt.Errorf("convert note to sync item: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"remote_id": item.ID,
+		"note_path": note.Path,
+	}).Info("Pushing local note to remote item")
+
+	updated, err := provider.UpdateItem(item, repoPath)
+	if err != nil {
+		return fmt.Errorf("update remote item: %w", err)
+	}
+	return s.updateNoteWithRemoteData(note, updated)
 }
 
 // noteToSyncItem constructs a sync.Item from a local note file.
@@ -0,0 +1,128 @@
+package sync
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+	"github.com/grovetools/nb/pkg/models"
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// newDirectionTestSyncer returns a Syncer whose notebook has three issue
+// notes: "1", edited locally since the last sync, "2", unchanged locally but
+// edited on the remote, and "Local only", which isn't on the remote yet. The
+// provider also has a new issue "3". Calls made while setting up are cleared.
+func newDirectionTestSyncer(t *testing.T) (*Syncer, *service.WorkspaceContext, *fakeProvider) {
+	t.Helper()
+	base := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	provider := &fakeProvider{remote: []*Item{
+		{ID: "1", Type: "issue", Title: "Edited locally", Body: "one", State: "OPEN", UpdatedAt: base},
+		{ID: "2", Type: "issue", Title: "Edited remotely", Body: "two", State: "OPEN", UpdatedAt: base},
+	}}
+	syncer, ctx := newWebhookTestSyncer(t, provider)
+	_, err := syncer.SyncWorkspace(ctx, SyncOptions{Direction: DirectionPull})
+	require.NoError(t, err)
+
+	later := base.Add(time.Hour)
+	require.NoError(t, os.Chtimes(notesByRemoteID(t, syncer, ctx)["1"].Path, later, later))
+	provider.remote = []*Item{
+		provider.remote[0],
+		{ID: "2", Type: "issue", Title: "Edited remotely, renamed", Body: "two", State: "OPEN", UpdatedAt: later},
+		{ID: "3", Type: "issue", Title: "New remotely", Body: "three", State: "OPEN", UpdatedAt: later},
+	}
+	fm := &frontmatter.Frontmatter{ID: "20240601-local", Title: "Local only"}
+	_, err = syncer.svc.CreateNoteWithContent(ctx, "issues", fm.Title, fm, "# Local only\n\nNot on the remote yet.\n")
+	require.NoError(t, err)
+
+	provider.created, provider.updated = nil, nil
+	return syncer, ctx, provider
+}
+
+// notesByRemoteID maps the remote ID of each note to it. Unsynced notes are
+// keyed by their title.
+func notesByRemoteID(t *testing.T, syncer *Syncer, ctx *service.WorkspaceContext) map[string]*models.Note {
+	t.Helper()
+	notes, err := syncer.svc.ListAllNotes(ctx, true, false)
+	require.NoError(t, err)
+	byID := make(map[string]*models.Note)
+	for _, note := range notes {
+		if note.Remote != nil && note.Remote.ID != "" {
+			byID[note.Remote.ID] = note
+		} else {
+			byID[note.Title] = note
+		}
+	}
+	return byID
+}
+
+func TestSyncDirectionPushLeavesLocalNotesAlone(t *testing.T) {
+	syncer, ctx, provider := newDirectionTestSyncer(t)
+	before := notesByRemoteID(t, syncer, ctx)
+	remoteEdited := readFile(t, before["2"].Path)
+
+	reports, err := syncer.SyncWorkspace(ctx, SyncOptions{Direction: DirectionPush})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Empty(t, reports[0].Error)
+
+	require.Len(t, provider.updated, 1)
+	assert.Equal(t, "1", provider.updated[0].ID)
+	require.Len(t, provider.created, 1)
+	assert.Equal(t, "Local only", provider.created[0].Title)
+
+	after := notesByRemoteID(t, syncer, ctx)
+	assert.Len(t, after, len(before), "no local note is created")
+	assert.NotContains(t, after, "3")
+	assert.Equal(t, remoteEdited, readFile(t, after["2"].Path), "remote edits aren't pulled")
+}
+
+func TestSyncDirectionPullLeavesRemoteAlone(t *testing.T) {
+	syncer, ctx, provider := newDirectionTestSyncer(t)
+	before := notesByRemoteID(t, syncer, ctx)
+	localEdited := readFile(t, before["1"].Path)
+
+	reports, err := syncer.SyncWorkspace(ctx, SyncOptions{Direction: DirectionPull})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Empty(t, reports[0].Error)
+	assert.Equal(t, 1, reports[0].Created)
+	assert.Equal(t, 1, reports[0].Updated)
+
+	assert.Empty(t, provider.created, "CreateItem must not be called")
+	assert.Empty(t, provider.updated, "UpdateItem must not be called")
+
+	after := notesByRemoteID(t, syncer, ctx)
+	require.Contains(t, after, "3")
+	assert.Contains(t, readFile(t, after["2"].Path), "Edited remotely, renamed")
+	assert.Equal(t, localEdited, readFile(t, after["1"].Path))
+	require.Contains(t, after, "Local only")
+	assert.Nil(t, after["Local only"].Remote)
+}
+
+func TestSyncDirectionBothPullsAndPushes(t *testing.T) {
+	syncer, ctx, provider := newDirectionTestSyncer(t)
+
+	reports, err := syncer.SyncWorkspace(ctx, SyncOptions{})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Empty(t, reports[0].Error)
+
+	require.Len(t, provider.created, 1)
+	assert.Equal(t, "Local only", provider.created[0].Title)
+
+	after := notesByRemoteID(t, syncer, ctx)
+	require.Contains(t, after, "3")
+	assert.Contains(t, readFile(t, after["2"].Path), "Edited remotely, renamed")
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,319 @@
+package sync
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// webhookProvider is the provider webhook deliveries come from.
+const webhookProvider = "github"
+
+// maxWebhookPayload caps the size of a webhook request body; GitHub caps
+// its payloads at 25 MB.
+const maxWebhookPayload = 25 << 20
+
+// WebhookPIDPath returns the file a running webhook server records its PID
+// in, ~/.grove/nb/webhook.pid.
+func WebhookPIDPath() string {
+	return filepath.Join(service.NBHomeDir(), "webhook.pid")
+}
+
+// VerifyWebhookSignature reports whether signature, the value of GitHub's
+// X-Hub-Signature-256 header ("sha256=<hex>"), is the HMAC-SHA256 of body
+// keyed with secret.
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	sig, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// webhookPayload holds the parts of an issues or pull_request event that
+// name the affected item.
+type webhookPayload struct {
+	Action string `json:"action"`
+	Issue  *struct {
+		Number int `json:"number"`
+	} `json:"issue"`
+	PullRequest *struct {
+		Number int `json:"number"`
+	} `json:"pull_request"`
+}
+
+// webhookItem returns the provider item type ("issue" or "pr") and ID that
+// an event of the given X-GitHub-Event type is about. ok is false for events
+// that don't concern a syncable item.
+func webhookItem(event string, body []byte) (itemType, id string, ok bool, err error) {
+	if event != "issues" && event != "pull_request" {
+		return "", "", false, nil
+	}
+	var payload webhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", "", false, fmt.Errorf("decode %s event: %w", event, err)
+	}
+	switch {
+	case event == "issues" && payload.Issue != nil && payload.Issue.Number > 0:
+		return "issue", strconv.Itoa(payload.Issue.Number), true, nil
+	case event == "pull_request" && payload.PullRequest != nil && payload.PullRequest.Number > 0:
+		return "pr", strconv.Itoa(payload.PullRequest.Number), true, nil
+	}
+	return "", "", false, fmt.Errorf("%s event names no item", event)
+}
+
+// newWebhookHandler returns the handler of GitHub webhook deliveries. Each
+// signed issues or pull_request event calls syncItem with the affected item;
+// other events (such as the ping sent when a webhook is created) are
+// acknowledged and ignored.
+func newWebhookHandler(secret string, logger *logrus.Entry, syncItem func(itemType, id string) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+			http.Error(w, "content type must be application/json", http.StatusUnsupportedMediaType)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayload))
+		if err != nil {
+			http.Error(w, "read payload", http.StatusBadRequest)
+			return
+		}
+		if !VerifyWebhookSignature(secret, body, r.Header.Get("X-Hub-Signature-256")) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		event := r.Header.Get("X-GitHub-Event")
+		itemType, id, ok, err := webhookItem(event, body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		fields := logrus.Fields{
+			"event":     event,
+			"delivery":  r.Header.Get("X-GitHub-Delivery"),
+			"item_type": itemType,
+			"remote_id": id,
+		}
+		if err := syncItem(itemType, id); err != nil {
+			logger.WithFields(fields).WithError(err).Error("Webhook sync failed")
+			// A busy notebook is worth a redelivery; anything else is not.
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrSyncLocked) {
+				status = http.StatusServiceUnavailable
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		logger.WithFields(fields).Info("Synced item from webhook")
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// WebhookHandler returns an http.Handler that verifies GitHub webhook
+// deliveries against secret and syncs the issue or pull request each one is
+// about into ctx with SyncItem.
+func (s *Syncer) WebhookHandler(ctx *service.WorkspaceContext, secret string) http.Handler {
+	return newWebhookHandler(secret, s.logger, func(itemType, id string) error {
+		_, err := s.SyncItem(ctx, webhookProvider, itemType, id)
+		return err
+	})
+}
+
+// StartWebhookServer serves WebhookHandler on port until the process is
+// interrupted or terminated, recording this process's PID in WebhookPIDPath
+// while it runs. It refuses to start while another live server is
+// recorded, and only records its PID once it listens on port. It is the
+// push-based alternative to running an incremental sync on a schedule.
+//
+// NOTE: This is a Syncer method rather than a Service one, as pkg/sync
+// already imports pkg/service.
+func (s *Syncer) StartWebhookServer(ctx *service.WorkspaceContext, port int, secret string) error {
+	if secret == "" {
+		return fmt.Errorf("a webhook secret is required")
+	}
+	pidPath := WebhookPIDPath()
+	if !filepath.IsAbs(pidPath) {
+		return fmt.Errorf("no home directory to record the webhook server in")
+	}
+	if pid, _ := ReadWebhookPID(); pid > 0 && processAlive(pid) {
+		return fmt.Errorf("a webhook server is already running (pid %d); stop it with: nb remote sync --webhook stop", pid)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("listen for webhooks: %w", err)
+	}
+	defer listener.Close()
+	if err := os.MkdirAll(filepath.Dir(pidPath), 0o755); err != nil {
+		return fmt.Errorf("create webhook state directory: %w", err)
+	}
+	if err := os.WriteFile(pidPath, []byte(fmt.Sprintf("%d\n", os.Getpid())), 0o644); err != nil {
+		return fmt.Errorf("write webhook pid file: %w", err)
+	}
+	defer removeWebhookPID(pidPath)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", s.WebhookHandler(ctx, secret))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	s.logger.WithFields(logrus.Fields{
+		"port":      port,
+		"workspace": ctx.CurrentWorkspace.Name,
+	}).Info("Listening for GitHub webhooks")
+	// Stop cleanly on SIGINT or SIGTERM (as sent by nb sync --webhook stop)
+	// so the PID file is removed.
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-sigCtx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx) //nolint:errcheck
+	}()
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("webhook server: %w", err)
+	}
+	return nil
+}
+
+// removeWebhookPID removes the PID file at path if it still records this
+// process, leaving one written by another server alone.
+func removeWebhookPID(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil || strings.TrimSpace(string(data)) != strconv.Itoa(os.Getpid()) {
+		return
+	}
+	os.Remove(path)
+}
+
+// processAlive reports whether a process with the given PID is running.
+func processAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = proc.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// ReadWebhookPID returns the PID recorded by a running webhook server, or 0
+// when none is recorded.
+func ReadWebhookPID() (int, error) {
+	data, err := os.ReadFile(WebhookPIDPath())
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read webhook pid file: %w", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid webhook pid file %s", WebhookPIDPath())
+	}
+	return pid, nil
+}
+
+// SyncItem pulls a single remote item into ctx: the note synced with it is
+// updated when the remote changed since, and a new note is created when
+// none is and the provider's config syncs items of that type. Unlike
+// SyncWorkspace, nothing is pushed and the sync state is left alone, so the
+// next incremental sync still covers everything since the last full run.
+func (s *Syncer) SyncItem(ctx *service.WorkspaceContext, providerName, itemType, id string) (*Report, error) {
+	syncConfigs, err := s.syncConfigs()
+	if err != nil {
+		return nil, err
+	}
+	var config *SyncConfig
+	for i := range syncConfigs {
+		if syncConfigs[i].Provider == providerName {
+			config = &syncConfigs[i]
+			break
+		}
+	}
+	if config == nil {
+		return nil, fmt.Errorf("no %s sync is configured for this notebook", providerName)
+	}
+	provider, err := s.provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.svc.LockNotebookFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	item, err := provider.GetItem(itemType, id, ctx.CurrentWorkspace.Path)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s %s: %w", itemType, id, err)
+	}
+	report := &Report{Provider: provider.Name(), Fetched: 1}
+
+	notes, err := s.svc.ListAllNotes(ctx, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local notes: %w", err)
+	}
+	for _, note := range notes {
+		if note.Remote == nil || note.Remote.Provider != provider.Name() || note.Remote.ID != id {
+			continue
+		}
+		if !s.needsUpdate(note, item) {
+			report.Unchanged++
+			return report, nil
+		}
+		if err := s.updateNoteFromItem(note, item); err != nil {
+			return nil, fmt.Errorf("update %s: %w", note.Path, err)
+		}
+		report.Updated++
+		return report, nil
+	}
+
+	noteType, ok := config.noteTypeFor(item)
+	if !ok {
+		report.Unchanged++
+		return report, nil
+	}
+	if _, err := s.createNoteFromItem(ctx, item, noteType); err != nil {
+		return nil, fmt.Errorf("create note for %s %s: %w", itemType, id, err)
+	}
+	report.Created++
+	return report, nil
+}
@@ -0,0 +1,210 @@
+package sync
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	coreconfig "github.com/grovetools/core/config"
+	coreworkspace "github.com/grovetools/core/pkg/workspace"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func signWebhook(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"action":"opened"}`)
+	assert.True(t, VerifyWebhookSignature("s3cret", body, signWebhook("s3cret", string(body))))
+	assert.False(t, VerifyWebhookSignature("other", body, signWebhook("s3cret", string(body))))
+	assert.False(t, VerifyWebhookSignature("s3cret", body, strings.TrimPrefix(signWebhook("s3cret", string(body)), "sha256=")))
+	assert.False(t, VerifyWebhookSignature("s3cret", body, "sha256=zz"))
+}
+
+func TestWebhookHandler(t *testing.T) {
+	type synced struct{ itemType, id string }
+	var calls []synced
+	var syncErr error
+	handler := newWebhookHandler("s3cret", logrus.NewEntry(logrus.New()), func(itemType, id string) error {
+		calls = append(calls, synced{itemType, id})
+		return syncErr
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	deliver := func(event, body, signature, contentType string) int {
+		req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("X-GitHub-Event", event)
+		req.Header.Set("X-Hub-Signature-256", signature)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	issue := `{"action":"edited","issue":{"number":42}}`
+	assert.Equal(t, http.StatusNoContent, deliver("issues", issue, signWebhook("s3cret", issue), "application/json"))
+	pr := `{"action":"synchronize","pull_request":{"number":7}}`
+	assert.Equal(t, http.StatusNoContent, deliver("pull_request", pr, signWebhook("s3cret", pr), "application/json; charset=utf-8"))
+	assert.Equal(t, []synced{{"issue", "42"}, {"pr", "7"}}, calls)
+
+	calls = nil
+	assert.Equal(t, http.StatusUnauthorized, deliver("issues", issue, signWebhook("wrong", issue), "application/json"))
+	assert.Equal(t, http.StatusUnsupportedMediaType, deliver("issues", issue, signWebhook("s3cret", issue), "application/x-www-form-urlencoded"))
+	ping := `{"zen":"Keep it logically awesome."}`
+	assert.Equal(t, http.StatusNoContent, deliver("ping", ping, signWebhook("s3cret", ping), "application/json"), "other events are acknowledged")
+	noItem := `{"action":"edited"}`
+	assert.Equal(t, http.StatusBadRequest, deliver("issues", noItem, signWebhook("s3cret", noItem), "application/json"))
+	assert.Empty(t, calls)
+
+	syncErr = ErrSyncLocked
+	assert.Equal(t, http.StatusServiceUnavailable, deliver("issues", issue, signWebhook("s3cret", issue), "application/json"), "a locked notebook asks for redelivery")
+	syncErr = errors.New("gh failed")
+	assert.Equal(t, http.StatusInternalServerError, deliver("issues", issue, signWebhook("s3cret", issue), "application/json"))
+
+	resp, err := server.Client().Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+// fakeProvider serves GetItem from items, keyed by "<type>/<id>", and Sync
+// from remote. It records the config of each Sync call and the items passed to
+// CreateItem and UpdateItem.
+type fakeProvider struct {
+	items   map[string]*Item
+	remote  []*Item
+	synced  []map[string]string
+	created []*Item
+	updated []*Item
+}
+
+func (p *fakeProvider) Name() string { return "github" }
+
+func (p *fakeProvider) Sync(config map[string]string, _ string) ([]*Item, error) {
+	p.synced = append(p.synced, config)
+	return p.remote, nil
+}
+
+func (p *fakeProvider) CreateItem(item *Item, _ string) (*Item, error) {
+	p.created = append(p.created, item)
+	return item, nil
+}
+
+func (p *fakeProvider) UpdateItem(item *Item, _ string) (*Item, error) {
+	p.updated = append(p.updated, item)
+	return item, nil
+}
+
+func (p *fakeProvider) AddComment(string, string, string, string) error { return nil }
+
+func (p *fakeProvider) GetItem(itemType, itemID, _ string) (*Item, error) {
+	item, ok := p.items[itemType+"/"+itemID]
+	if !ok {
+		return nil, fmt.Errorf("no %s %s", itemType, itemID)
+	}
+	return item, nil
+}
+
+// newWebhookTestSyncer returns a Syncer for a local-mode workspace whose
+// default notebook syncs GitHub issues (but not pull requests), with GROVE_HOME
+// pointed at a temporary directory.
+func newWebhookTestSyncer(t *testing.T, provider Provider) (*Syncer, *service.WorkspaceContext) {
+	t.Helper()
+	t.Setenv("GROVE_HOME", t.TempDir())
+	var cfg coreconfig.Config
+	require.NoError(t, yaml.Unmarshal([]byte(`
+notebooks:
+  definitions:
+    default:
+      sync:
+        - provider: github
+          issues_type: issues
+`), &cfg))
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	svc, err := service.New(&service.Config{}, nil, nil, logrus.NewEntry(logger))
+	require.NoError(t, err)
+	svc.CoreConfig = &cfg
+
+	syncer := NewSyncer(svc)
+	syncer.RegisterProvider("github", func() Provider { return provider })
+	ws := &coreworkspace.WorkspaceNode{Name: "proj", Path: t.TempDir()}
+	return syncer, &service.WorkspaceContext{NotebookContextWorkspace: ws, CurrentWorkspace: ws}
+}
+
+func TestSyncItem(t *testing.T) {
+	updated := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	issue := &Item{ID: "42", Type: "issue", Title: "Broken build", Body: "It fails.", State: "OPEN", UpdatedAt: updated}
+	provider := &fakeProvider{items: map[string]*Item{
+		"issue/42": issue,
+		"pr/7":     {ID: "7", Type: "pr", Title: "Fix build", UpdatedAt: updated},
+	}}
+	syncer, ctx := newWebhookTestSyncer(t, provider)
+
+	report, err := syncer.SyncItem(ctx, "github", "issue", "42")
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+
+	report, err = syncer.SyncItem(ctx, "github", "issue", "42")
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Unchanged, "an unchanged item is left alone")
+
+	issue.Title = "Broken build on main"
+	issue.UpdatedAt = updated.Add(time.Hour)
+	report, err = syncer.SyncItem(ctx, "github", "issue", "42")
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Updated)
+
+	notes, err := syncer.svc.ListAllNotes(ctx, true, false)
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	require.NotNil(t, notes[0].Remote)
+	assert.Equal(t, "42", notes[0].Remote.ID)
+
+	report, err = syncer.SyncItem(ctx, "github", "pr", "7")
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Unchanged, "pull requests aren't synced by this config")
+	notes, err = syncer.svc.ListAllNotes(ctx, true, false)
+	require.NoError(t, err)
+	assert.Len(t, notes, 1)
+
+	_, err = syncer.SyncItem(ctx, "gitlab", "issue", "42")
+	assert.Error(t, err)
+}
+
+func TestStartWebhookServerRefusesWhileAnotherRuns(t *testing.T) {
+	syncer, ctx := newWebhookTestSyncer(t, &fakeProvider{})
+	pidPath := WebhookPIDPath()
+	require.NoError(t, os.MkdirAll(filepath.Dir(pidPath), 0o755))
+	// This test process stands in for a running server.
+	running := strconv.Itoa(os.Getpid()) + "\n"
+	require.NoError(t, os.WriteFile(pidPath, []byte(running), 0o644))
+
+	err := syncer.StartWebhookServer(ctx, 0, "s3cret")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already running")
+	data, err := os.ReadFile(pidPath)
+	require.NoError(t, err)
+	assert.Equal(t, running, string(data), "the running server's PID file is kept")
+}
@@ -0,0 +1,146 @@
+package tree
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Build arranges flat file items, as returned by the service's item listing,
+// into a hierarchy: one TypeWorkspace root per Metadata["Workspace"], then one
+// TypeGroup node per segment of Metadata["Group"], then the files. Archive,
+// closed and artifact directories ("inbox/.archive", "plans/x/.artifacts")
+// become ordinary nested groups under their parent, and direct children of
+// "plans" are TypePlan. Group nodes carry "Workspace" and "Group" (the full
+// group path) in their Metadata, matching the TUI's group items.
+//
+// Roots are sorted by name with "global" first; within a node, groups come
+// before files, dot-groups (.archive, .closed, .artifacts) after the others,
+// and files are newest first. Build sets Parent on the given items; directory
+// items in the input are ignored.
+func Build(items []*Item) []*Item {
+	roots := make(map[string]*Item)
+	groups := make(map[string]*Item) // workspace + "\x00" + group path
+
+	for _, item := range items {
+		if item == nil || item.IsDir {
+			continue
+		}
+		ws, _ := item.Metadata["Workspace"].(string)
+		root, ok := roots[ws]
+		if !ok {
+			root = &Item{
+				Name:     ws,
+				IsDir:    true,
+				Type:     TypeWorkspace,
+				Metadata: map[string]interface{}{"Workspace": ws},
+			}
+			roots[ws] = root
+		}
+
+		parent := root
+		group, _ := item.Metadata["Group"].(string)
+		segments := splitGroup(group)
+		dir := filepath.Dir(item.Path)
+		for i, seg := range segments {
+			full := strings.Join(segments[:i+1], "/")
+			key := ws + "\x00" + full
+			node, ok := groups[key]
+			if !ok {
+				nodeType := TypeGroup
+				if i == 1 && segments[0] == "plans" && !strings.HasPrefix(seg, ".") {
+					nodeType = TypePlan
+				}
+				node = &Item{
+					Path:     ancestorDir(dir, len(segments)-1-i),
+					Name:     seg,
+					IsDir:    true,
+					Type:     nodeType,
+					Metadata: map[string]interface{}{"Workspace": ws, "Group": full},
+					Parent:   parent,
+				}
+				groups[key] = node
+				parent.Children = append(parent.Children, node)
+			}
+			parent = node
+		}
+		item.Parent = parent
+		parent.Children = append(parent.Children, item)
+	}
+
+	result := make([]*Item, 0, len(roots))
+	for _, root := range roots {
+		if len(root.Children) > 0 && root.Path == "" {
+			// The workspace directory is the parent of its top-level groups.
+			root.Path = filepath.Dir(root.Children[0].Path)
+		}
+		sortTree(root)
+		result = append(result, root)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].Name == "global") != (result[j].Name == "global") {
+			return result[i].Name == "global"
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Walk calls fn for item and every descendant in depth-first order, passing
+// each node's depth below item. Returning false from fn skips that node's
+// children.
+func Walk(item *Item, fn func(item *Item, depth int) bool) {
+	walk(item, 0, fn)
+}
+
+func walk(item *Item, depth int, fn func(*Item, int) bool) {
+	if !fn(item, depth) {
+		return
+	}
+	for _, child := range item.Children {
+		walk(child, depth+1, fn)
+	}
+}
+
+func splitGroup(group string) []string {
+	var segments []string
+	for _, seg := range strings.Split(filepath.ToSlash(group), "/") {
+		if seg != "" {
+			segments = append(segments, seg)
+		}
+	}
+	return segments
+}
+
+// ancestorDir walks n levels up from dir.
+func ancestorDir(dir string, n int) string {
+	for ; n > 0; n-- {
+		dir = filepath.Dir(dir)
+	}
+	return dir
+}
+
+func sortTree(node *Item) {
+	sort.SliceStable(node.Children, func(i, j int) bool {
+		a, b := node.Children[i], node.Children[j]
+		if a.IsDir != b.IsDir {
+			return a.IsDir
+		}
+		if a.IsDir {
+			aDot, bDot := strings.HasPrefix(a.Name, "."), strings.HasPrefix(b.Name, ".")
+			if aDot != bDot {
+				return !aDot
+			}
+			return a.Name < b.Name
+		}
+		if !a.ModTime.Equal(b.ModTime) {
+			return a.ModTime.After(b.ModTime)
+		}
+		return a.Name < b.Name
+	})
+	for _, child := range node.Children {
+		if child.IsDir {
+			sortTree(child)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package tree
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fileItem(ws, group, path string, age time.Duration) *Item {
+	return &Item{
+		Path:     path,
+		Name:     path[strings.LastIndex(path, "/")+1:],
+		ModTime:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(-age),
+		Type:     TypeNote,
+		Metadata: map[string]interface{}{"Workspace": ws, "Group": group},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	items := []*Item{
+		fileItem("proj", "inbox", "/nb/proj/inbox/old.md", 2*time.Hour),
+		fileItem("proj", "inbox", "/nb/proj/inbox/new.md", time.Hour),
+		fileItem("proj", "inbox/.archive", "/nb/proj/inbox/.archive/gone.md", 0),
+		fileItem("proj", "plans/feature", "/nb/proj/plans/feature/01-spec.md", 0),
+		fileItem("proj", "completed", "/nb/proj/completed/done.md", 0),
+		fileItem("global", "inbox", "/nb/global/inbox/g.md", 0),
+		{Path: "/nb/proj/inbox", IsDir: true, Metadata: map[string]interface{}{"Workspace": "proj"}},
+	}
+
+	roots := Build(items)
+	if len(roots) != 2 || roots[0].Name != "global" || roots[1].Name != "proj" {
+		t.Fatalf("roots = %v, want [global proj]", names(roots))
+	}
+
+	var lines []string
+	Walk(roots[1], func(item *Item, depth int) bool {
+		lines = append(lines, strings.Repeat("  ", depth)+item.Name+" "+string(item.Type))
+		return true
+	})
+	want := []string{
+		"proj workspace",
+		"  completed group",
+		"    done.md note",
+		"  inbox group",
+		"    .archive group",
+		"      gone.md note",
+		"    new.md note",
+		"    old.md note",
+		"  plans group",
+		"    feature plan",
+		"      01-spec.md note",
+	}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("tree:\n%s\nwant:\n%s", strings.Join(lines, "\n"), strings.Join(want, "\n"))
+	}
+
+	inbox := roots[1].Children[1]
+	if inbox.Path != "/nb/proj/inbox" || inbox.Metadata["Group"] != "inbox" {
+		t.Errorf("inbox node = %q %v", inbox.Path, inbox.Metadata)
+	}
+	archive := inbox.Children[0]
+	if archive.Path != "/nb/proj/inbox/.archive" || archive.Metadata["Group"] != "inbox/.archive" || archive.Parent != inbox {
+		t.Errorf("archive node = %q %v", archive.Path, archive.Metadata)
+	}
+	if roots[1].Path != "/nb/proj" {
+		t.Errorf("workspace path = %q, want /nb/proj", roots[1].Path)
+	}
+
+	// Returning false from Walk prunes the subtree.
+	count := 0
+	Walk(roots[1], func(item *Item, depth int) bool {
+		count++
+		return item.Type == TypeWorkspace
+	})
+	if count != 4 {
+		t.Errorf("pruned walk visited %d nodes, want 4", count)
+	}
+}
+
+func names(items []*Item) []string {
+	var out []string
+	for _, item := range items {
+		out = append(out, item.Name)
+	}
+	return out
+}
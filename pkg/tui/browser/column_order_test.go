@@ -0,0 +1,29 @@
+package browser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeColumnOrder(t *testing.T) {
+	known := []string{"TYPE", "STATUS", "TAGS", "PATH"}
+
+	cases := []struct {
+		name  string
+		saved []string
+		want  []string
+	}{
+		{"no saved order", nil, known},
+		{"full custom order", []string{"PATH", "TAGS", "STATUS", "TYPE"}, []string{"PATH", "TAGS", "STATUS", "TYPE"}},
+		{"new column appended", []string{"PATH", "TYPE", "STATUS"}, []string{"PATH", "TYPE", "STATUS", "TAGS"}},
+		{"removed column dropped", []string{"GONE", "TAGS", "TYPE"}, []string{"TAGS", "TYPE", "STATUS", "PATH"}},
+		{"duplicates ignored", []string{"TAGS", "TAGS", "TYPE"}, []string{"TAGS", "TYPE", "STATUS", "PATH"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := normalizeColumnOrder(tc.saved, known); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("normalizeColumnOrder(%v) = %v, want %v", tc.saved, got, tc.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package confirm
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -21,7 +23,11 @@ type CancelledMsg struct{}
 type Model struct {
 	Active bool
 	Prompt string
-	keys   keyMap
+	// Required, when set, must be typed and entered to confirm; y does
+	// nothing.
+	Required string
+	input    string
+	keys     keyMap
 }
 
 // New creates a new confirmation dialog model.
@@ -34,9 +40,18 @@ func New() Model {
 // Activate prepares the dialog for display with a given prompt.
 func (m *Model) Activate(prompt string) {
 	m.Prompt = prompt
+	m.Required = ""
+	m.input = ""
 	m.Active = true
 }
 
+// ActivateTyped prepares the dialog for display with a given prompt, asking
+// for required to be typed to confirm.
+func (m *Model) ActivateTyped(prompt, required string) {
+	m.Activate(prompt)
+	m.Required = required
+}
+
 // --- Update ---
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
@@ -46,6 +61,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.Required != "" {
+			return m.updateTyped(msg)
+		}
 		switch {
 		case key.Matches(msg, m.keys.Confirm):
 			m.Active = false
@@ -59,6 +77,28 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateTyped handles a key while the dialog waits for Required.
+func (m Model) updateTyped(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.Active = false
+		return m, func() tea.Msg { return CancelledMsg{} }
+	case tea.KeyEnter:
+		if m.input != m.Required {
+			return m, nil
+		}
+		m.Active = false
+		return m, func() tea.Msg { return ConfirmedMsg{} }
+	case tea.KeyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case tea.KeyRunes:
+		m.input += string(msg.Runes)
+	}
+	return m, nil
+}
+
 // --- View ---
 
 func (m Model) View() string {
@@ -66,17 +106,23 @@ func (m Model) View() string {
 		return ""
 	}
 
+	prompt, help := m.Prompt, "(y/n)"
+	if m.Required != "" {
+		prompt += fmt.Sprintf("\n\nType %s to confirm: %s▏", m.Required, m.input)
+		help = "(enter to confirm, esc to cancel)"
+	}
+
 	dialogBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(theme.DefaultTheme.Colors.Orange).
 		Padding(1, 2).
-		Render(m.Prompt)
+		Render(prompt)
 
 	helpText := lipgloss.NewStyle().
 		Faint(true).
 		Width(lipgloss.Width(dialogBox)).
 		Align(lipgloss.Center).
-		Render("\n\n(y/n)")
+		Render("\n\n" + help)
 
 	return lipgloss.JoinVertical(lipgloss.Left, dialogBox, helpText)
 }
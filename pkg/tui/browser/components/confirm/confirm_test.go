@@ -0,0 +1,49 @@
+package confirm
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTypedConfirmNeedsTheCount(t *testing.T) {
+	m := New()
+	m.ActivateTyped("Archive 250 notes?", "250")
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if cmd != nil || !m.Active {
+		t.Fatal("y must not confirm a typed confirmation")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+
+	for _, r := range "25" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || !m.Active {
+		t.Fatal("a wrong count must not confirm")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'0'}})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Active {
+		t.Error("dialog should close on confirm")
+	}
+	if _, ok := cmd().(ConfirmedMsg); !ok {
+		t.Errorf("got %#v, want ConfirmedMsg", cmd())
+	}
+}
+
+func TestActivateResetsTypedConfirm(t *testing.T) {
+	m := New()
+	m.ActivateTyped("Delete 300 notes?", "300")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if _, ok := cmd().(CancelledMsg); !ok || m.Active {
+		t.Fatalf("esc should cancel, got %#v", cmd())
+	}
+
+	m.Activate("Delete 1 note?")
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if _, ok := cmd().(ConfirmedMsg); !ok {
+		t.Errorf("y should confirm a plain confirmation, got %#v", cmd())
+	}
+}
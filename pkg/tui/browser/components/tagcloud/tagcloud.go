@@ -0,0 +1,363 @@
+package tagcloud
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/grovetools/core/tui/theme"
+)
+
+// --- Messages ---
+
+// SelectedMsg is sent when the user picks a tag with enter or a click.
+type SelectedMsg struct {
+	Tag string
+}
+
+// ClosedMsg is sent when the user closes the cloud without picking a tag.
+type ClosedMsg struct{}
+
+// --- Model ---
+
+// Tag is one entry in the cloud.
+type Tag struct {
+	Name  string
+	Count int
+}
+
+// headerLines is the number of lines View draws above the cloud: the title,
+// the filter line and a blank line.
+const headerLines = 3
+
+// gap is the number of spaces between two tags on a line.
+const gap = 1
+
+// Model is a full-screen tag cloud. Tags are drawn larger the more notes carry
+// them, and typing narrows the cloud with a fuzzy match on the tag name.
+type Model struct {
+	Active  bool
+	tags    []Tag // All tags, most used first
+	visible []Tag // Tags matching query, in the same order
+	query   string
+	cursor  int // Index into visible
+	width   int
+	height  int
+	keys    keyMap
+}
+
+// New creates a new, inactive tag cloud.
+func New() Model {
+	return Model{keys: defaultKeyMap}
+}
+
+// Activate shows the cloud for tags, clearing any previous filter.
+func (m *Model) Activate(tags []Tag) {
+	m.tags = append([]Tag(nil), tags...)
+	sort.SliceStable(m.tags, func(i, j int) bool {
+		if m.tags[i].Count != m.tags[j].Count {
+			return m.tags[i].Count > m.tags[j].Count
+		}
+		return m.tags[i].Name < m.tags[j].Name
+	})
+	m.query = ""
+	m.cursor = 0
+	m.filter()
+	m.Active = true
+}
+
+// SetSize sets the area the cloud is drawn in.
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// Query returns the current filter text.
+func (m Model) Query() string {
+	return m.query
+}
+
+// Visible returns the names of the tags matching the filter, in display order.
+func (m Model) Visible() []string {
+	names := make([]string, len(m.visible))
+	for i, t := range m.visible {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// filter recomputes the visible tags from the query and clamps the cursor.
+func (m *Model) filter() {
+	m.visible = nil
+	for _, t := range m.tags {
+		if fuzzyMatch(t.Name, m.query) {
+			m.visible = append(m.visible, t)
+		}
+	}
+	if m.cursor >= len(m.visible) {
+		m.cursor = max(len(m.visible)-1, 0)
+	}
+}
+
+// fuzzyMatch reports whether the runes of query appear in name in order,
+// ignoring case.
+func fuzzyMatch(name, query string) bool {
+	name = strings.ToLower(name)
+	for _, r := range strings.ToLower(query) {
+		i := strings.IndexRune(name, r)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(string(r)):]
+	}
+	return true
+}
+
+// --- Update ---
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.Active {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.keys.Close):
+			m.Active = false
+			return m, func() tea.Msg { return ClosedMsg{} }
+		case key.Matches(msg, m.keys.Select):
+			return m.selectCursor()
+		case key.Matches(msg, m.keys.Left):
+			m.cursor = max(m.cursor-1, 0)
+		case key.Matches(msg, m.keys.Right):
+			m.cursor = min(m.cursor+1, max(len(m.visible)-1, 0))
+		case key.Matches(msg, m.keys.Up):
+			m.moveRow(-1)
+		case key.Matches(msg, m.keys.Down):
+			m.moveRow(1)
+		case key.Matches(msg, m.keys.Backspace):
+			if m.query != "" {
+				r := []rune(m.query)
+				m.query = string(r[:len(r)-1])
+				m.filter()
+			}
+		case msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace:
+			m.query += string(msg.Runes)
+			m.cursor = 0
+			m.filter()
+		}
+	case tea.MouseMsg:
+		if msg.Button != tea.MouseButtonLeft || msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		if i, ok := m.TagAt(msg.X, msg.Y); ok {
+			m.cursor = i
+			return m.selectCursor()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) selectCursor() (Model, tea.Cmd) {
+	if m.cursor >= len(m.visible) {
+		return m, nil
+	}
+	tag := m.visible[m.cursor].Name
+	m.Active = false
+	return m, func() tea.Msg { return SelectedMsg{Tag: tag} }
+}
+
+// moveRow moves the cursor to the tag on the previous (delta < 0) or next
+// line whose left edge is closest to the current tag's.
+func (m *Model) moveRow(delta int) {
+	rows := m.layout()
+	for r, row := range rows {
+		for _, c := range row {
+			if c.index != m.cursor {
+				continue
+			}
+			target := r + delta
+			if target < 0 || target >= len(rows) {
+				return
+			}
+			best := rows[target][0]
+			for _, o := range rows[target] {
+				if abs(o.x-c.x) < abs(best.x-c.x) {
+					best = o
+				}
+			}
+			m.cursor = best.index
+			return
+		}
+	}
+}
+
+// TagAt returns the index of the visible tag drawn at column x, line y of
+// View's output.
+func (m Model) TagAt(x, y int) (int, bool) {
+	row := y - headerLines
+	rows := m.layout()
+	if row < 0 || row >= len(rows) {
+		return 0, false
+	}
+	for _, c := range rows[row] {
+		if x >= c.x && x < c.x+c.width {
+			return c.index, true
+		}
+	}
+	return 0, false
+}
+
+// cell is the position of one rendered tag in the cloud.
+type cell struct {
+	index int // Into visible
+	x     int
+	width int
+}
+
+// layout flows the visible tags into lines no wider than the cloud.
+func (m Model) layout() [][]cell {
+	maxCount := 0
+	for _, t := range m.visible {
+		maxCount = max(maxCount, t.Count)
+	}
+	var rows [][]cell
+	var row []cell
+	x := 0
+	for i, t := range m.visible {
+		w := lipgloss.Width(label(t, tier(t.Count, maxCount)))
+		if len(row) > 0 && x+w > m.width {
+			rows = append(rows, row)
+			row, x = nil, 0
+		}
+		row = append(row, cell{index: i, x: x, width: w})
+		x += w + gap
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// tier buckets count into 0 (rare) .. 3 (most used) relative to maxCount.
+func tier(count, maxCount int) int {
+	if maxCount <= 1 {
+		return 0
+	}
+	return min(3, 4*(count-1)/maxCount)
+}
+
+// label is the unstyled text of a tag: wider padding for higher tiers.
+func label(t Tag, tier int) string {
+	pad := strings.Repeat(" ", tier)
+	return fmt.Sprintf("%s#%s (%d)%s", pad, t.Name, t.Count, pad)
+}
+
+// --- View ---
+
+func (m Model) View() string {
+	if !m.Active {
+		return ""
+	}
+
+	colors := theme.DefaultTheme.Colors
+	tierStyles := []lipgloss.Style{
+		lipgloss.NewStyle().Foreground(colors.MutedText),
+		lipgloss.NewStyle().Foreground(colors.Blue),
+		lipgloss.NewStyle().Foreground(colors.Cyan).Bold(true),
+		lipgloss.NewStyle().Foreground(colors.Orange).Bold(true),
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Tag Cloud"))
+	b.WriteString(lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf("  %d/%d tags", len(m.visible), len(m.tags))))
+	b.WriteString("\nFilter: " + m.query + "▏\n\n")
+
+	maxCount := 0
+	for _, t := range m.visible {
+		maxCount = max(maxCount, t.Count)
+	}
+	rows := m.layout()
+	for r, row := range rows {
+		if m.height > 0 && r >= m.height-headerLines-2 {
+			break
+		}
+		x := 0
+		for _, c := range row {
+			t := m.visible[c.index]
+			tr := tier(t.Count, maxCount)
+			style := tierStyles[tr]
+			if c.index == m.cursor {
+				style = style.Reverse(true)
+			}
+			b.WriteString(strings.Repeat(" ", c.x-x))
+			b.WriteString(style.Render(label(t, tr)))
+			x = c.x + c.width
+		}
+		b.WriteString("\n")
+	}
+	if len(m.visible) == 0 {
+		b.WriteString(lipgloss.NewStyle().Faint(true).Render("No matching tags") + "\n")
+	}
+
+	b.WriteString(lipgloss.NewStyle().Faint(true).
+		Render("\ntype to filter • arrows to move • enter/click to filter by tag • esc to close"))
+	return b.String()
+}
+
+// --- KeyMap ---
+
+type keyMap struct {
+	Select    key.Binding
+	Close     key.Binding
+	Left      key.Binding
+	Right     key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	Backspace key.Binding
+}
+
+// Letters are typed into the filter, so movement uses arrows and ctrl keys
+// only.
+var defaultKeyMap = keyMap{
+	Select: key.NewBinding(
+		key.WithKeys("enter"),
+		key.WithHelp("enter", "filter by tag"),
+	),
+	Close: key.NewBinding(
+		key.WithKeys("esc"),
+		key.WithHelp("esc", "close"),
+	),
+	Left: key.NewBinding(
+		key.WithKeys("left", "shift+tab"),
+		key.WithHelp("←", "previous tag"),
+	),
+	Right: key.NewBinding(
+		key.WithKeys("right", "tab"),
+		key.WithHelp("→", "next tag"),
+	),
+	Up: key.NewBinding(
+		key.WithKeys("up", "ctrl+p"),
+		key.WithHelp("↑", "line up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "ctrl+n"),
+		key.WithHelp("↓", "line down"),
+	),
+	Backspace: key.NewBinding(
+		key.WithKeys("backspace"),
+		key.WithHelp("backspace", "delete filter character"),
+	),
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,58 @@
+package tagcloud
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFuzzyFilterAndSelect(t *testing.T) {
+	m := New()
+	m.SetSize(80, 24)
+	m.Activate([]Tag{{"golang", 2}, {"grove", 9}, {"meeting", 4}, {"lang", 4}})
+
+	if got, want := m.Visible(), []string{"grove", "lang", "meeting", "golang"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("order = %v, want %v (count desc, then name)", got, want)
+	}
+
+	for _, r := range "gln" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if got, want := m.Visible(), []string{"golang"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("filter %q = %v, want %v", m.Query(), got, want)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if got, want := m.Visible(), []string{"golang"}; m.Query() != "gl" || !reflect.DeepEqual(got, want) {
+		t.Fatalf("after backspace %q = %v", m.Query(), got)
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.Active {
+		t.Error("cloud should close on select")
+	}
+	if msg, ok := cmd().(SelectedMsg); !ok || msg.Tag != "golang" {
+		t.Errorf("got %#v, want SelectedMsg{golang}", cmd())
+	}
+}
+
+func TestTagAtFollowsLayout(t *testing.T) {
+	m := New()
+	m.SetSize(30, 24)
+	m.Activate([]Tag{{"a", 1}, {"b", 1}, {"c", 1}})
+
+	// "#a (1)" is 6 wide with a 1-space gap, so all three fit on one line.
+	if i, ok := m.TagAt(0, headerLines); !ok || i != 0 {
+		t.Errorf("TagAt(0) = %d, %v", i, ok)
+	}
+	if i, ok := m.TagAt(8, headerLines); !ok || i != 1 {
+		t.Errorf("TagAt(8) = %d, %v", i, ok)
+	}
+	if _, ok := m.TagAt(6, headerLines); ok {
+		t.Error("the gap between tags is not a tag")
+	}
+	if _, ok := m.TagAt(0, 0); ok {
+		t.Error("the header is not a tag")
+	}
+}
@@ -0,0 +1,74 @@
+package browser
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grovetools/core/util/pathutil"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// startExport opens the destination prompt for the selected notes, or the
+// note/group under the cursor.
+func (m *Model) startExport() tea.Cmd {
+	var paths []string
+	for _, p := range m.views.GetTargetedNotePaths() {
+		if strings.HasSuffix(p, ".md") {
+			paths = append(paths, p)
+		}
+	}
+	if len(paths) == 0 {
+		m.statusMessage = "No notes to export"
+		return nil
+	}
+	m.isExporting = true
+	m.exportPaths = paths
+	m.exportInput.SetValue("")
+	m.exportInput.Focus()
+	return textinput.Blink
+}
+
+// updateExport handles input while the export prompt is open. A destination
+// ending in .md gets the notes concatenated into it; anything else is a
+// directory they are copied into.
+func (m Model) updateExport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.isExporting = false
+		m.exportInput.Blur()
+		m.exportPaths = nil
+		return m, nil
+	case "enter":
+		dest := strings.TrimSpace(m.exportInput.Value())
+		paths := m.exportPaths
+		m.isExporting = false
+		m.exportInput.Blur()
+		m.exportPaths = nil
+		if dest == "" {
+			return m, nil
+		}
+		m.statusMessage = "Exporting..."
+		return m, exportNotesCmd(m.service, paths, dest)
+	}
+	var cmd tea.Cmd
+	m.exportInput, cmd = m.exportInput.Update(msg)
+	return m, cmd
+}
+
+// exportNotesCmd writes paths to dest, in the format its extension implies.
+func exportNotesCmd(svc *service.Service, paths []string, dest string) tea.Cmd {
+	return func() tea.Msg {
+		expanded, err := pathutil.Expand(dest)
+		if err != nil {
+			return notesExportedMsg{dest: dest, total: len(paths), err: err}
+		}
+		if abs, err := filepath.Abs(expanded); err == nil {
+			expanded = abs
+		}
+		n, err := svc.ExportNotes(paths, expanded, service.ExportFormatForPath(expanded))
+		return notesExportedMsg{dest: expanded, written: n, total: len(paths), err: err}
+	}
+}
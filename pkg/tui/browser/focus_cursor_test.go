@@ -0,0 +1,64 @@
+package browser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/grovetools/core/pkg/workspace"
+
+	"github.com/grovetools/nb/pkg/service"
+	"github.com/grovetools/nb/pkg/tree"
+	"github.com/grovetools/nb/pkg/tui/browser/views"
+)
+
+func TestFocusCursorPerWorkspace(t *testing.T) {
+	var items []*tree.Item
+	for i := 0; i < 6; i++ {
+		items = append(items, &tree.Item{
+			Path:     fmt.Sprintf("/notes/note-%d.md", i),
+			Name:     fmt.Sprintf("note-%d.md", i),
+			Type:     tree.TypeNote,
+			Metadata: map[string]interface{}{"Title": fmt.Sprintf("Note %d", i)},
+		})
+	}
+	m := &Model{
+		service:         &service.Service{},
+		allItems:        items,
+		filterInput:     textinput.New(),
+		views:           views.New(views.KeyMap{}, map[string]bool{}, views.DefaultSortConfig()),
+		recentNotesMode: true,
+	}
+	m.updateViewsState()
+
+	wsA := &workspace.WorkspaceNode{Name: "a", Path: "/code/a"}
+	wsB := &workspace.WorkspaceNode{Name: "b", Path: "/code/b"}
+
+	m.setFocus(wsA)
+	m.views.SetCursor(5)
+	m.setFocus(wsB)
+	m.restoreFocusCursor() // b was never focused: cursor stays put
+	if got := m.views.GetCursor(); got != 5 {
+		t.Fatalf("first focus of b: cursor = %d, want 5", got)
+	}
+	m.views.SetCursor(1)
+
+	m.setFocus(wsA)
+	m.restoreFocusCursor()
+	if got := m.views.GetCursor(); got != 5 {
+		t.Errorf("back to a: cursor = %d, want 5", got)
+	}
+	m.setFocus(wsB)
+	m.restoreFocusCursor()
+	if got := m.views.GetCursor(); got != 1 {
+		t.Errorf("back to b: cursor = %d, want 1", got)
+	}
+
+	// A saved position past the end of a shorter list is clamped.
+	m.cursorByWorkspace[wsA.Path] = 50
+	m.setFocus(wsA)
+	m.restoreFocusCursor()
+	if got := m.views.GetCursor(); got != len(items)-1 {
+		t.Errorf("clamped: cursor = %d, want %d", got, len(items)-1)
+	}
+}
@@ -0,0 +1,23 @@
+package browser
+
+import (
+	"testing"
+
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+func TestInboxCountSkipsArchivedNotes(t *testing.T) {
+	note := func(path, group string) *tree.Item {
+		return &tree.Item{Path: path, Type: tree.TypeNote, Metadata: map[string]interface{}{"Group": group}}
+	}
+	m := Model{allItems: []*tree.Item{
+		note("/nb/ws/inbox/a.md", "inbox"),
+		note("/nb/ws/inbox/b.md", "inbox"),
+		note("/nb/ws/inbox/.archive/c.md", "inbox/.archive"),
+		note("/nb/ws/.closed/inbox/d.md", "inbox"),
+		note("/nb/ws/issues/e.md", "issues"),
+	}}
+	if got := m.inboxCount(); got != 2 {
+		t.Errorf("inboxCount() = %d, want 2", got)
+	}
+}
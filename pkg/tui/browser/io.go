@@ -2,6 +2,8 @@ package browser
 
 import (
 	"context"
+	"io/fs"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -21,6 +23,7 @@ import (
 
 type workspacesLoadedMsg struct {
 	workspaces []*workspace.WorkspaceNode
+	archived   []service.ArchivedWorkspace
 }
 
 type itemsLoadedMsg struct {
@@ -29,6 +32,73 @@ type itemsLoadedMsg struct {
 	// loaded Job, so the view can resolve human titles and correlate artifacts
 	// with their owning job markdown file. Built by loadPlanJobs.
 	jobs map[string]*orchestration.Job
+	// notebookLocked is set when another process held the notebook lock
+	// as the items were loaded.
+	notebookLocked bool
+	// indexed is set when the items came from the daemon index, which has no
+	// flag field; their flags are read afterwards by loadNoteFlagsCmd.
+	indexed bool
+}
+
+// noteFlagsLoadedMsg carries the flags loadNoteFlagsCmd read, by note path.
+type noteFlagsLoadedMsg struct {
+	flags map[string]string
+}
+
+// loadNoteFlagsCmd reads the flags of items from their frontmatter. The
+// daemon index doesn't carry them, so they are read once the tree is shown
+// rather than holding up the load.
+func loadNoteFlagsCmd(items []*tree.Item) tea.Cmd {
+	var paths []string
+	for _, item := range items {
+		if !item.IsDir && item.Type != tree.TypeGeneric {
+			paths = append(paths, item.Path)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		flags := make(map[string]string)
+		for _, path := range paths {
+			if flag, err := service.ReadNoteFlag(path); err == nil && flag != "" {
+				flags[path] = flag
+			}
+		}
+		return noteFlagsLoadedMsg{flags: flags}
+	}
+}
+
+// withNotebookLockStatus wraps an items-loading command so its
+// itemsLoadedMsg also says whether another process holds the notebook lock,
+// probing the lock files in the command rather than in Update.
+func withNotebookLockStatus(svc *service.Service, cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		msg := cmd()
+		if loaded, ok := msg.(itemsLoadedMsg); ok {
+			loaded.notebookLocked = svc.NotebookLocked()
+			return loaded
+		}
+		return msg
+	}
+}
+
+// workspaceSummaryMsg carries the header summary computed for ws.
+type workspaceSummaryMsg struct {
+	ws      *workspace.WorkspaceNode
+	summary *service.WorkspaceSummary
+}
+
+// fetchWorkspaceSummaryCmd summarizes ws in the background. Errors leave the
+// header without a summary.
+func fetchWorkspaceSummaryCmd(svc *service.Service, ws *workspace.WorkspaceNode, includeArchived bool) tea.Cmd {
+	return func() tea.Msg {
+		summary, err := svc.WorkspaceSummary(ws, includeArchived)
+		if err != nil {
+			return workspaceSummaryMsg{ws: ws}
+		}
+		return workspaceSummaryMsg{ws: ws, summary: summary}
+	}
 }
 
 // loadPlanJobs discovers every plan directory referenced by the loaded items
@@ -85,16 +155,17 @@ func loadPlanJobs(items []*tree.Item) map[string]*orchestration.Job {
 }
 
 func fetchFocusedItemsCmd(svc *service.Service, focusedWS *workspace.WorkspaceNode, showArtifacts bool) tea.Cmd {
-	return func() tea.Msg {
+	return withNotebookLockStatus(svc, func() tea.Msg {
 		// Try daemon index first for fast startup
 		if items := tryDaemonIndex(focusedWS, svc); len(items) > 0 {
 			if !showArtifacts {
 				items = filterOutArtifacts(items)
 			}
+			items = appendUnfiledItems(items, svc, focusedWS)
 			sort.Slice(items, func(i, j int) bool {
 				return items[i].ModTime.After(items[j].ModTime)
 			})
-			return itemsLoadedMsg{items: items, jobs: loadPlanJobs(items)}
+			return itemsLoadedMsg{items: items, jobs: loadPlanJobs(items), indexed: true}
 		}
 
 		// Fall back to filesystem walk
@@ -151,7 +222,34 @@ func fetchFocusedItemsCmd(svc *service.Service, focusedWS *workspace.WorkspaceNo
 			return allItems[i].ModTime.After(allItems[j].ModTime)
 		})
 		return itemsLoadedMsg{items: allItems, jobs: loadPlanJobs(allItems)}
+	})
+}
+
+// appendUnfiledItems adds the focused workspace's loose notes to items
+// loaded from the daemon index, which only covers the content directories.
+// It is a no-op unless the service is configured to show unfiled notes.
+func appendUnfiledItems(items []*tree.Item, svc *service.Service, focusedWS *workspace.WorkspaceNode) []*tree.Item {
+	if focusedWS == nil || svc.Config == nil || !svc.Config.ShowUnfiled {
+		return items
 	}
+	wsCtx, err := svc.GetWorkspaceContext(focusedWS.Path)
+	if err != nil {
+		return items
+	}
+	unfiled, err := svc.ListUnfiledItems(wsCtx)
+	if err != nil {
+		return items
+	}
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		seen[item.Path] = true
+	}
+	for _, item := range unfiled {
+		if !seen[item.Path] {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 // tryDaemonIndex attempts to fetch note index entries from the daemon.
@@ -213,7 +311,48 @@ func tryDaemonIndex(focusedWS *workspace.WorkspaceNode, svc *service.Service) []
 		return nil
 	}
 
-	return convertIndexToItems(allEntries)
+	items := convertIndexToItems(allEntries)
+	countIndexedAttachments(items)
+	return items
+}
+
+// countIndexedAttachments sets Metadata["Attachments"] on the notes among
+// items with files in their attachments/<stem> directory. The daemon index
+// holds no attachment files for the 📎 badge to be counted from, so each
+// note directory's attachments/ is read instead, once.
+func countIndexedAttachments(items []*tree.Item) {
+	stemsByDir := make(map[string]map[string]bool)
+	for _, item := range items {
+		if item.IsDir || item.Type != tree.TypeNote {
+			continue
+		}
+		attachDir := service.NoteAttachmentDir(item.Path)
+		parent := filepath.Dir(attachDir)
+		stems, ok := stemsByDir[parent]
+		if !ok {
+			stems = make(map[string]bool)
+			entries, _ := os.ReadDir(parent)
+			for _, e := range entries {
+				if e.IsDir() {
+					stems[e.Name()] = true
+				}
+			}
+			stemsByDir[parent] = stems
+		}
+		if !stems[filepath.Base(attachDir)] {
+			continue
+		}
+		count := 0
+		_ = filepath.WalkDir(attachDir, func(_ string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				count++
+			}
+			return nil
+		})
+		if count > 0 {
+			item.Metadata["Attachments"] = count
+		}
+	}
 }
 
 // convertIndexToItems maps NoteIndexEntry slices to tree.Item slices.
@@ -274,6 +413,9 @@ func convertIndexToItems(entries []*models.NoteIndexEntry) []*tree.Item {
 
 		if !e.Created.IsZero() {
 			item.Metadata["Created"] = e.Created
+		} else if info, err := os.Stat(e.Path); err == nil {
+			// The index has no created date: date it as the service does.
+			item.Metadata["Created"] = service.NoteCreatedAt(e.Path, "", info)
 		}
 
 		items = append(items, item)
@@ -293,10 +435,25 @@ func filterOutArtifacts(items []*tree.Item) []*tree.Item {
 	return filtered
 }
 
-func fetchWorkspacesCmd(provider *workspace.Provider) tea.Cmd {
+func fetchWorkspacesCmd(svc *service.Service) tea.Cmd {
 	return func() tea.Msg {
-		// Get the real workspaces from the provider.
-		workspaces := provider.All()
+		// Get the real workspaces from the provider, minus the ones whose
+		// notebooks `nb workspace archive` has retired.
+		workspaces := svc.GetWorkspaceProvider().All()
+		archived, _ := svc.ListArchivedWorkspaces()
+		if len(archived) > 0 {
+			isArchived := make(map[string]bool, len(archived))
+			for _, aw := range archived {
+				isArchived[aw.Name] = true
+			}
+			active := make([]*workspace.WorkspaceNode, 0, len(workspaces))
+			for _, ws := range workspaces {
+				if !isArchived[ws.Name] {
+					active = append(active, ws)
+				}
+			}
+			workspaces = active
+		}
 
 		// Create and prepend a synthetic "global" workspace node.
 		// This confines the concept of a "global" workspace to the notebook TUI.
@@ -310,12 +467,12 @@ func fetchWorkspacesCmd(provider *workspace.Provider) tea.Cmd {
 
 		// We need to build the tree to get depth information for filtering ecosystems.
 		workspaces = workspace.BuildWorkspaceTree(workspaces)
-		return workspacesLoadedMsg{workspaces: workspaces}
+		return workspacesLoadedMsg{workspaces: workspaces, archived: archived}
 	}
 }
 
 func fetchAllItemsCmd(svc *service.Service, showArtifacts bool) tea.Cmd {
-	return func() tea.Msg {
+	return withNotebookLockStatus(svc, func() tea.Msg {
 		// Try daemon index first — fetch all entries (no workspace filter)
 		client := daemon.NewWithAutoStart()
 		defer client.Close()
@@ -331,7 +488,7 @@ func fetchAllItemsCmd(svc *service.Service, showArtifacts bool) tea.Cmd {
 				sort.Slice(items, func(i, j int) bool {
 					return items[i].ModTime.After(items[j].ModTime)
 				})
-				return itemsLoadedMsg{items: items, jobs: loadPlanJobs(items)}
+				return itemsLoadedMsg{items: items, jobs: loadPlanJobs(items), indexed: true}
 			}
 		}
 
@@ -352,7 +509,7 @@ func fetchAllItemsCmd(svc *service.Service, showArtifacts bool) tea.Cmd {
 			return items[i].ModTime.After(items[j].ModTime)
 		})
 		return itemsLoadedMsg{items: items, jobs: loadPlanJobs(items)}
-	}
+	})
 }
 
 // gitStatusLoadedMsg is sent when git status for a repository has been fetched
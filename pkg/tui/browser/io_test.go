@@ -0,0 +1,58 @@
+package browser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+func TestCountIndexedAttachments(t *testing.T) {
+	inbox := filepath.Join(t.TempDir(), "inbox")
+	for _, rel := range []string{"design.md", "plain.md", "attachments/design/sketch.png", "attachments/design/raw/data.csv"} {
+		path := filepath.Join(inbox, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	design := &tree.Item{Path: filepath.Join(inbox, "design.md"), Type: tree.TypeNote, Metadata: map[string]interface{}{}}
+	plain := &tree.Item{Path: filepath.Join(inbox, "plain.md"), Type: tree.TypeNote, Metadata: map[string]interface{}{}}
+
+	countIndexedAttachments([]*tree.Item{design, plain})
+
+	if got := design.Metadata["Attachments"]; got != 2 {
+		t.Errorf("design.md Attachments = %v, want 2", got)
+	}
+	if _, ok := plain.Metadata["Attachments"]; ok {
+		t.Errorf("plain.md has no attachments, got %v", plain.Metadata["Attachments"])
+	}
+}
+
+func TestLoadNoteFlagsCmd(t *testing.T) {
+	dir := t.TempDir()
+	flagged := filepath.Join(dir, "flagged.md")
+	plain := filepath.Join(dir, "plain.md")
+	if err := os.WriteFile(flagged, []byte("---\ntitle: Flagged\nflag: red\n---\nbody\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(plain, []byte("---\ntitle: Plain\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	items := []*tree.Item{
+		{Path: flagged, Type: tree.TypeNote},
+		{Path: plain, Type: tree.TypeNote},
+		{Path: filepath.Join(dir, "data.csv"), Type: tree.TypeGeneric},
+	}
+
+	msg, ok := loadNoteFlagsCmd(items)().(noteFlagsLoadedMsg)
+	if !ok {
+		t.Fatal("loadNoteFlagsCmd should return a noteFlagsLoadedMsg")
+	}
+	if len(msg.flags) != 1 || msg.flags[flagged] != "red" {
+		t.Errorf("flags = %v, want only %s: red", msg.flags, flagged)
+	}
+}
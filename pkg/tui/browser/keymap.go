@@ -19,19 +19,34 @@ type KeyMap struct {
 	FocusRecent     key.Binding
 	FocusArchive    key.Binding
 	JumpToArtifacts key.Binding
+	ShowRelated     key.Binding
+	JumpToLinked    key.Binding
+	PlanTimeline    key.Binding
+
+	// Preview search (while the preview is focused)
+	PreviewSearch     key.Binding
+	PreviewSearchNext key.Binding
+	PreviewSearchPrev key.Binding
+	// Selection operations (TUI-specific)
+	VisualLine key.Binding
 	// Search operations (TUI-specific)
-	ReEnterSearch key.Binding
+	ReEnterSearch    key.Binding
+	CycleSearchScope key.Binding
 	// Filter operations (TUI-specific)
 	FilterByTag      key.Binding
+	TagCloud         key.Binding
 	ToggleGitChanges key.Binding
 	Sort             key.Binding
 	CycleGrouping    key.Binding
 	// Toggle operations (TUI-specific)
 	ToggleArchives  key.Binding
+	ReverseSort     key.Binding
 	ToggleArtifacts key.Binding
 	ToggleGlobal    key.Binding
 	ToggleHold      key.Binding
 	ToggleColumns   key.Binding
+	ToggleToday     key.Binding
+	ToggleUntagged  key.Binding
 	// Note operations (TUI-specific)
 	CreateNote       key.Binding
 	CreateNoteInbox  key.Binding
@@ -39,13 +54,25 @@ type KeyMap struct {
 	CreatePlan       key.Binding
 	PromoteToJob     key.Binding
 	Rename           key.Binding
+	EditFrontmatter  key.Binding
 	PriorityUp       key.Binding
 	PriorityDown     key.Binding
+	CycleFlag        key.Binding
+	PlanStatus       key.Binding
+	AddAttachment    key.Binding
+	EditTags         key.Binding
+	Touch            key.Binding
+	PeekLinked       key.Binding
+	QuickLook        key.Binding
+	DiffSelected     key.Binding
+	ExportSelected   key.Binding
 	// Clipboard operations (TUI-specific)
-	Cut     key.Binding
-	Copy    key.Binding
-	Paste   key.Binding
-	Archive key.Binding
+	Cut            key.Binding
+	Copy           key.Binding
+	Paste          key.Binding
+	Archive        key.Binding
+	YankContent    key.Binding
+	YankContentRaw key.Binding
 	// Git operations (TUI-specific)
 	GitCommit      key.Binding
 	GitStageToggle key.Binding
@@ -64,17 +91,19 @@ func (k KeyMap) ShortHelp() []key.Binding {
 // Namespaces returns the which-key chord namespaces for the browser TUI, built
 // from the named KeyMap fields (so any user override applied by ApplyTUIOverrides
 // is reflected — Phase-1 §4 ConfigKey-stability rule). The "t" Toggle namespace
-// groups ta/tb/tg/th/tc/tp; the "g" Goto namespace groups gg (Base.Top), ga, gv.
+// groups ta/tb/tg/th/tc/tp/tt/tT; the "g" Goto namespace groups gg (Base.Top), ga, gv, gr, gl, gt.
 // The update loop arms them through the shared WhichKeyHost sequence engine and
 // View() renders the popup. Order here is the wire order ProcessChord relies on.
 func (k KeyMap) Namespaces() []keymap.Namespace {
 	return []keymap.Namespace{
 		{Prefix: "t", Label: "Toggle", Bindings: []key.Binding{
 			k.ToggleArchives, k.ToggleArtifacts, k.ToggleGlobal,
-			k.ToggleHold, k.ToggleColumns, k.Base.TogglePreview,
+			k.ToggleHold, k.ToggleColumns, k.Base.TogglePreview, k.ToggleToday,
+			k.ToggleUntagged, k.ReverseSort,
 		}},
 		{Prefix: "g", Label: "Goto", Bindings: []key.Binding{
-			k.Base.Top, k.JumpToArtifacts, k.FocusArchive,
+			k.Base.Top, k.JumpToArtifacts, k.FocusArchive, k.ShowRelated, k.JumpToLinked,
+			k.PlanTimeline,
 		}},
 	}
 }
@@ -88,9 +117,9 @@ func (k KeyMap) Sections() []keymap.Section {
 		// Actions (Base): confirm/back/edit/delete(dd)/yank(yy)/rename/refresh/copy-path.
 		// These are all handled in update.go but were previously invisible in help.
 		k.Base.ActionsSection(),
-		k.Base.SelectionSection(),
-		// Search plus the TUI-specific "i" re-enter-search binding.
-		k.Base.SearchSection().With(k.ReEnterSearch),
+		k.Base.SelectionSection().With(k.VisualLine),
+		// Search plus the TUI-specific "i" re-enter-search and ctrl+t scope bindings.
+		k.Base.SearchSection().With(k.ReEnterSearch, k.CycleSearchScope),
 		// Scoped View section: nb only implements switch-view (tab). Preview moved
 		// into the Toggle (t…) namespace as `tp`.
 		keymap.ViewSection(k.SwitchView),
@@ -100,26 +129,30 @@ func (k KeyMap) Sections() []keymap.Section {
 			k.FocusEcosystem, k.ClearFocus,
 			k.FocusSelected, k.FocusRecent,
 		),
-		// Goto (g…) namespace: only ga/gv are exported here — gg (Base.Top) stays
+		// Goto (g…) namespace: only ga/gv/gr/gl are exported here — gg (Base.Top) stays
 		// in the Navigation section, so exporting it again would mint a duplicate
 		// `top` ConfigKey and trip ValidateRegistry's duplicate-ConfigKey error.
-		keymap.NewSection("Goto (g…)", k.JumpToArtifacts, k.FocusArchive),
+		keymap.NewSection("Goto (g…)", k.JumpToArtifacts, k.FocusArchive, k.ShowRelated, k.JumpToLinked, k.PlanTimeline),
 		keymap.NewSection(keymap.SectionFilter,
-			k.FilterByTag, k.ToggleGitChanges, k.Sort, k.CycleGrouping,
+			k.FilterByTag, k.TagCloud, k.ToggleGitChanges, k.Sort, k.CycleGrouping,
+		),
+		keymap.NewSectionWithIcon("Preview", theme.IconSearch,
+			k.PreviewSearch, k.PreviewSearchNext, k.PreviewSearchPrev,
 		),
-		// Toggle (t…) namespace section (ta/tb/tg/th/tc/tp), rendered as
+		// Toggle (t…) namespace section (ta/tb/tg/th/tc/tp/tt/tT), rendered as
 		// "Toggle (t…)" via Namespace.Section().
 		ns[0].Section(),
 		// TUI-specific sections use explicit icons
 		keymap.NewSectionWithIcon("Notes", theme.IconNote,
 			k.CreateNote, k.CreateNoteInbox, k.CreateNoteGlobal,
-			k.CreatePlan, k.PromoteToJob, k.Rename,
-			k.PriorityUp, k.PriorityDown,
+			k.CreatePlan, k.PromoteToJob, k.Rename, k.EditFrontmatter,
+			k.PriorityUp, k.PriorityDown, k.CycleFlag, k.PlanStatus, k.AddAttachment,
+			k.EditTags, k.Touch, k.PeekLinked, k.QuickLook, k.DiffSelected, k.ExportSelected,
 		),
 		// CopyPath (ctrl+y) is already surfaced by Base.ActionsSection above;
 		// it is not repeated here to keep a single `copy_path` ConfigKey.
 		keymap.NewSectionWithIcon("Clipboard", theme.IconArchive,
-			k.Cut, k.Copy, k.Paste, k.Archive,
+			k.Cut, k.Copy, k.Paste, k.Archive, k.YankContent, k.YankContentRaw,
 		),
 		keymap.NewSection(keymap.SectionGit,
 			k.GitStageToggle, k.GitStageAll, k.GitUnstageAll, k.GitCommit,
@@ -166,16 +199,68 @@ func NewKeyMap(cfg *config.Config) KeyMap {
 			key.WithKeys("ga"),
 			key.WithHelp("ga", "goto job artifacts"),
 		),
+		// NOTE: The briefing requested "R" for the related-notes overlay, but "R"
+		// is already Rename. We bind the Goto chord "gr" (goto related) instead,
+		// which keeps it next to ga/gv in the which-key popup. Users can remap via
+		// config.
+		ShowRelated: key.NewBinding(
+			key.WithKeys("gr"),
+			key.WithHelp("gr", "goto related notes (shared tags)"),
+		),
+		// Goto (g…) namespace member: moves the cursor to the plan linked to
+		// the note under the cursor (or the note linked to a plan).
+		JumpToLinked: key.NewBinding(
+			key.WithKeys("gl"),
+			key.WithHelp("gl", "goto linked plan/note"),
+		),
+		// Preview search. ctrl+f is also Base.PageDown and n CreateNote; these
+		// only apply while the preview is focused, where the tree bindings
+		// don't.
+		PreviewSearch: key.NewBinding(
+			key.WithKeys("ctrl+f"),
+			key.WithHelp("ctrl+f", "search preview"),
+		),
+		PreviewSearchNext: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next preview match"),
+		),
+		PreviewSearchPrev: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "previous preview match"),
+		),
+		// NOTE: The briefing requested "t" for the plan timeline overlay, but a
+		// lone "t" is the prefix of the Toggle (t…) namespace and can never
+		// fire. We bind the Goto chord "gt" (goto timeline) instead, next to
+		// gr. Users can remap via config.
+		PlanTimeline: key.NewBinding(
+			key.WithKeys("gt"),
+			key.WithHelp("gt", "goto plan timeline"),
+		),
+		// Selection operations
+		VisualLine: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "visual-line select (esc to stop)"),
+		),
 		// Search operations
 		ReEnterSearch: key.NewBinding(
 			key.WithKeys("i"),
 			key.WithHelp("i", "re-enter search (vim insert)"),
 		),
+		// Only handled while the search input is focused, so a ctrl chord is
+		// needed to keep every printable key typeable.
+		CycleSearchScope: key.NewBinding(
+			key.WithKeys("ctrl+t"),
+			key.WithHelp("ctrl+t", "cycle search scope (title/body/all)"),
+		),
 		// Filter operations
 		FilterByTag: key.NewBinding(
 			key.WithKeys("&"),
 			key.WithHelp("&", "filter by tag"),
 		),
+		TagCloud: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "tag cloud"),
+		),
 		ToggleGitChanges: key.NewBinding(
 			key.WithKeys("<", ">"),
 			// Help label uses "/" so it is treated as an alternate-key list and
@@ -184,7 +269,7 @@ func NewKeyMap(cfg *config.Config) KeyMap {
 		),
 		Sort: key.NewBinding(
 			key.WithKeys("s"),
-			key.WithHelp("s", "toggle sort order"),
+			key.WithHelp("s", "cycle sort field (created/modified/title/workspace)"),
 		),
 		// NOTE: The briefing requested default key "g", but nb's browser already
 		// binds the "gg" go-to-top sequence; a lone "g" is always consumed as the
@@ -218,6 +303,18 @@ func NewKeyMap(cfg *config.Config) KeyMap {
 			key.WithKeys("tc"),
 			key.WithHelp("tc", "toggle columns"),
 		),
+		ToggleToday: key.NewBinding(
+			key.WithKeys("tt"),
+			key.WithHelp("tt", "toggle today section"),
+		),
+		ToggleUntagged: key.NewBinding(
+			key.WithKeys("tT"),
+			key.WithHelp("tT", "toggle notes without tags only"),
+		),
+		ReverseSort: key.NewBinding(
+			key.WithKeys("tr"),
+			key.WithHelp("tr", "reverse sort order"),
+		),
 		// Note operations
 		CreateNote: key.NewBinding(
 			key.WithKeys("n"),
@@ -243,6 +340,13 @@ func NewKeyMap(cfg *config.Config) KeyMap {
 			key.WithKeys("R"),
 			key.WithHelp("R", "rename note"),
 		),
+		// NOTE: The briefing requested "e" for the inline frontmatter editor, but
+		// "e" is Base.Edit (quick edit in the host's Editor pane). We bind the
+		// shifted "E" instead so both stay reachable. Users can remap via config.
+		EditFrontmatter: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "edit raw frontmatter"),
+		),
 		PriorityUp: key.NewBinding(
 			key.WithKeys("{"),
 			key.WithHelp("{", "bump priority more critical"),
@@ -251,6 +355,59 @@ func NewKeyMap(cfg *config.Config) KeyMap {
 			key.WithKeys("}"),
 			key.WithHelp("}", "bump priority less critical"),
 		),
+		CycleFlag: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "cycle note flag"),
+		),
+		// NOTE: ctrl+s is also "save" inside the frontmatter editor, but that
+		// modal consumes keys before the tree bindings run, so the two never
+		// collide.
+		PlanStatus: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "set plan status"),
+		),
+		// NOTE: The briefing requested "A" for adding attachments, but "A" is
+		// Base.SelectNone. We bind "ctrl+o" (open file) instead. Users can remap
+		// via config.
+		AddAttachment: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "attach file to note"),
+		),
+		// Opens a prompt for the selected notes: "tag" adds it, "-tag"
+		// removes it.
+		EditTags: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "add/remove (-tag) tag on selected"),
+		),
+		// Bumps `modified` (and the file mtime) to now so the note sorts as
+		// recent, like touch(1).
+		Touch: key.NewBinding(
+			key.WithKeys("U"),
+			key.WithHelp("U", "touch selected (bump modified)"),
+		),
+		// Shows the linked plan/note in the preview without moving the cursor
+		// (vim's K "look up"); esc restores the previous preview.
+		PeekLinked: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "peek linked plan/note in preview"),
+		),
+		// Pops up a summary of the note under the cursor over the tree, for
+		// when the preview pane is hidden.
+		QuickLook: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "quick look"),
+		),
+		// Compares the two selected notes in a scrollable overlay.
+		DiffSelected: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "diff two selected notes"),
+		),
+		// Prompts for a destination: a directory the notes are copied into,
+		// or a .md file they are concatenated into under ## headings.
+		ExportSelected: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "export (write out) selected"),
+		),
 		// Clipboard operations
 		Cut: key.NewBinding(
 			key.WithKeys("x"),
@@ -272,6 +429,16 @@ func NewKeyMap(cfg *config.Config) KeyMap {
 			key.WithKeys("X"),
 			key.WithHelp("X", "archive selected"),
 		),
+		// Unlike yy, these put the note's text on the system clipboard for
+		// pasting into other programs; nb's own paste buffer is untouched.
+		YankContent: key.NewBinding(
+			key.WithKeys("yc"),
+			key.WithHelp("yc", "yank note content to system clipboard"),
+		),
+		YankContentRaw: key.NewBinding(
+			key.WithKeys("yC"),
+			key.WithHelp("yC", "yank note content with frontmatter"),
+		),
 		// Git operations
 		GitCommit: key.NewBinding(
 			key.WithKeys("C"),
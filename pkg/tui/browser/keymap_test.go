@@ -100,7 +100,7 @@ func TestNamespacesMembership(t *testing.T) {
 	for _, b := range ns[0].Bindings {
 		toggleKeys[firstKey(b)] = true
 	}
-	for _, k := range []string{"ta", "tb", "tg", "th", "tc", "tp"} {
+	for _, k := range []string{"ta", "tb", "tg", "th", "tc", "tp", "tt"} {
 		if !toggleKeys[k] {
 			t.Errorf("Toggle namespace missing member %q", k)
 		}
@@ -109,7 +109,7 @@ func TestNamespacesMembership(t *testing.T) {
 	for _, b := range ns[1].Bindings {
 		gotoKeys[firstKey(b)] = true
 	}
-	for _, k := range []string{"gg", "ga", "gv"} {
+	for _, k := range []string{"gg", "ga", "gv", "gr", "gt"} {
 		if !gotoKeys[k] {
 			t.Errorf("Goto namespace missing member %q", k)
 		}
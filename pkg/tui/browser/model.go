@@ -8,9 +8,12 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/filepicker"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -28,6 +31,7 @@ import (
 	"github.com/grovetools/nb/pkg/sync"
 	"github.com/grovetools/nb/pkg/tree"
 	"github.com/grovetools/nb/pkg/tui/browser/components/confirm"
+	"github.com/grovetools/nb/pkg/tui/browser/components/tagcloud"
 	"github.com/grovetools/nb/pkg/tui/browser/views"
 )
 
@@ -46,7 +50,9 @@ type Model struct {
 	showArtifacts       bool                // Whether to show .artifacts directories
 	hideGlobal          bool                // Whether to hide the global workspace node
 	showOnHold          bool                // Whether to show on-hold plans
+	showTodaySection    bool                // Whether to pin the "Today" section above the tree
 	showGitModifiedOnly bool                // Whether to show only notes with git changes
+	showUntaggedOnly    bool                // Whether to show only notes without tags (tT)
 	spinner             spinner.Model
 	loadingCount        int
 	recentNotesMode     bool           // Whether to show only recent notes
@@ -59,6 +65,15 @@ type Model struct {
 	ecosystemPickerMode bool
 	focusedWorkspace    *workspace.WorkspaceNode
 	focusChanged        bool // Tracks if focus just changed (to reset collapse state)
+	// cursorByWorkspace remembers the cursor of each focus, keyed by the
+	// focused workspace's path ("" for the unfocused view), so switching back
+	// to a workspace puts the cursor where it was.
+	cursorByWorkspace map[string]int
+
+	// workspaceSummary is shown in the header while summaryWorkspace is the
+	// focused workspace. It is recomputed once per item load, not per key.
+	workspaceSummary *service.WorkspaceSummary
+	summaryWorkspace *workspace.WorkspaceNode
 
 	// Selection and archiving state
 	statusMessage string
@@ -81,11 +96,61 @@ type Model struct {
 	renameInput    textinput.Model
 	noteToRename   *models.Note
 
+	// Bulk tag edit state
+	isEditingTags bool
+	tagEditInput  textinput.Model
+	tagEditPaths  []string // Notes the entered tag is applied to
+
+	// Export prompt state
+	isExporting bool
+	exportInput textinput.Model
+	exportPaths []string // Notes written to the entered destination
+
+	// Raw frontmatter editor state
+	textareaMode      bool           // True while the inline frontmatter editor is open
+	frontmatterEditor textarea.Model // Built fresh each time the editor opens
+	frontmatterPath   string         // Note whose frontmatter is being edited
+	frontmatterError  string         // Last parse/validation error, shown inline
+
+	// Related notes overlay state
+	relatedMode   bool                  // True while the related-notes overlay is open
+	relatedSource string                // Title of the note the overlay was opened for
+	relatedNotes  []service.RelatedNote // Best match first
+	relatedCursor int
+
+	// Plan timeline overlay (gt on a plan)
+	timelineMode    bool
+	timelinePlan    string
+	timelineEntries []service.TimelineEntry // Oldest first
+	timelineCursor  int
+
+	// Plan notes overlay (enter on a plan with no notes shown)
+	planNotesMode   bool
+	planNotesPlan   string
+	planNotes       []*models.Note // Sorted by path
+	planNotesCursor int
+
+	// Quick-look popup (L on a note)
+	quickLookMode       bool
+	quickLookNote       *models.Note
+	quickLookPlanStatus string // Status of the note's linked plan, if any
+
+	// Diff overlay (D with two notes selected)
+	diffMode  bool
+	diffTitle string
+	diffView  viewport.Model
+
 	// Note promotion state
 	isPromotingToJob bool // True when showing plan picker for promote-to-job
 	noteToPromote    *models.Note
 	planPicker       list.Model
 
+	// Plan status picker state
+	planStatusMode   bool       // True when showing the status picker for a plan
+	planStatusPicker list.Model // Offers service.PlanStatuses
+	planStatusWs     string     // Workspace of the plan being updated
+	planStatusPlan   string     // Plan group being updated, e.g. "plans/my-plan"
+
 	// Column Visibility
 	columnVisibility map[string]bool
 	columnSelectMode bool
@@ -93,13 +158,20 @@ type Model struct {
 	availableColumns []string
 
 	// Grep mode state
-	isGrepping bool // True when in content search mode
+	isGrepping  bool   // True when in content search mode
+	searchScope string // Scope for unprefixed queries (service.SearchIn*); empty means title
 
 	// Tag filter mode state
 	isFilteringByTag bool   // True when in tag filter mode
 	selectedTag      string // The tag being filtered on
 	tagPickerMode    bool   // True when showing tag picker
 	tagPicker        list.Model
+	tagCloud         tagcloud.Model // Full-screen tag cloud; Active while shown
+
+	// Attachment picker state
+	attachPickerMode bool             // True when picking a file to attach
+	attachPicker     filepicker.Model // Browses the filesystem for the file
+	attachNotePath   string           // Note the picked file is attached to
 
 	// View component
 	views views.Model
@@ -110,6 +182,29 @@ type Model struct {
 	previewVisible bool // Whether the preview pane is shown
 	previewContent string
 	previewFile    string // Path of the file currently in preview
+	// previewStats is the word count and readability of the previewed note,
+	// shown in the status bar while the preview is open.
+	previewStats string
+
+	// Search within the preview (ctrl+f while it is focused, then n/N).
+	// Matches are 0-based line offsets into previewContent. While a search
+	// is active the preview viewport is shown in place of the tree.
+	previewSearchInput   textinput.Model
+	previewSearching     bool // The search input has focus
+	previewSearchQuery   string
+	previewSearchMatches []int
+	previewSearchIndex   int // Current entry in previewSearchMatches
+
+	// Peek state: while peekPath is set the preview shows the linked plan or
+	// note of the node at peekOrigin. Esc restores peekReturnPath.
+	peekPath          string
+	peekOrigin        string
+	peekReturnPath    string
+	peekOpenedPreview bool // The peek made the preview visible, so esc hides it again
+
+	// Mouse state, for detecting double clicks
+	lastClickIndex int       // Display index of the last left click
+	lastClickAt    time.Time // When it happened; zero when no click is pending
 
 	// Git status state
 	gitFileStatus   map[string]string // Key: normalized absolute path, Value: git status code
@@ -129,6 +224,14 @@ type Model struct {
 	// Hosting context
 	hosted bool // True when running inside groveterm; use SplitEditorRequestMsg
 
+	// Command used to open non-markdown files; empty picks a system viewer
+	// by file type (see openWithExternalTool).
+	openTool string
+
+	// Percent of the width given to the tree when the preview or editor is
+	// split beside it; 0 keeps the host's default split.
+	splitRatio int
+
 	// Flow plan jobs keyed by job ID (the opaque `.artifacts/<jobID>` dir name).
 	// Loaded by loadPlanJobs in io.go and refreshed on each itemsLoadedMsg. Used
 	// to resolve human-readable artifact titles and correlate artifacts with the
@@ -158,7 +261,18 @@ type Config struct {
 	Service      *service.Service
 	InitialFocus *workspace.WorkspaceNode
 	Context      *service.WorkspaceContext
-	Hosted       bool // True when embedded inside groveterm (use BSP splits for editing)
+	Hosted       bool   // True when embedded inside groveterm (use BSP splits for editing)
+	OpenTool     string // Command for opening non-markdown files, e.g. "feh -F"; empty uses the system viewer
+
+	// Width and Height lay the model out before the first WindowSizeMsg
+	// arrives, avoiding a flash of the default layout. 0 waits for it.
+	Width, Height int
+	// SplitRatio is the percent of the width given to the tree when a preview
+	// is split beside it. 0 uses the ratio saved from the last session.
+	SplitRatio int
+	// Sort is the initial note order. An empty Field uses the order saved
+	// from the last session.
+	Sort views.SortConfig
 }
 
 // New creates a new browser TUI model from a Config.
@@ -221,15 +335,22 @@ func New(cfg Config) Model {
 	renameInput.CharLimit = 200
 	renameInput.Width = 60
 
+	tagEditInput := textinput.New()
+	tagEditInput.Placeholder = "tag to add, or -tag to remove"
+	tagEditInput.CharLimit = 100
+	tagEditInput.Width = 60
+
+	exportInput := textinput.New()
+	exportInput.Placeholder = "directory, or file.md to concatenate"
+	exportInput.CharLimit = 500
+	exportInput.Width = 60
+
 	// Commit dialog setup
 	commitInput := textinput.New()
 	commitInput.Placeholder = "Update notes"
 	commitInput.CharLimit = 200
 	commitInput.Width = 60
 
-	// Column Visibility Setup - load from state
-	availableColumns := []string{"TYPE", "STATUS", "PRIORITY", "TAGS", "WORKSPACE", "CREATED", "MODIFIED", "PATH"}
-
 	// Load saved state
 	state, err := loadState()
 	if err != nil {
@@ -248,6 +369,9 @@ func New(cfg Config) Model {
 		}
 	}
 
+	// Column order - the saved order with any new columns appended.
+	availableColumns := normalizeColumnOrder(state.ColumnOrder, views.DefaultColumnOrder)
+
 	columnVisibility := state.ColumnVisibility
 	var columnItems []list.Item
 	for _, col := range availableColumns {
@@ -269,6 +393,18 @@ func New(cfg Config) Model {
 	planPicker.SetShowStatusBar(false)
 	planPicker.SetShowPagination(false)
 
+	// Initialize plan status picker (statuses are fixed)
+	var statusItems []list.Item
+	for _, status := range service.PlanStatuses {
+		statusItems = append(statusItems, planStatusItem(status))
+	}
+	planStatusPicker := list.New(statusItems, planStatusDelegate{}, 30, len(statusItems)+4)
+	planStatusPicker.Title = "Set Plan Status"
+	planStatusPicker.SetShowHelp(false)
+	planStatusPicker.SetFilteringEnabled(false)
+	planStatusPicker.SetShowStatusBar(false)
+	planStatusPicker.SetShowPagination(false)
+
 	// Initialize tag picker (will be populated when opened)
 	// Start with a reasonable default height, will be adjusted in populateTagPicker
 	tagPicker := list.New([]list.Item{}, tagDelegate{}, 40, 20)
@@ -297,7 +433,11 @@ func New(cfg Config) Model {
 		Select:       keys.Select,
 		SelectNone:   keys.SelectNone,
 	}
-	viewsModel := views.New(viewsKeys, columnVisibility)
+	sortConfig := cfg.Sort
+	if sortConfig.Field == "" && state.SortConfig != nil {
+		sortConfig = *state.SortConfig
+	}
+	viewsModel := views.New(viewsKeys, columnVisibility, sortConfig)
 
 	// Seed persisted collapse state and grouping axis from saved state.
 	// Use the existing SetCollapseState setter rather than overwriting the
@@ -310,6 +450,8 @@ func New(cfg Config) Model {
 		groupBy = "none"
 	}
 	viewsModel.SetGroupBy(groupBy)
+	viewsModel.SetViewMode(views.ParseViewMode(state.ViewMode))
+	viewsModel.SetColumnOrder(availableColumns)
 
 	// Initialize preview viewport
 	preview := viewport.New(80, 20) // Initial size, will be updated on WindowSizeMsg
@@ -317,7 +459,12 @@ func New(cfg Config) Model {
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(theme.DefaultTheme.Colors.MutedText)
 
-	return Model{
+	splitRatio := cfg.SplitRatio
+	if splitRatio == 0 {
+		splitRatio = state.SplitRatio
+	}
+
+	m := Model{
 		service:          svc,
 		keys:             keys,
 		help:             helpModel,
@@ -332,6 +479,8 @@ func New(cfg Config) Model {
 		noteTitleInput:   noteTitleInput,
 		noteTypePicker:   noteTypePicker,
 		renameInput:      renameInput,
+		tagEditInput:     tagEditInput,
+		exportInput:      exportInput,
 		columnVisibility: columnVisibility,
 		columnSelectMode: false,
 		columnList:       columnList,
@@ -339,31 +488,92 @@ func New(cfg Config) Model {
 		confirmDialog:    confirmDialog,
 		clipboard:        []string{},
 		planPicker:       planPicker,
+		planStatusPicker: planStatusPicker,
 		tagPicker:        tagPicker,
+		tagCloud:         tagcloud.New(),
 		views:            viewsModel,
 		preview:          preview,
 		previewFocused:   false,
 		previewVisible:   false, // Preview hidden by default
 		recentNotesMode:  false,
+		showTodaySection: svc.Config != nil && svc.Config.ShowTodaySection,
 		gitFileStatus:    make(map[string]string),
 		scannedGitRepos:  make(map[string]bool),
 		commitInput:      commitInput,
 		groupBy:          groupBy,
 		hosted:           cfg.Hosted,
+		openTool:         cfg.OpenTool,
+		splitRatio:       splitRatio,
+	}
+	if cfg.Width > 0 && cfg.Height > 0 {
+		m.resize(cfg.Width, cfg.Height)
+	}
+	if (cfg.SplitRatio != 0 && cfg.SplitRatio != state.SplitRatio) ||
+		(cfg.Sort.Field != "" && (state.SortConfig == nil || cfg.Sort != *state.SortConfig)) {
+		_ = m.saveState()
+	}
+	return m
+}
+
+// defaultPreviewRatio is the share of the width a hosted preview split takes
+// when no split ratio is set.
+const defaultPreviewRatio = 0.35
+
+// SplitRatio returns the percent of the width the tree should keep when a
+// preview or editor is split beside it, or 0 to let the host decide.
+func (m Model) SplitRatio() int { return m.splitRatio }
+
+// previewRatio is the share of the width requested for a hosted preview.
+func (m Model) previewRatio() float64 {
+	if m.splitRatio <= 0 || m.splitRatio >= 100 {
+		return defaultPreviewRatio
 	}
+	return float64(100-m.splitRatio) / 100
+}
+
+// resize lays the model out for a terminal of the given size. The preview is
+// rendered by the host, so the tree always gets the full width.
+func (m *Model) resize(width, height int) {
+	m.width, m.height = width, height
+	m.help.SetSize(width, height)
+
+	// header(1) + search(1) + blank(1) + view + blank(1) + status(1) + footer(1) + top_margin(1)
+	const mainContentHeight = 7
+	m.views.SetSize(m.width-4, m.height-mainContentHeight)
+	// The preview search shows the preview in the tree's place, with its
+	// search bar taking a row.
+	m.preview.Width, m.preview.Height = m.width-4, max(m.height-mainContentHeight-1, 1)
+	m.preview.SetYOffset(m.preview.YOffset)
+
+	m.columnList.SetSize(40, 8)
+	m.resizeNoteDiff(width, height)
 }
 
 // NoteCount returns the total number of notes in the browser list.
 func (m Model) NoteCount() int { return len(m.allItems) }
 
+// inboxCount is the number of unfiled inbox notes among the loaded items,
+// counted with the same rule as `nb inbox` (service.SelectInboxNotes).
+func (m Model) inboxCount() int {
+	var notes []*models.Note
+	for _, item := range m.allItems {
+		if item.IsDir || item.Type != tree.TypeNote {
+			continue
+		}
+		notes = append(notes, views.ItemToNote(item))
+	}
+	return len(service.SelectInboxNotes(notes))
+}
+
 // IsTextEntryActive reports whether a text input is currently focused,
 // so the pager knows to suspend navigation key bindings.
 func (m Model) IsTextEntryActive() bool {
-	return m.filterInput.Focused() || m.isCreatingNote || m.isRenamingNote || m.isCommitting || m.isPromotingToJob
+	return m.filterInput.Focused() || m.isCreatingNote || m.isRenamingNote || m.isEditingTags || m.isExporting || m.isCommitting || m.isPromotingToJob || m.planStatusMode || m.textareaMode || m.attachPickerMode || m.tagCloud.Active
 }
 
-// populateTagPicker collects all unique tags with counts and populates the tag picker, sorted by count descending
-func (m *Model) populateTagPicker() {
+// collectTagCounts counts the notes carrying each tag, skipping archived and
+// closed notes unless archives are shown.
+func (m *Model) collectTagCounts() map[string]int {
 	tagCounts := make(map[string]int)
 
 	// Count occurrences of each tag
@@ -383,6 +593,22 @@ func (m *Model) populateTagPicker() {
 			}
 		}
 	}
+	return tagCounts
+}
+
+// openTagCloud shows the full-screen tag cloud for the loaded notes.
+func (m *Model) openTagCloud() {
+	var tags []tagcloud.Tag
+	for tag, count := range m.collectTagCounts() {
+		tags = append(tags, tagcloud.Tag{Name: tag, Count: count})
+	}
+	m.tagCloud.SetSize(m.width-4, m.height-2)
+	m.tagCloud.Activate(tags)
+}
+
+// populateTagPicker collects all unique tags with counts and populates the tag picker, sorted by count descending
+func (m *Model) populateTagPicker() {
+	tagCounts := m.collectTagCounts()
 
 	// Convert to tagItem slice
 	type tagCount struct {
@@ -491,18 +717,36 @@ func (m Model) Init() tea.Cmd {
 		notesCmd = fetchAllItemsCmd(m.service, m.showArtifacts)
 	}
 	return tea.Batch(
-		fetchWorkspacesCmd(m.service.GetWorkspaceProvider()),
+		fetchWorkspacesCmd(m.service),
 		notesCmd,
 		m.updatePreviewContent(),
 		m.spinner.Tick,
 	)
 }
 
+// workspaceSummaryCmd recomputes the header summary for the focused
+// workspace, counting archived notes only while archives are shown. With no
+// workspace focused it returns nil.
+func (m *Model) workspaceSummaryCmd() tea.Cmd {
+	if m.focusedWorkspace == nil {
+		return nil
+	}
+	return fetchWorkspaceSummaryCmd(m.service, m.focusedWorkspace, m.showArchives)
+}
+
 // updatePreviewContent checks if the preview needs to be updated and returns a command to load the file.
 // When preview is visible, it also emits an embed.PreviewRequestMsg so the terminal host can
 // open/update a PTY-based preview split (nvim -R in the VDrawer).
 func (m *Model) updatePreviewContent() tea.Cmd {
 	node := m.views.GetCurrentNode()
+	if m.peekPath != "" {
+		// Keep the peeked file while the cursor stays on the node it was
+		// peeked from; moving elsewhere ends the peek.
+		if node != nil && node.Item != nil && node.Item.Path == m.peekOrigin {
+			return nil
+		}
+		m.clearPeek()
+	}
 	if node != nil && node.IsNote() {
 		// If the file in preview is already the selected one, do nothing.
 		if m.previewFile == node.Item.Path {
@@ -521,12 +765,12 @@ func (m *Model) updatePreviewContent() tea.Cmd {
 				// swaps the buffer in the existing split, preserving the ratio.
 				cmds = append(cmds,
 					func() tea.Msg {
-						return embed.SplitEditorRequestMsg{Path: path, Focus: false}
+						return embed.SplitEditorRequestMsg{Path: hostPreviewPath(path), Focus: false}
 					},
 				)
 			} else {
 				cmds = append(cmds, func() tea.Msg {
-					return embed.PreviewRequestMsg{Path: path}
+					return embed.PreviewRequestMsg{Path: hostPreviewPath(path)}
 				})
 			}
 		}
@@ -546,7 +790,10 @@ func (m *Model) updatePreviewContent() tea.Cmd {
 type fileContentReadyMsg struct {
 	path    string
 	content string
-	err     error
+	// fullSize is the file's size when content is only its head because
+	// the file is over max_parse_size; 0 otherwise.
+	fullSize int64
+	err      error
 }
 
 // notesArchivedMsg is sent when notes and/or plans have been archived
@@ -588,6 +835,85 @@ type noteRenamedMsg struct {
 	err     error
 }
 
+// tagsEditedMsg is sent after a tag is added to or removed from notes
+type tagsEditedMsg struct {
+	tag     string
+	removed bool
+	changed int
+	total   int
+	err     error
+}
+
+// notesExportedMsg is sent after the export action wrote notes out
+type notesExportedMsg struct {
+	dest    string
+	written int
+	total   int
+	err     error
+}
+
+// attachmentsAddedMsg is sent after files are attached to a note
+type attachmentsAddedMsg struct {
+	notePath string
+	paths    []string
+	err      error
+}
+
+// externalOpenFinishedMsg is sent after a non-markdown file was handed to an
+// external tool
+type externalOpenFinishedMsg struct {
+	path string
+	tool string
+	err  error
+}
+
+// frontmatterLoadedMsg carries a note's raw frontmatter into the inline editor.
+type frontmatterLoadedMsg struct {
+	path string
+	raw  string
+	err  error
+}
+
+// frontmatterSavedMsg is sent after the inline editor writes frontmatter back.
+type frontmatterSavedMsg struct {
+	path string
+	err  error
+}
+
+// planTimelineLoadedMsg carries the result of GetPlanTimeline.
+type planTimelineLoadedMsg struct {
+	plan    string
+	entries []service.TimelineEntry
+	err     error
+}
+
+// planNotesLoadedMsg carries the result of GetPlanNotes.
+type planNotesLoadedMsg struct {
+	plan  string
+	notes []*models.Note
+	err   error
+}
+
+// quickLookLoadedMsg carries the note parsed for the quick-look popup.
+type quickLookLoadedMsg struct {
+	note *models.Note
+	err  error
+}
+
+// noteDiffLoadedMsg carries the unified diff of the two selected notes.
+type noteDiffLoadedMsg struct {
+	title string
+	diff  string
+	err   error
+}
+
+// relatedNotesLoadedMsg carries the result of GetRelatedNotesScored.
+type relatedNotesLoadedMsg struct {
+	source  string
+	related []service.RelatedNote
+	err     error
+}
+
 // noteTypeItem implements the list.Item interface for the note type picker.
 type noteTypeItem string
 
@@ -682,6 +1008,35 @@ func (d planDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 	fmt.Fprint(w, str)
 }
 
+// planStatusItem implements the list.Item interface for the plan status picker.
+type planStatusItem string
+
+func (i planStatusItem) FilterValue() string { return string(i) }
+func (i planStatusItem) Title() string       { return string(i) }
+func (i planStatusItem) Description() string { return "" }
+
+// planStatusDelegate is a custom delegate with minimal spacing for the plan status picker
+type planStatusDelegate struct{}
+
+func (d planStatusDelegate) Height() int                             { return 1 }
+func (d planStatusDelegate) Spacing() int                            { return 0 }
+func (d planStatusDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+func (d planStatusDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	i, ok := item.(planStatusItem)
+	if !ok {
+		return
+	}
+
+	str := string(i)
+	if index == m.Index() {
+		str = lipgloss.NewStyle().Foreground(theme.DefaultTheme.Colors.Orange).Render("│ " + str)
+	} else {
+		str = "  " + str
+	}
+
+	fmt.Fprint(w, str)
+}
+
 // columnSelectItem represents an item in the column visibility list
 type columnSelectItem struct {
 	name     string
@@ -719,6 +1074,45 @@ func (d columnSelectDelegate) Render(w io.Writer, m list.Model, index int, item
 	fmt.Fprint(w, str)
 }
 
+// normalizeColumnOrder reconciles a saved column order with the columns this
+// version knows about: unknown (removed) columns are dropped, duplicates are
+// ignored, and known columns missing from the saved order are appended in
+// their default position order, so new columns never break a saved layout.
+func normalizeColumnOrder(saved, known []string) []string {
+	isKnown := make(map[string]bool, len(known))
+	for _, col := range known {
+		isKnown[col] = true
+	}
+	order := make([]string, 0, len(known))
+	seen := make(map[string]bool, len(known))
+	for _, col := range saved {
+		if isKnown[col] && !seen[col] {
+			order = append(order, col)
+			seen[col] = true
+		}
+	}
+	for _, col := range known {
+		if !seen[col] {
+			order = append(order, col)
+		}
+	}
+	return order
+}
+
+// moveSelectedColumn moves the column under the selector cursor by delta
+// positions, keeping the cursor on it, and applies the new order to the table.
+func (m *Model) moveSelectedColumn(delta int) {
+	from := m.columnList.Index()
+	to := from + delta
+	if from < 0 || from >= len(m.availableColumns) || to < 0 || to >= len(m.availableColumns) {
+		return
+	}
+	m.availableColumns[from], m.availableColumns[to] = m.availableColumns[to], m.availableColumns[from]
+	m.columnList.SetItems(m.getColumnListItems())
+	m.columnList.Select(to)
+	m.views.SetColumnOrder(m.availableColumns)
+}
+
 // getColumnListItems returns the current list items for the column selector
 func (m *Model) getColumnListItems() []list.Item {
 	var items []list.Item
@@ -740,6 +1134,17 @@ type tuiState struct {
 	// GroupBy persists the active "Group By" axis applied inside directory
 	// groups: one of "none", "date", "status", "tag".
 	GroupBy string `json:"group_by,omitempty"`
+	// ViewMode persists the tree/table/compact view (views.ViewMode.String).
+	ViewMode string `json:"view_mode,omitempty"`
+	// ColumnOrder persists the table column order chosen in the column
+	// selector. Columns missing from it are appended on load.
+	ColumnOrder []string `json:"column_order,omitempty"`
+	// SplitRatio persists the percent of the width kept by the tree when a
+	// preview is split beside it (nb tui --split-ratio).
+	SplitRatio int `json:"split_ratio,omitempty"`
+	// SortConfig persists the note order chosen with the Sort keys or
+	// nb tui --sort.
+	SortConfig *views.SortConfig `json:"sort_config,omitempty"`
 }
 
 // getStateFilePath returns the path to the TUI state file
@@ -792,10 +1197,22 @@ func (m *Model) saveState() error {
 		return err
 	}
 
+	// Recent and archive views force the table temporarily; persist the
+	// user's own choice instead.
+	viewMode := m.views.GetViewMode()
+	if m.recentNotesMode || m.archiveViewMode {
+		viewMode = m.savedViewMode
+	}
+
+	sortConfig := m.views.GetSortConfig()
 	state := tuiState{
 		ColumnVisibility: m.columnVisibility,
 		CollapsedNodes:   m.views.GetCollapseState(),
 		GroupBy:          m.groupBy,
+		ViewMode:         viewMode.String(),
+		ColumnOrder:      m.availableColumns,
+		SplitRatio:       m.splitRatio,
+		SortConfig:       &sortConfig,
 	}
 
 	data, err := json.MarshalIndent(state, "", "  ")
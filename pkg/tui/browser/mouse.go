@@ -0,0 +1,100 @@
+package browser
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// doubleClickInterval is the longest gap between two clicks on the same row
+// that still counts as a double click.
+const doubleClickInterval = 400 * time.Millisecond
+
+// mouseBlocked reports whether a modal overlay owns the screen, in which case
+// mouse events are ignored rather than acting on the hidden tree.
+func (m Model) mouseBlocked() bool {
+	return m.help.ShowAll || m.confirmDialog.Active || m.tagPickerMode || m.isPromotingToJob || m.planStatusMode ||
+		m.isCreatingNote || m.isRenamingNote || m.isEditingTags || m.isExporting || m.textareaMode || m.relatedMode || m.timelineMode || m.planNotesMode || m.quickLookMode || m.diffMode ||
+		m.isCommitting || m.columnSelectMode || m.attachPickerMode
+}
+
+// searchBarVisible mirrors the condition View uses to draw the search bar.
+func (m Model) searchBarVisible() bool {
+	return m.filterInput.Focused() || m.filterInput.Value() != ""
+}
+
+// treeTopLine is the screen row of the first line of the tree/table: a blank
+// top margin and the header, plus the search bar and its spacer when shown.
+func (m Model) treeTopLine() int {
+	if m.searchBarVisible() {
+		return 4
+	}
+	return 2
+}
+
+// handleMouse implements click-to-navigate and wheel scrolling. A single
+// click moves the cursor to the clicked row, a double click opens it like
+// enter, and clicking the search bar focuses the filter input. When the host
+// shows the preview beside the browser, clicks past the browser's width focus
+// the preview.
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	// The tag cloud handles its own clicks. View draws it one line down and
+	// tagCloudIndent columns in.
+	if m.tagCloud.Active {
+		msg.X -= tagCloudIndent
+		msg.Y--
+		var cmd tea.Cmd
+		m.tagCloud, cmd = m.tagCloud.Update(msg)
+		return m, cmd
+	}
+	if m.mouseBlocked() {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.views.MoveCursor(-1)
+		return m, nil
+	case tea.MouseButtonWheelDown:
+		m.views.MoveCursor(1)
+		return m, nil
+	case tea.MouseButtonLeft:
+	default:
+		return m, nil
+	}
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	if m.previewVisible && msg.X >= m.width {
+		m.previewFocused = true
+		return m, nil
+	}
+	m.previewFocused = false
+
+	if m.searchBarVisible() && msg.Y == 2 {
+		m.filterInput.Focus()
+		return m, textinput.Blink
+	}
+
+	idx := m.views.NodeIndexAtLine(msg.Y - m.treeTopLine())
+	if idx < 0 {
+		return m, nil
+	}
+	if m.filterInput.Focused() {
+		// Keep the filter value so the clicked result stays in view.
+		m.filterInput.Blur()
+	}
+
+	now := time.Now()
+	doubleClick := idx == m.lastClickIndex && now.Sub(m.lastClickAt) <= doubleClickInterval
+	m.views.SetCursor(idx)
+	if doubleClick {
+		// Reset so a third click starts a new pair instead of opening again.
+		m.lastClickAt = time.Time{}
+		return m, m.openCurrentNode()
+	}
+	m.lastClickIndex, m.lastClickAt = idx, now
+	return m, nil
+}
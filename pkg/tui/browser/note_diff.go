@@ -0,0 +1,127 @@
+package browser
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/grovetools/core/tui/theme"
+)
+
+// loadNoteDiffCmd diffs the two selected notes for the diff overlay, in path
+// order so the diff reads the same way every time.
+func (m *Model) loadNoteDiffCmd() tea.Cmd {
+	selected := m.views.GetSelected()
+	if len(selected) != 2 {
+		m.statusMessage = "Select exactly two notes to diff"
+		return nil
+	}
+	paths := make([]string, 0, 2)
+	for path := range selected {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	svc := m.service
+	return func() tea.Msg {
+		diff, err := svc.DiffNotes(paths[0], paths[1])
+		return noteDiffLoadedMsg{
+			title: filepath.Base(paths[0]) + " → " + filepath.Base(paths[1]),
+			diff:  diff,
+			err:   err,
+		}
+	}
+}
+
+// openNoteDiff shows diff in the overlay, sized to the terminal.
+func (m *Model) openNoteDiff(title, diff string) {
+	width, height := noteDiffViewportSize(m.width, m.height)
+	m.diffMode = true
+	m.diffTitle = title
+	m.diffView = viewport.New(width, height)
+	m.diffView.SetContent(colorizeDiff(diff))
+}
+
+// resizeNoteDiff fits an open diff overlay to a width x height terminal,
+// keeping the scroll position within the resized viewport.
+func (m *Model) resizeNoteDiff(width, height int) {
+	if !m.diffMode {
+		return
+	}
+	m.diffView.Width, m.diffView.Height = noteDiffViewportSize(width, height)
+	m.diffView.SetYOffset(m.diffView.YOffset)
+}
+
+// closeNoteDiff discards the diff overlay state.
+func (m *Model) closeNoteDiff() {
+	m.diffMode = false
+	m.diffTitle = ""
+}
+
+// updateNoteDiff handles input while the diff overlay is open: esc closes,
+// everything else scrolls.
+func (m Model) updateNoteDiff(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Back) || key.Matches(msg, m.keys.Quit) || key.Matches(msg, m.keys.DiffSelected) {
+		m.closeNoteDiff()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.diffView, cmd = m.diffView.Update(msg)
+	return m, cmd
+}
+
+// noteDiffView renders the diff overlay.
+func (m Model) noteDiffView() string {
+	faint := lipgloss.NewStyle().Faint(true)
+	header := lipgloss.NewStyle().Bold(true).Render(truncateLine(m.diffTitle, m.diffView.Width))
+	scroll := faint.Render(fmt.Sprintf("%3.0f%%", m.diffView.ScrollPercent()*100))
+
+	dialogBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.DefaultTheme.Colors.Cyan).
+		Padding(0, 1).
+		Render(header + "\n" + m.diffView.View() + "\n" + scroll)
+
+	helpText := faint.
+		Width(lipgloss.Width(dialogBox)).
+		Align(lipgloss.Center).
+		Render(truncateLine("j/k to scroll • Esc to close", lipgloss.Width(dialogBox)))
+
+	return lipgloss.JoinVertical(lipgloss.Left, dialogBox, helpText)
+}
+
+// noteDiffViewportSize returns the diff viewport size for a width x height
+// terminal: border and padding take 4 columns and a column of margin on
+// each side 2 more; border, header, scroll indicator and help line take 5
+// rows and the blank line above the overlay and a row of margin 2 more.
+func noteDiffViewportSize(width, height int) (int, int) {
+	return max(width-6, 20), max(height-7, 3)
+}
+
+// colorizeDiff colors a unified diff's added, removed and hunk header lines.
+func colorizeDiff(diff string) string {
+	colors := theme.DefaultTheme.Colors
+	added := lipgloss.NewStyle().Foreground(colors.Green)
+	removed := lipgloss.NewStyle().Foreground(colors.Red)
+	hunk := lipgloss.NewStyle().Foreground(colors.Cyan)
+
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = lipgloss.NewStyle().Bold(true).Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = added.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = removed.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = hunk.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
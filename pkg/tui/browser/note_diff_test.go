@@ -0,0 +1,48 @@
+package browser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColorizeDiffKeepsText(t *testing.T) {
+	diff := "--- a.md\n+++ b.md\n@@ -1,3 +1,3 @@\n # Note\n-two\n+2\n three\n"
+	// Tests don't write to a terminal, so the styles render as plain text.
+	got := colorizeDiff(diff)
+	if want := strings.TrimRight(diff, "\n"); got != want {
+		t.Errorf("colorizeDiff changed the text:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestNoteDiffViewportSize(t *testing.T) {
+	for _, tc := range []struct{ width, height, wantW, wantH int }{
+		{120, 40, 114, 33},
+		{10, 5, 20, 3},
+	} {
+		w, h := noteDiffViewportSize(tc.width, tc.height)
+		if w != tc.wantW || h != tc.wantH {
+			t.Errorf("noteDiffViewportSize(%d, %d) = %d, %d; want %d, %d", tc.width, tc.height, w, h, tc.wantW, tc.wantH)
+		}
+	}
+}
+
+func TestResizeNoteDiff(t *testing.T) {
+	var m Model
+	m.width, m.height = 120, 40
+	m.openNoteDiff("a.md → b.md", strings.Repeat("+line\n", 100))
+	m.diffView.GotoBottom()
+
+	m.resizeNoteDiff(100, 60)
+	if w, h := noteDiffViewportSize(100, 60); m.diffView.Width != w || m.diffView.Height != h {
+		t.Errorf("viewport = %dx%d, want %dx%d", m.diffView.Width, m.diffView.Height, w, h)
+	}
+	if !m.diffView.AtBottom() || m.diffView.YOffset != 100-m.diffView.Height {
+		t.Errorf("YOffset = %d after growing, want it clamped to %d", m.diffView.YOffset, 100-m.diffView.Height)
+	}
+
+	m.closeNoteDiff()
+	m.resizeNoteDiff(80, 24)
+	if m.diffView.Width == 74 {
+		t.Error("a closed overlay should not be resized")
+	}
+}
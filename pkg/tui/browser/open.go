@@ -0,0 +1,118 @@
+package browser
+
+import (
+	"fmt"
+	"mime"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+// mimeTypes covers the file types the browser shows most often; anything else
+// falls back to the platform's mime table.
+var mimeTypes = map[string]string{
+	".md":   "text/markdown",
+	".txt":  "text/plain",
+	".json": "application/json",
+	".xml":  "application/xml",
+	".yml":  "application/yaml",
+	".yaml": "application/yaml",
+	".pdf":  "application/pdf",
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".svg":  "image/svg+xml",
+	".webp": "image/webp",
+}
+
+// mimeTypeForExtension returns the MIME type for a file extension such as
+// ".png" (case-insensitive, leading dot optional). Unknown extensions map to
+// "application/octet-stream".
+func mimeTypeForExtension(ext string) string {
+	ext = strings.ToLower(ext)
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	if t, ok := mimeTypes[ext]; ok {
+		return t
+	}
+	if t := mime.TypeByExtension(ext); t != "" {
+		// Drop parameters such as "; charset=utf-8".
+		t, _, _ = strings.Cut(t, ";")
+		return strings.TrimSpace(t)
+	}
+	return "application/octet-stream"
+}
+
+// Viewers tried in order on Linux before falling back to xdg-open.
+var (
+	imageViewers = []string{"imv", "feh", "eog", "sxiv"}
+	pdfViewers   = []string{"zathura", "evince", "okular"}
+)
+
+// externalOpenCommand returns the command that opens path. A non-empty tool is
+// split like a shell command line (so quoted arguments survive) and gets path
+// appended. Otherwise images and PDFs use the
+// first installed viewer, and everything else uses the platform opener (open
+// on macOS, xdg-open elsewhere).
+func externalOpenCommand(tool, path string) (*exec.Cmd, error) {
+	if strings.TrimSpace(tool) != "" {
+		fields, err := service.SplitCommandLine(tool)
+		if err != nil {
+			return nil, fmt.Errorf("parse --tool %q: %w", tool, err)
+		}
+		return exec.Command(fields[0], append(fields[1:], path)...), nil
+	}
+
+	if runtime.GOOS == "darwin" {
+		// Preview handles both images and PDFs through open.
+		return exec.Command("open", path), nil
+	}
+
+	mimeType := mimeTypeForExtension(filepath.Ext(path))
+	var candidates []string
+	switch {
+	case strings.HasPrefix(mimeType, "image/"):
+		candidates = append(candidates, imageViewers...)
+	case mimeType == "application/pdf":
+		candidates = append(candidates, pdfViewers...)
+	}
+	for _, name := range append(candidates, "xdg-open") {
+		if bin, err := exec.LookPath(name); err == nil {
+			return exec.Command(bin, path), nil
+		}
+	}
+	return nil, fmt.Errorf("no viewer found for %s (install xdg-open or pass --tool)", mimeType)
+}
+
+// openWithExternalTool opens a non-markdown file outside the editor. A tool
+// given with `nb tui --tool` may be a terminal program, so it takes over the
+// terminal like $EDITOR does; system viewers are GUI programs and are started
+// in the background so the browser stays usable.
+func (m *Model) openWithExternalTool(path string) tea.Cmd {
+	cmd, err := externalOpenCommand(m.openTool, path)
+	if err != nil {
+		return func() tea.Msg { return externalOpenFinishedMsg{path: path, err: err} }
+	}
+	tool := filepath.Base(cmd.Path)
+
+	if m.openTool != "" {
+		return tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return externalOpenFinishedMsg{path: path, tool: tool, err: err}
+		})
+	}
+	return func() tea.Msg {
+		if err := cmd.Start(); err != nil {
+			return externalOpenFinishedMsg{path: path, tool: tool, err: err}
+		}
+		// Reap the viewer when it exits; its status is of no interest.
+		go func() { _ = cmd.Wait() }()
+		return externalOpenFinishedMsg{path: path, tool: tool}
+	}
+}
@@ -0,0 +1,50 @@
+package browser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMimeTypeForExtension(t *testing.T) {
+	tests := map[string]string{
+		".png":  "image/png",
+		".JPG":  "image/jpeg",
+		"gif":   "image/gif",
+		".pdf":  "application/pdf",
+		".json": "application/json",
+		".md":   "text/markdown",
+		".zzz9": "application/octet-stream",
+		"":      "application/octet-stream",
+	}
+	for ext, want := range tests {
+		if got := mimeTypeForExtension(ext); got != want {
+			t.Errorf("mimeTypeForExtension(%q) = %q, want %q", ext, got, want)
+		}
+	}
+}
+
+func TestExternalOpenCommandUsesTool(t *testing.T) {
+	cmd, err := externalOpenCommand("feh -F", "/tmp/diagram.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"feh", "-F", "/tmp/diagram.png"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("args = %q, want %q", cmd.Args, want)
+	}
+}
+
+func TestExternalOpenCommandQuotedTool(t *testing.T) {
+	cmd, err := externalOpenCommand(`"/opt/My Viewer/view" --title 'a b'`, "/tmp/diagram.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/opt/My Viewer/view", "--title", "a b", "/tmp/diagram.png"}
+	if !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("args = %q, want %q", cmd.Args, want)
+	}
+
+	if _, err := externalOpenCommand(`feh "-F`, "/tmp/diagram.png"); err == nil {
+		t.Error("unterminated quote: expected an error")
+	}
+}
@@ -0,0 +1,139 @@
+package browser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grovetools/core/tui/embed"
+
+	"github.com/grovetools/nb/pkg/tui/browser/views"
+)
+
+// linkedPeekPath returns the file to show when peeking at node's linked node.
+// A linked note is shown as is. A linked plan is a directory, so the job the
+// note was promoted to (plan_job) is shown, or else the plan's first job file.
+func linkedPeekPath(node *views.DisplayNode) (string, error) {
+	linked := node.LinkedNode
+	if linked == nil || linked.Item == nil {
+		return "", fmt.Errorf("no linked plan or note")
+	}
+	if !linked.Item.IsDir {
+		if _, err := os.Stat(linked.Item.Path); err != nil {
+			return "", fmt.Errorf("linked note %s was deleted", filepath.Base(linked.Item.Path))
+		}
+		return linked.Item.Path, nil
+	}
+
+	planDir := linked.Item.Path
+	entries, err := os.ReadDir(planDir)
+	if err != nil {
+		return "", fmt.Errorf("linked plan %s was deleted", filepath.Base(planDir))
+	}
+	if job, ok := node.Item.Metadata["PlanJob"].(string); ok && job != "" {
+		if _, err := os.Stat(filepath.Join(planDir, job)); err == nil {
+			return filepath.Join(planDir, job), nil
+		}
+	}
+	var jobs []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".md") && !strings.HasPrefix(e.Name(), ".") {
+			jobs = append(jobs, e.Name())
+		}
+	}
+	if len(jobs) == 0 {
+		return "", fmt.Errorf("linked plan %s has no job files", filepath.Base(planDir))
+	}
+	sort.Strings(jobs)
+	return filepath.Join(planDir, jobs[0]), nil
+}
+
+// peekLinked shows the linked plan or note of the node under the cursor in
+// the preview, opening the preview if needed. The cursor stays put; esc (or
+// moving the cursor) ends the peek.
+func (m *Model) peekLinked() tea.Cmd {
+	node := m.views.GetCurrentNode()
+	if node == nil || node.Item == nil {
+		return nil
+	}
+	path, err := linkedPeekPath(node)
+	if err != nil {
+		m.statusMessage = "Cannot peek: " + err.Error()
+		return nil
+	}
+
+	if m.peekPath == "" {
+		m.peekReturnPath = m.previewFile
+		m.peekOpenedPreview = !m.previewVisible
+	}
+	m.peekPath = path
+	m.peekOrigin = node.Item.Path
+	m.previewVisible = true
+	m.previewFile = path
+	m.statusMessage = fmt.Sprintf("Peeking %s (esc to return, gl to jump)", filepath.Base(path))
+	return tea.Batch(loadFileContentCmd(path), m.previewRequestCmd(path))
+}
+
+// endPeek restores the preview that was shown before the peek.
+func (m *Model) endPeek() tea.Cmd {
+	returnPath, closePreview := m.peekReturnPath, m.peekOpenedPreview
+	m.clearPeek()
+	if strings.HasPrefix(m.statusMessage, "Peeking") {
+		m.statusMessage = ""
+	}
+
+	if closePreview || returnPath == "" {
+		m.previewVisible = false
+		m.clearPreviewSearch()
+		m.previewFile = ""
+		if m.hosted {
+			return func() tea.Msg { return embed.SplitEditorCloseRequestMsg{} }
+		}
+		return func() tea.Msg { return embed.PreviewRequestMsg{Path: ""} }
+	}
+	m.previewFile = returnPath
+	return tea.Batch(loadFileContentCmd(returnPath), m.previewRequestCmd(returnPath))
+}
+
+// clearPeek forgets the peek without touching the preview.
+func (m *Model) clearPeek() {
+	m.peekPath = ""
+	m.peekOrigin = ""
+	m.peekReturnPath = ""
+	m.peekOpenedPreview = false
+}
+
+// previewRequestCmd asks the host to show path in the preview pane.
+func (m *Model) previewRequestCmd(path string) tea.Cmd {
+	if m.hosted {
+		return func() tea.Msg {
+			return embed.SplitEditorRequestMsg{Path: hostPreviewPath(path), Ratio: m.previewRatio(), Focus: false}
+		}
+	}
+	return func() tea.Msg { return embed.PreviewRequestMsg{Path: hostPreviewPath(path)} }
+}
+
+// jumpToLinked moves the cursor to the linked plan or note of the node under
+// the cursor.
+func (m *Model) jumpToLinked() tea.Cmd {
+	node := m.views.GetCurrentNode()
+	if node == nil || node.LinkedNode == nil || node.LinkedNode.Item == nil {
+		m.statusMessage = "No linked plan or note"
+		return nil
+	}
+	target := node.LinkedNode.Item.Path
+	if _, err := os.Stat(target); err != nil {
+		m.statusMessage = fmt.Sprintf("Linked %s was deleted", filepath.Base(target))
+		return nil
+	}
+	m.views.SetCursorToPath(target)
+	if cur := m.views.GetCurrentNode(); cur == nil || cur.Item == nil || cur.Item.Path != target {
+		m.statusMessage = fmt.Sprintf("%s is hidden by a fold or filter", filepath.Base(target))
+		return nil
+	}
+	m.statusMessage = "Jumped to " + filepath.Base(target)
+	return m.updatePreviewContent()
+}
@@ -0,0 +1,53 @@
+package browser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/nb/pkg/tree"
+	"github.com/grovetools/nb/pkg/tui/browser/views"
+)
+
+func TestLinkedPeekPath(t *testing.T) {
+	tmp := t.TempDir()
+	planDir := filepath.Join(tmp, "plans", "feature")
+	if err := os.MkdirAll(planDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"01-spec.md", "02-impl.md", ".grove-plan.yml"} {
+		if err := os.WriteFile(filepath.Join(planDir, name), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	notePath := filepath.Join(tmp, "inbox", "note.md")
+
+	plan := &views.DisplayNode{Item: &tree.Item{Path: planDir, IsDir: true, Type: tree.TypePlan}}
+	noteWith := func(job string) *views.DisplayNode {
+		return &views.DisplayNode{
+			Item:       &tree.Item{Path: notePath, Type: tree.TypeNote, Metadata: map[string]interface{}{"PlanJob": job}},
+			LinkedNode: plan,
+		}
+	}
+
+	if got, err := linkedPeekPath(noteWith("02-impl.md")); err != nil || got != filepath.Join(planDir, "02-impl.md") {
+		t.Errorf("plan_job: got %q, %v", got, err)
+	}
+	if got, err := linkedPeekPath(noteWith("")); err != nil || got != filepath.Join(planDir, "01-spec.md") {
+		t.Errorf("no plan_job: got %q, %v; want the first job", got, err)
+	}
+
+	// Plan -> note, where the note file is gone.
+	planToNote := &views.DisplayNode{Item: plan.Item, LinkedNode: &views.DisplayNode{Item: &tree.Item{Path: notePath, Type: tree.TypeNote}}}
+	if _, err := linkedPeekPath(planToNote); err == nil || !strings.Contains(err.Error(), "deleted") {
+		t.Errorf("deleted note: err = %v, want a deleted error", err)
+	}
+
+	if err := os.RemoveAll(planDir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := linkedPeekPath(noteWith("02-impl.md")); err == nil || !strings.Contains(err.Error(), "deleted") {
+		t.Errorf("deleted plan: err = %v, want a deleted error", err)
+	}
+}
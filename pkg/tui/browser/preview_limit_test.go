@@ -0,0 +1,44 @@
+package browser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func TestHostPreviewPathTruncatesOversizedNotes(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	t.Cleanup(func() { service.SetMaxParseSize(0) })
+	service.SetMaxParseSize(16)
+
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small.md")
+	huge := filepath.Join(dir, "huge.md")
+	if err := os.WriteFile(small, []byte("short\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(huge, []byte(strings.Repeat("line\n", 100)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := hostPreviewPath(small); got != small {
+		t.Errorf("hostPreviewPath(small) = %q, want the note itself", got)
+	}
+	got := hostPreviewPath(huge)
+	if got == huge || filepath.Ext(got) != ".md" {
+		t.Fatalf("hostPreviewPath(huge) = %q, want a .md copy", got)
+	}
+	content, err := os.ReadFile(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), "line\nline\nline\nl\n") {
+		t.Errorf("copy should start with the note's first 16 bytes, got %q", content)
+	}
+	if !strings.Contains(string(content), "over max_parse_size") {
+		t.Errorf("copy should end with the notice, got %q", content)
+	}
+}
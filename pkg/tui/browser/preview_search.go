@@ -0,0 +1,210 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/grovetools/core/tui/theme"
+)
+
+// previewMatchStyle marks search matches in the preview content.
+var previewMatchStyle = lipgloss.NewStyle().Reverse(true)
+
+// highlightMatches wraps every case-insensitive occurrence of query in content
+// with previewMatchStyle. An empty query returns content unchanged.
+func highlightMatches(content, query string) string {
+	if query == "" {
+		return content
+	}
+	lowerContent := strings.ToLower(content)
+	lowerQuery := strings.ToLower(query)
+	if len(lowerContent) != len(content) || len(lowerQuery) != len(query) {
+		// Lowercasing changed byte lengths (rare non-ASCII case folds), so
+		// offsets into lowerContent don't map back; fall back to exact case.
+		lowerContent, lowerQuery = content, query
+	}
+
+	var b strings.Builder
+	start := 0
+	for {
+		i := strings.Index(lowerContent[start:], lowerQuery)
+		if i < 0 {
+			break
+		}
+		i += start
+		b.WriteString(content[start:i])
+		// Style each line of the match separately so a span never straddles
+		// a line break in the viewport.
+		for j, part := range strings.Split(content[i:i+len(query)], "\n") {
+			if j > 0 {
+				b.WriteByte('\n')
+			}
+			b.WriteString(previewMatchStyle.Render(part))
+		}
+		start = i + len(query)
+	}
+	b.WriteString(content[start:])
+	return b.String()
+}
+
+// previewMatchLines returns the 0-based offsets of the lines of content that
+// contain query (case-insensitive), in order.
+func previewMatchLines(content, query string) []int {
+	if query == "" {
+		return nil
+	}
+	lowerQuery := strings.ToLower(query)
+	var lines []int
+	for i, line := range strings.Split(content, "\n") {
+		if strings.Contains(strings.ToLower(line), lowerQuery) {
+			lines = append(lines, i)
+		}
+	}
+	return lines
+}
+
+// openPreviewSearch opens the preview search input, seeded with the last
+// query so ctrl+f enter repeats it.
+func (m *Model) openPreviewSearch() tea.Cmd {
+	input := textinput.New()
+	input.Prompt = "/"
+	input.Placeholder = "search preview"
+	input.SetValue(m.previewSearchQuery)
+	input.CursorEnd()
+	input.Focus()
+	m.previewSearchInput = input
+	m.previewSearching = true
+	m.previewFocused = true
+	return textinput.Blink
+}
+
+// updatePreviewSearch handles input while the preview search input is open:
+// matches are highlighted as the query is typed, enter keeps the query for
+// n/N, esc clears the search.
+func (m Model) updatePreviewSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.clearPreviewSearch()
+		return m, nil
+	case tea.KeyEnter:
+		m.previewSearching = false
+		m.previewSearchInput.Blur()
+		if m.previewSearchQuery != "" && len(m.previewSearchMatches) == 0 {
+			m.statusMessage = "Pattern not found: " + m.previewSearchQuery
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.previewSearchInput, cmd = m.previewSearchInput.Update(msg)
+	if query := m.previewSearchInput.Value(); query != m.previewSearchQuery {
+		m.setPreviewSearchQuery(query)
+		m.jumpPreviewMatch(0)
+	}
+	return m, cmd
+}
+
+// updatePreviewSearchKeys handles ctrl+f, n and N while the preview is
+// focused. It reports whether msg was one of them. While the tree is focused
+// it handles nothing, so the keys keep their tree bindings (page down, new
+// note).
+func (m *Model) updatePreviewSearchKeys(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if !m.previewVisible || !m.previewFocused {
+		return nil, false
+	}
+	switch {
+	case key.Matches(msg, m.keys.PreviewSearch):
+		return m.openPreviewSearch(), true
+	case m.previewSearchQuery == "":
+		return nil, false
+	case key.Matches(msg, m.keys.PreviewSearchNext):
+		m.jumpPreviewMatch(1)
+		return nil, true
+	case key.Matches(msg, m.keys.PreviewSearchPrev):
+		m.jumpPreviewMatch(-1)
+		return nil, true
+	case key.Matches(msg, m.keys.Back):
+		m.clearPreviewSearch()
+		return nil, true
+	}
+	return nil, false
+}
+
+// setPreviewSearchQuery searches the preview content for query and
+// highlights the matches in the preview viewport.
+func (m *Model) setPreviewSearchQuery(query string) {
+	m.previewSearchQuery = query
+	m.previewSearchMatches = previewMatchLines(m.previewContent, query)
+	m.previewSearchIndex = 0
+	m.preview.SetContent(highlightMatches(m.previewContent, query))
+}
+
+// jumpPreviewMatch moves delta matches from the current one, wrapping at
+// either end, and scrolls the preview to it. A delta of 0 goes to the first
+// match at or below the top of the preview.
+func (m *Model) jumpPreviewMatch(delta int) {
+	n := len(m.previewSearchMatches)
+	if n == 0 {
+		return
+	}
+	if delta == 0 {
+		m.previewSearchIndex = 0
+		for i, line := range m.previewSearchMatches {
+			if line >= m.preview.YOffset {
+				m.previewSearchIndex = i
+				break
+			}
+		}
+	} else {
+		m.previewSearchIndex = ((m.previewSearchIndex+delta)%n + n) % n
+	}
+	m.preview.SetYOffset(m.previewSearchMatches[m.previewSearchIndex])
+}
+
+// clearPreviewSearch drops the search and its highlighting.
+func (m *Model) clearPreviewSearch() {
+	m.previewSearching = false
+	m.previewSearchInput.Blur()
+	m.previewSearchQuery = ""
+	m.previewSearchMatches = nil
+	m.previewSearchIndex = 0
+	m.preview.SetContent(m.previewContent)
+}
+
+// previewSearchActive reports whether a preview search is being typed or
+// kept for n/N. The preview viewport is then shown in place of the tree.
+func (m Model) previewSearchActive() bool {
+	return m.previewVisible && (m.previewSearching || m.previewSearchQuery != "")
+}
+
+// previewSearchBar renders the preview search line shown above the status
+// bar: the query (with the input caret while typing), the match position and
+// the matched line with its matches highlighted. It is empty when no search
+// is active.
+func (m Model) previewSearchBar() string {
+	if !m.previewSearchActive() {
+		return ""
+	}
+	bar := theme.IconSearch + " Preview "
+	if m.previewSearching {
+		bar += m.previewSearchInput.View()
+	} else {
+		bar += "/" + m.previewSearchQuery
+	}
+	n := len(m.previewSearchMatches)
+	if n == 0 {
+		if m.previewSearchQuery != "" {
+			bar += theme.DefaultTheme.Muted.Render("  no matches")
+		}
+		return bar
+	}
+	line := m.previewSearchMatches[m.previewSearchIndex]
+	text := strings.TrimSpace(strings.Split(m.previewContent, "\n")[line])
+	return fmt.Sprintf("%s  %s %s", bar,
+		theme.DefaultTheme.Muted.Render(fmt.Sprintf("%d/%d L%d:", m.previewSearchIndex+1, n, line+1)),
+		highlightMatches(text, m.previewSearchQuery))
+}
@@ -0,0 +1,114 @@
+package browser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestHighlightMatches(t *testing.T) {
+	content := "Alpha beta\nalphabet soup\nno match here"
+	got := highlightMatches(content, "alpha")
+	if n := strings.Count(got, previewMatchStyle.Render("Alpha")) + strings.Count(got, previewMatchStyle.Render("alpha")); n != 2 {
+		t.Errorf("want 2 highlighted matches (case-insensitive), got %d in %q", n, got)
+	}
+	if strings.Count(got, "\n") != 2 {
+		t.Errorf("highlighting changed the line structure: %q", got)
+	}
+	if highlightMatches(content, "") != content {
+		t.Error("an empty query should leave content unchanged")
+	}
+}
+
+func TestPreviewMatchLines(t *testing.T) {
+	content := "Alpha beta\nalphabet soup\nno match here\nALPHA"
+	if got, want := previewMatchLines(content, "alpha"), []int{0, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("previewMatchLines = %v, want %v", got, want)
+	}
+	if got := previewMatchLines(content, ""); got != nil {
+		t.Errorf("empty query matched %v", got)
+	}
+}
+
+// n and N wrap around the matches and scroll the preview to them.
+func TestJumpPreviewMatchWraps(t *testing.T) {
+	m := Model{preview: viewport.New(80, 2)}
+	m.previewContent = strings.Repeat("filler\n", 10) + "hit one\nfiller\nhit two\nfiller"
+	m.preview.SetContent(m.previewContent)
+	m.setPreviewSearchQuery("hit")
+	if !reflect.DeepEqual(m.previewSearchMatches, []int{10, 12}) {
+		t.Fatalf("matches = %v, want [10 12]", m.previewSearchMatches)
+	}
+
+	m.jumpPreviewMatch(0)
+	if m.previewSearchIndex != 0 || m.preview.YOffset != 10 {
+		t.Errorf("first jump: index %d offset %d, want 0 and 10", m.previewSearchIndex, m.preview.YOffset)
+	}
+	m.jumpPreviewMatch(1)
+	if m.previewSearchIndex != 1 || m.preview.YOffset != 12 {
+		t.Errorf("next: index %d offset %d, want 1 and 12", m.previewSearchIndex, m.preview.YOffset)
+	}
+	m.jumpPreviewMatch(1)
+	if m.previewSearchIndex != 0 {
+		t.Errorf("next past the last match should wrap to the first, got %d", m.previewSearchIndex)
+	}
+	m.jumpPreviewMatch(-1)
+	if m.previewSearchIndex != 1 {
+		t.Errorf("previous before the first match should wrap to the last, got %d", m.previewSearchIndex)
+	}
+
+	m.clearPreviewSearch()
+	if m.previewSearchQuery != "" || m.previewSearchMatches != nil {
+		t.Error("clearPreviewSearch left search state behind")
+	}
+}
+
+// ctrl+f, n and N only search the preview while it is focused; otherwise
+// they are left to the tree (page down, new note).
+func TestPreviewSearchKeysNeedFocus(t *testing.T) {
+	m := Model{keys: NewKeyMap(nil), preview: viewport.New(80, 5), previewVisible: true}
+	m.previewContent = "one hit\ntwo hit"
+	m.setPreviewSearchQuery("hit")
+
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyCtrlF},
+		{Type: tea.KeyRunes, Runes: []rune("n")},
+		{Type: tea.KeyRunes, Runes: []rune("N")},
+	} {
+		if _, ok := m.updatePreviewSearchKeys(msg); ok {
+			t.Errorf("%s was handled with the tree focused", msg)
+		}
+	}
+
+	m.previewFocused = true
+	if _, ok := m.updatePreviewSearchKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")}); !ok || m.previewSearchIndex != 1 {
+		t.Errorf("n with the preview focused: handled %v, index %d; want the next match", ok, m.previewSearchIndex)
+	}
+	if _, ok := m.updatePreviewSearchKeys(tea.KeyMsg{Type: tea.KeyCtrlF}); !ok || !m.previewSearching {
+		t.Error("ctrl+f with the preview focused should open the search input")
+	}
+}
+
+// The search shows the preview viewport, with the matches highlighted, in
+// place of the tree.
+func TestPreviewSearchShowsViewport(t *testing.T) {
+	m := Model{preview: viewport.New(80, 5), previewVisible: true}
+	if m.previewSearchActive() {
+		t.Fatal("no search is active yet")
+	}
+	m.previewContent = "alpha\nbeta"
+	m.setPreviewSearchQuery("beta")
+	if !m.previewSearchActive() {
+		t.Fatal("a kept query keeps the search active")
+	}
+	if view := m.preview.View(); !strings.Contains(view, previewMatchStyle.Render("beta")) {
+		t.Errorf("preview viewport does not show the highlighted match: %q", view)
+	}
+	m.previewVisible = false
+	if m.previewSearchActive() {
+		t.Error("a hidden preview shows no search")
+	}
+}
@@ -33,7 +33,7 @@ func TestBumpSelectedPriorityOptimistic(t *testing.T) {
 		Metadata: map[string]interface{}{"Priority": "p3", "Title": "Test"},
 	}
 
-	vm := views.New(views.KeyMap{}, map[string]bool{})
+	vm := views.New(views.KeyMap{}, map[string]bool{}, views.DefaultSortConfig())
 	m := &Model{
 		service:     &service.Service{},
 		allItems:    []*tree.Item{item},
@@ -0,0 +1,153 @@
+package browser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/grovetools/core/tui/embed"
+	"github.com/grovetools/core/tui/theme"
+
+	"github.com/grovetools/nb/pkg/models"
+	"github.com/grovetools/nb/pkg/service"
+)
+
+const (
+	// quickLookWidth is the widest the quick-look popup grows, border included.
+	quickLookWidth = 72
+	// quickLookExcerptLines is how many body lines the popup shows at most.
+	quickLookExcerptLines = 8
+)
+
+// loadQuickLookCmd parses the note under the cursor for the quick-look popup.
+func (m *Model) loadQuickLookCmd() tea.Cmd {
+	node := m.views.GetCurrentNode()
+	if node == nil || !node.IsNote() {
+		m.statusMessage = "Move the cursor onto a note for a quick look"
+		return nil
+	}
+	path := node.Item.Path
+	// The tree's workspace name is what GetPlanStatus resolves, so it wins
+	// over the one ParseNote derives from the path.
+	wsName, _ := node.Item.Metadata["Workspace"].(string)
+	return func() tea.Msg {
+		note, err := service.ParseNote(path)
+		if err != nil {
+			return quickLookLoadedMsg{err: err}
+		}
+		if wsName != "" {
+			note.Workspace = wsName
+		}
+		return quickLookLoadedMsg{note: note}
+	}
+}
+
+// closeQuickLook discards the quick-look popup state.
+func (m *Model) closeQuickLook() {
+	m.quickLookMode = false
+	m.quickLookNote = nil
+	m.quickLookPlanStatus = ""
+}
+
+// updateQuickLook handles input while the quick-look popup is open: enter
+// opens the note in its own pane, e quick-edits it, esc closes.
+func (m Model) updateQuickLook(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Quit), key.Matches(msg, m.keys.QuickLook):
+		m.closeQuickLook()
+	case key.Matches(msg, m.keys.Confirm), key.Matches(msg, m.keys.Edit):
+		path := m.quickLookNote.Path
+		dedicated := key.Matches(msg, m.keys.Confirm)
+		m.closeQuickLook()
+		return m, func() tea.Msg {
+			return embed.EditRequestMsg{Path: path, Dedicated: dedicated}
+		}
+	}
+	return m, nil
+}
+
+// quickLookView renders the quick-look popup sized to the terminal.
+func (m Model) quickLookView() string {
+	return renderQuickLook(m.quickLookNote, m.quickLookPlanStatus, m.width, m.height)
+}
+
+// renderQuickLook renders note's popup: title, workspace and dates, tags,
+// linked plan and its status, the start of the body and the word count.
+// Long lines are truncated and the excerpt is shortened, down to nothing, so
+// the popup and its help line fit within width x height.
+func renderQuickLook(note *models.Note, planStatus string, width, height int) string {
+	boxWidth := min(width-2, quickLookWidth)
+	// Border (1 each side) and horizontal padding (2 each side).
+	inner := max(boxWidth-6, 10)
+	faint := lipgloss.NewStyle().Faint(true)
+
+	title := note.FrontmatterTitle
+	if title == "" {
+		title = note.Title
+	}
+	header := []string{
+		lipgloss.NewStyle().Bold(true).Render(truncateLine(title, inner)),
+		faint.Render(truncateLine(fmt.Sprintf("%s · %s · modified %s",
+			note.Workspace, note.Type, note.ModifiedAt.Format("2006-01-02 15:04")), inner)),
+	}
+	if len(note.Tags) > 0 {
+		header = append(header, truncateLine("Tags: #"+strings.Join(note.Tags, " #"), inner))
+	}
+	if note.PlanRef != "" {
+		plan := "Plan: " + note.PlanRef
+		if planStatus != "" {
+			plan += " (" + planStatus + ")"
+		}
+		header = append(header, truncateLine(plan, inner))
+	}
+
+	footer := fmt.Sprintf("%d words", note.WordCount)
+	if note.HasTodos {
+		footer += fmt.Sprintf(" · %d/%d todos done", note.TodoDone, note.TodoOpen+note.TodoDone)
+	}
+
+	// Vertical budget: border and padding (4), the help line (3), header,
+	// footer and the blank lines around the excerpt (3).
+	excerptLines := min(quickLookExcerptLines, height-4-3-len(header)-3)
+	var excerpt []string
+	if excerptLines > 0 {
+		for _, line := range note.Excerpt(excerptLines) {
+			excerpt = append(excerpt, truncateLine(line, inner))
+		}
+	}
+
+	content := strings.Join(header, "\n")
+	if len(excerpt) > 0 {
+		content += "\n\n" + strings.Join(excerpt, "\n")
+	}
+	content += "\n\n" + faint.Render(footer)
+
+	dialogBox := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(theme.DefaultTheme.Colors.Cyan).
+		Padding(1, 2).
+		Width(inner + 4).
+		Render(content)
+
+	helpText := faint.
+		Width(lipgloss.Width(dialogBox)).
+		Align(lipgloss.Center).
+		Render("\n\n" + truncateLine("Enter to open • e to quick edit • Esc to close", lipgloss.Width(dialogBox)))
+
+	return lipgloss.JoinVertical(lipgloss.Left, dialogBox, helpText)
+}
+
+// truncateLine shortens s to at most width cells, marking the cut with an
+// ellipsis.
+func truncateLine(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && lipgloss.Width(string(r))+1 > width {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "…"
+}
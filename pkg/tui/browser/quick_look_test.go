@@ -0,0 +1,67 @@
+package browser
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestRenderQuickLookFitsTerminal(t *testing.T) {
+	var body strings.Builder
+	for i := 0; i < 30; i++ {
+		body.WriteString(strings.Repeat("word ", 40) + "\n")
+	}
+	note := &models.Note{
+		Title:      "note.md",
+		Tags:       []string{"alpha", "beta"},
+		PlanRef:    "plans/launch",
+		Workspace:  "nb",
+		Type:       "inbox",
+		Content:    "---\ntitle: T\n---\n" + body.String(),
+		WordCount:  1200,
+		ModifiedAt: time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+	}
+
+	for _, size := range [][2]int{{120, 40}, {50, 20}, {30, 14}} {
+		out := renderQuickLook(note, "active", size[0], size[1])
+		if w := lipgloss.Width(out); w > size[0] {
+			t.Errorf("%dx%d: popup is %d wide", size[0], size[1], w)
+		}
+		if h := lipgloss.Height(out); h > size[1] {
+			t.Errorf("%dx%d: popup is %d high", size[0], size[1], h)
+		}
+	}
+
+	out := renderQuickLook(note, "active", 120, 40)
+	for _, want := range []string{"note.md", "#alpha #beta", "plans/launch (active)", "1200 words", "…"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("popup is missing %q:\n%s", want, out)
+		}
+	}
+	if n := countLinesContaining(out, "word word"); n != quickLookExcerptLines {
+		t.Errorf("want %d excerpt lines, got %d", quickLookExcerptLines, n)
+	}
+}
+
+func countLinesContaining(s, sub string) int {
+	n := 0
+	for _, line := range strings.Split(s, "\n") {
+		if strings.Contains(line, sub) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestTruncateLine(t *testing.T) {
+	if got := truncateLine("short", 10); got != "short" {
+		t.Errorf("truncateLine(short) = %q", got)
+	}
+	if got := truncateLine("a longer line", 6); got != "a lon…" {
+		t.Errorf("truncateLine = %q, want %q", got, "a lon…")
+	}
+}
@@ -0,0 +1,60 @@
+package browser
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"github.com/grovetools/nb/pkg/service"
+	"github.com/grovetools/nb/pkg/tree"
+	"github.com/grovetools/nb/pkg/tui/browser/views"
+)
+
+// TestSelectionSurvivesArchive archives one of three selected notes and
+// checks the other two stay selected through the rebuild and the refresh
+// that follows, while a note gone from the refreshed items is dropped.
+func TestSelectionSurvivesArchive(t *testing.T) {
+	note := func(path string) *tree.Item {
+		return &tree.Item{
+			Path:     path,
+			Name:     path[1:],
+			Type:     tree.TypeNote,
+			Metadata: map[string]interface{}{"Title": path[1:]},
+		}
+	}
+	a, b, c := note("/a.md"), note("/b.md"), note("/c.md")
+
+	m := Model{
+		service:     &service.Service{},
+		allItems:    []*tree.Item{a, b, c},
+		filterInput: textinput.New(),
+		views:       views.New(views.KeyMap{}, map[string]bool{}, views.DefaultSortConfig()),
+		// A flat list built straight from allItems needs no workspace tree.
+		recentNotesMode: true,
+	}
+	m.updateViewsState()
+	for _, item := range []*tree.Item{a, b, c} {
+		m.views.GetSelected()[item.Path] = struct{}{}
+	}
+
+	updated, _ := m.Update(notesArchivedMsg{archivedPaths: []string{a.Path}})
+	m = updated.(Model)
+
+	selected := m.views.GetSelected()
+	if _, ok := selected[a.Path]; ok {
+		t.Errorf("archived note %s is still selected", a.Path)
+	}
+	for _, path := range []string{b.Path, c.Path} {
+		if _, ok := selected[path]; !ok {
+			t.Errorf("%s lost its selection after archiving %s", path, a.Path)
+		}
+	}
+
+	// The refresh after the archive no longer finds c.
+	m.allItems = []*tree.Item{b}
+	m.updateViewsState()
+	selected = m.views.GetSelected()
+	if _, ok := selected[b.Path]; !ok || len(selected) != 1 {
+		t.Errorf("after refresh selected = %v, want only %s", selected, b.Path)
+	}
+}
@@ -0,0 +1,31 @@
+package browser
+
+import (
+	"testing"
+
+	"github.com/grovetools/nb/pkg/service"
+)
+
+func TestTimelineWindowKeepsCursorInView(t *testing.T) {
+	m := Model{height: 24, timelineEntries: make([]service.TimelineEntry, 40)}
+	rows := m.timelineRows()
+
+	for _, tt := range []struct {
+		cursor, start int
+	}{
+		{0, 0},
+		{39, 40 - rows},
+		{20, 20 - rows/2},
+	} {
+		m.timelineCursor = tt.cursor
+		start, end := m.timelineWindow()
+		if start != tt.start || end != start+rows {
+			t.Errorf("cursor %d: window [%d, %d), want [%d, %d)", tt.cursor, start, end, tt.start, tt.start+rows)
+		}
+	}
+
+	m.timelineEntries = m.timelineEntries[:3]
+	if start, end := m.timelineWindow(); start != 0 || end != 3 {
+		t.Errorf("short timeline: window [%d, %d), want all of it", start, end)
+	}
+}
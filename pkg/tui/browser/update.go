@@ -1,15 +1,21 @@
 package browser
 
 import (
+	"crypto/sha256"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/filepicker"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/grovetools/core/logging"
@@ -26,6 +32,7 @@ import (
 	"github.com/grovetools/nb/pkg/sync/github"
 	"github.com/grovetools/nb/pkg/tree"
 	"github.com/grovetools/nb/pkg/tui/browser/components/confirm"
+	"github.com/grovetools/nb/pkg/tui/browser/components/tagcloud"
 	"github.com/grovetools/nb/pkg/tui/browser/views"
 )
 
@@ -58,6 +65,19 @@ func parseSearchInput(raw string) (query string, tag string, isGrep, isTag bool)
 	}
 }
 
+// nextSearchScope cycles the scope used for unprefixed search input:
+// title → body → all → title.
+func nextSearchScope(scope string) string {
+	switch scope {
+	case "", service.SearchInTitle:
+		return service.SearchInBody
+	case service.SearchInBody:
+		return service.SearchInAll
+	default:
+		return service.SearchInTitle
+	}
+}
+
 // updateViewsState synchronizes the view state with the browser model. It parses
 // the search input's prefix (see parseSearchInput) to derive the grep/tag/plain
 // mode rather than relying on standalone mode booleans, then pushes the stripped
@@ -65,8 +85,18 @@ func parseSearchInput(raw string) (query string, tag string, isGrep, isTag bool)
 func (m *Model) updateViewsState() {
 	log := logging.NewLogger("tui.browser.update")
 	log.Debug("updateViewsState called")
+	defer m.reportRenderFailures()
 
 	query, tag, isGrep, isTag := parseSearchInput(m.filterInput.Value())
+	// An unprefixed query follows the ctrl+t search scope: title keeps the
+	// tree filter, body greps content, and all greps content plus titles.
+	plain := !isGrep && !isTag
+	if plain && m.searchScope != "" && m.searchScope != service.SearchInTitle {
+		isGrep = true
+	}
+	m.views.SetGrepIncludesTitles(plain && m.searchScope == service.SearchInAll)
+	m.views.SetShowTodaySection(m.showTodaySection)
+	m.views.SetUntaggedOnly(m.showUntaggedOnly)
 	// Keep the model's mode flags in sync with the parsed input so other call
 	// sites (status bar, view header, second-Esc clear) observe a single source
 	// of truth.
@@ -78,6 +108,9 @@ func (m *Model) updateViewsState() {
 		m.selectedTag = ""
 	}
 
+	// Selections are keyed by path, so they carry over to the rebuilt tree;
+	// only notes that are gone are dropped.
+	m.views.PruneSelections(m.allItems)
 	m.views.SetParentState(
 		m.service,
 		m.allItems,
@@ -109,23 +142,42 @@ func (m *Model) updateViewsState() {
 		return
 	}
 
-	// Apply git status filter if active
-	if m.showGitModifiedOnly {
-		m.views.FilterDisplayTreeByGitStatus()
-	}
+	// Apply the git status, untagged and substring filters. In tag mode
+	// `query` is the additional within-tag search (empty unless the user typed
+	// "#tag extra"); the tag itself is already applied during BuildDisplayTree.
+	m.views.ApplyFilters()
+}
 
-	// Apply substring filter if present. In tag mode `query` is the additional
-	// within-tag search (empty unless the user typed "#tag extra"); the tag
-	// itself is already applied during BuildDisplayTree.
-	if query != "" {
-		m.views.FilterDisplayTree()
+// reportRenderFailures warns in the status bar when the last tree build had
+// to leave sections out; see views.Model.RenderFailures.
+func (m *Model) reportRenderFailures() {
+	if m.views.RenderFailures() > 0 {
+		m.statusMessage = "Warning: some notes could not be rendered (see log)"
 	}
 }
 
 // loadFileContentCmd is a command that reads a file and returns its content.
 func loadFileContentCmd(path string) tea.Cmd {
 	return func() tea.Msg {
-		content, err := os.ReadFile(path)
+		f, err := os.Open(path)
+		if err != nil {
+			return fileContentReadyMsg{path: path, err: err}
+		}
+		defer f.Close()
+		info, err := f.Stat()
+		if err != nil {
+			return fileContentReadyMsg{path: path, err: err}
+		}
+		// Past max_parse_size only the head is read.
+		if service.ExceedsMaxParseSize(info.Size()) {
+			head := make([]byte, service.MaxParseSize())
+			n, err := io.ReadFull(f, head)
+			if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return fileContentReadyMsg{path: path, err: err}
+			}
+			return fileContentReadyMsg{path: path, content: string(head[:n]), fullSize: info.Size()}
+		}
+		content, err := io.ReadAll(f)
 		if err != nil {
 			return fileContentReadyMsg{path: path, err: err}
 		}
@@ -133,6 +185,51 @@ func loadFileContentCmd(path string) tea.Cmd {
 	}
 }
 
+// hostPreviewPath returns the file the terminal host should preview for
+// path. The host reads the file itself, so one over max_parse_size is
+// previewed through a copy of its head that ends with a notice; any other
+// file, or one whose copy can't be written, is previewed as is.
+func hostPreviewPath(path string) string {
+	if path == "" {
+		return path
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return path
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || !service.ExceedsMaxParseSize(info.Size()) {
+		return path
+	}
+	head := make([]byte, service.MaxParseSize())
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return path
+	}
+	content := string(head[:n]) + "\n\n---\n" + oversizedNotice(path, info.Size(), int64(n)) + "\n"
+
+	// One copy per note, named after a hash of its path, so previewing the
+	// note again overwrites it; the extension keeps the host's highlighting.
+	sum := sha256.Sum256([]byte(path))
+	dir := filepath.Join(os.TempDir(), "grove-nb-preview")
+	copyPath := filepath.Join(dir, fmt.Sprintf("%x-%s", sum[:8], filepath.Base(path)))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return path
+	}
+	if err := os.WriteFile(copyPath, []byte(content), 0o600); err != nil {
+		return path
+	}
+	return copyPath
+}
+
+// oversizedNotice tells that only the first shown bytes of the fullSize-byte
+// file at path are previewed.
+func oversizedNotice(path string, fullSize, shown int64) string {
+	return fmt.Sprintf("%s is %s, over max_parse_size: showing the first %s",
+		filepath.Base(path), service.FormatBytes(fullSize), service.FormatBytes(shown))
+}
+
 // createPlanCmd creates a command to launch the flow TUI for promoting a note to a plan.
 func (m *Model) createPlanCmd(note *models.Note) tea.Cmd {
 	if note == nil {
@@ -237,6 +334,174 @@ func (m *Model) bumpSelectedPriority(moreCritical bool) tea.Cmd {
 	return nil
 }
 
+// cycleSelectedFlag moves the note under the cursor to the next flag of the
+// palette, and to no flag after the last one. Like bumpSelectedPriority it
+// updates the in-memory items and rebuilds locally instead of refreshing.
+func (m *Model) cycleSelectedFlag() tea.Cmd {
+	node := m.views.GetCurrentNode()
+	if node == nil || !node.IsNote() {
+		return nil
+	}
+	note := views.ItemToNote(node.Item)
+	next := service.NextFlag(m.service.Flags(), note.Flag)
+	if next == "" && note.Flag == "" {
+		return nil
+	}
+	// SetFlag toggles, so clearing passes the current flag.
+	want := next
+	if want == "" {
+		want = note.Flag
+	}
+	newFlag, err := m.service.SetFlag(note.Path, want)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Failed to set flag: %s", err)
+		return nil
+	}
+
+	path := note.Path
+	for _, item := range m.allItems {
+		if item.Path == path {
+			if item.Metadata == nil {
+				item.Metadata = make(map[string]interface{})
+			}
+			item.Metadata["Flag"] = newFlag
+		}
+	}
+	if node.Item != nil {
+		if node.Item.Metadata == nil {
+			node.Item.Metadata = make(map[string]interface{})
+		}
+		node.Item.Metadata["Flag"] = newFlag
+	}
+
+	m.updateViewsState()
+	m.views.SetCursorToPath(path)
+
+	if newFlag == "" {
+		m.statusMessage = "Cleared flag"
+	} else {
+		m.statusMessage = "Flag set to " + newFlag
+	}
+	return nil
+}
+
+// touchTargetedNotes bumps the modified time of the selected notes (or the
+// note under the cursor) to now. Like bumpSelectedPriority it updates the
+// in-memory items and rebuilds locally, so the touched notes re-sort to the top
+// immediately instead of waiting for the daemon to re-index them.
+func (m *Model) touchTargetedNotes() tea.Cmd {
+	var paths []string
+	for _, p := range m.views.GetTargetedNotePaths() {
+		if strings.HasSuffix(p, ".md") {
+			paths = append(paths, p)
+		}
+	}
+	if len(paths) == 0 {
+		m.statusMessage = "No notes to touch"
+		return nil
+	}
+
+	touched := make(map[string]time.Time, len(paths))
+	var touchErr error
+	for _, path := range paths {
+		if err := m.service.Touch(path); err != nil {
+			touchErr = fmt.Errorf("%s: %w", filepath.Base(path), err)
+			break
+		}
+		if info, err := os.Stat(path); err == nil {
+			touched[path] = info.ModTime()
+		}
+	}
+	if len(touched) == 0 {
+		if touchErr != nil {
+			m.statusMessage = fmt.Sprintf("Failed to touch %s", touchErr)
+		}
+		return nil
+	}
+
+	for _, item := range m.allItems {
+		if modTime, ok := touched[item.Path]; ok {
+			item.ModTime = modTime
+		}
+	}
+	cursorPath := paths[0]
+	if node := m.views.GetCurrentNode(); node != nil && node.Item != nil {
+		if _, ok := touched[node.Item.Path]; ok {
+			cursorPath = node.Item.Path
+		}
+	}
+	m.updateViewsState()
+	m.views.SetCursorToPath(cursorPath)
+
+	switch {
+	case touchErr != nil:
+		m.statusMessage = fmt.Sprintf("Touched %d notes, failed on %s", len(touched), touchErr)
+	case len(touched) == 1:
+		m.statusMessage = "Touched " + filepath.Base(cursorPath)
+	default:
+		m.statusMessage = fmt.Sprintf("Touched %d notes", len(touched))
+	}
+	return nil
+}
+
+// planStatusUpdatedMsg is sent after a plan's .grove-plan.yml status was written.
+type planStatusUpdatedMsg struct {
+	plan   string
+	status string
+	err    error
+}
+
+// openPlanStatusPicker opens the status picker for the plan under the cursor,
+// preselecting its current status.
+func (m *Model) openPlanStatusPicker() {
+	node := m.views.GetCurrentNode()
+	if node == nil || !node.IsPlan() {
+		m.statusMessage = "Move the cursor onto a plan to set its status"
+		return
+	}
+	wsName, _ := node.Item.Metadata["Workspace"].(string)
+	m.planStatusWs = wsName
+	m.planStatusPlan = node.Item.Name
+	current := m.views.GetPlanStatus(wsName, node.Item.Name)
+	m.planStatusPicker.Select(0)
+	for i, status := range service.PlanStatuses {
+		if status == current {
+			m.planStatusPicker.Select(i)
+			break
+		}
+	}
+	m.planStatusMode = true
+}
+
+// setPlanStatusCmd writes the status chosen in the picker to the plan's config.
+func (m *Model) setPlanStatusCmd() tea.Cmd {
+	selected, ok := m.planStatusPicker.SelectedItem().(planStatusItem)
+	if !ok {
+		return nil
+	}
+	svc := m.service
+	status := string(selected)
+	plan := m.planStatusPlan
+	// Resolve the workspace name to a path up front; "global" is addressed
+	// by name.
+	target := "global"
+	if m.planStatusWs != "global" {
+		ws, found := m.findWorkspaceNodeByName(m.planStatusWs)
+		if !found {
+			err := fmt.Errorf("workspace not found: %s", m.planStatusWs)
+			return func() tea.Msg { return planStatusUpdatedMsg{plan: plan, status: status, err: err} }
+		}
+		target = ws.Path
+	}
+	return func() tea.Msg {
+		ctx, err := svc.GetWorkspaceContext(target)
+		if err != nil {
+			return planStatusUpdatedMsg{plan: plan, status: status, err: err}
+		}
+		return planStatusUpdatedMsg{plan: plan, status: status, err: svc.UpdatePlanStatus(ctx, plan, status)}
+	}
+}
+
 // notePromotedToJobMsg is sent after a note has been promoted to a job in a plan.
 type notePromotedToJobMsg struct {
 	planName string
@@ -288,7 +553,7 @@ func (m *Model) syncWorkspaceCmd() tea.Cmd {
 		})
 
 		// Run sync
-		reports, err := syncer.SyncWorkspace(ctx)
+		reports, err := syncer.SyncWorkspace(ctx, sync.SyncOptions{})
 
 		return syncFinishedMsg{reports: reports, err: err}
 	}
@@ -296,6 +561,14 @@ func (m *Model) syncWorkspaceCmd() tea.Cmd {
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 	var cmd tea.Cmd
+	// The attachment picker reads directories asynchronously, so it needs to
+	// see non-key messages too. Keys are routed to it further down.
+	if _, isKey := msg.(tea.KeyMsg); m.attachPickerMode && !isKey {
+		m.attachPicker, cmd = m.attachPicker.Update(msg)
+		if cmd != nil {
+			return m, cmd
+		}
+	}
 	switch msg := msg.(type) {
 	case spinner.TickMsg:
 		var cmd tea.Cmd
@@ -333,10 +606,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 		m.statusMessage = ""
 		return m, nil
 	case fileContentReadyMsg:
+		// The peeked file vanished between the check and the read.
+		if msg.err != nil && msg.path == m.peekPath {
+			cmd := m.endPeek()
+			m.statusMessage = fmt.Sprintf("Cannot peek: %s was deleted", filepath.Base(msg.path))
+			return m, cmd
+		}
 		// Track the file path for dedup in updatePreviewContent.
 		// The actual preview rendering is handled by the terminal
 		// host's VDrawer (nvim -R), not the internal viewport.
 		m.previewFile = msg.path
+		if msg.err == nil {
+			m.previewContent = msg.content
+			// Re-run an active preview search against the new content.
+			m.setPreviewSearchQuery(m.previewSearchQuery)
+		}
+		if m.statusMessage == fmt.Sprintf("Loading %s...", filepath.Base(msg.path)) {
+			m.statusMessage = ""
+		}
+		m.previewStats = ""
+		if msg.err == nil && msg.fullSize > 0 {
+			m.statusMessage = oversizedNotice(msg.path, msg.fullSize, int64(len(msg.content)))
+		} else if msg.err == nil && m.peekPath == "" && strings.EqualFold(filepath.Ext(msg.path), ".md") {
+			// The host renders the preview itself, so the word count and
+			// readability go in the status bar, beside the note count.
+			score := service.ComputeReadability(msg.content)
+			m.previewStats = fmt.Sprintf("%d words · %s", score.Words, score)
+		}
 		return m, nil
 	case embed.EditFinishedMsg:
 		// External editor closed — refresh the tree to pick up any
@@ -345,18 +641,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 	case embed.SplitEditorClosedMsg:
 		// BSP split editor closed — refresh to pick up edits.
 		return m, func() tea.Msg { return refreshMsg{} }
-	case tea.WindowSizeMsg:
-		m.width, m.height = msg.Width, msg.Height
-		m.help.SetSize(msg.Width, msg.Height)
-
-		// Calculate pane sizes — preview is handled by the terminal host
-		// VDrawer, so nb always uses full width.
-		// header(1) + search(1) + blank(1) + view + blank(1) + status(1) + footer(1) + top_margin(1)
-		const mainContentHeight = 7
-		availableHeight := m.height - mainContentHeight
-		m.views.SetSize(m.width-4, availableHeight)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 
-		m.columnList.SetSize(40, 8)
+	case tea.WindowSizeMsg:
+		m.resize(msg.Width, msg.Height)
 		return m, nil
 
 	case workspacesLoadedMsg:
@@ -364,6 +653,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 			m.loadingCount--
 		}
 		m.workspaces = msg.workspaces
+		m.views.SetArchivedWorkspaces(msg.archived)
 
 		// If we have a focused workspace that's not in the provider's list,
 		// add it so the tree builder can display its notes
@@ -390,11 +680,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 		m.allItems = msg.items
 		m.jobs = msg.jobs
 		// Set collapse state on focus change OR on initial load
+		restoreCursor := m.focusChanged
 		if m.focusChanged || len(m.views.GetCollapseState()) == 0 {
 			m.setCollapseStateForFocus()
 			m.focusChanged = false
 		}
 		m.updateViewsState()
+		if restoreCursor {
+			m.restoreFocusCursor()
+		}
+		if msg.notebookLocked {
+			m.statusMessage = "Another nb process (e.g. a sync) is modifying the notebook; changes will wait for it"
+		}
+		m.reportRenderFailures()
 
 		// Trigger git status fetching for items in git repos
 		var gitCmds []tea.Cmd
@@ -406,10 +704,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				break
 			}
 		}
-		if len(gitCmds) > 0 {
-			return m, tea.Batch(append(gitCmds, m.updatePreviewContent())...)
+		cmds := append(gitCmds, m.updatePreviewContent(), m.workspaceSummaryCmd())
+		if msg.indexed {
+			cmds = append(cmds, loadNoteFlagsCmd(msg.items))
 		}
-		return m, m.updatePreviewContent()
+		return m, tea.Batch(cmds...)
+
+	case noteFlagsLoadedMsg:
+		// A flag set since the items loaded is already in the metadata.
+		changed := false
+		for _, item := range m.allItems {
+			if flag, ok := msg.flags[item.Path]; ok {
+				if _, set := item.Metadata["Flag"]; !set {
+					item.Metadata["Flag"] = flag
+					changed = true
+				}
+			}
+		}
+		if changed {
+			m.updateViewsState()
+		}
+		return m, nil
+
+	case workspaceSummaryMsg:
+		if msg.ws == m.focusedWorkspace {
+			m.workspaceSummary = msg.summary
+			m.summaryWorkspace = msg.ws
+		}
+		return m, nil
 
 	case gitStatusLoadedMsg:
 		if msg.err == nil && msg.repoPath != "" && msg.fileStatus != nil {
@@ -510,6 +832,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 		var allErrors []string
 		for _, report := range msg.reports {
 			parts := []string{}
+			if report.Error != "" {
+				parts = append(parts, "FAILED")
+				allErrors = append(allErrors, report.Error)
+			}
 			if report.Created > 0 {
 				parts = append(parts, fmt.Sprintf("%d created", report.Created))
 			}
@@ -549,7 +875,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 		}
 
 		return m, tea.Batch(
-			fetchWorkspacesCmd(m.service.GetWorkspaceProvider()),
+			fetchWorkspacesCmd(m.service),
 			notesCmd,
 			m.spinner.Tick,
 		)
@@ -572,8 +898,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 			}
 		}
 		m.allItems = newAllItems
-		// Clear selections
-		m.views.ClearSelections()
+		// Deselect the deleted notes; the rest of the selection stays
+		m.views.DeselectPaths(msg.deletedPaths)
 		// Rebuild display
 		m.updateViewsState()
 		m.clearGitStatus()
@@ -624,8 +950,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 		// Clear any staged auto-archive paths now that they've been processed.
 		m.autoArchivePaths = nil
 
-		// Clear selections
-		m.views.ClearSelections()
+		// Deselect what was archived; the rest of the selection stays
+		m.views.DeselectPaths(msg.archivedPaths)
+		if msg.archivedPlans > 0 {
+			m.views.ClearGroupSelections()
+		}
 
 		// Rebuild the display
 		m.updateViewsState()
@@ -679,6 +1008,169 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 		}
 		return m, tea.Batch(fetchAllItemsCmd(m.service, m.showArtifacts), m.spinner.Tick)
 
+	case tagcloud.SelectedMsg:
+		m.applyTagFilter(msg.Tag)
+		return m, nil
+
+	case tagsEditedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Error editing tags: %v", msg.err)
+			return m, nil
+		}
+		if msg.removed {
+			m.statusMessage = fmt.Sprintf("Removed #%s from %d of %d notes", msg.tag, msg.changed, msg.total)
+		} else {
+			m.statusMessage = fmt.Sprintf("Added #%s to %d of %d notes", msg.tag, msg.changed, msg.total)
+		}
+		if msg.changed == 0 {
+			return m, nil
+		}
+		m.views.ClearSelections()
+		m.clearGitStatus()
+		m.loadingCount++
+		if m.focusedWorkspace != nil {
+			return m, tea.Batch(fetchFocusedItemsCmd(m.service, m.focusedWorkspace, m.showArtifacts), m.spinner.Tick)
+		}
+		return m, tea.Batch(fetchAllItemsCmd(m.service, m.showArtifacts), m.spinner.Tick)
+
+	case notesExportedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Export failed after %d of %d notes: %v", msg.written, msg.total, msg.err)
+			return m, nil
+		}
+		m.statusMessage = fmt.Sprintf("Exported %d notes to %s", msg.written, msg.dest)
+		return m, nil
+
+	case attachmentsAddedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Error adding attachment: %v", msg.err)
+			return m, nil
+		}
+		m.statusMessage = fmt.Sprintf("Attached %s", filepath.Base(msg.paths[0]))
+		m.clearGitStatus()
+		// Refresh so the attachment directory shows up
+		m.loadingCount++
+		if m.focusedWorkspace != nil {
+			return m, tea.Batch(fetchFocusedItemsCmd(m.service, m.focusedWorkspace, m.showArtifacts), m.spinner.Tick)
+		}
+		return m, tea.Batch(fetchAllItemsCmd(m.service, m.showArtifacts), m.spinner.Tick)
+
+	case externalOpenFinishedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Failed to open %s: %v", filepath.Base(msg.path), msg.err)
+		} else {
+			m.statusMessage = fmt.Sprintf("Opened %s with %s", filepath.Base(msg.path), msg.tool)
+		}
+		return m, nil
+
+	case frontmatterLoadedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Error reading frontmatter: %v", msg.err)
+			return m, nil
+		}
+		m.openFrontmatterEditor(msg.path, msg.raw)
+		return m, textarea.Blink
+
+	case planTimelineLoadedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Error loading timeline: %v", msg.err)
+			return m, nil
+		}
+		if len(msg.entries) == 0 {
+			m.statusMessage = "No activity for plan " + msg.plan
+			return m, nil
+		}
+		m.timelineMode = true
+		m.timelinePlan = msg.plan
+		m.timelineEntries = msg.entries
+		// Start on the most recent event.
+		m.timelineCursor = len(msg.entries) - 1
+		return m, nil
+
+	case planNotesLoadedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Error loading plan notes: %v", msg.err)
+			return m, nil
+		}
+		if len(msg.notes) == 0 {
+			m.statusMessage = "No notes in plan " + msg.plan
+			return m, nil
+		}
+		m.statusMessage = ""
+		m.planNotesMode = true
+		m.planNotesPlan = msg.plan
+		m.planNotes = msg.notes
+		m.planNotesCursor = 0
+		return m, nil
+
+	case quickLookLoadedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Error reading note: %v", msg.err)
+			return m, nil
+		}
+		m.quickLookMode = true
+		m.quickLookNote = msg.note
+		m.quickLookPlanStatus = ""
+		if msg.note.PlanRef != "" {
+			m.quickLookPlanStatus = m.views.GetPlanStatus(msg.note.Workspace, msg.note.PlanRef)
+		}
+		return m, nil
+
+	case noteDiffLoadedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Error diffing notes: %v", msg.err)
+			return m, nil
+		}
+		if msg.diff == "" {
+			m.statusMessage = "No differences: " + msg.title
+			return m, nil
+		}
+		m.openNoteDiff(msg.title, msg.diff)
+		return m, nil
+
+	case relatedNotesLoadedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Error finding related notes: %v", msg.err)
+			return m, nil
+		}
+		if len(msg.related) == 0 {
+			m.statusMessage = "No related notes for " + msg.source
+			return m, nil
+		}
+		m.relatedMode = true
+		m.relatedSource = msg.source
+		m.relatedNotes = msg.related
+		m.relatedCursor = 0
+		return m, nil
+
+	case frontmatterSavedMsg:
+		if msg.err != nil {
+			// Keep the editor open so the user can fix the YAML in place.
+			m.frontmatterError = msg.err.Error()
+			return m, nil
+		}
+		m.closeFrontmatterEditor()
+		m.statusMessage = "Frontmatter saved"
+		m.clearGitStatus()
+		m.loadingCount++
+		if m.focusedWorkspace != nil {
+			return m, tea.Batch(fetchFocusedItemsCmd(m.service, m.focusedWorkspace, m.showArtifacts), m.spinner.Tick)
+		}
+		return m, tea.Batch(fetchAllItemsCmd(m.service, m.showArtifacts), m.spinner.Tick)
+
+	case planStatusUpdatedMsg:
+		if msg.err != nil {
+			m.statusMessage = fmt.Sprintf("Error setting plan status: %v", msg.err)
+			return m, nil
+		}
+		m.statusMessage = fmt.Sprintf("%s is now %s", strings.TrimPrefix(msg.plan, "plans/"), msg.status)
+		// Refresh so plans moved on/off hold follow the on-hold filter.
+		m.loadingCount++
+		if m.focusedWorkspace != nil {
+			return m, tea.Batch(fetchFocusedItemsCmd(m.service, m.focusedWorkspace, m.showArtifacts), m.spinner.Tick)
+		}
+		return m, tea.Batch(fetchAllItemsCmd(m.service, m.showArtifacts), m.spinner.Tick)
+
 	case notePromotedToJobMsg:
 		m.noteToPromote = nil
 		if msg.err != nil {
@@ -729,6 +1221,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				}
 				return m, nil
 			}
+			if key.Matches(msg, m.keys.CycleSearchScope) {
+				m.searchScope = nextSearchScope(m.searchScope)
+				m.updateViewsState()
+				return m, nil
+			}
 			// Pass all other keys to the input, then re-sync. updateViewsState
 			// parses the input prefix and routes to grep/tag/plain itself, so we
 			// no longer special-case grep here.
@@ -750,11 +1247,72 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 			return m.updateNoteRename(msg)
 		}
 
+		// Handle bulk tag edit prompt
+		if m.isEditingTags {
+			return m.updateTagEdit(msg)
+		}
+
+		// Handle export destination prompt
+		if m.isExporting {
+			return m.updateExport(msg)
+		}
+
 		// Handle commit dialog mode
 		if m.isCommitting {
 			return m.updateCommitDialog(msg)
 		}
 
+		// Handle inline frontmatter editor mode
+		if m.textareaMode {
+			return m.updateFrontmatterEditor(msg)
+		}
+
+		// Handle related-notes overlay
+		if m.relatedMode {
+			return m.updateRelatedOverlay(msg)
+		}
+
+		// Handle plan timeline overlay
+		if m.timelineMode {
+			return m.updateTimelineOverlay(msg)
+		}
+
+		// Handle plan notes overlay
+		if m.planNotesMode {
+			return m.updatePlanNotesOverlay(msg)
+		}
+
+		// Handle quick-look popup
+		if m.quickLookMode {
+			return m.updateQuickLook(msg)
+		}
+
+		// Handle the note diff overlay
+		if m.diffMode {
+			return m.updateNoteDiff(msg)
+		}
+
+		// Handle the preview search input
+		if m.previewSearching {
+			return m.updatePreviewSearch(msg)
+		}
+
+		// ctrl+f, n and N search the preview while it is focused
+		if cmd, ok := m.updatePreviewSearchKeys(msg); ok {
+			return m, cmd
+		}
+
+		// Handle attachment file picker
+		if m.attachPickerMode {
+			return m.updateAttachPicker(msg)
+		}
+
+		// Handle tag cloud overlay
+		if m.tagCloud.Active {
+			m.tagCloud, cmd = m.tagCloud.Update(msg)
+			return m, cmd
+		}
+
 		// Handle tag picker mode
 		if m.tagPickerMode {
 			switch msg.String() {
@@ -769,11 +1327,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				// key lets them append a within-tag query.
 				if selectedItem, ok := m.tagPicker.SelectedItem().(tagItem); ok {
 					m.tagPickerMode = false
-					m.filterInput.SetValue("#" + selectedItem.tag + " ")
-					m.filterInput.CursorEnd()
-					// Expand everything when tag filter is active
-					m.views.SetCollapseState(make(map[string]bool))
-					m.updateViewsState()
+					m.applyTagFilter(selectedItem.tag)
 				}
 				return m, nil
 			default:
@@ -799,6 +1353,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 			}
 		}
 
+		// Handle plan status picker
+		if m.planStatusMode {
+			switch msg.String() {
+			case "esc":
+				m.planStatusMode = false
+				return m, nil
+			case "enter":
+				m.planStatusMode = false
+				return m, m.setPlanStatusCmd()
+			default:
+				m.planStatusPicker, cmd = m.planStatusPicker.Update(msg)
+				return m, cmd
+			}
+		}
+
 		// Handle column selection mode
 		if m.columnSelectMode {
 			switch msg.String() {
@@ -817,6 +1386,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 					_ = m.saveState()
 				}
 				return m, nil
+			case "K", "shift+up":
+				m.moveSelectedColumn(-1)
+				_ = m.saveState()
+				return m, nil
+			case "J", "shift+down":
+				m.moveSelectedColumn(1)
+				_ = m.saveState()
+				return m, nil
 			default:
 				m.columnList, cmd = m.columnList.Update(msg)
 				return m, cmd
@@ -825,16 +1402,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 
 		// Chord seam via the reusable which-key host. `extra` carries the flat
 		// sequence chords — the gg motion, dd (delete), the z* folds — plus yy
-		// (Copy). The disabled Base.Yank is deliberately OMITTED: Matches ignores
-		// Enabled(), so leaving it in would race Copy for "yy". The host also arms
-		// the t…/g… namespaces from Namespaces(). Top-level-only arming (E3) comes
+		// (Copy) and yc/yC (yank content). The disabled Base.Yank is
+		// deliberately OMITTED: Matches ignores Enabled(), so leaving it in would
+		// race Copy for "yy". The host also arms the t…/g… namespaces from
+		// Namespaces(). Top-level-only arming (E3) comes
 		// free — every modal early-return above runs first, so a chord can never
 		// arm in search/create/rename/commit/tagPicker/promote/columnSelect/help.
 		extra := []key.Binding{
 			m.keys.Top, m.keys.Delete,
 			m.keys.FoldOpen, m.keys.FoldClose, m.keys.FoldToggle,
 			m.keys.FoldOpenAll, m.keys.FoldCloseAll,
-			m.keys.Copy,
+			m.keys.Copy, m.keys.YankContent, m.keys.YankContentRaw,
 		}
 		res, matched, chordCmd := m.whichKey.ProcessChord(msg, extra...)
 		switch res {
@@ -864,6 +1442,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				if err := m.saveState(); err != nil {
 					m.statusMessage = "Failed to save fold state: " + err.Error()
 				}
+				m.reportRenderFailures()
 				return m, tea.Batch(cmd, m.updatePreviewContent())
 			}
 			// Otherwise fall through to the flat/namespace switch below.
@@ -887,6 +1466,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				if err := m.saveState(); err != nil {
 					m.statusMessage = "Failed to save fold state: " + err.Error()
 				}
+				m.reportRenderFailures()
 			}
 			// After any view update that could change the cursor, update the preview.
 			return m, tea.Batch(cmd, m.updatePreviewContent())
@@ -906,9 +1486,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 		case key.Matches(msg, m.keys.ClearFocus):
 			if m.focusedWorkspace != nil || m.ecosystemPickerMode {
 				m.loadingCount++
-				m.focusedWorkspace = nil
+				m.setFocus(nil)
 				m.ecosystemPickerMode = false
-				m.focusChanged = true
 				// Re-fetch all notes for the global view
 				return m, tea.Batch(fetchAllItemsCmd(m.service, m.showArtifacts), m.spinner.Tick)
 			}
@@ -917,15 +1496,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 			if node != nil && node.IsWorkspace() {
 				m.loadingCount++
 				if ws, ok := node.Item.Metadata["Workspace"].(*workspace.WorkspaceNode); ok {
-					m.focusedWorkspace = ws
+					m.setFocus(ws)
+				} else {
+					m.focusChanged = true
 				}
 				m.ecosystemPickerMode = false // Focusing on a workspace exits picker mode
-				m.focusChanged = true
 				// Re-fetch notes for the newly focused workspace
 				return m, tea.Batch(fetchFocusedItemsCmd(m.service, m.focusedWorkspace, m.showArtifacts), m.spinner.Tick)
 			}
 		case key.Matches(msg, m.keys.SwitchView):
 			m.views.ToggleViewMode()
+			// Compact view flattens the node list, so rebuild for every mode.
+			m.updateViewsState()
+			m.statusMessage = "View: " + m.views.GetViewMode().String()
+			if err := m.saveState(); err != nil {
+				m.statusMessage = "Failed to save view mode: " + err.Error()
+			}
 		case key.Matches(msg, m.keys.FocusRecent):
 			m.recentNotesMode = !m.recentNotesMode
 			if m.recentNotesMode {
@@ -978,12 +1564,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				m.statusMessage = "Default view restored"
 			}
 			m.updateViewsState()
+		case key.Matches(msg, m.keys.PeekLinked):
+			return m, m.peekLinked()
+		case key.Matches(msg, m.keys.JumpToLinked):
+			return m, m.jumpToLinked()
+		case key.Matches(msg, m.keys.PlanTimeline):
+			return m, m.loadPlanTimelineCmd()
+		case key.Matches(msg, m.keys.QuickLook):
+			return m, m.loadQuickLookCmd()
+		case key.Matches(msg, m.keys.DiffSelected):
+			return m, m.loadNoteDiffCmd()
+		case key.Matches(msg, m.keys.ShowRelated):
+			node := m.views.GetCurrentNode()
+			if node == nil || !node.IsNote() {
+				return m, nil
+			}
+			if note := views.ItemToNote(node.Item); note != nil {
+				m.statusMessage = "Finding related notes..."
+				return m, loadRelatedNotesCmd(m.service, note)
+			}
 		case key.Matches(msg, m.keys.JumpToArtifacts):
 			if m.views.JumpToArtifactsForNote() {
 				m.statusMessage = "Jumped to job artifacts"
 			} else {
 				m.statusMessage = "No artifacts for this note"
 			}
+			m.reportRenderFailures()
 		case key.Matches(msg, m.keys.Search):
 			// The search key both starts a new search AND re-enters an existing
 			// one (vim-style). When the filter input is blurred-but-active (has a
@@ -1037,10 +1643,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				m.statusMessage = "No notes older than 30 days to archive"
 				return m, nil
 			}
-			m.confirmDialog.Activate(
-				fmt.Sprintf("Auto-archive %d notes older than 30 days?", len(m.autoArchivePaths)),
-			)
-			return m, nil
+			prompt := fmt.Sprintf("Auto-archive %d notes older than 30 days?", len(m.autoArchivePaths))
+			return m, m.activateConfirm(prompt, len(m.autoArchivePaths))
 		case key.Matches(msg, m.keys.FilterByTag):
 			// Always show the tag picker - allows switching between tags. On
 			// selection it inserts a "#tag " prefix into the single search input.
@@ -1061,12 +1665,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 			m.columnList.SetItems(m.getColumnListItems())
 			m.columnList.SetSize(40, 8)
 			return m, nil
-		case key.Matches(msg, m.keys.Sort):
-			m.views.ToggleSortOrder()
+		case key.Matches(msg, m.keys.Sort), key.Matches(msg, m.keys.ReverseSort):
+			if key.Matches(msg, m.keys.Sort) {
+				m.views.CycleSortField()
+			} else {
+				m.views.ToggleSortOrder()
+			}
+			m.statusMessage = "Sort: " + m.views.GetSortConfig().String()
+			if err := m.saveState(); err != nil {
+				m.statusMessage = "Failed to save sort order: " + err.Error()
+			}
+			m.reportRenderFailures()
 		case key.Matches(msg, m.keys.PriorityUp):
 			return m, m.bumpSelectedPriority(true)
 		case key.Matches(msg, m.keys.PriorityDown):
 			return m, m.bumpSelectedPriority(false)
+		case key.Matches(msg, m.keys.CycleFlag):
+			return m, m.cycleSelectedFlag()
 		case key.Matches(msg, m.keys.CycleGrouping):
 			m.groupBy = nextGroupBy(m.groupBy)
 			m.views.SetGroupBy(m.groupBy)
@@ -1083,6 +1698,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 			m.showArchives = !m.showArchives
 			m.statusMessage = fmt.Sprintf("Archives: %v (Found %d notes)", m.showArchives, len(m.allItems))
 			m.updateViewsState()
+			return m, m.workspaceSummaryCmd()
 		case key.Matches(msg, m.keys.ToggleArtifacts):
 			m.showArtifacts = !m.showArtifacts
 			m.statusMessage = fmt.Sprintf("Artifacts: %v", m.showArtifacts)
@@ -1101,13 +1717,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 		case key.Matches(msg, m.keys.ToggleGlobal):
 			m.hideGlobal = !m.hideGlobal
 			m.updateViewsState()
+		case key.Matches(msg, m.keys.ToggleToday):
+			m.showTodaySection = !m.showTodaySection
+			m.statusMessage = fmt.Sprintf("Today section: %v", m.showTodaySection)
+			m.updateViewsState()
+		case key.Matches(msg, m.keys.ToggleUntagged):
+			m.showUntaggedOnly = !m.showUntaggedOnly
+			if m.showUntaggedOnly {
+				m.statusMessage = "Filtering for notes without tags"
+			} else {
+				m.statusMessage = "Cleared notes without tags filter"
+			}
+			m.updateViewsState()
 		case key.Matches(msg, m.keys.Delete):
 			// dd — the chord seam re-synthesizes the completed "dd" here (the first
 			// "d" press was consumed as ChordPending above).
 			pathsToDelete := m.views.GetTargetedNotePaths()
 			if len(pathsToDelete) > 0 {
 				prompt := fmt.Sprintf("Permanently delete %d note(s)? This cannot be undone.", len(pathsToDelete))
-				m.confirmDialog.Activate(prompt)
+				return m, m.activateConfirm(prompt, len(pathsToDelete))
 			}
 		case key.Matches(msg, m.keys.Cut):
 			paths := m.views.GetTargetedNotePaths()
@@ -1119,7 +1747,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 					cutPaths[p] = struct{}{}
 				}
 				m.views.SetCutPaths(cutPaths)
-				m.statusMessage = fmt.Sprintf("Cut %d note(s) to clipboard", len(paths))
+				m.statusMessage = fmt.Sprintf("Cut %d note(s); p to move them here", len(paths))
 			}
 		case key.Matches(msg, m.keys.Copy):
 			paths := m.views.GetTargetedNotePaths()
@@ -1127,8 +1755,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				m.clipboard = paths
 				m.clipboardMode = "copy"
 				m.views.SetCutPaths(make(map[string]struct{})) // Clear cut visual
-				m.statusMessage = fmt.Sprintf("Copied %d note(s) to clipboard", len(paths))
+				m.statusMessage = fmt.Sprintf("Copied %d note(s); p to paste copies (yc yanks text)", len(paths))
 			}
+		case key.Matches(msg, m.keys.YankContent):
+			m.yankNoteContent(false)
+			return m, nil
+		case key.Matches(msg, m.keys.YankContentRaw):
+			m.yankNoteContent(true)
+			return m, nil
 		case key.Matches(msg, m.keys.Yank), key.Matches(msg, m.keys.CopyPath):
 			node := m.views.GetCurrentNode()
 			if node != nil && node.Item != nil {
@@ -1193,6 +1827,51 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				m.renameInput.Focus()
 				return m, textinput.Blink
 			}
+		case key.Matches(msg, m.keys.TagCloud):
+			m.openTagCloud()
+			return m, nil
+		case key.Matches(msg, m.keys.VisualLine):
+			if m.views.InVisualMode() {
+				m.views.ExitVisualMode()
+			} else {
+				m.views.StartVisualMode()
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.EditTags):
+			// Tag the selected notes, or the note/group under the cursor
+			var paths []string
+			for _, p := range m.views.GetTargetedNotePaths() {
+				if strings.HasSuffix(p, ".md") {
+					paths = append(paths, p)
+				}
+			}
+			if len(paths) == 0 {
+				m.statusMessage = "No notes to tag"
+				return m, nil
+			}
+			m.isEditingTags = true
+			m.tagEditPaths = paths
+			m.tagEditInput.SetValue("")
+			m.tagEditInput.Focus()
+			return m, textinput.Blink
+		case key.Matches(msg, m.keys.Touch):
+			return m, m.touchTargetedNotes()
+		case key.Matches(msg, m.keys.ExportSelected):
+			return m, m.startExport()
+		case key.Matches(msg, m.keys.AddAttachment):
+			// Attach a file: only works when cursor is on a note
+			node := m.views.GetCurrentNode()
+			if node != nil && node.IsNote() {
+				return m, m.openAttachPicker(node.Item.Path)
+			}
+			return m, nil
+		case key.Matches(msg, m.keys.EditFrontmatter):
+			// Edit raw frontmatter: only works when cursor is on a note
+			node := m.views.GetCurrentNode()
+			if node != nil && node.IsNote() {
+				return m, loadFrontmatterCmd(m.service, node.Item.Path)
+			}
+			return m, nil
 		case key.Matches(msg, m.keys.CreatePlan):
 			node := m.views.GetCurrentNode()
 			if node != nil && node.IsNote() {
@@ -1206,6 +1885,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				return m, m.createPlanCmd(note)
 			}
 			return m, nil
+		case key.Matches(msg, m.keys.PlanStatus):
+			m.openPlanStatusPicker()
+			return m, nil
 		case key.Matches(msg, m.keys.PromoteToJob):
 			node := m.views.GetCurrentNode()
 			if node != nil && node.IsNote() {
@@ -1233,7 +1915,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				} else {
 					prompt = fmt.Sprintf("Archive %d notes?", selectedNotes)
 				}
-				m.confirmDialog.Activate(prompt)
+				return m, m.activateConfirm(prompt, selectedNotes+selectedPlans)
 			}
 		case key.Matches(msg, m.keys.Confirm):
 			if m.ecosystemPickerMode {
@@ -1241,33 +1923,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				if node != nil && node.IsWorkspace() {
 					if ws, ok := node.Item.Metadata["Workspace"].(*workspace.WorkspaceNode); ok && ws.IsEcosystem() {
 						m.loadingCount++
-						m.focusedWorkspace = ws
+						m.setFocus(ws)
 						m.ecosystemPickerMode = false
-						m.focusChanged = true
 						// Re-fetch notes for the selected ecosystem
 						return m, tea.Batch(fetchFocusedItemsCmd(m.service, m.focusedWorkspace, m.showArtifacts), m.spinner.Tick)
 					}
 				}
 			} else {
-				var noteToOpen *models.Note
-				node := m.views.GetCurrentNode()
-				if node != nil {
-					if node.IsNote() {
-						noteToOpen = views.ItemToNote(node.Item)
-					} else if node.IsFoldable() {
-						// Toggle fold on workspaces and groups
-						m.views.ToggleFold()
-						return m, nil
-					}
-				}
-				if noteToOpen != nil {
-					path := noteToOpen.Path
-					// enter: dedicated open — the host pins the note to its
-					// own per-file editor pane (rail identity stays this note).
-					return m, func() tea.Msg {
-						return embed.EditRequestMsg{Path: path, Dedicated: true}
-					}
-				}
+				return m, m.openCurrentNode()
 			}
 		case key.Matches(msg, m.keys.Edit): // e - quick edit in the host's singleton Editor
 			node := m.views.GetCurrentNode()
@@ -1294,7 +1957,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 			path := noteToPreview.Path
 			m.previewVisible = !m.previewVisible
 			if !m.previewVisible {
+				m.clearPeek()
 				m.previewFocused = false
+				m.clearPreviewSearch()
 				m.previewFile = ""
 				if strings.Contains(m.statusMessage, "Previewing") || strings.Contains(m.statusMessage, "Loading") {
 					m.statusMessage = ""
@@ -1309,14 +1974,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 				}
 			}
 			m.previewFile = path
-			if m.hosted {
-				return m, func() tea.Msg {
-					return embed.SplitEditorRequestMsg{Path: path, Ratio: 0.35, Focus: false}
-				}
-			}
-			return m, func() tea.Msg {
-				return embed.PreviewRequestMsg{Path: path}
-			}
+			return m, m.previewRequestCmd(path)
 		case key.Matches(msg, m.keys.GitCommit):
 			// Start commit dialog
 			m.isCommitting = true
@@ -1338,9 +1996,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 			// Unstage all changes
 			return m, unstageAllCmd(m.service, m.allItems)
 		case key.Matches(msg, m.keys.Back):
+			// Leaving visual-line mode keeps the selection.
+			if m.views.InVisualMode() {
+				m.views.ExitVisualMode()
+				return m, nil
+			}
+			if m.peekPath != "" {
+				return m, m.endPeek()
+			}
 			if m.previewVisible {
 				m.previewVisible = false
 				m.previewFocused = false
+				m.clearPreviewSearch()
 				m.previewFile = ""
 				if strings.Contains(m.statusMessage, "Previewing") || strings.Contains(m.statusMessage, "Loading") {
 					m.statusMessage = ""
@@ -1400,6 +2067,43 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo
 	return m, nil
 }
 
+// openCurrentNode opens the note under the cursor in a dedicated editor pane,
+// or toggles the fold when the cursor is on a workspace or group. Files that
+// are not markdown go to openWithExternalTool instead.
+func (m *Model) openCurrentNode() tea.Cmd {
+	node := m.views.GetCurrentNode()
+	if node == nil {
+		return nil
+	}
+	// A plan whose notes are all hidden (filtered out, or artifacts only)
+	// has nothing to unfold; list its notes instead.
+	if node.IsPlan() && node.ChildCount == 0 {
+		return m.loadPlanNotesCmd()
+	}
+	if node.IsFoldable() {
+		m.views.ToggleFold()
+		return nil
+	}
+	if !node.IsNote() {
+		return nil
+	}
+	note := views.ItemToNote(node.Item)
+	if note == nil {
+		return nil
+	}
+	path := note.Path
+	// Markdown goes to the editor; anything else ($EDITOR can't do much with
+	// a PNG) goes to a viewer.
+	if !strings.EqualFold(filepath.Ext(path), ".md") {
+		return m.openWithExternalTool(path)
+	}
+	// Dedicated open: the host pins the note to its own per-file editor pane
+	// (rail identity stays this note).
+	return func() tea.Msg {
+		return embed.EditRequestMsg{Path: path, Dedicated: true}
+	}
+}
+
 // deleteSelectedNotesCmd creates a command to delete the selected notes.
 func (m *Model) deleteSelectedNotesCmd() tea.Cmd {
 	pathsToDelete := m.views.GetTargetedNotePaths()
@@ -1628,6 +2332,399 @@ func (m Model) updateNoteRename(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// applyTagFilter filters the tree to notes tagged tag by inserting the
+// "#tag " prefix into the single search input (reconciled model).
+// updateViewsState parses the prefix; the input stays blurred so the user can
+// navigate results immediately, and pressing the search/re-enter key lets
+// them append a within-tag query.
+func (m *Model) applyTagFilter(tag string) {
+	m.filterInput.SetValue("#" + tag + " ")
+	m.filterInput.CursorEnd()
+	// Expand everything when tag filter is active
+	m.views.SetCollapseState(make(map[string]bool))
+	m.updateViewsState()
+}
+
+// updateTagEdit handles input while the bulk tag prompt is open. Enter
+// applies the tag; a leading '-' removes it instead of adding it.
+func (m Model) updateTagEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.isEditingTags = false
+		m.tagEditInput.Blur()
+		m.tagEditPaths = nil
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.tagEditInput.Value())
+		paths := m.tagEditPaths
+		m.isEditingTags = false
+		m.tagEditInput.Blur()
+		m.tagEditPaths = nil
+		if value == "" {
+			return m, nil
+		}
+		return m, editTagsCmd(m.service, paths, value)
+	}
+	var cmd tea.Cmd
+	m.tagEditInput, cmd = m.tagEditInput.Update(msg)
+	return m, cmd
+}
+
+// editTagsCmd adds the tag in value to paths, or removes it when value starts
+// with '-'.
+func editTagsCmd(svc *service.Service, paths []string, value string) tea.Cmd {
+	return func() tea.Msg {
+		removed := strings.HasPrefix(value, "-")
+		tag := service.NormalizeTag(strings.TrimPrefix(value, "-"))
+		edit := svc.AddTag
+		if removed {
+			edit = svc.RemoveTag
+		}
+		changed, err := edit(paths, tag)
+		return tagsEditedMsg{tag: tag, removed: removed, changed: len(changed), total: len(paths), err: err}
+	}
+}
+
+// loadFrontmatterCmd reads the raw frontmatter of a note for the inline editor.
+func loadFrontmatterCmd(svc *service.Service, path string) tea.Cmd {
+	return func() tea.Msg {
+		raw, err := svc.ReadRawFrontmatter(path)
+		return frontmatterLoadedMsg{path: path, raw: raw, err: err}
+	}
+}
+
+// openFrontmatterEditor builds a fresh textarea sized to the terminal and
+// pre-populated with the note's raw YAML frontmatter.
+func (m *Model) openFrontmatterEditor(path, raw string) {
+	ta := textarea.New()
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0
+	width := m.width - 16
+	if width > 100 {
+		width = 100
+	}
+	if width < 30 {
+		width = 30
+	}
+	height := m.height - 14
+	if height > 30 {
+		height = 30
+	}
+	if height < 5 {
+		height = 5
+	}
+	ta.SetWidth(width)
+	ta.SetHeight(height)
+	ta.SetValue(raw)
+	ta.Focus()
+
+	m.frontmatterEditor = ta
+	m.frontmatterPath = path
+	m.frontmatterError = ""
+	m.textareaMode = true
+}
+
+// closeFrontmatterEditor discards the inline editor state.
+func (m *Model) closeFrontmatterEditor() {
+	m.textareaMode = false
+	m.frontmatterEditor.Blur()
+	m.frontmatterPath = ""
+	m.frontmatterError = ""
+}
+
+// updateFrontmatterEditor handles input when the inline frontmatter editor is
+// active. Ctrl+S validates and writes the YAML back (body untouched); Esc
+// discards the edit.
+func (m Model) updateFrontmatterEditor(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			m.closeFrontmatterEditor()
+			m.statusMessage = "Frontmatter edit discarded"
+			return m, nil
+		case "ctrl+s":
+			svc := m.service
+			path := m.frontmatterPath
+			raw := m.frontmatterEditor.Value()
+			return m, func() tea.Msg {
+				return frontmatterSavedMsg{path: path, err: svc.UpdateNoteRawFrontmatter(path, raw)}
+			}
+		}
+	}
+
+	m.frontmatterEditor, cmd = m.frontmatterEditor.Update(msg)
+	return m, cmd
+}
+
+// relatedNotesLimit caps the number of entries in the related-notes overlay.
+const relatedNotesLimit = 10
+
+// loadRelatedNotesCmd scores every note against note's tags for the overlay.
+func loadRelatedNotesCmd(svc *service.Service, note *models.Note) tea.Cmd {
+	source := note.FrontmatterTitle
+	if source == "" {
+		source = note.Title
+	}
+	path := note.Path
+	return func() tea.Msg {
+		related, err := svc.GetRelatedNotesScored(path, relatedNotesLimit)
+		return relatedNotesLoadedMsg{source: source, related: related, err: err}
+	}
+}
+
+// closeRelatedOverlay discards the related-notes overlay state.
+func (m *Model) closeRelatedOverlay() {
+	m.relatedMode = false
+	m.relatedSource = ""
+	m.relatedNotes = nil
+	m.relatedCursor = 0
+}
+
+// updateRelatedOverlay handles input while the related-notes overlay is open:
+// j/k move, enter opens the note in its own pane, e quick-edits it, esc closes.
+func (m Model) updateRelatedOverlay(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Quit):
+		m.closeRelatedOverlay()
+	case key.Matches(msg, m.keys.Up):
+		if m.relatedCursor > 0 {
+			m.relatedCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.relatedCursor < len(m.relatedNotes)-1 {
+			m.relatedCursor++
+		}
+	case key.Matches(msg, m.keys.Confirm), key.Matches(msg, m.keys.Edit):
+		if m.relatedCursor >= len(m.relatedNotes) {
+			return m, nil
+		}
+		path := m.relatedNotes[m.relatedCursor].Note.Path
+		dedicated := key.Matches(msg, m.keys.Confirm)
+		m.closeRelatedOverlay()
+		return m, func() tea.Msg {
+			return embed.EditRequestMsg{Path: path, Dedicated: dedicated}
+		}
+	}
+	return m, nil
+}
+
+// loadPlanTimelineCmd loads the timeline of the plan under the cursor.
+func (m *Model) loadPlanTimelineCmd() tea.Cmd {
+	node := m.views.GetCurrentNode()
+	if node == nil || !node.IsPlan() {
+		m.statusMessage = "Move the cursor onto a plan to see its timeline"
+		return nil
+	}
+	plan := node.Item.Name
+	target, ok := m.planWorkspaceTarget(node)
+	if !ok {
+		return nil
+	}
+	svc := m.service
+	m.statusMessage = "Loading timeline for " + plan + "..."
+	return func() tea.Msg {
+		ctx, err := svc.GetWorkspaceContext(target)
+		if err != nil {
+			return planTimelineLoadedMsg{plan: plan, err: err}
+		}
+		entries, err := svc.GetPlanTimeline(ctx, plan)
+		return planTimelineLoadedMsg{plan: plan, entries: entries, err: err}
+	}
+}
+
+// planWorkspaceTarget returns the GetWorkspaceContext argument for the
+// workspace of the plan node: its path, or "global" by name. ok is false, with
+// the status message set, when the workspace is unknown.
+func (m *Model) planWorkspaceTarget(node *views.DisplayNode) (target string, ok bool) {
+	wsName, _ := node.Item.Metadata["Workspace"].(string)
+	if wsName == "global" {
+		return "global", true
+	}
+	ws, found := m.findWorkspaceNodeByName(wsName)
+	if !found {
+		m.statusMessage = "Workspace not found: " + wsName
+		return "", false
+	}
+	return ws.Path, true
+}
+
+// loadPlanNotesCmd loads the notes of the plan under the cursor for the plan
+// notes overlay.
+func (m *Model) loadPlanNotesCmd() tea.Cmd {
+	node := m.views.GetCurrentNode()
+	if node == nil || !node.IsPlan() {
+		return nil
+	}
+	plan := node.Item.Name
+	target, ok := m.planWorkspaceTarget(node)
+	if !ok {
+		return nil
+	}
+	svc := m.service
+	m.statusMessage = "Loading notes for " + plan + "..."
+	return func() tea.Msg {
+		ctx, err := svc.GetWorkspaceContext(target)
+		if err != nil {
+			return planNotesLoadedMsg{plan: plan, err: err}
+		}
+		notes, err := svc.GetPlanNotes(ctx, plan)
+		return planNotesLoadedMsg{plan: plan, notes: notes, err: err}
+	}
+}
+
+// closePlanNotesOverlay discards the plan notes overlay state.
+func (m *Model) closePlanNotesOverlay() {
+	m.planNotesMode = false
+	m.planNotesPlan = ""
+	m.planNotes = nil
+	m.planNotesCursor = 0
+}
+
+// updatePlanNotesOverlay handles input while the plan notes overlay is open:
+// j/k move, enter opens the note in its own pane, e quick-edits it, esc
+// closes.
+func (m Model) updatePlanNotesOverlay(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Quit):
+		m.closePlanNotesOverlay()
+	case key.Matches(msg, m.keys.Up):
+		if m.planNotesCursor > 0 {
+			m.planNotesCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.planNotesCursor < len(m.planNotes)-1 {
+			m.planNotesCursor++
+		}
+	case key.Matches(msg, m.keys.Confirm), key.Matches(msg, m.keys.Edit):
+		if m.planNotesCursor >= len(m.planNotes) {
+			return m, nil
+		}
+		path := m.planNotes[m.planNotesCursor].Path
+		dedicated := key.Matches(msg, m.keys.Confirm)
+		m.closePlanNotesOverlay()
+		return m, func() tea.Msg {
+			return embed.EditRequestMsg{Path: path, Dedicated: dedicated}
+		}
+	}
+	return m, nil
+}
+
+// closeTimelineOverlay discards the plan timeline overlay state.
+func (m *Model) closeTimelineOverlay() {
+	m.timelineMode = false
+	m.timelinePlan = ""
+	m.timelineEntries = nil
+	m.timelineCursor = 0
+}
+
+// timelineRows is how many timeline entries fit in the overlay: the screen
+// less the border, padding, title and help lines, and the two scroll hints.
+func (m Model) timelineRows() int {
+	rows := m.height - 14
+	if rows < 3 {
+		rows = 3
+	}
+	return rows
+}
+
+// timelineWindow returns the range of timeline entries the overlay shows,
+// centered on the cursor where the list allows.
+func (m Model) timelineWindow() (start, end int) {
+	rows := m.timelineRows()
+	if len(m.timelineEntries) <= rows {
+		return 0, len(m.timelineEntries)
+	}
+	start = m.timelineCursor - rows/2
+	if start < 0 {
+		start = 0
+	}
+	if start > len(m.timelineEntries)-rows {
+		start = len(m.timelineEntries) - rows
+	}
+	return start, start + rows
+}
+
+// updateTimelineOverlay handles input while the plan timeline overlay is
+// open: j/k move, page up/down move a screen, G goes to the newest entry,
+// enter opens the entry's note in its own pane, e quick-edits it, esc
+// closes.
+func (m Model) updateTimelineOverlay(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	last := len(m.timelineEntries) - 1
+	switch {
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Quit):
+		m.closeTimelineOverlay()
+	case key.Matches(msg, m.keys.Up):
+		if m.timelineCursor > 0 {
+			m.timelineCursor--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.timelineCursor < last {
+			m.timelineCursor++
+		}
+	case key.Matches(msg, m.keys.PageUp):
+		m.timelineCursor = max(m.timelineCursor-m.timelineRows(), 0)
+	case key.Matches(msg, m.keys.PageDown):
+		m.timelineCursor = max(min(m.timelineCursor+m.timelineRows(), last), 0)
+	case key.Matches(msg, m.keys.Bottom):
+		m.timelineCursor = max(last, 0)
+	case key.Matches(msg, m.keys.Confirm), key.Matches(msg, m.keys.Edit):
+		if m.timelineCursor >= len(m.timelineEntries) {
+			return m, nil
+		}
+		path := m.timelineEntries[m.timelineCursor].Path
+		dedicated := key.Matches(msg, m.keys.Confirm)
+		m.closeTimelineOverlay()
+		return m, func() tea.Msg {
+			return embed.EditRequestMsg{Path: path, Dedicated: dedicated}
+		}
+	}
+	return m, nil
+}
+
+// openAttachPicker opens the file picker for attaching a file to the note at
+// notePath, starting in the user's home directory.
+func (m *Model) openAttachPicker(notePath string) tea.Cmd {
+	fp := filepicker.New()
+	fp.CurrentDirectory, _ = os.UserHomeDir()
+	// esc closes the picker rather than stepping back a directory.
+	fp.KeyMap.Back = key.NewBinding(key.WithKeys("h", "backspace", "left"), key.WithHelp("h", "back"))
+	fp.AutoHeight = false
+	fp.SetHeight(max(m.height-10, 5))
+	m.attachPicker = fp
+	m.attachPickerMode = true
+	m.attachNotePath = notePath
+	return fp.Init()
+}
+
+// updateAttachPicker handles keys while the attachment picker is open.
+func (m Model) updateAttachPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" {
+		m.attachPickerMode = false
+		m.attachNotePath = ""
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.attachPicker, cmd = m.attachPicker.Update(msg)
+	if ok, path := m.attachPicker.DidSelectFile(msg); ok {
+		notePath := m.attachNotePath
+		m.attachPickerMode = false
+		m.attachNotePath = ""
+		return m, addAttachmentCmd(m.service, notePath, path)
+	}
+	return m, cmd
+}
+
+// addAttachmentCmd copies path into the note's attachment directory and
+// links it from the note.
+func addAttachmentCmd(svc *service.Service, notePath, path string) tea.Cmd {
+	return func() tea.Msg {
+		paths, err := svc.AddAttachments(notePath, []string{path})
+		return attachmentsAddedMsg{notePath: notePath, paths: paths, err: err}
+	}
+}
+
 // renameNoteCmd creates a command to rename a note.
 func (m *Model) renameNoteCmd() tea.Cmd {
 	if m.noteToRename == nil {
@@ -1908,6 +3005,34 @@ func (m *Model) collapseAllWorkspaces() {
 	m.views.SetCollapseState(collapsedNodes)
 }
 
+// focusCursorKey is the cursorByWorkspace key of the current focus.
+func (m *Model) focusCursorKey() string {
+	if m.focusedWorkspace == nil {
+		return ""
+	}
+	return m.focusedWorkspace.Path
+}
+
+// setFocus saves the cursor position for the current focus, then focuses ws
+// (nil for the unfocused view). The items for the new focus still have to be
+// fetched; restoreFocusCursor puts the cursor back once they arrive.
+func (m *Model) setFocus(ws *workspace.WorkspaceNode) {
+	if m.cursorByWorkspace == nil {
+		m.cursorByWorkspace = make(map[string]int)
+	}
+	m.cursorByWorkspace[m.focusCursorKey()] = m.views.GetCursor()
+	m.focusedWorkspace = ws
+	m.focusChanged = true
+}
+
+// restoreFocusCursor moves the cursor to where it was when the current focus
+// was last left, if it has been focused before.
+func (m *Model) restoreFocusCursor() {
+	if cursor, ok := m.cursorByWorkspace[m.focusCursorKey()]; ok {
+		m.views.SetCursor(cursor)
+	}
+}
+
 // setCollapseStateForFocus systematically sets the collapse state based on the current focus level
 func (m *Model) setCollapseStateForFocus() {
 	collapsedNodes := make(map[string]bool) // Start fresh
@@ -1951,7 +3076,7 @@ func (m *Model) setCollapseStateForFocus() {
 					continue
 				}
 
-				groupNodeID := "dir:" + groupPath
+				groupNodeID := views.GroupNodeID(wsNode.Name, groupPath)
 
 				// Use DefaultExpand from NoteTypes to determine if group should be expanded
 				shouldExpand := false
@@ -1971,7 +3096,7 @@ func (m *Model) setCollapseStateForFocus() {
 		for groupName, typeConfig := range m.service.NoteTypes {
 			if typeConfig.DefaultExpand {
 				if groupPath, err := m.service.GetNotebookLocator().GetGroupDir(wsNode, groupName); err == nil {
-					delete(collapsedNodes, "dir:"+groupPath)
+					delete(collapsedNodes, views.GroupNodeID(wsNode.Name, groupPath))
 				}
 			}
 		}
@@ -2017,7 +3142,7 @@ func (m *Model) setCollapseStateForFocus() {
 					continue
 				}
 
-				groupNodeID := "dir:" + groupPath
+				groupNodeID := views.GroupNodeID(wsNode.Name, groupPath)
 
 				// Use DefaultExpand from NoteTypes to determine if group should be expanded
 				shouldExpand := false
@@ -2037,7 +3162,7 @@ func (m *Model) setCollapseStateForFocus() {
 		for groupName, typeConfig := range m.service.NoteTypes {
 			if typeConfig.DefaultExpand {
 				if groupPath, err := m.service.GetNotebookLocator().GetGroupDir(wsNode, groupName); err == nil {
-					delete(collapsedNodes, "dir:"+groupPath)
+					delete(collapsedNodes, views.GroupNodeID(wsNode.Name, groupPath))
 				}
 			}
 		}
@@ -2119,3 +3244,21 @@ func (m *Model) executeCommitCmd() tea.Cmd {
 		return commitFinishedMsg{success: true, message: message, err: nil}
 	}
 }
+
+// activateConfirm asks to confirm a destructive action on count items. Past
+// the configured confirm_threshold the count has to be typed; with
+// confirm_single off a single item is confirmed right away. The prompt is
+// kept either way, since the ConfirmedMsg handler dispatches on it.
+func (m *Model) activateConfirm(prompt string, count int) tea.Cmd {
+	cfg := m.service.Config
+	switch {
+	case count == 1 && cfg != nil && cfg.SkipSingleConfirm:
+		m.confirmDialog.Prompt = prompt
+		return func() tea.Msg { return confirm.ConfirmedMsg{} }
+	case cfg != nil && cfg.ConfirmThreshold > 0 && count > cfg.ConfirmThreshold:
+		m.confirmDialog.ActivateTyped(prompt, strconv.Itoa(count))
+	default:
+		m.confirmDialog.Activate(prompt)
+	}
+	return nil
+}
@@ -2,13 +2,20 @@ package browser
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/grovetools/core/pkg/workspace"
 	"github.com/grovetools/core/tui/keymap"
 	"github.com/grovetools/core/tui/theme"
+
+	"github.com/grovetools/nb/pkg/service"
 )
 
+// tagCloudIndent is the left margin of the full-screen tag cloud.
+const tagCloudIndent = 2
+
 // getNoteCreationContext returns a description of where the note will be created
 func (m Model) getNoteCreationContext() string {
 	if m.noteCreationMode == "inbox" {
@@ -62,6 +69,11 @@ func (m Model) View() string {
 		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, dialog)
 	}
 
+	// Render tag cloud if active
+	if m.tagCloud.Active {
+		return "\n" + lipgloss.NewStyle().PaddingLeft(tagCloudIndent).Render(m.tagCloud.View())
+	}
+
 	// Render tag picker if active
 	if m.tagPickerMode {
 		content := m.tagPicker.View()
@@ -88,6 +100,34 @@ func (m Model) View() string {
 		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, paddedOverlay)
 	}
 
+	// Render attachment file picker if active
+	if m.attachPickerMode {
+		title := lipgloss.NewStyle().Bold(true).
+			Render("Attach file to: " + filepath.Base(m.attachNotePath))
+		content := lipgloss.JoinVertical(lipgloss.Left, title, "",
+			m.attachPicker.CurrentDirectory, "", m.attachPicker.View())
+
+		dialogBox := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.DefaultTheme.Colors.Cyan).
+			Padding(1, 2).
+			Render(content)
+
+		helpText := lipgloss.NewStyle().
+			Faint(true).
+			Width(lipgloss.Width(dialogBox)).
+			Align(lipgloss.Center).
+			Render("\n\nEnter to attach • h to go up • Esc to cancel")
+
+		overlay := lipgloss.JoinVertical(lipgloss.Left, dialogBox, helpText)
+
+		paddedOverlay := lipgloss.NewStyle().
+			Padding(2, 0, 0, 4).
+			Render(overlay)
+
+		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, paddedOverlay)
+	}
+
 	// Render plan picker if active (promote to job)
 	if m.isPromotingToJob {
 		content := m.planPicker.View()
@@ -114,6 +154,27 @@ func (m Model) View() string {
 		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, paddedOverlay)
 	}
 
+	// Render plan status picker if active
+	if m.planStatusMode {
+		plan := strings.TrimPrefix(m.planStatusPlan, "plans/")
+		content := lipgloss.NewStyle().Faint(true).Render("Plan: "+plan) + "\n\n" + m.planStatusPicker.View()
+
+		dialogBox := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.DefaultTheme.Colors.Cyan).
+			Padding(1, 2).
+			Render(content)
+
+		helpText := lipgloss.NewStyle().
+			Faint(true).
+			Width(lipgloss.Width(dialogBox)).
+			Align(lipgloss.Center).
+			Render("\n\nEnter to select • Esc to cancel")
+
+		overlay := lipgloss.JoinVertical(lipgloss.Left, dialogBox, helpText)
+		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+	}
+
 	// Render note creation UI if active
 	if m.isCreatingNote {
 		// Get context information
@@ -176,6 +237,231 @@ func (m Model) View() string {
 		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
 	}
 
+	// Render export destination prompt if active
+	if m.isExporting {
+		contextLine := lipgloss.NewStyle().
+			Faint(true).
+			Render(fmt.Sprintf("Exporting %d note(s)", len(m.exportPaths)))
+
+		content := contextLine + "\n\nCopy into directory, or concatenate into a .md file:\n" + m.exportInput.View()
+
+		dialogBox := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.DefaultTheme.Colors.Cyan).
+			Padding(1, 2).
+			Render(content)
+
+		helpText := lipgloss.NewStyle().
+			Faint(true).
+			Width(lipgloss.Width(dialogBox)).
+			Align(lipgloss.Center).
+			Render("\n\nPress Enter to confirm • Esc to cancel")
+
+		overlay := lipgloss.JoinVertical(lipgloss.Left, dialogBox, helpText)
+		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+	}
+
+	// Render bulk tag prompt if active
+	if m.isEditingTags {
+		contextLine := lipgloss.NewStyle().
+			Faint(true).
+			Render(fmt.Sprintf("Tagging %d note(s)", len(m.tagEditPaths)))
+
+		content := contextLine + "\n\nTag (prefix with - to remove):\n" + m.tagEditInput.View()
+
+		dialogBox := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.DefaultTheme.Colors.Cyan).
+			Padding(1, 2).
+			Render(content)
+
+		helpText := lipgloss.NewStyle().
+			Faint(true).
+			Width(lipgloss.Width(dialogBox)).
+			Align(lipgloss.Center).
+			Render("\n\nPress Enter to confirm • Esc to cancel")
+
+		overlay := lipgloss.JoinVertical(lipgloss.Left, dialogBox, helpText)
+		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+	}
+
+	// Render inline frontmatter editor if active
+	if m.textareaMode {
+		contextLine := lipgloss.NewStyle().
+			Faint(true).
+			Render(fmt.Sprintf("Frontmatter: %s", filepath.Base(m.frontmatterPath)))
+
+		content := contextLine + "\n\n" + m.frontmatterEditor.View()
+		if m.frontmatterError != "" {
+			errLine := lipgloss.NewStyle().
+				Foreground(theme.DefaultTheme.Colors.Red).
+				Width(m.frontmatterEditor.Width()).
+				Render(m.frontmatterError)
+			content += "\n\n" + errLine
+		}
+
+		dialogBox := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.DefaultTheme.Colors.Cyan).
+			Padding(1, 2).
+			Render(content)
+
+		helpText := lipgloss.NewStyle().
+			Faint(true).
+			Width(lipgloss.Width(dialogBox)).
+			Align(lipgloss.Center).
+			Render("\n\nCtrl+S to save • Esc to discard")
+
+		overlay := lipgloss.JoinVertical(lipgloss.Left, dialogBox, helpText)
+		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+	}
+
+	// Render related-notes overlay if active
+	if m.relatedMode {
+		contextLine := lipgloss.NewStyle().
+			Faint(true).
+			Render(fmt.Sprintf("Related to: %s", m.relatedSource))
+
+		var rows []string
+		for i, r := range m.relatedNotes {
+			title := r.Note.FrontmatterTitle
+			if title == "" {
+				title = r.Note.Title
+			}
+			row := fmt.Sprintf("%.2f  %s  %s", r.Score, title,
+				lipgloss.NewStyle().Faint(true).Render(r.Note.Workspace))
+			if i == m.relatedCursor {
+				row = lipgloss.NewStyle().
+					Foreground(theme.DefaultTheme.Colors.Cyan).
+					Bold(true).
+					Render("> " + row)
+			} else {
+				row = "  " + row
+			}
+			rows = append(rows, row)
+		}
+
+		content := contextLine + "\n\n" + strings.Join(rows, "\n")
+
+		dialogBox := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.DefaultTheme.Colors.Cyan).
+			Padding(1, 2).
+			Render(content)
+
+		helpText := lipgloss.NewStyle().
+			Faint(true).
+			Width(lipgloss.Width(dialogBox)).
+			Align(lipgloss.Center).
+			Render("\n\nEnter to open • e to quick edit • Esc to close")
+
+		overlay := lipgloss.JoinVertical(lipgloss.Left, dialogBox, helpText)
+		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+	}
+
+	// Render plan timeline overlay if active
+	if m.timelineMode {
+		contextLine := lipgloss.NewStyle().
+			Faint(true).
+			Render(fmt.Sprintf("Timeline: %s", m.timelinePlan))
+
+		// Only the rows that fit are drawn, scrolled to keep the cursor in
+		// view.
+		start, end := m.timelineWindow()
+		var rows []string
+		if start > 0 {
+			rows = append(rows, lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf("  ↑ %d more", start)))
+		}
+		for i := start; i < end; i++ {
+			row := m.timelineEntries[i].String()
+			if i == m.timelineCursor {
+				row = lipgloss.NewStyle().
+					Foreground(theme.DefaultTheme.Colors.Cyan).
+					Bold(true).
+					Render("> " + row)
+			} else {
+				row = "  " + row
+			}
+			rows = append(rows, row)
+		}
+		if end < len(m.timelineEntries) {
+			rows = append(rows, lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf("  ↓ %d more", len(m.timelineEntries)-end)))
+		}
+
+		content := contextLine + "\n\n" + strings.Join(rows, "\n")
+
+		dialogBox := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.DefaultTheme.Colors.Cyan).
+			Padding(1, 2).
+			Render(content)
+
+		helpText := lipgloss.NewStyle().
+			Faint(true).
+			Width(lipgloss.Width(dialogBox)).
+			Align(lipgloss.Center).
+			Render("\n\nEnter to open • e to quick edit • Esc to close")
+
+		overlay := lipgloss.JoinVertical(lipgloss.Left, dialogBox, helpText)
+		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+	}
+
+	// Render plan notes overlay if active
+	if m.planNotesMode {
+		contextLine := lipgloss.NewStyle().
+			Faint(true).
+			Render(fmt.Sprintf("Notes in plan %s", m.planNotesPlan))
+
+		// Paths are shown relative to the plan directory.
+		planSegment := string(filepath.Separator) + m.planNotesPlan + string(filepath.Separator)
+		var rows []string
+		for i, note := range m.planNotes {
+			row := filepath.Base(note.Path)
+			if idx := strings.LastIndex(note.Path, planSegment); idx >= 0 {
+				row = note.Path[idx+len(planSegment):]
+			}
+			if note.FrontmatterTitle != "" {
+				row += "  " + lipgloss.NewStyle().Faint(true).Render(note.FrontmatterTitle)
+			}
+			if i == m.planNotesCursor {
+				row = lipgloss.NewStyle().
+					Foreground(theme.DefaultTheme.Colors.Cyan).
+					Bold(true).
+					Render("> " + row)
+			} else {
+				row = "  " + row
+			}
+			rows = append(rows, row)
+		}
+
+		content := contextLine + "\n\n" + strings.Join(rows, "\n")
+
+		dialogBox := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(theme.DefaultTheme.Colors.Cyan).
+			Padding(1, 2).
+			Render(content)
+
+		helpText := lipgloss.NewStyle().
+			Faint(true).
+			Width(lipgloss.Width(dialogBox)).
+			Align(lipgloss.Center).
+			Render("\n\nEnter to open • e to quick edit • Esc to close")
+
+		overlay := lipgloss.JoinVertical(lipgloss.Left, dialogBox, helpText)
+		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+	}
+
+	// Render quick-look popup if active
+	if m.quickLookMode && m.quickLookNote != nil {
+		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.quickLookView())
+	}
+
+	// Render the note diff overlay if active
+	if m.diffMode {
+		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, m.noteDiffView())
+	}
+
 	// Render git commit dialog if active
 	if m.isCommitting {
 		contextLine := lipgloss.NewStyle().
@@ -211,13 +497,18 @@ func (m Model) View() string {
 			Faint(true).
 			Width(lipgloss.Width(styledView)).
 			Align(lipgloss.Center).
-			Render("\n\nPress space to toggle • Enter/Esc/V to close")
+			Render("\n\nSpace to toggle • J/K to reorder • Enter/Esc to close")
 		content := lipgloss.JoinVertical(lipgloss.Left, styledView, helpText)
 		return "\n" + lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
 	}
 
 	// --- Single-pane layout (preview is handled by the terminal host VDrawer) ---
 	browserContent := m.views.View()
+	if m.previewSearchActive() {
+		// The host's preview can't be searched, so the search shows the
+		// note in the TUI's own preview viewport, in place of the tree.
+		browserContent = m.preview.View()
+	}
 	browserPaneStyle := lipgloss.NewStyle().Padding(0, 1)
 	viewContent := browserPaneStyle.Render(browserContent)
 
@@ -244,6 +535,19 @@ func (m Model) View() string {
 	headerParts := []string{notebookTitle}
 	if m.focusedWorkspace != nil {
 		headerParts = append(headerParts, " > ", m.focusedWorkspace.Name)
+		if m.workspaceSummary != nil && m.summaryWorkspace == m.focusedWorkspace {
+			headerParts = append(headerParts, lipgloss.NewStyle().
+				Foreground(theme.DefaultTheme.Colors.MutedText).
+				Render(" · "+m.workspaceSummary.String()))
+		}
+	}
+	// Inbox badge: unfiled notes across the visible workspaces (inbox zero
+	// hides it).
+	if n := m.inboxCount(); n > 0 {
+		inboxStyled := lipgloss.NewStyle().
+			Foreground(theme.DefaultTheme.Colors.Orange).
+			Render(fmt.Sprintf(" [Inbox: %d]", n))
+		headerParts = append(headerParts, inboxStyled)
 	}
 	if m.recentNotesMode {
 		headerParts = append(headerParts, " [Recent]")
@@ -251,6 +555,9 @@ func (m Model) View() string {
 	if m.showGitModifiedOnly {
 		headerParts = append(headerParts, " [Git Modified]")
 	}
+	if m.showUntaggedOnly {
+		headerParts = append(headerParts, " [Untagged]")
+	}
 
 	// Add group-by indicator when an axis other than "none" is active.
 	if m.groupBy != "" && m.groupBy != "none" {
@@ -279,6 +586,8 @@ func (m Model) View() string {
 		headerParts = append(headerParts, " [Search]")
 	} else if m.ecosystemPickerMode {
 		headerParts = append(headerParts, " [Select Ecosystem]")
+	} else if m.views.InVisualMode() {
+		headerParts = append(headerParts, " [Visual Line]")
 	}
 
 	// Join all parts and apply theme styling
@@ -301,20 +610,20 @@ func (m Model) View() string {
 			status = m.statusMessage
 		}
 	} else {
-		// Get note count and selection info from views
-		noteCount, selectedNotes, selectedPlans := m.views.GetCounts()
+		// Selection info from views; the note count is on the right.
+		_, selectedNotes, selectedPlans := m.views.GetCounts()
 
 		selectionInfo := ""
 		if selectedNotes > 0 && selectedPlans > 0 {
-			selectionInfo = fmt.Sprintf(" | %d notes + %d plans selected", selectedNotes, selectedPlans)
+			selectionInfo = fmt.Sprintf("%d notes + %d plans selected", selectedNotes, selectedPlans)
 		} else if selectedNotes > 0 {
-			selectionInfo = fmt.Sprintf(" | %d notes selected", selectedNotes)
+			selectionInfo = fmt.Sprintf("%d notes selected", selectedNotes)
 		} else if selectedPlans > 0 {
-			selectionInfo = fmt.Sprintf(" | %d plans selected", selectedPlans)
+			selectionInfo = fmt.Sprintf("%d plans selected", selectedPlans)
 		} else {
-			selectionInfo = " | 0 selected"
+			selectionInfo = "0 selected"
 		}
-		status = fmt.Sprintf("%d notes shown%s", noteCount, selectionInfo)
+		status = fmt.Sprintf("%s | [sort: %s]", selectionInfo, m.views.GetSortConfig())
 	}
 
 	// Immediate flat-chord footer hint (gg/dd/yy) so single-key arming is not
@@ -343,10 +652,12 @@ func (m Model) View() string {
 	var searchBar string
 	if m.filterInput.Focused() || m.filterInput.Value() != "" {
 		label := "Search: "
-		if m.isGrepping {
-			label = "Grep: "
-		} else if m.isFilteringByTag {
+		if m.isFilteringByTag {
 			label = "Tag: "
+		} else if strings.HasPrefix(m.filterInput.Value(), "?") {
+			label = "Grep: "
+		} else if m.searchScope != "" && m.searchScope != service.SearchInTitle {
+			label = fmt.Sprintf("Search [%s]: ", m.searchScope)
 		}
 		val := m.filterInput.Value()
 		caret := "█"
@@ -364,11 +675,17 @@ func (m Model) View() string {
 		mainContent = header
 	}
 
+	status = m.withNoteCount(status)
+	statusLines := theme.DefaultTheme.Muted.Render(status)
+	if bar := m.previewSearchBar(); bar != "" {
+		statusLines = lipgloss.JoinVertical(lipgloss.Left, bar, statusLines)
+	}
+
 	fullView := lipgloss.JoinVertical(lipgloss.Left,
 		mainContent,
 		viewContent,
 		"", // Another blank line for spacing
-		theme.DefaultTheme.Muted.Render(status),
+		statusLines,
 	)
 
 	// Apply global left padding, top margin, and width clamping
@@ -387,3 +704,24 @@ func (m Model) View() string {
 func (m Model) FooterView() string {
 	return m.help.View()
 }
+
+// withNoteCount right-aligns the note counter on the status line:
+// "[visible: N]", plus "[total: M]" while a search, tag, git or untagged
+// filter hides notes, after the previewed note's word count and readability
+// while the preview is open. It is shown whatever the line holds, so the
+// count of a filter stays in view while a status message is up.
+func (m *Model) withNoteCount(status string) string {
+	counter := fmt.Sprintf("[visible: %d]", m.views.GetVisibleNoteCount())
+	if m.filterInput.Value() != "" || m.showGitModifiedOnly || m.showUntaggedOnly {
+		counter += fmt.Sprintf(" [total: %d]", m.views.GetTotalNoteCount())
+	}
+	if m.previewVisible && m.previewFile != "" && m.previewStats != "" {
+		counter = m.previewStats + "  " + counter
+	}
+	// The view is padded two columns on the left; keep two free on the right.
+	gap := m.width - 4 - lipgloss.Width(status) - lipgloss.Width(counter)
+	if gap < 2 {
+		gap = 2
+	}
+	return status + strings.Repeat(" ", gap) + counter
+}
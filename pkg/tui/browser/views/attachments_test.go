@@ -0,0 +1,29 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+func TestIndexAttachmentsCountsFilesPerNote(t *testing.T) {
+	m := &Model{allItems: []*tree.Item{
+		{Path: "/ws/inbox/design.md"},
+		{Path: "/ws/inbox/attachments/design/sketch.png"},
+		{Path: "/ws/inbox/attachments/design/raw/data.csv"},
+		{Path: "/ws/inbox/attachments/loose.txt"},
+		{Path: "/ws/inbox/attachments/design", IsDir: true},
+		{Path: "/ws/issues/bug.md", Metadata: map[string]interface{}{"Attachments": 3}},
+	}}
+	m.indexAttachments()
+
+	if got := m.attachmentCounts["/ws/inbox/design.md"]; got != 2 {
+		t.Errorf("design.md attachments = %d, want 2", got)
+	}
+	if got := m.attachmentCounts["/ws/issues/bug.md"]; got != 3 {
+		t.Errorf("bug.md attachments = %d, want 3 from the index metadata", got)
+	}
+	if len(m.attachmentCounts) != 2 {
+		t.Errorf("attachmentCounts = %v, want only design.md and bug.md", m.attachmentCounts)
+	}
+}
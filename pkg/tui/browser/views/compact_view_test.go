@@ -0,0 +1,60 @@
+package views
+
+import (
+	"reflect"
+	"testing"
+
+	workspace "github.com/grovetools/core/pkg/workspace"
+
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+// CompactView flattens notes out of their groups, keeps the tree's scoping
+// (archives hidden, focus honored) and still runs the substring filter.
+func TestCompactViewFlattensAndFilters(t *testing.T) {
+	m, _ := newTreeTestModel(t)
+	m.viewMode = CompactView
+
+	inbox := testNoteItem("inbox", "inbox-note.md", "", nil, nil)
+	nested := testNoteItem("plans/alpha", "plan-note.md", "", nil, []string{"infra"})
+	archived := testNoteItem("inbox/.archive", "old-note.md", "", nil, nil)
+	outside := testNoteItem("inbox", "elsewhere.md", "", nil, nil)
+	outside.Metadata["Workspace"] = "other"
+	m.workspaces = append(m.workspaces, &workspace.WorkspaceNode{Name: "other", Path: "/tmp/other"})
+	m.allItems = []*tree.Item{inbox, nested, archived, outside}
+
+	m.BuildDisplayTree()
+	for _, n := range m.displayNodes {
+		if !n.IsNote() || n.Depth != 0 {
+			t.Fatalf("compact view should only contain depth-0 notes, got %+v", n.Item)
+		}
+	}
+	got := visibleNotePaths(m)
+	if len(got) != 2 {
+		t.Fatalf("got %v, want the two in-scope, non-archived notes", got)
+	}
+
+	m.filterValue = "infra"
+	m.BuildDisplayTree()
+	m.FilterDisplayTree()
+	if got := visibleNotePaths(m); !reflect.DeepEqual(got, []string{nested.Path}) {
+		t.Errorf("filtered compact view = %v, want %v", got, []string{nested.Path})
+	}
+}
+
+func TestToggleViewModeCycle(t *testing.T) {
+	m := &Model{viewMode: TreeView}
+	var seen []string
+	for i := 0; i < 3; i++ {
+		m.ToggleViewMode()
+		seen = append(seen, m.viewMode.String())
+	}
+	if want := []string{"table", "compact", "tree"}; !reflect.DeepEqual(seen, want) {
+		t.Errorf("cycle = %v, want %v", seen, want)
+	}
+	for _, mode := range []ViewMode{TreeView, TableView, CompactView} {
+		if ParseViewMode(mode.String()) != mode {
+			t.Errorf("ParseViewMode(%q) did not round-trip", mode.String())
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+func TestNoteCounts(t *testing.T) {
+	note := func(path string) *tree.Item { return &tree.Item{Path: path, Metadata: map[string]interface{}{}} }
+	group := &tree.Item{Path: "/ws/inbox", IsDir: true, Type: tree.TypeGroup}
+	a, b, c := note("/ws/inbox/a.md"), note("/ws/inbox/b.md"), note("/ws/inbox/c.md")
+	pinned := note("/ws/inbox/a.md")
+	pinned.Metadata["Pinned"] = true
+
+	m := &Model{
+		allItems:     []*tree.Item{group, a, b, c},
+		displayNodes: []*DisplayNode{{Item: pinned}, {Item: group}, {Item: a}, {Item: b}},
+	}
+	if got := m.GetVisibleNoteCount(); got != 2 {
+		t.Errorf("GetVisibleNoteCount() = %d, want 2 (groups and pinned copies are not counted)", got)
+	}
+	if got := m.GetTotalNoteCount(); got != 3 {
+		t.Errorf("GetTotalNoteCount() = %d, want 3", got)
+	}
+	if noteCount, _, _ := m.GetCounts(); noteCount != 2 {
+		t.Errorf("GetCounts() noteCount = %d, want 2", noteCount)
+	}
+}
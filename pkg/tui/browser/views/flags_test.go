@@ -0,0 +1,28 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestNoteHasTagMatchesFlagFilter(t *testing.T) {
+	note := &models.Note{Tags: []string{"bug"}, Flag: "red"}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"bug", true},
+		{"BUG", true},
+		{"flag:red", true},
+		{"Flag:RED", true},
+		{"flag:green", false},
+		{"red", false},
+	}
+	for _, tt := range tests {
+		if got := noteHasTag(note, tt.filter); got != tt.want {
+			t.Errorf("noteHasTag(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
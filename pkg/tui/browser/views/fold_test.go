@@ -0,0 +1,52 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+func testGroupNode(ws, path, group string) *DisplayNode {
+	return &DisplayNode{Item: &tree.Item{
+		Path:     path,
+		Name:     group,
+		IsDir:    true,
+		Type:     tree.TypeGroup,
+		Metadata: map[string]interface{}{"Workspace": ws, "Group": group},
+	}}
+}
+
+// Two workspaces can resolve to the same notes directory (e.g. worktrees of
+// one repo); folding inbox under one must not fold it under the other.
+func TestGroupFoldIsPerWorkspace(t *testing.T) {
+	m, _ := newTreeTestModel(t)
+	inboxA := testGroupNode("ws-a", "/nb/inbox", "inbox")
+	inboxB := testGroupNode("ws-b", "/nb/inbox", "inbox")
+	if inboxA.NodeID() == inboxB.NodeID() {
+		t.Fatalf("inbox NodeIDs collide across workspaces: %q", inboxA.NodeID())
+	}
+
+	m.displayNodes = []*DisplayNode{inboxA, inboxB}
+	m.cursor = 0
+	m.toggleFold()
+	if !m.collapsedNodes[inboxA.NodeID()] {
+		t.Fatal("inbox under ws-a should be folded")
+	}
+	if m.collapsedNodes[inboxB.NodeID()] {
+		t.Error("folding inbox under ws-a also folded inbox under ws-b")
+	}
+
+	// Recursively opening ws-a's plans only opens ws-a's subgroups.
+	plansA := testGroupNode("ws-a", "/nb/plans", "plans")
+	subA := testGroupNode("ws-a", "/nb/plans/feature", "plans/feature")
+	subB := testGroupNode("ws-b", "/nb/plans/feature", "plans/feature")
+	m.collapsedNodes = map[string]bool{plansA.NodeID(): true, subA.NodeID(): true, subB.NodeID(): true}
+	m.displayNodes = []*DisplayNode{plansA, subA, subB}
+	m.openFoldRecursive(0)
+	if m.collapsedNodes[plansA.NodeID()] || m.collapsedNodes[subA.NodeID()] {
+		t.Error("openFoldRecursive left ws-a plans folded")
+	}
+	if !m.collapsedNodes[subB.NodeID()] {
+		t.Error("openFoldRecursive on ws-a plans unfolded ws-b's subgroup")
+	}
+}
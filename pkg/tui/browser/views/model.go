@@ -18,8 +18,35 @@ type ViewMode int
 const (
 	TreeView ViewMode = iota
 	TableView
+	// CompactView is a flat, single-column list of notes (glyph, title, dim
+	// date) that ignores the group hierarchy; meant for narrow terminals.
+	CompactView
 )
 
+// String returns the name used to persist the view mode.
+func (v ViewMode) String() string {
+	switch v {
+	case TableView:
+		return "table"
+	case CompactView:
+		return "compact"
+	default:
+		return "tree"
+	}
+}
+
+// ParseViewMode is the inverse of ViewMode.String. Unknown names yield TreeView.
+func ParseViewMode(s string) ViewMode {
+	switch s {
+	case "table":
+		return TableView
+	case "compact":
+		return CompactView
+	default:
+		return TreeView
+	}
+}
+
 // DisplayNode represents a single line in the hierarchical TUI view.
 type DisplayNode struct {
 	Item *tree.Item
@@ -39,16 +66,30 @@ type DisplayNode struct {
 }
 
 // NodeID returns a unique identifier for this node (for tracking collapsed state).
+// Group-like directories (groups, plans, .archive, synthetic buckets) carry
+// their workspace name in Metadata["Workspace"] and are keyed by it as well as
+// by path, so two workspaces that resolve to the same notes directory keep
+// separate fold state. Workspace nodes carry a *WorkspaceNode there instead and
+// stay keyed by path alone.
 func (n *DisplayNode) NodeID() string {
 	if n.Item == nil {
 		return "separator"
 	}
 	if n.Item.IsDir {
+		if ws, ok := n.Item.Metadata["Workspace"].(string); ok && ws != "" {
+			return GroupNodeID(ws, n.Item.Path)
+		}
 		return "dir:" + n.Item.Path
 	}
 	return "file:" + n.Item.Path
 }
 
+// GroupNodeID is the NodeID of a group-like directory at path in workspace
+// wsName; the browser uses it to seed default fold state.
+func GroupNodeID(wsName, path string) string {
+	return "dir:" + wsName + ":" + path
+}
+
 // IsFoldable returns true if this node can be collapsed/expanded. Directories
 // are always foldable; note rows become foldable when artifacts are nested
 // directly beneath them (Phase 3).
@@ -103,14 +144,18 @@ type Model struct {
 	cursor           int
 	scrollOffset     int
 	viewMode         ViewMode
-	sortAscending    bool
+	sortConfig       SortConfig
+	renderFailures   int // Tree sections the last build recovered from; see guardRender
 	jumpMap          map[rune]int
 	collapsedNodes   map[string]bool
 	seededCollapse   map[string]bool // node IDs whose default-collapse has been applied once
 	selected         map[string]struct{}
 	selectedGroups   map[string]struct{}
+	visualMode       bool // Visual-line selection: cursor movement selects notes
+	visualAnchor     int  // Display index where visual-line selection started
 	cutPaths         map[string]struct{}
 	columnVisibility map[string]bool
+	columnOrder      []string // Table column order; nil means DefaultColumnOrder
 	width            int
 	height           int
 	sequence         *keymap.SequenceState // For detecting multi-key sequences (gg, z*)
@@ -119,20 +164,24 @@ type Model struct {
 	service              *service.Service
 	allItems             []*tree.Item
 	workspaces           []*workspace.WorkspaceNode
+	archivedWorkspaces   []service.ArchivedWorkspace // Listed in their own section when showArchives is on
 	focusedWorkspace     *workspace.WorkspaceNode
 	ecosystemPickerMode  bool
 	hideGlobal           bool
 	showArchives         bool
 	showArtifacts        bool
 	showOnHold           bool
+	showTodaySection     bool // Pin a synthetic "Today" section above the tree
 	filterValue          string
 	isGrepping           bool
+	grepIncludesTitles   bool   // "all" search scope: grep results also include title matches
 	pendingWorkspaceInit string // Workspace name to initialize child groups for after next rebuild
 	isFilteringByTag     bool
 	selectedTag          string
 	recentNotesMode      bool
 	archiveViewMode      bool
 	showGitModifiedOnly  bool
+	showUntaggedOnly     bool
 	groupBy              string // "none", "date", "status", "tag", "priority"
 
 	// Flow plan jobs keyed by job ID (the opaque `.artifacts/<jobID>` dir name),
@@ -145,17 +194,25 @@ type Model struct {
 	jobFileToID       map[string]string
 	jobIDToArtifactCt map[string]int // job ID -> number of artifact files under .artifacts/<jobID>
 
+	// attachmentCounts maps a note path to the number of loaded files in its
+	// attachments/<stem> directory, for the 📎 badge. Rebuilt in
+	// SetParentState.
+	attachmentCounts map[string]int
+
 	// Git status for rendering indicators
 	gitFileStatus   map[string]string // Key: normalized absolute path, Value: git status code
 	gitDeletedFiles []string          // Paths of deleted files (don't exist on disk)
 }
 
 // New creates a new view model.
-func New(keys KeyMap, columnVisibility map[string]bool) Model {
+func New(keys KeyMap, columnVisibility map[string]bool, sortConfig SortConfig) Model {
+	if !isSortField(sortConfig.Field) {
+		sortConfig = DefaultSortConfig()
+	}
 	return Model{
 		keys:             keys,
 		viewMode:         TreeView,
-		sortAscending:    false,
+		sortConfig:       sortConfig,
 		jumpMap:          make(map[rune]int),
 		collapsedNodes:   make(map[string]bool), // Start with all expanded
 		seededCollapse:   make(map[string]bool),
@@ -184,6 +241,38 @@ func (m *Model) GetGroupBy() string {
 	return m.groupBy
 }
 
+// SetShowTodaySection pins a synthetic "Today" section, the notes created or
+// modified today within the current focus, above the tree.
+func (m *Model) SetShowTodaySection(show bool) {
+	m.showTodaySection = show
+}
+
+// SetUntaggedOnly limits the tree to notes without tags; the filter itself is
+// applied by FilterDisplayTreeUntagged.
+func (m *Model) SetUntaggedOnly(only bool) {
+	m.showUntaggedOnly = only
+}
+
+// SetArchivedWorkspaces sets the workspaces `nb workspace archive` has
+// retired, listed in a collapsed section of their own while archives are
+// shown.
+func (m *Model) SetArchivedWorkspaces(archived []service.ArchivedWorkspace) {
+	m.archivedWorkspaces = archived
+}
+
+// SetGrepIncludesTitles makes ApplyGrepFilter also keep notes whose title
+// matches the query, for the "all" search scope.
+func (m *Model) SetGrepIncludesTitles(include bool) {
+	m.grepIncludesTitles = include
+}
+
+// plansEnabled reports whether plans/ gets grove-flow plan handling (status
+// icons, on-hold hiding, plan_ref links). The plans_as_group setting turns it
+// off so plans render like any other nested group.
+func (m *Model) plansEnabled() bool {
+	return m.service == nil || m.service.Config == nil || !m.service.Config.PlansAsGroup
+}
+
 // SetSize sets the dimensions of the view.
 func (m *Model) SetSize(w, h int) {
 	m.width = w
@@ -222,6 +311,35 @@ func (m *Model) SetParentState(
 	m.archiveViewMode = archiveViewMode
 	m.gitFileStatus = gitFileStatus
 	m.setJobs(jobs)
+	m.indexAttachments()
+}
+
+// indexAttachments counts the loaded files of the form
+// "<dir>/attachments/<stem>/<file>" per owning note, "<dir>/<stem>.md".
+// Notes loaded from the daemon index, which has no such files, carry their
+// count in Metadata["Attachments"] instead.
+func (m *Model) indexAttachments() {
+	marker := "/" + service.AttachmentsDirName + "/"
+	m.attachmentCounts = make(map[string]int)
+	for _, item := range m.allItems {
+		if item == nil || item.IsDir {
+			continue
+		}
+		if n, ok := item.Metadata["Attachments"].(int); ok {
+			m.attachmentCounts[item.Path] += n
+			continue
+		}
+		idx := strings.Index(item.Path, marker)
+		if idx < 0 {
+			continue
+		}
+		rest := item.Path[idx+len(marker):]
+		slash := strings.IndexByte(rest, '/')
+		if slash <= 0 {
+			continue
+		}
+		m.attachmentCounts[item.Path[:idx]+"/"+rest[:slash]+".md"]++
+	}
 }
 
 // setJobs stores the flow job map and rebuilds the derived lookup maps used
@@ -330,9 +448,7 @@ func (m *Model) JumpToArtifactsForNote() bool {
 			delete(m.collapsedNodes, dn.NodeID())
 		}
 	}
-	m.BuildDisplayTree()
-	m.FilterDisplayTreeByGitStatus()
-	m.FilterDisplayTree()
+	m.rebuildDisplayTree()
 
 	for i, dn := range m.displayNodes {
 		if dn.Item == nil || !dn.Item.IsDir {
@@ -362,11 +478,15 @@ func (m *Model) CursorToFirstNote() bool {
 	return false
 }
 
-// ToggleViewMode switches between tree and table views.
+// ToggleViewMode cycles tree → table → compact → tree. Entering or leaving
+// compact changes the node list, so callers must rebuild the display tree.
 func (m *Model) ToggleViewMode() {
-	if m.viewMode == TreeView {
+	switch m.viewMode {
+	case TreeView:
 		m.viewMode = TableView
-	} else {
+	case TableView:
+		m.viewMode = CompactView
+	default:
 		m.viewMode = TreeView
 	}
 	m.cursor = 0
@@ -377,14 +497,6 @@ func (m *Model) SetViewMode(mode ViewMode) {
 	m.viewMode = mode
 }
 
-// ToggleSortOrder switches between ascending and descending sort.
-func (m *Model) ToggleSortOrder() {
-	m.sortAscending = !m.sortAscending
-	m.BuildDisplayTree()
-	m.FilterDisplayTreeByGitStatus()
-	m.FilterDisplayTree()
-}
-
 // BumpPriority returns the priority one step more critical (true) or less
 // critical (false) than the given priority. The ladder is:
 //
@@ -419,6 +531,30 @@ func (m *Model) SetColumnVisibility(visibility map[string]bool) {
 	m.columnVisibility = visibility
 }
 
+// DefaultColumnOrder is the table column order used until the user reorders
+// columns in the selector. The name column is always first and not listed.
+var DefaultColumnOrder = []string{"TYPE", "STATUS", "PRIORITY", "TAGS", "CREATED", "MODIFIED", "WORKSPACE", "PATH"}
+
+// SetColumnOrder sets the order in which visible table columns are rendered.
+func (m *Model) SetColumnOrder(order []string) {
+	m.columnOrder = append([]string(nil), order...)
+}
+
+// visibleColumns returns the visible table columns in display order.
+func (m *Model) visibleColumns() []string {
+	order := m.columnOrder
+	if len(order) == 0 {
+		order = DefaultColumnOrder
+	}
+	var cols []string
+	for _, col := range order {
+		if m.columnVisibility[col] {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
 // GetCurrentNode returns the node currently under the cursor.
 func (m *Model) GetCurrentNode() *DisplayNode {
 	if m.cursor >= 0 && m.cursor < len(m.displayNodes) {
@@ -493,6 +629,77 @@ func (m *Model) ClearSelections() {
 	m.selectedGroups = make(map[string]struct{})
 }
 
+// DeselectPaths drops paths from the note selection, leaving the rest of it
+// in place.
+func (m *Model) DeselectPaths(paths []string) {
+	for _, path := range paths {
+		delete(m.selected, path)
+	}
+}
+
+// ClearGroupSelections clears the selected plan groups, keeping the selected
+// notes.
+func (m *Model) ClearGroupSelections() {
+	m.selectedGroups = make(map[string]struct{})
+}
+
+// PruneSelections drops selected notes that are no longer among items, so a
+// selection survives a rebuild for every note that still exists.
+func (m *Model) PruneSelections(items []*tree.Item) {
+	if len(m.selected) == 0 {
+		return
+	}
+	existing := make(map[string]bool, len(items))
+	for _, item := range items {
+		existing[item.Path] = true
+	}
+	for path := range m.selected {
+		if !existing[path] {
+			delete(m.selected, path)
+		}
+	}
+}
+
+// StartVisualMode begins vim-style visual-line selection anchored at the
+// cursor. Until ExitVisualMode, every note between the anchor and the cursor
+// is added to the selection as the cursor moves.
+func (m *Model) StartVisualMode() {
+	m.visualMode = true
+	m.visualAnchor = m.cursor
+	m.extendVisualSelection()
+}
+
+// ExitVisualMode leaves visual-line selection, keeping what was selected.
+func (m *Model) ExitVisualMode() {
+	m.visualMode = false
+}
+
+// InVisualMode reports whether visual-line selection is active.
+func (m *Model) InVisualMode() bool {
+	return m.visualMode
+}
+
+// extendVisualSelection selects every note (file) node between the visual
+// anchor and the cursor. Groups, plans and separators are skipped. Notes
+// passed over stay selected when the cursor moves back.
+func (m *Model) extendVisualSelection() {
+	if !m.visualMode {
+		return
+	}
+	if m.selected == nil {
+		m.selected = make(map[string]struct{})
+	}
+	lo, hi := m.visualAnchor, m.cursor
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	for i := max(lo, 0); i <= hi && i < len(m.displayNodes); i++ {
+		if node := m.displayNodes[i]; node.Item != nil && !node.Item.IsDir {
+			m.selected[node.Item.Path] = struct{}{}
+		}
+	}
+}
+
 // SetCutPaths updates the cut paths for visual indication.
 func (m *Model) SetCutPaths(paths map[string]struct{}) {
 	m.cutPaths = paths
@@ -518,9 +725,7 @@ func (m *Model) ToggleFold() {
 	} else {
 		m.collapsedNodes[nodeID] = true
 	}
-	m.BuildDisplayTree()
-	m.FilterDisplayTreeByGitStatus()
-	m.FilterDisplayTree()
+	m.rebuildDisplayTree()
 }
 
 // initializeChildGroupCollapseState sets the default collapse state for child groups
@@ -610,6 +815,41 @@ func (m *Model) GetCursor() int {
 	return m.cursor
 }
 
+// SetCursor moves the cursor to display index i (clamped to the list),
+// adjusting the scroll so it stays visible.
+func (m *Model) SetCursor(i int) {
+	if i < 0 {
+		i = 0
+	}
+	m.cursor = i
+	m.clampCursor()
+	m.adjustScroll()
+	m.extendVisualSelection()
+}
+
+// MoveCursor moves the cursor by delta rows (negative moves up), clamped to
+// the list.
+func (m *Model) MoveCursor(delta int) {
+	m.SetCursor(m.cursor + delta)
+}
+
+// NodeIndexAtLine maps a line of the rendered view (0 is the first line View
+// returns) to the index of the display node drawn there. It returns -1 for the
+// table header, separators, and lines past the last visible node.
+func (m *Model) NodeIndexAtLine(line int) int {
+	if m.viewMode == TableView {
+		line -= 2 // header row + its bottom border
+	}
+	if line < 0 || line >= m.getViewportHeight() {
+		return -1
+	}
+	idx := m.scrollOffset + line
+	if idx >= len(m.displayNodes) || m.displayNodes[idx].IsSeparator() {
+		return -1
+	}
+	return idx
+}
+
 // GetDisplayNodes returns the current display nodes (for operations that need direct access).
 func (m *Model) GetDisplayNodes() []*DisplayNode {
 	return m.displayNodes
@@ -654,17 +894,36 @@ func (m *Model) clampCursor() {
 
 // GetCounts returns note count and selection counts for display.
 func (m *Model) GetCounts() (noteCount, selectedNotes, selectedPlans int) {
-	// Count notes in display nodes (files, not directories)
-	for _, node := range m.displayNodes {
-		if node.Item != nil && !node.Item.IsDir {
-			noteCount++
-		}
-	}
+	noteCount = m.GetVisibleNoteCount()
 	selectedNotes = len(m.selected)
 	selectedPlans = len(m.selectedGroups)
 	return
 }
 
+// GetVisibleNoteCount returns the number of notes in the display tree, after
+// any search, tag or git filter. Pinned copies in the Today section are not
+// counted again.
+func (m *Model) GetVisibleNoteCount() int {
+	count := 0
+	for _, node := range m.displayNodes {
+		if node.IsNote() && !isPinned(node.Item) {
+			count++
+		}
+	}
+	return count
+}
+
+// GetTotalNoteCount returns the number of notes loaded, before any filter.
+func (m *Model) GetTotalNoteCount() int {
+	count := 0
+	for _, item := range m.allItems {
+		if item != nil && !item.IsDir {
+			count++
+		}
+	}
+	return count
+}
+
 // GetSelected returns the set of selected note paths.
 func (m *Model) GetSelected() map[string]struct{} {
 	return m.selected
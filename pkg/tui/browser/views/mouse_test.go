@@ -0,0 +1,51 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+func TestNodeIndexAtLine(t *testing.T) {
+	note := func(name string) *DisplayNode {
+		return &DisplayNode{Item: &tree.Item{Name: name, Path: "/tmp/" + name, Type: tree.TypeNote}}
+	}
+	m := &Model{
+		displayNodes: []*DisplayNode{note("a"), note("b"), {}, note("c"), note("d"), note("e")},
+		height:       6, // viewport of 3 rows in tree view
+	}
+
+	if got := m.NodeIndexAtLine(0); got != 0 {
+		t.Errorf("line 0 = %d, want 0", got)
+	}
+	if got := m.NodeIndexAtLine(2); got != -1 {
+		t.Errorf("separator line = %d, want -1", got)
+	}
+	if got := m.NodeIndexAtLine(3); got != -1 {
+		t.Errorf("line past the viewport = %d, want -1", got)
+	}
+
+	m.scrollOffset = 3
+	if got := m.NodeIndexAtLine(1); got != 4 {
+		t.Errorf("scrolled line 1 = %d, want 4", got)
+	}
+
+	m.viewMode = TableView
+	m.height = 8
+	m.scrollOffset = 0
+	if got := m.NodeIndexAtLine(1); got != -1 {
+		t.Errorf("table header line = %d, want -1", got)
+	}
+	if got := m.NodeIndexAtLine(2); got != 0 {
+		t.Errorf("first table row = %d, want 0", got)
+	}
+
+	m.SetCursor(99)
+	if m.cursor != len(m.displayNodes)-1 {
+		t.Errorf("SetCursor clamps to %d, got %d", len(m.displayNodes)-1, m.cursor)
+	}
+	m.MoveCursor(-10)
+	if m.cursor != 0 {
+		t.Errorf("MoveCursor clamps to 0, got %d", m.cursor)
+	}
+}
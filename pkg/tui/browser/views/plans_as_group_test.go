@@ -0,0 +1,54 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/grovetools/nb/pkg/service"
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+func TestPlansAsGroupRendersPlansLikeAnyGroup(t *testing.T) {
+	countTypes := func(m *Model) (plans, groups int, planHeading bool) {
+		for _, n := range m.displayNodes {
+			if n.Item == nil {
+				continue
+			}
+			switch n.Item.Type {
+			case tree.TypePlan:
+				plans++
+			case tree.TypeGroup:
+				groups++
+				if n.Item.Name == "plans" {
+					planHeading = true
+				}
+			}
+		}
+		return plans, groups, planHeading
+	}
+	items := []*tree.Item{
+		testNoteItem("plans/my-plan", "01-spec.md", "", nil, nil),
+		testNoteItem("inbox", "idea.md", "", nil, nil),
+	}
+
+	m, _ := newTreeTestModel(t)
+	m.allItems = items
+	m.BuildDisplayTree()
+	if plans, _, _ := countTypes(m); plans != 1 {
+		t.Fatalf("default: got %d plan nodes, want 1", plans)
+	}
+
+	m, _ = newTreeTestModel(t)
+	m.service.Config = &service.Config{PlansAsGroup: true}
+	m.allItems = items
+	m.BuildDisplayTree()
+	plans, groups, planHeading := countTypes(m)
+	if plans != 0 {
+		t.Errorf("plans_as_group: got %d plan nodes, want 0", plans)
+	}
+	if !planHeading || groups < 3 {
+		t.Errorf("plans_as_group: want plans, plans/my-plan and inbox as groups, got %d groups (plans heading %v)", groups, planHeading)
+	}
+	if status := m.GetPlanStatus("demo", "plans/my-plan"); status != "" {
+		t.Errorf("plans_as_group: GetPlanStatus = %q, want empty", status)
+	}
+}
@@ -75,8 +75,8 @@ func TestSortNotesByDate(t *testing.T) {
 		{Path: "newer.md", Priority: "p3", CreatedAt: now},
 	}
 
-	// sortAscending false (newest first); priority must NOT reorder.
-	m := &Model{sortAscending: false}
+	// Default sort (newest first); priority must NOT reorder.
+	m := &Model{sortConfig: DefaultSortConfig()}
 	m.sortNotes(notes)
 
 	if notes[0].Path != "newer.md" {
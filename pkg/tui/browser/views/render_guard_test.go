@@ -0,0 +1,39 @@
+package views
+
+import "testing"
+
+func TestGuardRenderRecoversAndKeepsNodes(t *testing.T) {
+	m := &Model{}
+	var nodes []*DisplayNode
+	m.guardRender("inbox", func() {
+		nodes = append(nodes, &DisplayNode{})
+		panic("unexpected metadata")
+	})
+	m.guardRender("plans", func() {
+		nodes = append(nodes, &DisplayNode{})
+	})
+
+	if got := m.RenderFailures(); got != 1 {
+		t.Errorf("RenderFailures() = %d, want 1", got)
+	}
+	if len(nodes) != 2 {
+		t.Errorf("got %d nodes, want the 2 appended around the panic", len(nodes))
+	}
+}
+
+func TestGuardFlatListEmptiesOnPanic(t *testing.T) {
+	m := &Model{displayNodes: []*DisplayNode{{}, {}}, cursor: 1}
+	m.guardFlatList("compact list", func() {
+		panic("unexpected metadata")
+	})
+
+	if got := m.RenderFailures(); got != 1 {
+		t.Errorf("RenderFailures() = %d, want 1", got)
+	}
+	if len(m.displayNodes) != 0 {
+		t.Errorf("got %d nodes, want the stale list dropped", len(m.displayNodes))
+	}
+	if m.cursor != 0 {
+		t.Errorf("cursor = %d, want 0", m.cursor)
+	}
+}
@@ -3,6 +3,7 @@ package views
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -126,6 +127,40 @@ func TestApplyGrepFilterUsesSearcherResults(t *testing.T) {
 	}
 }
 
+// The "all" search scope keeps notes matched by content or by title.
+func TestApplyGrepFilterIncludesTitlesInAllScope(t *testing.T) {
+	m, _ := newTreeTestModel(t)
+	body := testNoteItem("alpha", "body-hit.md", "", nil, nil)
+	titled := testNoteItem("alpha", "design-notes.md", "", nil, nil)
+	miss := testNoteItem("alpha", "miss.md", "", nil, nil)
+	m.allItems = []*tree.Item{body, titled, miss}
+
+	orig := grepSearcher
+	defer func() { grepSearcher = orig }()
+	grepSearcher = func(query string, dirs []string) ([]string, error) {
+		return []string{body.Path}, nil
+	}
+
+	m.filterValue = "design"
+	m.isGrepping = true
+	if _, err := m.ApplyGrepFilter(); err != nil {
+		t.Fatalf("ApplyGrepFilter: %v", err)
+	}
+	if got := visibleNotePaths(m); !reflect.DeepEqual(got, []string{body.Path}) {
+		t.Errorf("body scope: got notes %v, want only %v", got, body.Path)
+	}
+
+	m.SetGrepIncludesTitles(true)
+	if _, err := m.ApplyGrepFilter(); err != nil {
+		t.Fatalf("ApplyGrepFilter: %v", err)
+	}
+	got := visibleNotePaths(m)
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, []string{body.Path, titled.Path}) {
+		t.Errorf("all scope: got notes %v, want %v", got, []string{body.Path, titled.Path})
+	}
+}
+
 // C1: a searcher error must surface instead of silently pruning everything.
 func TestApplyGrepFilterSurfacesSearcherError(t *testing.T) {
 	m, _ := newTreeTestModel(t)
@@ -233,3 +268,48 @@ func TestGetNoteStatusTodoCells(t *testing.T) {
 		})
 	}
 }
+
+func TestChecklistBadge(t *testing.T) {
+	cases := []struct {
+		name string
+		note *models.Note
+		want string
+	}{
+		{"partial progress", &models.Note{TodoOpen: 3, TodoDone: 2}, "[2/5]"},
+		{"all done", &models.Note{TodoDone: 4}, "[4/4]"},
+		{"cancelled excluded", &models.Note{TodoOpen: 1, TodoDone: 1, TodoCancelled: 3}, "[1/2]"},
+		{"no checkboxes", &models.Note{}, ""},
+		{"nil note", nil, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := checklistBadge(tc.note); got != tc.want {
+				t.Errorf("checklistBadge = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// Sorting and folding rebuild the tree; every active filter, the untagged
+// one included, must be reapplied to the rebuilt tree.
+func TestRebuildKeepsUntaggedFilter(t *testing.T) {
+	m, _ := newTreeTestModel(t)
+	tagged := testNoteItem("alpha", "tagged.md", "", nil, []string{"infra"})
+	untagged := testNoteItem("alpha", "untagged.md", "", nil, nil)
+	m.allItems = []*tree.Item{tagged, untagged}
+	m.showUntaggedOnly = true
+	want := []string{untagged.Path}
+
+	m.rebuildDisplayTree()
+	if got := visibleNotePaths(m); !reflect.DeepEqual(got, want) {
+		t.Fatalf("filtered tree: got notes %v, want %v", got, want)
+	}
+	m.SetSortConfig(SortConfig{Field: SortByTitle, Ascending: true})
+	if got := visibleNotePaths(m); !reflect.DeepEqual(got, want) {
+		t.Errorf("after sorting: got notes %v, want %v", got, want)
+	}
+	m.openAllFolds()
+	if got := visibleNotePaths(m); !reflect.DeepEqual(got, want) {
+		t.Errorf("after opening folds: got notes %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// Note sort fields, in the order the Sort key cycles through them.
+const (
+	SortByCreated   = "created"
+	SortByModified  = "modified"
+	SortByTitle     = "title"
+	SortByWorkspace = "workspace"
+)
+
+// SortFields lists the accepted SortConfig fields.
+var SortFields = []string{SortByCreated, SortByModified, SortByTitle, SortByWorkspace}
+
+// SortConfig is the order notes are listed in within their group.
+type SortConfig struct {
+	Field     string `json:"field"`
+	Ascending bool   `json:"ascending"`
+}
+
+// DefaultSortConfig lists the newest notes first.
+func DefaultSortConfig() SortConfig {
+	return SortConfig{Field: SortByCreated}
+}
+
+// ParseSortConfig reads a --sort value: a field, optionally followed by
+// ":asc" or ":desc". Without a direction the field's natural one is used:
+// newest first for dates, A to Z otherwise.
+func ParseSortConfig(s string) (SortConfig, error) {
+	field, dir, hasDir := strings.Cut(strings.ToLower(strings.TrimSpace(s)), ":")
+	if !isSortField(field) {
+		return SortConfig{}, fmt.Errorf("invalid sort field %q (expected one of %s)", field, strings.Join(SortFields, ", "))
+	}
+	cfg := naturalSortConfig(field)
+	if hasDir {
+		switch dir {
+		case "asc":
+			cfg.Ascending = true
+		case "desc":
+			cfg.Ascending = false
+		default:
+			return SortConfig{}, fmt.Errorf("invalid sort direction %q (expected asc or desc)", dir)
+		}
+	}
+	return cfg, nil
+}
+
+// Next returns the next field in SortFields, in its natural direction.
+func (c SortConfig) Next() SortConfig {
+	for i, field := range SortFields {
+		if field == c.Field {
+			return naturalSortConfig(SortFields[(i+1)%len(SortFields)])
+		}
+	}
+	return DefaultSortConfig()
+}
+
+// String renders c for the status bar, e.g. "modified↓".
+func (c SortConfig) String() string {
+	if c.Ascending {
+		return c.Field + "↑"
+	}
+	return c.Field + "↓"
+}
+
+// naturalSortConfig sorts dates newest first and names A to Z.
+func naturalSortConfig(field string) SortConfig {
+	return SortConfig{Field: field, Ascending: field == SortByTitle || field == SortByWorkspace}
+}
+
+func isSortField(field string) bool {
+	for _, f := range SortFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// SetSortConfig sets the note order, falling back to DefaultSortConfig for an
+// unknown field, and rebuilds the tree.
+func (m *Model) SetSortConfig(c SortConfig) {
+	if !isSortField(c.Field) {
+		c = DefaultSortConfig()
+	}
+	m.sortConfig = c
+	m.rebuildDisplayTree()
+}
+
+// GetSortConfig returns the note order.
+func (m *Model) GetSortConfig() SortConfig {
+	return m.sortConfig
+}
+
+// CycleSortField switches to the next sort field.
+func (m *Model) CycleSortField() {
+	m.SetSortConfig(m.sortConfig.Next())
+}
+
+// ToggleSortOrder reverses the sort direction.
+func (m *Model) ToggleSortOrder() {
+	c := m.sortConfig
+	c.Ascending = !c.Ascending
+	m.SetSortConfig(c)
+}
+
+// sortNotes sorts a slice of notes in place by m.sortConfig. Priority no
+// longer affects flat ordering — it is surfaced exclusively through the
+// group-by-priority axis (partitionByPriority, which orders buckets
+// most-critical-first) and the per-priority filename coloring.
+//
+// This is the single canonical note comparator; all tree-building sort sites
+// route through it so sort behavior stays consistent. Ties keep their
+// creation order, newest first.
+func (m *Model) sortNotes(notes []*models.Note) {
+	compare := func(a, b *models.Note) int {
+		switch m.sortConfig.Field {
+		case SortByModified:
+			return a.ModifiedAt.Compare(b.ModifiedAt)
+		case SortByTitle:
+			return strings.Compare(strings.ToLower(noteSortTitle(a)), strings.ToLower(noteSortTitle(b)))
+		case SortByWorkspace:
+			return strings.Compare(strings.ToLower(a.Workspace), strings.ToLower(b.Workspace))
+		default:
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		c := compare(notes[i], notes[j])
+		if c == 0 {
+			return notes[i].CreatedAt.After(notes[j].CreatedAt)
+		}
+		if m.sortConfig.Ascending {
+			return c < 0
+		}
+		return c > 0
+	})
+}
+
+// noteSortTitle is the title a note sorts by: its frontmatter title, else
+// its filename.
+func noteSortTitle(n *models.Note) string {
+	if n.FrontmatterTitle != "" {
+		return n.FrontmatterTitle
+	}
+	return n.Title
+}
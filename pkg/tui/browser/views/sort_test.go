@@ -0,0 +1,80 @@
+package views
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+func TestParseSortConfig(t *testing.T) {
+	cases := map[string]SortConfig{
+		"created":     {Field: SortByCreated},
+		"Modified":    {Field: SortByModified},
+		"title":       {Field: SortByTitle, Ascending: true},
+		"title:desc":  {Field: SortByTitle},
+		"created:asc": {Field: SortByCreated, Ascending: true},
+	}
+	for in, want := range cases {
+		got, err := ParseSortConfig(in)
+		if err != nil {
+			t.Errorf("ParseSortConfig(%q): %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseSortConfig(%q) = %+v, want %+v", in, got, want)
+		}
+	}
+	for _, in := range []string{"", "size", "title:up"} {
+		if _, err := ParseSortConfig(in); err == nil {
+			t.Errorf("ParseSortConfig(%q) should fail", in)
+		}
+	}
+}
+
+func TestSortConfigNextCycles(t *testing.T) {
+	c := DefaultSortConfig()
+	var seen []string
+	for range SortFields {
+		seen = append(seen, c.String())
+		c = c.Next()
+	}
+	if want := []string{"created↓", "modified↓", "title↑", "workspace↑"}; !reflect.DeepEqual(seen, want) {
+		t.Errorf("cycle = %v, want %v", seen, want)
+	}
+	if c != DefaultSortConfig() {
+		t.Errorf("cycle should wrap to %v, got %v", DefaultSortConfig(), c)
+	}
+}
+
+func TestSortNotesByField(t *testing.T) {
+	now := time.Now()
+	a := &models.Note{Path: "a", Title: "b.md", FrontmatterTitle: "Alpha", Workspace: "zeta", CreatedAt: now.Add(-2 * time.Hour), ModifiedAt: now}
+	b := &models.Note{Path: "b", Title: "beta.md", Workspace: "eta", CreatedAt: now.Add(-time.Hour), ModifiedAt: now.Add(-3 * time.Hour)}
+	c := &models.Note{Path: "c", Title: "Gamma.md", Workspace: "eta", CreatedAt: now, ModifiedAt: now.Add(-time.Hour)}
+
+	cases := []struct {
+		sort SortConfig
+		want []string
+	}{
+		{DefaultSortConfig(), []string{"c", "b", "a"}},
+		{SortConfig{Field: SortByCreated, Ascending: true}, []string{"a", "b", "c"}},
+		{SortConfig{Field: SortByModified}, []string{"a", "c", "b"}},
+		{SortConfig{Field: SortByTitle, Ascending: true}, []string{"a", "b", "c"}},
+		// Ties on workspace keep the newest note first.
+		{SortConfig{Field: SortByWorkspace, Ascending: true}, []string{"c", "b", "a"}},
+	}
+	for _, tc := range cases {
+		m := &Model{sortConfig: tc.sort}
+		notes := []*models.Note{b, a, c}
+		m.sortNotes(notes)
+		var got []string
+		for _, n := range notes {
+			got = append(got, n.Path)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v: got %v, want %v", tc.sort, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package views
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	workspace "github.com/grovetools/core/pkg/workspace"
+
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+// The pinned "Today" section lists today's in-scope notes ahead of the tree,
+// without taking them out of their groups, and folds like any group.
+func TestTodaySectionPinsTodaysNotes(t *testing.T) {
+	m, _ := newTreeTestModel(t)
+	m.showTodaySection = true
+
+	fresh := testNoteItem("inbox", "fresh.md", "", nil, nil)
+	old := testNoteItem("inbox", "old.md", "", nil, nil)
+	old.Metadata["Created"] = time.Now().AddDate(0, 0, -3)
+	old.ModTime = time.Now().AddDate(0, 0, -3)
+	touched := testNoteItem("inbox", "touched.md", "", nil, nil)
+	touched.Metadata["Created"] = time.Now().AddDate(0, -1, 0)
+	touched.ModTime = time.Now()
+	outside := testNoteItem("inbox", "elsewhere.md", "", nil, nil)
+	outside.Metadata["Workspace"] = "other"
+	m.workspaces = append(m.workspaces, &workspace.WorkspaceNode{Name: "other", Path: "/tmp/other"})
+	m.allItems = []*tree.Item{fresh, old, touched, outside}
+
+	m.BuildDisplayTree()
+	if len(m.displayNodes) == 0 || m.displayNodes[0].Item.Path != todaySectionPath {
+		t.Fatalf("first node should be the Today section, got %+v", m.displayNodes)
+	}
+	var pinned []string
+	for _, n := range m.displayNodes[1:] {
+		if !n.IsNote() || !isPinned(n.Item) {
+			break
+		}
+		pinned = append(pinned, n.Item.Path)
+	}
+	// touched was modified most recently (fresh has no mtime), and the
+	// out-of-focus note is left out.
+	if want := []string{touched.Path, fresh.Path}; !reflect.DeepEqual(pinned, want) {
+		t.Errorf("Today section = %v, want %v", pinned, want)
+	}
+	if got := len(visibleNotePaths(m)); got != 5 {
+		t.Errorf("got %d note rows, want the 2 pinned plus the 3 in-scope notes in their group", got)
+	}
+
+	m.collapsedNodes[m.displayNodes[0].NodeID()] = true
+	m.BuildDisplayTree()
+	if m.displayNodes[0].Item.Path != todaySectionPath || isPinned(m.displayNodes[1].Item) {
+		t.Errorf("folding the Today section should hide only its rows")
+	}
+
+	m.showTodaySection = false
+	m.BuildDisplayTree()
+	if m.displayNodes[0].Item.Path == todaySectionPath {
+		t.Errorf("Today section shown while disabled")
+	}
+}
@@ -0,0 +1,47 @@
+package views
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+// Standalone workspaces are listed under "ungrouped" in the global view, and
+// their notes render there with their groups as they would for any other
+// workspace.
+func TestUngroupedSectionRendersNotes(t *testing.T) {
+	m, ws := newTreeTestModel(t)
+	m.focusedWorkspace = nil
+	inbox := testNoteItem("inbox", "a.md", "", nil, nil)
+	spike := testNoteItem("research/spikes", "b.md", "", nil, nil)
+	m.allItems = []*tree.Item{inbox, spike}
+
+	m.BuildDisplayTree()
+	var ungrouped, wsNode *DisplayNode
+	for _, n := range m.displayNodes {
+		switch {
+		case n.Item.Path == "ungrouped":
+			ungrouped = n
+		case n.Item.Path == ws.Path:
+			wsNode = n
+		}
+	}
+	if ungrouped == nil || wsNode == nil {
+		t.Fatalf("expected the ungrouped section and its workspace, got %+v", m.displayNodes)
+	}
+	if want := []string{inbox.Path, spike.Path}; !reflect.DeepEqual(visibleNotePaths(m), want) {
+		t.Errorf("notes = %v, want %v", visibleNotePaths(m), want)
+	}
+	for _, n := range m.displayNodes {
+		if n.IsNote() && n.Depth <= wsNode.Depth+1 {
+			t.Errorf("note %s at depth %d should sit in a group below the workspace (depth %d)", n.Item.Path, n.Depth, wsNode.Depth)
+		}
+	}
+
+	m.collapsedNodes[wsNode.NodeID()] = true
+	m.BuildDisplayTree()
+	if got := visibleNotePaths(m); len(got) != 0 {
+		t.Errorf("collapsed workspace still shows notes %v", got)
+	}
+}
@@ -1,10 +1,10 @@
 package views
 
 import (
-	"errors"
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"runtime/debug"
 	"sort"
 	"strings"
 	"time"
@@ -17,6 +17,7 @@ import (
 	"github.com/grovetools/core/util/pathutil"
 
 	"github.com/grovetools/nb/pkg/models"
+	"github.com/grovetools/nb/pkg/service"
 	"github.com/grovetools/nb/pkg/tree"
 )
 
@@ -67,6 +68,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 		// Get the current buffer for checking fold sequences
 		buffer := m.sequence.Buffer()
+		prevCursor := m.cursor
 
 		switch {
 		case key.Matches(msg, m.keys.Up):
@@ -174,6 +176,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			// Clear all selections
 			m.selected = make(map[string]struct{})
 			m.selectedGroups = make(map[string]struct{})
+			m.visualMode = false
 		default:
 			// Clear sequence buffer for keys that aren't part of sequences
 			// unless we're in the middle of a potential sequence
@@ -181,6 +184,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				m.sequence.Clear()
 			}
 		}
+		// In visual-line mode, movement selects the notes passed over.
+		if m.cursor != prevCursor {
+			m.extendVisualSelection()
+		}
 	}
 	return m, nil
 }
@@ -211,38 +218,64 @@ func priorityRank(priority string) string {
 	return priority
 }
 
-// sortNotes sorts a slice of notes in place by creation time, honoring
-// m.sortAscending. Priority no longer affects flat ordering — it is surfaced
-// exclusively through the group-by-priority axis (partitionByPriority, which
-// orders buckets most-critical-first) and the per-priority filename coloring.
-//
-// This is the single canonical note comparator; all tree-building sort sites
-// route through it so sort behavior stays consistent.
-func (m *Model) sortNotes(notes []*models.Note) {
-	sort.SliceStable(notes, func(i, j int) bool {
-		if m.sortAscending {
-			return notes[i].CreatedAt.Before(notes[j].CreatedAt)
-		}
-		return notes[i].CreatedAt.After(notes[j].CreatedAt)
-	})
+// guardRender runs one section of the tree build. A panic in it, e.g. from
+// note metadata of an unexpected type, is logged and counted instead of
+// taking down the TUI; the nodes appended before it are kept. It reports
+// whether the section rendered without a panic.
+func (m *Model) guardRender(section string, render func()) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+			m.renderFailures++
+			if m.service != nil && m.service.Logger != nil {
+				m.service.Logger.WithField("section", section).Errorf("Recovered from panic while building the note tree: %v\n%s", r, debug.Stack())
+			}
+		}
+	}()
+	render()
+	return true
+}
+
+// guardFlatList builds one of the flat note lists (or the tag-filtered tree)
+// under guardRender. Such a build only replaces displayNodes once complete,
+// so a failed one empties it rather than leaving the previous view in place.
+func (m *Model) guardFlatList(section string, build func()) {
+	if !m.guardRender(section, build) {
+		m.displayNodes = nil
+		m.jumpMap = make(map[rune]int)
+		m.clampCursor()
+	}
+}
+
+// RenderFailures returns the number of sections the last BuildDisplayTree
+// left out after recovering from a panic.
+func (m *Model) RenderFailures() int {
+	return m.renderFailures
 }
 
 // BuildDisplayTree constructs the hierarchical list of nodes for rendering.
 func (m *Model) BuildDisplayTree() { //nolint:gocyclo
+	m.renderFailures = 0
 	if m.recentNotesMode {
-		m.buildRecentNotesList()
+		m.guardFlatList("recent notes", m.buildRecentNotesList)
 		m.ApplyLinks()
 		return
 	}
 
 	if m.archiveViewMode {
-		m.buildArchiveNotesList()
+		m.guardFlatList("archived notes", m.buildArchiveNotesList)
+		m.ApplyLinks()
+		return
+	}
+
+	if m.viewMode == CompactView && !m.ecosystemPickerMode {
+		m.guardFlatList("compact list", m.buildCompactNotesList)
 		m.ApplyLinks()
 		return
 	}
 
 	if m.isFilteringByTag && m.selectedTag != "" {
-		m.buildTagFilteredTree()
+		m.guardFlatList("tagged notes", m.buildTagFilteredTree)
 		m.ApplyLinks()
 		return
 	}
@@ -251,7 +284,7 @@ func (m *Model) BuildDisplayTree() { //nolint:gocyclo
 	var workspacesToShow []*workspace.WorkspaceNode
 
 	// Check if we should ignore collapsed state (when searching or filtering by git status)
-	hasSearchFilter := (m.filterValue != "" && !m.isGrepping) || m.showGitModifiedOnly
+	hasSearchFilter := (m.filterValue != "" && !m.isGrepping) || m.showGitModifiedOnly || m.showUntaggedOnly
 
 	// 1. Filter workspaces based on focus mode
 	var showUngroupedSection bool
@@ -349,7 +382,8 @@ func (m *Model) BuildDisplayTree() { //nolint:gocyclo
 		}
 	}
 
-	// Group notes by workspace path, then by group (directory)
+	// Group notes by workspace, then by group (directory), through the
+	// service's WorkspaceTree, the same model nb list --tree prints.
 	notesByWorkspace := make(map[string]map[string][]*models.Note)
 
 	// Create a map of workspace names to their paths for relative path calculation
@@ -358,13 +392,23 @@ func (m *Model) BuildDisplayTree() { //nolint:gocyclo
 		workspacePathMap[ws.Name] = ws.Path
 	}
 
-	for _, note := range allNotes {
+	notesTree := service.NewWorkspaceTree(allNotes)
+	for _, ws := range notesTree.Workspaces {
 		// Normalize workspace name to lowercase for case-insensitive matching
-		wsKey := strings.ToLower(note.Workspace)
+		wsKey := strings.ToLower(ws.Name)
 		if _, ok := notesByWorkspace[wsKey]; !ok {
 			notesByWorkspace[wsKey] = make(map[string][]*models.Note)
 		}
-		notesByWorkspace[wsKey][note.Group] = append(notesByWorkspace[wsKey][note.Group], note)
+		for group, notes := range notesTree.NotesByGroup(ws.Name) {
+			notesByWorkspace[wsKey][group] = append(notesByWorkspace[wsKey][group], notes...)
+		}
+	}
+
+	// Pin the "Today" section above the workspaces
+	if m.showTodaySection && !m.ecosystemPickerMode {
+		m.guardRender("today", func() {
+			m.addTodaySection(&nodes, allNotes, workspacePathMap, hasSearchFilter)
+		})
 	}
 
 	// 3. Build the display node list and jump map
@@ -440,300 +484,7 @@ func (m *Model) BuildDisplayTree() { //nolint:gocyclo
 		}
 
 		if noteGroups, ok := notesByWorkspace[wsKey]; ok {
-			// Separate regular groups, archived subgroups, and artifact subgroups
-			// archiveSubgroups maps "parent" -> "child" -> notes
-			// e.g., "plans" -> "test-plan" -> [notes in plans/.archive/test-plan]
-			// artifactSubgroups maps "parent" -> "jobName" -> notes
-			// e.g., "plans/binary-test" -> "impl-foo-abc123" -> [briefing/log/etc files]
-			// jobName == "" means files directly under .artifacts (no per-job subdir).
-			var regularGroups []string
-			var rootNotes []*models.Note
-			planGroups := make(map[string][]*models.Note)
-			holdPlanGroups := make(map[string][]*models.Note)
-			archiveSubgroups := make(map[string]map[string][]*models.Note)
-			closedSubgroups := make(map[string]map[string][]*models.Note)
-			artifactSubgroups := make(map[string]map[string][]*models.Note)
-
-			for name, notes := range noteGroups {
-				// Handle root notes (e.g. grove.toml)
-				if name == "" {
-					rootNotes = append(rootNotes, notes...)
-					continue
-				}
-
-				// Check if this is an archived or closed group - skip if archives are hidden
-				isArchived := strings.Contains(name, "/.archive")
-				isClosed := strings.Contains(name, "/.closed")
-				if (isArchived || isClosed) && !m.showArchives {
-					continue
-				}
-
-				// Check if this is an artifact group - skip if artifacts are hidden
-				isArtifact := strings.Contains(name, "/.artifacts")
-				if isArtifact && !m.showArtifacts {
-					continue
-				}
-
-				// Skip double-nested archives (e.g., plans/.archive/foo/.archive/bar)
-				// Count occurrences of "/.archive" in the path
-				archiveCount := strings.Count(name, "/.archive")
-				if archiveCount > 1 {
-					continue
-				}
-
-				// Skip double-nested closed (e.g., issues/.closed/foo/.closed/bar)
-				closedCount := strings.Count(name, "/.closed")
-				if closedCount > 1 {
-					continue
-				}
-
-				// Check if this matches pattern "<parent>/.archive/<child>"
-				if strings.Contains(name, "/.archive/") {
-					parts := strings.Split(name, "/.archive/")
-					if len(parts) == 2 {
-						parent := parts[0]
-						child := parts[1]
-						if archiveSubgroups[parent] == nil {
-							archiveSubgroups[parent] = make(map[string][]*models.Note)
-						}
-						archiveSubgroups[parent][child] = notes
-						continue
-					}
-				}
-
-				// Check if this matches pattern "<parent>/.archive" (notes directly in .archive folder)
-				if strings.HasSuffix(name, "/.archive") {
-					parent := strings.TrimSuffix(name, "/.archive")
-					if archiveSubgroups[parent] == nil {
-						archiveSubgroups[parent] = make(map[string][]*models.Note)
-					}
-					// Use empty string as key to indicate notes directly in .archive
-					archiveSubgroups[parent][""] = notes
-					continue
-				}
-
-				// Check if this matches pattern "<parent>/.closed/<child>"
-				if strings.Contains(name, "/.closed/") {
-					parts := strings.Split(name, "/.closed/")
-					if len(parts) == 2 {
-						parent := parts[0]
-						child := parts[1]
-						if closedSubgroups[parent] == nil {
-							closedSubgroups[parent] = make(map[string][]*models.Note)
-						}
-						closedSubgroups[parent][child] = notes
-						continue
-					}
-				}
-
-				// Check if this matches pattern "<parent>/.closed" (notes directly in .closed folder)
-				if strings.HasSuffix(name, "/.closed") {
-					parent := strings.TrimSuffix(name, "/.closed")
-					if closedSubgroups[parent] == nil {
-						closedSubgroups[parent] = make(map[string][]*models.Note)
-					}
-					// Use empty string as key to indicate notes directly in .closed
-					closedSubgroups[parent][""] = notes
-					continue
-				}
-
-				// Check if this matches pattern "<parent>/.artifacts/<jobName>"
-				// (each job dir under .artifacts becomes its own subgroup).
-				if idx := strings.Index(name, "/.artifacts/"); idx >= 0 {
-					parent := name[:idx]
-					jobName := name[idx+len("/.artifacts/"):]
-					if artifactSubgroups[parent] == nil {
-						artifactSubgroups[parent] = make(map[string][]*models.Note)
-					}
-					artifactSubgroups[parent][jobName] = notes
-					continue
-				}
-
-				// Check if this matches pattern "<parent>/.artifacts" (files directly in .artifacts folder)
-				if strings.HasSuffix(name, "/.artifacts") {
-					parent := strings.TrimSuffix(name, "/.artifacts")
-					if artifactSubgroups[parent] == nil {
-						artifactSubgroups[parent] = make(map[string][]*models.Note)
-					}
-					artifactSubgroups[parent][""] = notes
-					continue
-				}
-
-				// Handle plans grouping
-				if strings.HasPrefix(name, "plans/") {
-					planName := strings.TrimPrefix(name, "plans/")
-					// Check plan status to separate on-hold plans
-					planStatus := m.GetPlanStatus(ws.Name, name)
-					if planStatus == "hold" { //nolint:goconst
-						if !m.showOnHold {
-							// Skip on-hold plans unless showOnHold is true
-							continue
-						}
-						// Add to hold plans group
-						holdPlanGroups[planName] = notes
-					} else {
-						// Add to regular plans group
-						planGroups[planName] = notes
-					}
-				} else {
-					regularGroups = append(regularGroups, name)
-				}
-			}
-			ensureParentGroup := func(parent string) {
-				if parent == "plans" || parent == "" { //nolint:goconst
-					return
-				}
-				if strings.HasPrefix(parent, "plans/") {
-					planName := strings.TrimPrefix(parent, "plans/")
-					if planGroups[planName] == nil && holdPlanGroups[planName] == nil {
-						planStatus := m.GetPlanStatus(ws.Name, parent)
-						if planStatus == "hold" {
-							if m.showOnHold {
-								holdPlanGroups[planName] = []*models.Note{}
-							}
-						} else {
-							planGroups[planName] = []*models.Note{}
-						}
-					}
-				} else {
-					found := false
-					for _, rg := range regularGroups {
-						if rg == parent {
-							found = true
-							break
-						}
-					}
-					if !found {
-						regularGroups = append(regularGroups, parent)
-					}
-				}
-			}
-
-			for parent := range archiveSubgroups {
-				ensureParentGroup(parent)
-			}
-			for parent := range closedSubgroups {
-				ensureParentGroup(parent)
-			}
-			for parent := range artifactSubgroups {
-				ensureParentGroup(parent)
-			}
-
-			// Sort groups using SortOrder from NoteTypes registry
-			// Groups with lower SortOrder appear first, then alphabetically by name
-			sort.SliceStable(regularGroups, func(i, j int) bool {
-				nameA := regularGroups[i]
-				nameB := regularGroups[j]
-
-				// Get SortOrder from registry, default to 100 if not found or if SortOrder is 0
-				sortOrderA := 100
-				sortOrderB := 100
-				if typeConfig, ok := m.service.NoteTypes[nameA]; ok && typeConfig.SortOrder != 0 {
-					sortOrderA = typeConfig.SortOrder
-				}
-				if typeConfig, ok := m.service.NoteTypes[nameB]; ok && typeConfig.SortOrder != 0 {
-					sortOrderB = typeConfig.SortOrder
-				}
-
-				// Sort by SortOrder first, then alphabetically
-				if sortOrderA != sortOrderB {
-					return sortOrderA < sortOrderB
-				}
-				return nameA < nameB
-			})
-
-			// Check if we have plans to add a "plans" parent group
-			hasPlans := len(planGroups) > 0 || len(archiveSubgroups["plans"]) > 0
-			hasHoldPlans := len(holdPlanGroups) > 0
-
-			// Render groups in the sorted order
-			notesRootDir, err := m.service.GetNotebookLocator().GetNotesDir(ws, "")
-			if err == nil { // Proceed only if we can get the notes root directory
-				// Determine where to insert plans based on SortOrder
-				plansSortOrder := 100
-				if typeConfig, ok := m.service.NoteTypes["plans"]; ok && typeConfig.SortOrder != 0 {
-					plansSortOrder = typeConfig.SortOrder
-				}
-
-				// Split regular groups into those that come before and after plans
-				var groupsBeforePlans []string
-				var groupsAfterPlans []string
-				for _, groupName := range regularGroups {
-					groupSortOrder := 100
-					if typeConfig, ok := m.service.NoteTypes[groupName]; ok && typeConfig.SortOrder != 0 {
-						groupSortOrder = typeConfig.SortOrder
-					}
-					if groupSortOrder < plansSortOrder {
-						groupsBeforePlans = append(groupsBeforePlans, groupName)
-					} else {
-						groupsAfterPlans = append(groupsAfterPlans, groupName)
-					}
-				}
-
-				// Render groups before plans
-				if len(groupsBeforePlans) > 0 {
-					rootGroupNode := buildGroupTree(noteGroups, groupsBeforePlans)
-					hasFollowingTopLevelSiblings := hasPlans || len(groupsAfterPlans) > 0 || hasHoldPlans || len(rootNotes) > 0
-					config := treeRenderConfig{
-						itemType:            tree.TypeGroup,
-						groupMetadataPrefix: "",
-						nameUsesPrefix:      false,
-						includeArchives:     true,
-						includeClosed:       true,
-						includeArtifacts:    true,
-					}
-					m.renderTree(&nodes, ws, rootGroupNode, ws.TreePrefix+"  ", ws.Depth+1, hasSearchFilter, workspacePathMap, notesRootDir, config, hasFollowingTopLevelSiblings, archiveSubgroups, closedSubgroups, artifactSubgroups)
-				}
-
-				// Render Plans Group in its sorted position
-				if hasPlans {
-					hasGroupsAfter := len(groupsAfterPlans) > 0 || hasHoldPlans || len(rootNotes) > 0
-					m.addPlansGroup(&nodes, ws, planGroups, archiveSubgroups, artifactSubgroups, hasSearchFilter, workspacePathMap, hasGroupsAfter)
-				}
-
-				// Render groups after plans
-				if len(groupsAfterPlans) > 0 {
-					rootGroupNode := buildGroupTree(noteGroups, groupsAfterPlans)
-					hasFollowingTopLevelSiblings := hasHoldPlans || len(rootNotes) > 0
-					config := treeRenderConfig{
-						itemType:            tree.TypeGroup,
-						groupMetadataPrefix: "",
-						nameUsesPrefix:      false,
-						includeArchives:     true,
-						includeClosed:       true,
-						includeArtifacts:    true,
-					}
-					m.renderTree(&nodes, ws, rootGroupNode, ws.TreePrefix+"  ", ws.Depth+1, hasSearchFilter, workspacePathMap, notesRootDir, config, hasFollowingTopLevelSiblings, archiveSubgroups, closedSubgroups, artifactSubgroups)
-				}
-
-				// Render On-Hold Plans (always last before root notes)
-				if hasHoldPlans {
-					m.addHoldPlansGroup(&nodes, ws, holdPlanGroups, hasSearchFilter, workspacePathMap, len(rootNotes) > 0)
-				}
-
-				// Render root notes (e.g. grove.toml) directly under workspace
-				if len(rootNotes) > 0 {
-					m.sortNotes(rootNotes)
-					for ni, note := range rootNotes {
-						isLastRootNote := ni == len(rootNotes)-1
-						var notePrefix strings.Builder
-						noteIndent := strings.ReplaceAll(ws.TreePrefix, "├ ", "│ ")
-						noteIndent = strings.ReplaceAll(noteIndent, "└ ", "  ")
-						notePrefix.WriteString(noteIndent)
-						if isLastRootNote {
-							notePrefix.WriteString("└ ")
-						} else {
-							notePrefix.WriteString("├ ")
-						}
-						nodes = append(nodes, &DisplayNode{
-							Item:         noteToItem(note),
-							Prefix:       notePrefix.String(),
-							Depth:        ws.Depth + 1,
-							RelativePath: calculateRelativePath(note, workspacePathMap, m.focusedWorkspace),
-						})
-					}
-				}
-			}
+			m.renderWorkspaceNotes(&nodes, ws, noteGroups, hasSearchFilter, workspacePathMap)
 		}
 
 		// Mark that we need a separator before child workspaces
@@ -752,6 +503,11 @@ func (m *Model) BuildDisplayTree() { //nolint:gocyclo
 		m.addUngroupedSection(&nodes, ungroupedWorkspaces, notesByWorkspace, hasSearchFilter, workspacePathMap)
 	}
 
+	// 5. Add the archived workspaces section
+	if m.showArchives && !m.ecosystemPickerMode && !hasSearchFilter {
+		m.addArchivedWorkspacesSection(&nodes)
+	}
+
 	m.displayNodes = nodes
 	m.ApplyLinks()
 
@@ -795,11 +551,8 @@ func (m *Model) buildTagFilteredTree() {
 			continue
 		}
 
-		for _, tag := range note.Tags {
-			if strings.EqualFold(tag, tagFilter) {
-				filteredNotes = append(filteredNotes, note)
-				break
-			}
+		if noteHasTag(note, tagFilter) {
+			filteredNotes = append(filteredNotes, note)
 		}
 	}
 
@@ -1440,7 +1193,9 @@ func (m *Model) addPlansGroup(nodes *[]*DisplayNode, ws *workspace.WorkspaceNode
 			includeArchives:     false,
 			includeClosed:       false,
 		}
-		m.renderTree(nodes, ws, planTree, plansPrefix.String(), ws.Depth+2, hasSearchFilter, workspacePathMap, plansPath, config, hasPlansArchive, nil, nil, artifactSubgroups)
+		m.guardRender("plans", func() {
+			m.renderTree(nodes, ws, planTree, plansPrefix.String(), ws.Depth+2, hasSearchFilter, workspacePathMap, plansPath, config, hasPlansArchive, nil, nil, artifactSubgroups)
+		})
 
 		// Add .archive parent group if there are archived children
 		if hasPlansArchive {
@@ -1512,7 +1267,9 @@ func (m *Model) addPlansArchiveGroup(nodes *[]*DisplayNode, ws *workspace.Worksp
 			includeArchives:     false,
 			includeClosed:       false,
 		}
-		m.renderTree(nodes, ws, archivedPlanTree, archivePrefix.String(), ws.Depth+2, hasSearchFilter, workspacePathMap, archivePath, config, false, nil, nil, nil)
+		m.guardRender("archived plans", func() {
+			m.renderTree(nodes, ws, archivedPlanTree, archivePrefix.String(), ws.Depth+2, hasSearchFilter, workspacePathMap, archivePath, config, false, nil, nil, nil)
+		})
 	}
 }
 
@@ -1634,6 +1391,313 @@ func (m *Model) addHoldPlansGroup(nodes *[]*DisplayNode, ws *workspace.Workspace
 	}
 }
 
+// renderWorkspaceNotes appends ws's note groups below its workspace node:
+// regular groups and plans in SortOrder, with their archived, closed and
+// artifact subgroups, then on-hold plans and the notes at the notebook root.
+// Prefixes and depths follow ws.TreePrefix and ws.Depth.
+func (m *Model) renderWorkspaceNotes(nodes *[]*DisplayNode, ws *workspace.WorkspaceNode, noteGroups map[string][]*models.Note, hasSearchFilter bool, workspacePathMap map[string]string) {
+	// Separate regular groups, archived subgroups, and artifact subgroups
+	// archiveSubgroups maps "parent" -> "child" -> notes
+	// e.g., "plans" -> "test-plan" -> [notes in plans/.archive/test-plan]
+	// artifactSubgroups maps "parent" -> "jobName" -> notes
+	// e.g., "plans/binary-test" -> "impl-foo-abc123" -> [briefing/log/etc files]
+	// jobName == "" means files directly under .artifacts (no per-job subdir).
+	var regularGroups []string
+	var rootNotes []*models.Note
+	planGroups := make(map[string][]*models.Note)
+	holdPlanGroups := make(map[string][]*models.Note)
+	archiveSubgroups := make(map[string]map[string][]*models.Note)
+	closedSubgroups := make(map[string]map[string][]*models.Note)
+	artifactSubgroups := make(map[string]map[string][]*models.Note)
+
+	for name, notes := range noteGroups {
+		// Handle root notes (e.g. grove.toml)
+		if name == "" {
+			rootNotes = append(rootNotes, notes...)
+			continue
+		}
+
+		// Check if this is an archived or closed group - skip if archives are hidden
+		isArchived := strings.Contains(name, "/.archive")
+		isClosed := strings.Contains(name, "/.closed")
+		if (isArchived || isClosed) && !m.showArchives {
+			continue
+		}
+
+		// Check if this is an artifact group - skip if artifacts are hidden
+		isArtifact := strings.Contains(name, "/.artifacts")
+		if isArtifact && !m.showArtifacts {
+			continue
+		}
+
+		// Skip double-nested archives (e.g., plans/.archive/foo/.archive/bar)
+		// Count occurrences of "/.archive" in the path
+		archiveCount := strings.Count(name, "/.archive")
+		if archiveCount > 1 {
+			continue
+		}
+
+		// Skip double-nested closed (e.g., issues/.closed/foo/.closed/bar)
+		closedCount := strings.Count(name, "/.closed")
+		if closedCount > 1 {
+			continue
+		}
+
+		// Check if this matches pattern "<parent>/.archive/<child>"
+		if strings.Contains(name, "/.archive/") {
+			parts := strings.Split(name, "/.archive/")
+			if len(parts) == 2 {
+				parent := parts[0]
+				child := parts[1]
+				if archiveSubgroups[parent] == nil {
+					archiveSubgroups[parent] = make(map[string][]*models.Note)
+				}
+				archiveSubgroups[parent][child] = notes
+				continue
+			}
+		}
+
+		// Check if this matches pattern "<parent>/.archive" (notes directly in .archive folder)
+		if strings.HasSuffix(name, "/.archive") {
+			parent := strings.TrimSuffix(name, "/.archive")
+			if archiveSubgroups[parent] == nil {
+				archiveSubgroups[parent] = make(map[string][]*models.Note)
+			}
+			// Use empty string as key to indicate notes directly in .archive
+			archiveSubgroups[parent][""] = notes
+			continue
+		}
+
+		// Check if this matches pattern "<parent>/.closed/<child>"
+		if strings.Contains(name, "/.closed/") {
+			parts := strings.Split(name, "/.closed/")
+			if len(parts) == 2 {
+				parent := parts[0]
+				child := parts[1]
+				if closedSubgroups[parent] == nil {
+					closedSubgroups[parent] = make(map[string][]*models.Note)
+				}
+				closedSubgroups[parent][child] = notes
+				continue
+			}
+		}
+
+		// Check if this matches pattern "<parent>/.closed" (notes directly in .closed folder)
+		if strings.HasSuffix(name, "/.closed") {
+			parent := strings.TrimSuffix(name, "/.closed")
+			if closedSubgroups[parent] == nil {
+				closedSubgroups[parent] = make(map[string][]*models.Note)
+			}
+			// Use empty string as key to indicate notes directly in .closed
+			closedSubgroups[parent][""] = notes
+			continue
+		}
+
+		// Check if this matches pattern "<parent>/.artifacts/<jobName>"
+		// (each job dir under .artifacts becomes its own subgroup).
+		if idx := strings.Index(name, "/.artifacts/"); idx >= 0 {
+			parent := name[:idx]
+			jobName := name[idx+len("/.artifacts/"):]
+			if artifactSubgroups[parent] == nil {
+				artifactSubgroups[parent] = make(map[string][]*models.Note)
+			}
+			artifactSubgroups[parent][jobName] = notes
+			continue
+		}
+
+		// Check if this matches pattern "<parent>/.artifacts" (files directly in .artifacts folder)
+		if strings.HasSuffix(name, "/.artifacts") {
+			parent := strings.TrimSuffix(name, "/.artifacts")
+			if artifactSubgroups[parent] == nil {
+				artifactSubgroups[parent] = make(map[string][]*models.Note)
+			}
+			artifactSubgroups[parent][""] = notes
+			continue
+		}
+
+		// Handle plans grouping
+		if strings.HasPrefix(name, "plans/") && m.plansEnabled() {
+			planName := strings.TrimPrefix(name, "plans/")
+			// Check plan status to separate on-hold plans
+			planStatus := m.GetPlanStatus(ws.Name, name)
+			if planStatus == "hold" { //nolint:goconst
+				if !m.showOnHold {
+					// Skip on-hold plans unless showOnHold is true
+					continue
+				}
+				// Add to hold plans group
+				holdPlanGroups[planName] = notes
+			} else {
+				// Add to regular plans group
+				planGroups[planName] = notes
+			}
+		} else {
+			regularGroups = append(regularGroups, name)
+		}
+	}
+	ensureParentGroup := func(parent string) {
+		if parent == "" || (parent == "plans" && m.plansEnabled()) { //nolint:goconst
+			return
+		}
+		if strings.HasPrefix(parent, "plans/") && m.plansEnabled() {
+			planName := strings.TrimPrefix(parent, "plans/")
+			if planGroups[planName] == nil && holdPlanGroups[planName] == nil {
+				planStatus := m.GetPlanStatus(ws.Name, parent)
+				if planStatus == "hold" {
+					if m.showOnHold {
+						holdPlanGroups[planName] = []*models.Note{}
+					}
+				} else {
+					planGroups[planName] = []*models.Note{}
+				}
+			}
+		} else {
+			found := false
+			for _, rg := range regularGroups {
+				if rg == parent {
+					found = true
+					break
+				}
+			}
+			if !found {
+				regularGroups = append(regularGroups, parent)
+			}
+		}
+	}
+
+	for parent := range archiveSubgroups {
+		ensureParentGroup(parent)
+	}
+	for parent := range closedSubgroups {
+		ensureParentGroup(parent)
+	}
+	for parent := range artifactSubgroups {
+		ensureParentGroup(parent)
+	}
+
+	// Sort groups using SortOrder from NoteTypes registry
+	// Groups with lower SortOrder appear first, then alphabetically by name
+	sort.SliceStable(regularGroups, func(i, j int) bool {
+		nameA := regularGroups[i]
+		nameB := regularGroups[j]
+
+		// Get SortOrder from registry, default to 100 if not found or if SortOrder is 0
+		sortOrderA := 100
+		sortOrderB := 100
+		if typeConfig, ok := m.service.NoteTypes[nameA]; ok && typeConfig.SortOrder != 0 {
+			sortOrderA = typeConfig.SortOrder
+		}
+		if typeConfig, ok := m.service.NoteTypes[nameB]; ok && typeConfig.SortOrder != 0 {
+			sortOrderB = typeConfig.SortOrder
+		}
+
+		// Sort by SortOrder first, then alphabetically
+		if sortOrderA != sortOrderB {
+			return sortOrderA < sortOrderB
+		}
+		return nameA < nameB
+	})
+
+	// Check if we have plans to add a "plans" parent group
+	hasPlans := m.plansEnabled() && (len(planGroups) > 0 || len(archiveSubgroups["plans"]) > 0)
+	hasHoldPlans := len(holdPlanGroups) > 0
+
+	// Render groups in the sorted order
+	notesRootDir, err := m.service.GetNotebookLocator().GetNotesDir(ws, "")
+	if err == nil { // Proceed only if we can get the notes root directory
+		// Determine where to insert plans based on SortOrder
+		plansSortOrder := 100
+		if typeConfig, ok := m.service.NoteTypes["plans"]; ok && typeConfig.SortOrder != 0 {
+			plansSortOrder = typeConfig.SortOrder
+		}
+
+		// Split regular groups into those that come before and after plans
+		var groupsBeforePlans []string
+		var groupsAfterPlans []string
+		for _, groupName := range regularGroups {
+			groupSortOrder := 100
+			if typeConfig, ok := m.service.NoteTypes[groupName]; ok && typeConfig.SortOrder != 0 {
+				groupSortOrder = typeConfig.SortOrder
+			}
+			if groupSortOrder < plansSortOrder {
+				groupsBeforePlans = append(groupsBeforePlans, groupName)
+			} else {
+				groupsAfterPlans = append(groupsAfterPlans, groupName)
+			}
+		}
+
+		// Render groups before plans
+		if len(groupsBeforePlans) > 0 {
+			rootGroupNode := buildGroupTree(noteGroups, groupsBeforePlans)
+			hasFollowingTopLevelSiblings := hasPlans || len(groupsAfterPlans) > 0 || hasHoldPlans || len(rootNotes) > 0
+			config := treeRenderConfig{
+				itemType:            tree.TypeGroup,
+				groupMetadataPrefix: "",
+				nameUsesPrefix:      false,
+				includeArchives:     true,
+				includeClosed:       true,
+				includeArtifacts:    true,
+			}
+			m.guardRender(ws.Name, func() {
+				m.renderTree(nodes, ws, rootGroupNode, ws.TreePrefix+"  ", ws.Depth+1, hasSearchFilter, workspacePathMap, notesRootDir, config, hasFollowingTopLevelSiblings, archiveSubgroups, closedSubgroups, artifactSubgroups)
+			})
+		}
+
+		// Render Plans Group in its sorted position
+		if hasPlans {
+			hasGroupsAfter := len(groupsAfterPlans) > 0 || hasHoldPlans || len(rootNotes) > 0
+			m.addPlansGroup(nodes, ws, planGroups, archiveSubgroups, artifactSubgroups, hasSearchFilter, workspacePathMap, hasGroupsAfter)
+		}
+
+		// Render groups after plans
+		if len(groupsAfterPlans) > 0 {
+			rootGroupNode := buildGroupTree(noteGroups, groupsAfterPlans)
+			hasFollowingTopLevelSiblings := hasHoldPlans || len(rootNotes) > 0
+			config := treeRenderConfig{
+				itemType:            tree.TypeGroup,
+				groupMetadataPrefix: "",
+				nameUsesPrefix:      false,
+				includeArchives:     true,
+				includeClosed:       true,
+				includeArtifacts:    true,
+			}
+			m.guardRender(ws.Name, func() {
+				m.renderTree(nodes, ws, rootGroupNode, ws.TreePrefix+"  ", ws.Depth+1, hasSearchFilter, workspacePathMap, notesRootDir, config, hasFollowingTopLevelSiblings, archiveSubgroups, closedSubgroups, artifactSubgroups)
+			})
+		}
+
+		// Render On-Hold Plans (always last before root notes)
+		if hasHoldPlans {
+			m.guardRender("on-hold plans", func() {
+				m.addHoldPlansGroup(nodes, ws, holdPlanGroups, hasSearchFilter, workspacePathMap, len(rootNotes) > 0)
+			})
+		}
+
+		// Render root notes (e.g. grove.toml) directly under workspace
+		if len(rootNotes) > 0 {
+			m.sortNotes(rootNotes)
+			for ni, note := range rootNotes {
+				isLastRootNote := ni == len(rootNotes)-1
+				var notePrefix strings.Builder
+				noteIndent := strings.ReplaceAll(ws.TreePrefix, "├ ", "│ ")
+				noteIndent = strings.ReplaceAll(noteIndent, "└ ", "  ")
+				notePrefix.WriteString(noteIndent)
+				if isLastRootNote {
+					notePrefix.WriteString("└ ")
+				} else {
+					notePrefix.WriteString("├ ")
+				}
+				*nodes = append(*nodes, &DisplayNode{
+					Item:         noteToItem(note),
+					Prefix:       notePrefix.String(),
+					Depth:        ws.Depth + 1,
+					RelativePath: calculateRelativePath(note, workspacePathMap, m.focusedWorkspace),
+				})
+			}
+		}
+	}
+}
+
 func (m *Model) addUngroupedSection(nodes *[]*DisplayNode, ungroupedWorkspaces []*workspace.WorkspaceNode, notesByWorkspace map[string]map[string][]*models.Note, hasSearchFilter bool, workspacePathMap map[string]string) {
 	ungroupedItem := &tree.Item{
 		Path:     "ungrouped",
@@ -1708,9 +1772,14 @@ func (m *Model) addUngroupedSection(nodes *[]*DisplayNode, ungroupedWorkspaces [
 				continue
 			}
 
-			// Render notes for this ungrouped workspace (similar logic to main loop, abbreviated here)
-			// For brevity, this implementation is simplified
-			// In a complete implementation, this would follow the same pattern as the main workspace rendering
+			// Render the notes as the main loop does, indented under
+			// "ungrouped" like the workspace node.
+			if noteGroups, ok := notesByWorkspace[wsKey]; ok {
+				wsCopy := *ws
+				wsCopy.TreePrefix = adjustedPrefix
+				wsCopy.Depth = node.Depth
+				m.renderWorkspaceNotes(nodes, &wsCopy, noteGroups, hasSearchFilter, workspacePathMap)
+			}
 		}
 	}
 }
@@ -1912,7 +1981,9 @@ func (m *Model) renderSyntheticGroups(
 	if len(buckets) == 0 {
 		// Nothing to bucket: fall back to a flat note list. Artifacts stay as a
 		// sibling subgroup under group-by, so no nesting map is passed.
-		m.addNoteNodes(nodes, notesInGroup, ws, groupPrefix, depth, workspacePathMap, hasFollowingSiblings, nil, "")
+		m.guardRender(groupName, func() {
+			m.addNoteNodes(nodes, notesInGroup, ws, groupPrefix, depth, workspacePathMap, hasFollowingSiblings, nil, "")
+		})
 		return
 	}
 
@@ -1960,7 +2031,9 @@ func (m *Model) renderSyntheticGroups(
 		// Render the bucket's notes when expanded. Artifacts remain a sibling
 		// subgroup under group-by, so no nesting map is passed.
 		if !m.collapsedNodes[bucketNode.NodeID()] || hasSearchFilter {
-			m.addNoteNodes(nodes, bucket.notes, ws, bucketPrefix.String(), depth+1, workspacePathMap, false, nil, "")
+			m.guardRender(groupName, func() {
+				m.addNoteNodes(nodes, bucket.notes, ws, bucketPrefix.String(), depth+1, workspacePathMap, false, nil, "")
+			})
 		}
 	}
 }
@@ -2263,8 +2336,10 @@ func (m *Model) renderTree(
 				recursiveConfig.itemType = tree.TypeGroup
 			}
 
-			// Recurse for subdirectories
-			m.renderTree(nodes, ws, child, nextParentPrefix, depth+1, hasSearchFilter, workspacePathMap, rootDir, recursiveConfig, false, archiveSubgroups, closedSubgroups, artifactSubgroups)
+			// Recurse for subdirectories; a panic in one is confined to it.
+			m.guardRender(child.fullName, func() {
+				m.renderTree(nodes, ws, child, nextParentPrefix, depth+1, hasSearchFilter, workspacePathMap, rootDir, recursiveConfig, false, archiveSubgroups, closedSubgroups, artifactSubgroups)
+			})
 
 			// Render notes and special subgroups if this node corresponds to an original group
 			artifactGroupKey := child.fullName
@@ -2305,7 +2380,9 @@ func (m *Model) renderTree(
 						if !nestArtifacts {
 							subgroupsForNesting = nil
 						}
-						m.addNoteNodes(nodes, child.notes, ws, childPrefix.String(), depth, workspacePathMap, hasFollowingNoteSiblings, subgroupsForNesting, artifactGroupKey)
+						m.guardRender(child.fullName, func() {
+							m.addNoteNodes(nodes, child.notes, ws, childPrefix.String(), depth, workspacePathMap, hasFollowingNoteSiblings, subgroupsForNesting, artifactGroupKey)
+						})
 					}
 				}
 
@@ -2480,6 +2557,39 @@ func (m *Model) FilterDisplayTree() {
 	m.clampCursor()
 }
 
+// ApplyFilters narrows a freshly built tree by every active filter: git
+// changes, untagged notes and the filter text. Every rebuild of the tree
+// calls it, so sorting, folding or revealing a note keeps the view filtered.
+func (m *Model) ApplyFilters() {
+	m.FilterDisplayTreeByGitStatus()
+	m.FilterDisplayTreeUntagged()
+	m.FilterDisplayTree()
+}
+
+// rebuildDisplayTree rebuilds the tree and reapplies the filters.
+func (m *Model) rebuildDisplayTree() {
+	m.BuildDisplayTree()
+	m.ApplyFilters()
+}
+
+// FilterDisplayTreeUntagged filters the tree view to the notes without tags
+// (see service.IsUntagged), preserving parent nodes.
+func (m *Model) FilterDisplayTreeUntagged() {
+	if !m.showUntaggedOnly {
+		return
+	}
+	untagged := make(map[string]bool)
+	for _, node := range m.displayNodes {
+		if node.IsNote() {
+			tags, _ := node.Item.Metadata["Tags"].([]string)
+			if service.IsUntagged(tags) {
+				untagged[node.Item.Path] = true
+			}
+		}
+	}
+	m.keepNotesByPath(untagged)
+}
+
 // FilterDisplayTreeByGitStatus filters the tree view to show only notes with git changes, preserving parent nodes.
 func (m *Model) FilterDisplayTreeByGitStatus() {
 	if !m.showGitModifiedOnly || m.gitFileStatus == nil {
@@ -2660,7 +2770,8 @@ var grepSearcher = runContentSearch
 // runContentSearch shells out to ripgrep (fallback: grep -ril) for a
 // case-insensitive files-with-matches search over the given directories.
 // A "no matches" exit (code 1 for both tools) yields an empty, nil-error
-// result.
+// result, and files that can't be read are skipped as service.SearchNotes
+// skips them.
 func runContentSearch(query string, dirs []string) ([]string, error) {
 	var cmd *exec.Cmd
 	if _, err := exec.LookPath("rg"); err == nil {
@@ -2673,12 +2784,8 @@ func runContentSearch(query string, dirs []string) ([]string, error) {
 		cmd = exec.Command("grep", args...)
 	}
 
-	out, err := cmd.Output()
+	out, _, err := service.RunSearchCommand(cmd)
 	if err != nil {
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
-			return nil, nil
-		}
 		return nil, err
 	}
 
@@ -2696,7 +2803,7 @@ func (m *Model) ApplyGrepFilter() (string, error) {
 	query := m.filterValue
 	if query == "" {
 		// Restore the full tree with original collapsed state
-		m.BuildDisplayTree()
+		m.rebuildDisplayTree()
 		return "", nil
 	}
 
@@ -2757,6 +2864,16 @@ func (m *Model) ApplyGrepFilter() (string, error) {
 		}
 	}
 
+	// In the "all" search scope a note also matches on its title.
+	if m.grepIncludesTitles {
+		filter := strings.ToLower(query)
+		for _, item := range m.allItems {
+			if !item.IsDir && noteMatchesFilter(item, filter) {
+				resultPaths[item.Path] = true
+			}
+		}
+	}
+
 	statusMsg := fmt.Sprintf("Found %d matching notes", len(resultPaths))
 
 	// Temporarily expand all nodes to show grep results
@@ -2776,6 +2893,14 @@ func (m *Model) ApplyGrepFilter() (string, error) {
 func (m *Model) filterDisplayTreeByPaths(pathsToKeep map[string]bool) {
 	// Rebuild the full tree (already expanded by caller)
 	m.BuildDisplayTree()
+	m.FilterDisplayTreeByGitStatus()
+	m.FilterDisplayTreeUntagged()
+	m.keepNotesByPath(pathsToKeep)
+}
+
+// keepNotesByPath narrows the current tree to the notes whose paths are in
+// pathsToKeep, preserving their parent nodes.
+func (m *Model) keepNotesByPath(pathsToKeep map[string]bool) {
 	fullTree := m.displayNodes
 
 	// Normalize all paths to keep for case-insensitive comparison
@@ -2849,9 +2974,7 @@ func (m *Model) toggleFold() {
 	} else {
 		m.collapsedNodes[nodeID] = true
 	}
-	m.BuildDisplayTree()
-	m.FilterDisplayTreeByGitStatus()
-	m.FilterDisplayTree()
+	m.rebuildDisplayTree()
 }
 
 func (m *Model) openFold() {
@@ -2863,9 +2986,7 @@ func (m *Model) openFold() {
 		return
 	}
 	delete(m.collapsedNodes, node.NodeID())
-	m.BuildDisplayTree()
-	m.FilterDisplayTreeByGitStatus()
-	m.FilterDisplayTree()
+	m.rebuildDisplayTree()
 }
 
 func (m *Model) closeFold() {
@@ -2877,9 +2998,7 @@ func (m *Model) closeFold() {
 		return
 	}
 	m.collapsedNodes[node.NodeID()] = true
-	m.BuildDisplayTree()
-	m.FilterDisplayTreeByGitStatus()
-	m.FilterDisplayTree()
+	m.rebuildDisplayTree()
 }
 
 func (m *Model) closeAllFolds() {
@@ -2888,16 +3007,12 @@ func (m *Model) closeAllFolds() {
 			m.collapsedNodes[node.NodeID()] = true
 		}
 	}
-	m.BuildDisplayTree()
-	m.FilterDisplayTreeByGitStatus()
-	m.FilterDisplayTree()
+	m.rebuildDisplayTree()
 }
 
 func (m *Model) openAllFolds() {
 	m.collapsedNodes = make(map[string]bool)
-	m.BuildDisplayTree()
-	m.FilterDisplayTreeByGitStatus()
-	m.FilterDisplayTree()
+	m.rebuildDisplayTree()
 }
 
 func (m *Model) closeFoldRecursive(cursorIndex int) {
@@ -2923,9 +3038,7 @@ func (m *Model) closeFoldRecursive(cursorIndex int) {
 			m.collapsedNodes[childNode.NodeID()] = true
 		}
 	}
-	m.BuildDisplayTree()
-	m.FilterDisplayTreeByGitStatus()
-	m.FilterDisplayTree()
+	m.rebuildDisplayTree()
 }
 
 func (m *Model) openFoldRecursive(cursorIndex int) {
@@ -2943,46 +3056,37 @@ func (m *Model) openFoldRecursive(cursorIndex int) {
 	if node.IsWorkspace() {
 		// Un-collapse all descendant workspaces and their note groups
 		wsPath := node.Item.Path
-		var wsName string
+		wsNames := make(map[string]bool)
 		if ws, ok := node.Item.Metadata["Workspace"].(*workspace.WorkspaceNode); ok {
-			wsName = ws.Name
+			wsNames[ws.Name] = true
 		}
 
 		for _, ws := range m.workspaces {
 			if strings.HasPrefix(ws.Path, wsPath) && ws.Path != wsPath {
-				delete(m.collapsedNodes, "ws:"+ws.Path)
+				delete(m.collapsedNodes, "dir:"+ws.Path)
+				wsNames[ws.Name] = true
 			}
 		}
-		for _, item := range m.allItems {
-			if !item.IsDir {
-				if itemWs, ok := item.Metadata["Workspace"].(string); ok && itemWs == wsName {
-					if group, ok := item.Metadata["Group"].(string); ok {
-						delete(m.collapsedNodes, "grp:"+group)
-						if strings.HasPrefix(group, "plans/") {
-							delete(m.collapsedNodes, "grp:plans")
-						}
-					}
+		for nodeID := range m.collapsedNodes {
+			for wsName := range wsNames {
+				if strings.HasPrefix(nodeID, GroupNodeID(wsName, "")) {
+					delete(m.collapsedNodes, nodeID)
 				}
 			}
 		}
 	} else if node.IsGroup() {
-		// Un-collapse child groups (e.g., 'plans' contains 'plans/sub-plan')
-		groupNamePrefix := node.Item.Name + "/"
+		// Un-collapse child groups (e.g., 'plans' contains 'plans/sub-plan'),
+		// scoped to this group's workspace.
 		wsName, _ := node.Item.Metadata["Workspace"].(string)
-		for _, item := range m.allItems {
-			if !item.IsDir {
-				if itemWs, ok := item.Metadata["Workspace"].(string); ok && itemWs == wsName {
-					if group, ok := item.Metadata["Group"].(string); ok && strings.HasPrefix(group, groupNamePrefix) {
-						delete(m.collapsedNodes, "grp:"+group)
-					}
-				}
+		childPrefix := GroupNodeID(wsName, node.Item.Path+string(filepath.Separator))
+		for nodeID := range m.collapsedNodes {
+			if strings.HasPrefix(nodeID, childPrefix) {
+				delete(m.collapsedNodes, nodeID)
 			}
 		}
 	}
 
-	m.BuildDisplayTree()
-	m.FilterDisplayTreeByGitStatus()
-	m.FilterDisplayTree()
+	m.rebuildDisplayTree()
 }
 
 func (m *Model) toggleFoldRecursive(cursorIndex int) {
@@ -3002,6 +3106,146 @@ func (m *Model) toggleFoldRecursive(cursorIndex int) {
 	}
 }
 
+// archivedWorkspacesSectionPath is the synthetic path of the archived
+// workspaces section; like todaySectionPath it only keys the fold state.
+const archivedWorkspacesSectionPath = ".synthetic-archived-workspaces"
+
+// addArchivedWorkspacesSection appends a section listing the workspaces
+// retired by `nb workspace archive`, collapsed until first expanded. Their
+// notes aren't loaded, so each row is just the archived directory.
+func (m *Model) addArchivedWorkspacesSection(nodes *[]*DisplayNode) {
+	if len(m.archivedWorkspaces) == 0 {
+		return
+	}
+
+	section := &DisplayNode{
+		Item: &tree.Item{
+			Path:  archivedWorkspacesSectionPath,
+			Name:  "archived workspaces",
+			IsDir: true,
+			Type:  tree.TypeGroup,
+			Metadata: map[string]interface{}{
+				"Icon": theme.IconArchive,
+			},
+		},
+		Depth:      0,
+		ChildCount: len(m.archivedWorkspaces),
+	}
+	*nodes = append(*nodes, section)
+	m.seedCollapsedDefault(section.NodeID())
+	if m.collapsedNodes[section.NodeID()] {
+		return
+	}
+
+	for i, aw := range m.archivedWorkspaces {
+		prefix := "├ "
+		if i == len(m.archivedWorkspaces)-1 {
+			prefix = "└ "
+		}
+		metadata := map[string]interface{}{"Icon": theme.IconArchive}
+		if aw.Info != nil {
+			metadata["ArchivedAt"] = aw.Info.ArchivedAt
+		}
+		*nodes = append(*nodes, &DisplayNode{
+			Item: &tree.Item{
+				Path:     aw.Path,
+				Name:     aw.Name,
+				IsDir:    true,
+				Type:     tree.TypeGroup,
+				Metadata: metadata,
+			},
+			Prefix: prefix,
+			Depth:  1,
+		})
+	}
+}
+
+// todaySectionPath is the synthetic path of the pinned "Today" section. It
+// only keys the section's fold state; nothing exists on disk there.
+const todaySectionPath = ".synthetic-today"
+
+// addTodaySection appends the pinned "Today" section: a synthetic group of
+// the in-scope notes created or modified today, most recently modified first.
+// The notes also stay in their own groups, so folding the section only hides
+// these rows. Nothing is added when no note qualifies.
+func (m *Model) addTodaySection(nodes *[]*DisplayNode, allNotes []*models.Note, workspacePathMap map[string]string, hasSearchFilter bool) {
+	notes := m.todayNotes(allNotes, time.Now())
+	if len(notes) == 0 {
+		return
+	}
+
+	section := &DisplayNode{
+		Item: &tree.Item{
+			Path:  todaySectionPath,
+			Name:  "Today",
+			IsDir: true,
+			Type:  tree.TypeGroup,
+			Metadata: map[string]interface{}{
+				"Icon":   theme.IconCalendar,
+				"Pinned": true,
+			},
+		},
+		Depth:      0,
+		ChildCount: len(notes),
+	}
+	*nodes = append(*nodes, section)
+	if m.collapsedNodes[section.NodeID()] && !hasSearchFilter {
+		return
+	}
+
+	for i, note := range notes {
+		prefix := "├ "
+		if i == len(notes)-1 {
+			prefix = "└ "
+		}
+		item := noteToItem(note)
+		item.Metadata["Pinned"] = true
+		*nodes = append(*nodes, &DisplayNode{
+			Item:         item,
+			Prefix:       prefix,
+			Depth:        1,
+			RelativePath: calculateRelativePath(note, workspacePathMap, m.focusedWorkspace),
+		})
+	}
+}
+
+// todayNotes returns the notes in the current scope (see workspaceScope)
+// created or modified on now's calendar day, most recently modified first.
+// Archived notes and artifacts follow the archive and artifact toggles.
+func (m *Model) todayNotes(allNotes []*models.Note, now time.Time) []*models.Note {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	inScope := m.workspaceScope()
+
+	var notes []*models.Note
+	for _, note := range allNotes {
+		if !inScope[strings.ToLower(note.Workspace)] {
+			continue
+		}
+		if note.CreatedAt.Before(today) && note.ModifiedAt.Before(today) {
+			continue
+		}
+		isArchived := strings.Contains(note.Path, "/.archive/") || strings.Contains(note.Path, "/.closed/")
+		if isArchived && !m.showArchives {
+			continue
+		}
+		if strings.Contains(note.Path, "/.artifacts") && !m.showArtifacts {
+			continue
+		}
+		notes = append(notes, note)
+	}
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].ModifiedAt.After(notes[j].ModifiedAt)
+	})
+	return notes
+}
+
+// isPinned reports whether item is a row of the pinned "Today" section rather
+// than the tree proper.
+func isPinned(item *tree.Item) bool {
+	pinned, _ := item.Metadata["Pinned"].(bool)
+	return pinned
+}
+
 // buildRecentNotesList constructs a flat list of notes for the recent view.
 func (m *Model) buildRecentNotesList() {
 	// Convert items to notes
@@ -3017,31 +3261,16 @@ func (m *Model) buildRecentNotesList() {
 	if m.isFilteringByTag && m.selectedTag != "" {
 		var taggedNotes []*models.Note
 		for _, note := range notesToDisplay {
-			for _, tag := range note.Tags {
-				if tag == m.selectedTag {
-					taggedNotes = append(taggedNotes, note)
-					break
-				}
+			if noteHasTag(note, m.selectedTag) {
+				taggedNotes = append(taggedNotes, note)
 			}
 		}
 		notesToDisplay = taggedNotes
 	}
 
-	// Filter out archived notes if not shown
-	if !m.showArchives {
-		var nonArchivedNotes []*models.Note
-		for _, note := range notesToDisplay {
-			if !note.IsArchived {
-				nonArchivedNotes = append(nonArchivedNotes, note)
-			}
-		}
-		notesToDisplay = nonArchivedNotes
-	}
-
-	// Sort by modified date descending
-	sort.SliceStable(notesToDisplay, func(i, j int) bool {
-		return notesToDisplay[i].ModifiedAt.After(notesToDisplay[j].ModifiedAt)
-	})
+	// Drop archived notes (unless shown) and sort by modified date descending,
+	// using the same ordering as `nb recent`.
+	notesToDisplay = service.SelectRecentNotes(notesToDisplay, 0, m.showArchives)
 
 	// Create flat list of display nodes
 	var nodes []*DisplayNode
@@ -3063,6 +3292,106 @@ func (m *Model) buildRecentNotesList() {
 	m.clampCursor()
 }
 
+// buildCompactNotesList constructs the flat note list for CompactView. It
+// honors the same scoping as the tree (focus, hidden global, archives,
+// artifacts, tag filter) but drops workspaces and groups; the substring, git
+// and grep filters are applied afterwards by the usual passes.
+func (m *Model) buildCompactNotesList() {
+	inScope := m.workspaceScope()
+	workspacePathMap := make(map[string]string)
+	for _, ws := range m.workspaces {
+		workspacePathMap[ws.Name] = ws.Path
+	}
+
+	var notes []*models.Note
+	for _, item := range m.allItems {
+		if item.IsDir {
+			continue
+		}
+		note := ItemToNote(item)
+		if !inScope[strings.ToLower(note.Workspace)] {
+			continue
+		}
+		isArchived := strings.Contains(note.Path, "/.archive/") || strings.Contains(note.Path, "/.closed/")
+		if isArchived && !m.showArchives {
+			continue
+		}
+		if strings.Contains(note.Path, "/.artifacts") && !m.showArtifacts {
+			continue
+		}
+		if m.isFilteringByTag && m.selectedTag != "" && !noteHasTag(note, m.selectedTag) {
+			continue
+		}
+		notes = append(notes, note)
+	}
+	m.sortNotes(notes)
+
+	nodes := make([]*DisplayNode, 0, len(notes))
+	for _, note := range notes {
+		nodes = append(nodes, &DisplayNode{
+			Item:         noteToItem(note),
+			Depth:        0,
+			RelativePath: calculateRelativePath(note, workspacePathMap, m.focusedWorkspace),
+		})
+	}
+
+	m.displayNodes = nodes
+	m.jumpMap = make(map[rune]int) // No jump keys in flat list
+	m.clampCursor()
+}
+
+// workspaceScope returns the lowercased names of the workspaces whose notes
+// are in view: the focused workspace and its children (every workspace when
+// nothing is focused), plus global unless it is hidden.
+func (m *Model) workspaceScope() map[string]bool {
+	var normFocused string
+	if m.focusedWorkspace != nil {
+		normFocused, _ = pathutil.NormalizeForLookup(m.focusedWorkspace.Path)
+	}
+	inScope := make(map[string]bool)
+	for _, ws := range m.workspaces {
+		if ws.Name == "global" { //nolint:goconst
+			inScope[strings.ToLower(ws.Name)] = !m.hideGlobal
+			continue
+		}
+		if m.focusedWorkspace == nil {
+			inScope[strings.ToLower(ws.Name)] = true
+			continue
+		}
+		normWs, _ := pathutil.NormalizeForLookup(ws.Path)
+		if normWs == normFocused || strings.HasPrefix(normWs, normFocused+string(filepath.Separator)) {
+			inScope[strings.ToLower(ws.Name)] = true
+		}
+	}
+	return inScope
+}
+
+// flagFilterPrefix turns a tag filter into a flag filter: "#flag:red" lists
+// the notes flagged red.
+const flagFilterPrefix = "flag:"
+
+// cutFlagFilter returns the flag a "flag:<name>" tag filter selects.
+func cutFlagFilter(tag string) (string, bool) {
+	if len(tag) < len(flagFilterPrefix) || !strings.EqualFold(tag[:len(flagFilterPrefix)], flagFilterPrefix) {
+		return "", false
+	}
+	return tag[len(flagFilterPrefix):], true
+}
+
+// noteHasTag reports whether note carries tag (case-insensitive), or the
+// flag of a "flag:<name>" filter.
+func noteHasTag(note *models.Note, tag string) bool {
+	if flag, ok := cutFlagFilter(tag); ok {
+		return strings.EqualFold(note.Flag, flag)
+	}
+	for _, t := range note.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
 // buildArchiveNotesList constructs a flat list of archived/closed notes for the
 // dedicated archive view. Only notes living under a /.archive/ or /.closed/ path
 // are included; everything else is hidden. Sorted by modified date descending.
@@ -3081,11 +3410,8 @@ func (m *Model) buildArchiveNotesList() {
 	if m.isFilteringByTag && m.selectedTag != "" {
 		var taggedNotes []*models.Note
 		for _, note := range archivedNotes {
-			for _, tag := range note.Tags {
-				if tag == m.selectedTag {
-					taggedNotes = append(taggedNotes, note)
-					break
-				}
+			if noteHasTag(note, m.selectedTag) {
+				taggedNotes = append(taggedNotes, note)
 			}
 		}
 		archivedNotes = taggedNotes
@@ -3154,9 +3480,19 @@ func ItemToNote(item *tree.Item) *models.Note {
 	if planRef, ok := item.Metadata["PlanRef"].(string); ok {
 		note.PlanRef = planRef
 	}
+	if linkRef, ok := item.Metadata["LinkRef"].(string); ok {
+		note.LinkRef = linkRef
+	}
+	if linkType, ok := item.Metadata["LinkType"].(string); ok {
+		note.LinkType = linkType
+	}
 	if priority, ok := item.Metadata["Priority"].(string); ok {
 		note.Priority = priority
 	}
+	if flag, ok := item.Metadata["Flag"].(string); ok {
+		note.Flag = flag
+	}
+	// Items are dated by service.NoteCreatedAt; only synthetic ones lack it.
 	if created, ok := item.Metadata["Created"].(time.Time); ok {
 		note.CreatedAt = created
 	} else {
@@ -3212,7 +3548,10 @@ func noteToItem(note *models.Note) *tree.Item {
 	item.Metadata["Branch"] = note.Branch
 	item.Metadata["Tags"] = note.Tags
 	item.Metadata["PlanRef"] = note.PlanRef
+	item.Metadata["LinkRef"] = note.LinkRef
+	item.Metadata["LinkType"] = note.LinkType
 	item.Metadata["Priority"] = note.Priority
+	item.Metadata["Flag"] = note.Flag
 	item.Metadata["Created"] = note.CreatedAt
 	item.Metadata["TodoOpen"] = note.TodoOpen
 	item.Metadata["TodoDone"] = note.TodoDone
@@ -3225,10 +3564,16 @@ func noteToItem(note *models.Note) *tree.Item {
 	return item
 }
 
-// ApplyLinks iterates through the display nodes to find and link notes with their corresponding plans.
+// ApplyLinks iterates through the display nodes to find and link notes with
+// their corresponding plans. A note without a plan link is linked to the note
+// its link_ref (set by nb note link) points at, and that note back to it when
+// it has no link of its own.
 func (m *Model) ApplyLinks() {
 	notesWithPlanRef := make(map[string]*DisplayNode)
 	planNodes := make(map[string]*DisplayNode)
+	notesWithLinkRef := make(map[string]*DisplayNode)
+	noteNodes := make(map[string]*DisplayNode)
+	linkPlans := m.plansEnabled()
 
 	// First pass: collect all notes with plan references and all plan nodes.
 	for _, node := range m.displayNodes {
@@ -3240,6 +3585,17 @@ func (m *Model) ApplyLinks() {
 			continue
 		}
 
+		if !node.Item.IsDir && node.Item.Type == tree.TypeNote {
+			noteNodes[node.Item.Path] = node
+			if linkRef, ok := node.Item.Metadata["LinkRef"].(string); ok && linkRef != "" {
+				notesWithLinkRef[service.ResolveLinkRef(node.Item.Path, linkRef)] = node
+			}
+		}
+
+		if !linkPlans {
+			continue
+		}
+
 		if !node.Item.IsDir && node.Item.Type == tree.TypeNote {
 			// Check if this note has a plan_ref
 			if planRef, ok := node.Item.Metadata["PlanRef"].(string); ok && planRef != "" {
@@ -3277,4 +3633,16 @@ func (m *Model) ApplyLinks() {
 			planNode.LinkedNode = noteNode
 		}
 	}
+
+	// Third pass: note-to-note links, where the plan links left room.
+	for targetPath, sourceNode := range notesWithLinkRef {
+		targetNode, ok := noteNodes[targetPath]
+		if !ok || sourceNode.LinkedNode != nil {
+			continue
+		}
+		sourceNode.LinkedNode = targetNode
+		if targetNode.LinkedNode == nil {
+			targetNode.LinkedNode = sourceNode
+		}
+	}
 }
@@ -13,7 +13,9 @@ import (
 	"github.com/grovetools/core/tui/theme"
 	"github.com/grovetools/core/util/pathutil"
 
+	"github.com/grovetools/nb/pkg/frontmatter"
 	"github.com/grovetools/nb/pkg/models"
+	"github.com/grovetools/nb/pkg/service"
 	"github.com/grovetools/nb/pkg/tree"
 )
 
@@ -25,6 +27,7 @@ type nodeRenderInfo struct {
 	name        string
 	count       string // "(d)"
 	suffix      string
+	checklist   string // "[done/total]" for notes with checkboxes
 	isArchived  bool
 	isArtifact  bool
 	isPlan      bool
@@ -38,10 +41,15 @@ type nodeRenderInfo struct {
 
 // View renders the main content area (tree or table view).
 func (m *Model) View() string {
-	if m.viewMode == TreeView {
-		return m.renderTreeView()
+	switch m.viewMode {
+	case TableView:
+		return m.renderTableView()
+	case CompactView:
+		if !m.ecosystemPickerMode {
+			return m.renderCompactView()
+		}
 	}
-	return m.renderTableView()
+	return m.renderTreeView()
 }
 
 // recomputePrefixes recalculates tree prefixes to match the neotree style.
@@ -162,6 +170,72 @@ func (m *Model) renderTreeView() string {
 	return b.String()
 }
 
+// compactDateWidth is the width of the dim "Jan 02" date in CompactView.
+const compactDateWidth = 6
+
+// renderCompactView renders one line per note: cursor, glyph, title and a dim
+// modified date right-aligned to the pane width. Titles are truncated so the
+// view stays readable down to ~40 columns.
+func (m *Model) renderCompactView() string {
+	var b strings.Builder
+
+	viewportHeight := m.getViewportHeight()
+	start := m.scrollOffset
+	end := m.scrollOffset + viewportHeight
+	if end > len(m.displayNodes) {
+		end = len(m.displayNodes)
+	}
+
+	width := m.width
+	if width <= 0 {
+		width = 40
+	}
+	displayLoc := frontmatter.DisplayLocation()
+
+	for i := start; i < end; i++ {
+		node := m.displayNodes[i]
+		isSelected := i == m.cursor
+
+		cursor := "  "
+		if isSelected {
+			cursor = lipgloss.NewStyle().Foreground(theme.DefaultTheme.Colors.Orange).Render("▶ ")
+		}
+
+		info := m.getNodeRenderInfo(node)
+		info.prefix = ""
+		date := ""
+		if node.Item != nil {
+			date = node.Item.ModTime.In(displayLoc).Format("Jan 02")
+		}
+
+		// cursor (2) + glyph (2) + space before date (1) + date
+		nameWidth := width - 2 - 2 - 1 - compactDateWidth
+		if nameWidth < 8 {
+			nameWidth = 8
+		}
+		if runes := []rune(info.name); lipgloss.Width(info.name) > nameWidth && len(runes) > nameWidth-1 {
+			info.name = string(runes[:nameWidth-1]) + "…"
+		}
+
+		content := m.styleNodeContent(info, isSelected)
+		gap := width - 2 - lipgloss.Width(content) - compactDateWidth
+		if gap < 1 {
+			gap = 1
+		}
+		line := cursor + content + strings.Repeat(" ", gap) + theme.DefaultTheme.Muted.Render(date)
+
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	if len(m.displayNodes) > viewportHeight {
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf(" (%d-%d of %d)", start+1, end, len(m.displayNodes))))
+	}
+
+	return b.String()
+}
+
 // renderTableView renders the table view with columns.
 func (m *Model) renderTableView() string {
 	var b strings.Builder
@@ -185,29 +259,19 @@ func (m *Model) renderTableView() string {
 	var headerParts []string
 	headerParts = append(headerParts, padOrTruncate("", selectionWidth))
 	headerParts = append(headerParts, padOrTruncate("WORKSPACE / NOTE", nameWidth))
-	if m.columnVisibility["TYPE"] {
-		headerParts = append(headerParts, separator, padOrTruncate("TYPE", typeWidth))
-	}
-	if m.columnVisibility["STATUS"] {
-		headerParts = append(headerParts, separator, padOrTruncate("STATUS", statusWidth))
-	}
-	if m.columnVisibility["PRIORITY"] {
-		headerParts = append(headerParts, separator, padOrTruncate("PRIORITY", priorityWidth))
-	}
-	if m.columnVisibility["TAGS"] {
-		headerParts = append(headerParts, separator, padOrTruncate("TAGS", tagsWidth))
-	}
-	if m.columnVisibility["CREATED"] {
-		headerParts = append(headerParts, separator, padOrTruncate("CREATED", createdWidth))
-	}
-	if m.columnVisibility["MODIFIED"] {
-		headerParts = append(headerParts, separator, padOrTruncate("MODIFIED", modifiedWidth))
-	}
-	if m.columnVisibility["WORKSPACE"] {
-		headerParts = append(headerParts, separator, padOrTruncate("WORKSPACE", workspaceWidth))
-	}
-	if m.columnVisibility["PATH"] {
-		headerParts = append(headerParts, separator, padOrTruncate("PATH", pathWidth))
+	columnWidths := map[string]int{
+		"TYPE":      typeWidth,
+		"STATUS":    statusWidth,
+		"PRIORITY":  priorityWidth,
+		"TAGS":      tagsWidth,
+		"CREATED":   createdWidth,
+		"MODIFIED":  modifiedWidth,
+		"WORKSPACE": workspaceWidth,
+		"PATH":      pathWidth,
+	}
+	columns := m.visibleColumns()
+	for _, col := range columns {
+		headerParts = append(headerParts, separator, padOrTruncate(col, columnWidths[col]))
 	}
 	header := strings.Join(headerParts, "")
 
@@ -251,10 +315,13 @@ func (m *Model) renderTableView() string {
 			if ws, ok := node.Item.Metadata["Workspace"].(string); ok {
 				workspaceCol = ws
 			}
+			// Frontmatter times and filesystem mtimes are both shown in the
+			// display zone so the two columns agree.
+			displayLoc := frontmatter.DisplayLocation()
 			if created, ok := node.Item.Metadata["CreatedAt"].(time.Time); ok {
-				createdCol = created.Format("2006-01-02 15:04")
+				createdCol = created.In(displayLoc).Format("2006-01-02 15:04")
 			}
-			modifiedCol = node.Item.ModTime.Format("2006-01-02 15:04")
+			modifiedCol = node.Item.ModTime.In(displayLoc).Format("2006-01-02 15:04")
 			pathCol = node.RelativePath
 		} else if info.isPlan {
 			wsName, _ := node.Item.Metadata["Workspace"].(string)
@@ -276,33 +343,25 @@ func (m *Model) renderTableView() string {
 		var rowParts []string
 		rowParts = append(rowParts, padOrTruncate(selCol, selectionWidth))
 		rowParts = append(rowParts, styledNameCol)
-		if m.columnVisibility["TYPE"] {
-			rowParts = append(rowParts, separator, padOrTruncate(typeCol, typeWidth))
+		priorityCell := ""
+		if badge := renderPriorityBadge(priorityCol); badge != "" {
+			priorityCell = badge
 		}
-		if m.columnVisibility["STATUS"] {
-			rowParts = append(rowParts, separator, padOrTruncate(statusCol, statusWidth))
+		cells := map[string]string{
+			"TYPE":      typeCol,
+			"STATUS":    statusCol,
+			"PRIORITY":  priorityCell,
+			"TAGS":      tagsCol,
+			"CREATED":   createdCol,
+			"MODIFIED":  modifiedCol,
+			"WORKSPACE": workspaceCol,
 		}
-		if m.columnVisibility["PRIORITY"] {
-			priorityCell := ""
-			if badge := renderPriorityBadge(priorityCol); badge != "" {
-				priorityCell = badge
+		for _, col := range columns {
+			if col == "PATH" {
+				rowParts = append(rowParts, separator, theme.DefaultTheme.Muted.Render(padOrTruncate(pathCol, pathWidth)))
+				continue
 			}
-			rowParts = append(rowParts, separator, padOrTruncate(priorityCell, priorityWidth))
-		}
-		if m.columnVisibility["TAGS"] {
-			rowParts = append(rowParts, separator, padOrTruncate(tagsCol, tagsWidth))
-		}
-		if m.columnVisibility["CREATED"] {
-			rowParts = append(rowParts, separator, padOrTruncate(createdCol, createdWidth))
-		}
-		if m.columnVisibility["MODIFIED"] {
-			rowParts = append(rowParts, separator, padOrTruncate(modifiedCol, modifiedWidth))
-		}
-		if m.columnVisibility["WORKSPACE"] {
-			rowParts = append(rowParts, separator, padOrTruncate(workspaceCol, workspaceWidth))
-		}
-		if m.columnVisibility["PATH"] {
-			rowParts = append(rowParts, separator, theme.DefaultTheme.Muted.Render(padOrTruncate(pathCol, pathWidth)))
+			rowParts = append(rowParts, separator, padOrTruncate(cells[col], columnWidths[col]))
 		}
 		row := strings.Join(rowParts, "")
 
@@ -425,6 +484,7 @@ func (m *Model) getNodeRenderInfo(node *DisplayNode) nodeRenderInfo {
 		info.name = node.Item.Name
 		info.isArchived = strings.Contains(node.Item.Path, "/.archive/") || strings.Contains(node.Item.Path, "/.closed/")
 		info.isArtifact = node.Item.Type == tree.TypeArtifact
+		info.checklist = checklistBadge(note)
 		if _, ok := m.selected[node.Item.Path]; ok {
 			info.indicator = "■" // Selected indicator
 		} else {
@@ -455,7 +515,26 @@ func (m *Model) getNodeRenderInfo(node *DisplayNode) nodeRenderInfo {
 
 	// Add link suffix if the node is linked
 	if node.LinkedNode != nil {
-		if node.IsNote() {
+		if node.IsNote() && node.LinkedNode.IsNote() {
+			// Note -> Note: [see also: → Title] from the source, and
+			// [see also: ← Title] from the target.
+			linkType, _ := node.Item.Metadata["LinkType"].(string)
+			arrow := "→"
+			if ref, _ := node.Item.Metadata["LinkRef"].(string); ref == "" || service.ResolveLinkRef(node.Item.Path, ref) != node.LinkedNode.Item.Path {
+				linkType, _ = node.LinkedNode.Item.Metadata["LinkType"].(string)
+				arrow = "←"
+			}
+			if linkType == "" {
+				linkType = service.LinkTypeReference
+			}
+			linkedTitle := ""
+			if t, ok := node.LinkedNode.Item.Metadata["Title"].(string); ok {
+				linkedTitle = t
+			}
+			italicStyle := lipgloss.NewStyle().Italic(true)
+			prefix := italicStyle.Render(linkType + ":")
+			info.suffix = fmt.Sprintf(" [%s %s %s]", prefix, arrow, linkedTitle)
+		} else if node.IsNote() {
 			// Note -> Plan: [plan: → plan-name]
 			linkedGroup := ""
 			if g, ok := node.LinkedNode.Item.Metadata["Group"].(string); ok {
@@ -481,6 +560,11 @@ func (m *Model) getNodeRenderInfo(node *DisplayNode) nodeRenderInfo {
 		_ = node // suppress unused warning
 	}
 
+	// Attachment badge, after any link suffix.
+	if node.IsNote() && m.attachmentCounts[node.Item.Path] > 0 {
+		info.suffix += " 📎"
+	}
+
 	return info
 }
 
@@ -550,7 +634,7 @@ func (m *Model) styleNodeContent(info nodeRenderInfo, isSelected bool) string {
 	} else if info.isPlan {
 		// Individual plans are italic with default text color
 		style = style.Italic(true)
-	} else if info.isGroup && info.name == "plans" {
+	} else if info.isGroup && info.name == "plans" && m.plansEnabled() {
 		// Plans heading is italic with default text color
 		style = style.Italic(true)
 	} else if info.isGroup && info.name == "in_progress" {
@@ -578,6 +662,11 @@ func (m *Model) styleNodeContent(info nodeRenderInfo, isSelected bool) string {
 		case "p1":
 			style = style.Foreground(theme.DefaultTheme.Colors.Orange)
 		}
+		// A flag is an explicit triage color, so it overrides the priority
+		// color; p0 keeps its bold.
+		if color := service.FlagColor(m.service.Flags(), info.note.Flag); color != "" {
+			style = style.Foreground(m.mapColorString(color))
+		}
 	}
 
 	// 4. Apply state modifiers
@@ -602,6 +691,11 @@ func (m *Model) styleNodeContent(info nodeRenderInfo, isSelected bool) string {
 	// Prepare suffix styling
 	suffix := theme.DefaultTheme.Muted.Render(info.suffix)
 
+	checklist := ""
+	if info.checklist != "" {
+		checklist = " " + theme.DefaultTheme.Muted.Render(info.checklist)
+	}
+
 	// Add git status indicator
 	gitIndicator := ""
 	if info.gitStatus != "" {
@@ -619,7 +713,21 @@ func (m *Model) styleNodeContent(info nodeRenderInfo, isSelected bool) string {
 		styledName = style.Render(info.name)
 	}
 
-	return content + styledName + gitIndicator + suffix
+	return content + styledName + checklist + gitIndicator + suffix
+}
+
+// checklistBadge returns the "[done/total]" progress badge shown after a
+// note's title in the tree, or "" when the note has no checkboxes. Like the
+// STATUS cell, cancelled items count toward neither side.
+func checklistBadge(note *models.Note) string {
+	if note == nil {
+		return ""
+	}
+	total := note.TodoOpen + note.TodoDone
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("[%d/%d]", note.TodoDone, total)
 }
 
 // renderPriorityBadge returns a colored "[pN]" badge for a note priority, or
@@ -715,6 +823,8 @@ func (m *Model) mapColorString(colorStr string) lipgloss.TerminalColor {
 		return theme.DefaultTheme.Colors.Red
 	case "orange":
 		return theme.DefaultTheme.Colors.Orange
+	case "yellow":
+		return theme.DefaultTheme.Colors.Yellow
 	case "blue":
 		return theme.DefaultTheme.Colors.Blue
 	case "pink":
@@ -1097,8 +1207,13 @@ func getGroupIcon(groupName string, noteTypes map[string]*coreconfig.NoteTypeCon
 	return theme.IconFolder
 }
 
-// getPlanStatus reads the plan status from the .grove-plan.yml file
+// getPlanStatus reads the plan status from the .grove-plan.yml file. It
+// returns "" without touching the filesystem when plans_as_group is set.
 func (m *Model) GetPlanStatus(workspaceName, planGroup string) string {
+	if !m.plansEnabled() {
+		return ""
+	}
+
 	// Find workspace to get the node
 	var wsNode *workspace.WorkspaceNode
 	for _, ws := range m.workspaces {
@@ -1148,7 +1263,7 @@ func (m *Model) GetPlanStatus(workspaceName, planGroup string) string {
 // getPlanStatusIcon returns the appropriate icon for a plan status
 func getPlanStatusIcon(status string) string {
 	switch status {
-	case "completed":
+	case "completed", "closed":
 		return theme.IconStatusCompleted
 	case "running":
 		return theme.IconStatusRunning
@@ -1168,8 +1283,10 @@ func getPlanStatusIcon(status string) string {
 		return theme.IconStatusTodo
 	case "hold":
 		return theme.IconStatusHold
-	case "abandoned":
+	case "abandoned", "cancelled":
 		return theme.IconStatusAbandoned
+	case "active":
+		return theme.IconStatusRunning
 	default:
 		return theme.IconPending // pending
 	}
@@ -0,0 +1,41 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/grovetools/nb/pkg/tree"
+)
+
+func TestVisualLineSelectsOnlyNotes(t *testing.T) {
+	note := func(name string) *DisplayNode {
+		return &DisplayNode{Item: &tree.Item{Name: name, Path: "/tmp/" + name, Type: tree.TypeNote}}
+	}
+	group := &DisplayNode{Item: &tree.Item{Name: "inbox", Path: "/tmp/inbox", IsDir: true, Type: tree.TypeGroup}}
+	m := &Model{
+		displayNodes: []*DisplayNode{note("a"), group, note("b"), {}, note("c"), note("d")},
+		height:       20,
+	}
+
+	m.SetCursor(0)
+	m.StartVisualMode()
+	m.MoveCursor(4)
+	if !m.InVisualMode() {
+		t.Fatal("expected visual mode")
+	}
+	for _, want := range []string{"/tmp/a", "/tmp/b", "/tmp/c"} {
+		if _, ok := m.selected[want]; !ok {
+			t.Errorf("%s not selected", want)
+		}
+	}
+	if len(m.selected) != 3 {
+		t.Errorf("selected %d paths, want 3 (groups and separators skipped): %v", len(m.selected), m.selected)
+	}
+
+	// Moving back keeps what was passed over; leaving keeps the selection.
+	m.MoveCursor(-4)
+	m.ExitVisualMode()
+	m.MoveCursor(5)
+	if len(m.selected) != 3 {
+		t.Errorf("selection changed outside visual mode: %v", m.selected)
+	}
+}
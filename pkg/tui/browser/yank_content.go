@@ -0,0 +1,45 @@
+package browser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/atotto/clipboard"
+
+	"github.com/grovetools/nb/pkg/models"
+)
+
+// yankNoteContent copies the text of the note under the cursor to the system
+// clipboard, for pasting outside nb, without its frontmatter unless
+// withFrontmatter. Unlike yy it leaves nb's own copy/paste clipboard alone.
+func (m *Model) yankNoteContent(withFrontmatter bool) {
+	node := m.views.GetCurrentNode()
+	if node == nil || !node.IsNote() {
+		m.statusMessage = "Move the cursor onto a note to yank its content"
+		return
+	}
+	data, err := os.ReadFile(node.Item.Path)
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("Error reading note: %v", err)
+		return
+	}
+	text := noteClipboardText(string(data), withFrontmatter)
+	if err := clipboard.WriteAll(text); err != nil {
+		m.statusMessage = fmt.Sprintf("Error copying content: %v", err)
+		return
+	}
+	what := "content"
+	if withFrontmatter {
+		what = "content and frontmatter"
+	}
+	m.statusMessage = fmt.Sprintf("Yanked %s of %s to the system clipboard (%d lines)",
+		what, filepath.Base(node.Item.Path), strings.Count(strings.TrimRight(text, "\n"), "\n")+1)
+}
+
+// noteClipboardText returns the note text to put on the clipboard: the body
+// without leading blank lines, or the whole file when withFrontmatter.
+func noteClipboardText(content string, withFrontmatter bool) string {
+	return (&models.Note{Content: content}).ToMarkdown(withFrontmatter)
+}
@@ -0,0 +1,16 @@
+package browser
+
+import "testing"
+
+func TestNoteClipboardText(t *testing.T) {
+	content := "---\ntitle: Idea\n---\n\n# Idea\n\nBody\n"
+	if got, want := noteClipboardText(content, false), "# Idea\n\nBody\n"; got != want {
+		t.Errorf("noteClipboardText(stripped) = %q, want %q", got, want)
+	}
+	if got := noteClipboardText(content, true); got != content {
+		t.Errorf("noteClipboardText(with frontmatter) = %q, want the whole file", got)
+	}
+	if got, want := noteClipboardText("plain\n", false), "plain\n"; got != want {
+		t.Errorf("noteClipboardText(no frontmatter) = %q, want %q", got, want)
+	}
+}
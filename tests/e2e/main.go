@@ -21,6 +21,7 @@ func main() {
 		NotebookFileBrowserScenario(),
 		NotebookTUIScenario(),
 		NotebookTUIComprehensiveScenario(),
+		NotebookTUIGrepScenario(),
 		NotebookConceptBasicScenario(),
 		NotebookConceptListScenario(),
 		NotebookConceptLinkingScenario(),
@@ -31,6 +32,7 @@ func main() {
 		NotebookPasteIntoPlanScenario(),
 		NotebookPromoteToJobScenario(),
 		NotebookFullLifecycleScenario(),
+		NotebookLintFixIDsScenario(),
 	}
 
 	// Setup signal handling for graceful shutdown.
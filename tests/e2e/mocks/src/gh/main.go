@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -89,6 +90,35 @@ func handleList(args []string, stateDir string) {
 		fmt.Fprintf(os.Stderr, "mock gh: failed to read %s: %v\n", jsonPath, err)
 		os.Exit(1)
 	}
+
+	// Support the one search qualifier nb uses: --search "updated:>TIMESTAMP".
+	for i := 2; i+1 < len(args); i++ {
+		if args[i] != "--search" {
+			continue
+		}
+		stamp, ok := strings.CutPrefix(args[i+1], "updated:>")
+		if !ok {
+			fmt.Fprintf(os.Stderr, "mock gh: unhandled search %q\n", args[i+1])
+			os.Exit(1)
+		}
+		since, err := time.Parse(time.RFC3339, stamp)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "mock gh: bad updated timestamp %q: %v\n", stamp, err)
+			os.Exit(1)
+		}
+		var items []ghItem
+		if err := json.Unmarshal(data, &items); err != nil {
+			fmt.Fprintf(os.Stderr, "mock gh: failed to parse %s: %v\n", jsonPath, err)
+			os.Exit(1)
+		}
+		filtered := []ghItem{}
+		for _, item := range items {
+			if item.UpdatedAt.After(since) {
+				filtered = append(filtered, item)
+			}
+		}
+		data, _ = json.MarshalIndent(filtered, "", "\t")
+	}
 	fmt.Println(string(data))
 }
 
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/grovetools/tend/pkg/fs"
+	"github.com/grovetools/tend/pkg/harness"
+	"github.com/grovetools/tend/pkg/verify"
+
+	"github.com/grovetools/nb/pkg/frontmatter"
+)
+
+// NotebookLintFixIDsScenario verifies that `nb lint` reports notes sharing a
+// frontmatter id and that --fix-ids gives the newer note a unique id and
+// repoints its plan job's note_ref.
+func NotebookLintFixIDsScenario() *harness.Scenario {
+	return harness.NewScenario(
+		"notebook-lint-fix-ids",
+		"Verifies duplicate frontmatter ids are reported and repaired by nb lint --fix-ids.",
+		[]string{"notebook", "lint"},
+		[]harness.Step{
+			harness.NewStep("Setup local notebook with two notes sharing an id", func(ctx *harness.Context) error {
+				projectDir := ctx.NewDir("lint-project")
+				localYAML := `
+name: lint-project
+version: '1.0'
+notebooks:
+  rules:
+    default: "local"
+  definitions:
+    local:
+      root_dir: ""
+`
+				if err := fs.WriteString(filepath.Join(projectDir, "grove.yml"), localYAML); err != nil {
+					return err
+				}
+
+				inbox := filepath.Join(projectDir, ".notebook", "notes", "inbox")
+				inProgress := filepath.Join(projectDir, ".notebook", "notes", "in_progress")
+				planDir := filepath.Join(projectDir, ".notebook", "plans", "feature")
+				for _, dir := range []string{inbox, inProgress, planDir} {
+					if err := fs.CreateDir(dir); err != nil {
+						return err
+					}
+				}
+
+				older := filepath.Join(inbox, "20240101-api-design.md")
+				newer := filepath.Join(inProgress, "20240102-api-design.md")
+				job := filepath.Join(planDir, "01-api-design.md")
+				files := map[string]string{
+					older: "---\nid: api-design\ntitle: API design\ncreated: 2024-01-01 09:00:00\n---\n\nFirst.\n",
+					newer: "---\nid: api-design\ntitle: API Design\ncreated: 2024-01-02 09:00:00\nplan_ref: plans/feature\nplan_job: 01-api-design.md\n---\n\nSecond.\n",
+					job:   "---\nid: job-api-design\ntitle: API Design\nnote_ref: api-design\n---\n\nJob body.\n",
+				}
+				for path, content := range files {
+					if err := fs.WriteString(path, content); err != nil {
+						return err
+					}
+				}
+
+				ctx.Set("project_dir", projectDir)
+				ctx.Set("older_path", older)
+				ctx.Set("newer_path", newer)
+				ctx.Set("job_path", job)
+				return nil
+			}),
+
+			harness.NewStep("nb lint reports the conflict and fails", func(ctx *harness.Context) error {
+				cmd := ctx.Bin("lint").Dir(ctx.GetString("project_dir"))
+				result := cmd.Run()
+				ctx.ShowCommandOutput(cmd.String(), result.Stdout, result.Stderr)
+				if result.Error == nil {
+					return fmt.Errorf("expected nb lint to exit non-zero on duplicate ids")
+				}
+				return ctx.Verify(func(v *verify.Collector) {
+					v.Contains("conflict reported", result.Stdout, "id api-design is used by 2 notes")
+					v.Contains("older note listed", result.Stdout, ctx.GetString("older_path"))
+					v.Contains("newer note listed", result.Stdout, ctx.GetString("newer_path"))
+				})
+			}),
+
+			harness.NewStep("nb lint --fix-ids gives the newer note a unique id", func(ctx *harness.Context) error {
+				cmd := ctx.Bin("lint", "--fix-ids").Dir(ctx.GetString("project_dir"))
+				result := cmd.Run()
+				ctx.ShowCommandOutput(cmd.String(), result.Stdout, result.Stderr)
+				if result.Error != nil {
+					return result.Error
+				}
+
+				readFM := func(key string) (*frontmatter.Frontmatter, string, error) {
+					content, err := fs.ReadString(ctx.GetString(key))
+					if err != nil {
+						return nil, "", err
+					}
+					fm, _, err := frontmatter.Parse(content)
+					return fm, content, err
+				}
+				olderFM, _, err := readFM("older_path")
+				if err != nil {
+					return err
+				}
+				newerFM, newerContent, err := readFM("newer_path")
+				if err != nil {
+					return err
+				}
+				_, jobContent, err := readFM("job_path")
+				if err != nil {
+					return err
+				}
+
+				return ctx.Verify(func(v *verify.Collector) {
+					v.Equal("older note keeps its id", "api-design", olderFM.ID)
+					v.NotEqual("newer note gets a new id", "api-design", newerFM.ID)
+					v.Contains("new id keeps the old one as prefix", newerFM.ID, "api-design-")
+					v.Contains("only the id line changed", newerContent, "created: 2024-01-02 09:00:00\nplan_ref: plans/feature\n")
+					v.Contains("job note_ref follows the renamed note", jobContent, "note_ref: "+newerFM.ID+"\n")
+				})
+			}),
+
+			harness.NewStep("nb lint passes after the fix", func(ctx *harness.Context) error {
+				cmd := ctx.Bin("lint").Dir(ctx.GetString("project_dir"))
+				result := cmd.Run()
+				ctx.ShowCommandOutput(cmd.String(), result.Stdout, result.Stderr)
+				if result.Error != nil {
+					return result.Error
+				}
+				return ctx.Verify(func(v *verify.Collector) {
+					v.Contains("no conflicts left", result.Stdout, "No duplicate ids found.")
+				})
+			}),
+		},
+	)
+}
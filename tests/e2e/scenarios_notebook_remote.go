@@ -406,6 +406,69 @@ notebooks:
 					v.Contains("local note was updated with remote URL", localNoteContent, "url: https://github.com/test/repo/issues/103")
 				})
 			}),
+
+			// Step 8: Push a single note of a non-synced type with --push
+			harness.NewStep("Push a single note to a new issue with --push", func(ctx *harness.Context) error {
+				projectDir := ctx.GetString("project_dir")
+				stateDir := ctx.GetString("state_dir")
+
+				cmd := ctx.Bin("new", "--no-edit", "Push this note").Dir(projectDir)
+				result := cmd.Run()
+				ctx.ShowCommandOutput(cmd.String(), result.Stdout, result.Stderr)
+				if result.Error != nil {
+					return fmt.Errorf("failed to create inbox note: %w", result.Error)
+				}
+				inboxDir := filepath.Join(ctx.HomeDir(), ".grove", "notebooks", "nb", "workspaces", "sync-project", "inbox")
+				files, err := fs.ListFiles(inboxDir)
+				if err != nil {
+					return fmt.Errorf("could not list files in inbox directory: %w", err)
+				}
+				var notePath string
+				for _, file := range files {
+					if content, err := fs.ReadString(filepath.Join(inboxDir, file)); err == nil && strings.Contains(content, "Push this note") {
+						notePath = filepath.Join(inboxDir, file)
+						break
+					}
+				}
+				if notePath == "" {
+					return fmt.Errorf("could not find inbox note with title 'Push this note'")
+				}
+
+				cmd = ctx.Bin("tag", "add", "needs-triage", notePath).Dir(projectDir)
+				result = cmd.Run()
+				ctx.ShowCommandOutput(cmd.String(), result.Stdout, result.Stderr)
+				if result.Error != nil {
+					return fmt.Errorf("failed to tag note: %w", result.Error)
+				}
+
+				cmd = ctx.Bin("remote", "sync", "--push", notePath).Dir(projectDir).Env("GH_MOCK_STATE_DIR=" + stateDir)
+				result = cmd.Run()
+				ctx.ShowCommandOutput(cmd.String(), result.Stdout, result.Stderr)
+				if result.Error != nil {
+					return result.Error
+				}
+
+				issuesJSON, err := fs.ReadString(filepath.Join(stateDir, "issues.json"))
+				if err != nil {
+					return err
+				}
+				if err := ctx.Verify(func(v *verify.Collector) {
+					v.Contains("pushed note was created as an issue", issuesJSON, `"title": "Push this note"`)
+					v.Contains("pushed issue has the next number", issuesJSON, `"number": 104`)
+					v.Contains("note tags were sent as labels", issuesJSON, `"name": "needs-triage"`)
+				}); err != nil {
+					return err
+				}
+
+				noteContent, err := fs.ReadString(notePath)
+				if err != nil {
+					return err
+				}
+				return ctx.Verify(func(v *verify.Collector) {
+					v.Contains("pushed note records the remote ID", noteContent, "id: 104")
+					v.Contains("pushed note records the issue URL", noteContent, "url: https://github.com/test/repo/issues/104")
+				})
+			}),
 		},
 	)
 }
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/grovetools/tend/pkg/fs"
+	"github.com/grovetools/tend/pkg/git"
+	"github.com/grovetools/tend/pkg/harness"
+	"github.com/grovetools/tend/pkg/tui"
+	"github.com/grovetools/tend/pkg/verify"
+)
+
+// NotebookTUIGrepScenario tests content search ("?" prefix in the search input)
+// in `nb tui`. It requires a real `rg` binary on PATH.
+func NotebookTUIGrepScenario() *harness.Scenario {
+	return harness.NewScenario(
+		"notebook-tui-grep",
+		"Verifies that grep mode in `nb tui` filters the tree to notes with matching content.",
+		[]string{"notebook", "tui", "grep", "e2e"},
+		[]harness.Step{
+			harness.NewStep("Setup notes with known content", setupTUIGrepEnvironment),
+			harness.NewStep("Launch TUI", launchTUIGrep),
+			harness.NewStep("Grep filters the tree to matching notes", testTUIGrepFilters),
+			harness.NewStep("Esc restores the full tree", testTUIGrepClear),
+		},
+	)
+}
+
+// setupTUIGrepEnvironment creates a single project whose notes only share the
+// grep needle in two files, spread across different groups.
+func setupTUIGrepEnvironment(ctx *harness.Context) error {
+	if _, err := exec.LookPath("rg"); err != nil {
+		return fmt.Errorf("notebook-tui-grep requires ripgrep (rg) on PATH: %w", err)
+	}
+
+	notebookRoot := filepath.Join(ctx.HomeDir(), ".grove", "notebooks", "nb")
+	globalYAML := fmt.Sprintf(`
+version: "1.0"
+groves:
+  e2e-projects:
+    path: "%s"
+notebooks:
+  rules:
+    default: "main"
+  definitions:
+    main:
+      root_dir: "%s"
+`, ctx.RootDir, notebookRoot)
+	globalConfigDir := filepath.Join(ctx.HomeDir(), ".config", "grove")
+	if err := fs.CreateDir(globalConfigDir); err != nil {
+		return err
+	}
+	if err := fs.WriteString(filepath.Join(globalConfigDir, "grove.yml"), globalYAML); err != nil {
+		return err
+	}
+
+	projectDir := ctx.NewDir("grep-project")
+	if err := fs.WriteString(filepath.Join(projectDir, "grove.yml"), "name: grep-project\nversion: '1.0'"); err != nil {
+		return err
+	}
+	repo, err := git.SetupTestRepo(projectDir)
+	if err != nil {
+		return err
+	}
+	if err := repo.AddCommit("initial commit"); err != nil {
+		return err
+	}
+	projectRoot := filepath.Join(notebookRoot, "workspaces", "grep-project")
+
+	notes := map[string]string{
+		filepath.Join("inbox", "cache-notes.md"):       "---\ntitle: Cache Notes\n---\n# Cache Notes\nThe zebrafish cache expires hourly.",
+		filepath.Join("inbox", "meeting.md"):           "---\ntitle: Meeting\n---\n# Meeting\nDiscussed the roadmap.",
+		filepath.Join("research", "deep-dive.md"):      "---\ntitle: Deep Dive\n---\n# Deep Dive\nA zebrafish appears here too.",
+		filepath.Join("research", "unrelated-idea.md"): "---\ntitle: Unrelated Idea\n---\n# Unrelated Idea\nNothing to see.",
+		filepath.Join("issues", "bug-report.md"):       "---\ntitle: Bug Report\n---\n# Bug Report\nSteps to reproduce.",
+	}
+	for rel, content := range notes {
+		if err := fs.WriteString(filepath.Join(projectRoot, rel), content); err != nil {
+			return err
+		}
+	}
+
+	ctx.Set("project_dir", projectDir)
+	return nil
+}
+
+func launchTUIGrep(ctx *harness.Context) error {
+	nbBin, err := findProjectBinary()
+	if err != nil {
+		return err
+	}
+
+	session, err := ctx.StartTUI(nbBin, []string{"tui"},
+		tui.WithCwd(ctx.GetString("project_dir")),
+		tui.WithEnv("HOME="+ctx.HomeDir()),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to start TUI session: %w", err)
+	}
+	ctx.Set("tui_session", session)
+
+	if err := session.WaitForText("inbox", 10*time.Second); err != nil {
+		view, _ := session.Capture()
+		ctx.ShowCommandOutput("TUI Failed to Start - Current View", view, "")
+		return fmt.Errorf("timeout waiting for TUI to start (looking for 'inbox'): %w", err)
+	}
+	if err := session.WaitStable(); err != nil {
+		return err
+	}
+
+	initialView, _ := session.Capture()
+	ctx.ShowCommandOutput("TUI Initial View", initialView, "")
+
+	return ctx.Verify(func(v *verify.Collector) {
+		v.Equal("research group is visible", nil, session.AssertContains("research"))
+		v.Equal("issues group is visible", nil, session.AssertContains("issues"))
+	})
+}
+
+// testTUIGrepFilters opens search with '/', enters a "?"-prefixed query and
+// checks that only the matching notes remain, along with their workspace and
+// group ancestors.
+func testTUIGrepFilters(ctx *harness.Context) error {
+	session := ctx.Get("tui_session").(*tui.Session)
+
+	_ = session.SendKeys("/")
+	time.Sleep(500 * time.Millisecond)
+	if err := session.WaitStable(); err != nil {
+		return err
+	}
+
+	_ = session.SendKeys("?zebrafish")
+	if err := session.WaitForText("Found 2 matching notes", 10*time.Second); err != nil {
+		view, _ := session.Capture()
+		ctx.ShowCommandOutput("TUI grep - Current View", view, "")
+		return fmt.Errorf("timeout waiting for grep results: %w", err)
+	}
+	if err := session.WaitStable(); err != nil {
+		return err
+	}
+
+	grepView, _ := session.Capture()
+	ctx.ShowCommandOutput("TUI grep results", grepView, "")
+
+	return ctx.Verify(func(v *verify.Collector) {
+		v.Equal("matching inbox note is visible", nil, session.AssertContains("cache-notes.md"))
+		v.Equal("matching research note is visible", nil, session.AssertContains("deep-dive.md"))
+		v.Equal("workspace node is preserved", nil, session.AssertContains("grep-project"))
+		v.Equal("inbox group is preserved", nil, session.AssertContains("inbox"))
+		v.Equal("research group is preserved", nil, session.AssertContains("research"))
+		v.NotContains("non-matching inbox note is hidden", grepView, "meeting.md")
+		v.NotContains("non-matching research note is hidden", grepView, "unrelated-idea.md")
+		v.NotContains("group without matches is hidden", grepView, "bug-report.md")
+	})
+}
+
+// testTUIGrepClear blurs the search input with Esc and clears it with a second
+// Esc, which should bring back the groups the grep hid.
+func testTUIGrepClear(ctx *harness.Context) error {
+	session := ctx.Get("tui_session").(*tui.Session)
+
+	for i := 0; i < 2; i++ {
+		_ = session.SendKeys("\x1b") // Esc
+		time.Sleep(500 * time.Millisecond)
+		if err := session.WaitStable(); err != nil {
+			return err
+		}
+	}
+
+	restoredView, _ := session.Capture()
+	ctx.ShowCommandOutput("TUI after clearing grep", restoredView, "")
+
+	if err := ctx.Verify(func(v *verify.Collector) {
+		v.Equal("issues group is visible again", nil, session.AssertContains("issues"))
+		v.NotContains("grep result count is cleared", restoredView, "Found 2 matching notes")
+	}); err != nil {
+		return err
+	}
+
+	_ = session.SendKeys("q")
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}